@@ -279,6 +279,12 @@ const defaultServerConfig: AdminConfig = {
         FileMaxQueueSize: 1000,
         AdvancedLoggingJSON: {},
         Certificate: '',
+        HashChainEnabled: false,
+        HashChainFileName: '',
+        HashChainSigningKeyFile: '',
+        HashChainCheckpointInterval: 1000,
+        DatabaseEnabled: false,
+        DatabaseRetentionDays: 0,
     },
     NotificationLogSettings: {
         EnableConsole: true,
@@ -599,6 +605,12 @@ const defaultServerConfig: AdminConfig = {
         EnableNotificationMetrics: true,
         ClientSideUserIds: [],
     },
+    TracingSettings: {
+        Enable: false,
+        OTLPEndpoint: 'localhost:4318',
+        Insecure: true,
+        SampleRate: 1,
+    },
     ExperimentalSettings: {
         ClientSideCertEnable: false,
         ClientSideCertCheck: 'secondary',
@@ -720,7 +732,9 @@ const defaultServerConfig: AdminConfig = {
         AutomaticPrepackagedPlugins: true,
         RequirePluginSignature: false,
         MarketplaceURL: 'https://api.integrations.mattermost.com',
+        MarketplaceCatalogPath: '',
         SignaturePublicKeyFiles: [],
+        RevokedSignatureKeyIds: [],
         ChimeraOAuthProxyURL: '',
     },
     DisplaySettings: {
@@ -29,8 +29,10 @@ func (api *API) InitPlugin() {
 	api.BaseRoutes.Plugin.Handle("", api.APISessionRequired(removePlugin)).Methods(http.MethodDelete)
 	api.BaseRoutes.Plugins.Handle("/install_from_url", api.APISessionRequired(installPluginFromURL)).Methods(http.MethodPost)
 	api.BaseRoutes.Plugins.Handle("/marketplace", api.APISessionRequired(installMarketplacePlugin)).Methods(http.MethodPost)
+	api.BaseRoutes.Plugins.Handle("/marketplace/catalog", api.APISessionRequired(publishMarketplaceCatalogPlugin, handlerParamFileAPI)).Methods(http.MethodPost)
 
 	api.BaseRoutes.Plugins.Handle("/statuses", api.APISessionRequired(getPluginStatuses)).Methods(http.MethodGet)
+	api.BaseRoutes.Plugins.Handle("/signatures", api.APISessionRequired(getPluginSignatureReports)).Methods(http.MethodGet)
 	api.BaseRoutes.Plugin.Handle("/enable", api.APISessionRequired(enablePlugin)).Methods(http.MethodPost)
 	api.BaseRoutes.Plugin.Handle("/disable", api.APISessionRequired(disablePlugin)).Methods(http.MethodPost)
 
@@ -172,6 +174,75 @@ func installMarketplacePlugin(c *Context, w http.ResponseWriter, r *http.Request
 	}
 }
 
+func publishMarketplaceCatalogPlugin(c *Context, w http.ResponseWriter, r *http.Request) {
+	config := c.App.Config()
+	if !*config.PluginSettings.Enable || !*config.PluginSettings.EnableMarketplace {
+		c.Err = model.NewAppError("publishMarketplaceCatalogPlugin", "app.plugin.marketplace_disabled.app_error", nil, "", http.StatusNotImplemented)
+		return
+	}
+
+	auditRec := c.MakeAuditRecord(model.AuditEventPublishMarketplaceCatalogPlugin, model.AuditStatusFail)
+	defer c.LogAuditRec(auditRec)
+
+	if !c.App.SessionHasPermissionTo(*c.AppContext.Session(), model.PermissionSysconsoleWritePlugins) {
+		c.SetPermissionError(model.PermissionSysconsoleWritePlugins)
+		return
+	}
+
+	if err := r.ParseMultipartForm(MaxPluginMemory); err != nil {
+		if err.Error() == "http: request body too large" {
+			c.Err = model.NewAppError("publishMarketplaceCatalogPlugin", "api.plugin.upload.file_too_large.app_error", nil, "", http.StatusRequestEntityTooLarge)
+			return
+		}
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	m := r.MultipartForm
+
+	pluginArray, ok := m.File["plugin"]
+	if !ok || len(pluginArray) == 0 {
+		c.Err = model.NewAppError("publishMarketplaceCatalogPlugin", "api.plugin.upload.no_file.app_error", nil, "", http.StatusBadRequest)
+		return
+	}
+	model.AddEventParameterToAuditRec(auditRec, "filename", pluginArray[0].Filename)
+
+	signatureArray, ok := m.File["signature"]
+	if !ok || len(signatureArray) == 0 {
+		c.Err = model.NewAppError("publishMarketplaceCatalogPlugin", "api.plugin.publish_catalog.no_signature.app_error", nil, "", http.StatusBadRequest)
+		return
+	}
+
+	file, err := pluginArray[0].Open()
+	if err != nil {
+		c.Err = model.NewAppError("publishMarketplaceCatalogPlugin", "api.plugin.upload.file.app_error", nil, "", http.StatusBadRequest)
+		return
+	}
+	defer file.Close()
+
+	signature, err := signatureArray[0].Open()
+	if err != nil {
+		c.Err = model.NewAppError("publishMarketplaceCatalogPlugin", "api.plugin.upload.file.app_error", nil, "", http.StatusBadRequest)
+		return
+	}
+	defer signature.Close()
+
+	manifest, appErr := c.App.PublishMarketplaceCatalogPlugin(file, signature)
+	if appErr != nil {
+		c.Err = appErr
+		return
+	}
+
+	auditRec.Success()
+	auditRec.AddMeta("plugin_id", manifest.Id)
+	auditRec.AddMeta("plugin_version", manifest.Version)
+
+	w.WriteHeader(http.StatusCreated)
+	if err := json.NewEncoder(w).Encode(manifest); err != nil {
+		c.Logger.Warn("Error while writing response", mlog.Err(err))
+	}
+}
+
 func getPlugins(c *Context, w http.ResponseWriter, r *http.Request) {
 	if !*c.App.Config().PluginSettings.Enable {
 		c.Err = model.NewAppError("getPlugins", "app.plugin.disabled.app_error", nil, "", http.StatusNotImplemented)
@@ -216,6 +287,28 @@ func getPluginStatuses(c *Context, w http.ResponseWriter, r *http.Request) {
 	}
 }
 
+func getPluginSignatureReports(c *Context, w http.ResponseWriter, r *http.Request) {
+	if !*c.App.Config().PluginSettings.Enable {
+		c.Err = model.NewAppError("getPluginSignatureReports", "app.plugin.disabled.app_error", nil, "", http.StatusNotImplemented)
+		return
+	}
+
+	if !c.App.SessionHasPermissionTo(*c.AppContext.Session(), model.PermissionSysconsoleReadPlugins) {
+		c.SetPermissionError(model.PermissionSysconsoleReadPlugins)
+		return
+	}
+
+	reports, appErr := c.App.GetPluginSignatureReports()
+	if appErr != nil {
+		c.Err = appErr
+		return
+	}
+
+	if err := json.NewEncoder(w).Encode(reports); err != nil {
+		c.Logger.Warn("Error while writing response", mlog.Err(err))
+	}
+}
+
 func removePlugin(c *Context, w http.ResponseWriter, r *http.Request) {
 	c.RequirePluginId()
 	if c.Err != nil {
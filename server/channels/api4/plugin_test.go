@@ -1405,6 +1405,47 @@ func TestGetPrepackagedPlaybooksPluginIn(t *testing.T) {
 	})
 }
 
+func TestGetPluginSignatureReports(t *testing.T) {
+	mainHelper.Parallel(t)
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+
+	th.App.UpdateConfig(func(cfg *model.Config) {
+		*cfg.PluginSettings.Enable = true
+		*cfg.PluginSettings.EnableUploads = true
+	})
+
+	path, _ := fileutils.FindDir("tests")
+	tarData, err := os.ReadFile(filepath.Join(path, "testplugin.tar.gz"))
+	require.NoError(t, err)
+
+	manifest, _, err := th.SystemAdminClient.UploadPlugin(context.Background(), bytes.NewReader(tarData))
+	require.NoError(t, err)
+
+	th.TestForSystemAdminAndLocal(t, func(t *testing.T, client *model.Client4) {
+		reports, _, err := client.GetPluginSignatureReports(context.Background())
+		require.NoError(t, err)
+
+		var found bool
+		for _, report := range reports {
+			if report.PluginId == manifest.Id {
+				found = true
+				assert.Equal(t, model.PluginSignatureStatusUnsigned, report.Status)
+			}
+		}
+		assert.True(t, found)
+	})
+
+	_, resp, err := th.Client.GetPluginSignatureReports(context.Background())
+	require.Error(t, err)
+	CheckForbiddenStatus(t, resp)
+
+	th.App.UpdateConfig(func(cfg *model.Config) { *cfg.PluginSettings.Enable = false })
+	_, resp, err = th.SystemAdminClient.GetPluginSignatureReports(context.Background())
+	require.Error(t, err)
+	CheckNotImplementedStatus(t, resp)
+}
+
 func TestInstallMarketplacePlugin(t *testing.T) {
 	path, _ := fileutils.FindDir("tests")
 
@@ -1752,6 +1793,100 @@ func TestInstallMarketplacePlugin(t *testing.T) {
 	}, "verify EnterprisePlugins is true for E20")
 }
 
+func TestPublishMarketplaceCatalogPlugin(t *testing.T) {
+	path, _ := fileutils.FindDir("tests")
+
+	th := SetupConfig(t, func(cfg *model.Config) {
+		*cfg.PluginSettings.Enable = true
+		*cfg.PluginSettings.EnableMarketplace = true
+		*cfg.PluginSettings.EnableRemoteMarketplace = false
+		cfg.PluginSettings.SignaturePublicKeyFiles = []string{
+			filepath.Join(path, "development-private-key.asc"),
+		}
+	}).InitBasic()
+	defer th.TearDown()
+
+	tarData, err := os.ReadFile(filepath.Join(path, "testplugin2.tar.gz"))
+	require.NoError(t, err)
+	sigData, err := os.ReadFile(filepath.Join(path, "testplugin2.tar.gz.sig"))
+	require.NoError(t, err)
+	otherTarData, err := os.ReadFile(filepath.Join(path, "testplugin.tar.gz"))
+	require.NoError(t, err)
+
+	th.TestForSystemAdminAndLocal(t, func(t *testing.T, client *model.Client4) {
+		th.App.UpdateConfig(func(cfg *model.Config) {
+			*cfg.PluginSettings.MarketplaceCatalogPath = ""
+		})
+
+		manifest, resp, err := client.PublishMarketplaceCatalogPlugin(context.Background(), bytes.NewReader(tarData), bytes.NewReader(sigData))
+		require.Error(t, err)
+		CheckNotImplementedStatus(t, resp)
+		require.Nil(t, manifest)
+	}, "catalog not configured")
+
+	t.Run("no permission", func(t *testing.T) {
+		th.App.UpdateConfig(func(cfg *model.Config) {
+			*cfg.PluginSettings.MarketplaceCatalogPath = t.TempDir()
+		})
+
+		manifest, resp, err := th.Client.PublishMarketplaceCatalogPlugin(context.Background(), bytes.NewReader(tarData), bytes.NewReader(sigData))
+		require.Error(t, err)
+		CheckForbiddenStatus(t, resp)
+		require.Nil(t, manifest)
+	})
+
+	th.TestForSystemAdminAndLocal(t, func(t *testing.T, client *model.Client4) {
+		th.App.UpdateConfig(func(cfg *model.Config) {
+			*cfg.PluginSettings.MarketplaceCatalogPath = t.TempDir()
+		})
+
+		manifest, resp, err := client.PublishMarketplaceCatalogPlugin(context.Background(), bytes.NewReader(otherTarData), bytes.NewReader(sigData))
+		require.Error(t, err)
+		CheckInternalErrorStatus(t, resp)
+		require.Nil(t, manifest)
+
+		plugins, _, err := client.GetMarketplacePlugins(context.Background(), &model.MarketplacePluginFilter{RemoteOnly: true})
+		require.NoError(t, err)
+		require.Empty(t, plugins)
+	}, "invalid signature")
+
+	for name, catalogPath := range map[string]string{
+		"local directory": "",
+		"file store path": "marketplace_catalog",
+	} {
+		th.TestForSystemAdminAndLocal(t, func(t *testing.T, client *model.Client4) {
+			if catalogPath == "" {
+				catalogPath = t.TempDir()
+			}
+			th.App.UpdateConfig(func(cfg *model.Config) {
+				*cfg.PluginSettings.MarketplaceCatalogPath = catalogPath
+			})
+
+			manifest, resp, err := client.PublishMarketplaceCatalogPlugin(context.Background(), bytes.NewReader(tarData), bytes.NewReader(sigData))
+			require.NoError(t, err)
+			CheckCreatedStatus(t, resp)
+			require.Equal(t, "testplugin2", manifest.Id)
+
+			plugins, _, err := client.GetMarketplacePlugins(context.Background(), &model.MarketplacePluginFilter{RemoteOnly: true})
+			require.NoError(t, err)
+			require.Len(t, plugins, 1)
+			require.Equal(t, "testplugin2", plugins[0].Manifest.Id)
+			require.Equal(t, manifest.Version, plugins[0].Manifest.Version)
+
+			manifest, _, err = client.InstallMarketplacePlugin(context.Background(), &model.InstallMarketplacePluginRequest{Id: "testplugin2"})
+			require.NoError(t, err)
+			require.Equal(t, "testplugin2", manifest.Id)
+
+			savedSigFile, appErr := th.App.ReadFile(filepath.Join("plugins", "testplugin2.tar.gz.sig"))
+			require.Nil(t, appErr)
+			require.EqualValues(t, sigData, savedSigFile)
+
+			_, err = client.RemovePlugin(context.Background(), manifest.Id)
+			require.NoError(t, err)
+		}, "publish and install from "+name)
+	}
+}
+
 func TestInstallMarketplacePluginPrepackagedDisabled(t *testing.T) {
 	mainHelper.Parallel(t)
 	path, _ := fileutils.FindDir("tests")
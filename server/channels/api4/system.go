@@ -45,6 +45,8 @@ func (api *API) InitSystem() {
 	api.BaseRoutes.System.Handle("/timezones", api.APISessionRequired(getSupportedTimezones)).Methods(http.MethodGet)
 
 	api.BaseRoutes.APIRoot.Handle("/audits", api.APISessionRequired(getAudits)).Methods(http.MethodGet)
+	api.BaseRoutes.APIRoot.Handle("/audits/records/search", api.APISessionRequired(searchAuditRecords)).Methods(http.MethodPost)
+	api.BaseRoutes.APIRoot.Handle("/audits/records/export", api.APISessionRequired(exportAuditRecords)).Methods(http.MethodPost)
 	api.BaseRoutes.APIRoot.Handle("/notifications/test", api.APISessionRequired(testNotifications)).Methods(http.MethodPost)
 	api.BaseRoutes.APIRoot.Handle("/email/test", api.APISessionRequired(testEmail)).Methods(http.MethodPost)
 	api.BaseRoutes.APIRoot.Handle("/site_url/test", api.APISessionRequired(testSiteURL)).Methods(http.MethodPost)
@@ -319,6 +321,85 @@ func getAudits(c *Context, w http.ResponseWriter, r *http.Request) {
 	}
 }
 
+func searchAuditRecords(c *Context, w http.ResponseWriter, r *http.Request) {
+	auditRec := c.MakeAuditRecord(model.AuditEventSearchAuditRecords, model.AuditStatusFail)
+	defer c.LogAuditRec(auditRec)
+
+	if !c.App.SessionHasPermissionTo(*c.AppContext.Session(), model.PermissionReadAudits) {
+		c.SetPermissionError(model.PermissionReadAudits)
+		return
+	}
+
+	var opts model.AuditRecordSearchOptions
+	if err := json.NewDecoder(r.Body).Decode(&opts); err != nil {
+		c.SetInvalidParamWithErr("options", err)
+		return
+	}
+	model.AddEventParameterAuditableToAuditRec(auditRec, "options", opts)
+
+	records, appErr := c.App.SearchAuditRecords(c.AppContext, opts)
+	if appErr != nil {
+		c.Err = appErr
+		return
+	}
+
+	auditRec.Success()
+
+	if err := json.NewEncoder(w).Encode(records); err != nil {
+		c.Logger.Warn("Error while writing response", mlog.Err(err))
+	}
+}
+
+func exportAuditRecords(c *Context, w http.ResponseWriter, r *http.Request) {
+	auditRec := c.MakeAuditRecord(model.AuditEventExportAuditRecords, model.AuditStatusFail)
+	defer c.LogAuditRec(auditRec)
+
+	if !c.App.SessionHasPermissionTo(*c.AppContext.Session(), model.PermissionReadAudits) {
+		c.SetPermissionError(model.PermissionReadAudits)
+		return
+	}
+
+	format := r.URL.Query().Get("format")
+	if format == "" {
+		format = model.AuditRecordExportFormatJSON
+	}
+	var contentType string
+	switch format {
+	case model.AuditRecordExportFormatCSV:
+		contentType = "text/csv"
+	case model.AuditRecordExportFormatJSON:
+		contentType = "application/json"
+	default:
+		c.SetInvalidParam("format")
+		return
+	}
+	model.AddEventParameterToAuditRec(auditRec, "format", format)
+
+	var opts model.AuditRecordSearchOptions
+	if err := json.NewDecoder(r.Body).Decode(&opts); err != nil {
+		c.SetInvalidParamWithErr("options", err)
+		return
+	}
+	model.AddEventParameterAuditableToAuditRec(auditRec, "options", opts)
+
+	// The options are checked before anything is written, since errors
+	// can't be returned once the export started.
+	opts.SetDefaults()
+	if appErr := opts.IsValid(); appErr != nil {
+		c.Err = appErr
+		return
+	}
+
+	w.Header().Set("Content-Type", contentType)
+	w.Header().Set("Content-Disposition", "attachment; filename=\"audit_records."+format+"\"")
+	if appErr := c.App.ExportAuditRecords(c.AppContext, w, opts, format); appErr != nil {
+		c.Logger.Warn("Error while exporting audit records", mlog.Err(appErr))
+		return
+	}
+
+	auditRec.Success()
+}
+
 func databaseRecycle(c *Context, w http.ResponseWriter, r *http.Request) {
 	if !c.App.SessionHasPermissionToAndNotRestrictedAdmin(*c.AppContext.Session(), model.PermissionRecycleDatabaseConnections) {
 		c.SetPermissionError(model.PermissionRecycleDatabaseConnections)
@@ -7,6 +7,7 @@ import (
 	"bytes"
 	"context"
 	"encoding/base64"
+	"encoding/csv"
 	"encoding/json"
 	"fmt"
 	"io"
@@ -143,6 +144,109 @@ func TestGetAudits(t *testing.T) {
 	CheckUnauthorizedStatus(t, resp)
 }
 
+func TestSearchAuditRecords(t *testing.T) {
+	mainHelper.Parallel(t)
+	th := Setup(t)
+	defer th.TearDown()
+	client := th.Client
+
+	userID := model.NewId()
+	records := []*model.StoredAuditRecord{
+		model.NewStoredAuditRecord("audit-api", model.AuditRecord{
+			EventName: model.AuditEventLogin,
+			Status:    model.AuditStatusSuccess,
+			Actor:     model.AuditEventActor{UserId: userID, IpAddress: "10.0.0.1"},
+		}),
+		model.NewStoredAuditRecord("audit-api", model.AuditRecord{
+			EventName: model.AuditEventUpdateUser,
+			Status:    model.AuditStatusFail,
+			Actor:     model.AuditEventActor{UserId: userID, IpAddress: "10.0.0.2"},
+		}),
+	}
+	records[0].CreateAt = 1000
+	records[1].CreateAt = 2000
+	_, err := th.App.Srv().Store().AuditRecord().SaveMultiple(records)
+	require.NoError(t, err)
+
+	th.TestForSystemAdminAndLocal(t, func(t *testing.T, client *model.Client4) {
+		found, _, err := client.SearchAuditRecords(context.Background(), model.AuditRecordSearchOptions{UserId: userID})
+		require.NoError(t, err)
+		require.Len(t, found, 2)
+		assert.Equal(t, records[1].Id, found[0].Id)
+		assert.Equal(t, records[0].Id, found[1].Id)
+
+		found, _, err = client.SearchAuditRecords(context.Background(), model.AuditRecordSearchOptions{UserId: userID, Status: model.AuditStatusFail})
+		require.NoError(t, err)
+		require.Len(t, found, 1)
+		assert.Equal(t, records[1].Id, found[0].Id)
+
+		found, _, err = client.SearchAuditRecords(context.Background(), model.AuditRecordSearchOptions{UserId: userID, IpAddress: "10.0.0.1", Until: 1500})
+		require.NoError(t, err)
+		require.Len(t, found, 1)
+		assert.Equal(t, records[0].Id, found[0].Id)
+
+		_, resp, err := client.SearchAuditRecords(context.Background(), model.AuditRecordSearchOptions{PerPage: model.AuditRecordSearchMaxPerPage + 1})
+		require.Error(t, err)
+		CheckBadRequestStatus(t, resp)
+
+		_, resp, err = client.SearchAuditRecords(context.Background(), model.AuditRecordSearchOptions{Status: "unknown"})
+		require.Error(t, err)
+		CheckBadRequestStatus(t, resp)
+	})
+
+	_, resp, err := client.SearchAuditRecords(context.Background(), model.AuditRecordSearchOptions{UserId: userID})
+	require.Error(t, err)
+	CheckForbiddenStatus(t, resp)
+}
+
+func TestExportAuditRecords(t *testing.T) {
+	mainHelper.Parallel(t)
+	th := Setup(t)
+	defer th.TearDown()
+	client := th.Client
+
+	userID := model.NewId()
+	records := []*model.StoredAuditRecord{
+		model.NewStoredAuditRecord("audit-api", model.AuditRecord{EventName: model.AuditEventLogin, Status: model.AuditStatusSuccess, Actor: model.AuditEventActor{UserId: userID}}),
+		model.NewStoredAuditRecord("audit-api", model.AuditRecord{EventName: model.AuditEventLogout, Status: model.AuditStatusSuccess, Actor: model.AuditEventActor{UserId: userID}}),
+	}
+	_, err := th.App.Srv().Store().AuditRecord().SaveMultiple(records)
+	require.NoError(t, err)
+
+	th.TestForSystemAdminAndLocal(t, func(t *testing.T, client *model.Client4) {
+		t.Run("json", func(t *testing.T) {
+			var buf bytes.Buffer
+			_, _, err := client.ExportAuditRecords(context.Background(), model.AuditRecordSearchOptions{UserId: userID}, model.AuditRecordExportFormatJSON, &buf)
+			require.NoError(t, err)
+
+			var exported []*model.StoredAuditRecord
+			require.NoError(t, json.Unmarshal(buf.Bytes(), &exported))
+			require.Len(t, exported, 2)
+		})
+
+		t.Run("csv", func(t *testing.T) {
+			var buf bytes.Buffer
+			_, _, err := client.ExportAuditRecords(context.Background(), model.AuditRecordSearchOptions{UserId: userID}, model.AuditRecordExportFormatCSV, &buf)
+			require.NoError(t, err)
+
+			rows, err := csv.NewReader(&buf).ReadAll()
+			require.NoError(t, err)
+			require.Len(t, rows, 3)
+			assert.Equal(t, model.AuditRecordCSVHeader(), rows[0])
+		})
+
+		t.Run("invalid format", func(t *testing.T) {
+			_, resp, err := client.ExportAuditRecords(context.Background(), model.AuditRecordSearchOptions{}, "xml", io.Discard)
+			require.Error(t, err)
+			CheckBadRequestStatus(t, resp)
+		})
+	})
+
+	_, resp, err := client.ExportAuditRecords(context.Background(), model.AuditRecordSearchOptions{}, model.AuditRecordExportFormatJSON, io.Discard)
+	require.Error(t, err)
+	CheckForbiddenStatus(t, resp)
+}
+
 func TestEmailTest(t *testing.T) {
 	mainHelper.Parallel(t)
 	th := Setup(t)
@@ -0,0 +1,118 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package api4
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost/server/public/model"
+)
+
+func TestCreateUserDataExport(t *testing.T) {
+	mainHelper.Parallel(t)
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+
+	_, resp, err := th.Client.CreateUserDataExport(context.Background(), th.BasicUser2.Id)
+	require.Error(t, err)
+	CheckForbiddenStatus(t, resp)
+
+	job, resp, err := th.Client.CreateUserDataExport(context.Background(), th.BasicUser.Id)
+	require.NoError(t, err)
+	CheckCreatedStatus(t, resp)
+	require.Equal(t, model.JobTypeUserDataExport, job.Type)
+	require.Equal(t, th.BasicUser.Id, job.Data["user_id"])
+
+	_, resp, err = th.Client.CreateUserDataExport(context.Background(), th.BasicUser.Id)
+	require.Error(t, err)
+	CheckBadRequestStatus(t, resp)
+
+	jobs, _, err := th.Client.GetUserDataExports(context.Background(), th.BasicUser.Id)
+	require.NoError(t, err)
+	require.Len(t, jobs, 1)
+	require.Equal(t, job.Id, jobs[0].Id)
+
+	_, resp, err = th.Client.GetUserDataExports(context.Background(), th.BasicUser2.Id)
+	require.Error(t, err)
+	CheckForbiddenStatus(t, resp)
+
+	t.Run("rate limited", func(t *testing.T) {
+		_, err := th.App.Srv().Store().Job().Save(&model.Job{
+			Id:       model.NewId(),
+			Type:     model.JobTypeUserDataExport,
+			Status:   model.JobStatusSuccess,
+			CreateAt: model.GetMillis(),
+			Data:     model.StringMap{"user_id": th.BasicUser2.Id},
+		})
+		require.NoError(t, err)
+
+		client := th.CreateClient()
+		_, _, err = client.Login(context.Background(), th.BasicUser2.Username, th.BasicUser2.Password)
+		require.NoError(t, err)
+
+		_, resp, err := client.CreateUserDataExport(context.Background(), th.BasicUser2.Id)
+		require.Error(t, err)
+		require.Equal(t, http.StatusTooManyRequests, resp.StatusCode)
+
+		// Admins exporting the data of a user aren't rate limited.
+		_, resp, err = th.SystemAdminClient.CreateUserDataExport(context.Background(), th.BasicUser2.Id)
+		require.NoError(t, err)
+		CheckCreatedStatus(t, resp)
+	})
+
+	t.Run("disabled", func(t *testing.T) {
+		th.App.UpdateConfig(func(cfg *model.Config) { *cfg.ExportSettings.EnableUserDataExport = false })
+		defer th.App.UpdateConfig(func(cfg *model.Config) { *cfg.ExportSettings.EnableUserDataExport = true })
+
+		_, resp, err := th.SystemAdminClient.CreateUserDataExport(context.Background(), th.SystemAdminUser.Id)
+		require.Error(t, err)
+		CheckNotImplementedStatus(t, resp)
+	})
+}
+
+func TestDownloadUserDataExport(t *testing.T) {
+	mainHelper.Parallel(t)
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+
+	filename := model.NewId() + "_user_data_export.zip"
+	job, err := th.App.Srv().Store().Job().Save(&model.Job{
+		Id:       model.NewId(),
+		Type:     model.JobTypeUserDataExport,
+		Status:   model.JobStatusSuccess,
+		CreateAt: model.GetMillis(),
+		Data:     model.StringMap{"user_id": th.BasicUser.Id, "export_file": filename},
+	})
+	require.NoError(t, err)
+
+	filePath := filepath.Join(*th.App.Config().ExportSettings.Directory, filename)
+	_, appErr := th.App.WriteExportFile(bytes.NewReader([]byte("export")), filePath)
+	require.Nil(t, appErr)
+	defer func() {
+		appErr := th.App.RemoveExportFile(filePath)
+		require.Nil(t, appErr)
+	}()
+
+	data, _, err := th.Client.DownloadUserDataExport(context.Background(), th.BasicUser.Id, job.Id)
+	require.NoError(t, err)
+	require.Equal(t, "export", string(data))
+
+	_, resp, err := th.Client.DownloadUserDataExport(context.Background(), th.BasicUser2.Id, job.Id)
+	require.Error(t, err)
+	CheckForbiddenStatus(t, resp)
+
+	_, resp, err = th.SystemAdminClient.DownloadUserDataExport(context.Background(), th.BasicUser2.Id, job.Id)
+	require.Error(t, err)
+	CheckNotFoundStatus(t, resp)
+
+	data, _, err = th.SystemAdminClient.DownloadUserDataExport(context.Background(), th.BasicUser.Id, job.Id)
+	require.NoError(t, err)
+	require.Equal(t, "export", string(data))
+}
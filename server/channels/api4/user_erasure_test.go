@@ -0,0 +1,42 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package api4
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost/server/public/model"
+)
+
+func TestEraseUser(t *testing.T) {
+	mainHelper.Parallel(t)
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+
+	_, resp, err := th.Client.EraseUser(context.Background(), th.BasicUser2.Id, true)
+	require.Error(t, err)
+	CheckForbiddenStatus(t, resp)
+
+	_, resp, err = th.Client.EraseUser(context.Background(), th.BasicUser.Id, true)
+	require.Error(t, err)
+	CheckForbiddenStatus(t, resp)
+
+	job, resp, err := th.SystemAdminClient.EraseUser(context.Background(), th.BasicUser2.Id, true)
+	require.NoError(t, err)
+	CheckCreatedStatus(t, resp)
+	require.Equal(t, model.JobTypeUserErasure, job.Type)
+	require.Equal(t, th.BasicUser2.Id, job.Data[model.UserErasureJobDataUserId])
+	require.Equal(t, "true", job.Data[model.UserErasureJobDataDryRun])
+
+	_, resp, err = th.SystemAdminClient.EraseUser(context.Background(), th.BasicUser2.Id, false)
+	require.Error(t, err)
+	CheckBadRequestStatus(t, resp)
+
+	_, resp, err = th.SystemAdminClient.EraseUser(context.Background(), model.NewId(), false)
+	require.Error(t, err)
+	CheckNotFoundStatus(t, resp)
+}
@@ -47,6 +47,7 @@ func (api *API) InitUserLocal() {
 	api.BaseRoutes.Users.Handle("/migrate_auth/saml", api.APILocal(migrateAuthToSaml)).Methods(http.MethodPost)
 
 	api.BaseRoutes.User.Handle("/uploads", api.APILocal(localGetUploadsForUser)).Methods(http.MethodGet)
+	api.BaseRoutes.User.Handle("/websocket_connections", api.APILocal(getUserWebSocketConnections)).Methods(http.MethodGet)
 }
 
 func localGetUsers(c *Context, w http.ResponseWriter, r *http.Request) {
@@ -4221,6 +4221,40 @@ func TestGetUserAudits(t *testing.T) {
 	require.NoError(t, err)
 }
 
+func TestGetUserWebSocketConnections(t *testing.T) {
+	mainHelper.Parallel(t)
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+
+	wsClient, err := th.CreateWebSocketClient()
+	require.NoError(t, err)
+	defer wsClient.Close()
+	wsClient.Listen()
+
+	require.Eventually(t, func() bool {
+		infos, appErr := th.App.InspectWebConnsForUser(th.BasicUser.Id)
+		return appErr == nil && len(infos) == 1
+	}, 5*time.Second, 100*time.Millisecond)
+
+	_, resp, err := th.Client.GetUserWebSocketConnections(context.Background(), th.BasicUser.Id)
+	require.Error(t, err)
+	CheckForbiddenStatus(t, resp)
+
+	th.TestForSystemAdminAndLocal(t, func(t *testing.T, client *model.Client4) {
+		infos, _, err := client.GetUserWebSocketConnections(context.Background(), th.BasicUser.Id)
+		require.NoError(t, err)
+		require.Len(t, infos, 1)
+		assert.Equal(t, th.BasicUser.Id, infos[0].UserId)
+		assert.Equal(t, model.WebConnTransportWebSocket, infos[0].Transport)
+		assert.True(t, infos[0].Active)
+		assert.NotEmpty(t, infos[0].ConnectionId)
+
+		infos, _, err = client.GetUserWebSocketConnections(context.Background(), th.BasicUser2.Id)
+		require.NoError(t, err)
+		require.Empty(t, infos)
+	})
+}
+
 func TestVerifyUserEmail(t *testing.T) {
 	mainHelper.Parallel(t)
 	th := Setup(t)
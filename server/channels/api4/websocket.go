@@ -4,6 +4,8 @@
 package api4
 
 import (
+	"encoding/json"
+	"errors"
 	"net/http"
 	"strconv"
 
@@ -21,6 +23,10 @@ const (
 	postedAckParam         = "posted_ack"
 	disconnectErrCodeParam = "disconnect_err_code"
 
+	// lastEventIDHeader is sent by browsers when an event stream is
+	// automatically re-established.
+	lastEventIDHeader = "Last-Event-ID"
+
 	clientPingTimeoutErrCode      = 4000
 	clientSequenceMismatchErrCode = 4001
 )
@@ -52,6 +58,11 @@ func validateDisconnectErrCode(errCode string) bool {
 func (api *API) InitWebSocket() {
 	// Optionally supports a trailing slash
 	api.BaseRoutes.APIRoot.Handle("/{websocket:websocket(?:\\/)?}", api.APIHandlerTrustRequester(connectWebSocket)).Methods(http.MethodGet)
+
+	// Server-Sent Events transport for clients which can't upgrade to a WebSocket.
+	// Requests from the client are sent to /sse/actions instead of over the socket.
+	api.BaseRoutes.APIRoot.Handle("/sse", api.APISessionRequiredTrustRequester(connectSSE)).Methods(http.MethodGet)
+	api.BaseRoutes.APIRoot.Handle("/sse/actions", api.APISessionRequired(postSSEAction)).Methods(http.MethodPost)
 }
 
 func connectWebSocket(c *Context, w http.ResponseWriter, r *http.Request) {
@@ -123,3 +134,86 @@ func connectWebSocket(c *Context, w http.ResponseWriter, r *http.Request) {
 
 	wc.Pump()
 }
+
+func connectSSE(c *Context, w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	cfg := &platform.WebConnConfig{
+		Session:       *c.AppContext.Session(),
+		TFunc:         c.AppContext.T,
+		Locale:        "",
+		Active:        true,
+		PostedAck:     query.Get(postedAckParam) == "true",
+		RemoteAddress: c.AppContext.IPAddress(),
+		XForwardedFor: c.AppContext.XForwardedFor(),
+		OriginClient:  string(web.GetOriginClient(r)),
+		ConnectionID:  query.Get(connectionIDParam),
+	}
+
+	disconnectErrCode := query.Get(disconnectErrCodeParam)
+	if codeValid := validateDisconnectErrCode(disconnectErrCode); codeValid {
+		cfg.DisconnectErrCode = disconnectErrCode
+	}
+
+	seqVal := query.Get(sequenceNumberParam)
+	if cfg.ConnectionID == "" {
+		// Browsers resend the ID of the last received event on their own when
+		// the stream drops, which carries everything needed to resume it.
+		if connID, seq, ok := platform.ParseSSEEventID(r.Header.Get(lastEventIDHeader)); ok {
+			cfg.ConnectionID = connID
+			seqVal = seq
+		}
+	}
+
+	if cfg.ConnectionID == "" {
+		cfg.ConnectionID = model.NewId()
+	} else {
+		var err error
+		cfg, err = c.App.Srv().Platform().PopulateWebConnConfig(c.AppContext.Session(), cfg, seqVal)
+		if err != nil {
+			c.SetInvalidParamWithErr(connectionIDParam, err)
+			return
+		}
+	}
+
+	sse, err := platform.NewSSEConn(w, r)
+	if err != nil {
+		c.Err = model.NewAppError("connectSSE", "api.web_socket.connect_sse.streaming.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+		return
+	}
+	cfg.SSE = sse
+
+	// The response has been started at this point, so errors can only be logged.
+	wc := c.App.Srv().Platform().NewWebConn(cfg, c.App, c.App.Srv().Channels())
+	if err := c.App.Srv().Platform().HubRegister(wc); err != nil {
+		c.Logger.Error("Error while registering to hub", mlog.String("id", cfg.ConnectionID), mlog.Err(err))
+		sse.Close()
+		return
+	}
+
+	wc.Pump()
+}
+
+func postSSEAction(c *Context, w http.ResponseWriter, r *http.Request) {
+	connectionID := r.URL.Query().Get(connectionIDParam)
+	if !model.IsValidId(connectionID) {
+		c.SetInvalidURLParam(connectionIDParam)
+		return
+	}
+
+	var req model.WebSocketRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		c.SetInvalidParamWithErr("websocket_request", err)
+		return
+	}
+
+	if err := c.App.Srv().Platform().EnqueueSSERequest(c.AppContext.Session().UserId, connectionID, &req); err != nil {
+		if errors.Is(err, platform.ErrSSEConnNotFound) {
+			c.Err = model.NewAppError("postSSEAction", "api.web_socket.sse_action.not_found.app_error", nil, "", http.StatusNotFound).Wrap(err)
+			return
+		}
+		c.Err = model.NewAppError("postSSEAction", "api.web_socket.sse_action.queue_full.app_error", nil, "", http.StatusTooManyRequests).Wrap(err)
+		return
+	}
+
+	ReturnStatusOK(w)
+}
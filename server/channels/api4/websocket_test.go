@@ -4,6 +4,7 @@
 package api4
 
 import (
+	"bufio"
 	"context"
 	"fmt"
 	"net/http"
@@ -466,6 +467,101 @@ func TestWebSocketUpgrade(t *testing.T) {
 	testlib.AssertLog(t, buffer, mlog.LvlDebug.Name, "URL Blocked because of CORS. Url: ")
 }
 
+func TestServerSentEvents(t *testing.T) {
+	mainHelper.Parallel(t)
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+
+	connect := func(t *testing.T, header http.Header) (*http.Response, *bufio.Reader) {
+		t.Helper()
+		req, err := http.NewRequest(http.MethodGet, th.Client.APIURL+"/sse", nil)
+		require.NoError(t, err)
+		req.Header = header
+		req.Header.Set(model.HeaderAuth, th.Client.AuthType+" "+th.Client.AuthToken)
+		resp, err := http.DefaultClient.Do(req)
+		require.NoError(t, err)
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+		require.Equal(t, "text/event-stream", resp.Header.Get("Content-Type"))
+		return resp, bufio.NewReader(resp.Body)
+	}
+
+	// readEvent returns the id and data fields of the next message in the stream,
+	// skipping the status changes of the user, which are broadcast asynchronously.
+	readEvent := func(t *testing.T, rd *bufio.Reader) (string, string) {
+		t.Helper()
+		var id, data string
+		for {
+			line, err := rd.ReadString('\n')
+			require.NoError(t, err)
+			line = strings.TrimSuffix(line, "\n")
+			switch {
+			case line == "" && data != "":
+				if evt, err := model.WebSocketEventFromJSON(strings.NewReader(data)); err == nil && evt.EventType() == model.WebsocketEventStatusChange {
+					id, data = "", ""
+					continue
+				}
+				return id, data
+			case strings.HasPrefix(line, "id: "):
+				id = strings.TrimPrefix(line, "id: ")
+			case strings.HasPrefix(line, "data: "):
+				data = strings.TrimPrefix(line, "data: ")
+			}
+		}
+	}
+
+	resp, rd := connect(t, http.Header{})
+
+	id, data := readEvent(t, rd)
+	hello, err := model.WebSocketEventFromJSON(strings.NewReader(data))
+	require.NoError(t, err)
+	require.Equal(t, model.WebsocketEventHello, hello.EventType())
+	connID := hello.GetData()["connection_id"].(string)
+	require.Equal(t, connID+":1", id)
+
+	t.Run("actions are answered over the stream", func(t *testing.T) {
+		body := `{"seq": 1, "action": "ping"}`
+		actionResp, err := th.Client.DoAPIPost(context.Background(), "/sse/actions?connection_id="+connID, body)
+		require.NoError(t, err)
+		require.Equal(t, http.StatusOK, actionResp.StatusCode)
+		actionResp.Body.Close()
+
+		_, data := readEvent(t, rd)
+		reply, err := model.WebSocketResponseFromJSON(strings.NewReader(data))
+		require.NoError(t, err)
+		require.Equal(t, model.StatusOk, reply.Status)
+		require.Equal(t, int64(1), reply.SeqReply)
+	})
+
+	t.Run("unknown connection", func(t *testing.T) {
+		actionResp, err := th.Client.DoAPIPost(context.Background(), "/sse/actions?connection_id="+model.NewId(), `{"seq": 1, "action": "ping"}`)
+		require.Error(t, err)
+		CheckNotFoundStatus(t, model.BuildResponse(actionResp))
+	})
+
+	t.Run("resume with Last-Event-ID", func(t *testing.T) {
+		resp.Body.Close()
+		// Wait for the connection to be marked inactive.
+		require.Eventually(t, func() bool {
+			return th.App.Srv().Platform().WebConnCountForUser(th.BasicUser.Id) == 0
+		}, 5*time.Second, 50*time.Millisecond)
+
+		th.App.Publish(model.NewWebSocketEvent(model.WebsocketEventTyping, "", "", th.BasicUser.Id, nil, ""))
+		time.Sleep(300 * time.Millisecond)
+
+		header := http.Header{}
+		header.Set("Last-Event-ID", id)
+		resumed, rd := connect(t, header)
+		defer resumed.Body.Close()
+
+		// The connection is reused, so no new hello is sent.
+		_, data := readEvent(t, rd)
+		evt, err := model.WebSocketEventFromJSON(strings.NewReader(data))
+		require.NoError(t, err)
+		require.Equal(t, model.WebsocketEventTyping, evt.EventType())
+		require.Positive(t, evt.GetSequence())
+	})
+}
+
 func TestValidateDisconnectErrCode(t *testing.T) {
 	testCases := []struct {
 		name    string
@@ -4,6 +4,7 @@
 package app
 
 import (
+	"encoding/csv"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -62,6 +63,106 @@ func (a *App) GetAuditsPage(rctx request.CTX, userID string, page int, perPage i
 	return audits, nil
 }
 
+// SearchAuditRecords returns a page of the audit records saved when
+// ExperimentalAuditSettings.DatabaseEnabled is set, newest first.
+func (a *App) SearchAuditRecords(rctx request.CTX, opts model.AuditRecordSearchOptions) ([]*model.StoredAuditRecord, *model.AppError) {
+	opts.SetDefaults()
+	if appErr := opts.IsValid(); appErr != nil {
+		return nil, appErr
+	}
+
+	records, err := a.Srv().Store().AuditRecord().Search(opts)
+	if err != nil {
+		var outErr *store.ErrOutOfBounds
+		switch {
+		case errors.As(err, &outErr):
+			return nil, model.NewAppError("SearchAuditRecords", "app.audit.get.limit.app_error", nil, "", http.StatusBadRequest).Wrap(err)
+		default:
+			return nil, model.NewAppError("SearchAuditRecords", "app.audit_record.search.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+		}
+	}
+	return records, nil
+}
+
+// ExportAuditRecords writes every audit record matching opts to w, ignoring
+// the paging options. The records are fetched a page at a time, so an error
+// may be returned after part of the export was written.
+func (a *App) ExportAuditRecords(rctx request.CTX, w io.Writer, opts model.AuditRecordSearchOptions, format string) *model.AppError {
+	if format != model.AuditRecordExportFormatCSV && format != model.AuditRecordExportFormatJSON {
+		return model.NewAppError("ExportAuditRecords", "app.audit_record.export.format.app_error", map[string]any{"Format": format}, "", http.StatusBadRequest)
+	}
+
+	// Records logged during the export must not shift the pages.
+	if opts.Until == 0 {
+		opts.Until = model.GetMillis() + 1
+	}
+	opts.Page = 0
+	opts.PerPage = model.AuditRecordSearchMaxPerPage
+	if appErr := opts.IsValid(); appErr != nil {
+		return appErr
+	}
+
+	var csvWriter *csv.Writer
+	if format == model.AuditRecordExportFormatCSV {
+		csvWriter = csv.NewWriter(w)
+		if err := csvWriter.Write(model.AuditRecordCSVHeader()); err != nil {
+			return model.NewAppError("ExportAuditRecords", "app.audit_record.export.write.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+		}
+	} else if _, err := io.WriteString(w, "["); err != nil {
+		return model.NewAppError("ExportAuditRecords", "app.audit_record.export.write.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+
+	count := 0
+	for {
+		records, appErr := a.SearchAuditRecords(rctx, opts)
+		if appErr != nil {
+			return appErr
+		}
+
+		for _, record := range records {
+			var err error
+			if csvWriter != nil {
+				var row []string
+				if row, err = record.ToCSVRow(); err == nil {
+					err = csvWriter.Write(row)
+				}
+			} else {
+				var b []byte
+				if b, err = json.Marshal(record); err == nil {
+					if count > 0 {
+						_, err = io.WriteString(w, ",")
+					}
+					if err == nil {
+						_, err = w.Write(b)
+					}
+				}
+			}
+			if err != nil {
+				return model.NewAppError("ExportAuditRecords", "app.audit_record.export.write.app_error", nil, "id="+record.Id, http.StatusInternalServerError).Wrap(err)
+			}
+			count++
+		}
+
+		if len(records) < opts.PerPage {
+			break
+		}
+		opts.Page++
+	}
+
+	var err error
+	if csvWriter != nil {
+		csvWriter.Flush()
+		err = csvWriter.Error()
+	} else {
+		_, err = io.WriteString(w, "]")
+	}
+	if err != nil {
+		return model.NewAppError("ExportAuditRecords", "app.audit_record.export.write.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+
+	return nil
+}
+
 // LogAuditRec logs an audit record using default LvlAuditCLI.
 func (a *App) LogAuditRec(rctx request.CTX, rec *model.AuditRecord, err error) {
 	a.LogAuditRecWithLevel(rctx, rec, mlog.LvlAuditCLI, err)
@@ -151,6 +252,25 @@ func (s *Server) configureAudit(adt *audit.Audit, bAllowAdvancedLogging bool) er
 		cfg.Append(cfgAdditional)
 	}
 
+	auditSettings := s.platform.Config().ExperimentalAuditSettings
+	if *auditSettings.HashChainEnabled {
+		targetCfg, err := audit.NewHashChainTargetCfg(audit.HashChainOptions{
+			Filename:           *auditSettings.HashChainFileName,
+			SigningKeyFile:     *auditSettings.HashChainSigningKeyFile,
+			CheckpointInterval: *auditSettings.HashChainCheckpointInterval,
+		}, *auditSettings.FileMaxQueueSize)
+		if err != nil {
+			return fmt.Errorf("invalid hash chain config for audit, %w", err)
+		}
+		cfg["_hashChainAudit"] = targetCfg
+	}
+
+	if *auditSettings.DatabaseEnabled {
+		adt.ConfigureDatabase(s.Store().AuditRecord(), *auditSettings.FileMaxQueueSize)
+	} else {
+		adt.ConfigureDatabase(nil, 0)
+	}
+
 	return adt.Configure(cfg)
 }
 
@@ -232,6 +232,25 @@ func (a *App) CreateChannel(c request.CTX, channel *model.Channel, addMember boo
 	a.handleChannelCategoryName(channel)
 
 	channel.DisplayName = strings.TrimSpace(channel.DisplayName)
+
+	var rejectionReason string
+	pluginContext := pluginContext(c)
+	a.ch.RunMultiHook(func(hooks plugin.Hooks, _ *model.Manifest) bool {
+		var replacementChannel *model.Channel
+		replacementChannel, rejectionReason = hooks.ChannelWillBeCreated(pluginContext, channel)
+		if replacementChannel != nil {
+			// Plugins can't change the team, type or creator of the channel.
+			replacementChannel.TeamId = channel.TeamId
+			replacementChannel.Type = channel.Type
+			replacementChannel.CreatorId = channel.CreatorId
+			channel = replacementChannel
+		}
+		return rejectionReason == ""
+	}, plugin.ChannelWillBeCreatedID)
+	if rejectionReason != "" {
+		return nil, model.NewAppError("CreateChannel", "app.channel.create_channel.rejected_by_plugin.app_error", map[string]any{"Reason": rejectionReason}, "", http.StatusBadRequest)
+	}
+
 	sc, nErr := a.Srv().Store().Channel().Save(c, channel, *a.Config().TeamSettings.MaxChannelsPerTeam)
 	if nErr != nil {
 		var invErr *store.ErrInvalidInput
@@ -304,7 +323,6 @@ func (a *App) CreateChannel(c request.CTX, channel *model.Channel, addMember boo
 	}
 
 	a.Srv().Go(func() {
-		pluginContext := pluginContext(c)
 		a.ch.RunMultiHook(func(hooks plugin.Hooks, _ *model.Manifest) bool {
 			hooks.ChannelHasBeenCreated(pluginContext, sc)
 			return true
@@ -709,6 +727,11 @@ func (a *App) GetGroupChannel(c request.CTX, userIDs []string) (*model.Channel,
 
 // UpdateChannel updates a given channel by its Id. It also publishes the CHANNEL_UPDATED event.
 func (a *App) UpdateChannel(c request.CTX, channel *model.Channel) (*model.Channel, *model.AppError) {
+	channel, appErr := a.runChannelWillBeUpdatedHook(c, channel)
+	if appErr != nil {
+		return nil, appErr
+	}
+
 	ok, appErr := a.ChannelAccessControlled(c, channel.Id)
 	if appErr != nil {
 		return nil, appErr
@@ -747,6 +770,43 @@ func (a *App) UpdateChannel(c request.CTX, channel *model.Channel) (*model.Chann
 	return channel, nil
 }
 
+// runChannelWillBeUpdatedHook gives plugins the chance to modify or reject a channel update
+// before it is saved. The channel returned is the one to save, with the id, team, type and
+// creator of the channel passed, which plugins can't change.
+func (a *App) runChannelWillBeUpdatedHook(c request.CTX, channel *model.Channel) (*model.Channel, *model.AppError) {
+	var oldChannel *model.Channel
+	var rejectionReason string
+	var appErr *model.AppError
+	pluginContext := pluginContext(c)
+	a.ch.RunMultiHook(func(hooks plugin.Hooks, _ *model.Manifest) bool {
+		if oldChannel == nil {
+			oldChannel, appErr = a.GetChannel(c, channel.Id)
+			if appErr != nil {
+				return false
+			}
+		}
+
+		var replacementChannel *model.Channel
+		replacementChannel, rejectionReason = hooks.ChannelWillBeUpdated(pluginContext, channel, oldChannel)
+		if replacementChannel != nil {
+			replacementChannel.Id = oldChannel.Id
+			replacementChannel.TeamId = channel.TeamId
+			replacementChannel.Type = channel.Type
+			replacementChannel.CreatorId = channel.CreatorId
+			channel = replacementChannel
+		}
+		return rejectionReason == ""
+	}, plugin.ChannelWillBeUpdatedID)
+	if appErr != nil {
+		return nil, appErr
+	}
+	if rejectionReason != "" {
+		return nil, model.NewAppError("UpdateChannel", "app.channel.update_channel.rejected_by_plugin.app_error", map[string]any{"Reason": rejectionReason}, "", http.StatusBadRequest)
+	}
+
+	return channel, nil
+}
+
 // CreateChannelScheme creates a new Scheme of scope channel and assigns it to the channel.
 func (a *App) CreateChannelScheme(c request.CTX, channel *model.Channel) (*model.Scheme, *model.AppError) {
 	scheme, err := a.CreateScheme(&model.Scheme{
@@ -801,10 +861,11 @@ func (a *App) UpdateChannelPrivacy(c request.CTX, oldChannel *model.Channel, use
 		} else {
 			channel.Type = model.ChannelTypeOpen
 		}
-		// revert to previous channel privacy
-		if _, err = a.UpdateChannel(c, channel); err != nil {
-			a.Log().Error("Failed to revert channel privacy after posting an update message failed", mlog.Err(err))
+		// revert to previous channel privacy, which plugins already agreed to
+		if _, nErr := a.Srv().Store().Channel().Update(c, channel); nErr != nil {
+			a.Log().Error("Failed to revert channel privacy after posting an update message failed", mlog.Err(nErr))
 		}
+		a.Srv().Platform().InvalidateCacheForChannel(channel)
 		return channel, postErr
 	}
 
@@ -859,6 +920,16 @@ func (a *App) RestoreChannel(c request.CTX, channel *model.Channel, userID strin
 		return nil, model.NewAppError("restoreChannel", "api.channel.restore_channel.restored.app_error", nil, "", http.StatusBadRequest)
 	}
 
+	var rejectionReason string
+	pluginContext := pluginContext(c)
+	a.ch.RunMultiHook(func(hooks plugin.Hooks, _ *model.Manifest) bool {
+		rejectionReason = hooks.ChannelWillBeUnarchived(pluginContext, channel)
+		return rejectionReason == ""
+	}, plugin.ChannelWillBeUnarchivedID)
+	if rejectionReason != "" {
+		return nil, model.NewAppError("RestoreChannel", "app.channel.restore_channel.rejected_by_plugin.app_error", map[string]any{"Reason": rejectionReason}, "", http.StatusBadRequest)
+	}
+
 	if err := a.Srv().Store().Channel().Restore(channel.Id, model.GetMillis()); err != nil {
 		return nil, model.NewAppError("RestoreChannel", "app.channel.restore.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
 	}
@@ -1547,6 +1618,16 @@ func (a *App) DeleteChannel(c request.CTX, channel *model.Channel, userID string
 		return err
 	}
 
+	var rejectionReason string
+	pluginContext := pluginContext(c)
+	a.ch.RunMultiHook(func(hooks plugin.Hooks, _ *model.Manifest) bool {
+		rejectionReason = hooks.ChannelWillBeArchived(pluginContext, channel)
+		return rejectionReason == ""
+	}, plugin.ChannelWillBeArchivedID)
+	if rejectionReason != "" {
+		return model.NewAppError("DeleteChannel", "app.channel.delete_channel.rejected_by_plugin.app_error", map[string]any{"Reason": rejectionReason}, "", http.StatusBadRequest)
+	}
+
 	if user != nil {
 		T := i18n.GetUserTranslations(user.Locale)
 
@@ -2291,7 +2372,7 @@ func (a *App) GetChannelMembersByIds(c request.CTX, channelID string, userIDs []
 }
 
 func (a *App) GetChannelMembersForUser(c request.CTX, teamID string, userID string) (model.ChannelMembers, *model.AppError) {
-	channelMembers, err := a.Srv().Store().Channel().GetMembersForUser(teamID, userID)
+	channelMembers, err := a.Srv().Store().Channel().GetMembersForUser(c.Context(), teamID, userID)
 	if err != nil {
 		return nil, model.NewAppError("GetChannelMembersForUser", "app.channel.get_members.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
 	}
@@ -3017,7 +3098,7 @@ func (a *App) markChannelAsUnreadFromPostCRTUnsupported(c request.CTX, postID st
 		if mErr != nil {
 			return nil, model.NewAppError("MarkChannelAsUnreadFromPost", "app.channel.update_last_viewed_at_post.app_error", nil, "", http.StatusInternalServerError).Wrap(mErr)
 		}
-		thread, mErr := a.Srv().Store().Thread().GetThreadForUser(threadMembership, true, a.IsPostPriorityEnabled())
+		thread, mErr := a.Srv().Store().Thread().GetThreadForUser(c, threadMembership, true, a.IsPostPriorityEnabled())
 		if mErr != nil {
 			return nil, model.NewAppError("MarkChannelAsUnreadFromPost", "app.channel.update_last_viewed_at_post.app_error", nil, "", http.StatusInternalServerError).Wrap(mErr)
 		}
@@ -3235,6 +3316,8 @@ func (a *App) MarkChannelsAsViewed(c request.CTX, channelIDs []string, userID st
 			return nil, model.NewAppError("MarkChannelsAsViewed", "app.channel.update_last_viewed_at.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
 		}
 	}
+	// These store methods don't take the request context, so record the write here.
+	a.Srv().Platform().RecordWrite(c.Context())
 
 	if *a.Config().ServiceSettings.EnableChannelViewedMessages {
 		message := model.NewWebSocketEvent(model.WebsocketEventMultipleChannelsViewed, "", "", userID, nil, "")
@@ -3377,11 +3460,18 @@ func (a *App) MoveChannel(c request.CTX, team *model.Team, channel *model.Channe
 		}
 	}
 
+	movedChannel := channel.DeepCopy()
+	movedChannel.TeamId = team.Id
+	movedChannel, err = a.runChannelWillBeUpdatedHook(c, movedChannel)
+	if err != nil {
+		return err
+	}
+
 	if nErr := a.Srv().Store().Channel().UpdateSidebarChannelCategoryOnMove(channel, team.Id); nErr != nil {
 		return model.NewAppError("MoveChannel", "app.channel.sidebar_categories.app_error", nil, "", http.StatusInternalServerError).Wrap(nErr)
 	}
 
-	channel.TeamId = team.Id
+	*channel = *movedChannel
 	if _, err := a.Srv().Store().Channel().Update(c, channel); err != nil {
 		var appErr *model.AppError
 		var uniqueConstraintErr *store.ErrUniqueConstraint
@@ -44,6 +44,8 @@ type Channels struct {
 
 	pluginCommandsLock            sync.RWMutex
 	pluginCommands                []*PluginCommand
+	pluginJobTypesLock            sync.RWMutex
+	pluginJobTypes                map[string]*model.PluginJobTypeOptions
 	pluginsLock                   sync.RWMutex
 	pluginsEnvironment            *plugin.Environment
 	pluginConfigListenerID        string
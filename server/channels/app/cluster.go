@@ -3,6 +3,33 @@
 
 package app
 
+import (
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/public/pluginapi/cluster"
+)
+
+// clusterMutexNamespace is the namespace of the key-value store holding the cluster mutexes of the
+// server. It isn't a valid plugin ID, so it can't clash with the keys of a plugin.
+const clusterMutexNamespace = "server:cluster_mutex"
+
+// clusterMutexStore backs the cluster mutexes of the server with the key-value store.
+type clusterMutexStore struct {
+	srv *Server
+}
+
+func (s *clusterMutexStore) KVSetWithOptions(key string, value []byte, options model.PluginKVSetOptions) (bool, *model.AppError) {
+	return s.srv.platform.SetPluginKeyWithOptions(clusterMutexNamespace, key, value, options)
+}
+
+func (s *clusterMutexStore) LogError(msg string, keyValuePairs ...any) {
+	s.srv.Log().Sugar().Errorw(msg, keyValuePairs...)
+}
+
+// newClusterMutex returns a mutex with the given name, shared by all the servers of the cluster.
+func (s *Server) newClusterMutex(name string) (*cluster.Mutex, error) {
+	return cluster.NewMutex(&clusterMutexStore{srv: s}, name)
+}
+
 // Registers a given function to be called when the cluster leader may have changed. Returns a unique ID for the
 // listener which can later be used to remove it. If clustering is not enabled in this build, the callback will never
 // be called.
@@ -27,6 +27,14 @@ func (s *Server) clusterRemovePluginHandler(msg *model.ClusterMessage) {
 	s.Channels().removePluginFromClusterMessage(data.Id)
 }
 
+func (s *Server) clusterDeactivatePluginHandler(msg *model.ClusterMessage) {
+	var data model.PluginEventData
+	if jsonErr := json.Unmarshal(msg.Data, &data); jsonErr != nil {
+		s.Log().Warn("Failed to decode from JSON", mlog.Err(jsonErr))
+	}
+	s.Channels().deactivatePluginFromClusterMessage(data.Id)
+}
+
 func (s *Server) clusterPluginEventHandler(msg *model.ClusterMessage) {
 	if msg.Props == nil {
 		s.Log().Warn("ClusterMessage.Props for plugin event should not be nil")
@@ -61,6 +69,7 @@ func (s *Server) clusterPluginEventHandler(msg *model.ClusterMessage) {
 func (s *Server) registerClusterHandlers() {
 	s.platform.RegisterClusterMessageHandler(model.ClusterEventInstallPlugin, s.clusterInstallPluginHandler)
 	s.platform.RegisterClusterMessageHandler(model.ClusterEventRemovePlugin, s.clusterRemovePluginHandler)
+	s.platform.RegisterClusterMessageHandler(model.ClusterEventDeactivatePlugin, s.clusterDeactivatePluginHandler)
 	s.platform.RegisterClusterMessageHandler(model.ClusterEventPluginEvent, s.clusterPluginEventHandler)
 
 	s.platform.RegisterClusterHandlers()
@@ -7,6 +7,7 @@ import (
 	"github.com/mattermost/mattermost/server/public/plugin"
 	"github.com/mattermost/mattermost/server/public/shared/request"
 	"github.com/mattermost/mattermost/server/v8/channels/store/sqlstore"
+	"github.com/mattermost/mattermost/server/v8/platform/services/tracing"
 )
 
 // RequestContextWithMaster adds the context value that master DB should be selected for this request.
@@ -14,6 +15,15 @@ func RequestContextWithMaster(c request.CTX) request.CTX {
 	return sqlstore.RequestContextWithMaster(c)
 }
 
+// RequestContextWithReadYourWrites tracks the reads and writes of the request by the user making it,
+// so that the user reads from master right after writing something. See SqlStore.RecordWrite.
+func RequestContextWithReadYourWrites(c request.CTX) request.CTX {
+	if c.Session() == nil || c.Session().UserId == "" {
+		return c
+	}
+	return sqlstore.RequestContextWithReadYourWrites(c, c.Session().UserId)
+}
+
 func pluginContext(c request.CTX) *plugin.Context {
 	context := &plugin.Context{
 		RequestId:      c.RequestId(),
@@ -21,6 +31,7 @@ func pluginContext(c request.CTX) *plugin.Context {
 		IPAddress:      c.IPAddress(),
 		AcceptLanguage: c.AcceptLanguage(),
 		UserAgent:      c.UserAgent(),
+		TraceParent:    tracing.TraceParent(c.Context()),
 	}
 	return context
 }
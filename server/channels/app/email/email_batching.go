@@ -5,6 +5,7 @@ package email
 
 import (
 	"bytes"
+	"context"
 	"fmt"
 	"html/template"
 	"io"
@@ -206,7 +207,7 @@ func (job *EmailBatchingJob) checkPendingNotifications(now time.Time, handler fu
 				inspectedTeamNames[notification.teamName] = team.Id
 			}
 
-			channelMembers, err := job.service.store.Channel().GetMembersForUser(inspectedTeamNames[notification.teamName], userID)
+			channelMembers, err := job.service.store.Channel().GetMembersForUser(context.Background(), inspectedTeamNames[notification.teamName], userID)
 			if err != nil {
 				mlog.Error("Unable to find ChannelMembers for user", mlog.Err(err))
 				continue
@@ -373,7 +374,25 @@ func (es *Service) sendBatchedEmailNotification(userID string, notifications []*
 		mlog.Error("Unable to render email", mlog.Err(renderErr))
 	}
 
-	if nErr := es.SendMailWithEmbeddedFiles(user.Email, subject, renderedPage, embeddedFiles, "", "", "", "BatchedEmailNotification"); nErr != nil {
-		mlog.Warn("Unable to send batched email notification", mlog.String("email", user.Email), mlog.Err(nErr))
+	postIds := make([]string, 0, len(notifications))
+	for _, notification := range notifications {
+		postIds = append(postIds, notification.post.Id)
+	}
+	emailNotification, rejectionReason := RunEmailNotificationWillBeSentHook(es.hookRunner, &model.EmailNotification{
+		Type:         model.EmailNotificationTypeBatched,
+		RecipientId:  user.Id,
+		To:           user.Email,
+		Subject:      subject,
+		Body:         renderedPage,
+		TemplateData: TemplateDataStrings(data),
+		PostIds:      postIds,
+	})
+	if rejectionReason != "" {
+		mlog.Debug("Batched email notification rejected by plugin", mlog.String("user_id", user.Id), mlog.String("rejection_reason", rejectionReason))
+		return
+	}
+
+	if nErr := es.SendMailWithEmbeddedFiles(emailNotification.To, emailNotification.Subject, emailNotification.Body, embeddedFiles, "", "", "", "BatchedEmailNotification"); nErr != nil {
+		mlog.Warn("Unable to send batched email notification", mlog.String("email", emailNotification.To), mlog.Err(nErr))
 	}
 }
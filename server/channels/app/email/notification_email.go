@@ -12,9 +12,11 @@ import (
 	"strings"
 
 	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/public/plugin"
 	"github.com/mattermost/mattermost/server/public/shared/i18n"
 	"github.com/mattermost/mattermost/server/public/shared/mlog"
 	"github.com/mattermost/mattermost/server/v8/channels/utils"
+	"github.com/mattermost/mattermost/server/v8/platform/shared/templates"
 )
 
 type FieldRow struct {
@@ -181,3 +183,45 @@ func (es *Service) GenerateHyperlinkForChannels(postMessage, teamName, landingUR
 	}
 	return postMessage, nil
 }
+
+// RunEmailNotificationWillBeSentHook lets plugins modify, redirect or suppress a notification
+// email. It returns the notification to send, or the reason it was suppressed.
+func RunEmailNotificationWillBeSentHook(runner HookRunner, notification *model.EmailNotification) (*model.EmailNotification, string) {
+	if runner == nil {
+		return notification, ""
+	}
+
+	var rejectionReason string
+	runner.RunMultiHook(func(hooks plugin.Hooks, _ *model.Manifest) bool {
+		var replacement *model.EmailNotification
+		replacement, rejectionReason = hooks.EmailNotificationWillBeSent(notification)
+		if rejectionReason != "" {
+			return false
+		}
+		if replacement != nil {
+			if replacement.To != "" {
+				notification.To = replacement.To
+			}
+			if replacement.Subject != "" {
+				notification.Subject = replacement.Subject
+			}
+			if replacement.Body != "" {
+				notification.Body = replacement.Body
+			}
+		}
+		return true
+	}, plugin.EmailNotificationWillBeSentID)
+
+	return notification, rejectionReason
+}
+
+// TemplateDataStrings returns the text values of the template data, as given to plugins.
+func TemplateDataStrings(data templates.Data) map[string]string {
+	values := make(map[string]string, len(data.Props))
+	for key, value := range data.Props {
+		if text, ok := value.(string); ok {
+			values[key] = text
+		}
+	}
+	return values
+}
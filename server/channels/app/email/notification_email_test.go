@@ -6,9 +6,13 @@ package email
 import (
 	"testing"
 
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
 	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/public/plugin"
+	"github.com/mattermost/mattermost/server/public/plugin/plugintest"
+	"github.com/mattermost/mattermost/server/public/plugin/plugintest/mock"
 )
 
 func TestProcessMessageAttachments(t *testing.T) {
@@ -72,3 +76,121 @@ func TestProcessMessageAttachments(t *testing.T) {
 	require.Equal(t, processedAttachmentsPost[0].FieldRows[0].Cells[0].Title, "message attachment 1 field 1 title")
 	require.Equal(t, processedAttachmentsPost[1].Color, "#FF0000")
 }
+
+type testHookRunner struct {
+	hooks []plugin.Hooks
+}
+
+func (r *testHookRunner) RunMultiHook(hookRunnerFunc func(hooks plugin.Hooks, manifest *model.Manifest) bool, hookId int) {
+	for _, hooks := range r.hooks {
+		if !hookRunnerFunc(hooks, &model.Manifest{Id: "testplugin"}) {
+			return
+		}
+	}
+}
+
+func TestRunEmailNotificationWillBeSentHook(t *testing.T) {
+	newNotification := func() *model.EmailNotification {
+		return &model.EmailNotification{
+			Type:        model.EmailNotificationTypeImmediate,
+			RecipientId: "user1",
+			To:          "user1@example.com",
+			Subject:     "subject",
+			Body:        "body",
+		}
+	}
+
+	t.Run("without plugins", func(t *testing.T) {
+		notification, rejectionReason := RunEmailNotificationWillBeSentHook(nil, newNotification())
+		assert.Empty(t, rejectionReason)
+		assert.Equal(t, newNotification(), notification)
+	})
+
+	t.Run("modified and redirected", func(t *testing.T) {
+		hooks := &plugintest.Hooks{}
+		defer hooks.AssertExpectations(t)
+		hooks.On("EmailNotificationWillBeSent", mock.AnythingOfType("*model.EmailNotification")).Return(&model.EmailNotification{
+			Type:        model.EmailNotificationTypeBatched,
+			RecipientId: "user2",
+			To:          "compliance@example.com",
+			Subject:     "new subject",
+			Body:        "new body",
+		}, "")
+
+		notification, rejectionReason := RunEmailNotificationWillBeSentHook(&testHookRunner{hooks: []plugin.Hooks{hooks}}, newNotification())
+		assert.Empty(t, rejectionReason)
+		assert.Equal(t, "compliance@example.com", notification.To)
+		assert.Equal(t, "new subject", notification.Subject)
+		assert.Equal(t, "new body", notification.Body)
+		assert.Equal(t, model.EmailNotificationTypeImmediate, notification.Type)
+		assert.Equal(t, "user1", notification.RecipientId)
+	})
+
+	t.Run("the recipient is kept without a replacement address", func(t *testing.T) {
+		hooks := &plugintest.Hooks{}
+		defer hooks.AssertExpectations(t)
+		hooks.On("EmailNotificationWillBeSent", mock.AnythingOfType("*model.EmailNotification")).Return(&model.EmailNotification{Subject: "new subject", Body: "new body"}, "")
+
+		notification, rejectionReason := RunEmailNotificationWillBeSentHook(&testHookRunner{hooks: []plugin.Hooks{hooks}}, newNotification())
+		assert.Empty(t, rejectionReason)
+		assert.Equal(t, "user1@example.com", notification.To)
+		assert.Equal(t, "new subject", notification.Subject)
+	})
+
+	t.Run("the rendered subject and body are kept without replacements", func(t *testing.T) {
+		hooks := &plugintest.Hooks{}
+		defer hooks.AssertExpectations(t)
+		hooks.On("EmailNotificationWillBeSent", mock.AnythingOfType("*model.EmailNotification")).Return(&model.EmailNotification{To: "compliance@example.com"}, "")
+
+		notification, rejectionReason := RunEmailNotificationWillBeSentHook(&testHookRunner{hooks: []plugin.Hooks{hooks}}, newNotification())
+		assert.Empty(t, rejectionReason)
+		assert.Equal(t, "compliance@example.com", notification.To)
+		assert.Equal(t, "subject", notification.Subject)
+		assert.Equal(t, "body", notification.Body)
+	})
+
+	t.Run("suppressed", func(t *testing.T) {
+		hooks := &plugintest.Hooks{}
+		defer hooks.AssertExpectations(t)
+		hooks.On("EmailNotificationWillBeSent", mock.AnythingOfType("*model.EmailNotification")).Return(nil, "suppressed")
+		next := &plugintest.Hooks{}
+		defer next.AssertExpectations(t)
+
+		_, rejectionReason := RunEmailNotificationWillBeSentHook(&testHookRunner{hooks: []plugin.Hooks{hooks, next}}, newNotification())
+		assert.Equal(t, "suppressed", rejectionReason)
+	})
+}
+
+func TestSendBatchedEmailNotificationRunsHook(t *testing.T) {
+	mainHelper.Parallel(t)
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+
+	post1, err := th.store.Post().Save(th.Context, &model.Post{UserId: th.BasicUser.Id, ChannelId: th.BasicChannel.Id, Message: "post1"})
+	require.NoError(t, err)
+	post2, err := th.store.Post().Save(th.Context, &model.Post{UserId: th.BasicUser.Id, ChannelId: th.BasicChannel.Id, Message: "post2"})
+	require.NoError(t, err)
+
+	var received *model.EmailNotification
+	hooks := &plugintest.Hooks{}
+	defer hooks.AssertExpectations(t)
+	hooks.On("EmailNotificationWillBeSent", mock.AnythingOfType("*model.EmailNotification")).Run(func(args mock.Arguments) {
+		received = args.Get(0).(*model.EmailNotification)
+	}).Return(nil, "suppressed")
+	th.service.hookRunner = &testHookRunner{hooks: []plugin.Hooks{hooks}}
+
+	th.service.sendBatchedEmailNotification(th.BasicUser2.Id, []*batchedNotification{
+		{post: post1, teamName: th.BasicTeam.Name},
+		{post: post2, teamName: th.BasicTeam.Name},
+	})
+
+	require.NotNil(t, received)
+	assert.Equal(t, model.EmailNotificationTypeBatched, received.Type)
+	assert.Equal(t, th.BasicUser2.Id, received.RecipientId)
+	assert.Equal(t, th.BasicUser2.Email, received.To)
+	assert.NotEmpty(t, received.Subject)
+	assert.Contains(t, received.Body, "post1")
+	assert.Contains(t, received.Body, "post2")
+	assert.NotEmpty(t, received.TemplateData["Title"])
+	assert.Equal(t, []string{post1.Id, post2.Id}, received.PostIds)
+}
@@ -13,6 +13,7 @@ import (
 	"github.com/throttled/throttled/store/memstore"
 
 	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/public/plugin"
 	"github.com/mattermost/mattermost/server/public/shared/i18n"
 	"github.com/mattermost/mattermost/server/public/shared/mlog"
 	"github.com/mattermost/mattermost/server/v8/channels/app/users"
@@ -49,6 +50,7 @@ type Service struct {
 	store       store.Store
 
 	templatesContainer      *templates.Container
+	hookRunner              HookRunner
 	perHourEmailRateLimiter *throttled.GCRARateLimiter
 	perDayEmailRateLimiter  *throttled.GCRARateLimiter
 	EmailBatching           *EmailBatchingJob
@@ -61,6 +63,13 @@ type ServiceConfig struct {
 	TemplatesContainer *templates.Container
 	UserService        *users.UserService
 	Store              store.Store
+	// HookRunner runs the plugin hooks for notification emails. It is optional.
+	HookRunner HookRunner
+}
+
+// HookRunner runs plugin hooks, implemented by the app's Channels.
+type HookRunner interface {
+	RunMultiHook(hookRunnerFunc func(hooks plugin.Hooks, manifest *model.Manifest) bool, hookId int)
 }
 
 func NewService(config ServiceConfig) (*Service, error) {
@@ -73,6 +82,7 @@ func NewService(config ServiceConfig) (*Service, error) {
 		license:            config.LicenseFn,
 		store:              config.Store,
 		userService:        config.UserService,
+		hookRunner:         config.HookRunner,
 	}
 	if err := service.setUpRateLimiters(); err != nil {
 		return nil, err
@@ -136,7 +136,7 @@ func (a *App) BulkExport(ctx request.CTX, writer io.Writer, outPath string, job
 	}
 
 	ctx.Logger().Info("Bulk export: exporting version")
-	if err := a.exportVersion(writer); err != nil {
+	if err := a.exportVersion(writer, opts.Since); err != nil {
 		return err
 	}
 
@@ -147,52 +147,70 @@ func (a *App) BulkExport(ctx request.CTX, writer io.Writer, outPath string, job
 	}
 
 	ctx.Logger().Info("Bulk export: exporting teams")
-	teamNames, appErr := a.exportAllTeams(ctx, job, writer)
+	teamNames, appErr := a.exportAllTeams(ctx, job, writer, opts.Since)
 	if appErr != nil {
 		return appErr
 	}
 
 	ctx.Logger().Info("Bulk export: exporting channels")
-	if appErr = a.exportAllChannels(ctx, job, writer, teamNames, opts.IncludeArchivedChannels); appErr != nil {
+	if appErr = a.exportAllChannels(ctx, job, writer, teamNames, opts.IncludeArchivedChannels, opts.Since); appErr != nil {
 		return appErr
 	}
 
 	ctx.Logger().Info("Bulk export: exporting users")
-	profilePictures, appErr := a.exportAllUsers(ctx, job, writer, opts.IncludeArchivedChannels, opts.IncludeProfilePictures)
+	profilePictures, appErr := a.exportAllUsers(ctx, job, writer, opts.IncludeArchivedChannels, opts.IncludeProfilePictures, opts.Since)
 	if appErr != nil {
 		return appErr
 	}
 
 	ctx.Logger().Info("Bulk export: exporting bots")
-	botPPs, appErr := a.exportAllBots(ctx, job, writer, opts.IncludeProfilePictures)
+	botPPs, appErr := a.exportAllBots(ctx, job, writer, opts.IncludeProfilePictures, opts.Since)
 	if appErr != nil {
 		return appErr
 	}
 	profilePictures = append(profilePictures, botPPs...)
 
+	ctx.Logger().Info("Bulk export: exporting channel bookmarks")
+	bookmarkFiles, appErr := a.exportAllChannelBookmarks(ctx, job, writer, opts.IncludeArchivedChannels, opts.Since)
+	if appErr != nil {
+		return appErr
+	}
+
 	ctx.Logger().Info("Bulk export: exporting posts")
-	attachments, appErr := a.exportAllPosts(ctx, job, writer, opts.IncludeAttachments, opts.IncludeArchivedChannels)
+	attachments, appErr := a.exportAllPosts(ctx, job, writer, opts.IncludeAttachments, opts.IncludeArchivedChannels, opts.Since)
 	if appErr != nil {
 		return appErr
 	}
 
 	ctx.Logger().Info("Bulk export: exporting emoji")
-	emojiPaths, appErr := a.exportCustomEmoji(ctx, job, writer, outPath, "exported_emoji", !opts.CreateArchive)
+	emojiPaths, appErr := a.exportCustomEmoji(ctx, job, writer, outPath, "exported_emoji", !opts.CreateArchive, opts.Since)
 	if appErr != nil {
 		return appErr
 	}
 
 	ctx.Logger().Info("Bulk export: exporting direct channels")
-	if appErr = a.exportAllDirectChannels(ctx, job, writer, opts.IncludeArchivedChannels); appErr != nil {
+	if appErr = a.exportAllDirectChannels(ctx, job, writer, opts.IncludeArchivedChannels, opts.Since); appErr != nil {
 		return appErr
 	}
 
 	ctx.Logger().Info("Bulk export: exporting direct posts")
-	directAttachments, appErr := a.exportAllDirectPosts(ctx, job, writer, opts.IncludeAttachments, opts.IncludeArchivedChannels)
+	directAttachments, appErr := a.exportAllDirectPosts(ctx, job, writer, opts.IncludeAttachments, opts.IncludeArchivedChannels, opts.Since)
 	if appErr != nil {
 		return appErr
 	}
 
+	if opts.Since > 0 {
+		ctx.Logger().Info("Bulk export: exporting deleted posts")
+		if appErr = a.exportDeletedPosts(ctx, job, writer, opts.Since); appErr != nil {
+			return appErr
+		}
+
+		ctx.Logger().Info("Bulk export: exporting deleted emoji")
+		if appErr = a.exportDeletedEmoji(ctx, job, writer, opts.Since); appErr != nil {
+			return appErr
+		}
+	}
+
 	if opts.IncludeAttachments {
 		ctx.Logger().Info("Bulk export: exporting file attachments")
 		warnings, appErr := a.exportAttachments(ctx, attachments, outPath, zipWr)
@@ -207,6 +225,13 @@ func (a *App) BulkExport(ctx request.CTX, writer io.Writer, outPath string, job
 		}
 		warnings = append(warnings, newWarnings...)
 
+		ctx.Logger().Info("Bulk export: exporting channel bookmark files")
+		newWarnings, appErr = a.exportAttachments(ctx, bookmarkFiles, outPath, zipWr)
+		if appErr != nil {
+			return appErr
+		}
+		warnings = append(warnings, newWarnings...)
+
 		totalExportedEmojis := 0
 		emojisLen := len(emojiPaths)
 		ctx.Logger().Info("Bulk export: exporting custom emojis")
@@ -233,7 +258,7 @@ func (a *App) BulkExport(ctx request.CTX, writer io.Writer, outPath string, job
 			updateJobProgress(ctx.Logger(), a.Srv().Store(), job, "num_warnings", len(warnings))
 		}
 
-		updateJobProgress(ctx.Logger(), a.Srv().Store(), job, "attachments_exported", len(attachments)+len(directAttachments)+len(emojiPaths))
+		updateJobProgress(ctx.Logger(), a.Srv().Store(), job, "attachments_exported", len(attachments)+len(directAttachments)+len(bookmarkFiles)+len(emojiPaths))
 	}
 
 	if opts.IncludeProfilePictures {
@@ -281,13 +306,14 @@ func (a *App) exportWriteLine(w io.Writer, line *imports.LineImportData) *model.
 	return nil
 }
 
-func (a *App) exportVersion(writer io.Writer) *model.AppError {
+func (a *App) exportVersion(writer io.Writer, since int64) *model.AppError {
 	version := 1
 
 	info := &imports.VersionInfoImportData{
 		Generator: "mattermost-server",
 		Version:   fmt.Sprintf("%s (%s, enterprise: %s)", model.CurrentVersion, model.BuildHash, model.BuildEnterpriseReady),
 		Created:   time.Now().Format(time.RFC3339Nano),
+		Since:     since,
 	}
 
 	versionLine := &imports.LineImportData{
@@ -399,7 +425,10 @@ func (a *App) exportSchemes(ctx request.CTX, job *model.Job, writer io.Writer, s
 	}
 }
 
-func (a *App) exportAllTeams(ctx request.CTX, job *model.Job, writer io.Writer) (map[string]bool, *model.AppError) {
+// exportAllTeams exports the teams and returns the names of the ones that
+// aren't deleted. When since is set, only the teams updated since are
+// exported and the teams deleted since are exported as tombstones.
+func (a *App) exportAllTeams(ctx request.CTX, job *model.Job, writer io.Writer, since int64) (map[string]bool, *model.AppError) {
 	afterId := strings.Repeat("0", 26)
 	teamNames := make(map[string]bool)
 	cnt := 0
@@ -420,10 +449,19 @@ func (a *App) exportAllTeams(ctx request.CTX, job *model.Job, writer io.Writer)
 
 			// Skip deleted.
 			if team.DeleteAt != 0 {
+				if since > 0 && team.DeleteAt >= since {
+					if err := a.exportWriteLine(writer, importLineFromTeamTombstone(team)); err != nil {
+						return nil, err
+					}
+				}
 				continue
 			}
 			teamNames[team.Name] = true
 
+			if team.UpdateAt < since {
+				continue
+			}
+
 			teamLine := importLineFromTeam(team)
 			if err := a.exportWriteLine(writer, teamLine); err != nil {
 				return nil, err
@@ -434,7 +472,7 @@ func (a *App) exportAllTeams(ctx request.CTX, job *model.Job, writer io.Writer)
 	return teamNames, nil
 }
 
-func (a *App) exportAllChannels(ctx request.CTX, job *model.Job, writer io.Writer, teamNames map[string]bool, withArchived bool) *model.AppError {
+func (a *App) exportAllChannels(ctx request.CTX, job *model.Job, writer io.Writer, teamNames map[string]bool, withArchived bool, since int64) *model.AppError {
 	afterId := strings.Repeat("0", 26)
 	cnt := 0
 	for {
@@ -452,14 +490,18 @@ func (a *App) exportAllChannels(ctx request.CTX, job *model.Job, writer io.Write
 		for _, channel := range channels {
 			afterId = channel.Id
 
-			// Skip deleted.
-			if channel.DeleteAt != 0 && !withArchived {
+			// Skip deleted, unless archived since the previous export.
+			if channel.DeleteAt != 0 && !withArchived && (since == 0 || channel.DeleteAt < since) {
 				continue
 			}
 			// Skip channels on deleted teams.
 			if ok := teamNames[channel.TeamName]; !ok {
 				continue
 			}
+			// Skip unchanged.
+			if channel.UpdateAt < since && channel.DeleteAt < since {
+				continue
+			}
 
 			channelLine := importLineFromChannel(channel)
 			if err := a.exportWriteLine(writer, channelLine); err != nil {
@@ -471,10 +513,16 @@ func (a *App) exportAllChannels(ctx request.CTX, job *model.Job, writer io.Write
 	return nil
 }
 
-func (a *App) exportAllUsers(ctx request.CTX, job *model.Job, writer io.Writer, includeArchivedChannels, includeProfilePictures bool) ([]string, *model.AppError) {
+func (a *App) exportAllUsers(ctx request.CTX, job *model.Job, writer io.Writer, includeArchivedChannels, includeProfilePictures bool, since int64) ([]string, *model.AppError) {
 	afterId := strings.Repeat("0", 26)
 	cnt := 0
 	profilePictures := []string{}
+
+	cpaFields, appErr := a.exportableCPAFields()
+	if appErr != nil {
+		return profilePictures, appErr
+	}
+
 	for {
 		users, err := a.Srv().Store().User().GetAllAfter(1000, afterId)
 		if err != nil {
@@ -495,72 +543,24 @@ func (a *App) exportAllUsers(ctx request.CTX, job *model.Job, writer io.Writer,
 				continue
 			}
 
-			// Gathering here the exportable preferences to pass them on to importLineFromUser
-			exportedPrefs := make(map[string]*string)
-			allPrefs, err := a.GetPreferencesForUser(ctx, user.Id)
-			if err != nil {
-				return profilePictures, err
-			}
-			for _, pref := range allPrefs {
-				// We need to manage the special cases
-				// Here we manage Tutorial steps
-				if pref.Category == model.PreferenceCategoryTutorialSteps {
-					pref.Name = ""
-					// Then the email interval
-				} else if pref.Category == model.PreferenceCategoryNotifications && pref.Name == model.PreferenceNameEmailInterval {
-					switch pref.Value {
-					case model.PreferenceEmailIntervalNoBatchingSeconds:
-						pref.Value = model.PreferenceEmailIntervalImmediately
-					case model.PreferenceEmailIntervalFifteenAsSeconds:
-						pref.Value = model.PreferenceEmailIntervalFifteen
-					case model.PreferenceEmailIntervalHourAsSeconds:
-						pref.Value = model.PreferenceEmailIntervalHour
-					case "0":
-						pref.Value = ""
-					}
-				}
-				id, ok := exportablePreferences[imports.ComparablePreference{
-					Category: pref.Category,
-					Name:     pref.Name,
-				}]
-				if ok {
-					prefPtr := pref.Value
-					if prefPtr != "" {
-						exportedPrefs[id] = &prefPtr
-					} else {
-						exportedPrefs[id] = nil
-					}
-				}
-			}
-
-			userLine := importLineFromUser(user, exportedPrefs)
-
-			if includeProfilePictures {
-				var pp string
-				pp, err = a.GetProfileImagePath(user)
+			// Skip unchanged.
+			if user.UpdateAt < since {
+				changed, err := a.userMembershipsChangedSince(user.Id, since)
 				if err != nil {
 					return profilePictures, err
 				}
-				if pp != "" {
-					userLine.User.ProfileImage = &pp
-					profilePictures = append(profilePictures, pp)
+				if !changed {
+					continue
 				}
 			}
 
-			userLine.User.NotifyProps = a.buildUserNotifyProps(user.NotifyProps)
-
-			// Adding custom status
-			if cs := user.GetCustomStatus(); cs != nil {
-				userLine.User.CustomStatus = cs
-			}
-
-			// Do the Team Memberships.
-			members, err := a.buildUserTeamAndChannelMemberships(ctx, user.Id, includeArchivedChannels)
+			userLine, pp, err := a.buildUserLine(ctx, user, cpaFields, includeArchivedChannels, includeProfilePictures)
 			if err != nil {
 				return profilePictures, err
 			}
-
-			userLine.User.Teams = members
+			if pp != "" {
+				profilePictures = append(profilePictures, pp)
+			}
 
 			if err := a.exportWriteLine(writer, userLine); err != nil {
 				return profilePictures, err
@@ -571,7 +571,103 @@ func (a *App) exportAllUsers(ctx request.CTX, job *model.Job, writer io.Writer,
 	return profilePictures, nil
 }
 
-func (a *App) exportAllBots(ctx request.CTX, job *model.Job, writer io.Writer, includeProfilePictures bool) ([]string, *model.AppError) {
+// buildUserLine builds the import line of the user, along with the path of
+// their profile picture when it's included.
+func (a *App) buildUserLine(ctx request.CTX, user *model.User, cpaFields map[string]*model.CPAField, includeArchivedChannels, includeProfilePicture bool) (*imports.LineImportData, string, *model.AppError) {
+	// Gathering here the exportable preferences to pass them on to importLineFromUser
+	exportedPrefs := make(map[string]*string)
+	allPrefs, err := a.GetPreferencesForUser(ctx, user.Id)
+	if err != nil {
+		return nil, "", err
+	}
+	for _, pref := range allPrefs {
+		// We need to manage the special cases
+		// Here we manage Tutorial steps
+		if pref.Category == model.PreferenceCategoryTutorialSteps {
+			pref.Name = ""
+			// Then the email interval
+		} else if pref.Category == model.PreferenceCategoryNotifications && pref.Name == model.PreferenceNameEmailInterval {
+			switch pref.Value {
+			case model.PreferenceEmailIntervalNoBatchingSeconds:
+				pref.Value = model.PreferenceEmailIntervalImmediately
+			case model.PreferenceEmailIntervalFifteenAsSeconds:
+				pref.Value = model.PreferenceEmailIntervalFifteen
+			case model.PreferenceEmailIntervalHourAsSeconds:
+				pref.Value = model.PreferenceEmailIntervalHour
+			case "0":
+				pref.Value = ""
+			}
+		}
+		id, ok := exportablePreferences[imports.ComparablePreference{
+			Category: pref.Category,
+			Name:     pref.Name,
+		}]
+		if ok {
+			prefPtr := pref.Value
+			if prefPtr != "" {
+				exportedPrefs[id] = &prefPtr
+			} else {
+				exportedPrefs[id] = nil
+			}
+		}
+	}
+
+	userLine := importLineFromUser(user, exportedPrefs)
+
+	var profilePicture string
+	if includeProfilePicture {
+		profilePicture, err = a.GetProfileImagePath(user)
+		if err != nil {
+			return nil, "", err
+		}
+		if profilePicture != "" {
+			userLine.User.ProfileImage = &profilePicture
+		}
+	}
+
+	userLine.User.NotifyProps = a.buildUserNotifyProps(user.NotifyProps)
+
+	// Adding custom status
+	if cs := user.GetCustomStatus(); cs != nil {
+		userLine.User.CustomStatus = cs
+	}
+
+	// Do the Team Memberships.
+	members, err := a.buildUserTeamAndChannelMemberships(ctx, user.Id, includeArchivedChannels)
+	if err != nil {
+		return nil, "", err
+	}
+
+	userLine.User.Teams = members
+
+	userLine.User.CustomProfileAttributes, err = a.buildUserCustomProfileAttributes(cpaFields, user.Id)
+	if err != nil {
+		return nil, "", err
+	}
+
+	return userLine, profilePicture, nil
+}
+
+// exportableCPAFields returns the custom profile attribute fields by id.
+func (a *App) exportableCPAFields() (map[string]*model.CPAField, *model.AppError) {
+	fields, appErr := a.ListCPAFields()
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	fieldsByID := make(map[string]*model.CPAField, len(fields))
+	for _, field := range fields {
+		cpaField, err := model.NewCPAFieldFromPropertyField(field)
+		if err != nil {
+			return nil, model.NewAppError("exportableCPAFields", "app.custom_profile_attributes.property_field_conversion.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+		}
+		fieldsByID[field.ID] = cpaField
+	}
+
+	return fieldsByID, nil
+}
+
+func (a *App) exportAllBots(ctx request.CTX, job *model.Job, writer io.Writer, includeProfilePictures bool, since int64) ([]string, *model.AppError) {
 	afterId := ""
 	cnt := 0
 	profilePictures := []string{}
@@ -590,6 +686,11 @@ func (a *App) exportAllBots(ctx request.CTX, job *model.Job, writer io.Writer, i
 		for _, bot := range bots {
 			afterId = bot.UserId
 
+			// Skip unchanged.
+			if bot.UpdateAt < since {
+				continue
+			}
+
 			var ownerUsername string
 			owner, err := a.Srv().Store().User().Get(ctx.Context(), bot.OwnerId)
 			if err != nil {
@@ -629,6 +730,173 @@ func (a *App) exportAllBots(ctx request.CTX, job *model.Job, writer io.Writer, i
 	return profilePictures, nil
 }
 
+func (a *App) exportAllChannelBookmarks(ctx request.CTX, job *model.Job, writer io.Writer, withArchived bool, since int64) ([]imports.AttachmentImportData, *model.AppError) {
+	var files []imports.AttachmentImportData
+	afterId := strings.Repeat("0", 26)
+	usernames := map[string]string{}
+	cnt := 0
+	for {
+		channels, err := a.Srv().Store().Channel().GetAllChannelsForExportAfter(1000, afterId)
+		if err != nil {
+			return nil, model.NewAppError("exportAllChannelBookmarks", "app.channel.get_all.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+		}
+
+		if len(channels) == 0 {
+			break
+		}
+
+		for _, channel := range channels {
+			afterId = channel.Id
+
+			// Skip deleted.
+			if channel.DeleteAt != 0 && !withArchived {
+				continue
+			}
+
+			bookmarks, err := a.Srv().Store().ChannelBookmark().GetBookmarksForChannelSince(channel.Id, since)
+			if err != nil {
+				return nil, model.NewAppError("exportAllChannelBookmarks", "app.channel.bookmark.get.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+			}
+
+			for _, bookmark := range bookmarks {
+				if bookmark.DeleteAt != 0 {
+					if since > 0 {
+						if err := a.exportWriteLine(writer, importLineFromChannelBookmarkTombstone(channel.TeamName, channel.Name, bookmark)); err != nil {
+							return nil, err
+						}
+					}
+					continue
+				}
+
+				username, ok := usernames[bookmark.OwnerId]
+				if !ok {
+					owner, err := a.Srv().Store().User().Get(context.Background(), bookmark.OwnerId)
+					if err != nil {
+						var nfErr *store.ErrNotFound
+						if errors.As(err, &nfErr) {
+							ctx.Logger().Info("Skipping channel bookmark since its owner doesn't exist anymore", mlog.String("bookmark_id", bookmark.Id))
+							continue
+						}
+						return nil, model.NewAppError("exportAllChannelBookmarks", "app.user.get.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+					}
+					username = owner.Username
+					usernames[bookmark.OwnerId] = username
+				}
+
+				bookmarkLine := importLineFromChannelBookmark(channel.TeamName, channel.Name, username, bookmark)
+				if bookmarkLine.Bookmark.File != nil {
+					files = append(files, *bookmarkLine.Bookmark.File)
+				} else if bookmark.Type == model.ChannelBookmarkFile {
+					ctx.Logger().Info("Skipping file channel bookmark without a file", mlog.String("bookmark_id", bookmark.Id))
+					continue
+				}
+
+				if err := a.exportWriteLine(writer, bookmarkLine); err != nil {
+					return nil, err
+				}
+				cnt++
+			}
+		}
+	}
+	updateJobProgress(ctx.Logger(), a.Srv().Store(), job, "channel_bookmarks_exported", cnt)
+
+	return files, nil
+}
+
+// buildUserCustomProfileAttributes returns the custom profile attribute values
+// of a user, with option ids and user ids replaced by names and usernames.
+func (a *App) buildUserCustomProfileAttributes(fieldsByID map[string]*model.CPAField, userID string) (*[]imports.UserCustomProfileAttributeImportData, *model.AppError) {
+	if len(fieldsByID) == 0 {
+		return nil, nil
+	}
+
+	values, appErr := a.ListCPAValues(userID)
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	attributes := make([]imports.UserCustomProfileAttributeImportData, 0, len(values))
+	for _, value := range values {
+		field, ok := fieldsByID[value.FieldID]
+		if !ok {
+			continue
+		}
+
+		mapped, err := mapCustomProfileAttributeValue(field, value.Value, func(id string) (string, bool) {
+			switch field.Type {
+			case model.PropertyFieldTypeSelect, model.PropertyFieldTypeMultiselect:
+				for _, option := range field.Attrs.Options {
+					if option.ID == id {
+						return option.Name, true
+					}
+				}
+			case model.PropertyFieldTypeUser, model.PropertyFieldTypeMultiuser:
+				if user, err := a.Srv().Store().User().Get(context.Background(), id); err == nil {
+					return user.Username, true
+				}
+			}
+			return "", false
+		})
+		if err != nil {
+			return nil, model.NewAppError("buildUserCustomProfileAttributes", "app.custom_profile_attributes.property_field_conversion.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+		}
+
+		attributes = append(attributes, imports.UserCustomProfileAttributeImportData{
+			Name:  model.NewPointer(field.Name),
+			Value: mapped,
+		})
+	}
+
+	if len(attributes) == 0 {
+		return nil, nil
+	}
+	return &attributes, nil
+}
+
+// buildUserSidebarCategories returns the custom sidebar categories of a user
+// in a team. Channels outside of the team, such as direct messages, are left
+// out as they are referenced by name.
+func (a *App) buildUserSidebarCategories(c request.CTX, userID, teamID string) (*[]imports.UserSidebarCategoryImportData, *model.AppError) {
+	categories, err := a.Srv().Store().Channel().GetSidebarCategoriesForTeamForUser(userID, teamID)
+	if err != nil {
+		return nil, model.NewAppError("buildUserSidebarCategories", "app.channel.sidebar_categories.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+
+	var data []imports.UserSidebarCategoryImportData
+	for _, category := range categories.Categories {
+		if category.Type != model.SidebarCategoryCustom {
+			continue
+		}
+
+		channelNames := []string{}
+		if len(category.Channels) > 0 {
+			channels, err := a.Srv().Store().Channel().GetChannelsByIds(category.Channels, false)
+			if err != nil {
+				return nil, model.NewAppError("buildUserSidebarCategories", "app.channel.get_channels_by_ids.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+			}
+			namesByID := make(map[string]string, len(channels))
+			for _, channel := range channels {
+				if channel.TeamId == teamID {
+					namesByID[channel.Id] = channel.Name
+				}
+			}
+			// Keep the order of the category.
+			for _, channelID := range category.Channels {
+				if name, ok := namesByID[channelID]; ok {
+					channelNames = append(channelNames, name)
+				}
+			}
+		}
+
+		data = append(data, *importSidebarCategoryFromCategory(category, channelNames))
+	}
+
+	if len(data) == 0 {
+		return nil, nil
+	}
+	return &data, nil
+}
+
 func (a *App) buildUserTeamAndChannelMemberships(c request.CTX, userID string, includeArchivedChannels bool) (*[]imports.UserTeamImportData, *model.AppError) {
 	var memberships []imports.UserTeamImportData
 
@@ -659,12 +927,44 @@ func (a *App) buildUserTeamAndChannelMemberships(c request.CTX, userID string, i
 
 		memberData.Channels = channelMembers
 
+		memberData.Categories, err = a.buildUserSidebarCategories(c, userID, member.TeamId)
+		if err != nil {
+			return nil, err
+		}
+
 		memberships = append(memberships, *memberData)
 	}
 
 	return &memberships, nil
 }
 
+// userMembershipsChangedSince reports whether the team or channel
+// memberships of a user changed since the given time.
+func (a *App) userMembershipsChangedSince(userID string, since int64) (bool, *model.AppError) {
+	members, err := a.Srv().Store().Team().GetTeamMembersForExport(userID)
+	if err != nil {
+		return false, model.NewAppError("userMembershipsChangedSince", "app.team.get_members.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+
+	for _, member := range members {
+		if member.CreateAt >= since || member.DeleteAt >= since {
+			return true, nil
+		}
+
+		channelMembers, err := a.Srv().Store().Channel().GetChannelMembersForExport(userID, member.TeamId, true)
+		if err != nil {
+			return false, model.NewAppError("userMembershipsChangedSince", "app.channel.get_members.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+		}
+		for _, channelMember := range channelMembers {
+			if channelMember.LastUpdateAt >= since {
+				return true, nil
+			}
+		}
+	}
+
+	return false, nil
+}
+
 func (a *App) buildUserChannelMemberships(c request.CTX, userID string, teamID string, includeArchivedChannels bool) (*[]imports.UserChannelImportData, *model.AppError) {
 	members, nErr := a.Srv().Store().Channel().GetChannelMembersForExport(userID, teamID, includeArchivedChannels)
 	if nErr != nil {
@@ -704,7 +1004,7 @@ func (a *App) buildUserNotifyProps(notifyProps model.StringMap) *imports.UserNot
 	}
 }
 
-func (a *App) exportAllPosts(ctx request.CTX, job *model.Job, writer io.Writer, withAttachments bool, includeArchivedChannels bool) ([]imports.AttachmentImportData, *model.AppError) {
+func (a *App) exportAllPosts(ctx request.CTX, job *model.Job, writer io.Writer, withAttachments bool, includeArchivedChannels bool, since int64) ([]imports.AttachmentImportData, *model.AppError) {
 	var attachments []imports.AttachmentImportData
 	afterId := strings.Repeat("0", 26)
 	var postProcessCount uint64
@@ -717,7 +1017,7 @@ func (a *App) exportAllPosts(ctx request.CTX, job *model.Job, writer io.Writer,
 			logCheckpoint = time.Now()
 		}
 
-		posts, nErr := a.Srv().Store().Post().GetParentsForExportAfter(1000, afterId, includeArchivedChannels)
+		posts, nErr := a.Srv().Store().Post().GetParentsForExportAfter(1000, afterId, includeArchivedChannels, since)
 		if nErr != nil {
 			return nil, model.NewAppError("exportAllPosts", "app.post.get_posts.app_error", nil, "", http.StatusInternalServerError).Wrap(nErr)
 		}
@@ -778,6 +1078,11 @@ func (a *App) exportAllPosts(ctx request.CTX, job *model.Job, writer io.Writer,
 				}
 			}
 
+			postLine.Post.Priority, postLine.Post.Acknowledgements, postLine.Post.EditHistory, err = a.buildPostMetadata(ctx, &post.Post)
+			if err != nil {
+				return nil, err
+			}
+
 			if err := a.exportWriteLine(writer, postLine); err != nil {
 				return nil, err
 			}
@@ -814,6 +1119,12 @@ func (a *App) buildPostReplies(ctx request.CTX, postID string, withAttachments b
 			}
 		}
 
+		var appErr *model.AppError
+		replyImportObject.Priority, replyImportObject.Acknowledgements, replyImportObject.EditHistory, appErr = a.buildPostMetadata(ctx, &reply.Post)
+		if appErr != nil {
+			return nil, nil, appErr
+		}
+
 		replies = append(replies, *replyImportObject)
 	}
 
@@ -859,6 +1170,64 @@ func (a *App) BuildPostReactions(ctx request.CTX, postID string) (*[]ReactionImp
 	return &reactionsOfPost, nil
 }
 
+// buildPostMetadata returns the priority, acknowledgements and edit history
+// of a post, or nil for the ones the post doesn't have.
+func (a *App) buildPostMetadata(ctx request.CTX, post *model.Post) (*imports.PostPriorityImportData, *[]imports.PostAcknowledgementImportData, *[]imports.PostEditHistoryImportData, *model.AppError) {
+	var (
+		priority    *imports.PostPriorityImportData
+		acks        *[]imports.PostAcknowledgementImportData
+		editHistory *[]imports.PostEditHistoryImportData
+	)
+
+	postPriority, appErr := a.GetPriorityForPost(post.Id)
+	if appErr != nil {
+		return nil, nil, nil, appErr
+	}
+	if postPriority != nil {
+		priority = importPriorityFromPostPriority(postPriority)
+	}
+
+	if postPriority != nil && postPriority.RequestedAck != nil && *postPriority.RequestedAck {
+		acknowledgements, err := a.Srv().Store().PostAcknowledgement().GetForPost(post.Id)
+		if err != nil {
+			return nil, nil, nil, model.NewAppError("buildPostMetadata", "app.acknowledgement.getforpost.get.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+		}
+		data := make([]imports.PostAcknowledgementImportData, 0, len(acknowledgements))
+		for _, ack := range acknowledgements {
+			user, err := a.Srv().Store().User().Get(context.Background(), ack.UserId)
+			if err != nil {
+				var nfErr *store.ErrNotFound
+				if errors.As(err, &nfErr) {
+					ctx.Logger().Info("Skipping acknowledgement by user since the entity doesn't exist anymore", mlog.String("user_id", ack.UserId))
+					continue
+				}
+				return nil, nil, nil, model.NewAppError("buildPostMetadata", "app.user.get.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+			}
+			data = append(data, *importAcknowledgementFromPostAcknowledgement(user, ack))
+		}
+		if len(data) > 0 {
+			acks = &data
+		}
+	}
+
+	if post.EditAt != 0 {
+		history, err := a.Srv().Store().Post().GetEditHistoryForPost(post.Id)
+		var nfErr *store.ErrNotFound
+		if err != nil && !errors.As(err, &nfErr) {
+			return nil, nil, nil, model.NewAppError("buildPostMetadata", "app.post.get.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+		}
+		if len(history) > 0 {
+			data := make([]imports.PostEditHistoryImportData, 0, len(history))
+			for _, version := range history {
+				data = append(data, *importEditHistoryFromPost(version))
+			}
+			editHistory = &data
+		}
+	}
+
+	return priority, acks, editHistory, nil
+}
+
 func (a *App) buildPostAttachments(postID string) ([]imports.AttachmentImportData, *model.AppError) {
 	infos, nErr := a.Srv().Store().FileInfo().GetForPost(postID, false, false, false)
 	if nErr != nil {
@@ -873,7 +1242,7 @@ func (a *App) buildPostAttachments(postID string) ([]imports.AttachmentImportDat
 	return attachments, nil
 }
 
-func (a *App) exportCustomEmoji(rctx request.CTX, job *model.Job, writer io.Writer, outPath, exportDir string, exportFiles bool) ([]string, *model.AppError) {
+func (a *App) exportCustomEmoji(rctx request.CTX, job *model.Job, writer io.Writer, outPath, exportDir string, exportFiles bool, since int64) ([]string, *model.AppError) {
 	var emojiPaths []string
 	pageNumber := 0
 	cnt := 0
@@ -902,6 +1271,11 @@ func (a *App) exportCustomEmoji(rctx request.CTX, job *model.Job, writer io.Writ
 			}
 
 			for _, emoji := range customEmojiList {
+				// Skip unchanged.
+				if emoji.UpdateAt < since {
+					continue
+				}
+
 				emojiImagePath := filepath.Join(emojiPath, emoji.Id, "image")
 				filePath := filepath.Join(exportDir, emoji.Id, "image")
 				if exportFiles {
@@ -965,7 +1339,7 @@ func (a *App) copyEmojiImages(rctx request.CTX, emojiId string, emojiImagePath s
 	return nil
 }
 
-func (a *App) exportAllDirectChannels(ctx request.CTX, job *model.Job, writer io.Writer, includeArchivedChannels bool) *model.AppError {
+func (a *App) exportAllDirectChannels(ctx request.CTX, job *model.Job, writer io.Writer, includeArchivedChannels bool, since int64) *model.AppError {
 	afterId := strings.Repeat("0", 26)
 	cnt := 0
 	for {
@@ -983,8 +1357,8 @@ func (a *App) exportAllDirectChannels(ctx request.CTX, job *model.Job, writer io
 		for _, channel := range channels {
 			afterId = channel.Id
 
-			// Skip deleted.
-			if channel.DeleteAt != 0 {
+			// Skip deleted and unchanged.
+			if channel.DeleteAt != 0 || channel.UpdateAt < since {
 				continue
 			}
 
@@ -1109,7 +1483,7 @@ func (a *App) buildShownByList(channel *model.DirectChannelForExport) ([]string,
 	return shownBy, nil
 }
 
-func (a *App) exportAllDirectPosts(ctx request.CTX, job *model.Job, writer io.Writer, withAttachments, includeArchivedChannels bool) ([]imports.AttachmentImportData, *model.AppError) {
+func (a *App) exportAllDirectPosts(ctx request.CTX, job *model.Job, writer io.Writer, withAttachments, includeArchivedChannels bool, since int64) ([]imports.AttachmentImportData, *model.AppError) {
 	var attachments []imports.AttachmentImportData
 	afterId := strings.Repeat("0", 26)
 	var postProcessCount uint64
@@ -1122,7 +1496,7 @@ func (a *App) exportAllDirectPosts(ctx request.CTX, job *model.Job, writer io.Wr
 			logCheckpoint = time.Now()
 		}
 
-		posts, err := a.Srv().Store().Post().GetDirectPostParentsForExportAfter(1000, afterId, includeArchivedChannels)
+		posts, err := a.Srv().Store().Post().GetDirectPostParentsForExportAfter(1000, afterId, includeArchivedChannels, since)
 		if err != nil {
 			return nil, model.NewAppError("exportAllDirectPosts", "app.post.get_direct_posts.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
 		}
@@ -1186,6 +1560,11 @@ func (a *App) exportAllDirectPosts(ctx request.CTX, job *model.Job, writer io.Wr
 				postLine.DirectPost.ThreadFollowers = &followers
 			}
 
+			postLine.DirectPost.Priority, postLine.DirectPost.Acknowledgements, postLine.DirectPost.EditHistory, err = a.buildPostMetadata(ctx, &post.Post)
+			if err != nil {
+				return nil, err
+			}
+
 			if err := a.exportWriteLine(writer, postLine); err != nil {
 				return nil, err
 			}
@@ -1194,6 +1573,92 @@ func (a *App) exportAllDirectPosts(ctx request.CTX, job *model.Job, writer io.Wr
 	return attachments, nil
 }
 
+// exportDeletedPosts exports the posts and replies deleted since the given
+// time as tombstones.
+func (a *App) exportDeletedPosts(ctx request.CTX, job *model.Job, writer io.Writer, since int64) *model.AppError {
+	afterId := strings.Repeat("0", 26)
+	channelMembers := map[string][]string{}
+	cnt := 0
+	for {
+		posts, err := a.Srv().Store().Post().GetDeletedForExportAfter(1000, afterId, since)
+		if err != nil {
+			return model.NewAppError("exportDeletedPosts", "app.post.get_posts.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+		}
+
+		if len(posts) == 0 {
+			break
+		}
+		cnt += len(posts)
+		updateJobProgress(ctx.Logger(), a.Srv().Store(), job, "deleted_posts_exported", cnt)
+
+		for _, post := range posts {
+			afterId = post.Id
+
+			// Posts in direct channels have no team and are referenced
+			// by the members of their channel instead.
+			var members []string
+			if post.TeamName == "" {
+				var ok bool
+				if members, ok = channelMembers[post.ChannelId]; !ok {
+					profiles, err := a.Srv().Store().User().GetAllProfilesInChannel(ctx.Context(), post.ChannelId, false)
+					if err != nil {
+						return model.NewAppError("exportDeletedPosts", "app.channel.get_members.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+					}
+					for _, profile := range profiles {
+						members = append(members, profile.Username)
+					}
+					channelMembers[post.ChannelId] = members
+				}
+				if len(members) == 0 {
+					continue
+				}
+			}
+
+			if err := a.exportWriteLine(writer, importLineFromPostTombstone(post, members)); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// exportDeletedEmoji exports the custom emoji deleted since the given time as
+// tombstones, unless an emoji with the same name was created since.
+func (a *App) exportDeletedEmoji(ctx request.CTX, job *model.Job, writer io.Writer, since int64) *model.AppError {
+	offset := 0
+	for {
+		emojis, err := a.Srv().Store().Emoji().GetDeletedSince(since, offset, 100)
+		if err != nil {
+			return model.NewAppError("exportDeletedEmoji", "app.emoji.get_list.internal_error", nil, "", http.StatusInternalServerError).Wrap(err)
+		}
+
+		if len(emojis) == 0 {
+			break
+		}
+		offset += len(emojis)
+		updateJobProgress(ctx.Logger(), a.Srv().Store(), job, "deleted_emojis_exported", offset)
+
+		for _, emoji := range emojis {
+			_, err := a.Srv().Store().Emoji().GetByName(ctx, emoji.Name, false)
+			if err == nil {
+				// Recreated since, so it's exported as an emoji instead.
+				continue
+			}
+			var nfErr *store.ErrNotFound
+			if !errors.As(err, &nfErr) {
+				return model.NewAppError("exportDeletedEmoji", "app.emoji.get_by_name.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+			}
+
+			if err := a.exportWriteLine(writer, importLineFromEmojiTombstone(emoji)); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
 func (a *App) exportFile(rctx request.CTX, outPath, filePath string, zipWr *zip.Writer) *model.AppError {
 	rd, appErr := a.FileReader(filePath)
 	if appErr != nil {
@@ -298,6 +298,29 @@ func importLineForDirectPost(post *model.DirectPostForExport) *imports.LineImpor
 	}
 }
 
+// importLineForDirectPostForExport returns the direct_post line of a post in a
+// direct or group channel given the members of the channel.
+func importLineForDirectPostForExport(post *model.PostForExport, channelMembers []string) *imports.LineImportData {
+	if len(channelMembers) == 1 {
+		channelMembers = []string{channelMembers[0], channelMembers[0]}
+	}
+	f := []string(post.FlaggedBy)
+	return &imports.LineImportData{
+		Type: "direct_post",
+		DirectPost: &imports.DirectPostImportData{
+			ChannelMembers: &channelMembers,
+			User:           &post.Username,
+			Type:           &post.Type,
+			Message:        &post.Message,
+			Props:          &post.Props,
+			CreateAt:       &post.CreateAt,
+			EditAt:         &post.EditAt,
+			IsPinned:       &post.IsPinned,
+			FlaggedBy:      &f,
+		},
+	}
+}
+
 func importReplyFromPost(post *model.ReplyForExport) *imports.ReplyImportData {
 	f := []string(post.FlaggedBy)
 	return &imports.ReplyImportData{
@@ -380,3 +403,134 @@ func importFollowerFromThreadMember(threadMember *model.ThreadMembershipForExpor
 		UnreadMentions: &threadMember.UnreadMentions,
 	}
 }
+
+func importPriorityFromPostPriority(priority *model.PostPriority) *imports.PostPriorityImportData {
+	return &imports.PostPriorityImportData{
+		Priority:                priority.Priority,
+		RequestedAck:            priority.RequestedAck,
+		PersistentNotifications: priority.PersistentNotifications,
+	}
+}
+
+func importAcknowledgementFromPostAcknowledgement(user *model.User, ack *model.PostAcknowledgement) *imports.PostAcknowledgementImportData {
+	return &imports.PostAcknowledgementImportData{
+		User:           &user.Username,
+		AcknowledgedAt: &ack.AcknowledgedAt,
+	}
+}
+
+func importEditHistoryFromPost(post *model.Post) *imports.PostEditHistoryImportData {
+	data := &imports.PostEditHistoryImportData{
+		Message:    &post.Message,
+		ReplacedAt: &post.DeleteAt,
+	}
+	if post.EditAt != 0 {
+		data.EditAt = &post.EditAt
+	}
+	if props := post.GetProps(); len(props) > 0 {
+		data.Props = &props
+	}
+	return data
+}
+
+func importLineFromChannelBookmark(teamName, channelName, ownerUsername string, bookmark *model.ChannelBookmarkWithFileInfo) *imports.LineImportData {
+	data := &imports.ChannelBookmarkImportData{
+		Team:        &teamName,
+		Channel:     &channelName,
+		Owner:       &ownerUsername,
+		Type:        &bookmark.Type,
+		DisplayName: &bookmark.DisplayName,
+		SortOrder:   &bookmark.SortOrder,
+		CreateAt:    &bookmark.CreateAt,
+	}
+	if bookmark.LinkUrl != "" {
+		data.LinkURL = &bookmark.LinkUrl
+	}
+	if bookmark.ImageUrl != "" {
+		data.ImageURL = &bookmark.ImageUrl
+	}
+	if bookmark.Emoji != "" {
+		data.Emoji = &bookmark.Emoji
+	}
+	if bookmark.FileInfo != nil {
+		data.File = &imports.AttachmentImportData{Path: &bookmark.FileInfo.Path}
+	}
+
+	return &imports.LineImportData{
+		Type:     "channel_bookmark",
+		Bookmark: data,
+	}
+}
+
+func importLineFromTeamTombstone(team *model.TeamForExport) *imports.LineImportData {
+	return &imports.LineImportData{
+		Type: "tombstone",
+		Tombstone: &imports.TombstoneImportData{
+			Type:     model.NewPointer("team"),
+			Team:     &team.Name,
+			DeleteAt: &team.DeleteAt,
+		},
+	}
+}
+
+func importLineFromChannelBookmarkTombstone(teamName, channelName string, bookmark *model.ChannelBookmarkWithFileInfo) *imports.LineImportData {
+	return &imports.LineImportData{
+		Type: "tombstone",
+		Tombstone: &imports.TombstoneImportData{
+			Type:        model.NewPointer("channel_bookmark"),
+			Team:        &teamName,
+			Channel:     &channelName,
+			DisplayName: &bookmark.DisplayName,
+			DeleteAt:    &bookmark.DeleteAt,
+		},
+	}
+}
+
+func importLineFromEmojiTombstone(emoji *model.Emoji) *imports.LineImportData {
+	return &imports.LineImportData{
+		Type: "tombstone",
+		Tombstone: &imports.TombstoneImportData{
+			Type:     model.NewPointer("emoji"),
+			Name:     &emoji.Name,
+			DeleteAt: &emoji.DeleteAt,
+		},
+	}
+}
+
+// importLineFromPostTombstone returns the tombstone of a deleted post. Posts
+// in direct channels are referenced by channelMembers rather than by team
+// and channel.
+func importLineFromPostTombstone(post *model.PostForExport, channelMembers []string) *imports.LineImportData {
+	data := &imports.TombstoneImportData{
+		Type:     model.NewPointer("post"),
+		User:     &post.Username,
+		Message:  &post.Message,
+		CreateAt: &post.CreateAt,
+		DeleteAt: &post.DeleteAt,
+	}
+	if post.TeamName == "" {
+		if len(channelMembers) == 1 {
+			channelMembers = []string{channelMembers[0], channelMembers[0]}
+		}
+		data.Type = model.NewPointer("direct_post")
+		data.ChannelMembers = &channelMembers
+	} else {
+		data.Team = &post.TeamName
+		data.Channel = &post.ChannelName
+	}
+
+	return &imports.LineImportData{
+		Type:      "tombstone",
+		Tombstone: data,
+	}
+}
+
+func importSidebarCategoryFromCategory(category *model.SidebarCategoryWithChannels, channelNames []string) *imports.UserSidebarCategoryImportData {
+	return &imports.UserSidebarCategoryImportData{
+		DisplayName: &category.DisplayName,
+		Sorting:     &category.Sorting,
+		Muted:       &category.Muted,
+		Collapsed:   &category.Collapsed,
+		Channels:    &channelNames,
+	}
+}
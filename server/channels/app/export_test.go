@@ -23,6 +23,7 @@ import (
 
 	"github.com/mattermost/mattermost/server/public/model"
 	"github.com/mattermost/mattermost/server/v8/channels/app/imports"
+	"github.com/mattermost/mattermost/server/v8/channels/store"
 	"github.com/mattermost/mattermost/server/v8/channels/utils"
 	"github.com/mattermost/mattermost/server/v8/channels/utils/fileutils"
 )
@@ -192,7 +193,7 @@ func TestExportCustomEmoji(t *testing.T) {
 	outPath, err := filepath.Abs(filePath)
 	require.NoError(t, err)
 
-	_, appErr := th.App.exportCustomEmoji(th.Context, nil, fileWriter, outPath, dirNameToExportEmoji, false)
+	_, appErr := th.App.exportCustomEmoji(th.Context, nil, fileWriter, outPath, dirNameToExportEmoji, false, 0)
 	require.Nil(t, appErr, "should not have failed")
 }
 
@@ -410,7 +411,7 @@ func TestExportDMChannel(t *testing.T) {
 		assert.Equal(t, 1, len(channels))
 
 		// Ensure the posts of the deleted DM channel do not leak to the self-DM channel
-		posts, nErr := th2.App.Srv().Store().Post().GetPosts(model.GetPostsOptions{
+		posts, nErr := th2.App.Srv().Store().Post().GetPosts(th2.Context, model.GetPostsOptions{
 			ChannelId:      channels[0].Id,
 			PerPage:        1000,
 			IncludeDeleted: true,
@@ -584,7 +585,7 @@ func TestExportDMandGMPost(t *testing.T) {
 	_, appErr = th1.App.CreatePost(th1.Context, p4, gmChannel, model.CreatePostFlags{SetOnline: true})
 	require.Nil(t, appErr)
 
-	posts, err := th1.App.Srv().Store().Post().GetDirectPostParentsForExportAfter(1000, "0000000", false)
+	posts, err := th1.App.Srv().Store().Post().GetDirectPostParentsForExportAfter(1000, "0000000", false, 0)
 	require.NoError(t, err)
 	assert.Equal(t, 4, len(posts))
 
@@ -597,7 +598,7 @@ func TestExportDMandGMPost(t *testing.T) {
 	th2 := Setup(t)
 	defer th2.TearDown()
 
-	posts, err = th2.App.Srv().Store().Post().GetDirectPostParentsForExportAfter(1000, "0000000", false)
+	posts, err = th2.App.Srv().Store().Post().GetDirectPostParentsForExportAfter(1000, "0000000", false, 0)
 	require.NoError(t, err)
 	assert.Equal(t, 0, len(posts))
 
@@ -606,7 +607,7 @@ func TestExportDMandGMPost(t *testing.T) {
 	assert.Nil(t, appErr)
 	assert.Equal(t, 0, i)
 
-	posts, err = th2.App.Srv().Store().Post().GetDirectPostParentsForExportAfter(1000, "0000000", false)
+	posts, err = th2.App.Srv().Store().Post().GetDirectPostParentsForExportAfter(1000, "0000000", false, 0)
 	require.NoError(t, err)
 
 	// Adding some determinism so its possible to assert on slice index
@@ -660,7 +661,7 @@ func TestExportPostWithProps(t *testing.T) {
 	_, appErr = th1.App.CreatePost(th1.Context, p2, gmChannel, model.CreatePostFlags{SetOnline: true})
 	require.Nil(t, appErr)
 
-	posts, err := th1.App.Srv().Store().Post().GetDirectPostParentsForExportAfter(1000, "0000000", false)
+	posts, err := th1.App.Srv().Store().Post().GetDirectPostParentsForExportAfter(1000, "0000000", false, 0)
 	require.NoError(t, err)
 	assert.Len(t, posts, 2)
 	require.NotEmpty(t, posts[0].Props)
@@ -675,7 +676,7 @@ func TestExportPostWithProps(t *testing.T) {
 	th2 := Setup(t)
 	defer th2.TearDown()
 
-	posts, err = th2.App.Srv().Store().Post().GetDirectPostParentsForExportAfter(1000, "0000000", false)
+	posts, err = th2.App.Srv().Store().Post().GetDirectPostParentsForExportAfter(1000, "0000000", false, 0)
 	require.NoError(t, err)
 	assert.Len(t, posts, 0)
 
@@ -684,7 +685,7 @@ func TestExportPostWithProps(t *testing.T) {
 	assert.Nil(t, appErr)
 	assert.Equal(t, 0, i)
 
-	posts, err = th2.App.Srv().Store().Post().GetDirectPostParentsForExportAfter(1000, "0000000", false)
+	posts, err = th2.App.Srv().Store().Post().GetDirectPostParentsForExportAfter(1000, "0000000", false, 0)
 	require.NoError(t, err)
 
 	// Adding some determinism so its possible to assert on slice index
@@ -744,7 +745,7 @@ func TestExportDMPostWithSelf(t *testing.T) {
 	err := th1.App.BulkExport(th1.Context, &b, "somePath", nil, model.BulkExportOpts{})
 	require.Nil(t, err)
 
-	posts, nErr := th1.App.Srv().Store().Post().GetDirectPostParentsForExportAfter(1000, "0000000", false)
+	posts, nErr := th1.App.Srv().Store().Post().GetDirectPostParentsForExportAfter(1000, "0000000", false, 0)
 	require.NoError(t, nErr)
 	assert.Equal(t, 1, len(posts))
 
@@ -753,7 +754,7 @@ func TestExportDMPostWithSelf(t *testing.T) {
 	th2 := Setup(t)
 	defer th2.TearDown()
 
-	posts, nErr = th2.App.Srv().Store().Post().GetDirectPostParentsForExportAfter(1000, "0000000", false)
+	posts, nErr = th2.App.Srv().Store().Post().GetDirectPostParentsForExportAfter(1000, "0000000", false, 0)
 	require.NoError(t, nErr)
 	assert.Equal(t, 0, len(posts))
 
@@ -762,7 +763,7 @@ func TestExportDMPostWithSelf(t *testing.T) {
 	assert.Nil(t, err)
 	assert.Equal(t, 0, i)
 
-	posts, nErr = th2.App.Srv().Store().Post().GetDirectPostParentsForExportAfter(1000, "0000000", false)
+	posts, nErr = th2.App.Srv().Store().Post().GetDirectPostParentsForExportAfter(1000, "0000000", false, 0)
 	require.NoError(t, nErr)
 	assert.Equal(t, 1, len(posts))
 	assert.Equal(t, 1, len((*posts[0].ChannelMembers)))
@@ -1739,7 +1740,7 @@ func TestExportDeactivatedUserDMs(t *testing.T) {
 	require.Equal(t, 1, len(channels), "Direct channel should be imported")
 
 	// 9. Verify all posts were imported
-	posts, nErr := th2.App.Srv().Store().Post().GetPosts(model.GetPostsOptions{
+	posts, nErr := th2.App.Srv().Store().Post().GetPosts(th2.Context, model.GetPostsOptions{
 		ChannelId: channels[0].Id,
 		PerPage:   1000,
 	}, false, nil)
@@ -1771,3 +1772,324 @@ func TestExportDeactivatedUserDMs(t *testing.T) {
 	require.True(t, foundThreadedReplyInImport,
 		"Threaded reply from deactivated user should be imported")
 }
+
+func TestExportImportPostMetadataAndBookmarks(t *testing.T) {
+	mainHelper.Parallel(t)
+	th1 := Setup(t).InitBasic()
+
+	// Custom profile attribute fields aren't exported, so they are created on both servers,
+	// where the select options get different ids.
+	createCPAFields := func(t *testing.T, th *TestHelper) (*model.PropertyField, *model.PropertyField) {
+		t.Helper()
+		groupID, err := th.App.CpaGroupID()
+		require.NoError(t, err)
+
+		textField, err := model.NewCPAFieldFromPropertyField(&model.PropertyField{
+			GroupID: groupID,
+			Name:    "Department",
+			Type:    model.PropertyFieldTypeText,
+		})
+		require.NoError(t, err)
+		createdTextField, appErr := th.App.CreateCPAField(textField)
+		require.Nil(t, appErr)
+
+		selectField, err := model.NewCPAFieldFromPropertyField(&model.PropertyField{
+			GroupID: groupID,
+			Name:    "Office",
+			Type:    model.PropertyFieldTypeSelect,
+			Attrs: model.StringInterface{
+				model.PropertyFieldAttributeOptions: []any{
+					map[string]any{"name": "Berlin"},
+					map[string]any{"name": "Lisbon"},
+				},
+			},
+		})
+		require.NoError(t, err)
+		createdSelectField, appErr := th.App.CreateCPAField(selectField)
+		require.Nil(t, appErr)
+
+		return createdTextField, createdSelectField
+	}
+	selectOptionID := func(t *testing.T, field *model.PropertyField, name string) string {
+		t.Helper()
+		for _, option := range field.Attrs[model.PropertyFieldAttributeOptions].(model.PropertyOptions[*model.CustomProfileAttributesSelectOption]) {
+			if option.Name == name {
+				return option.ID
+			}
+		}
+		require.Failf(t, "option not found", "option %s", name)
+		return ""
+	}
+
+	textField, selectField := createCPAFields(t, th1)
+	_, appErr := th1.App.PatchCPAValues(th1.BasicUser.Id, map[string]json.RawMessage{
+		textField.ID:   json.RawMessage(`"Engineering"`),
+		selectField.ID: json.RawMessage(`"` + selectOptionID(t, selectField, "Lisbon") + `"`),
+	}, false)
+	require.Nil(t, appErr)
+
+	post := th1.CreatePost(th1.BasicChannel)
+	_, err := th1.App.Srv().Store().PostPriority().Save(&model.PostPriority{
+		PostId:                  post.Id,
+		ChannelId:               post.ChannelId,
+		Priority:                model.NewPointer(model.PostPriorityImportant),
+		RequestedAck:            model.NewPointer(true),
+		PersistentNotifications: model.NewPointer(false),
+	})
+	require.NoError(t, err)
+	_, err = th1.App.Srv().Store().PostAcknowledgement().SaveWithModel(&model.PostAcknowledgement{
+		UserId:         th1.BasicUser2.Id,
+		PostId:         post.Id,
+		ChannelId:      post.ChannelId,
+		AcknowledgedAt: post.CreateAt + 1,
+	})
+	require.NoError(t, err)
+
+	originalMessage := post.Message
+	edited := post.Clone()
+	edited.Message = "edited " + model.NewId()
+	edited.EditAt = model.GetMillis()
+	_, err = th1.App.Srv().Store().Post().Update(th1.Context, edited, post.Clone())
+	require.NoError(t, err)
+
+	_, err = th1.App.Srv().Store().ChannelBookmark().Save(&model.ChannelBookmark{
+		ChannelId:   th1.BasicChannel.Id,
+		OwnerId:     th1.BasicUser.Id,
+		DisplayName: "Docs",
+		Type:        model.ChannelBookmarkLink,
+		LinkUrl:     "https://example.com/docs",
+	}, true)
+	require.NoError(t, err)
+
+	_, appErr = th1.App.CreateSidebarCategory(th1.Context, th1.BasicUser.Id, th1.BasicTeam.Id, &model.SidebarCategoryWithChannels{
+		SidebarCategory: model.SidebarCategory{
+			UserId:      th1.BasicUser.Id,
+			TeamId:      th1.BasicTeam.Id,
+			DisplayName: "Projects",
+			Type:        model.SidebarCategoryCustom,
+			Sorting:     model.SidebarCategorySortAlphabetical,
+		},
+		Channels: []string{th1.BasicChannel.Id},
+	})
+	require.Nil(t, appErr)
+
+	var b bytes.Buffer
+	appErr = th1.App.BulkExport(th1.Context, &b, "somePath", nil, model.BulkExportOpts{})
+	require.Nil(t, appErr)
+
+	teamName := th1.BasicTeam.Name
+	channelName := th1.BasicChannel.Name
+	username := th1.BasicUser.Username
+	th1.TearDown()
+
+	th2 := Setup(t)
+	defer th2.TearDown()
+
+	textField, selectField = createCPAFields(t, th2)
+
+	i, appErr := th2.App.BulkImport(th2.Context, &b, nil, false, 5)
+	require.Nil(t, appErr)
+	require.Equal(t, 0, i)
+
+	team, err := th2.App.Srv().Store().Team().GetByName(teamName)
+	require.NoError(t, err)
+	channel, err := th2.App.Srv().Store().Channel().GetByName(team.Id, channelName, false)
+	require.NoError(t, err)
+	user, err := th2.App.Srv().Store().User().GetByUsername(username)
+	require.NoError(t, err)
+
+	posts, err := th2.App.Srv().Store().Post().GetPostsCreatedAt(channel.Id, post.CreateAt)
+	require.NoError(t, err)
+	var imported *model.Post
+	for _, p := range posts {
+		if p.Message == edited.Message {
+			imported = p
+		}
+	}
+	require.NotNil(t, imported)
+
+	priority, err := th2.App.Srv().Store().PostPriority().GetForPost(imported.Id)
+	require.NoError(t, err)
+	assert.Equal(t, model.PostPriorityImportant, *priority.Priority)
+	assert.True(t, *priority.RequestedAck)
+
+	acks, err := th2.App.Srv().Store().PostAcknowledgement().GetForPost(imported.Id)
+	require.NoError(t, err)
+	require.Len(t, acks, 1)
+	assert.Equal(t, post.CreateAt+1, acks[0].AcknowledgedAt)
+
+	history, err := th2.App.Srv().Store().Post().GetEditHistoryForPost(imported.Id)
+	require.NoError(t, err)
+	require.Len(t, history, 1)
+	assert.Equal(t, originalMessage, history[0].Message)
+
+	bookmarks, err := th2.App.Srv().Store().ChannelBookmark().GetBookmarksForChannelSince(channel.Id, 0)
+	require.NoError(t, err)
+	require.Len(t, bookmarks, 1)
+	assert.Equal(t, "Docs", bookmarks[0].DisplayName)
+	assert.Equal(t, "https://example.com/docs", bookmarks[0].LinkUrl)
+	assert.Equal(t, user.Id, bookmarks[0].OwnerId)
+
+	values, appErr := th2.App.ListCPAValues(user.Id)
+	require.Nil(t, appErr)
+	valuesByField := make(map[string]string, len(values))
+	for _, value := range values {
+		valuesByField[value.FieldID] = string(value.Value)
+	}
+	assert.Equal(t, map[string]string{
+		textField.ID:   `"Engineering"`,
+		selectField.ID: `"` + selectOptionID(t, selectField, "Lisbon") + `"`,
+	}, valuesByField)
+
+	categories, appErr := th2.App.GetSidebarCategoriesForTeamForUser(th2.Context, user.Id, team.Id)
+	require.Nil(t, appErr)
+	var projects *model.SidebarCategoryWithChannels
+	for _, category := range categories.Categories {
+		if category.Type == model.SidebarCategoryCustom && category.DisplayName == "Projects" {
+			projects = category
+		}
+	}
+	require.NotNil(t, projects)
+	assert.Equal(t, model.SidebarCategorySortAlphabetical, projects.Sorting)
+	assert.Equal(t, []string{channel.Id}, projects.Channels)
+
+	// Importing the same data again must not duplicate anything.
+	var b2 bytes.Buffer
+	appErr = th2.App.BulkExport(th2.Context, &b2, "somePath", nil, model.BulkExportOpts{})
+	require.Nil(t, appErr)
+	i, appErr = th2.App.BulkImport(th2.Context, &b2, nil, false, 5)
+	require.Nil(t, appErr)
+	require.Equal(t, 0, i)
+
+	history, err = th2.App.Srv().Store().Post().GetEditHistoryForPost(imported.Id)
+	require.NoError(t, err)
+	assert.Len(t, history, 1)
+	bookmarks, err = th2.App.Srv().Store().ChannelBookmark().GetBookmarksForChannelSince(channel.Id, 0)
+	require.NoError(t, err)
+	assert.Len(t, bookmarks, 1)
+}
+
+func TestExportIncremental(t *testing.T) {
+	mainHelper.Parallel(t)
+	th1 := Setup(t).InitBasic()
+
+	editedPost := th1.CreatePost(th1.BasicChannel)
+	deletedPost := th1.CreatePost(th1.BasicChannel)
+
+	var full bytes.Buffer
+	appErr := th1.App.BulkExport(th1.Context, &full, "somePath", nil, model.BulkExportOpts{})
+	require.Nil(t, appErr)
+
+	time.Sleep(time.Millisecond)
+	since := model.GetMillis()
+	time.Sleep(time.Millisecond)
+
+	originalMessage := editedPost.Message
+	edited := editedPost.Clone()
+	edited.Message = "edited " + model.NewId()
+	edited.EditAt = model.GetMillis()
+	_, err := th1.App.Srv().Store().Post().Update(th1.Context, edited, editedPost.Clone())
+	require.NoError(t, err)
+
+	_, appErr = th1.App.DeletePost(th1.Context, deletedPost.Id, th1.BasicUser.Id)
+	require.Nil(t, appErr)
+
+	// CreatePost backdates posts by default.
+	newPost := th1.CreatePost(th1.BasicChannel, func(p *model.Post) { p.CreateAt = model.GetMillis() })
+
+	// The emoji images aren't needed as they are only exported for live emoji.
+	deletedEmoji, err := th1.App.Srv().Store().Emoji().Save(&model.Emoji{CreatorId: th1.BasicUser.Id, Name: "deleted" + model.NewId()})
+	require.NoError(t, err)
+	require.Nil(t, th1.App.DeleteEmoji(th1.Context, deletedEmoji))
+
+	var delta bytes.Buffer
+	appErr = th1.App.BulkExport(th1.Context, &delta, "somePath", nil, model.BulkExportOpts{Since: since})
+	require.Nil(t, appErr)
+
+	var deltaLines []imports.LineImportData
+	scanner := bufio.NewScanner(bytes.NewReader(delta.Bytes()))
+	for scanner.Scan() {
+		var line imports.LineImportData
+		require.NoError(t, json.Unmarshal(scanner.Bytes(), &line))
+		deltaLines = append(deltaLines, line)
+	}
+	require.NotEmpty(t, deltaLines)
+	require.Equal(t, since, deltaLines[0].Info.Since)
+
+	var postMessages []string
+	var tombstones []*imports.TombstoneImportData
+	for _, line := range deltaLines {
+		switch line.Type {
+		case "team":
+			assert.Fail(t, "unchanged teams should not be exported")
+		case "post":
+			postMessages = append(postMessages, *line.Post.Message)
+		case "tombstone":
+			tombstones = append(tombstones, line.Tombstone)
+		}
+	}
+	assert.ElementsMatch(t, []string{edited.Message, newPost.Message}, postMessages)
+	require.Len(t, tombstones, 2)
+	assert.Equal(t, "post", *tombstones[0].Type)
+	assert.Equal(t, deletedPost.Message, *tombstones[0].Message)
+	assert.Equal(t, deletedPost.CreateAt, *tombstones[0].CreateAt)
+	assert.Equal(t, "emoji", *tombstones[1].Type)
+	assert.Equal(t, deletedEmoji.Name, *tombstones[1].Name)
+
+	teamName := th1.BasicTeam.Name
+	channelName := th1.BasicChannel.Name
+	th1.TearDown()
+
+	th2 := Setup(t)
+	defer th2.TearDown()
+
+	i, appErr := th2.App.BulkImport(th2.Context, &full, nil, false, 5)
+	require.Nil(t, appErr)
+	require.Equal(t, 0, i)
+
+	// As if imported from an earlier export.
+	_, err = th2.App.Srv().Store().Emoji().Save(&model.Emoji{CreatorId: model.NewId(), Name: deletedEmoji.Name})
+	require.NoError(t, err)
+
+	i, appErr = th2.App.BulkImport(th2.Context, &delta, nil, false, 5)
+	require.Nil(t, appErr)
+	require.Equal(t, 0, i)
+
+	team, err := th2.App.Srv().Store().Team().GetByName(teamName)
+	require.NoError(t, err)
+	channel, err := th2.App.Srv().Store().Channel().GetByName(team.Id, channelName, false)
+	require.NoError(t, err)
+
+	posts, err := th2.App.Srv().Store().Post().GetPostsCreatedAt(channel.Id, editedPost.CreateAt)
+	require.NoError(t, err)
+	var current []*model.Post
+	for _, p := range posts {
+		if p.DeleteAt == 0 {
+			current = append(current, p)
+		}
+	}
+	require.Len(t, current, 1, "the edited post should be updated rather than duplicated")
+	assert.Equal(t, edited.Message, current[0].Message)
+
+	history, err := th2.App.Srv().Store().Post().GetEditHistoryForPost(current[0].Id)
+	require.NoError(t, err)
+	require.Len(t, history, 1)
+	assert.Equal(t, originalMessage, history[0].Message)
+
+	posts, err = th2.App.Srv().Store().Post().GetPostsCreatedAt(channel.Id, deletedPost.CreateAt)
+	require.NoError(t, err)
+	for _, p := range posts {
+		if p.Message == deletedPost.Message {
+			assert.NotZero(t, p.DeleteAt, "the deleted post should be deleted by its tombstone")
+		}
+	}
+
+	posts, err = th2.App.Srv().Store().Post().GetPostsCreatedAt(channel.Id, newPost.CreateAt)
+	require.NoError(t, err)
+	require.Len(t, posts, 1)
+	assert.Equal(t, newPost.Message, posts[0].Message)
+
+	_, err = th2.App.Srv().Store().Emoji().GetByName(th2.Context, deletedEmoji.Name, false)
+	var nfErr *store.ErrNotFound
+	assert.ErrorAs(t, err, &nfErr, "the deleted emoji should be deleted by its tombstone")
+}
@@ -1485,6 +1485,18 @@ func (a *App) SearchFilesInTeamForUser(c request.CTX, terms string, userId strin
 		}
 	}
 
+	finalParamsList, appErr := a.runSearchWillBeExecutedHook(c, &model.PluginSearch{
+		Type:    model.PluginSearchTypeFiles,
+		UserId:  userId,
+		TeamId:  teamId,
+		Params:  finalParamsList,
+		Page:    page,
+		PerPage: perPage,
+	})
+	if appErr != nil {
+		return nil, appErr
+	}
+
 	// If the processed search params are empty, return empty search results.
 	if len(finalParamsList) == 0 {
 		return model.NewFileInfoList(), nil
@@ -127,6 +127,14 @@ func processAttachments(c request.CTX, line *imports.LineImportData, basePath st
 				}
 			}
 		}
+	case "channel_bookmark":
+		if line.Bookmark.File != nil {
+			files := []imports.AttachmentImportData{*line.Bookmark.File}
+			if err := processAttachmentPaths(c, &files, basePath, filesMap); err != nil {
+				return err
+			}
+			*line.Bookmark.File = files[0]
+		}
 	case "emoji":
 		if line.Emoji.Image != nil {
 			path, valid := imports.ValidateAttachmentPathForImport(*line.Emoji.Image, basePath)
@@ -146,7 +154,8 @@ func processAttachments(c request.CTX, line *imports.LineImportData, basePath st
 	return nil
 }
 
-func (a *App) bulkImportWorker(c request.CTX, dryRun, extractContent bool, wg *sync.WaitGroup, lines <-chan imports.LineImportWorkerData, errors chan<- imports.LineImportWorkerError) {
+func (a *App) bulkImportWorker(c request.CTX, session *importSession, wg *sync.WaitGroup, lines <-chan imports.LineImportWorkerData, errors chan<- imports.LineImportWorkerError) {
+	dryRun, extractContent := session.opts.DryRun, session.opts.ExtractContent
 	workerID := model.NewId()
 	processedLines := uint64(0)
 
@@ -164,6 +173,10 @@ func (a *App) bulkImportWorker(c request.CTX, dryRun, extractContent bool, wg *s
 			postLines = append(postLines, line)
 			if line.Post == nil {
 				errors <- imports.LineImportWorkerError{Error: model.NewAppError("BulkImport", "app.import.import_line.null_post.error", nil, "", http.StatusBadRequest), LineNumber: line.LineNumber}
+			} else if dryRun {
+				if err := a.planImportPost(session, line.Post); err != nil {
+					errors <- imports.LineImportWorkerError{Error: err, LineNumber: line.LineNumber}
+				}
 			}
 			if len(postLines) >= importMultiplePostsThreshold {
 				if errLine, err := a.importMultiplePostLines(c, postLines, dryRun, extractContent); err != nil {
@@ -175,6 +188,10 @@ func (a *App) bulkImportWorker(c request.CTX, dryRun, extractContent bool, wg *s
 			directPostLines = append(directPostLines, line)
 			if line.DirectPost == nil {
 				errors <- imports.LineImportWorkerError{Error: model.NewAppError("BulkImport", "app.import.import_line.null_direct_post.error", nil, "", http.StatusBadRequest), LineNumber: line.LineNumber}
+			} else if dryRun {
+				if err := a.planImportDirectPost(session, line.DirectPost); err != nil {
+					errors <- imports.LineImportWorkerError{Error: err, LineNumber: line.LineNumber}
+				}
 			}
 			if len(directPostLines) >= importMultiplePostsThreshold {
 				if errLine, err := a.importMultipleDirectPostLines(c, directPostLines, dryRun, extractContent); err != nil {
@@ -183,8 +200,12 @@ func (a *App) bulkImportWorker(c request.CTX, dryRun, extractContent bool, wg *s
 				directPostLines = []imports.LineImportWorkerData{}
 			}
 		default:
-			if err := a.importLine(c, line.LineImportData, dryRun); err != nil {
+			if ok, err := a.resolveImportConflict(c, session, &line.LineImportData); err != nil {
 				errors <- imports.LineImportWorkerError{Error: err, LineNumber: line.LineNumber}
+			} else if ok {
+				if err := a.importLine(c, line.LineImportData, dryRun); err != nil {
+					errors <- imports.LineImportWorkerError{Error: err, LineNumber: line.LineNumber}
+				}
 			}
 		}
 
@@ -207,18 +228,43 @@ func (a *App) bulkImportWorker(c request.CTX, dryRun, extractContent bool, wg *s
 }
 
 func (a *App) BulkImport(c request.CTX, jsonlReader io.Reader, attachmentsReader *zip.Reader, dryRun bool, workers int) (int, *model.AppError) {
-	return a.bulkImport(c, jsonlReader, attachmentsReader, dryRun, true, workers, "")
+	_, lineNumber, err := a.bulkImport(c, jsonlReader, attachmentsReader, imports.BulkImportOpts{
+		DryRun:         dryRun,
+		ExtractContent: true,
+		Workers:        workers,
+	})
+	return lineNumber, err
 }
 
 func (a *App) BulkImportWithPath(c request.CTX, jsonlReader io.Reader, attachmentsReader *zip.Reader, dryRun, extractContent bool, workers int, importPath string) (int, *model.AppError) {
-	return a.bulkImport(c, jsonlReader, attachmentsReader, dryRun, extractContent, workers, importPath)
+	_, lineNumber, err := a.bulkImport(c, jsonlReader, attachmentsReader, imports.BulkImportOpts{
+		DryRun:         dryRun,
+		ExtractContent: extractContent,
+		Workers:        workers,
+		ImportPath:     importPath,
+	})
+	return lineNumber, err
+}
+
+// BulkImportWithOpts imports the lines of jsonlReader and returns what the
+// import did, or would do in a dry run, per entity type. The report is
+// returned even if the import stopped on an error.
+func (a *App) BulkImportWithOpts(c request.CTX, jsonlReader io.Reader, attachmentsReader *zip.Reader, opts imports.BulkImportOpts) (*imports.ImportReport, int, *model.AppError) {
+	if opts.ConflictPolicy != "" && !opts.ConflictPolicy.IsValid() {
+		return nil, 0, model.NewAppError("BulkImport", "app.import.bulk_import.invalid_conflict_policy.error", map[string]any{"Policy": opts.ConflictPolicy}, "", http.StatusBadRequest)
+	}
+
+	return a.bulkImport(c, jsonlReader, attachmentsReader, opts)
 }
 
 // bulkImport will extract attachments from attachmentsReader if it is
 // not nil. If it is nil, it will look for attachments on the
 // filesystem in the locations specified by the JSONL file according
 // to the older behavior
-func (a *App) bulkImport(c request.CTX, jsonlReader io.Reader, attachmentsReader *zip.Reader, dryRun, extractContent bool, workers int, importPath string) (int, *model.AppError) {
+func (a *App) bulkImport(c request.CTX, jsonlReader io.Reader, attachmentsReader *zip.Reader, opts imports.BulkImportOpts) (*imports.ImportReport, int, *model.AppError) {
+	session := newImportSession(opts)
+	workers := opts.Workers
+
 	scanner := bufio.NewScanner(jsonlReader)
 	buf := make([]byte, 0, 64*1024)
 	scanner.Buffer(buf, maxScanTokenSize)
@@ -249,21 +295,21 @@ func (a *App) bulkImport(c request.CTX, jsonlReader io.Reader, attachmentsReader
 
 		var line imports.LineImportData
 		if err := json.Unmarshal(scanner.Bytes(), &line); err != nil {
-			return lineNumber, model.NewAppError("BulkImport", "app.import.bulk_import.json_decode.error", nil, "", http.StatusBadRequest).Wrap(err)
+			return session.report, lineNumber, model.NewAppError("BulkImport", "app.import.bulk_import.json_decode.error", nil, "", http.StatusBadRequest).Wrap(err)
 		}
 
-		if err := processAttachments(c, &line, importPath, attachedFiles); err != nil {
+		if err := processAttachments(c, &line, opts.ImportPath, attachedFiles); err != nil {
 			c.Logger().Warn("Error while processing import attachments. Objects might be broken.", mlog.Err(err))
 		}
 
 		if lineNumber == 1 {
 			importDataFileVersion, appErr := processImportDataFileVersionLine(line)
 			if appErr != nil {
-				return lineNumber, appErr
+				return session.report, lineNumber, appErr
 			}
 
 			if importDataFileVersion != 1 {
-				return lineNumber, model.NewAppError("BulkImport", "app.import.bulk_import.unsupported_version.error", nil, "", http.StatusBadRequest)
+				return session.report, lineNumber, model.NewAppError("BulkImport", "app.import.bulk_import.unsupported_version.error", nil, "", http.StatusBadRequest)
 			}
 			lastLineType = line.Type
 			continue
@@ -286,7 +332,7 @@ func (a *App) bulkImport(c request.CTX, jsonlReader io.Reader, attachmentsReader
 				if len(errorsChan) != 0 {
 					err := <-errorsChan
 					if stopOnError(c, err) {
-						return err.LineNumber, err.Error
+						return session.report, err.LineNumber, err.Error
 					}
 				}
 			}
@@ -303,17 +349,21 @@ func (a *App) bulkImport(c request.CTX, jsonlReader io.Reader, attachmentsReader
 			linesChan = make(chan imports.LineImportWorkerData, workers)
 			for i := 0; i < workers; i++ {
 				wg.Add(1)
-				go a.bulkImportWorker(c, dryRun, extractContent, &wg, linesChan, errorsChan)
+				go a.bulkImportWorker(c, session, &wg, linesChan, errorsChan)
 			}
 		}
 
+		// The lines referencing renamed entities are rewritten once the
+		// lines of the previous segments are imported.
+		session.renames.Apply(&line)
+
 		select {
 		case linesChan <- imports.LineImportWorkerData{LineImportData: line, LineNumber: lineNumber}:
 		case err := <-errorsChan:
 			if stopOnError(c, err) {
 				close(linesChan)
 				wg.Wait()
-				return err.LineNumber, err.Error
+				return session.report, err.LineNumber, err.Error
 			}
 		}
 	}
@@ -328,15 +378,15 @@ func (a *App) bulkImport(c request.CTX, jsonlReader io.Reader, attachmentsReader
 	if len(errorsChan) != 0 {
 		err := <-errorsChan
 		if stopOnError(c, err) {
-			return err.LineNumber, err.Error
+			return session.report, err.LineNumber, err.Error
 		}
 	}
 
 	if err := scanner.Err(); err != nil {
-		return 0, model.NewAppError("BulkImport", "app.import.bulk_import.file_scan.error", nil, "", http.StatusInternalServerError).Wrap(err)
+		return session.report, 0, model.NewAppError("BulkImport", "app.import.bulk_import.file_scan.error", nil, "", http.StatusInternalServerError).Wrap(err)
 	}
 
-	return 0, nil
+	return session.report, 0, nil
 }
 
 func processImportDataFileVersionLine(line imports.LineImportData) (int, *model.AppError) {
@@ -389,6 +439,16 @@ func (a *App) importLine(c request.CTX, line imports.LineImportData, dryRun bool
 			return model.NewAppError("BulkImport", "app.import.import_line.null_emoji.error", nil, "", http.StatusBadRequest)
 		}
 		return a.importEmoji(c, line.Emoji, dryRun)
+	case line.Type == "channel_bookmark":
+		if line.Bookmark == nil {
+			return model.NewAppError("BulkImport", "app.import.import_line.null_channel_bookmark.error", nil, "", http.StatusBadRequest)
+		}
+		return a.importChannelBookmark(c, line.Bookmark, dryRun)
+	case line.Type == "tombstone":
+		if line.Tombstone == nil {
+			return model.NewAppError("BulkImport", "app.import.import_line.null_tombstone.error", nil, "", http.StatusBadRequest)
+		}
+		return a.importTombstone(c, line.Tombstone, dryRun)
 	default:
 		return model.NewAppError("BulkImport", "app.import.import_line.unknown_line_type.error", map[string]any{"Type": line.Type}, "", http.StatusBadRequest)
 	}
@@ -0,0 +1,554 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package app
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/public/shared/request"
+	"github.com/mattermost/mattermost/server/v8/channels/app/imports"
+	"github.com/mattermost/mattermost/server/v8/channels/store"
+)
+
+// importSession holds the options of a bulk import and what it did, shared
+// by the workers of the import.
+type importSession struct {
+	opts    imports.BulkImportOpts
+	report  *imports.ImportReport
+	renames *imports.ImportRenames
+
+	// planned holds the entities a dry run would have created, so that
+	// the lines referencing them are reported as a real import would do
+	// them.
+	planned sync.Map
+	// claimed holds the names handed out to renamed entities, so that two
+	// lines are never renamed to the same name.
+	claimed sync.Map
+	// skipped holds the existing teams and channels left untouched by the
+	// skip policy, so that the user lines don't add memberships to them.
+	skipped sync.Map
+}
+
+func newImportSession(opts imports.BulkImportOpts) *importSession {
+	if opts.ConflictPolicy == "" {
+		opts.ConflictPolicy = imports.ConflictPolicyOverwrite
+	}
+
+	return &importSession{
+		opts:    opts,
+		report:  imports.NewImportReport(opts.DryRun, opts.ConflictPolicy),
+		renames: imports.NewImportRenames(),
+	}
+}
+
+func (s *importSession) plan(key string) {
+	if s.opts.DryRun {
+		s.planned.Store(key, true)
+	}
+}
+
+func (s *importSession) isPlanned(key string) bool {
+	_, ok := s.planned.Load(key)
+	return ok
+}
+
+func (s *importSession) skip(key string) {
+	s.skipped.Store(key, true)
+}
+
+func (s *importSession) isSkipped(key string) bool {
+	_, ok := s.skipped.Load(key)
+	return ok
+}
+
+func teamImportKey(team string) string {
+	return "team:" + strings.ToLower(team)
+}
+
+func channelImportKey(team, channel string) string {
+	return "channel:" + strings.ToLower(team) + "/" + strings.ToLower(channel)
+}
+
+func userImportKey(username string) string {
+	return "user:" + strings.ToLower(username)
+}
+
+func emojiImportKey(name string) string {
+	return "emoji:" + name
+}
+
+func importLookupError(err error) *model.AppError {
+	return model.NewAppError("BulkImport", "app.import.resolve_conflict.lookup.error", nil, "", http.StatusInternalServerError).Wrap(err)
+}
+
+// isNotFound tells missing entities apart from lookup failures.
+func isNotFound(err error) bool {
+	var nfErr *store.ErrNotFound
+	return errors.As(err, &nfErr)
+}
+
+// freeImportName returns the first name derived from name that is neither
+// taken on the server nor handed out to another renamed entity.
+func (s *importSession) freeImportName(scope, name string, maxLen int, taken func(string) (bool, *model.AppError)) (string, *model.AppError) {
+	for i := 2; ; i++ {
+		suffix := "-" + strconv.Itoa(i)
+		base := name
+		if len(base)+len(suffix) > maxLen {
+			base = base[:maxLen-len(suffix)]
+		}
+		candidate := base + suffix
+
+		if _, claimed := s.claimed.LoadOrStore(scope+candidate, true); claimed {
+			continue
+		}
+		isTaken, err := taken(candidate)
+		if err != nil {
+			return "", err
+		}
+		if !isTaken && !s.isPlanned(scope+candidate) {
+			return candidate, nil
+		}
+	}
+}
+
+// resolveImportConflict records what a line does to the entity it describes
+// and applies the conflict policy to the teams, channels, users, bots and
+// custom emoji that already exist. It returns false when the line must not be imported.
+// Invalid lines are left for the import functions to report.
+func (a *App) resolveImportConflict(rctx request.CTX, s *importSession, line *imports.LineImportData) (bool, *model.AppError) {
+	switch {
+	case line.Type == "team" && line.Team != nil:
+		if imports.ValidateTeamImportData(line.Team) != nil {
+			return true, nil
+		}
+		return a.resolveTeamConflict(s, line.Team)
+	case line.Type == "channel" && line.Channel != nil:
+		if imports.ValidateChannelImportData(line.Channel) != nil {
+			return true, nil
+		}
+		return a.resolveChannelConflict(s, line.Channel)
+	case line.Type == "user" && line.User != nil:
+		if imports.ValidateUserImportData(line.User) != nil {
+			return true, nil
+		}
+		return a.resolveUserConflict(rctx, s, line.User)
+	case line.Type == "bot" && line.Bot != nil:
+		if imports.ValidateBotImportData(line.Bot) != nil {
+			return true, nil
+		}
+		return a.resolveBotConflict(s, line.Bot)
+	case line.Type == "emoji" && line.Emoji != nil:
+		if imports.ValidateEmojiImportData(line.Emoji) != nil {
+			return true, nil
+		}
+		return a.resolveEmojiConflict(rctx, s, line.Emoji)
+	}
+
+	return true, nil
+}
+
+func (a *App) teamExists(s *importSession, name string) (bool, *model.AppError) {
+	if s.isPlanned(teamImportKey(name)) {
+		return true, nil
+	}
+	if _, err := a.Srv().Store().Team().GetByName(strings.ToLower(name)); err != nil {
+		if isNotFound(err) {
+			return false, nil
+		}
+		return false, importLookupError(err)
+	}
+	return true, nil
+}
+
+func (a *App) resolveTeamConflict(s *importSession, data *imports.TeamImportData) (bool, *model.AppError) {
+	name := strings.ToLower(*data.Name)
+	exists, err := a.teamExists(s, name)
+	if err != nil {
+		return false, err
+	}
+
+	if !exists {
+		s.report.Record("team", imports.ImportActionCreate)
+		s.plan(teamImportKey(name))
+		return true, nil
+	}
+
+	switch s.opts.ConflictPolicy {
+	case imports.ConflictPolicySkip:
+		s.report.Record("team", imports.ImportActionSkip)
+		s.skip(teamImportKey(name))
+		return false, nil
+	case imports.ConflictPolicyMerge:
+		// The memberships of the team come with the users.
+		s.report.Record("team", imports.ImportActionMerge)
+		return false, nil
+	case imports.ConflictPolicyRename:
+		newName, err := s.freeImportName("team:", name, model.TeamNameMaxLength, func(candidate string) (bool, *model.AppError) {
+			return a.teamExists(s, candidate)
+		})
+		if err != nil {
+			return false, err
+		}
+		data.Name = model.NewPointer(newName)
+		s.renames.RenameTeam(name, newName)
+		s.report.RecordRename(imports.ImportRename{Entity: "team", From: name, To: newName})
+		s.plan(teamImportKey(newName))
+		return true, nil
+	default:
+		s.report.Record("team", imports.ImportActionUpdate)
+		return true, nil
+	}
+}
+
+func (a *App) channelExists(s *importSession, team, name string) (bool, *model.AppError) {
+	if s.isPlanned(channelImportKey(team, name)) {
+		return true, nil
+	}
+	if s.isPlanned(teamImportKey(team)) {
+		return false, nil
+	}
+
+	t, err := a.Srv().Store().Team().GetByName(strings.ToLower(team))
+	if err != nil {
+		if isNotFound(err) {
+			return false, nil
+		}
+		return false, importLookupError(err)
+	}
+	if _, err := a.Srv().Store().Channel().GetByNameIncludeDeleted(t.Id, strings.ToLower(name), true); err != nil {
+		if isNotFound(err) {
+			return false, nil
+		}
+		return false, importLookupError(err)
+	}
+	return true, nil
+}
+
+func (a *App) resolveChannelConflict(s *importSession, data *imports.ChannelImportData) (bool, *model.AppError) {
+	team := strings.ToLower(*data.Team)
+	name := strings.ToLower(*data.Name)
+
+	// Skipped teams are left untouched, including their channels.
+	if s.isSkipped(teamImportKey(team)) {
+		s.report.Record("channel", imports.ImportActionSkip)
+		s.skip(channelImportKey(team, name))
+		return false, nil
+	}
+
+	exists, err := a.channelExists(s, team, name)
+	if err != nil {
+		return false, err
+	}
+
+	if !exists {
+		s.report.Record("channel", imports.ImportActionCreate)
+		s.plan(channelImportKey(team, name))
+		return true, nil
+	}
+
+	switch s.opts.ConflictPolicy {
+	case imports.ConflictPolicySkip:
+		s.report.Record("channel", imports.ImportActionSkip)
+		s.skip(channelImportKey(team, name))
+		return false, nil
+	case imports.ConflictPolicyMerge:
+		// The memberships of the channel come with the users.
+		s.report.Record("channel", imports.ImportActionMerge)
+		return false, nil
+	case imports.ConflictPolicyRename:
+		newName, err := s.freeImportName("channel:"+team+"/", name, model.ChannelNameMaxLength, func(candidate string) (bool, *model.AppError) {
+			return a.channelExists(s, team, candidate)
+		})
+		if err != nil {
+			return false, err
+		}
+		data.Name = model.NewPointer(newName)
+		s.renames.RenameChannel(team, name, newName)
+		s.report.RecordRename(imports.ImportRename{Entity: "channel", Team: team, From: name, To: newName})
+		s.plan(channelImportKey(team, newName))
+		return true, nil
+	default:
+		s.report.Record("channel", imports.ImportActionUpdate)
+		return true, nil
+	}
+}
+
+func (a *App) usernameTaken(s *importSession, username string) (bool, *model.AppError) {
+	if s.isPlanned(userImportKey(username)) {
+		return true, nil
+	}
+	if _, err := a.Srv().Store().User().GetByUsername(strings.ToLower(username)); err != nil {
+		if isNotFound(err) {
+			return false, nil
+		}
+		return false, importLookupError(err)
+	}
+	return true, nil
+}
+
+// renameImportedUser gives a user or bot whose username is taken a free
+// username, for the other lines of the import file to reference.
+func (a *App) renameImportedUser(s *importSession, entity string, username *string) *model.AppError {
+	name := strings.ToLower(*username)
+	newName, err := s.freeImportName("user:", name, model.UserNameMaxLength, func(candidate string) (bool, *model.AppError) {
+		return a.usernameTaken(s, candidate)
+	})
+	if err != nil {
+		return err
+	}
+
+	*username = newName
+	s.renames.RenameUser(name, newName)
+	s.report.RecordRename(imports.ImportRename{Entity: entity, From: name, To: newName})
+	s.plan(userImportKey(newName))
+	return nil
+}
+
+// dropSkippedMemberships removes the memberships of the teams and channels
+// skipped by the import from the teams of a user line.
+func (s *importSession) dropSkippedMemberships(teams *[]imports.UserTeamImportData) {
+	if teams == nil {
+		return
+	}
+
+	kept := (*teams)[:0]
+	for _, team := range *teams {
+		if team.Name == nil {
+			kept = append(kept, team)
+			continue
+		}
+		if s.isSkipped(teamImportKey(*team.Name)) {
+			continue
+		}
+		if team.Channels != nil {
+			channels := (*team.Channels)[:0]
+			for _, channel := range *team.Channels {
+				if channel.Name == nil || !s.isSkipped(channelImportKey(*team.Name, *channel.Name)) {
+					channels = append(channels, channel)
+				}
+			}
+			team.Channels = &channels
+		}
+		if team.Categories != nil {
+			for i := range *team.Categories {
+				category := &(*team.Categories)[i]
+				if category.Channels == nil {
+					continue
+				}
+				channels := (*category.Channels)[:0]
+				for _, channel := range *category.Channels {
+					if !s.isSkipped(channelImportKey(*team.Name, channel)) {
+						channels = append(channels, channel)
+					}
+				}
+				category.Channels = &channels
+			}
+		}
+		kept = append(kept, team)
+	}
+	*teams = kept
+}
+
+func (a *App) resolveUserConflict(rctx request.CTX, s *importSession, data *imports.UserImportData) (bool, *model.AppError) {
+	s.dropSkippedMemberships(data.Teams)
+
+	user, err := a.Srv().Store().User().GetByUsername(strings.ToLower(*data.Username))
+	if err != nil && !isNotFound(err) {
+		return false, importLookupError(err)
+	}
+
+	if user == nil {
+		if s.isPlanned(userImportKey(*data.Username)) {
+			s.report.Record("user", imports.ImportActionUpdate)
+		} else {
+			s.report.Record("user", imports.ImportActionCreate)
+			s.plan(userImportKey(*data.Username))
+		}
+		return true, nil
+	}
+
+	switch s.opts.ConflictPolicy {
+	case imports.ConflictPolicySkip:
+		s.report.Record("user", imports.ImportActionSkip)
+		return false, nil
+	case imports.ConflictPolicyMerge:
+		s.report.Record("user", imports.ImportActionMerge)
+		if s.opts.DryRun || data.Teams == nil {
+			return false, nil
+		}
+		// The user is left as is, only the memberships are added.
+		return false, a.importUserTeams(rctx, user, data.Teams)
+	case imports.ConflictPolicyRename:
+		// A user of the same email is the same person, and can't be
+		// imported a second time under another username.
+		if _, err := a.Srv().Store().User().GetByEmail(strings.ToLower(*data.Email)); err == nil {
+			return false, model.NewAppError("BulkImport", "app.import.resolve_conflict.rename_email_taken.error", map[string]any{"Username": *data.Username}, "", http.StatusBadRequest)
+		} else if !isNotFound(err) {
+			return false, importLookupError(err)
+		}
+		if err := a.renameImportedUser(s, "user", data.Username); err != nil {
+			return false, err
+		}
+		return true, nil
+	default:
+		s.report.Record("user", imports.ImportActionUpdate)
+		return true, nil
+	}
+}
+
+func (a *App) resolveBotConflict(s *importSession, data *imports.BotImportData) (bool, *model.AppError) {
+	taken, err := a.usernameTaken(s, *data.Username)
+	if err != nil {
+		return false, err
+	}
+
+	if !taken {
+		s.report.Record("bot", imports.ImportActionCreate)
+		s.plan(userImportKey(*data.Username))
+		return true, nil
+	}
+
+	switch s.opts.ConflictPolicy {
+	case imports.ConflictPolicySkip, imports.ConflictPolicyMerge:
+		// Bots have no memberships to merge.
+		s.report.Record("bot", imports.ImportActionSkip)
+		return false, nil
+	case imports.ConflictPolicyRename:
+		if err := a.renameImportedUser(s, "bot", data.Username); err != nil {
+			return false, err
+		}
+		return true, nil
+	default:
+		s.report.Record("bot", imports.ImportActionUpdate)
+		return true, nil
+	}
+}
+
+func (a *App) emojiExists(rctx request.CTX, s *importSession, name string) (bool, *model.AppError) {
+	if s.isPlanned(emojiImportKey(name)) {
+		return true, nil
+	}
+	if _, err := a.Srv().Store().Emoji().GetByName(rctx, name, false); err != nil {
+		if isNotFound(err) {
+			return false, nil
+		}
+		return false, importLookupError(err)
+	}
+	return true, nil
+}
+
+func (a *App) resolveEmojiConflict(rctx request.CTX, s *importSession, data *imports.EmojiImportData) (bool, *model.AppError) {
+	exists, err := a.emojiExists(rctx, s, *data.Name)
+	if err != nil {
+		return false, err
+	}
+
+	if !exists {
+		s.report.Record("emoji", imports.ImportActionCreate)
+		s.plan(emojiImportKey(*data.Name))
+		return true, nil
+	}
+
+	switch s.opts.ConflictPolicy {
+	case imports.ConflictPolicySkip, imports.ConflictPolicyMerge:
+		// Emoji have no memberships to merge.
+		s.report.Record("emoji", imports.ImportActionSkip)
+		return false, nil
+	case imports.ConflictPolicyRename:
+		name := *data.Name
+		newName, err := s.freeImportName("emoji:", name, model.EmojiNameMaxLength, func(candidate string) (bool, *model.AppError) {
+			return a.emojiExists(rctx, s, candidate)
+		})
+		if err != nil {
+			return false, err
+		}
+		data.Name = model.NewPointer(newName)
+		s.report.RecordRename(imports.ImportRename{Entity: "emoji", From: name, To: newName})
+		s.plan(emojiImportKey(newName))
+		return true, nil
+	default:
+		s.report.Record("emoji", imports.ImportActionUpdate)
+		return true, nil
+	}
+}
+
+// planImportPost records whether a post line of a dry run would create a
+// post or update an already imported one.
+func (a *App) planImportPost(s *importSession, data *imports.PostImportData) *model.AppError {
+	if data == nil || data.Team == nil || data.Channel == nil || data.CreateAt == nil || data.Message == nil {
+		return nil
+	}
+	if s.isPlanned(channelImportKey(*data.Team, *data.Channel)) || s.isPlanned(teamImportKey(*data.Team)) {
+		s.report.Record("post", imports.ImportActionCreate)
+		return nil
+	}
+
+	team, err := a.Srv().Store().Team().GetByName(strings.ToLower(*data.Team))
+	if err != nil && !isNotFound(err) {
+		return importLookupError(err)
+	}
+	var channel *model.Channel
+	if team != nil {
+		channel, err = a.Srv().Store().Channel().GetByName(team.Id, strings.ToLower(*data.Channel), true)
+		if err != nil && !isNotFound(err) {
+			return importLookupError(err)
+		}
+	}
+
+	return a.planImportPostInChannel(s, "post", channel, data.CreateAt, data.Message, data.EditHistory)
+}
+
+// planImportDirectPost is planImportPost for direct posts.
+func (a *App) planImportDirectPost(s *importSession, data *imports.DirectPostImportData) *model.AppError {
+	if data == nil || data.ChannelMembers == nil || data.CreateAt == nil || data.Message == nil {
+		return nil
+	}
+
+	users, err := a.Srv().Store().User().GetProfilesByUsernames(*data.ChannelMembers, nil)
+	if err != nil {
+		return importLookupError(err)
+	}
+	if len(users) != len(*data.ChannelMembers) {
+		s.report.Record("direct_post", imports.ImportActionCreate)
+		return nil
+	}
+
+	userIDs := make([]string, 0, len(users))
+	for _, user := range users {
+		userIDs = append(userIDs, user.Id)
+	}
+	channelName := model.GetGroupNameFromUserIds(userIDs)
+	if len(userIDs) == 2 {
+		channelName = model.GetDMNameFromIds(userIDs[0], userIDs[1])
+	}
+	channel, nErr := a.Srv().Store().Channel().GetByName("", channelName, true)
+	if nErr != nil && !isNotFound(nErr) {
+		return importLookupError(nErr)
+	}
+
+	return a.planImportPostInChannel(s, "direct_post", channel, data.CreateAt, data.Message, data.EditHistory)
+}
+
+func (a *App) planImportPostInChannel(s *importSession, entity string, channel *model.Channel, createAt *int64, message *string, editHistory *[]imports.PostEditHistoryImportData) *model.AppError {
+	if channel == nil {
+		s.report.Record(entity, imports.ImportActionCreate)
+		return nil
+	}
+
+	posts, err := a.Srv().Store().Post().GetPostsCreatedAt(channel.Id, *createAt)
+	if err != nil {
+		return importLookupError(err)
+	}
+	if matchImportedPost(posts, *message, editHistory) != nil {
+		s.report.Record(entity, imports.ImportActionUpdate)
+	} else {
+		s.report.Record(entity, imports.ImportActionCreate)
+	}
+	return nil
+}
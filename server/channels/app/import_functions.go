@@ -7,6 +7,7 @@ import (
 	"bytes"
 	"context"
 	"crypto/sha256"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
@@ -830,9 +831,103 @@ func (a *App) importUser(rctx request.CTX, data *imports.UserImportData, dryRun
 		}
 	}
 
+	if err := a.importUserCustomProfileAttributes(rctx, savedUser, data.CustomProfileAttributes); err != nil {
+		return err
+	}
+
 	return a.importUserTeams(rctx, savedUser, data.Teams)
 }
 
+// importUserCustomProfileAttributes sets the custom profile attribute values
+// of a user. Fields are matched by name, and the option names and usernames
+// of the values are translated into the ids used on this server.
+func (a *App) importUserCustomProfileAttributes(rctx request.CTX, user *model.User, data *[]imports.UserCustomProfileAttributeImportData) *model.AppError {
+	if data == nil || len(*data) == 0 {
+		return nil
+	}
+
+	fields, appErr := a.ListCPAFields()
+	if appErr != nil {
+		return appErr
+	}
+
+	fieldsByName := make(map[string]*model.CPAField, len(fields))
+	for _, field := range fields {
+		cpaField, err := model.NewCPAFieldFromPropertyField(field)
+		if err != nil {
+			return model.NewAppError("BulkImport", "app.import.import_user.custom_profile_attributes.error", nil, "", http.StatusInternalServerError).Wrap(err)
+		}
+		fieldsByName[field.Name] = cpaField
+	}
+
+	values := make(map[string]json.RawMessage, len(*data))
+	for _, attr := range *data {
+		field, ok := fieldsByName[*attr.Name]
+		if !ok {
+			return model.NewAppError("BulkImport", "app.import.import_user.custom_profile_attribute_not_found.error", map[string]any{"Name": *attr.Name}, "", http.StatusBadRequest)
+		}
+
+		value, err := mapCustomProfileAttributeValue(field, attr.Value, func(ref string) (string, bool) {
+			switch field.Type {
+			case model.PropertyFieldTypeSelect, model.PropertyFieldTypeMultiselect:
+				for _, option := range field.Attrs.Options {
+					if option.Name == ref || option.ID == ref {
+						return option.ID, true
+					}
+				}
+			case model.PropertyFieldTypeUser, model.PropertyFieldTypeMultiuser:
+				if u, err := a.Srv().Store().User().GetByUsername(ref); err == nil {
+					return u.Id, true
+				}
+			}
+			rctx.Logger().Warn("Skipping unknown custom profile attribute value", mlog.String("field", field.Name), mlog.String("value", ref))
+			return "", false
+		})
+		if err != nil {
+			return model.NewAppError("BulkImport", "app.import.import_user.custom_profile_attributes.error", nil, "", http.StatusBadRequest).Wrap(err)
+		}
+		values[field.ID] = value
+	}
+
+	if _, appErr := a.PatchCPAValues(user.Id, values, true); appErr != nil {
+		return appErr
+	}
+
+	return nil
+}
+
+// mapCustomProfileAttributeValue rewrites the option or user references of
+// a custom profile attribute value with mapFn, dropping the references it
+// can't map. Values of other field types are returned unchanged.
+func mapCustomProfileAttributeValue(field *model.CPAField, value json.RawMessage, mapFn func(string) (string, bool)) (json.RawMessage, error) {
+	switch field.Type {
+	case model.PropertyFieldTypeSelect, model.PropertyFieldTypeUser:
+		var ref string
+		if err := json.Unmarshal(value, &ref); err != nil {
+			return nil, err
+		}
+		if ref == "" {
+			return value, nil
+		}
+		mapped, _ := mapFn(ref)
+		return json.Marshal(mapped)
+	case model.PropertyFieldTypeMultiselect, model.PropertyFieldTypeMultiuser:
+		var refs []string
+		if err := json.Unmarshal(value, &refs); err != nil {
+			return nil, err
+		}
+		mapped := make([]string, 0, len(refs))
+		for _, ref := range refs {
+			if m, ok := mapFn(ref); ok {
+				mapped = append(mapped, m)
+			}
+		}
+		return json.Marshal(mapped)
+	default:
+		return value, nil
+	}
+}
+
 func (a *App) importBot(rctx request.CTX, data *imports.BotImportData, dryRun bool) *model.AppError {
 	var fields []mlog.Field
 	if data != nil && data.Username != nil {
@@ -1005,6 +1100,7 @@ func (a *App) importUserTeams(rctx request.CTX, user *model.User, data *[]import
 		isGuestByTeamID          = map[string]bool{}
 		isUserByTeamId           = map[string]bool{}
 		isAdminByTeamID          = map[string]bool{}
+		categoriesByTeamID       = map[string]*[]imports.UserSidebarCategoryImportData{}
 	)
 
 	existingMemberships, nErr := a.Srv().Store().Team().GetTeamsForUser(rctx, user.Id, "", true)
@@ -1018,6 +1114,8 @@ func (a *App) importUserTeams(rctx request.CTX, user *model.User, data *[]import
 	for _, tdata := range *data {
 		team := allTeams[strings.ToLower(*tdata.Name)]
 
+		categoriesByTeamID[team.Id] = tdata.Categories
+
 		// Team-specific theme Preferences.
 		if tdata.Theme != nil {
 			teamThemePreferencesByID[team.Id] = append(teamThemePreferencesByID[team.Id], model.Preference{
@@ -1138,6 +1236,87 @@ func (a *App) importUserTeams(rctx request.CTX, user *model.User, data *[]import
 		if err := a.importUserChannels(rctx, user, team, &channelsToImport); err != nil {
 			return err
 		}
+		if err := a.importUserSidebarCategories(rctx, user, team, categoriesByTeamID[team.Id]); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// importUserSidebarCategories creates the custom sidebar categories of a user
+// in a team, or updates the ones with the same display name. It must run
+// after the user has joined the channels of the team.
+func (a *App) importUserSidebarCategories(rctx request.CTX, user *model.User, team *model.Team, data *[]imports.UserSidebarCategoryImportData) *model.AppError {
+	if data == nil || len(*data) == 0 {
+		return nil
+	}
+
+	existing, appErr := a.GetSidebarCategoriesForTeamForUser(rctx, user.Id, team.Id)
+	if appErr != nil {
+		return appErr
+	}
+	customByName := map[string]*model.SidebarCategoryWithChannels{}
+	for _, category := range existing.Categories {
+		if category.Type == model.SidebarCategoryCustom {
+			customByName[category.DisplayName] = category
+		}
+	}
+
+	channelNames := []string{}
+	for _, cdata := range *data {
+		if cdata.Channels != nil {
+			channelNames = append(channelNames, *cdata.Channels...)
+		}
+	}
+	channels := map[string]*model.Channel{}
+	if len(channelNames) > 0 {
+		channels, appErr = a.getChannelsByNames(channelNames, team.Id)
+		if appErr != nil {
+			return appErr
+		}
+	}
+
+	for _, cdata := range *data {
+		category, ok := customByName[*cdata.DisplayName]
+		if !ok {
+			category, appErr = a.CreateSidebarCategory(rctx, user.Id, team.Id, &model.SidebarCategoryWithChannels{
+				SidebarCategory: model.SidebarCategory{
+					UserId:      user.Id,
+					TeamId:      team.Id,
+					Type:        model.SidebarCategoryCustom,
+					DisplayName: *cdata.DisplayName,
+				},
+			})
+			if appErr != nil {
+				return model.NewAppError("BulkImport", "app.import.import_user_teams.save_categories.error", nil, "", http.StatusInternalServerError).Wrap(appErr)
+			}
+		}
+
+		if cdata.Channels != nil {
+			category.Channels = make([]string, 0, len(*cdata.Channels))
+			for _, name := range *cdata.Channels {
+				channel, ok := channels[strings.ToLower(name)]
+				if !ok {
+					rctx.Logger().Warn("Skipping unknown channel in sidebar category", mlog.String("category", *cdata.DisplayName), mlog.String("channel_name", name))
+					continue
+				}
+				category.Channels = append(category.Channels, channel.Id)
+			}
+		}
+		if cdata.Sorting != nil {
+			category.Sorting = *cdata.Sorting
+		}
+		if cdata.Muted != nil {
+			category.Muted = *cdata.Muted
+		}
+		if cdata.Collapsed != nil {
+			category.Collapsed = *cdata.Collapsed
+		}
+
+		if _, appErr := a.UpdateSidebarCategories(rctx, user.Id, team.Id, []*model.SidebarCategoryWithChannels{category}); appErr != nil {
+			return model.NewAppError("BulkImport", "app.import.import_user_teams.save_categories.error", nil, "", http.StatusInternalServerError).Wrap(appErr)
+		}
 	}
 
 	return nil
@@ -1169,7 +1348,7 @@ func (a *App) importUserChannels(rctx request.CTX, user *model.User, team *model
 		isAdminByChannelId       = map[string]bool{}
 	)
 
-	existingMemberships, nErr := a.Srv().Store().Channel().GetMembersForUser(team.Id, user.Id)
+	existingMemberships, nErr := a.Srv().Store().Channel().GetMembersForUser(rctx.Context(), team.Id, user.Id)
 	if nErr != nil {
 		return model.NewAppError("importUserChannels", "app.channel.get_members.app_error", nil, "", http.StatusInternalServerError).Wrap(nErr)
 	}
@@ -1361,6 +1540,99 @@ func (a *App) importReaction(data *imports.ReactionImportData, post *model.Post)
 	return nil
 }
 
+// importPostMetadata saves the priority, acknowledgements and edit history of
+// an imported post, which must already have been saved.
+func (a *App) importPostMetadata(rctx request.CTX, post *model.Post, priority *imports.PostPriorityImportData, acks *[]imports.PostAcknowledgementImportData, editHistory *[]imports.PostEditHistoryImportData) *model.AppError {
+	if priority != nil {
+		postPriority := &model.PostPriority{
+			PostId:                  post.Id,
+			ChannelId:               post.ChannelId,
+			Priority:                priority.Priority,
+			RequestedAck:            model.NewPointer(priority.RequestedAck != nil && *priority.RequestedAck),
+			PersistentNotifications: model.NewPointer(priority.PersistentNotifications != nil && *priority.PersistentNotifications),
+		}
+		if _, err := a.Srv().Store().PostPriority().Save(postPriority); err != nil {
+			return model.NewAppError("BulkImport", "app.import.import_post.save_priority.error", nil, "", http.StatusInternalServerError).Wrap(err)
+		}
+
+		// Imported posts are history, so their persistent notifications
+		// must not start firing on the target server.
+		if *postPriority.PersistentNotifications {
+			if err := a.Srv().Store().PostPersistentNotification().Delete([]string{post.Id}); err != nil {
+				return model.NewAppError("BulkImport", "app.import.import_post.save_priority.error", nil, "", http.StatusInternalServerError).Wrap(err)
+			}
+		}
+	}
+
+	if acks != nil && len(*acks) > 0 {
+		usernames := make([]string, 0, len(*acks))
+		for _, ack := range *acks {
+			usernames = append(usernames, *ack.User)
+		}
+		users, appErr := a.getUsersByUsernames(usernames)
+		if appErr != nil {
+			return appErr
+		}
+
+		acknowledgements := make([]*model.PostAcknowledgement, 0, len(*acks))
+		for _, ack := range *acks {
+			acknowledgements = append(acknowledgements, &model.PostAcknowledgement{
+				UserId:         users[strings.ToLower(*ack.User)].Id,
+				PostId:         post.Id,
+				ChannelId:      post.ChannelId,
+				AcknowledgedAt: *ack.AcknowledgedAt,
+			})
+		}
+		if _, err := a.Srv().Store().PostAcknowledgement().BatchSave(acknowledgements); err != nil {
+			return model.NewAppError("BulkImport", "app.import.import_post.save_acknowledgements.error", nil, "", http.StatusInternalServerError).Wrap(err)
+		}
+	}
+
+	if editHistory != nil && len(*editHistory) > 0 {
+		// Skip the versions that a previous import of the same post already
+		// saved, so that importing a file twice doesn't duplicate them.
+		existing := map[int64]bool{}
+		previous, err := a.Srv().Store().Post().GetEditHistoryForPost(post.Id)
+		var nfErr *store.ErrNotFound
+		if err != nil && !errors.As(err, &nfErr) {
+			return model.NewAppError("BulkImport", "app.import.import_post.save_edit_history.error", nil, "", http.StatusInternalServerError).Wrap(err)
+		}
+		for _, p := range previous {
+			existing[p.DeleteAt] = true
+		}
+
+		history := make([]*model.Post, 0, len(*editHistory))
+		for _, edit := range *editHistory {
+			if existing[*edit.ReplacedAt] {
+				continue
+			}
+			version := &model.Post{
+				OriginalId: post.Id,
+				CreateAt:   post.CreateAt,
+				UpdateAt:   *edit.ReplacedAt,
+				DeleteAt:   *edit.ReplacedAt,
+				UserId:     post.UserId,
+				ChannelId:  post.ChannelId,
+				RootId:     post.RootId,
+				Message:    *edit.Message,
+				Type:       post.Type,
+			}
+			if edit.EditAt != nil {
+				version.EditAt = *edit.EditAt
+			}
+			if edit.Props != nil {
+				version.Props = *edit.Props
+			}
+			history = append(history, version)
+		}
+		if err := a.Srv().Store().Post().SaveEditHistory(history); err != nil {
+			return model.NewAppError("BulkImport", "app.import.import_post.save_edit_history.error", nil, "", http.StatusInternalServerError).Wrap(err)
+		}
+	}
+
+	return nil
+}
+
 func (a *App) importReplies(rctx request.CTX, data []imports.ReplyImportData, post *model.Post, teamID string, extractContent bool) *model.AppError {
 	var err *model.AppError
 	usernames := []string{}
@@ -1403,13 +1675,13 @@ func (a *App) importReplies(rctx request.CTX, data []imports.ReplyImportData, po
 			return model.NewAppError("importReplies", "app.post.get_posts_created_at.app_error", nil, "", http.StatusInternalServerError).Wrap(nErr)
 		}
 
-		var reply *model.Post
+		threadReplies := make([]*model.Post, 0, len(replies))
 		for _, r := range replies {
-			if r.Message == *replyData.Message && r.RootId == post.Id {
-				reply = r
-				break
+			if r.RootId == post.Id {
+				threadReplies = append(threadReplies, r)
 			}
 		}
+		reply := matchImportedPost(threadReplies, *replyData.Message, replyData.EditHistory)
 
 		if reply == nil {
 			reply = &model.Post{}
@@ -1526,6 +1798,11 @@ func (a *App) importReplies(rctx request.CTX, data []imports.ReplyImportData, po
 				}
 			}
 		}
+
+		replyData := postWithData.replyData
+		if err := a.importPostMetadata(rctx, postWithData.post, replyData.Priority, replyData.Acknowledgements, replyData.EditHistory); err != nil {
+			return err
+		}
 	}
 
 	return nil
@@ -1845,13 +2122,7 @@ func (a *App) importMultiplePostLines(rctx request.CTX, lines []imports.LineImpo
 			return line.LineNumber, model.NewAppError("importMultiplePostLines", "app.post.get_posts_created_at.app_error", nil, "", http.StatusInternalServerError).Wrap(nErr)
 		}
 
-		var post *model.Post
-		for _, p := range posts {
-			if p.Message == *line.Post.Message {
-				post = p
-				break
-			}
-		}
+		post := matchImportedPost(posts, *line.Post.Message, line.Post.EditHistory)
 
 		if post == nil {
 			post = &model.Post{}
@@ -2018,6 +2289,11 @@ func (a *App) importMultiplePostLines(rctx request.CTX, lines []imports.LineImpo
 			}
 		}
 
+		postData := postWithData.postData
+		if err := a.importPostMetadata(rctx, postWithData.post, postData.Priority, postData.Acknowledgements, postData.EditHistory); err != nil {
+			return postWithData.lineNumber, err
+		}
+
 		if postWithData.postData.Replies != nil && len(*postWithData.postData.Replies) > 0 {
 			err := a.importReplies(rctx, *postWithData.postData.Replies, postWithData.post, postWithData.team.Id, extractContent)
 			if err != nil {
@@ -2368,13 +2644,7 @@ func (a *App) importMultipleDirectPostLines(rctx request.CTX, lines []imports.Li
 			return line.LineNumber, model.NewAppError("BulkImport", "app.post.get_posts_created_at.app_error", nil, "", http.StatusInternalServerError).Wrap(nErr)
 		}
 
-		var post *model.Post
-		for _, p := range posts {
-			if p.Message == *line.DirectPost.Message {
-				post = p
-				break
-			}
-		}
+		post := matchImportedPost(posts, *line.DirectPost.Message, line.DirectPost.EditHistory)
 
 		if post == nil {
 			post = &model.Post{}
@@ -2528,6 +2798,11 @@ func (a *App) importMultipleDirectPostLines(rctx request.CTX, lines []imports.Li
 			}
 		}
 
+		directPostData := postWithData.directPostData
+		if err := a.importPostMetadata(rctx, postWithData.post, directPostData.Priority, directPostData.Acknowledgements, directPostData.EditHistory); err != nil {
+			return postWithData.lineNumber, err
+		}
+
 		if postWithData.directPostData.Replies != nil {
 			if err := a.importReplies(rctx, *postWithData.directPostData.Replies, postWithData.post, "noteam", extractContent); err != nil {
 				return postWithData.lineNumber, err
@@ -2610,6 +2885,263 @@ func (a *App) importEmoji(rctx request.CTX, data *imports.EmojiImportData, dryRu
 	return nil
 }
 
+func (a *App) importChannelBookmark(rctx request.CTX, data *imports.ChannelBookmarkImportData, dryRun bool) *model.AppError {
+	var fields []mlog.Field
+	if data != nil && data.DisplayName != nil {
+		fields = append(fields, mlog.String("bookmark_name", *data.DisplayName))
+	}
+	rctx.Logger().Info("Validating channel bookmark", fields...)
+
+	if err := imports.ValidateChannelBookmarkImportData(data); err != nil {
+		return err
+	}
+
+	// If this is a Dry Run, do not continue any further.
+	if dryRun {
+		return nil
+	}
+
+	rctx.Logger().Info("Importing channel bookmark", fields...)
+
+	team, err := a.Srv().Store().Team().GetByName(*data.Team)
+	if err != nil {
+		return model.NewAppError("BulkImport", "app.import.import_channel.team_not_found.error", map[string]any{"TeamName": *data.Team}, "", http.StatusBadRequest).Wrap(err)
+	}
+
+	channel, err := a.Srv().Store().Channel().GetByName(team.Id, *data.Channel, false)
+	if err != nil {
+		return model.NewAppError("BulkImport", "app.import.import_channel_bookmark.channel_not_found.error", map[string]any{"ChannelName": *data.Channel}, "", http.StatusBadRequest).Wrap(err)
+	}
+
+	owner, err := a.Srv().Store().User().GetByUsername(*data.Owner)
+	if err != nil {
+		return model.NewAppError("BulkImport", "app.import.import_channel_bookmark.owner_not_found.error", map[string]any{"Username": *data.Owner}, "", http.StatusBadRequest).Wrap(err)
+	}
+
+	// Bookmarks have no natural key, so a bookmark with the same name and
+	// type in the channel is considered to be the one being imported.
+	existing, err := a.Srv().Store().ChannelBookmark().GetBookmarksForChannelSince(channel.Id, 0)
+	if err != nil {
+		return model.NewAppError("BulkImport", "app.import.import_channel_bookmark.save.error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+	for _, bookmark := range existing {
+		if bookmark.DeleteAt == 0 && bookmark.DisplayName == *data.DisplayName && bookmark.Type == *data.Type {
+			rctx.Logger().Info("Skipping channel bookmark that already exists", fields...)
+			return nil
+		}
+	}
+
+	bookmark := &model.ChannelBookmark{
+		ChannelId:   channel.Id,
+		OwnerId:     owner.Id,
+		DisplayName: *data.DisplayName,
+		Type:        *data.Type,
+	}
+	if data.CreateAt != nil {
+		bookmark.CreateAt = *data.CreateAt
+	}
+	if data.LinkURL != nil {
+		bookmark.LinkUrl = *data.LinkURL
+	}
+	if data.ImageURL != nil {
+		bookmark.ImageUrl = *data.ImageURL
+	}
+	if data.Emoji != nil {
+		bookmark.Emoji = *data.Emoji
+	}
+	if data.SortOrder != nil {
+		bookmark.SortOrder = *data.SortOrder
+	}
+
+	if bookmark.Type == model.ChannelBookmarkFile {
+		// Bookmark files are owned by a placeholder user so that they are
+		// stored apart from post attachments.
+		placeholder := &model.Post{
+			ChannelId: channel.Id,
+			UserId:    model.BookmarkFileOwner,
+			CreateAt:  bookmark.CreateAt,
+		}
+		if placeholder.CreateAt == 0 {
+			placeholder.CreateAt = model.GetMillis()
+		}
+		fileInfo, appErr := a.importAttachment(rctx, data.File, placeholder, team.Id, false)
+		if appErr != nil {
+			return appErr
+		}
+		bookmark.FileId = fileInfo.Id
+	}
+
+	if _, err := a.Srv().Store().ChannelBookmark().Save(bookmark, data.SortOrder == nil); err != nil {
+		var appErr *model.AppError
+		if errors.As(err, &appErr) {
+			return appErr
+		}
+		return model.NewAppError("BulkImport", "app.import.import_channel_bookmark.save.error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+
+	return nil
+}
+
+// importTombstone deletes the entity a tombstone line refers to. Tombstones
+// whose entity doesn't exist are skipped, as it was either never imported or
+// already deleted.
+func (a *App) importTombstone(rctx request.CTX, data *imports.TombstoneImportData, dryRun bool) *model.AppError {
+	if err := imports.ValidateTombstoneImportData(data); err != nil {
+		return err
+	}
+
+	// If this is a Dry Run, do not continue any further.
+	if dryRun {
+		return nil
+	}
+
+	fields := []mlog.Field{mlog.String("type", *data.Type)}
+	var nfErr *store.ErrNotFound
+
+	if *data.Type == "direct_post" {
+		users, appErr := a.getUsersByUsernames(append([]string{*data.User}, *data.ChannelMembers...))
+		if appErr != nil {
+			rctx.Logger().Info("Skipping tombstone for a direct post of users that don't exist", fields...)
+			return nil
+		}
+
+		userIDs := make([]string, 0, len(*data.ChannelMembers))
+		for _, username := range *data.ChannelMembers {
+			userIDs = append(userIDs, users[strings.ToLower(username)].Id)
+		}
+
+		channelName := model.GetGroupNameFromUserIds(userIDs)
+		if len(userIDs) == 2 {
+			channelName = model.GetDMNameFromIds(userIDs[0], userIDs[1])
+		}
+		channel, err := a.Srv().Store().Channel().GetByName("", channelName, true)
+		if err != nil {
+			if errors.As(err, &nfErr) {
+				rctx.Logger().Info("Skipping tombstone for a post in a direct channel that doesn't exist", fields...)
+				return nil
+			}
+			return model.NewAppError("BulkImport", "app.import.import_tombstone.channel_not_found.error", nil, "", http.StatusInternalServerError).Wrap(err)
+		}
+
+		return a.importPostTombstone(rctx, data, channel.Id, users[strings.ToLower(*data.User)].Id)
+	}
+
+	if *data.Type == "emoji" {
+		emoji, err := a.Srv().Store().Emoji().GetByName(rctx, *data.Name, false)
+		if err != nil {
+			if errors.As(err, &nfErr) {
+				rctx.Logger().Info("Skipping tombstone for an emoji that doesn't exist", append(fields, mlog.String("emoji_name", *data.Name))...)
+				return nil
+			}
+			return model.NewAppError("BulkImport", "app.emoji.get_by_name.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+		}
+		return a.DeleteEmoji(rctx, emoji)
+	}
+
+	team, err := a.Srv().Store().Team().GetByName(*data.Team)
+	if err != nil {
+		if errors.As(err, &nfErr) {
+			rctx.Logger().Info("Skipping tombstone for a team that doesn't exist", append(fields, mlog.String("team_name", *data.Team))...)
+			return nil
+		}
+		return model.NewAppError("BulkImport", "app.import.import_tombstone.team_not_found.error", map[string]any{"TeamName": *data.Team}, "", http.StatusInternalServerError).Wrap(err)
+	}
+
+	if *data.Type == "team" {
+		if team.DeleteAt != 0 {
+			return nil
+		}
+		return a.SoftDeleteTeam(team.Id)
+	}
+
+	channel, err := a.Srv().Store().Channel().GetByName(team.Id, *data.Channel, true)
+	if err != nil {
+		if errors.As(err, &nfErr) {
+			rctx.Logger().Info("Skipping tombstone for a channel that doesn't exist", append(fields, mlog.String("channel_name", *data.Channel))...)
+			return nil
+		}
+		return model.NewAppError("BulkImport", "app.import.import_tombstone.channel_not_found.error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+
+	if *data.Type == "channel_bookmark" {
+		bookmarks, err := a.Srv().Store().ChannelBookmark().GetBookmarksForChannelSince(channel.Id, 0)
+		if err != nil {
+			return model.NewAppError("BulkImport", "app.import.import_tombstone.delete.error", nil, "", http.StatusInternalServerError).Wrap(err)
+		}
+		for _, bookmark := range bookmarks {
+			if bookmark.DeleteAt != 0 || bookmark.DisplayName != *data.DisplayName {
+				continue
+			}
+			if err := a.Srv().Store().ChannelBookmark().Delete(bookmark.Id, true); err != nil {
+				return model.NewAppError("BulkImport", "app.import.import_tombstone.delete.error", nil, "", http.StatusInternalServerError).Wrap(err)
+			}
+		}
+		return nil
+	}
+
+	user, err := a.Srv().Store().User().GetByUsername(*data.User)
+	if err != nil {
+		if errors.As(err, &nfErr) {
+			rctx.Logger().Info("Skipping tombstone for a post of a user that doesn't exist", fields...)
+			return nil
+		}
+		return model.NewAppError("BulkImport", "app.import.import_tombstone.delete.error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+
+	return a.importPostTombstone(rctx, data, channel.Id, user.Id)
+}
+
+func (a *App) importPostTombstone(rctx request.CTX, data *imports.TombstoneImportData, channelID, userID string) *model.AppError {
+	posts, err := a.Srv().Store().Post().GetPostsCreatedAt(channelID, *data.CreateAt)
+	if err != nil {
+		return model.NewAppError("BulkImport", "app.post.get_posts_created_at.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+
+	for _, post := range posts {
+		if post.DeleteAt != 0 || post.OriginalId != "" || post.UserId != userID || post.Message != *data.Message {
+			continue
+		}
+
+		if err := a.Srv().Store().Post().Delete(rctx, post.Id, *data.DeleteAt, ""); err != nil {
+			return model.NewAppError("BulkImport", "app.import.import_tombstone.delete.error", nil, "", http.StatusInternalServerError).Wrap(err)
+		}
+		if _, err := a.Srv().Store().FileInfo().DeleteForPost(rctx, post.Id); err != nil {
+			rctx.Logger().Warn("Error while deleting the files of a deleted post", mlog.String("post_id", post.Id), mlog.Err(err))
+		}
+	}
+
+	return nil
+}
+
+// matchImportedPost returns the post among candidates that an imported post
+// refers to, or nil. Posts are matched by message, falling back to the
+// messages in the imported edit history so that a post edited since an
+// earlier import is updated in place rather than duplicated.
+func matchImportedPost(candidates []*model.Post, message string, editHistory *[]imports.PostEditHistoryImportData) *model.Post {
+	for _, p := range candidates {
+		if p.Message == message {
+			return p
+		}
+	}
+
+	if editHistory == nil {
+		return nil
+	}
+
+	for _, p := range candidates {
+		if p.DeleteAt != 0 || p.OriginalId != "" {
+			continue
+		}
+		for _, edit := range *editHistory {
+			if edit.Message != nil && p.Message == *edit.Message {
+				return p
+			}
+		}
+	}
+
+	return nil
+}
+
 func (a *App) extractThreadMembers(line *imports.LineImportWorkerData, users map[string]*model.User, post *model.Post) ([]*model.ThreadMembership, int, *model.AppError) {
 	threadMemberships := []*model.ThreadMembership{}
 
@@ -1946,7 +1946,7 @@ func TestImportUserTeams(t *testing.T) {
 
 				totalMembers := 0
 				for _, teamMember := range teamMembers {
-					channelMembers, err := th.App.Srv().Store().Channel().GetMembersForUser(teamMember.TeamId, user.Id)
+					channelMembers, err := th.App.Srv().Store().Channel().GetMembersForUser(th.Context.Context(), teamMember.TeamId, user.Id)
 					require.NoError(t, err)
 					totalMembers += len(channelMembers)
 				}
@@ -2086,7 +2086,7 @@ func TestImportUserChannels(t *testing.T) {
 				} else {
 					require.Nil(t, appErr)
 				}
-				channelMembers, err := th.App.Srv().Store().Channel().GetMembersForUser(th.BasicTeam.Id, user.Id)
+				channelMembers, err := th.App.Srv().Store().Channel().GetMembersForUser(th.Context.Context(), th.BasicTeam.Id, user.Id)
 				require.NoError(t, err)
 				require.Len(t, channelMembers, tc.expectedUserChannels)
 				if tc.expectedUserChannels == 1 {
@@ -5,6 +5,7 @@ package app
 
 import (
 	"archive/zip"
+	"context"
 	"io"
 	"net/http"
 	"os"
@@ -284,6 +285,201 @@ func TestImportBulkImport(t *testing.T) {
 	})
 }
 
+func TestImportBulkImportConflictPolicies(t *testing.T) {
+	mainHelper.Parallel(t)
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+
+	teamName := model.NewRandomTeamName()
+	channelName := model.NewId()
+	username := model.NewUsername()
+	username2 := model.NewUsername()
+
+	data := func(displayName string) string {
+		return `{"type": "version", "version": 1}
+{"type": "team", "team": {"type": "O", "display_name": "` + displayName + `", "name": "` + teamName + `"}}
+{"type": "channel", "channel": {"type": "O", "display_name": "` + displayName + `", "team": "` + teamName + `", "name": "` + channelName + `"}}
+{"type": "user", "user": {"username": "` + username + `", "email": "` + username + `@example.com", "nickname": "` + displayName + `", "teams": [{"name": "` + teamName + `", "channels": [{"name": "` + channelName + `"}]}]}}
+{"type": "post", "post": {"team": "` + teamName + `", "channel": "` + channelName + `", "user": "` + username + `", "message": "Hello World", "create_at": 123456789012}}`
+	}
+
+	bulkImport := func(t *testing.T, input string, opts imports.BulkImportOpts) *imports.ImportReport {
+		t.Helper()
+		opts.Workers = 2
+		report, line, err := th.App.BulkImportWithOpts(th.Context, strings.NewReader(input), nil, opts)
+		require.Nil(t, err)
+		require.Equal(t, 0, line)
+		require.NotNil(t, report)
+		return report
+	}
+
+	t.Run("dry run of new entities", func(t *testing.T) {
+		report := bulkImport(t, data("first"), imports.BulkImportOpts{DryRun: true})
+
+		assert.True(t, report.DryRun)
+		assert.Equal(t, imports.ConflictPolicyOverwrite, report.ConflictPolicy)
+		assert.Equal(t, &imports.ImportEntityReport{Create: 1}, report.Entities["team"])
+		assert.Equal(t, &imports.ImportEntityReport{Create: 1}, report.Entities["channel"])
+		assert.Equal(t, &imports.ImportEntityReport{Create: 1}, report.Entities["user"])
+		assert.Equal(t, &imports.ImportEntityReport{Create: 1}, report.Entities["post"])
+
+		_, err := th.App.Srv().Store().Team().GetByName(teamName)
+		require.Error(t, err, "a dry run must not import anything")
+	})
+
+	bulkImport(t, data("first"), imports.BulkImportOpts{})
+
+	team, err := th.App.Srv().Store().Team().GetByName(teamName)
+	require.NoError(t, err)
+
+	t.Run("dry run of existing entities", func(t *testing.T) {
+		report := bulkImport(t, data("second"), imports.BulkImportOpts{DryRun: true, ConflictPolicy: imports.ConflictPolicySkip})
+
+		assert.Equal(t, &imports.ImportEntityReport{Skip: 1}, report.Entities["team"])
+		assert.Equal(t, &imports.ImportEntityReport{Skip: 1}, report.Entities["channel"])
+		assert.Equal(t, &imports.ImportEntityReport{Skip: 1}, report.Entities["user"])
+		assert.Equal(t, &imports.ImportEntityReport{Update: 1}, report.Entities["post"])
+	})
+
+	t.Run("skip", func(t *testing.T) {
+		bulkImport(t, data("second"), imports.BulkImportOpts{ConflictPolicy: imports.ConflictPolicySkip})
+
+		updatedTeam, err := th.App.Srv().Store().Team().GetByName(teamName)
+		require.NoError(t, err)
+		assert.Equal(t, "first", updatedTeam.DisplayName)
+
+		user, err := th.App.Srv().Store().User().GetByUsername(username)
+		require.NoError(t, err)
+		assert.Equal(t, "first", user.Nickname)
+	})
+
+	t.Run("skip memberships of skipped teams and channels", func(t *testing.T) {
+		newUsername := model.NewUsername()
+		newChannelName := model.NewId()
+		input := `{"type": "version", "version": 1}
+{"type": "team", "team": {"type": "O", "display_name": "second", "name": "` + teamName + `"}}
+{"type": "channel", "channel": {"type": "O", "display_name": "second", "team": "` + teamName + `", "name": "` + newChannelName + `"}}
+{"type": "user", "user": {"username": "` + newUsername + `", "email": "` + newUsername + `@example.com", "teams": [{"name": "` + teamName + `", "channels": [{"name": "` + channelName + `"}]}, {"name": "` + th.BasicTeam.Name + `", "channels": [{"name": "` + th.BasicChannel.Name + `"}]}]}}`
+
+		report := bulkImport(t, input, imports.BulkImportOpts{ConflictPolicy: imports.ConflictPolicySkip})
+		assert.Equal(t, &imports.ImportEntityReport{Skip: 1}, report.Entities["team"])
+		assert.Equal(t, &imports.ImportEntityReport{Skip: 1}, report.Entities["channel"])
+		assert.Equal(t, &imports.ImportEntityReport{Create: 1}, report.Entities["user"])
+
+		_, err := th.App.Srv().Store().Channel().GetByName(team.Id, newChannelName, false)
+		require.Error(t, err, "the channels of a skipped team must not be imported")
+
+		user, err := th.App.Srv().Store().User().GetByUsername(newUsername)
+		require.NoError(t, err)
+		_, err = th.App.Srv().Store().Team().GetMember(th.Context, team.Id, user.Id)
+		require.Error(t, err, "the user must not be added to the skipped team")
+		_, err = th.App.Srv().Store().Channel().GetMember(context.Background(), th.BasicChannel.Id, user.Id)
+		require.NoError(t, err, "the memberships of other teams should be imported")
+	})
+
+	t.Run("merge", func(t *testing.T) {
+		otherChannel := th.CreateChannel(th.Context, team)
+		input := `{"type": "version", "version": 1}
+{"type": "team", "team": {"type": "O", "display_name": "third", "name": "` + teamName + `"}}
+{"type": "user", "user": {"username": "` + username + `", "email": "` + username + `@example.com", "nickname": "third", "teams": [{"name": "` + teamName + `", "channels": [{"name": "` + otherChannel.Name + `"}]}]}}`
+
+		report := bulkImport(t, input, imports.BulkImportOpts{ConflictPolicy: imports.ConflictPolicyMerge})
+		assert.Equal(t, &imports.ImportEntityReport{Merge: 1}, report.Entities["team"])
+		assert.Equal(t, &imports.ImportEntityReport{Merge: 1}, report.Entities["user"])
+
+		user, err := th.App.Srv().Store().User().GetByUsername(username)
+		require.NoError(t, err)
+		assert.Equal(t, "first", user.Nickname)
+
+		_, err = th.App.Srv().Store().Channel().GetMember(context.Background(), otherChannel.Id, user.Id)
+		require.NoError(t, err, "the memberships of the import should be added")
+	})
+
+	t.Run("overwrite", func(t *testing.T) {
+		report := bulkImport(t, data("fourth"), imports.BulkImportOpts{ConflictPolicy: imports.ConflictPolicyOverwrite})
+		assert.Equal(t, &imports.ImportEntityReport{Update: 1}, report.Entities["team"])
+
+		updatedTeam, err := th.App.Srv().Store().Team().GetByName(teamName)
+		require.NoError(t, err)
+		assert.Equal(t, "fourth", updatedTeam.DisplayName)
+	})
+
+	t.Run("rename", func(t *testing.T) {
+		input := `{"type": "version", "version": 1}
+{"type": "team", "team": {"type": "O", "display_name": "renamed", "name": "` + teamName + `"}}
+{"type": "channel", "channel": {"type": "O", "display_name": "renamed", "team": "` + teamName + `", "name": "` + channelName + `"}}
+{"type": "user", "user": {"username": "` + username + `", "email": "` + username2 + `@example.com", "teams": [{"name": "` + teamName + `", "channels": [{"name": "` + channelName + `"}]}]}}
+{"type": "post", "post": {"team": "` + teamName + `", "channel": "` + channelName + `", "user": "` + username + `", "message": "Hello Renamed", "create_at": 123456789013}}`
+
+		report := bulkImport(t, input, imports.BulkImportOpts{ConflictPolicy: imports.ConflictPolicyRename})
+		require.Len(t, report.Renames, 2)
+
+		renamedTeam, err := th.App.Srv().Store().Team().GetByName(teamName + "-2")
+		require.NoError(t, err)
+		assert.Equal(t, "renamed", renamedTeam.DisplayName)
+
+		// The channel name is free in the renamed team.
+		renamedChannel, err := th.App.Srv().Store().Channel().GetByName(renamedTeam.Id, channelName, false)
+		require.NoError(t, err)
+		assert.Equal(t, "renamed", renamedChannel.DisplayName)
+
+		renamedUser, err := th.App.Srv().Store().User().GetByUsername(username + "-2")
+		require.NoError(t, err)
+		assert.Equal(t, username2+"@example.com", renamedUser.Email)
+
+		posts, err := th.App.Srv().Store().Post().GetPostsCreatedAt(renamedChannel.Id, 123456789013)
+		require.NoError(t, err)
+		require.Len(t, posts, 1)
+		assert.Equal(t, renamedUser.Id, posts[0].UserId)
+	})
+
+	t.Run("rename a user of a taken email", func(t *testing.T) {
+		input := `{"type": "version", "version": 1}
+{"type": "user", "user": {"username": "` + username + `", "email": "` + username + `@example.com"}}`
+
+		_, line, appErr := th.App.BulkImportWithOpts(th.Context, strings.NewReader(input), nil, imports.BulkImportOpts{Workers: 2, ConflictPolicy: imports.ConflictPolicyRename})
+		require.NotNil(t, appErr)
+		assert.Equal(t, "app.import.resolve_conflict.rename_email_taken.error", appErr.Id)
+		assert.Equal(t, 2, line)
+	})
+
+	t.Run("emoji", func(t *testing.T) {
+		testsDir, _ := fileutils.FindDir("tests")
+		emojiName := model.NewId()
+		input := `{"type": "version", "version": 1}
+{"type": "emoji", "emoji": {"name": "` + emojiName + `", "image": "test.png"}}`
+
+		report := bulkImport(t, input, imports.BulkImportOpts{ImportPath: testsDir})
+		assert.Equal(t, &imports.ImportEntityReport{Create: 1}, report.Entities["emoji"])
+		emoji, err := th.App.Srv().Store().Emoji().GetByName(th.Context, emojiName, false)
+		require.NoError(t, err)
+
+		for _, policy := range []imports.ConflictPolicy{imports.ConflictPolicySkip, imports.ConflictPolicyMerge} {
+			report = bulkImport(t, input, imports.BulkImportOpts{ImportPath: testsDir, ConflictPolicy: policy})
+			assert.Equal(t, &imports.ImportEntityReport{Skip: 1}, report.Entities["emoji"], policy)
+		}
+
+		report = bulkImport(t, input, imports.BulkImportOpts{ImportPath: testsDir, ConflictPolicy: imports.ConflictPolicyRename})
+		require.Len(t, report.Renames, 1)
+		assert.Equal(t, imports.ImportRename{Entity: "emoji", From: emojiName, To: emojiName + "-2"}, report.Renames[0])
+		renamed, err := th.App.Srv().Store().Emoji().GetByName(th.Context, emojiName+"-2", false)
+		require.NoError(t, err)
+		assert.NotEqual(t, emoji.Id, renamed.Id)
+
+		report = bulkImport(t, input, imports.BulkImportOpts{ImportPath: testsDir, ConflictPolicy: imports.ConflictPolicyOverwrite})
+		assert.Equal(t, &imports.ImportEntityReport{Update: 1}, report.Entities["emoji"])
+		updated, err := th.App.Srv().Store().Emoji().GetByName(th.Context, emojiName, false)
+		require.NoError(t, err)
+		assert.Equal(t, emoji.Id, updated.Id)
+	})
+
+	t.Run("invalid policy", func(t *testing.T) {
+		_, _, appErr := th.App.BulkImportWithOpts(th.Context, strings.NewReader(data("fifth")), nil, imports.BulkImportOpts{Workers: 2, ConflictPolicy: "replace"})
+		require.NotNil(t, appErr)
+		assert.Equal(t, "app.import.bulk_import.invalid_conflict_policy.error", appErr.Id)
+	})
+}
+
 func TestImportProcessImportDataFileVersionLine(t *testing.T) {
 	mainHelper.Parallel(t)
 	data := imports.LineImportData{
@@ -0,0 +1,323 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package imports
+
+import (
+	"sort"
+	"strings"
+	"sync"
+)
+
+// ConflictPolicy selects what a bulk import does with the teams, channels,
+// users, bots and custom emoji of the import file that already exist on the
+// server.
+type ConflictPolicy string
+
+const (
+	// ConflictPolicyOverwrite updates existing entities with the imported
+	// data. This is the default.
+	ConflictPolicyOverwrite ConflictPolicy = "overwrite"
+	// ConflictPolicySkip leaves existing entities and their memberships
+	// untouched. The channels of skipped teams are skipped as well.
+	ConflictPolicySkip ConflictPolicy = "skip"
+	// ConflictPolicyMerge leaves existing entities untouched but adds the
+	// imported memberships to them.
+	ConflictPolicyMerge ConflictPolicy = "merge"
+	// ConflictPolicyRename imports entities whose name is taken under a
+	// new, free name. The lines of the import file referencing them are
+	// rewritten to the new name.
+	ConflictPolicyRename ConflictPolicy = "rename"
+)
+
+func (p ConflictPolicy) IsValid() bool {
+	switch p {
+	case ConflictPolicyOverwrite, ConflictPolicySkip, ConflictPolicyMerge, ConflictPolicyRename:
+		return true
+	}
+	return false
+}
+
+// BulkImportOpts are the options of a bulk import.
+type BulkImportOpts struct {
+	// DryRun validates the lines and reports what they would do without
+	// writing anything.
+	DryRun         bool
+	ExtractContent bool
+	Workers        int
+	// ImportPath is the directory the attachments are read from.
+	ImportPath     string
+	ConflictPolicy ConflictPolicy
+}
+
+// ImportAction is what a line of the import file did, or would do in a dry
+// run, to the entity it describes.
+type ImportAction string
+
+const (
+	ImportActionCreate ImportAction = "create"
+	ImportActionUpdate ImportAction = "update"
+	ImportActionSkip   ImportAction = "skip"
+	ImportActionMerge  ImportAction = "merge"
+	ImportActionRename ImportAction = "rename"
+)
+
+// ImportEntityReport counts the actions taken on the entities of one type.
+type ImportEntityReport struct {
+	Create int64 `json:"create"`
+	Update int64 `json:"update"`
+	Skip   int64 `json:"skip"`
+	Merge  int64 `json:"merge"`
+	Rename int64 `json:"rename"`
+}
+
+// ImportRename is an entity imported under a new name.
+type ImportRename struct {
+	Entity string `json:"entity"`
+	Team   string `json:"team,omitempty"`
+	From   string `json:"from"`
+	To     string `json:"to"`
+}
+
+// ImportReport sums up what a bulk import did, or would do in a dry run,
+// per entity type. It's safe for concurrent use.
+type ImportReport struct {
+	DryRun         bool                           `json:"dry_run"`
+	ConflictPolicy ConflictPolicy                 `json:"conflict_policy"`
+	Entities       map[string]*ImportEntityReport `json:"entities"`
+	Renames        []ImportRename                 `json:"renames,omitempty"`
+
+	mut sync.Mutex
+}
+
+func NewImportReport(dryRun bool, policy ConflictPolicy) *ImportReport {
+	return &ImportReport{
+		DryRun:         dryRun,
+		ConflictPolicy: policy,
+		Entities:       map[string]*ImportEntityReport{},
+	}
+}
+
+// Record counts an action taken on an entity of the given type.
+func (r *ImportReport) Record(entity string, action ImportAction) {
+	r.mut.Lock()
+	defer r.mut.Unlock()
+
+	counts, ok := r.Entities[entity]
+	if !ok {
+		counts = &ImportEntityReport{}
+		r.Entities[entity] = counts
+	}
+
+	switch action {
+	case ImportActionCreate:
+		counts.Create++
+	case ImportActionUpdate:
+		counts.Update++
+	case ImportActionSkip:
+		counts.Skip++
+	case ImportActionMerge:
+		counts.Merge++
+	case ImportActionRename:
+		counts.Rename++
+	}
+}
+
+// RecordRename counts an entity imported under a new name.
+func (r *ImportReport) RecordRename(rename ImportRename) {
+	r.Record(rename.Entity, ImportActionRename)
+
+	r.mut.Lock()
+	defer r.mut.Unlock()
+	r.Renames = append(r.Renames, rename)
+}
+
+// EntityTypes returns the types of the entities of the report, sorted.
+func (r *ImportReport) EntityTypes() []string {
+	r.mut.Lock()
+	defer r.mut.Unlock()
+
+	entities := make([]string, 0, len(r.Entities))
+	for entity := range r.Entities {
+		entities = append(entities, entity)
+	}
+	sort.Strings(entities)
+	return entities
+}
+
+// ImportRenames maps the names of the teams, channels and users imported
+// under a new name. It's safe for concurrent use.
+type ImportRenames struct {
+	mut      sync.RWMutex
+	teams    map[string]string
+	channels map[string]map[string]string // team name -> channel name -> new name
+	users    map[string]string
+}
+
+func NewImportRenames() *ImportRenames {
+	return &ImportRenames{
+		teams:    map[string]string{},
+		channels: map[string]map[string]string{},
+		users:    map[string]string{},
+	}
+}
+
+func (r *ImportRenames) RenameTeam(from, to string) {
+	r.mut.Lock()
+	defer r.mut.Unlock()
+	r.teams[strings.ToLower(from)] = to
+}
+
+// RenameChannel records the renaming of a channel of the given team, named
+// as in the import lines once rewritten.
+func (r *ImportRenames) RenameChannel(team, from, to string) {
+	r.mut.Lock()
+	defer r.mut.Unlock()
+
+	team = strings.ToLower(team)
+	if r.channels[team] == nil {
+		r.channels[team] = map[string]string{}
+	}
+	r.channels[team][strings.ToLower(from)] = to
+}
+
+func (r *ImportRenames) RenameUser(from, to string) {
+	r.mut.Lock()
+	defer r.mut.Unlock()
+	r.users[strings.ToLower(from)] = to
+}
+
+func (r *ImportRenames) team(name *string) {
+	if name == nil {
+		return
+	}
+	if to, ok := r.teams[strings.ToLower(*name)]; ok {
+		*name = to
+	}
+}
+
+func (r *ImportRenames) channel(team, name *string) {
+	if team == nil || name == nil {
+		return
+	}
+	if to, ok := r.channels[strings.ToLower(*team)][strings.ToLower(*name)]; ok {
+		*name = to
+	}
+}
+
+func (r *ImportRenames) user(name *string) {
+	if name == nil {
+		return
+	}
+	if to, ok := r.users[strings.ToLower(*name)]; ok {
+		*name = to
+	}
+}
+
+func (r *ImportRenames) userList(names *[]string) {
+	if names == nil {
+		return
+	}
+	for i := range *names {
+		r.user(&(*names)[i])
+	}
+}
+
+// Apply rewrites the references of an import line to the renamed teams,
+// channels and users. Lines are rewritten before being imported, once the
+// lines of the entities they reference are imported.
+func (r *ImportRenames) Apply(line *LineImportData) {
+	r.mut.RLock()
+	defer r.mut.RUnlock()
+
+	if len(r.teams) == 0 && len(r.channels) == 0 && len(r.users) == 0 {
+		return
+	}
+
+	switch {
+	case line.Channel != nil:
+		r.team(line.Channel.Team)
+	case line.User != nil:
+		r.applyUserTeams(line.User.Teams)
+	case line.Bot != nil:
+		r.user(line.Bot.Owner)
+	case line.Post != nil:
+		r.team(line.Post.Team)
+		r.channel(line.Post.Team, line.Post.Channel)
+		r.user(line.Post.User)
+		r.applyPost(line.Post.FlaggedBy, line.Post.Reactions, line.Post.Replies, line.Post.ThreadFollowers, line.Post.Acknowledgements)
+	case line.DirectChannel != nil:
+		r.userList(line.DirectChannel.Members)
+		r.userList(line.DirectChannel.FavoritedBy)
+		r.userList(line.DirectChannel.ShownBy)
+		for _, participant := range line.DirectChannel.Participants {
+			if participant != nil {
+				r.user(participant.Username)
+			}
+		}
+	case line.DirectPost != nil:
+		r.userList(line.DirectPost.ChannelMembers)
+		r.user(line.DirectPost.User)
+		r.applyPost(line.DirectPost.FlaggedBy, line.DirectPost.Reactions, line.DirectPost.Replies, line.DirectPost.ThreadFollowers, line.DirectPost.Acknowledgements)
+	case line.Bookmark != nil:
+		r.team(line.Bookmark.Team)
+		r.channel(line.Bookmark.Team, line.Bookmark.Channel)
+		r.user(line.Bookmark.Owner)
+	case line.Tombstone != nil:
+		r.team(line.Tombstone.Team)
+		r.channel(line.Tombstone.Team, line.Tombstone.Channel)
+		r.user(line.Tombstone.User)
+		r.userList(line.Tombstone.ChannelMembers)
+	}
+}
+
+func (r *ImportRenames) applyUserTeams(teams *[]UserTeamImportData) {
+	if teams == nil {
+		return
+	}
+	for i := range *teams {
+		team := &(*teams)[i]
+		r.team(team.Name)
+		if team.Channels != nil {
+			for j := range *team.Channels {
+				r.channel(team.Name, (*team.Channels)[j].Name)
+			}
+		}
+		if team.Categories != nil {
+			for _, category := range *team.Categories {
+				if category.Channels == nil {
+					continue
+				}
+				for k := range *category.Channels {
+					r.channel(team.Name, &(*category.Channels)[k])
+				}
+			}
+		}
+	}
+}
+
+func (r *ImportRenames) applyPost(flaggedBy *[]string, reactions *[]ReactionImportData, replies *[]ReplyImportData, followers *[]ThreadFollowerImportData, acks *[]PostAcknowledgementImportData) {
+	r.userList(flaggedBy)
+	if reactions != nil {
+		for i := range *reactions {
+			r.user((*reactions)[i].User)
+		}
+	}
+	if followers != nil {
+		for i := range *followers {
+			r.user((*followers)[i].User)
+		}
+	}
+	if acks != nil {
+		for i := range *acks {
+			r.user((*acks)[i].User)
+		}
+	}
+	if replies != nil {
+		for i := range *replies {
+			reply := &(*replies)[i]
+			r.user(reply.User)
+			r.applyPost(reply.FlaggedBy, reply.Reactions, nil, nil, reply.Acknowledgements)
+		}
+	}
+}
@@ -0,0 +1,163 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package imports
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost/server/public/model"
+)
+
+func TestConflictPolicyIsValid(t *testing.T) {
+	for _, policy := range []ConflictPolicy{ConflictPolicyOverwrite, ConflictPolicySkip, ConflictPolicyMerge, ConflictPolicyRename} {
+		assert.True(t, policy.IsValid(), policy)
+	}
+	assert.False(t, ConflictPolicy("").IsValid())
+	assert.False(t, ConflictPolicy("replace").IsValid())
+}
+
+func TestImportReport(t *testing.T) {
+	report := NewImportReport(true, ConflictPolicyRename)
+
+	var wg sync.WaitGroup
+	for range 10 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			report.Record("user", ImportActionCreate)
+			report.Record("team", ImportActionUpdate)
+		}()
+	}
+	wg.Wait()
+
+	report.Record("user", ImportActionSkip)
+	report.Record("channel", ImportActionMerge)
+	report.RecordRename(ImportRename{Entity: "user", From: "alice", To: "alice-2"})
+
+	assert.Equal(t, []string{"channel", "team", "user"}, report.EntityTypes())
+	assert.Equal(t, &ImportEntityReport{Create: 10, Skip: 1, Rename: 1}, report.Entities["user"])
+	assert.Equal(t, &ImportEntityReport{Update: 10}, report.Entities["team"])
+	assert.Equal(t, &ImportEntityReport{Merge: 1}, report.Entities["channel"])
+	assert.Equal(t, []ImportRename{{Entity: "user", From: "alice", To: "alice-2"}}, report.Renames)
+}
+
+func TestImportRenamesApply(t *testing.T) {
+	renames := NewImportRenames()
+
+	t.Run("nothing renamed", func(t *testing.T) {
+		line := LineImportData{
+			Type:    "channel",
+			Channel: &ChannelImportData{Team: model.NewPointer("team")},
+		}
+		renames.Apply(&line)
+		assert.Equal(t, "team", *line.Channel.Team)
+	})
+
+	renames.RenameTeam("Team", "team-2")
+	renames.RenameChannel("team-2", "town", "town-2")
+	renames.RenameUser("alice", "alice-2")
+
+	t.Run("channel", func(t *testing.T) {
+		line := LineImportData{
+			Type:    "channel",
+			Channel: &ChannelImportData{Team: model.NewPointer("TEAM"), Name: model.NewPointer("town")},
+		}
+		renames.Apply(&line)
+		assert.Equal(t, "team-2", *line.Channel.Team)
+		// The channel line itself is renamed by the conflict resolution.
+		assert.Equal(t, "town", *line.Channel.Name)
+	})
+
+	t.Run("user memberships", func(t *testing.T) {
+		line := LineImportData{
+			Type: "user",
+			User: &UserImportData{
+				Username: model.NewPointer("alice"),
+				Teams: &[]UserTeamImportData{{
+					Name:     model.NewPointer("team"),
+					Channels: &[]UserChannelImportData{{Name: model.NewPointer("town")}, {Name: model.NewPointer("other")}},
+					Categories: &[]UserSidebarCategoryImportData{{
+						Channels: &[]string{"town"},
+					}},
+				}},
+			},
+		}
+		renames.Apply(&line)
+
+		team := (*line.User.Teams)[0]
+		assert.Equal(t, "alice", *line.User.Username)
+		assert.Equal(t, "team-2", *team.Name)
+		assert.Equal(t, "town-2", *(*team.Channels)[0].Name)
+		assert.Equal(t, "other", *(*team.Channels)[1].Name)
+		assert.Equal(t, []string{"town-2"}, *(*team.Categories)[0].Channels)
+	})
+
+	t.Run("post", func(t *testing.T) {
+		line := LineImportData{
+			Type: "post",
+			Post: &PostImportData{
+				Team:      model.NewPointer("team"),
+				Channel:   model.NewPointer("town"),
+				User:      model.NewPointer("alice"),
+				FlaggedBy: &[]string{"bob", "alice"},
+				Reactions: &[]ReactionImportData{{User: model.NewPointer("alice")}},
+				Replies: &[]ReplyImportData{{
+					User:             model.NewPointer("alice"),
+					Acknowledgements: &[]PostAcknowledgementImportData{{User: model.NewPointer("alice")}},
+				}},
+				ThreadFollowers: &[]ThreadFollowerImportData{{User: model.NewPointer("alice")}},
+			},
+		}
+		renames.Apply(&line)
+
+		require.NotNil(t, line.Post)
+		assert.Equal(t, "team-2", *line.Post.Team)
+		assert.Equal(t, "town-2", *line.Post.Channel)
+		assert.Equal(t, "alice-2", *line.Post.User)
+		assert.Equal(t, []string{"bob", "alice-2"}, *line.Post.FlaggedBy)
+		assert.Equal(t, "alice-2", *(*line.Post.Reactions)[0].User)
+		assert.Equal(t, "alice-2", *(*line.Post.Replies)[0].User)
+		assert.Equal(t, "alice-2", *(*(*line.Post.Replies)[0].Acknowledgements)[0].User)
+		assert.Equal(t, "alice-2", *(*line.Post.ThreadFollowers)[0].User)
+	})
+
+	t.Run("direct channel and post", func(t *testing.T) {
+		channelLine := LineImportData{
+			Type: "direct_channel",
+			DirectChannel: &DirectChannelImportData{
+				Members:      &[]string{"alice", "bob"},
+				Participants: []*DirectChannelMemberImportData{{Username: model.NewPointer("alice")}},
+				FavoritedBy:  &[]string{"alice"},
+			},
+		}
+		renames.Apply(&channelLine)
+		assert.Equal(t, []string{"alice-2", "bob"}, *channelLine.DirectChannel.Members)
+		assert.Equal(t, "alice-2", *channelLine.DirectChannel.Participants[0].Username)
+		assert.Equal(t, []string{"alice-2"}, *channelLine.DirectChannel.FavoritedBy)
+
+		postLine := LineImportData{
+			Type: "direct_post",
+			DirectPost: &DirectPostImportData{
+				ChannelMembers: &[]string{"alice", "bob"},
+				User:           model.NewPointer("bob"),
+			},
+		}
+		renames.Apply(&postLine)
+		assert.Equal(t, []string{"alice-2", "bob"}, *postLine.DirectPost.ChannelMembers)
+		assert.Equal(t, "bob", *postLine.DirectPost.User)
+	})
+
+	t.Run("bot owner", func(t *testing.T) {
+		line := LineImportData{
+			Type: "bot",
+			Bot:  &BotImportData{Username: model.NewPointer("bot"), Owner: model.NewPointer("alice")},
+		}
+		renames.Apply(&line)
+		assert.Equal(t, "alice-2", *line.Bot.Owner)
+	})
+}
@@ -13,25 +13,30 @@ import (
 // Import Data Models
 
 type LineImportData struct {
-	Type          string                   `json:"type"`
-	Role          *RoleImportData          `json:"role,omitempty"`
-	Scheme        *SchemeImportData        `json:"scheme,omitempty"`
-	Team          *TeamImportData          `json:"team,omitempty"`
-	Channel       *ChannelImportData       `json:"channel,omitempty"`
-	User          *UserImportData          `json:"user,omitempty"`
-	Bot           *BotImportData           `json:"bot,omitempty"`
-	Post          *PostImportData          `json:"post,omitempty"`
-	DirectChannel *DirectChannelImportData `json:"direct_channel,omitempty"`
-	DirectPost    *DirectPostImportData    `json:"direct_post,omitempty"`
-	Emoji         *EmojiImportData         `json:"emoji,omitempty"`
-	Version       *int                     `json:"version,omitempty"`
-	Info          *VersionInfoImportData   `json:"info,omitempty"`
+	Type          string                     `json:"type"`
+	Role          *RoleImportData            `json:"role,omitempty"`
+	Scheme        *SchemeImportData          `json:"scheme,omitempty"`
+	Team          *TeamImportData            `json:"team,omitempty"`
+	Channel       *ChannelImportData         `json:"channel,omitempty"`
+	User          *UserImportData            `json:"user,omitempty"`
+	Bot           *BotImportData             `json:"bot,omitempty"`
+	Post          *PostImportData            `json:"post,omitempty"`
+	DirectChannel *DirectChannelImportData   `json:"direct_channel,omitempty"`
+	DirectPost    *DirectPostImportData      `json:"direct_post,omitempty"`
+	Emoji         *EmojiImportData           `json:"emoji,omitempty"`
+	Bookmark      *ChannelBookmarkImportData `json:"channel_bookmark,omitempty"`
+	Tombstone     *TombstoneImportData       `json:"tombstone,omitempty"`
+	Version       *int                       `json:"version,omitempty"`
+	Info          *VersionInfoImportData     `json:"info,omitempty"`
 }
 
 type VersionInfoImportData struct {
-	Generator  string          `json:"generator"`
-	Version    string          `json:"version"`
-	Created    string          `json:"created"`
+	Generator string `json:"generator"`
+	Version   string `json:"version"`
+	Created   string `json:"created"`
+	// Since is set on incremental exports to the time in milliseconds
+	// from which changes were exported.
+	Since      int64           `json:"since,omitempty"`
 	Additional json.RawMessage `json:"additional,omitempty"`
 }
 
@@ -100,6 +105,8 @@ type UserImportData struct {
 
 	NotifyProps  *UserNotifyPropsImportData `json:"notify_props,omitempty"`
 	CustomStatus *model.CustomStatus        `json:"custom_status,omitempty"`
+
+	CustomProfileAttributes *[]UserCustomProfileAttributeImportData `json:"custom_profile_attributes,omitempty"`
 }
 
 type BotImportData struct {
@@ -130,6 +137,8 @@ type UserTeamImportData struct {
 	Roles    *string                  `json:"roles"`
 	Theme    *string                  `json:"theme,omitempty"`
 	Channels *[]UserChannelImportData `json:"channels,omitempty"`
+
+	Categories *[]UserSidebarCategoryImportData `json:"categories,omitempty"`
 }
 
 type UserChannelImportData struct {
@@ -193,6 +202,10 @@ type ReplyImportData struct {
 	Reactions   *[]ReactionImportData   `json:"reactions,omitempty"`
 	Attachments *[]AttachmentImportData `json:"attachments,omitempty"`
 	IsPinned    *bool                   `json:"is_pinned,omitempty"`
+
+	Priority         *PostPriorityImportData          `json:"priority,omitempty"`
+	Acknowledgements *[]PostAcknowledgementImportData `json:"acknowledgements,omitempty"`
+	EditHistory      *[]PostEditHistoryImportData     `json:"edit_history,omitempty"`
 }
 
 type PostImportData struct {
@@ -213,6 +226,10 @@ type PostImportData struct {
 	IsPinned    *bool                   `json:"is_pinned,omitempty"`
 
 	ThreadFollowers *[]ThreadFollowerImportData `json:"thread_followers,omitempty"`
+
+	Priority         *PostPriorityImportData          `json:"priority,omitempty"`
+	Acknowledgements *[]PostAcknowledgementImportData `json:"acknowledgements,omitempty"`
+	EditHistory      *[]PostEditHistoryImportData     `json:"edit_history,omitempty"`
 }
 
 type DirectChannelImportData struct {
@@ -241,6 +258,10 @@ type DirectPostImportData struct {
 	IsPinned    *bool                   `json:"is_pinned,omitempty"`
 
 	ThreadFollowers *[]ThreadFollowerImportData `json:"thread_followers,omitempty"`
+
+	Priority         *PostPriorityImportData          `json:"priority,omitempty"`
+	Acknowledgements *[]PostAcknowledgementImportData `json:"acknowledgements,omitempty"`
+	EditHistory      *[]PostEditHistoryImportData     `json:"edit_history,omitempty"`
 }
 
 type SchemeImportData struct {
@@ -291,3 +312,78 @@ type ThreadFollowerImportData struct {
 	LastViewed     *int64  `json:"last_viewed,omitempty"`
 	UnreadMentions *int64  `json:"unread_mentions,omitempty"`
 }
+
+type PostPriorityImportData struct {
+	Priority                *string `json:"priority"`
+	RequestedAck            *bool   `json:"requested_ack,omitempty"`
+	PersistentNotifications *bool   `json:"persistent_notifications,omitempty"`
+}
+
+type PostAcknowledgementImportData struct {
+	User           *string `json:"user"`
+	AcknowledgedAt *int64  `json:"acknowledged_at"`
+}
+
+// PostEditHistoryImportData is a previous version of a post, replaced by
+// an edit at ReplacedAt.
+type PostEditHistoryImportData struct {
+	Message    *string                `json:"message"`
+	Props      *model.StringInterface `json:"props,omitempty"`
+	EditAt     *int64                 `json:"edit_at,omitempty"`
+	ReplacedAt *int64                 `json:"replaced_at"`
+}
+
+type ChannelBookmarkImportData struct {
+	Team    *string `json:"team"`
+	Channel *string `json:"channel"`
+	// Owner is the username of the user who created the bookmark.
+	Owner       *string                    `json:"owner"`
+	Type        *model.ChannelBookmarkType `json:"type"`
+	DisplayName *string                    `json:"display_name"`
+	SortOrder   *int64                     `json:"sort_order,omitempty"`
+	LinkURL     *string                    `json:"link_url,omitempty"`
+	ImageURL    *string                    `json:"image_url,omitempty"`
+	Emoji       *string                    `json:"emoji,omitempty"`
+	CreateAt    *int64                     `json:"create_at,omitempty"`
+	File        *AttachmentImportData      `json:"file,omitempty"`
+}
+
+// TombstoneImportData records the deletion of an entity in an incremental
+// export. Entities are referenced the same way their own import lines
+// reference them, as ids differ between servers.
+type TombstoneImportData struct {
+	// Type is the type of the deleted entity: "post", "direct_post",
+	// "channel_bookmark", "team" or "emoji".
+	Type *string `json:"type"`
+	Team *string `json:"team,omitempty"`
+	// Channel is set for posts and channel bookmarks.
+	Channel *string `json:"channel,omitempty"`
+	// ChannelMembers is set for direct posts instead of Team and Channel.
+	ChannelMembers *[]string `json:"channel_members,omitempty"`
+	// User, Message and CreateAt identify a deleted post.
+	User     *string `json:"user,omitempty"`
+	Message  *string `json:"message,omitempty"`
+	CreateAt *int64  `json:"create_at,omitempty"`
+	// DisplayName identifies a deleted channel bookmark.
+	DisplayName *string `json:"display_name,omitempty"`
+	// Name identifies a deleted emoji.
+	Name     *string `json:"name,omitempty"`
+	DeleteAt *int64  `json:"delete_at"`
+}
+
+// UserCustomProfileAttributeImportData is the value of a custom profile
+// attribute of a user. The options of select fields are referenced by name
+// and the users of user fields by username, as their ids differ between
+// servers.
+type UserCustomProfileAttributeImportData struct {
+	Name  *string         `json:"name"`
+	Value json.RawMessage `json:"value"`
+}
+
+type UserSidebarCategoryImportData struct {
+	DisplayName *string                       `json:"display_name"`
+	Sorting     *model.SidebarCategorySorting `json:"sorting,omitempty"`
+	Muted       *bool                         `json:"muted,omitempty"`
+	Collapsed   *bool                         `json:"collapsed,omitempty"`
+	Channels    *[]string                     `json:"channels,omitempty"`
+}
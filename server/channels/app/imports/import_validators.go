@@ -8,6 +8,7 @@ import (
 	"net/http"
 	"os"
 	"path/filepath"
+	"slices"
 	"strings"
 	"unicode/utf8"
 
@@ -316,6 +317,10 @@ func ValidateUserImportData(data *UserImportData) *model.AppError {
 		return model.NewAppError("BulkImport", "app.import.validate_user_import_data.advanced_props_email_interval.error", nil, "", http.StatusBadRequest)
 	}
 
+	if err := ValidateUserCustomProfileAttributesImportData(data.CustomProfileAttributes); err != nil {
+		return err
+	}
+
 	if data.Teams != nil {
 		return ValidateUserTeamsImportData(data.Teams)
 	}
@@ -400,6 +405,10 @@ func ValidateUserTeamsImportData(data *[]UserTeamImportData) *model.AppError {
 			}
 		}
 
+		if err := ValidateUserSidebarCategoriesImportData(tdata.Categories); err != nil {
+			return err
+		}
+
 		if tdata.Theme != nil && strings.Trim(*tdata.Theme, " \t\r") != "" {
 			var unused map[string]string
 			if err := json.NewDecoder(strings.NewReader(*tdata.Theme)).Decode(&unused); err != nil {
@@ -505,7 +514,7 @@ func ValidateReplyImportData(data *ReplyImportData, parentCreateAt int64, maxPos
 		}
 	}
 
-	return nil
+	return validatePostMetadataImportData(data.Priority, data.Acknowledgements, data.EditHistory, *data.CreateAt, maxPostSize)
 }
 
 func ValidatePostImportData(data *PostImportData, maxPostSize int) *model.AppError {
@@ -571,7 +580,7 @@ func ValidatePostImportData(data *PostImportData, maxPostSize int) *model.AppErr
 		}
 	}
 
-	return nil
+	return validatePostMetadataImportData(data.Priority, data.Acknowledgements, data.EditHistory, *data.CreateAt, maxPostSize)
 }
 
 func ValidateDirectChannelImportData(data *DirectChannelImportData) *model.AppError {
@@ -703,7 +712,7 @@ func ValidateDirectPostImportData(data *DirectPostImportData, maxPostSize int) *
 		}
 	}
 
-	return nil
+	return validatePostMetadataImportData(data.Priority, data.Acknowledgements, data.EditHistory, *data.CreateAt, maxPostSize)
 }
 
 // ValidateEmojiImportData validates emoji data and returns if the import name
@@ -745,6 +754,254 @@ func ValidateThreadFollowerImportData(data *ThreadFollowerImportData) *model.App
 	return nil
 }
 
+var validPostPriorities = []string{
+	"",
+	model.PostPriorityUrgent,
+	model.PostPriorityImportant,
+}
+
+func ValidatePostPriorityImportData(data *PostPriorityImportData) *model.AppError {
+	if data == nil {
+		return nil
+	}
+
+	if data.Priority == nil {
+		return model.NewAppError("BulkImport", "app.import.validate_post_priority_import_data.priority_missing.error", nil, "", http.StatusBadRequest)
+	}
+
+	if !slices.Contains(validPostPriorities, *data.Priority) {
+		return model.NewAppError("BulkImport", "app.import.validate_post_priority_import_data.priority_invalid.error", map[string]any{"Priority": *data.Priority}, "", http.StatusBadRequest)
+	}
+
+	if data.PersistentNotifications != nil && *data.PersistentNotifications && *data.Priority != model.PostPriorityUrgent {
+		return model.NewAppError("BulkImport", "app.import.validate_post_priority_import_data.persistent_notifications.error", nil, "", http.StatusBadRequest)
+	}
+
+	return nil
+}
+
+func ValidatePostAcknowledgementImportData(data *PostAcknowledgementImportData, parentCreateAt int64) *model.AppError {
+	if data.User == nil || *data.User == "" {
+		return model.NewAppError("BulkImport", "app.import.validate_post_acknowledgement_import_data.user_missing.error", nil, "", http.StatusBadRequest)
+	}
+
+	if data.AcknowledgedAt == nil || *data.AcknowledgedAt == 0 {
+		return model.NewAppError("BulkImport", "app.import.validate_post_acknowledgement_import_data.acknowledged_at_missing.error", nil, "", http.StatusBadRequest)
+	} else if *data.AcknowledgedAt < parentCreateAt {
+		return model.NewAppError("BulkImport", "app.import.validate_post_acknowledgement_import_data.acknowledged_at_before_parent.error", nil, "", http.StatusBadRequest)
+	}
+
+	return nil
+}
+
+func ValidatePostEditHistoryImportData(data *PostEditHistoryImportData, parentCreateAt int64, maxPostSize int) *model.AppError {
+	if data.Message == nil {
+		return model.NewAppError("BulkImport", "app.import.validate_post_edit_history_import_data.message_missing.error", nil, "", http.StatusBadRequest)
+	} else if utf8.RuneCountInString(*data.Message) > maxPostSize {
+		return model.NewAppError("BulkImport", "app.import.validate_post_edit_history_import_data.message_length.error", nil, "", http.StatusBadRequest)
+	}
+
+	if data.ReplacedAt == nil || *data.ReplacedAt == 0 {
+		return model.NewAppError("BulkImport", "app.import.validate_post_edit_history_import_data.replaced_at_missing.error", nil, "", http.StatusBadRequest)
+	} else if *data.ReplacedAt < parentCreateAt {
+		return model.NewAppError("BulkImport", "app.import.validate_post_edit_history_import_data.replaced_at_before_parent.error", nil, "", http.StatusBadRequest)
+	}
+
+	if data.Props != nil && utf8.RuneCountInString(model.StringInterfaceToJSON(*data.Props)) > model.PostPropsMaxRunes {
+		return model.NewAppError("BulkImport", "app.import.validate_post_edit_history_import_data.props_too_large.error", nil, "", http.StatusBadRequest)
+	}
+
+	return nil
+}
+
+// validatePostMetadataImportData validates the priority, acknowledgements and
+// edit history shared by posts, replies and direct posts.
+func validatePostMetadataImportData(priority *PostPriorityImportData, acks *[]PostAcknowledgementImportData, editHistory *[]PostEditHistoryImportData, createAt int64, maxPostSize int) *model.AppError {
+	if err := ValidatePostPriorityImportData(priority); err != nil {
+		return err
+	}
+
+	if acks != nil {
+		for _, ack := range *acks {
+			if err := ValidatePostAcknowledgementImportData(&ack, createAt); err != nil {
+				return err
+			}
+		}
+	}
+
+	if editHistory != nil {
+		for _, edit := range *editHistory {
+			if err := ValidatePostEditHistoryImportData(&edit, createAt, maxPostSize); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+func ValidateChannelBookmarkImportData(data *ChannelBookmarkImportData) *model.AppError {
+	if data == nil {
+		return model.NewAppError("BulkImport", "app.import.validate_channel_bookmark_import_data.empty.error", nil, "", http.StatusBadRequest)
+	}
+
+	if data.Team == nil || *data.Team == "" {
+		return model.NewAppError("BulkImport", "app.import.validate_channel_bookmark_import_data.team_missing.error", nil, "", http.StatusBadRequest)
+	}
+
+	if data.Channel == nil || *data.Channel == "" {
+		return model.NewAppError("BulkImport", "app.import.validate_channel_bookmark_import_data.channel_missing.error", nil, "", http.StatusBadRequest)
+	}
+
+	if data.Owner == nil || *data.Owner == "" {
+		return model.NewAppError("BulkImport", "app.import.validate_channel_bookmark_import_data.owner_missing.error", nil, "", http.StatusBadRequest)
+	}
+
+	if data.DisplayName == nil || *data.DisplayName == "" || utf8.RuneCountInString(*data.DisplayName) > model.DisplayNameMaxRunes {
+		return model.NewAppError("BulkImport", "app.import.validate_channel_bookmark_import_data.display_name_invalid.error", nil, "", http.StatusBadRequest)
+	}
+
+	if data.Type == nil {
+		return model.NewAppError("BulkImport", "app.import.validate_channel_bookmark_import_data.type_invalid.error", nil, "", http.StatusBadRequest)
+	}
+
+	switch *data.Type {
+	case model.ChannelBookmarkLink:
+		if data.LinkURL == nil || !model.IsValidHTTPURL(*data.LinkURL) || utf8.RuneCountInString(*data.LinkURL) > model.LinkMaxRunes {
+			return model.NewAppError("BulkImport", "app.import.validate_channel_bookmark_import_data.link_url_invalid.error", nil, "", http.StatusBadRequest)
+		}
+		if data.File != nil {
+			return model.NewAppError("BulkImport", "app.import.validate_channel_bookmark_import_data.link_with_file.error", nil, "", http.StatusBadRequest)
+		}
+	case model.ChannelBookmarkFile:
+		if data.File == nil || data.File.Path == nil || *data.File.Path == "" {
+			return model.NewAppError("BulkImport", "app.import.validate_channel_bookmark_import_data.file_missing.error", nil, "", http.StatusBadRequest)
+		}
+		if data.LinkURL != nil && *data.LinkURL != "" {
+			return model.NewAppError("BulkImport", "app.import.validate_channel_bookmark_import_data.file_with_link.error", nil, "", http.StatusBadRequest)
+		}
+		if err := ValidateAttachmentImportData(data.File); err != nil {
+			return err
+		}
+	default:
+		return model.NewAppError("BulkImport", "app.import.validate_channel_bookmark_import_data.type_invalid.error", nil, "", http.StatusBadRequest)
+	}
+
+	if data.ImageURL != nil && *data.ImageURL != "" && (!model.IsValidHTTPURL(*data.ImageURL) || utf8.RuneCountInString(*data.ImageURL) > model.LinkMaxRunes) {
+		return model.NewAppError("BulkImport", "app.import.validate_channel_bookmark_import_data.image_url_invalid.error", nil, "", http.StatusBadRequest)
+	}
+
+	return nil
+}
+
+func ValidateTombstoneImportData(data *TombstoneImportData) *model.AppError {
+	if data == nil {
+		return model.NewAppError("BulkImport", "app.import.validate_tombstone_import_data.empty.error", nil, "", http.StatusBadRequest)
+	}
+
+	if data.DeleteAt == nil || *data.DeleteAt == 0 {
+		return model.NewAppError("BulkImport", "app.import.validate_tombstone_import_data.delete_at_missing.error", nil, "", http.StatusBadRequest)
+	}
+
+	if data.Type == nil {
+		return model.NewAppError("BulkImport", "app.import.validate_tombstone_import_data.type_invalid.error", nil, "", http.StatusBadRequest)
+	}
+
+	switch *data.Type {
+	case "team":
+		if data.Team == nil || *data.Team == "" {
+			return model.NewAppError("BulkImport", "app.import.validate_tombstone_import_data.team_missing.error", nil, "", http.StatusBadRequest)
+		}
+	case "channel_bookmark":
+		if data.Team == nil || *data.Team == "" {
+			return model.NewAppError("BulkImport", "app.import.validate_tombstone_import_data.team_missing.error", nil, "", http.StatusBadRequest)
+		}
+		if data.Channel == nil || *data.Channel == "" {
+			return model.NewAppError("BulkImport", "app.import.validate_tombstone_import_data.channel_missing.error", nil, "", http.StatusBadRequest)
+		}
+		if data.DisplayName == nil || *data.DisplayName == "" {
+			return model.NewAppError("BulkImport", "app.import.validate_tombstone_import_data.display_name_missing.error", nil, "", http.StatusBadRequest)
+		}
+	case "emoji":
+		if data.Name == nil || *data.Name == "" {
+			return model.NewAppError("BulkImport", "app.import.validate_tombstone_import_data.name_missing.error", nil, "", http.StatusBadRequest)
+		}
+	case "post", "direct_post":
+		if *data.Type == "post" {
+			if data.Team == nil || *data.Team == "" {
+				return model.NewAppError("BulkImport", "app.import.validate_tombstone_import_data.team_missing.error", nil, "", http.StatusBadRequest)
+			}
+			if data.Channel == nil || *data.Channel == "" {
+				return model.NewAppError("BulkImport", "app.import.validate_tombstone_import_data.channel_missing.error", nil, "", http.StatusBadRequest)
+			}
+		} else if data.ChannelMembers == nil || len(*data.ChannelMembers) < 2 || len(*data.ChannelMembers) > model.ChannelGroupMaxUsers {
+			return model.NewAppError("BulkImport", "app.import.validate_tombstone_import_data.channel_members_invalid.error", nil, "", http.StatusBadRequest)
+		}
+		if data.User == nil || *data.User == "" {
+			return model.NewAppError("BulkImport", "app.import.validate_tombstone_import_data.user_missing.error", nil, "", http.StatusBadRequest)
+		}
+		if data.Message == nil {
+			return model.NewAppError("BulkImport", "app.import.validate_tombstone_import_data.message_missing.error", nil, "", http.StatusBadRequest)
+		}
+		if data.CreateAt == nil || *data.CreateAt == 0 {
+			return model.NewAppError("BulkImport", "app.import.validate_tombstone_import_data.create_at_missing.error", nil, "", http.StatusBadRequest)
+		}
+	default:
+		return model.NewAppError("BulkImport", "app.import.validate_tombstone_import_data.type_invalid.error", nil, "", http.StatusBadRequest)
+	}
+
+	return nil
+}
+
+func ValidateUserCustomProfileAttributesImportData(data *[]UserCustomProfileAttributeImportData) *model.AppError {
+	if data == nil {
+		return nil
+	}
+
+	seen := make(map[string]bool, len(*data))
+	for _, attr := range *data {
+		if attr.Name == nil || *attr.Name == "" {
+			return model.NewAppError("BulkImport", "app.import.validate_user_custom_profile_attributes_import_data.name_missing.error", nil, "", http.StatusBadRequest)
+		}
+
+		if seen[*attr.Name] {
+			return model.NewAppError("BulkImport", "app.import.validate_user_custom_profile_attributes_import_data.duplicate.error", map[string]any{"Name": *attr.Name}, "", http.StatusBadRequest)
+		}
+		seen[*attr.Name] = true
+
+		if len(attr.Value) == 0 || !json.Valid(attr.Value) {
+			return model.NewAppError("BulkImport", "app.import.validate_user_custom_profile_attributes_import_data.value_invalid.error", map[string]any{"Name": *attr.Name}, "", http.StatusBadRequest)
+		}
+	}
+
+	return nil
+}
+
+func ValidateUserSidebarCategoriesImportData(data *[]UserSidebarCategoryImportData) *model.AppError {
+	if data == nil {
+		return nil
+	}
+
+	for _, cdata := range *data {
+		if cdata.DisplayName == nil || *cdata.DisplayName == "" || utf8.RuneCountInString(*cdata.DisplayName) > model.ChannelDisplayNameMaxRunes {
+			return model.NewAppError("BulkImport", "app.import.validate_user_sidebar_categories_import_data.display_name_invalid.error", nil, "", http.StatusBadRequest)
+		}
+
+		if cdata.Sorting != nil && !isValidSidebarCategorySorting(*cdata.Sorting) {
+			return model.NewAppError("BulkImport", "app.import.validate_user_sidebar_categories_import_data.sorting_invalid.error", nil, "", http.StatusBadRequest)
+		}
+	}
+
+	return nil
+}
+
+func isValidSidebarCategorySorting(sorting model.SidebarCategorySorting) bool {
+	return sorting == model.SidebarCategorySortDefault ||
+		sorting == model.SidebarCategorySortManual ||
+		sorting == model.SidebarCategorySortRecent ||
+		sorting == model.SidebarCategorySortAlphabetical
+}
+
 func isValidTrueOrFalseString(value string) bool {
 	return value == "true" || value == "false"
 }
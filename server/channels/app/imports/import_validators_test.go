@@ -4,6 +4,7 @@
 package imports
 
 import (
+	"encoding/json"
 	"fmt"
 	"path/filepath"
 	"strings"
@@ -1601,6 +1602,298 @@ func checkNoError(t *testing.T, err *model.AppError) {
 	require.Nil(t, err, "Unexpected Error: %v", err)
 }
 
+func TestImportValidatePostPriorityImportData(t *testing.T) {
+	testCases := []struct {
+		testName    string
+		input       *PostPriorityImportData
+		expectError bool
+	}{
+		{
+			testName:    "nil",
+			input:       nil,
+			expectError: false,
+		},
+		{
+			testName:    "urgent with persistent notifications",
+			input:       &PostPriorityImportData{Priority: model.NewPointer(model.PostPriorityUrgent), RequestedAck: model.NewPointer(true), PersistentNotifications: model.NewPointer(true)},
+			expectError: false,
+		},
+		{
+			testName:    "empty priority with requested ack",
+			input:       &PostPriorityImportData{Priority: model.NewPointer(""), RequestedAck: model.NewPointer(true)},
+			expectError: false,
+		},
+		{
+			testName:    "missing priority",
+			input:       &PostPriorityImportData{},
+			expectError: true,
+		},
+		{
+			testName:    "unknown priority",
+			input:       &PostPriorityImportData{Priority: model.NewPointer("critical")},
+			expectError: true,
+		},
+		{
+			testName:    "persistent notifications on important post",
+			input:       &PostPriorityImportData{Priority: model.NewPointer(model.PostPriorityImportant), PersistentNotifications: model.NewPointer(true)},
+			expectError: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.testName, func(t *testing.T) {
+			err := ValidatePostPriorityImportData(tc.input)
+			if tc.expectError {
+				require.NotNil(t, err)
+			} else {
+				assert.Nil(t, err)
+			}
+		})
+	}
+}
+
+func TestImportValidatePostAcknowledgementImportData(t *testing.T) {
+	data := PostAcknowledgementImportData{User: model.NewPointer("user1"), AcknowledgedAt: model.NewPointer(int64(2000))}
+	require.Nil(t, ValidatePostAcknowledgementImportData(&data, 1000))
+
+	data = PostAcknowledgementImportData{AcknowledgedAt: model.NewPointer(int64(2000))}
+	require.NotNil(t, ValidatePostAcknowledgementImportData(&data, 1000), "missing user")
+
+	data = PostAcknowledgementImportData{User: model.NewPointer("user1")}
+	require.NotNil(t, ValidatePostAcknowledgementImportData(&data, 1000), "missing acknowledged_at")
+
+	data = PostAcknowledgementImportData{User: model.NewPointer("user1"), AcknowledgedAt: model.NewPointer(int64(500))}
+	require.NotNil(t, ValidatePostAcknowledgementImportData(&data, 1000), "acknowledged before the post")
+}
+
+func TestImportValidatePostEditHistoryImportData(t *testing.T) {
+	maxPostSize := 10000
+
+	data := PostEditHistoryImportData{Message: model.NewPointer("message"), ReplacedAt: model.NewPointer(int64(2000))}
+	require.Nil(t, ValidatePostEditHistoryImportData(&data, 1000, maxPostSize))
+
+	data = PostEditHistoryImportData{ReplacedAt: model.NewPointer(int64(2000))}
+	require.NotNil(t, ValidatePostEditHistoryImportData(&data, 1000, maxPostSize), "missing message")
+
+	data = PostEditHistoryImportData{Message: model.NewPointer(strings.Repeat("0", maxPostSize+1)), ReplacedAt: model.NewPointer(int64(2000))}
+	require.NotNil(t, ValidatePostEditHistoryImportData(&data, 1000, maxPostSize), "message too long")
+
+	data = PostEditHistoryImportData{Message: model.NewPointer("message")}
+	require.NotNil(t, ValidatePostEditHistoryImportData(&data, 1000, maxPostSize), "missing replaced_at")
+
+	data = PostEditHistoryImportData{Message: model.NewPointer("message"), ReplacedAt: model.NewPointer(int64(500))}
+	require.NotNil(t, ValidatePostEditHistoryImportData(&data, 1000, maxPostSize), "replaced before the post")
+
+	t.Run("wired into post validation", func(t *testing.T) {
+		post := PostImportData{
+			Team:        model.NewPointer("teamname"),
+			Channel:     model.NewPointer("channelname"),
+			User:        model.NewPointer("username"),
+			Message:     model.NewPointer("message"),
+			CreateAt:    model.NewPointer(int64(1000)),
+			EditHistory: &[]PostEditHistoryImportData{{Message: model.NewPointer("message"), ReplacedAt: model.NewPointer(int64(500))}},
+		}
+		require.NotNil(t, ValidatePostImportData(&post, maxPostSize))
+
+		post.EditHistory = &[]PostEditHistoryImportData{{Message: model.NewPointer("message"), ReplacedAt: model.NewPointer(int64(1500))}}
+		post.Acknowledgements = &[]PostAcknowledgementImportData{{User: model.NewPointer("user1")}}
+		require.NotNil(t, ValidatePostImportData(&post, maxPostSize))
+
+		post.Acknowledgements = &[]PostAcknowledgementImportData{{User: model.NewPointer("user1"), AcknowledgedAt: model.NewPointer(int64(1500))}}
+		post.Priority = &PostPriorityImportData{Priority: model.NewPointer(model.PostPriorityUrgent), RequestedAck: model.NewPointer(true)}
+		require.Nil(t, ValidatePostImportData(&post, maxPostSize))
+	})
+}
+
+func TestImportValidateChannelBookmarkImportData(t *testing.T) {
+	validLink := func() *ChannelBookmarkImportData {
+		return &ChannelBookmarkImportData{
+			Team:        model.NewPointer("teamname"),
+			Channel:     model.NewPointer("channelname"),
+			Owner:       model.NewPointer("username"),
+			Type:        model.NewPointer(model.ChannelBookmarkLink),
+			DisplayName: model.NewPointer("Docs"),
+			LinkURL:     model.NewPointer("https://example.com/docs"),
+		}
+	}
+
+	require.Nil(t, ValidateChannelBookmarkImportData(validLink()))
+	require.NotNil(t, ValidateChannelBookmarkImportData(nil))
+
+	testCases := []struct {
+		testName string
+		modify   func(*ChannelBookmarkImportData)
+	}{
+		{"missing team", func(d *ChannelBookmarkImportData) { d.Team = nil }},
+		{"missing channel", func(d *ChannelBookmarkImportData) { d.Channel = model.NewPointer("") }},
+		{"missing owner", func(d *ChannelBookmarkImportData) { d.Owner = nil }},
+		{"missing display name", func(d *ChannelBookmarkImportData) { d.DisplayName = nil }},
+		{"display name too long", func(d *ChannelBookmarkImportData) {
+			d.DisplayName = model.NewPointer(strings.Repeat("a", model.DisplayNameMaxRunes+1))
+		}},
+		{"missing type", func(d *ChannelBookmarkImportData) { d.Type = nil }},
+		{"unknown type", func(d *ChannelBookmarkImportData) { d.Type = model.NewPointer(model.ChannelBookmarkType("note")) }},
+		{"invalid link", func(d *ChannelBookmarkImportData) { d.LinkURL = model.NewPointer("not a url") }},
+		{"link with file", func(d *ChannelBookmarkImportData) {
+			d.File = &AttachmentImportData{Path: model.NewPointer("data/file.txt")}
+		}},
+		{"invalid image url", func(d *ChannelBookmarkImportData) { d.ImageURL = model.NewPointer("not a url") }},
+		{"file without path", func(d *ChannelBookmarkImportData) {
+			d.Type = model.NewPointer(model.ChannelBookmarkFile)
+			d.LinkURL = nil
+		}},
+		{"file with link", func(d *ChannelBookmarkImportData) {
+			d.Type = model.NewPointer(model.ChannelBookmarkFile)
+			d.File = &AttachmentImportData{Path: model.NewPointer("data/file.txt")}
+		}},
+		{"file outside of the import", func(d *ChannelBookmarkImportData) {
+			d.Type = model.NewPointer(model.ChannelBookmarkFile)
+			d.LinkURL = nil
+			d.File = &AttachmentImportData{Path: model.NewPointer("../../etc/passwd")}
+		}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.testName, func(t *testing.T) {
+			data := validLink()
+			tc.modify(data)
+			require.NotNil(t, ValidateChannelBookmarkImportData(data))
+		})
+	}
+
+	t.Run("file", func(t *testing.T) {
+		data := validLink()
+		data.Type = model.NewPointer(model.ChannelBookmarkFile)
+		data.LinkURL = nil
+		data.File = &AttachmentImportData{Path: model.NewPointer("data/file.txt")}
+		require.Nil(t, ValidateChannelBookmarkImportData(data))
+	})
+}
+
+func TestImportValidateTombstoneImportData(t *testing.T) {
+	validPost := func() *TombstoneImportData {
+		return &TombstoneImportData{
+			Type:     model.NewPointer("post"),
+			Team:     model.NewPointer("teamname"),
+			Channel:  model.NewPointer("channelname"),
+			User:     model.NewPointer("username"),
+			Message:  model.NewPointer("message"),
+			CreateAt: model.NewPointer(model.GetMillis()),
+			DeleteAt: model.NewPointer(model.GetMillis()),
+		}
+	}
+
+	require.Nil(t, ValidateTombstoneImportData(validPost()))
+	require.NotNil(t, ValidateTombstoneImportData(nil))
+
+	require.Nil(t, ValidateTombstoneImportData(&TombstoneImportData{
+		Type:     model.NewPointer("team"),
+		Team:     model.NewPointer("teamname"),
+		DeleteAt: model.NewPointer(model.GetMillis()),
+	}))
+
+	require.Nil(t, ValidateTombstoneImportData(&TombstoneImportData{
+		Type:        model.NewPointer("channel_bookmark"),
+		Team:        model.NewPointer("teamname"),
+		Channel:     model.NewPointer("channelname"),
+		DisplayName: model.NewPointer("Docs"),
+		DeleteAt:    model.NewPointer(model.GetMillis()),
+	}))
+
+	require.Nil(t, ValidateTombstoneImportData(&TombstoneImportData{
+		Type:     model.NewPointer("emoji"),
+		Name:     model.NewPointer("emojiname"),
+		DeleteAt: model.NewPointer(model.GetMillis()),
+	}))
+
+	require.Nil(t, ValidateTombstoneImportData(&TombstoneImportData{
+		Type:           model.NewPointer("direct_post"),
+		ChannelMembers: &[]string{"username", "username2"},
+		User:           model.NewPointer("username"),
+		Message:        model.NewPointer("message"),
+		CreateAt:       model.NewPointer(model.GetMillis()),
+		DeleteAt:       model.NewPointer(model.GetMillis()),
+	}))
+
+	testCases := []struct {
+		testName string
+		modify   func(*TombstoneImportData)
+	}{
+		{"missing type", func(d *TombstoneImportData) { d.Type = nil }},
+		{"unknown type", func(d *TombstoneImportData) { d.Type = model.NewPointer("reaction") }},
+		{"missing delete at", func(d *TombstoneImportData) { d.DeleteAt = nil }},
+		{"zero delete at", func(d *TombstoneImportData) { d.DeleteAt = model.NewPointer(int64(0)) }},
+		{"missing team", func(d *TombstoneImportData) { d.Team = nil }},
+		{"missing channel", func(d *TombstoneImportData) { d.Channel = model.NewPointer("") }},
+		{"missing user", func(d *TombstoneImportData) { d.User = nil }},
+		{"missing message", func(d *TombstoneImportData) { d.Message = nil }},
+		{"missing create at", func(d *TombstoneImportData) { d.CreateAt = nil }},
+		{"bookmark without display name", func(d *TombstoneImportData) { d.Type = model.NewPointer("channel_bookmark") }},
+		{"emoji without name", func(d *TombstoneImportData) { d.Type = model.NewPointer("emoji") }},
+		{"direct post without channel members", func(d *TombstoneImportData) { d.Type = model.NewPointer("direct_post") }},
+		{"direct post with one channel member", func(d *TombstoneImportData) {
+			d.Type = model.NewPointer("direct_post")
+			d.ChannelMembers = &[]string{"username"}
+		}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.testName, func(t *testing.T) {
+			data := validPost()
+			tc.modify(data)
+			require.NotNil(t, ValidateTombstoneImportData(data))
+		})
+	}
+}
+
+func TestImportValidateUserCustomProfileAttributesImportData(t *testing.T) {
+	require.Nil(t, ValidateUserCustomProfileAttributesImportData(nil))
+
+	data := []UserCustomProfileAttributeImportData{
+		{Name: model.NewPointer("Department"), Value: json.RawMessage(`"Engineering"`)},
+		{Name: model.NewPointer("Skills"), Value: json.RawMessage(`["Go","SQL"]`)},
+	}
+	require.Nil(t, ValidateUserCustomProfileAttributesImportData(&data))
+
+	data = []UserCustomProfileAttributeImportData{{Value: json.RawMessage(`"Engineering"`)}}
+	require.NotNil(t, ValidateUserCustomProfileAttributesImportData(&data), "missing name")
+
+	data = []UserCustomProfileAttributeImportData{{Name: model.NewPointer("Department")}}
+	require.NotNil(t, ValidateUserCustomProfileAttributesImportData(&data), "missing value")
+
+	data = []UserCustomProfileAttributeImportData{{Name: model.NewPointer("Department"), Value: json.RawMessage(`{`)}}
+	require.NotNil(t, ValidateUserCustomProfileAttributesImportData(&data), "invalid value")
+
+	data = []UserCustomProfileAttributeImportData{
+		{Name: model.NewPointer("Department"), Value: json.RawMessage(`"Engineering"`)},
+		{Name: model.NewPointer("Department"), Value: json.RawMessage(`"Sales"`)},
+	}
+	require.NotNil(t, ValidateUserCustomProfileAttributesImportData(&data), "duplicate name")
+}
+
+func TestImportValidateUserSidebarCategoriesImportData(t *testing.T) {
+	require.Nil(t, ValidateUserSidebarCategoriesImportData(nil))
+
+	data := []UserSidebarCategoryImportData{{
+		DisplayName: model.NewPointer("Projects"),
+		Sorting:     model.NewPointer(model.SidebarCategorySortAlphabetical),
+		Channels:    &[]string{"channel1", "channel2"},
+	}}
+	require.Nil(t, ValidateUserSidebarCategoriesImportData(&data))
+
+	data = []UserSidebarCategoryImportData{{}}
+	require.NotNil(t, ValidateUserSidebarCategoriesImportData(&data), "missing display name")
+
+	data = []UserSidebarCategoryImportData{{DisplayName: model.NewPointer(strings.Repeat("a", model.ChannelDisplayNameMaxRunes+1))}}
+	require.NotNil(t, ValidateUserSidebarCategoriesImportData(&data), "display name too long")
+
+	data = []UserSidebarCategoryImportData{{DisplayName: model.NewPointer("Projects"), Sorting: model.NewPointer(model.SidebarCategorySorting("random"))}}
+	require.NotNil(t, ValidateUserSidebarCategoriesImportData(&data), "invalid sorting")
+
+	teams := []UserTeamImportData{{Name: model.NewPointer("teamname"), Categories: &data}}
+	require.NotNil(t, ValidateUserTeamsImportData(&teams), "validated as part of the team memberships")
+}
+
 func TestIsValidGuestRoles(t *testing.T) {
 	testCases := []struct {
 		name     string
@@ -99,10 +99,14 @@ func (a *App) SessionHasPermissionToCreateJob(session model.Session, job *model.
 		model.JobTypeCloud,
 		model.JobTypeExtractContent:
 		return a.SessionHasPermissionTo(session, model.PermissionManageJobs), model.PermissionManageJobs
-	case model.JobTypeAccessControlSync:
+	case model.JobTypeAccessControlSync, model.JobTypeUserErasure:
 		return a.SessionHasPermissionTo(session, model.PermissionManageSystem), model.PermissionManageSystem
 	}
 
+	if model.IsPluginJobType(job.Type) {
+		return a.SessionHasPermissionTo(session, model.PermissionManageJobs), model.PermissionManageJobs
+	}
+
 	return false, nil
 }
 
@@ -132,13 +136,18 @@ func (a *App) SessionHasPermissionToManageJob(session model.Session, job *model.
 		model.JobTypeImportDelete,
 		model.JobTypeExportProcess,
 		model.JobTypeExportDelete,
+		model.JobTypeUserDataExport,
 		model.JobTypeCloud,
 		model.JobTypeExtractContent:
 		permission = model.PermissionManageJobs
-	case model.JobTypeAccessControlSync:
+	case model.JobTypeAccessControlSync, model.JobTypeUserErasure:
 		permission = model.PermissionManageSystem
 	}
 
+	if permission == nil && model.IsPluginJobType(job.Type) {
+		permission = model.PermissionManageJobs
+	}
+
 	if permission == nil {
 		return false, nil
 	}
@@ -169,13 +178,18 @@ func (a *App) SessionHasPermissionToReadJob(session model.Session, jobType strin
 		model.JobTypeImportDelete,
 		model.JobTypeExportProcess,
 		model.JobTypeExportDelete,
+		model.JobTypeUserDataExport,
 		model.JobTypeCloud,
 		model.JobTypeMobileSessionMetadata,
 		model.JobTypeExtractContent:
 		return a.SessionHasPermissionTo(session, model.PermissionReadJobs), model.PermissionReadJobs
-	case model.JobTypeAccessControlSync:
+	case model.JobTypeAccessControlSync, model.JobTypeUserErasure:
 		return a.SessionHasPermissionTo(session, model.PermissionManageSystem), model.PermissionManageSystem
 	}
 
+	if model.IsPluginJobType(jobType) {
+		return a.SessionHasPermissionTo(session, model.PermissionReadJobs), model.PermissionReadJobs
+	}
+
 	return false, nil
 }
@@ -20,6 +20,7 @@ import (
 	"github.com/mattermost/mattermost/server/public/shared/request"
 	"github.com/mattermost/mattermost/server/v8/channels/store"
 	"github.com/mattermost/mattermost/server/v8/platform/services/telemetry"
+	"github.com/mattermost/mattermost/server/v8/platform/services/tracing"
 )
 
 func (a *App) canSendPushNotifications() bool {
@@ -45,6 +46,9 @@ func (a *App) canSendPushNotifications() bool {
 }
 
 func (a *App) SendNotifications(c request.CTX, post *model.Post, team *model.Team, channel *model.Channel, sender *model.User, parentPostList *model.PostList, setOnline bool) ([]string, error) {
+	c, span := tracing.StartRequestSpan(c, "app:SendNotifications")
+	defer span.End()
+
 	// Do not send notifications in archived channels
 	if channel.DeleteAt > 0 {
 		return []string{}, nil
@@ -771,7 +775,7 @@ func (a *App) SendNotifications(c request.CTX, post *model.Post, team *model.Tea
 					}
 					threadMembership = tm
 				}
-				userThread, err := a.Srv().Store().Thread().GetThreadForUser(threadMembership, true, a.IsPostPriorityEnabled())
+				userThread, err := a.Srv().Store().Thread().GetThreadForUser(c, threadMembership, true, a.IsPostPriorityEnabled())
 				if err != nil {
 					a.CountNotificationReason(model.NotificationStatusError, model.NotificationTypeWebsocket, model.NotificationReasonFetchError, model.NotificationNoPlatform)
 					a.NotificationsLog().Error("Missing thread",
@@ -996,7 +1000,7 @@ func (a *App) RemoveNotifications(c request.CTX, post *model.Post, channel *mode
 				return err
 			}
 
-			userThread, err := a.Srv().Store().Thread().GetThreadForUser(threadMembership, true, a.IsPostPriorityEnabled())
+			userThread, err := a.Srv().Store().Thread().GetThreadForUser(c, threadMembership, true, a.IsPostPriorityEnabled())
 			if err != nil {
 				return err
 			}
@@ -18,6 +18,7 @@ import (
 	"github.com/mattermost/mattermost/server/public/shared/request"
 	email "github.com/mattermost/mattermost/server/v8/channels/app/email"
 	"github.com/mattermost/mattermost/server/v8/channels/utils"
+	"github.com/mattermost/mattermost/server/v8/platform/shared/templates"
 )
 
 func (a *App) sendNotificationEmail(c request.CTX, notification *PostNotification, user *model.User, team *model.Team, senderProfileImage []byte) error {
@@ -108,11 +109,33 @@ func (a *App) sendNotificationEmail(c request.CTX, notification *PostNotificatio
 
 	landingURL := a.GetSiteURL() + "/landing#/" + team.Name
 
-	var bodyText, err = a.getNotificationEmailBody(c, user, post, channel, channelName, senderName, team.Name, landingURL, emailNotificationContentsType, useMilitaryTime, translateFunc, senderPhoto)
+	data := a.getNotificationEmailTemplateData(c, user, post, channel, channelName, senderName, team.Name, landingURL, emailNotificationContentsType, useMilitaryTime, translateFunc, senderPhoto)
+	bodyText, err := a.Srv().TemplatesContainer().RenderToString("messages_notification", data)
 	if err != nil {
 		return errors.Wrap(err, "unable to render the email notification template")
 	}
 
+	emailNotification, rejectionReason := email.RunEmailNotificationWillBeSentHook(a.ch, &model.EmailNotification{
+		Type:         model.EmailNotificationTypeImmediate,
+		RecipientId:  user.Id,
+		To:           user.Email,
+		Subject:      html.UnescapeString(subjectText),
+		Body:         bodyText,
+		TemplateData: email.TemplateDataStrings(data),
+		PostIds:      []string{post.Id},
+	})
+	if rejectionReason != "" {
+		a.NotificationsLog().Debug("Email notification rejected by plugin",
+			mlog.String("type", model.NotificationTypeEmail),
+			mlog.String("status", model.NotificationStatusNotSent),
+			mlog.String("reason", model.NotificationReasonRejectedByPlugin),
+			mlog.String("rejection_reason", rejectionReason),
+			mlog.String("user_id", user.Id),
+			mlog.String("post_id", post.Id),
+		)
+		return nil
+	}
+
 	templateString := "<%s@" + utils.GetHostnameFromSiteURL(a.GetSiteURL()) + ">"
 	messageID := ""
 	inReplyTo := ""
@@ -129,8 +152,8 @@ func (a *App) sendNotificationEmail(c request.CTX, notification *PostNotificatio
 	}
 
 	a.Srv().Go(func() {
-		if nErr := a.Srv().EmailService.SendMailWithEmbeddedFiles(user.Email, html.UnescapeString(subjectText), bodyText, embeddedFiles, messageID, inReplyTo, references, "Notification"); nErr != nil {
-			c.Logger().Error("Error while sending the email", mlog.String("user_email", user.Email), mlog.Err(nErr))
+		if nErr := a.Srv().EmailService.SendMailWithEmbeddedFiles(emailNotification.To, emailNotification.Subject, emailNotification.Body, embeddedFiles, messageID, inReplyTo, references, "Notification"); nErr != nil {
+			c.Logger().Error("Error while sending the email", mlog.String("user_email", emailNotification.To), mlog.Err(nErr))
 		}
 	})
 
@@ -218,6 +241,11 @@ type postData struct {
  * Computes the email body for notification messages
  */
 func (a *App) getNotificationEmailBody(c request.CTX, recipient *model.User, post *model.Post, channel *model.Channel, channelName string, senderName string, teamName string, landingURL string, emailNotificationContentsType string, useMilitaryTime bool, translateFunc i18n.TranslateFunc, senderPhoto string) (string, error) {
+	data := a.getNotificationEmailTemplateData(c, recipient, post, channel, channelName, senderName, teamName, landingURL, emailNotificationContentsType, useMilitaryTime, translateFunc, senderPhoto)
+	return a.Srv().TemplatesContainer().RenderToString("messages_notification", data)
+}
+
+func (a *App) getNotificationEmailTemplateData(c request.CTX, recipient *model.User, post *model.Post, channel *model.Channel, channelName string, senderName string, teamName string, landingURL string, emailNotificationContentsType string, useMilitaryTime bool, translateFunc i18n.TranslateFunc, senderPhoto string) templates.Data {
 	pData := postData{
 		SenderName:  truncateUserNames(senderName, 22),
 		SenderPhoto: senderPhoto,
@@ -293,7 +321,7 @@ func (a *App) getNotificationEmailBody(c request.CTX, recipient *model.User, pos
 		data.Props["Posts"] = []postData{}
 	}
 
-	return a.Srv().TemplatesContainer().RenderToString("messages_notification", data)
+	return data
 }
 
 func (a *App) GetMessageForNotification(post *model.Post, teamName, siteUrl string, translateFunc i18n.TranslateFunc) string {
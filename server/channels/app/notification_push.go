@@ -310,7 +310,7 @@ func (a *App) getPushNotificationMessage(contentsConfig, postMessage string, exp
 	return senderName + userLocale("api.post.send_notifications_and_forget.push_general_message")
 }
 
-func (a *App) getUserBadgeCount(userID string, isCRTEnabled bool) (int, *model.AppError) {
+func (a *App) getUserBadgeCount(rctx request.CTX, userID string, isCRTEnabled bool) (int, *model.AppError) {
 	unreadCount, err := a.Srv().Store().User().GetUnreadCount(userID, isCRTEnabled)
 	if err != nil {
 		return 0, model.NewAppError("getUserBadgeCount", "app.user.get_unread_count.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
@@ -318,7 +318,7 @@ func (a *App) getUserBadgeCount(userID string, isCRTEnabled bool) (int, *model.A
 	badgeCount := int(unreadCount)
 
 	if isCRTEnabled {
-		threadUnreadMentions, err := a.Srv().Store().Thread().GetTotalUnreadMentions(userID, "", model.GetUserThreadsOpts{})
+		threadUnreadMentions, err := a.Srv().Store().Thread().GetTotalUnreadMentions(rctx, userID, "", model.GetUserThreadsOpts{})
 		if err != nil {
 			return 0, model.NewAppError("getUserBadgeCount", "app.user.get_thread_count_for_user.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
 		}
@@ -331,7 +331,7 @@ func (a *App) getUserBadgeCount(userID string, isCRTEnabled bool) (int, *model.A
 func (a *App) clearPushNotificationSync(c request.CTX, currentSessionId, userID, channelID, rootID string) *model.AppError {
 	isCRTEnabled := a.IsCRTEnabledForUser(c, userID)
 
-	badgeCount, err := a.getUserBadgeCount(userID, isCRTEnabled)
+	badgeCount, err := a.getUserBadgeCount(c, userID, isCRTEnabled)
 	if err != nil {
 		return model.NewAppError("clearPushNotificationSync", "app.user.get_badge_count.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
 	}
@@ -364,7 +364,7 @@ func (a *App) clearPushNotification(currentSessionId, userID, channelID, rootID
 }
 
 func (a *App) updateMobileAppBadgeSync(c request.CTX, userID string) *model.AppError {
-	badgeCount, err := a.getUserBadgeCount(userID, a.IsCRTEnabledForUser(c, userID))
+	badgeCount, err := a.getUserBadgeCount(c, userID, a.IsCRTEnabledForUser(c, userID))
 	if err != nil {
 		return model.NewAppError("updateMobileAppBadgeSync", "app.user.get_badge_count.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
 	}
@@ -717,7 +717,7 @@ func (a *App) BuildPushNotificationMessage(c request.CTX, contentsConfig string,
 		msg = a.buildFullPushNotificationMessage(c, contentsConfig, post, user, channel, channelName, senderName, explicitMention, channelWideMention, replyToThreadType)
 	}
 
-	badgeCount, err := a.getUserBadgeCount(user.Id, a.IsCRTEnabledForUser(c, user.Id))
+	badgeCount, err := a.getUserBadgeCount(c, user.Id, a.IsCRTEnabledForUser(c, user.Id))
 	if err != nil {
 		return nil, model.NewAppError("BuildPushNotificationMessage", "app.user.get_badge_count.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
 	}
@@ -80,7 +80,7 @@ func Test_SendNotifyAdminPosts(t *testing.T) {
 		}
 		require.NoError(t, err, "Expected message to have been sent within %d seconds", timeout)
 
-		postList, err := th.App.Srv().Store().Post().GetPosts(model.GetPostsOptions{ChannelId: channel.Id, Page: 0, PerPage: 1}, false, map[string]bool{})
+		postList, err := th.App.Srv().Store().Post().GetPosts(th.Context, model.GetPostsOptions{ChannelId: channel.Id, Page: 0, PerPage: 1}, false, map[string]bool{})
 		require.NoError(t, err)
 
 		post := postList.Posts[postList.Order[0]]
@@ -127,7 +127,7 @@ func Test_SendNotifyAdminPosts(t *testing.T) {
 		}
 		require.NoError(t, err, "Expected message to have been sent within %d seconds", timeout)
 
-		postList, err := th.App.Srv().Store().Post().GetPosts(model.GetPostsOptions{ChannelId: channel.Id, Page: 0, PerPage: 1}, false, map[string]bool{})
+		postList, err := th.App.Srv().Store().Post().GetPosts(th.Context, model.GetPostsOptions{ChannelId: channel.Id, Page: 0, PerPage: 1}, false, map[string]bool{})
 		require.NoError(t, err)
 
 		post := postList.Posts[postList.Order[0]]
@@ -248,7 +248,7 @@ func Test_SendNotifyAdminPosts(t *testing.T) {
 		}
 		require.NoError(t, err, "Expected message to have been sent within %d seconds", timeout)
 
-		postList, err := th.App.Srv().Store().Post().GetPosts(model.GetPostsOptions{ChannelId: channel.Id, Page: 0, PerPage: 1}, false, map[string]bool{})
+		postList, err := th.App.Srv().Store().Post().GetPosts(th.Context, model.GetPostsOptions{ChannelId: channel.Id, Page: 0, PerPage: 1}, false, map[string]bool{})
 		require.NoError(t, err)
 
 		post := postList.Posts[postList.Order[0]]
@@ -177,3 +177,7 @@ func (c *ClusterMock) WebConnCountForUser(userID string) (int, *model.AppError)
 func (c *ClusterMock) GetWSQueues(userID, connectionID string, seqNum int64) (map[string]*model.WSQueues, error) {
 	return nil, nil
 }
+
+func (c *ClusterMock) GetWebConnInfosForUser(userID string) ([]*model.WebConnInfo, error) {
+	return nil, nil
+}
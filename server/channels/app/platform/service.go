@@ -4,11 +4,13 @@
 package platform
 
 import (
+	"context"
 	"crypto/ecdsa"
 	"errors"
 	"fmt"
 	"hash/maphash"
 	"net/http"
+	"reflect"
 	"runtime"
 	"strconv"
 	"sync"
@@ -31,6 +33,7 @@ import (
 	"github.com/mattermost/mattermost/server/v8/platform/services/cache"
 	"github.com/mattermost/mattermost/server/v8/platform/services/searchengine"
 	"github.com/mattermost/mattermost/server/v8/platform/services/searchengine/bleveengine"
+	"github.com/mattermost/mattermost/server/v8/platform/services/tracing"
 	"github.com/mattermost/mattermost/server/v8/platform/shared/filestore"
 )
 
@@ -74,6 +77,9 @@ type PlatformService struct {
 	metrics      *platformMetrics
 	metricsIFace einterfaces.MetricsInterface
 
+	tracingLock sync.Mutex
+	tracing     *tracing.Provider
+
 	featureFlagSynchronizerMutex sync.Mutex
 	featureFlagSynchronizer      *featureflag.Synchronizer
 	featureFlagStop              chan struct{}
@@ -388,7 +394,20 @@ func New(sc ServiceConfig, options ...Option) (*PlatformService, error) {
 		})
 	}
 
-	// Step 11: Init AsymmetricSigningKey depends on step 6 (store)
+	// Step 11: Init the exporter of the traces
+	if err = ps.resetTracing(); err != nil {
+		return nil, err
+	}
+
+	ps.configStore.AddListener(func(oldCfg, newCfg *model.Config) {
+		if !reflect.DeepEqual(oldCfg.TracingSettings, newCfg.TracingSettings) {
+			if err := ps.resetTracing(); err != nil {
+				mlog.Warn("Failed to reset tracing", mlog.Err(err))
+			}
+		}
+	})
+
+	// Step 12: Init AsymmetricSigningKey depends on step 6 (store)
 	if err = ps.EnsureAsymmetricSigningKey(); err != nil {
 		return nil, fmt.Errorf("unable to ensure asymmetric signing key: %w", err)
 	}
@@ -568,6 +587,22 @@ func (ps *PlatformService) SetSqlStore(s *sqlstore.SqlStore) {
 	ps.sqlStore = s
 }
 
+// RecordWrite records a write for the read-your-writes key of the context, so that the
+// following reads with the same key go to master until the replicas have caught up.
+func (ps *PlatformService) RecordWrite(ctx context.Context) {
+	if ps.sqlStore != nil {
+		ps.sqlStore.RecordWrite(ctx)
+	}
+}
+
+// RecordWritePosition records the position of the writes recorded with the context, so that the
+// following reads with the same key go back to the replicas as soon as they have caught up.
+func (ps *PlatformService) RecordWritePosition(ctx context.Context) {
+	if ps.sqlStore != nil {
+		ps.sqlStore.RecordWritePosition(ctx)
+	}
+}
+
 func (ps *PlatformService) SetSharedChannelService(s SharedChannelServiceIFace) {
 	ps.shareChannelServiceMux.Lock()
 	defer ps.shareChannelServiceMux.Unlock()
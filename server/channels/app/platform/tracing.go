@@ -0,0 +1,66 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package platform
+
+import (
+	"context"
+	"os"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/mattermost/mattermost/server/public/shared/mlog"
+	"github.com/mattermost/mattermost/server/v8/platform/services/tracing"
+)
+
+const tracingShutdownTimeout = 5 * time.Second
+
+// resetTracing restarts the exporter of the traces with the current config,
+// or stops it if tracing is disabled.
+func (ps *PlatformService) resetTracing() error {
+	ps.tracingLock.Lock()
+	defer ps.tracingLock.Unlock()
+
+	ps.shutdownTracing()
+
+	settings := ps.Config().TracingSettings
+	if !*settings.Enable {
+		return nil
+	}
+
+	hostname, err := os.Hostname()
+	if err != nil {
+		ps.logger.Warn("Unable to get the hostname to identify the traces", mlog.Err(err))
+	}
+
+	provider, err := tracing.NewProvider(settings, hostname)
+	if err != nil {
+		return errors.Wrap(err, "failed to start tracing")
+	}
+	ps.tracing = provider
+
+	ps.logger.Info("Exporting traces", mlog.String("endpoint", *settings.OTLPEndpoint), mlog.Float("sample_rate", *settings.SampleRate))
+	return nil
+}
+
+func (ps *PlatformService) shutdownTracing() {
+	if ps.tracing == nil {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), tracingShutdownTimeout)
+	defer cancel()
+	if err := ps.tracing.Shutdown(ctx); err != nil {
+		ps.logger.Warn("Failed to export the pending traces", mlog.Err(err))
+	}
+	ps.tracing = nil
+}
+
+// ShutdownTracing exports the pending traces and stops the exporter.
+func (ps *PlatformService) ShutdownTracing() {
+	ps.tracingLock.Lock()
+	defer ps.tracingLock.Unlock()
+
+	ps.shutdownTracing()
+}
@@ -63,6 +63,7 @@ type pluginWSPostedHook struct {
 
 type WebConnConfig struct {
 	WebSocket         *websocket.Conn
+	SSE               *SSEConn
 	Session           model.Session
 	TFunc             i18n.TranslateFunc
 	Locale            string
@@ -91,6 +92,7 @@ type WebConn struct {
 	Suite             SuiteIFace
 	HookRunner        HookRunner
 	WebSocket         *websocket.Conn
+	SSE               *SSEConn // Set instead of WebSocket when connected over Server-Sent Events
 	T                 i18n.TranslateFunc
 	Locale            string
 	Sequence          int64
@@ -111,6 +113,11 @@ type WebConn struct {
 	// Pointer which indicates the next slot to insert.
 	// It is only to be incremented during writing or clearing the queue.
 	deadQueuePointer int
+	// sequenceSnapshot and deadQueuePointerSnapshot mirror Sequence and
+	// deadQueuePointer, which belong to the write pump, so that they can
+	// be read from the hub for diagnostics.
+	sequenceSnapshot         atomic.Int64
+	deadQueuePointerSnapshot atomic.Int64
 	// active indicates whether there is an open websocket connection attached
 	// to this webConn or not.
 	Active atomic.Bool
@@ -209,13 +216,15 @@ func (ps *PlatformService) NewWebConn(cfg *WebConnConfig, suite SuiteIFace, runn
 
 	// Disable TCP_NO_DELAY for higher throughput
 	var tcpConn *net.TCPConn
-	switch conn := cfg.WebSocket.UnderlyingConn().(type) {
-	case *net.TCPConn:
-		tcpConn = conn
-	case *tls.Conn:
-		newConn, ok := conn.NetConn().(*net.TCPConn)
-		if ok {
-			tcpConn = newConn
+	if cfg.WebSocket != nil {
+		switch conn := cfg.WebSocket.UnderlyingConn().(type) {
+		case *net.TCPConn:
+			tcpConn = conn
+		case *tls.Conn:
+			newConn, ok := conn.NetConn().(*net.TCPConn)
+			if ok {
+				tcpConn = newConn
+			}
 		}
 	}
 
@@ -243,6 +252,7 @@ func (ps *PlatformService) NewWebConn(cfg *WebConnConfig, suite SuiteIFace, runn
 		deadQueuePointer:   cfg.deadQueuePointer,
 		Sequence:           cfg.sequence,
 		WebSocket:          cfg.WebSocket,
+		SSE:                cfg.SSE,
 		lastUserActivityAt: model.GetMillis(),
 		UserId:             cfg.Session.UserId,
 		T:                  cfg.TFunc,
@@ -260,6 +270,7 @@ func (ps *PlatformService) NewWebConn(cfg *WebConnConfig, suite SuiteIFace, runn
 		xForwardedFor:      cfg.XForwardedFor,
 	}
 	wc.Active.Store(cfg.Active)
+	wc.storeQueueSnapshot()
 
 	wc.SetSession(&cfg.Session)
 	wc.SetSessionToken(cfg.Session.Token)
@@ -295,10 +306,19 @@ func (wc *WebConn) pluginPostedConsumer(wg *sync.WaitGroup) {
 
 // Close closes the WebConn.
 func (wc *WebConn) Close() {
-	wc.WebSocket.Close()
+	wc.closeTransport()
 	<-wc.pumpFinished
 }
 
+// closeTransport closes the underlying WebSocket or SSE stream.
+func (wc *WebConn) closeTransport() {
+	if wc.SSE != nil {
+		wc.SSE.Close()
+		return
+	}
+	wc.WebSocket.Close()
+}
+
 // GetSessionExpiresAt returns the time at which the session expires.
 func (wc *WebConn) GetSessionExpiresAt() int64 {
 	return atomic.LoadInt64(&wc.sessionExpiresAt)
@@ -416,7 +436,11 @@ func (wc *WebConn) Pump() {
 	wg.Add(1)
 	go wc.pluginPostedConsumer(&wg)
 
-	wc.readPump()
+	if wc.SSE != nil {
+		wc.sseReadPump()
+	} else {
+		wc.readPump()
+	}
 	close(wc.endWritePump)
 	close(wc.pluginPosted)
 	wg.Wait()
@@ -483,30 +507,36 @@ func (wc *WebConn) readPump() {
 			return
 		}
 
-		// Messages which actions are prefixed with the plugin prefix
-		// should only be dispatched to the plugins
-		if !strings.HasPrefix(req.Action, websocketMessagePluginPrefix) {
-			wc.Platform.WebSocketRouter.ServeWebSocket(wc, &req)
-		}
+		wc.handleRequest(&req)
+	}
+}
 
-		clonedReq, err := req.Clone()
-		if err != nil {
-			wc.logSocketErr("websocket.cloneRequest", err)
-			continue
-		}
+// handleRequest routes a request received from the client and
+// forwards it to the plugins.
+func (wc *WebConn) handleRequest(req *model.WebSocketRequest) {
+	// Messages which actions are prefixed with the plugin prefix
+	// should only be dispatched to the plugins
+	if !strings.HasPrefix(req.Action, websocketMessagePluginPrefix) {
+		wc.Platform.WebSocketRouter.ServeWebSocket(wc, req)
+	}
 
-		if session := wc.GetSession(); session != nil {
-			clonedReq.Session.Id = session.Id
-		}
+	clonedReq, err := req.Clone()
+	if err != nil {
+		wc.logSocketErr("websocket.cloneRequest", err)
+		return
+	}
 
-		if clonedReq.Data == nil {
-			clonedReq.Data = map[string]any{}
-		}
-		clonedReq.Data[model.WebSocketRemoteAddr] = wc.remoteAddress
-		clonedReq.Data[model.WebSocketXForwardedFor] = wc.xForwardedFor
+	if session := wc.GetSession(); session != nil {
+		clonedReq.Session.Id = session.Id
+	}
 
-		wc.pluginPosted <- pluginWSPostedHook{wc.GetConnectionID(), wc.UserId, clonedReq}
+	if clonedReq.Data == nil {
+		clonedReq.Data = map[string]any{}
 	}
+	clonedReq.Data[model.WebSocketRemoteAddr] = wc.remoteAddress
+	clonedReq.Data[model.WebSocketXForwardedFor] = wc.xForwardedFor
+
+	wc.pluginPosted <- pluginWSPostedHook{wc.GetConnectionID(), wc.UserId, clonedReq}
 }
 
 func (wc *WebConn) writePump() {
@@ -516,7 +546,7 @@ func (wc *WebConn) writePump() {
 	defer func() {
 		ticker.Stop()
 		authTicker.Stop()
-		wc.WebSocket.Close()
+		wc.closeTransport()
 	}()
 
 	if wc.Sequence != 0 {
@@ -622,7 +652,11 @@ func (wc *WebConn) writePump() {
 
 		case <-authTicker.C:
 			if wc.GetSessionToken() == "" {
-				wc.Platform.logger.Debug("websocket.authTicker: did not authenticate", mlog.Stringer("ip_address", wc.WebSocket.RemoteAddr()))
+				if wc.SSE != nil {
+					wc.Platform.logger.Debug("websocket.authTicker: did not authenticate", mlog.String("ip_address", wc.SSE.remoteAddr))
+				} else {
+					wc.Platform.logger.Debug("websocket.authTicker: did not authenticate", mlog.Stringer("ip_address", wc.WebSocket.RemoteAddr()))
+				}
 				return
 			}
 			authTicker.Stop()
@@ -633,6 +667,9 @@ func (wc *WebConn) writePump() {
 // writeMessageBuf is a helper utility that wraps the write to the socket
 // along with setting the write deadline.
 func (wc *WebConn) writeMessageBuf(msgType int, data []byte) error {
+	if wc.SSE != nil {
+		return wc.SSE.writeMessage(msgType, data, SSEEventID(wc.GetConnectionID(), wc.Sequence))
+	}
 	if err := wc.WebSocket.SetWriteDeadline(time.Now().Add(writeWaitTime)); err != nil {
 		return err
 	}
@@ -649,6 +686,7 @@ func (wc *WebConn) writeMessage(msg *model.WebSocketEvent) error {
 		return nil
 	}
 	wc.Sequence++
+	wc.storeQueueSnapshot()
 
 	return wc.writeMessageBuf(websocket.TextMessage, buf.Bytes())
 }
@@ -657,6 +695,14 @@ func (wc *WebConn) writeMessage(msg *model.WebSocketEvent) error {
 func (wc *WebConn) addToDeadQueue(msg *model.WebSocketEvent) {
 	wc.deadQueue[wc.deadQueuePointer] = msg
 	wc.deadQueuePointer = (wc.deadQueuePointer + 1) % deadQueueSize
+	wc.storeQueueSnapshot()
+}
+
+// storeQueueSnapshot publishes the current sequence and dead queue pointer
+// to be read by Info. It must be called from the write pump.
+func (wc *WebConn) storeQueueSnapshot() {
+	wc.sequenceSnapshot.Store(wc.Sequence)
+	wc.deadQueuePointerSnapshot.Store(int64(wc.deadQueuePointer))
 }
 
 // hasMsgLoss indicates whether the next wanted sequence is right after
@@ -723,6 +769,7 @@ func (wc *WebConn) clearDeadQueue() {
 		wc.deadQueue[i] = nil
 	}
 	wc.deadQueuePointer = 0
+	wc.storeQueueSnapshot()
 }
 
 // drainDeadQueue will write all messages from a given index to the socket.
@@ -762,6 +809,42 @@ func (wc *WebConn) drainDeadQueue(index int) error {
 	return nil
 }
 
+// info returns the diagnostic details of the connection. Since it reads
+// lastUserActivityAt, it must be called from the hub goroutine.
+func (wc *WebConn) info(hubIndex int) *model.WebConnInfo {
+	transport := model.WebConnTransportWebSocket
+	if wc.SSE != nil {
+		transport = model.WebConnTransportSSE
+	}
+
+	var sessionID string
+	if session := wc.GetSession(); session != nil {
+		sessionID = session.Id
+	}
+
+	return &model.WebConnInfo{
+		HubIndex:           hubIndex,
+		ConnectionId:       wc.GetConnectionID(),
+		UserId:             wc.UserId,
+		SessionId:          sessionID,
+		Transport:          transport,
+		OriginClient:       wc.originClient,
+		RemoteAddress:      wc.remoteAddress,
+		XForwardedFor:      wc.xForwardedFor,
+		Active:             wc.Active.Load(),
+		ReuseCount:         wc.reuseCount,
+		Sequence:           wc.sequenceSnapshot.Load(),
+		SendQueueLength:    len(wc.send),
+		SendQueueCapacity:  cap(wc.send),
+		DeadQueuePointer:   int(wc.deadQueuePointerSnapshot.Load()),
+		DeadQueueCapacity:  len(wc.deadQueue),
+		LastUserActivityAt: wc.lastUserActivityAt,
+		ActiveChannelId:    wc.GetActiveChannelID(),
+		ActiveTeamId:       wc.GetActiveTeamID(),
+		PostedAck:          wc.PostedAck,
+	}
+}
+
 // InvalidateCache resets all internal data of the WebConn.
 func (wc *WebConn) InvalidateCache() {
 	wc.allChannelMembers = nil
@@ -0,0 +1,284 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package platform
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gorilla/websocket"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/public/shared/mlog"
+)
+
+// sseRequestQueueSize is the number of client requests received over the
+// regular API that can be waiting to be processed for a single SSE connection.
+const sseRequestQueueSize = 32
+
+// ErrSSEConnNotFound is returned when a client request is addressed to an
+// SSE connection which isn't registered on this node.
+var ErrSSEConnNotFound = errors.New("sse connection not found")
+
+// SSEConn is the transport of a WebConn established over Server-Sent Events
+// instead of a WebSocket. It's used by clients sitting behind proxies which
+// drop long-lived WebSocket upgrades.
+//
+// Events are written to the HTTP response as they are sent to the WebConn.
+// Since the stream is unidirectional, client requests (e.g. typing or
+// presence updates) are received over the regular API and queued through
+// Enqueue, and their responses are delivered back over the stream.
+type SSEConn struct {
+	w          http.ResponseWriter
+	rc         *http.ResponseController
+	remoteAddr string
+
+	// clientGone is closed when the underlying HTTP request is finished.
+	clientGone <-chan struct{}
+	requests   chan *model.WebSocketRequest
+	closed     chan struct{}
+	closeOnce  sync.Once
+}
+
+// NewSSEConn prepares the given response for streaming events and
+// returns the transport to be used in WebConnConfig.
+func NewSSEConn(w http.ResponseWriter, r *http.Request) (*SSEConn, error) {
+	// Checked before anything is written so that the caller can still
+	// respond with an error.
+	if !canFlush(w) {
+		return nil, errors.New("response does not support streaming")
+	}
+
+	rc := http.NewResponseController(w)
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	// Prevents nginx from buffering the stream.
+	w.Header().Set("X-Accel-Buffering", "no")
+	w.WriteHeader(http.StatusOK)
+
+	if err := rc.Flush(); err != nil {
+		return nil, fmt.Errorf("response does not support streaming: %w", err)
+	}
+
+	return &SSEConn{
+		w:          w,
+		rc:         rc,
+		remoteAddr: r.RemoteAddr,
+		clientGone: r.Context().Done(),
+		requests:   make(chan *model.WebSocketRequest, sseRequestQueueSize),
+		closed:     make(chan struct{}),
+	}, nil
+}
+
+// canFlush reports whether w, or any writer it wraps, implements http.Flusher.
+func canFlush(w http.ResponseWriter) bool {
+	for {
+		switch t := w.(type) {
+		case http.Flusher:
+			return true
+		case interface{ Unwrap() http.ResponseWriter }:
+			w = t.Unwrap()
+		default:
+			return false
+		}
+	}
+}
+
+// Enqueue hands a client request over to the connection's read pump.
+// It fails if the connection is closed or too many requests are pending.
+func (c *SSEConn) Enqueue(req *model.WebSocketRequest) error {
+	select {
+	case <-c.closed:
+		return ErrSSEConnNotFound
+	default:
+	}
+
+	select {
+	case c.requests <- req:
+		return nil
+	case <-c.closed:
+		return ErrSSEConnNotFound
+	default:
+		return errors.New("sse request queue is full")
+	}
+}
+
+// Close terminates the stream. It is safe to call multiple times.
+func (c *SSEConn) Close() {
+	c.closeOnce.Do(func() {
+		close(c.closed)
+	})
+}
+
+// writeMessage writes a frame to the stream. msgType uses the
+// WebSocket message types so that the write pump can stay
+// transport agnostic.
+func (c *SSEConn) writeMessage(msgType int, data []byte, eventID string) error {
+	select {
+	case <-c.closed:
+		return websocket.ErrCloseSent
+	default:
+	}
+
+	var buf bytes.Buffer
+	switch msgType {
+	case websocket.TextMessage:
+		if eventID != "" {
+			buf.WriteString("id: ")
+			buf.WriteString(eventID)
+			buf.WriteByte('\n')
+		}
+		// The JSON encoder terminates every message with a newline,
+		// which would otherwise end the data field early.
+		buf.WriteString("data: ")
+		buf.Write(bytes.TrimRight(data, "\n"))
+		buf.WriteString("\n\n")
+	case websocket.PingMessage:
+		// Comments are ignored by clients but keep proxies from
+		// timing out an idle stream.
+		buf.WriteString(": ping\n\n")
+	case websocket.CloseMessage:
+		c.Close()
+		return nil
+	default:
+		return fmt.Errorf("unsupported sse message type %d", msgType)
+	}
+
+	// The stream outlives the server's WriteTimeout, so the deadline is
+	// extended on every write the same way it's done for WebSockets.
+	if err := c.rc.SetWriteDeadline(time.Now().Add(writeWaitTime)); err != nil && !errors.Is(err, http.ErrNotSupported) {
+		return err
+	}
+	if _, err := c.w.Write(buf.Bytes()); err != nil {
+		return err
+	}
+	return c.rc.Flush()
+}
+
+// SSEEventID returns the identifier written alongside every event of the
+// stream. Browsers send it back in the Last-Event-ID header when they
+// reconnect, which lets the connection be resumed from the reliable
+// websocket queues.
+func SSEEventID(connectionID string, sequence int64) string {
+	return connectionID + ":" + strconv.FormatInt(sequence, 10)
+}
+
+// ParseSSEEventID is the reverse of SSEEventID.
+func ParseSSEEventID(id string) (connectionID string, sequence string, ok bool) {
+	connectionID, sequence, ok = strings.Cut(id, ":")
+	if !ok || !model.IsValidId(connectionID) {
+		return "", "", false
+	}
+	if _, err := strconv.ParseInt(sequence, 10, 64); err != nil {
+		return "", "", false
+	}
+	return connectionID, sequence, true
+}
+
+// sseReadPump is the SSE counterpart of readPump. It processes the client
+// requests received over the API until the client goes away.
+func (wc *WebConn) sseReadPump() {
+	defer func() {
+		if metrics := wc.Platform.metricsIFace; metrics != nil {
+			metrics.DecrementHTTPWebSockets(wc.originClient)
+		}
+		wc.SSE.Close()
+	}()
+	if metrics := wc.Platform.metricsIFace; metrics != nil {
+		metrics.IncrementHTTPWebSockets(wc.originClient)
+	}
+
+	for {
+		select {
+		case req := <-wc.SSE.requests:
+			wc.handleRequest(req)
+		case <-wc.SSE.clientGone:
+			wc.Platform.logger.Debug("sse: client side closed stream",
+				mlog.String("user_id", wc.UserId),
+				mlog.String("conn_id", wc.GetConnectionID()),
+				mlog.String("origin_client", wc.originClient))
+			return
+		case <-wc.SSE.closed:
+			return
+		}
+	}
+}
+
+// sseClusterRequest is a client request forwarded to the other nodes of the
+// cluster, when the SSE connection it's addressed to isn't registered locally.
+type sseClusterRequest struct {
+	UserId       string                  `json:"user_id"`
+	ConnectionId string                  `json:"connection_id"`
+	Request      *model.WebSocketRequest `json:"request"`
+}
+
+// EnqueueSSERequest routes a client request received over the API to the
+// user's SSE connection identified by connectionID.
+//
+// The stream and the API request can be served by different nodes when the
+// load balancer doesn't use sticky sessions. Requests for connections which
+// aren't registered on this node are then forwarded to the cluster, and are
+// dropped by the nodes which don't hold the connection either.
+func (ps *PlatformService) EnqueueSSERequest(userID, connectionID string, req *model.WebSocketRequest) error {
+	err := ps.enqueueSSERequestSkipClusterSend(userID, connectionID, req)
+	if !errors.Is(err, ErrSSEConnNotFound) || ps.clusterIFace == nil {
+		return err
+	}
+
+	data, err := json.Marshal(sseClusterRequest{
+		UserId:       userID,
+		ConnectionId: connectionID,
+		Request:      req,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to encode sse request: %w", err)
+	}
+
+	ps.clusterIFace.SendClusterMessage(&model.ClusterMessage{
+		Event:    model.ClusterEventSSERequest,
+		SendType: model.ClusterSendReliable,
+		Data:     data,
+	})
+
+	return nil
+}
+
+func (ps *PlatformService) clusterSSERequestHandler(msg *model.ClusterMessage) {
+	var clusterReq sseClusterRequest
+	if err := json.Unmarshal(msg.Data, &clusterReq); err != nil {
+		ps.logger.Warn("Failed to decode sse request from JSON", mlog.Err(err))
+		return
+	}
+
+	err := ps.enqueueSSERequestSkipClusterSend(clusterReq.UserId, clusterReq.ConnectionId, clusterReq.Request)
+	if err != nil && !errors.Is(err, ErrSSEConnNotFound) {
+		ps.logger.Debug("Failed to enqueue sse request from cluster",
+			mlog.String("user_id", clusterReq.UserId),
+			mlog.String("conn_id", clusterReq.ConnectionId),
+			mlog.Err(err))
+	}
+}
+
+func (ps *PlatformService) enqueueSSERequestSkipClusterSend(userID, connectionID string, req *model.WebSocketRequest) error {
+	hub := ps.GetHubForUserId(userID)
+	if hub == nil {
+		return ErrSSEConnNotFound
+	}
+
+	wc := hub.GetConn(userID, connectionID)
+	if wc == nil || wc.SSE == nil {
+		return ErrSSEConnNotFound
+	}
+
+	return wc.SSE.Enqueue(req)
+}
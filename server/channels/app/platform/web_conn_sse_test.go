@@ -0,0 +1,134 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package platform
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gorilla/websocket"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/v8/channels/testlib"
+)
+
+// nonFlushingWriter hides the http.Flusher implementation of the recorder.
+type nonFlushingWriter struct {
+	rec *httptest.ResponseRecorder
+}
+
+func (w *nonFlushingWriter) Header() http.Header         { return w.rec.Header() }
+func (w *nonFlushingWriter) Write(b []byte) (int, error) { return w.rec.Write(b) }
+func (w *nonFlushingWriter) WriteHeader(code int)        { w.rec.WriteHeader(code) }
+
+func TestSSEConnWriteMessage(t *testing.T) {
+	rec := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodGet, "/api/v4/sse", nil)
+
+	conn, err := NewSSEConn(rec, req)
+	require.NoError(t, err)
+	assert.Equal(t, "text/event-stream", rec.Header().Get("Content-Type"))
+	assert.True(t, rec.Flushed)
+
+	connID := model.NewId()
+	require.NoError(t, conn.writeMessage(websocket.TextMessage, []byte(`{"event":"hello","seq":0}`+"\n"), SSEEventID(connID, 1)))
+	require.NoError(t, conn.writeMessage(websocket.PingMessage, nil, ""))
+	assert.Equal(t, "id: "+connID+":1\ndata: {\"event\":\"hello\",\"seq\":0}\n\n: ping\n\n", rec.Body.String())
+
+	require.NoError(t, conn.writeMessage(websocket.CloseMessage, nil, ""))
+	assert.ErrorIs(t, conn.writeMessage(websocket.TextMessage, []byte("{}"), ""), websocket.ErrCloseSent)
+	assert.ErrorIs(t, conn.Enqueue(&model.WebSocketRequest{Seq: 1, Action: "ping"}), ErrSSEConnNotFound)
+}
+
+func TestSSEConnEnqueue(t *testing.T) {
+	conn, err := NewSSEConn(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/api/v4/sse", nil))
+	require.NoError(t, err)
+
+	for i := 0; i < sseRequestQueueSize; i++ {
+		require.NoError(t, conn.Enqueue(&model.WebSocketRequest{Seq: int64(i + 1), Action: "ping"}))
+	}
+	err = conn.Enqueue(&model.WebSocketRequest{Seq: sseRequestQueueSize + 1, Action: "ping"})
+	require.Error(t, err)
+	assert.NotErrorIs(t, err, ErrSSEConnNotFound)
+
+	req := <-conn.requests
+	assert.Equal(t, int64(1), req.Seq)
+}
+
+func TestParseSSEEventID(t *testing.T) {
+	connID := model.NewId()
+
+	id, seq, ok := ParseSSEEventID(SSEEventID(connID, 42))
+	require.True(t, ok)
+	assert.Equal(t, connID, id)
+	assert.Equal(t, "42", seq)
+
+	for _, invalid := range []string{"", connID, "abc:1", connID + ":", connID + ":x"} {
+		_, _, ok = ParseSSEEventID(invalid)
+		assert.False(t, ok, invalid)
+	}
+}
+
+func TestNewSSEConnWithoutFlusher(t *testing.T) {
+	rec := httptest.NewRecorder()
+
+	_, err := NewSSEConn(&nonFlushingWriter{rec: rec}, httptest.NewRequest(http.MethodGet, "/api/v4/sse", nil))
+	require.Error(t, err)
+	assert.Empty(t, rec.Header().Get("Content-Type"))
+	assert.Zero(t, rec.Body.Len())
+	assert.False(t, rec.Flushed)
+}
+
+func TestEnqueueSSERequestCluster(t *testing.T) {
+	testCluster := &testlib.FakeClusterInterface{}
+	th := SetupWithCluster(t, testCluster)
+	defer th.TearDown()
+
+	userID := model.NewId()
+	connectionID := model.NewId()
+	req := &model.WebSocketRequest{Seq: 1, Action: "user_typing"}
+
+	t.Run("unknown connections are forwarded to the cluster", func(t *testing.T) {
+		require.NoError(t, th.Service.EnqueueSSERequest(userID, connectionID, req))
+
+		messages := testCluster.SelectMessages(func(msg *model.ClusterMessage) bool {
+			return msg.Event == model.ClusterEventSSERequest
+		})
+		require.Len(t, messages, 1)
+		assert.Equal(t, model.ClusterSendReliable, messages[0].SendType)
+	})
+
+	t.Run("forwarded requests are queued on the node holding the connection", func(t *testing.T) {
+		sse, err := NewSSEConn(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/api/v4/sse", nil))
+		require.NoError(t, err)
+		wc := th.Service.NewWebConn(&WebConnConfig{
+			Session:      model.Session{UserId: userID},
+			ConnectionID: connectionID,
+			Active:       true,
+			SSE:          sse,
+		}, th.Suite, &hookRunner{})
+		require.NoError(t, th.Service.HubRegister(wc))
+		defer th.Service.HubUnregister(wc)
+
+		messages := testCluster.SelectMessages(func(msg *model.ClusterMessage) bool {
+			return msg.Event == model.ClusterEventSSERequest
+		})
+		require.NotEmpty(t, messages)
+		th.Service.clusterSSERequestHandler(messages[0])
+
+		select {
+		case received := <-sse.requests:
+			assert.Equal(t, req.Seq, received.Seq)
+			assert.Equal(t, req.Action, received.Action)
+		default:
+			require.Fail(t, "request wasn't queued")
+		}
+
+		// Pump until the connection is closed by the hub on teardown.
+		go wc.Pump()
+	})
+}
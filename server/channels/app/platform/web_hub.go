@@ -8,6 +8,7 @@ import (
 	"hash/maphash"
 	"iter"
 	"maps"
+	"os"
 	"runtime"
 	"runtime/debug"
 	"strconv"
@@ -60,6 +61,17 @@ type webConnCheckMessage struct {
 	result       chan *CheckConnResult
 }
 
+type webConnLookupMessage struct {
+	userID       string
+	connectionID string
+	result       chan *WebConn
+}
+
+type webConnInfoMessage struct {
+	userID string
+	result chan []*model.WebConnInfo
+}
+
 type webConnCountMessage struct {
 	userID string
 	result chan int
@@ -87,6 +99,8 @@ type Hub struct {
 	explicitStop    bool
 	checkRegistered chan *webConnSessionMessage
 	checkConn       chan *webConnCheckMessage
+	lookupConn      chan *webConnLookupMessage
+	connInfos       chan *webConnInfoMessage
 	connCount       chan *webConnCountMessage
 	broadcastHooks  map[string]BroadcastHook
 
@@ -108,6 +122,8 @@ func newWebHub(ps *PlatformService) *Hub {
 		directMsg:       make(chan *webConnDirectMessage),
 		checkRegistered: make(chan *webConnSessionMessage),
 		checkConn:       make(chan *webConnCheckMessage),
+		lookupConn:      make(chan *webConnLookupMessage),
+		connInfos:       make(chan *webConnInfoMessage),
 		connCount:       make(chan *webConnCountMessage),
 		hubSemaphore:    make(chan struct{}, hubSemaphoreCount),
 	}
@@ -359,6 +375,40 @@ func (ps *PlatformService) WebConnCountForUser(userID string) int {
 	return 0
 }
 
+// GetWebConnInfosForUser returns the diagnostic details of all the
+// websocket connections of a given userID on this node.
+func (ps *PlatformService) GetWebConnInfosForUser(userID string) []*model.WebConnInfo {
+	hub := ps.GetHubForUserId(userID)
+	if hub == nil {
+		return nil
+	}
+
+	infos := hub.WebConnInfosForUser(userID)
+	hostname, err := os.Hostname()
+	if err != nil {
+		ps.logger.Warn("Could not get hostname", mlog.Err(err))
+	}
+	for _, info := range infos {
+		info.Hostname = hostname
+	}
+	return infos
+}
+
+// InspectWebConnsForUser returns the diagnostic details of all the
+// websocket connections of a given userID across the cluster.
+func (ps *PlatformService) InspectWebConnsForUser(userID string) ([]*model.WebConnInfo, error) {
+	infos := ps.GetWebConnInfosForUser(userID)
+	if ps.Cluster() == nil {
+		return infos, nil
+	}
+
+	clusterInfos, err := ps.Cluster().GetWebConnInfosForUser(userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get websocket connections from the cluster: %w", err)
+	}
+	return append(infos, clusterInfos...), nil
+}
+
 // Register registers a connection to the hub.
 func (h *Hub) Register(webConn *WebConn) error {
 	wr := &webConnRegisterMessage{
@@ -410,6 +460,37 @@ func (h *Hub) CheckConn(userID, connectionID string) *CheckConnResult {
 	return nil
 }
 
+// GetConn returns the active connection of the user with the given
+// connectionID, or nil if it isn't registered in the hub.
+func (h *Hub) GetConn(userID, connectionID string) *WebConn {
+	req := &webConnLookupMessage{
+		userID:       userID,
+		connectionID: connectionID,
+		result:       make(chan *WebConn),
+	}
+	select {
+	case h.lookupConn <- req:
+		return <-req.result
+	case <-h.stop:
+	}
+	return nil
+}
+
+// WebConnInfosForUser returns the diagnostic details of all the
+// connections of the user, including inactive ones.
+func (h *Hub) WebConnInfosForUser(userID string) []*model.WebConnInfo {
+	req := &webConnInfoMessage{
+		userID: userID,
+		result: make(chan []*model.WebConnInfo),
+	}
+	select {
+	case h.connInfos <- req:
+		return <-req.result
+	case <-h.stop:
+	}
+	return nil
+}
+
 func (h *Hub) WebConnCountForUser(userID string) int {
 	req := &webConnCountMessage{
 		userID: userID,
@@ -555,6 +636,19 @@ func (h *Hub) Start() {
 					}
 				}
 				req.result <- res
+			case req := <-h.lookupConn:
+				var res *WebConn
+				conn := connIndex.ForConnection(req.connectionID)
+				if conn != nil && conn.UserId == req.userID && conn.Active.Load() {
+					res = conn
+				}
+				req.result <- res
+			case req := <-h.connInfos:
+				infos := []*model.WebConnInfo{}
+				for conn := range connIndex.ForUser(req.userID) {
+					infos = append(infos, conn.info(h.connectionIndex))
+				}
+				req.result <- infos
 			case req := <-h.connCount:
 				req.result <- connIndex.ForUserActiveCount(req.userID)
 			case <-ticker.C:
@@ -121,6 +121,9 @@ func (ch *Channels) syncPluginsActiveState() {
 				defer wg.Done()
 
 				deactivated := pluginsEnvironment.Deactivate(plugin.Manifest.Id)
+				if deactivated {
+					ch.unregisterPluginJobTypes(plugin.Manifest.Id)
+				}
 				if deactivated && plugin.Manifest.HasClient() {
 					message := model.NewWebSocketEvent(model.WebsocketEventPluginDisabled, "", "", "", nil, "")
 					message.Add("manifest", plugin.Manifest.ClientManifest())
@@ -129,28 +132,32 @@ func (ch *Channels) syncPluginsActiveState() {
 			}(plugin)
 		}
 
-		// Activate any plugins that have been enabled
-		for _, plugin := range enabledPlugins {
-			wg.Add(1)
-			go func(plugin *model.BundleInfo) {
-				defer wg.Done()
-
-				pluginID := plugin.Manifest.Id
-				logger := ch.srv.Log().With(mlog.String("plugin_id", pluginID), mlog.String("bundle_path", plugin.Path))
-
-				updatedManifest, activated, err := pluginsEnvironment.Activate(pluginID)
-				if err != nil {
-					logger.Error("Unable to activate plugin", mlog.Err(err))
-					return
-				}
+		// Activate any plugins that have been enabled, after the plugins they depend on.
+		for _, group := range plugin.GroupByDependencies(enabledPlugins) {
+			var groupWg sync.WaitGroup
+			for _, plugin := range group {
+				groupWg.Add(1)
+				go func(plugin *model.BundleInfo) {
+					defer groupWg.Done()
+
+					pluginID := plugin.Manifest.Id
+					logger := ch.srv.Log().With(mlog.String("plugin_id", pluginID), mlog.String("bundle_path", plugin.Path))
+
+					updatedManifest, activated, err := pluginsEnvironment.Activate(pluginID)
+					if err != nil {
+						logger.Error("Unable to activate plugin", mlog.Err(err))
+						return
+					}
 
-				if activated {
-					// Notify all cluster clients if ready
-					if err := ch.notifyPluginEnabled(updatedManifest); err != nil {
-						logger.Error("Failed to notify cluster on plugin enable", mlog.Err(err))
+					if activated {
+						// Notify all cluster clients if ready
+						if err := ch.notifyPluginEnabled(updatedManifest); err != nil {
+							logger.Error("Failed to notify cluster on plugin enable", mlog.Err(err))
+						}
 					}
-				}
-			}(plugin)
+				}(plugin)
+			}
+			groupWg.Wait()
 		}
 		wg.Wait()
 	} else { // If plugins are disabled, shutdown plugins.
@@ -215,6 +222,10 @@ func (ch *Channels) initPlugins(c request.CTX, pluginDir, webappPluginDir string
 		ch.srv.Log().Error("Failed to start up plugins", mlog.Err(err))
 		return
 	}
+	env.SetResourceLimits(ch.cfgSvc.Config().PluginSettings.ResourceLimits)
+	env.SetResourceLimitExceededHandler(ch.handlePluginResourceLimitExceeded)
+	env.SetMigrationHandler(ch.applyPluginMigrations)
+
 	ch.pluginsLock.Lock()
 	ch.pluginsEnvironment = env
 	ch.pluginsLock.Unlock()
@@ -243,6 +254,10 @@ func (ch *Channels) initPlugins(c request.CTX, pluginDir, webappPluginDir string
 			ch.syncPluginsActiveState()
 		}
 
+		if env := ch.GetPluginsEnvironment(); env != nil {
+			env.SetResourceLimits(newCfg.PluginSettings.ResourceLimits)
+		}
+
 		ch.RunMultiHook(func(hooks plugin.Hooks, _ *model.Manifest) bool {
 			if err := hooks.OnConfigurationChange(); err != nil {
 				ch.srv.Log().Error("Plugin OnConfigurationChange hook failed", mlog.Err(err))
@@ -320,22 +335,17 @@ func (ch *Channels) syncPlugins() *model.AppError {
 			}
 			defer bundle.Close()
 
-			if *ch.cfgSvc.Config().PluginSettings.RequirePluginSignature {
-				signature, appErr := ch.srv.fileReader(plugin.signaturePath)
-				if appErr != nil {
-					logger.Error("Failed to open plugin signature from file store.", mlog.Err(appErr))
-					return
-				}
-				defer signature.Close()
-
-				if appErr = ch.verifyPlugin(logger, bundle, signature); appErr != nil {
-					logger.Error("Failed to validate plugin signature", mlog.Err(appErr))
-					return
-				}
+			pluginSignature, appErr := ch.verifyPluginFromFileStore(logger, plugin, bundle)
+			if appErr != nil {
+				logger.Error("Failed to validate plugin signature", mlog.Err(appErr))
+				return
+			}
+			if pluginSignature != nil {
+				logger = logger.With(mlog.String("key_id", pluginSignature.KeyId))
 			}
 
 			logger.Info("Syncing plugin from file store")
-			if _, err := ch.installPluginLocally(bundle, installPluginLocallyAlways); err != nil && err.Id != "app.plugin.skip_installation.app_error" {
+			if _, err := ch.installPluginLocally(bundle, pluginSignature, installPluginLocallyAlways); err != nil && err.Id != "app.plugin.skip_installation.app_error" {
 				logger.Error("Failed to sync plugin from file store", mlog.Err(err))
 			}
 		}(plugin)
@@ -534,8 +544,8 @@ func (a *App) GetPlugins() (*model.PluginsResponse, *model.AppError) {
 	return resp, nil
 }
 
-// GetMarketplacePlugins returns a list of plugins from the marketplace-server,
-// and plugins that are installed locally.
+// GetMarketplacePlugins returns a list of plugins from the marketplace-server and the
+// marketplace catalog, and plugins that are installed locally.
 func (a *App) GetMarketplacePlugins(rctx request.CTX, filter *model.MarketplacePluginFilter) ([]*model.MarketplacePlugin, *model.AppError) {
 	plugins := map[string]*model.MarketplacePlugin{}
 
@@ -547,6 +557,10 @@ func (a *App) GetMarketplacePlugins(rctx request.CTX, filter *model.MarketplaceP
 		plugins = p
 	}
 
+	// The catalog is served by this server, so it's listed even when only local plugins are, such
+	// as when the remote marketplace can't be reached.
+	a.mergeMarketplaceCatalogPlugins(plugins)
+
 	if !filter.RemoteOnly {
 		appErr := a.mergePrepackagedPlugins(plugins)
 		if appErr != nil {
@@ -1065,7 +1079,7 @@ func (ch *Channels) processPrepackagedPlugin(pluginPath *pluginSignaturePath) (*
 		return plugin, nil
 	}
 
-	if _, err := ch.installExtractedPlugin(plugin.Manifest, pluginDir, installPluginLocallyOnlyIfNewOrUpgrade); err != nil && err.Id != "app.plugin.skip_installation.app_error" {
+	if _, err := ch.installExtractedPlugin(plugin.Manifest, pluginDir, plugin.Signature, installPluginLocallyOnlyIfNewOrUpgrade); err != nil && err.Id != "app.plugin.skip_installation.app_error" {
 		return nil, errors.Wrapf(err, "Failed to install extracted prepackaged plugin %s", pluginPath.bundlePath)
 	}
 
@@ -1263,7 +1277,8 @@ func (ch *Channels) buildPrepackagedPlugin(logger *mlog.Logger, pluginPath *plug
 	if _, err := pluginFile.Seek(0, io.SeekStart); err != nil {
 		return nil, "", errors.Wrapf(err, "Failed to seek to start of plugin file for signature verification: %s", pluginPath.bundlePath)
 	}
-	if appErr := ch.verifyPlugin(logger, pluginFile, signatureFile); appErr != nil {
+	pluginSignature, appErr := ch.verifyPlugin(logger, pluginFile, signatureFile)
+	if appErr != nil {
 		return nil, "", errors.Wrapf(appErr, "Prepackaged plugin signature verification failed for %s using %s", pluginPath.bundlePath, pluginPath.signaturePath)
 	}
 
@@ -1280,6 +1295,7 @@ func (ch *Channels) buildPrepackagedPlugin(logger *mlog.Logger, pluginPath *plug
 	plugin.Manifest = manifest
 	plugin.Path = pluginPath.bundlePath
 	plugin.SignaturePath = pluginPath.signaturePath
+	plugin.Signature = pluginSignature
 
 	if manifest.IconPath != "" {
 		iconData, err := getIcon(filepath.Join(pluginDir, manifest.IconPath))
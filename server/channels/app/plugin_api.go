@@ -826,7 +826,7 @@ func (api *PluginAPI) GetPost(postID string) (*model.Post, *model.AppError) {
 }
 
 func (api *PluginAPI) GetPostsSince(channelID string, time int64) (*model.PostList, *model.AppError) {
-	list, appErr := api.app.GetPostsSince(model.GetPostsSinceOptions{ChannelId: channelID, Time: time})
+	list, appErr := api.app.GetPostsSince(api.ctx, model.GetPostsSinceOptions{ChannelId: channelID, Time: time})
 	if list != nil {
 		list = list.ForPlugin()
 	}
@@ -850,7 +850,7 @@ func (api *PluginAPI) GetPostsBefore(channelID, postID string, page, perPage int
 }
 
 func (api *PluginAPI) GetPostsForChannel(channelID string, page, perPage int) (*model.PostList, *model.AppError) {
-	list, appErr := api.app.GetPostsPage(model.GetPostsOptions{ChannelId: channelID, Page: page, PerPage: perPage})
+	list, appErr := api.app.GetPostsPage(api.ctx, model.GetPostsOptions{ChannelId: channelID, Page: page, PerPage: perPage})
 	if list != nil {
 		list = list.ForPlugin()
 	}
@@ -1585,3 +1585,33 @@ func (api *PluginAPI) DeletePropertyValuesForTarget(groupID, targetType, targetI
 func (api *PluginAPI) DeletePropertyValuesForField(groupID, fieldID string) error {
 	return api.app.PropertyService().DeletePropertyValuesForField(groupID, fieldID)
 }
+
+func (api *PluginAPI) RegisterJobType(name string, options *model.PluginJobTypeOptions) error {
+	if appErr := api.app.RegisterPluginJobType(api.id, name, options); appErr != nil {
+		return appErr
+	}
+	return nil
+}
+
+func (api *PluginAPI) CreateJob(name string, data map[string]string) (*model.Job, error) {
+	job, appErr := api.app.CreatePluginJob(api.ctx, api.id, name, data)
+	if appErr != nil {
+		return nil, appErr
+	}
+	return job, nil
+}
+
+func (api *PluginAPI) GetJob(jobID string) (*model.Job, error) {
+	job, appErr := api.app.GetPluginJob(api.ctx, api.id, jobID)
+	if appErr != nil {
+		return nil, appErr
+	}
+	return job, nil
+}
+
+func (api *PluginAPI) SetJobProgress(jobID string, progress int64) error {
+	if appErr := api.app.SetPluginJobProgress(api.ctx, api.id, jobID, progress); appErr != nil {
+		return appErr
+	}
+	return nil
+}
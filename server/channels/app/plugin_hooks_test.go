@@ -6,6 +6,7 @@ package app
 import (
 	"bytes"
 	_ "embed"
+	"encoding/json"
 	"fmt"
 	"io"
 	"net/http"
@@ -1916,7 +1917,7 @@ func TestChannelHasBeenCreated(t *testing.T) {
 		require.NotNil(t, channel)
 
 		assert.EventuallyWithT(t, func(t *assert.CollectT) {
-			posts, appErr := th.App.GetPosts(channel.Id, 0, 1)
+			posts, appErr := th.App.GetPosts(th.Context, channel.Id, 0, 1)
 
 			require.Nil(t, appErr)
 			assert.True(t, len(posts.Order) > 0)
@@ -1943,7 +1944,7 @@ func TestChannelHasBeenCreated(t *testing.T) {
 		require.NotNil(t, channel)
 
 		assert.EventuallyWithT(t, func(t *assert.CollectT) {
-			posts, appErr := th.App.GetPosts(channel.Id, 0, 1)
+			posts, appErr := th.App.GetPosts(th.Context, channel.Id, 0, 1)
 
 			require.Nil(t, appErr)
 			assert.True(t, len(posts.Order) > 0)
@@ -1970,7 +1971,7 @@ func TestChannelHasBeenCreated(t *testing.T) {
 		require.NotNil(t, channel)
 
 		assert.EventuallyWithT(t, func(t *assert.CollectT) {
-			posts, appErr := th.App.GetPosts(channel.Id, 0, 1)
+			posts, appErr := th.App.GetPosts(th.Context, channel.Id, 0, 1)
 
 			require.Nil(t, appErr)
 			assert.True(t, len(posts.Order) > 0)
@@ -2054,7 +2055,7 @@ func TestUserHasJoinedChannel(t *testing.T) {
 		require.Nil(t, appErr)
 
 		assert.EventuallyWithT(t, func(t *assert.CollectT) {
-			posts, appErr := th.App.GetPosts(channel.Id, 0, 30)
+			posts, appErr := th.App.GetPosts(th.Context, channel.Id, 0, 30)
 
 			require.Nil(t, appErr)
 			assert.True(t, len(posts.Order) > 0)
@@ -2103,7 +2104,7 @@ func TestUserHasJoinedChannel(t *testing.T) {
 		assert.Eventually(t, func() bool {
 			// Typically, the post we're looking for will be the latest, but there's a race between the plugin and
 			// "User has joined the channel" post which means the plugin post may not the the latest one
-			posts, appErr := th.App.GetPosts(channel.Id, 0, 10)
+			posts, appErr := th.App.GetPosts(th.Context, channel.Id, 0, 10)
 			require.Nil(t, appErr)
 
 			for _, postId := range posts.Order {
@@ -2139,7 +2140,7 @@ func TestUserHasJoinedChannel(t *testing.T) {
 
 		var posts *model.PostList
 		require.EventuallyWithT(t, func(c *assert.CollectT) {
-			posts, appErr = th.App.GetPosts(channel.Id, 0, 10)
+			posts, appErr = th.App.GetPosts(th.Context, channel.Id, 0, 10)
 			assert.Nil(t, appErr)
 		}, 2*time.Second, 100*time.Millisecond)
 
@@ -2170,7 +2171,7 @@ func TestUserHasJoinedChannel(t *testing.T) {
 
 		var posts *model.PostList
 		require.EventuallyWithT(t, func(c *assert.CollectT) {
-			posts, appErr = th.App.GetPosts(channel.Id, 0, 10)
+			posts, appErr = th.App.GetPosts(th.Context, channel.Id, 0, 10)
 			assert.Nil(t, appErr)
 		}, 2*time.Second, 100*time.Millisecond)
 
@@ -2202,7 +2203,7 @@ func TestUserHasJoinedChannel(t *testing.T) {
 
 		var posts *model.PostList
 		require.EventuallyWithT(t, func(c *assert.CollectT) {
-			posts, appErr = th.App.GetPosts(channel.Id, 0, 10)
+			posts, appErr = th.App.GetPosts(th.Context, channel.Id, 0, 10)
 			assert.Nil(t, appErr)
 		}, 2*time.Second, 100*time.Millisecond)
 
@@ -2216,3 +2217,524 @@ func TestUserHasJoinedChannel(t *testing.T) {
 		}
 	})
 }
+
+func TestHookChannelWillBeCreated(t *testing.T) {
+	mainHelper.Parallel(t)
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+
+	tearDown, _, _ := SetAppEnvironmentWithPlugins(t,
+		[]string{
+			`
+		package main
+
+		import (
+			"strings"
+
+			"github.com/mattermost/mattermost/server/public/plugin"
+			"github.com/mattermost/mattermost/server/public/model"
+		)
+
+		type MyPlugin struct {
+			plugin.MattermostPlugin
+		}
+
+		func (p *MyPlugin) ChannelWillBeCreated(c *plugin.Context, channel *model.Channel) (*model.Channel, string) {
+			if strings.HasPrefix(channel.Name, "rejected") {
+				return nil, "channel name is not allowed"
+			}
+			channel.Purpose = "fromplugin"
+			channel.Type = model.ChannelTypePrivate
+			channel.TeamId = model.NewId()
+			return channel, ""
+		}
+
+		func main() {
+			plugin.ClientMain(&MyPlugin{})
+		}
+	`,
+		}, th.App, th.NewPluginAPI)
+	defer tearDown()
+
+	channel, appErr := th.App.CreateChannel(th.Context, &model.Channel{
+		TeamId:      th.BasicTeam.Id,
+		Name:        "allowed-" + model.NewId(),
+		DisplayName: "Allowed",
+		Type:        model.ChannelTypeOpen,
+		CreatorId:   th.BasicUser.Id,
+	}, true)
+	require.Nil(t, appErr)
+	assert.Equal(t, "fromplugin", channel.Purpose)
+	// Plugins can't change the team or type of the channel.
+	assert.Equal(t, model.ChannelTypeOpen, channel.Type)
+	assert.Equal(t, th.BasicTeam.Id, channel.TeamId)
+
+	channel, appErr = th.App.CreateChannel(th.Context, &model.Channel{
+		TeamId:      th.BasicTeam.Id,
+		Name:        "rejected-" + model.NewId(),
+		DisplayName: "Rejected",
+		Type:        model.ChannelTypeOpen,
+		CreatorId:   th.BasicUser.Id,
+	}, true)
+	require.NotNil(t, appErr)
+	assert.Nil(t, channel)
+	assert.Equal(t, "app.channel.create_channel.rejected_by_plugin.app_error", appErr.Id)
+	assert.Equal(t, http.StatusBadRequest, appErr.StatusCode)
+}
+
+func TestHookChannelWillBeUpdated(t *testing.T) {
+	mainHelper.Parallel(t)
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+
+	tearDown, _, _ := SetAppEnvironmentWithPlugins(t,
+		[]string{
+			`
+		package main
+
+		import (
+			"github.com/mattermost/mattermost/server/public/plugin"
+			"github.com/mattermost/mattermost/server/public/model"
+		)
+
+		type MyPlugin struct {
+			plugin.MattermostPlugin
+		}
+
+		func (p *MyPlugin) ChannelWillBeUpdated(c *plugin.Context, newChannel, oldChannel *model.Channel) (*model.Channel, string) {
+			if newChannel.Header == "rejected" {
+				return nil, "header is not allowed"
+			}
+			if newChannel.TeamId != oldChannel.TeamId && oldChannel.Header == "unmovable" {
+				return nil, "channel can't be moved"
+			}
+			newChannel.Purpose = oldChannel.Purpose + "fromplugin"
+			newChannel.Type = model.ChannelTypePrivate
+			newChannel.TeamId = model.NewId()
+			newChannel.CreatorId = model.NewId()
+			return newChannel, ""
+		}
+
+		func main() {
+			plugin.ClientMain(&MyPlugin{})
+		}
+	`,
+		}, th.App, th.NewPluginAPI)
+	defer tearDown()
+
+	channel, appErr := th.App.PatchChannel(th.Context, th.BasicChannel.DeepCopy(), &model.ChannelPatch{Header: model.NewPointer("allowed")}, th.BasicUser.Id)
+	require.Nil(t, appErr)
+	assert.Equal(t, "allowed", channel.Header)
+	assert.Equal(t, th.BasicChannel.Purpose+"fromplugin", channel.Purpose)
+
+	_, appErr = th.App.PatchChannel(th.Context, channel.DeepCopy(), &model.ChannelPatch{Header: model.NewPointer("rejected")}, th.BasicUser.Id)
+	require.NotNil(t, appErr)
+	assert.Equal(t, "app.channel.update_channel.rejected_by_plugin.app_error", appErr.Id)
+
+	channel, appErr = th.App.GetChannel(th.Context, channel.Id)
+	require.Nil(t, appErr)
+	assert.Equal(t, "allowed", channel.Header)
+	// Plugins can't change the team, type or creator of the channel.
+	assert.Equal(t, th.BasicChannel.Type, channel.Type)
+	assert.Equal(t, th.BasicChannel.TeamId, channel.TeamId)
+	assert.Equal(t, th.BasicChannel.CreatorId, channel.CreatorId)
+
+	t.Run("move", func(t *testing.T) {
+		team := th.CreateTeam()
+		th.LinkUserToTeam(th.BasicUser, team)
+		th.LinkUserToTeam(th.BasicUser2, team)
+
+		unmovable := th.CreateChannel(th.Context, th.BasicTeam, func(ch *model.Channel) { ch.Header = "unmovable" })
+		appErr := th.App.MoveChannel(th.Context, team, unmovable, th.BasicUser)
+		require.NotNil(t, appErr)
+		assert.Equal(t, "app.channel.update_channel.rejected_by_plugin.app_error", appErr.Id)
+
+		unmovable, appErr = th.App.GetChannel(th.Context, unmovable.Id)
+		require.Nil(t, appErr)
+		assert.Equal(t, th.BasicTeam.Id, unmovable.TeamId)
+
+		movable := th.CreateChannel(th.Context, th.BasicTeam)
+		appErr = th.App.MoveChannel(th.Context, team, movable, th.BasicUser)
+		require.Nil(t, appErr)
+		assert.Equal(t, team.Id, movable.TeamId)
+		assert.True(t, strings.HasSuffix(movable.Purpose, "fromplugin"))
+	})
+}
+
+func TestHookChannelWillBeArchived(t *testing.T) {
+	mainHelper.Parallel(t)
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+
+	tearDown, _, _ := SetAppEnvironmentWithPlugins(t,
+		[]string{
+			`
+		package main
+
+		import (
+			"github.com/mattermost/mattermost/server/public/plugin"
+			"github.com/mattermost/mattermost/server/public/model"
+		)
+
+		type MyPlugin struct {
+			plugin.MattermostPlugin
+		}
+
+		func (p *MyPlugin) ChannelWillBeArchived(c *plugin.Context, channel *model.Channel) string {
+			if channel.Purpose == "keep" {
+				return "channel must be kept"
+			}
+			return ""
+		}
+
+		func (p *MyPlugin) ChannelWillBeUnarchived(c *plugin.Context, channel *model.Channel) string {
+			return "channel must stay archived"
+		}
+
+		func main() {
+			plugin.ClientMain(&MyPlugin{})
+		}
+	`,
+		}, th.App, th.NewPluginAPI)
+	defer tearDown()
+
+	kept := th.CreateChannel(th.Context, th.BasicTeam)
+	kept.Purpose = "keep"
+	kept, appErr := th.App.UpdateChannel(th.Context, kept)
+	require.Nil(t, appErr)
+
+	appErr = th.App.DeleteChannel(th.Context, kept, th.BasicUser.Id)
+	require.NotNil(t, appErr)
+	assert.Equal(t, "app.channel.delete_channel.rejected_by_plugin.app_error", appErr.Id)
+
+	kept, appErr = th.App.GetChannel(th.Context, kept.Id)
+	require.Nil(t, appErr)
+	assert.Zero(t, kept.DeleteAt)
+
+	archived := th.CreateChannel(th.Context, th.BasicTeam)
+	appErr = th.App.DeleteChannel(th.Context, archived, th.BasicUser.Id)
+	require.Nil(t, appErr)
+
+	archived, appErr = th.App.GetChannel(th.Context, archived.Id)
+	require.Nil(t, appErr)
+	require.NotZero(t, archived.DeleteAt)
+
+	_, appErr = th.App.RestoreChannel(th.Context, archived, th.BasicUser.Id)
+	require.NotNil(t, appErr)
+	assert.Equal(t, "app.channel.restore_channel.rejected_by_plugin.app_error", appErr.Id)
+
+	archived, appErr = th.App.GetChannel(th.Context, archived.Id)
+	require.Nil(t, appErr)
+	assert.NotZero(t, archived.DeleteAt)
+}
+
+func TestHookTeamWillBeJoined(t *testing.T) {
+	mainHelper.Parallel(t)
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+
+	tearDown, _, _ := SetAppEnvironmentWithPlugins(t,
+		[]string{
+			`
+		package main
+
+		import (
+			"github.com/mattermost/mattermost/server/public/plugin"
+			"github.com/mattermost/mattermost/server/public/model"
+		)
+
+		type MyPlugin struct {
+			plugin.MattermostPlugin
+		}
+
+		func (p *MyPlugin) TeamWillBeJoined(c *plugin.Context, team *model.Team, user *model.User, actor *model.User) string {
+			if actor == nil {
+				return "users must be added by an admin"
+			}
+			return ""
+		}
+
+		func main() {
+			plugin.ClientMain(&MyPlugin{})
+		}
+	`,
+		}, th.App, th.NewPluginAPI)
+	defer tearDown()
+
+	user := th.CreateUser()
+
+	_, appErr := th.App.JoinUserToTeam(th.Context, th.BasicTeam, user, "")
+	require.NotNil(t, appErr)
+	assert.Equal(t, "app.team.join_user_to_team.rejected_by_plugin.app_error", appErr.Id)
+
+	_, appErr = th.App.GetTeamMember(th.Context, th.BasicTeam.Id, user.Id)
+	require.NotNil(t, appErr)
+
+	_, appErr = th.App.JoinUserToTeam(th.Context, th.BasicTeam, user, th.SystemAdminUser.Id)
+	require.Nil(t, appErr)
+
+	// Existing members aren't checked again.
+	_, appErr = th.App.JoinUserToTeam(th.Context, th.BasicTeam, user, "")
+	require.Nil(t, appErr)
+}
+
+func TestHookUserWillBeUpdated(t *testing.T) {
+	mainHelper.Parallel(t)
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+
+	tearDown, _, _ := SetAppEnvironmentWithPlugins(t,
+		[]string{
+			`
+		package main
+
+		import (
+			"github.com/mattermost/mattermost/server/public/plugin"
+			"github.com/mattermost/mattermost/server/public/model"
+		)
+
+		type MyPlugin struct {
+			plugin.MattermostPlugin
+		}
+
+		func (p *MyPlugin) UserWillBeUpdated(c *plugin.Context, newUser, oldUser *model.User) (*model.User, string) {
+			if newUser.Nickname == "rejected" {
+				return nil, "nickname is not allowed"
+			}
+			newUser.Position = "fromplugin"
+			newUser.Roles = model.SystemAdminRoleId + " " + model.SystemUserRoleId
+			newUser.AuthService = model.UserAuthServiceGitlab
+			return newUser, ""
+		}
+
+		func main() {
+			plugin.ClientMain(&MyPlugin{})
+		}
+	`,
+		}, th.App, th.NewPluginAPI)
+	defer tearDown()
+
+	user, appErr := th.App.PatchUser(th.Context, th.BasicUser.Id, &model.UserPatch{Nickname: model.NewPointer("allowed")}, false)
+	require.Nil(t, appErr)
+	assert.Equal(t, "allowed", user.Nickname)
+	assert.Equal(t, "fromplugin", user.Position)
+
+	_, appErr = th.App.PatchUser(th.Context, th.BasicUser.Id, &model.UserPatch{Nickname: model.NewPointer("rejected")}, false)
+	require.NotNil(t, appErr)
+	assert.Equal(t, "app.user.update.rejected_by_plugin.app_error", appErr.Id)
+
+	user, appErr = th.App.GetUser(th.BasicUser.Id)
+	require.Nil(t, appErr)
+	assert.Equal(t, "allowed", user.Nickname)
+	// Plugins can't change the roles or authentication of the user.
+	assert.Equal(t, th.BasicUser.Roles, user.Roles)
+	assert.Equal(t, th.BasicUser.AuthService, user.AuthService)
+}
+
+func TestHookSearchWillBeExecuted(t *testing.T) {
+	mainHelper.Parallel(t)
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+
+	tearDown, _, _ := SetAppEnvironmentWithPlugins(t,
+		[]string{
+			`
+		package main
+
+		import (
+			"github.com/mattermost/mattermost/server/public/plugin"
+			"github.com/mattermost/mattermost/server/public/model"
+		)
+
+		type MyPlugin struct {
+			plugin.MattermostPlugin
+		}
+
+		func (p *MyPlugin) SearchWillBeExecuted(c *plugin.Context, search *model.PluginSearch) (*model.PluginSearch, string) {
+			for _, params := range search.Params {
+				if params.Terms == "forbidden" {
+					return nil, "search term is not allowed for " + search.Type
+				}
+				if params.Terms == "alias" {
+					params.Terms = "pineapple"
+				}
+				if params.Terms == "everything" {
+					params.Terms = "*"
+				}
+			}
+			return search, ""
+		}
+
+		func main() {
+			plugin.ClientMain(&MyPlugin{})
+		}
+	`,
+		}, th.App, th.NewPluginAPI)
+	defer tearDown()
+
+	post := th.CreatePost(th.BasicChannel, func(p *model.Post) { p.Message = "pineapple" })
+
+	results, appErr := th.App.SearchPostsForUser(th.Context, "alias", th.BasicUser.Id, th.BasicTeam.Id, false, false, 0, 0, 20)
+	require.Nil(t, appErr)
+	assert.Equal(t, []string{post.Id}, results.Order)
+
+	_, appErr = th.App.SearchPostsForUser(th.Context, "forbidden", th.BasicUser.Id, th.BasicTeam.Id, false, false, 0, 0, 20)
+	require.NotNil(t, appErr)
+	assert.Equal(t, "app.search.rejected_by_plugin.app_error", appErr.Id)
+
+	// Plugins can't search for everything any more than users can.
+	results, appErr = th.App.SearchPostsForUser(th.Context, "everything", th.BasicUser.Id, th.BasicTeam.Id, false, false, 0, 0, 20)
+	require.Nil(t, appErr)
+	assert.Empty(t, results.Order)
+
+	_, appErr = th.App.SearchFilesInTeamForUser(th.Context, "forbidden", th.BasicUser.Id, th.BasicTeam.Id, false, false, 0, 0, 20)
+	require.NotNil(t, appErr)
+	assert.Equal(t, "app.search.rejected_by_plugin.app_error", appErr.Id)
+}
+
+func TestHookSearchResultsProvided(t *testing.T) {
+	mainHelper.Parallel(t)
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+
+	older := th.CreatePost(th.BasicChannel, func(p *model.Post) {
+		p.Message = "pineapple older"
+		p.CreateAt = model.GetMillis() - 30000
+	})
+	newer := th.CreatePost(th.BasicChannel, func(p *model.Post) { p.Message = "pineapple newer" })
+
+	tearDown, _, _ := SetAppEnvironmentWithPlugins(t,
+		[]string{
+			fmt.Sprintf(`
+		package main
+
+		import (
+			"github.com/mattermost/mattermost/server/public/plugin"
+			"github.com/mattermost/mattermost/server/public/model"
+		)
+
+		type MyPlugin struct {
+			plugin.MattermostPlugin
+		}
+
+		func (p *MyPlugin) SearchWillBeExecuted(c *plugin.Context, search *model.PluginSearch) (*model.PluginSearch, string) {
+			for _, params := range search.Params {
+				if params.Terms == "wiki" {
+					search.Params = nil
+					break
+				}
+			}
+			return search, ""
+		}
+
+		func (p *MyPlugin) SearchResultsProvided(c *plugin.Context, search *model.PluginSearch) (*model.PostSearchResults, error) {
+			post := &model.Post{
+				ChannelId: "%s",
+				UserId:    search.UserId,
+				Message:   "pineapple wiki page",
+				Type:      "custom_wiki",
+				CreateAt:  %d,
+			}
+			list := model.NewPostList()
+			list.Posts["wiki"] = post
+			list.AddOrder("wiki")
+			return model.MakePostSearchResults(list, model.PostSearchMatches{"wiki": {"pineapple"}}), nil
+		}
+
+		func main() {
+			plugin.ClientMain(&MyPlugin{})
+		}
+	`, th.BasicChannel.Id, older.CreateAt+10000),
+		}, th.App, th.NewPluginAPI)
+	defer tearDown()
+
+	results, appErr := th.App.SearchPostsForUser(th.Context, "pineapple", th.BasicUser.Id, th.BasicTeam.Id, false, false, 0, 0, 20)
+	require.Nil(t, appErr)
+	require.Len(t, results.Order, 3)
+	assert.Equal(t, newer.Id, results.Order[0])
+	assert.Equal(t, older.Id, results.Order[2])
+
+	virtual := results.Posts[results.Order[1]]
+	require.NotNil(t, virtual)
+	assert.NotEmpty(t, virtual.Id)
+	assert.Equal(t, "pineapple wiki page", virtual.Message)
+	assert.Equal(t, "true", virtual.GetProp(model.PostPropsFromPlugin))
+	assert.Equal(t, []string{"pineapple"}, results.Matches[virtual.Id])
+
+	_, err := th.App.Srv().Store().Post().GetSingle(th.Context, virtual.Id, false)
+	require.Error(t, err)
+
+	// The results of plugins are only merged into the first page.
+	results, appErr = th.App.SearchPostsForUser(th.Context, "pineapple", th.BasicUser.Id, th.BasicTeam.Id, false, false, 0, 1, 20)
+	require.Nil(t, appErr)
+	assert.Empty(t, results.Order)
+
+	// Plugins provide results even if no search params are left to run.
+	results, appErr = th.App.SearchPostsForUser(th.Context, "wiki", th.BasicUser.Id, th.BasicTeam.Id, false, false, 0, 0, 20)
+	require.Nil(t, appErr)
+	require.Len(t, results.Order, 1)
+	assert.Equal(t, "pineapple wiki page", results.Posts[results.Order[0]].Message)
+}
+
+func TestHookEmailNotificationWillBeSent(t *testing.T) {
+	mainHelper.Parallel(t)
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+
+	th.App.UpdateConfig(func(cfg *model.Config) {
+		*cfg.EmailSettings.EnableEmailBatching = false
+	})
+
+	tearDown, pluginIDs, _ := SetAppEnvironmentWithPlugins(t,
+		[]string{
+			`
+		package main
+
+		import (
+			"encoding/json"
+
+			"github.com/mattermost/mattermost/server/public/plugin"
+			"github.com/mattermost/mattermost/server/public/model"
+		)
+
+		type MyPlugin struct {
+			plugin.MattermostPlugin
+		}
+
+		func (p *MyPlugin) EmailNotificationWillBeSent(emailNotification *model.EmailNotification) (*model.EmailNotification, string) {
+			data, _ := json.Marshal(emailNotification)
+			p.API.KVSet("email", data)
+			return nil, "suppressed"
+		}
+
+		func main() {
+			plugin.ClientMain(&MyPlugin{})
+		}
+	`,
+		}, th.App, th.NewPluginAPI)
+	defer tearDown()
+
+	post := th.CreatePost(th.BasicChannel)
+	notification := &PostNotification{
+		Channel:    th.BasicChannel,
+		Post:       post,
+		ProfileMap: map[string]*model.User{th.BasicUser.Id: th.BasicUser},
+		Sender:     th.BasicUser,
+	}
+	err := th.App.sendNotificationEmail(th.Context, notification, th.BasicUser2, th.BasicTeam, nil)
+	require.NoError(t, err)
+
+	data, appErr := th.App.GetPluginKey(pluginIDs[0], "email")
+	require.Nil(t, appErr)
+	require.NotNil(t, data)
+
+	var received model.EmailNotification
+	require.NoError(t, json.Unmarshal(data, &received))
+	assert.Equal(t, model.EmailNotificationTypeImmediate, received.Type)
+	assert.Equal(t, th.BasicUser2.Id, received.RecipientId)
+	assert.Equal(t, th.BasicUser2.Email, received.To)
+	assert.NotEmpty(t, received.Subject)
+	assert.Contains(t, received.Body, post.Message)
+	assert.NotEmpty(t, received.TemplateData["ButtonURL"])
+	assert.Equal(t, []string{post.Id}, received.PostIds)
+}
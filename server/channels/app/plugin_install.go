@@ -44,6 +44,10 @@
 // Plugins are installed to the filestore when the user installs via the marketplace or system
 // console. (Or because the plugin is transitionally prepackaged).
 //
+// A plugin requiring capabilities or permissions the server doesn't provide can't be installed,
+// and neither can a plugin depending on another plugin installed with an incompatible version.
+// Missing dependencies are allowed, but the plugin isn't activated until they are running.
+//
 // ### Enabling a Plugin
 //
 // When a plugin is enabled, all connected websocket clients are notified so as to fetch any
@@ -80,13 +84,14 @@ import (
 	"net/http"
 	"os"
 	"path/filepath"
+	"slices"
+	"strings"
 
 	"github.com/blang/semver/v4"
 
 	"github.com/mattermost/mattermost/server/public/model"
 	"github.com/mattermost/mattermost/server/public/shared/mlog"
 	"github.com/mattermost/mattermost/server/public/utils"
-	"github.com/mattermost/mattermost/server/v8/platform/shared/filestore"
 )
 
 // fileStorePluginFolder is the folder name in the file store of the plugin bundles installed.
@@ -122,22 +127,13 @@ func (ch *Channels) installPluginFromClusterMessage(pluginID string) {
 	}
 	defer bundle.Close()
 
-	var signature filestore.ReadCloseSeeker
-	if *ch.cfgSvc.Config().PluginSettings.RequirePluginSignature {
-		signature, appErr = ch.srv.fileReader(plugin.signaturePath)
-		if appErr != nil {
-			logger.Error("Failed to open plugin signature from file store.", mlog.Err(appErr))
-			return
-		}
-		defer signature.Close()
-
-		if err := ch.verifyPlugin(logger, bundle, signature); err != nil {
-			logger.Error("Failed to validate plugin signature.", mlog.Err(appErr))
-			return
-		}
+	pluginSignature, appErr := ch.verifyPluginFromFileStore(logger, plugin, bundle)
+	if appErr != nil {
+		logger.Error("Failed to validate plugin signature.", mlog.Err(appErr))
+		return
 	}
 
-	manifest, appErr := ch.installPluginLocally(bundle, installPluginLocallyAlways)
+	manifest, appErr := ch.installPluginLocally(bundle, pluginSignature, installPluginLocallyAlways)
 	if appErr != nil {
 		// A log line already appears if the plugin is on the blocklist or skipped
 		if appErr.Id != "app.plugin.blocked.app_error" && appErr.Id != "app.plugin.skip_installation.app_error" {
@@ -171,6 +167,25 @@ func (ch *Channels) removePluginFromClusterMessage(pluginID string) {
 	}
 }
 
+// deactivatePluginFromClusterMessage is called when a peer needs a plugin to stop running on all
+// servers, such as while rolling back its migrations, signalling all other servers to deactivate it.
+// The plugin is activated again once the peer installs the replacing bundle.
+func (ch *Channels) deactivatePluginFromClusterMessage(pluginID string) {
+	logger := ch.srv.Log().With(mlog.String("plugin_id", pluginID))
+
+	logger.Info("Deactivating plugin as per cluster message")
+
+	pluginsEnvironment := ch.GetPluginsEnvironment()
+	if pluginsEnvironment == nil {
+		return
+	}
+	pluginsEnvironment.Deactivate(pluginID)
+
+	if err := ch.notifyPluginStatusesChanged(); err != nil {
+		logger.Error("Failed to notify plugin status changed", mlog.Err(err))
+	}
+}
+
 // InstallPlugin unpacks and installs a plugin but does not enable or activate it unless the the
 // plugin was already enabled.
 func (a *App) InstallPlugin(pluginFile io.ReadSeeker, replace bool) (*model.Manifest, *model.AppError) {
@@ -179,16 +194,17 @@ func (a *App) InstallPlugin(pluginFile io.ReadSeeker, replace bool) (*model.Mani
 		installationStrategy = installPluginLocallyAlways
 	}
 
-	return a.ch.installPlugin(pluginFile, nil, installationStrategy)
+	return a.ch.installPlugin(pluginFile, nil, nil, installationStrategy)
 }
 
 // installPlugin extracts and installs the given plugin bundle (optionally signed) for the
 // current server, activating the plugin if already enabled, installs it to the filestore for
-// cluster peers to use, and then broadcasts the change to connected websockets.
+// cluster peers to use, and then broadcasts the change to connected websockets. The plugin
+// signature is the key the signature was verified to be made with, if signed.
 //
 // The given installation strategy decides how to handle upgrade scenarios.
-func (ch *Channels) installPlugin(bundle, signature io.ReadSeeker, installationStrategy pluginInstallationStrategy) (*model.Manifest, *model.AppError) {
-	manifest, appErr := ch.installPluginLocally(bundle, installationStrategy)
+func (ch *Channels) installPlugin(bundle, signature io.ReadSeeker, pluginSignature *model.PluginSignature, installationStrategy pluginInstallationStrategy) (*model.Manifest, *model.AppError) {
+	manifest, appErr := ch.installPluginLocally(bundle, pluginSignature, installationStrategy)
 	if appErr != nil {
 		return nil, appErr
 	}
@@ -259,8 +275,8 @@ func (ch *Channels) installPluginToFilestore(manifest *model.Manifest, bundle, s
 }
 
 // InstallMarketplacePlugin installs a plugin listed in the marketplace server. It will get the
-// plugin bundle from the prepackaged folder, if available, or remotely if EnableRemoteMarketplace
-// is true.
+// plugin bundle from the prepackaged folder, if available, from the marketplace catalog if
+// configured, or remotely if EnableRemoteMarketplace is true, preferring the newest version.
 func (ch *Channels) InstallMarketplacePlugin(request *model.InstallMarketplacePluginRequest) (*model.Manifest, *model.AppError) {
 	logger := ch.srv.Log().With(
 		mlog.String("plugin_id", request.Id),
@@ -292,6 +308,44 @@ func (ch *Channels) InstallMarketplacePlugin(request *model.InstallMarketplacePl
 		logger.Debug("Found matching pre-packaged plugin", mlog.String("bundle_path", prepackagedPlugin.Path), mlog.String("signature_path", prepackagedPlugin.SignaturePath))
 	}
 
+	// foundVersion is the version of the plugin found so far, which is only replaced by newer ones.
+	var foundVersion semver.Version
+	if prepackagedPlugin != nil {
+		var err error
+		foundVersion, err = semver.Parse(prepackagedPlugin.Manifest.Version)
+		if err != nil {
+			return nil, model.NewAppError("InstallMarketplacePlugin", "app.plugin.invalid_version.app_error", nil, "", http.StatusBadRequest).Wrap(err)
+		}
+	}
+
+	catalogPlugin, appErr := ch.getMarketplaceCatalogPlugin(request.Id, request.Version)
+	if appErr != nil && appErr.Id != "app.plugin.marketplace_plugins.not_found.app_error" {
+		logger.Warn("Failed to read marketplace catalog to install plugin", mlog.Err(appErr))
+	}
+	if catalogPlugin != nil {
+		catalogVersion, err := semver.Parse(catalogPlugin.Manifest.Version)
+		if err != nil {
+			return nil, model.NewAppError("InstallMarketplacePlugin", "app.plugin.invalid_version.app_error", nil, "", http.StatusBadRequest).Wrap(err)
+		}
+
+		if foundVersion.LT(catalogVersion) { // Always true if no prepackaged plugin was found
+			logger.Debug("Found upgraded plugin in marketplace catalog", mlog.String("version", catalogPlugin.Manifest.Version))
+
+			bundleReader, signatureReader, appErr := ch.openMarketplaceCatalogPlugin(request.Id, catalogPlugin.Manifest.Version)
+			if appErr != nil {
+				return nil, appErr
+			}
+			defer bundleReader.Close()
+			defer signatureReader.Close()
+
+			pluginFile = bundleReader
+			signatureFile = signatureReader
+			foundVersion = catalogVersion
+		} else {
+			logger.Debug("Preferring pre-packaged plugin over version in marketplace catalog", mlog.String("version", catalogPlugin.Manifest.Version))
+		}
+	}
+
 	if *ch.cfgSvc.Config().PluginSettings.EnableRemoteMarketplace {
 		var plugin *model.BaseMarketplacePlugin
 		plugin, appErr = ch.getRemoteMarketplacePlugin(request.Id, request.Version)
@@ -301,21 +355,12 @@ func (ch *Channels) InstallMarketplacePlugin(request *model.InstallMarketplacePl
 		}
 
 		if plugin != nil {
-			var prepackagedVersion semver.Version
-			if prepackagedPlugin != nil {
-				var err error
-				prepackagedVersion, err = semver.Parse(prepackagedPlugin.Manifest.Version)
-				if err != nil {
-					return nil, model.NewAppError("InstallMarketplacePlugin", "app.plugin.invalid_version.app_error", nil, "", http.StatusBadRequest).Wrap(err)
-				}
-			}
-
 			marketplaceVersion, err := semver.Parse(plugin.Manifest.Version)
 			if err != nil {
 				return nil, model.NewAppError("InstallMarketplacePlugin", "app.prepackged-plugin.invalid_version.app_error", nil, "", http.StatusBadRequest).Wrap(err)
 			}
 
-			if prepackagedVersion.LT(marketplaceVersion) { // Always true if no prepackaged plugin was found
+			if foundVersion.LT(marketplaceVersion) { // Always true if no plugin was found yet
 				logger.Debug("Found upgraded plugin from remote marketplace", mlog.String("version", plugin.Manifest.Version), mlog.String("download_url", plugin.DownloadURL))
 
 				downloadedPluginBytes, err := ch.srv.downloadFromURL(plugin.DownloadURL)
@@ -329,7 +374,7 @@ func (ch *Channels) InstallMarketplacePlugin(request *model.InstallMarketplacePl
 				pluginFile = bytes.NewReader(downloadedPluginBytes)
 				signatureFile = signature
 			} else {
-				logger.Debug("Preferring pre-packaged plugin over version in remote marketplace", mlog.String("version", plugin.Manifest.Version), mlog.String("download_url", plugin.DownloadURL))
+				logger.Debug("Preferring pre-packaged or catalog plugin over version in remote marketplace", mlog.String("version", plugin.Manifest.Version), mlog.String("download_url", plugin.DownloadURL))
 			}
 		}
 	}
@@ -341,12 +386,13 @@ func (ch *Channels) InstallMarketplacePlugin(request *model.InstallMarketplacePl
 		return nil, model.NewAppError("InstallMarketplacePlugin", "app.plugin.marketplace_plugins.signature_not_found.app_error", nil, "", http.StatusInternalServerError)
 	}
 
-	appErr = ch.verifyPlugin(logger, pluginFile, signatureFile)
+	pluginSignature, appErr := ch.verifyPlugin(logger, pluginFile, signatureFile)
 	if appErr != nil {
 		return nil, appErr
 	}
+	logger.Info("Verified marketplace plugin signature", mlog.String("key_id", pluginSignature.KeyId), mlog.String("key_name", pluginSignature.KeyName))
 
-	manifest, appErr := ch.installPlugin(pluginFile, signatureFile, installPluginLocallyAlways)
+	manifest, appErr := ch.installPlugin(pluginFile, signatureFile, pluginSignature, installPluginLocallyAlways)
 	if appErr != nil {
 		return nil, appErr
 	}
@@ -366,10 +412,10 @@ const (
 )
 
 // installPluginLocally extracts and installs the given plugin bundle for the current server,
-// activating the plugin if already enabled.
+// activating the plugin if already enabled. The plugin signature is recorded with the plugin.
 //
 // The given installation strategy decides how to handle upgrade scenarios.
-func (ch *Channels) installPluginLocally(bundle io.ReadSeeker, installationStrategy pluginInstallationStrategy) (*model.Manifest, *model.AppError) {
+func (ch *Channels) installPluginLocally(bundle io.ReadSeeker, pluginSignature *model.PluginSignature, installationStrategy pluginInstallationStrategy) (*model.Manifest, *model.AppError) {
 	pluginsEnvironment := ch.GetPluginsEnvironment()
 	if pluginsEnvironment == nil {
 		return nil, model.NewAppError("installPluginLocally", "app.plugin.disabled.app_error", nil, "", http.StatusNotImplemented)
@@ -386,7 +432,7 @@ func (ch *Channels) installPluginLocally(bundle io.ReadSeeker, installationStrat
 		return nil, appErr
 	}
 
-	manifest, appErr = ch.installExtractedPlugin(manifest, pluginDir, installationStrategy)
+	manifest, appErr = ch.installExtractedPlugin(manifest, pluginDir, pluginSignature, installationStrategy)
 	if appErr != nil {
 		return nil, appErr
 	}
@@ -427,10 +473,11 @@ func extractPlugin(bundle io.ReadSeeker, extractDir string) (*model.Manifest, st
 }
 
 // installExtractedPlugin installs a plugin previously extracted to a temporary directory,
-// activating the plugin automatically if already enabled by the server configuration.
+// activating the plugin automatically if already enabled by the server configuration. The key the
+// plugin is signed with, if any, is recorded with the plugin.
 //
 // The given installation strategy decides how to handle upgrade scenarios.
-func (ch *Channels) installExtractedPlugin(manifest *model.Manifest, fromPluginDir string, installationStrategy pluginInstallationStrategy) (*model.Manifest, *model.AppError) {
+func (ch *Channels) installExtractedPlugin(manifest *model.Manifest, fromPluginDir string, pluginSignature *model.PluginSignature, installationStrategy pluginInstallationStrategy) (*model.Manifest, *model.AppError) {
 	logger := ch.srv.Log().With(mlog.String("plugin_id", manifest.Id))
 
 	logger.Info("Installing extracted plugin", mlog.String("version", manifest.Version))
@@ -447,11 +494,23 @@ func (ch *Channels) installExtractedPlugin(manifest *model.Manifest, fromPluginD
 
 	// Check for plugins installed with the same ID.
 	var existingManifest *model.Manifest
+	var existingBundle *model.BundleInfo
+	var downgrade *pluginMigrationsDowngrade
+	installedManifests := make([]*model.Manifest, 0, len(bundles))
 	for _, bundle := range bundles {
-		if bundle.Manifest != nil && bundle.Manifest.Id == manifest.Id {
+		if bundle.Manifest == nil {
+			continue
+		}
+		if bundle.Manifest.Id == manifest.Id {
 			existingManifest = bundle.Manifest
-			break
+			existingBundle = bundle
+			continue
 		}
+		installedManifests = append(installedManifests, bundle.Manifest)
+	}
+
+	if appErr := checkPluginRequirements(logger, manifest, installedManifests); appErr != nil {
+		return nil, appErr
 	}
 
 	if existingManifest != nil {
@@ -480,6 +539,14 @@ func (ch *Channels) installExtractedPlugin(manifest *model.Manifest, fromPluginD
 			}
 		}
 
+		// Load the down migrations of the existing installation before it's removed, as only
+		// its bundle ships them.
+		var appErr *model.AppError
+		downgrade, appErr = ch.preparePluginMigrationsDowngrade(existingBundle, manifest, fromPluginDir)
+		if appErr != nil {
+			return nil, appErr
+		}
+
 		// Otherwise remove the existing installation prior to installing below.
 		logger.Info("Removing existing installation of plugin before local install", mlog.String("existing_version", existingManifest.Version))
 		if err := ch.removePluginLocally(existingManifest.Id); err != nil {
@@ -493,6 +560,10 @@ func (ch *Channels) installExtractedPlugin(manifest *model.Manifest, fromPluginD
 		return nil, model.NewAppError("installExtractedPlugin", "app.plugin.mvdir.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
 	}
 
+	if err := savePluginSignature(bundlePath, pluginSignature); err != nil {
+		return nil, model.NewAppError("installExtractedPlugin", "app.plugin.store_signature.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+
 	if manifest.HasWebapp() {
 		updatedManifest, err := pluginsEnvironment.UnpackWebappBundle(manifest.Id)
 		if err != nil {
@@ -501,6 +572,14 @@ func (ch *Channels) installExtractedPlugin(manifest *model.Manifest, fromPluginD
 		manifest = updatedManifest
 	}
 
+	// Roll back the migrations the existing installation applied that the version now in place
+	// doesn't ship, before it gets activated.
+	if downgrade != nil {
+		if appErr := ch.rollbackPluginMigrationsOnDowngrade(downgrade); appErr != nil {
+			return nil, appErr
+		}
+	}
+
 	// Activate the plugin if enabled.
 	pluginState := ch.cfgSvc.Config().PluginSettings.PluginStates[manifest.Id]
 	if pluginState != nil && pluginState.Enable {
@@ -520,6 +599,37 @@ func (ch *Channels) installExtractedPlugin(manifest *model.Manifest, fromPluginD
 	return manifest, nil
 }
 
+// checkPluginRequirements returns an error if the server doesn't provide the capabilities and
+// permissions required by the plugin, or if a plugin it depends on is installed with an
+// incompatible version. Missing dependencies don't prevent the installation, as they can be
+// installed afterwards, but the plugin won't be activated until they are running.
+func checkPluginRequirements(logger mlog.LoggerIFace, manifest *model.Manifest, installedManifests []*model.Manifest) *model.AppError {
+	unmet := manifest.UnmetServerRequirements()
+	for _, dependency := range manifest.Dependencies {
+		i := slices.IndexFunc(installedManifests, func(m *model.Manifest) bool { return m.Id == dependency.Id })
+		if i == -1 {
+			logger.Warn("Plugin dependency is not installed", mlog.String("dependency", dependency.String()))
+		} else if !dependency.SatisfiedBy(installedManifests[i].Version) {
+			unmet = append(unmet, fmt.Sprintf("requires plugin %s, but version %s is installed", dependency, installedManifests[i].Version))
+		}
+	}
+
+	// Warn about the installed plugins this version no longer satisfies.
+	for _, installed := range installedManifests {
+		for _, dependency := range installed.Dependencies {
+			if dependency.Id == manifest.Id && !dependency.SatisfiedBy(manifest.Version) {
+				logger.Warn("Plugin version doesn't satisfy the dependency of an installed plugin", mlog.String("dependent_plugin_id", installed.Id), mlog.String("dependency", dependency.String()))
+			}
+		}
+	}
+
+	if len(unmet) > 0 {
+		return model.NewAppError("installExtractedPlugin", "app.plugin.unmet_requirements.app_error", map[string]any{"Requirements": strings.Join(unmet, "; ")}, "", http.StatusBadRequest)
+	}
+
+	return nil
+}
+
 // RemovePlugin removes a plugin from all servers.
 func (ch *Channels) RemovePlugin(id string) *model.AppError {
 	logger := ch.srv.Log().With(mlog.String("plugin_id", id))
@@ -593,6 +703,7 @@ func (ch *Channels) removePluginLocally(id string) *model.AppError {
 	pluginsEnvironment.Deactivate(id)
 	pluginsEnvironment.RemovePlugin(id)
 	ch.unregisterPluginCommands(id)
+	ch.unregisterPluginJobTypes(id)
 
 	if err := os.RemoveAll(unpackedBundlePath); err != nil {
 		return model.NewAppError("removePlugin", "app.plugin.remove.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
@@ -73,7 +73,7 @@ func TestInstallPluginLocally(t *testing.T) {
 		th := Setup(t)
 		defer th.TearDown()
 
-		actualManifest, appErr := th.App.ch.installPluginLocally(&nilReadSeeker{}, installPluginLocallyOnlyIfNew)
+		actualManifest, appErr := th.App.ch.installPluginLocally(&nilReadSeeker{}, nil, installPluginLocallyOnlyIfNew)
 		require.NotNil(t, appErr)
 		assert.Equal(t, "app.plugin.extract.app_error", appErr.Id, appErr.Error())
 		require.Nil(t, actualManifest)
@@ -87,7 +87,7 @@ func TestInstallPluginLocally(t *testing.T) {
 			{"test", "test file"},
 		})
 
-		actualManifest, appErr := th.App.ch.installPluginLocally(reader, installPluginLocallyOnlyIfNew)
+		actualManifest, appErr := th.App.ch.installPluginLocally(reader, nil, installPluginLocallyOnlyIfNew)
 		require.NotNil(t, appErr)
 		assert.Equal(t, "app.plugin.manifest.app_error", appErr.Id, appErr.Error())
 		require.Nil(t, actualManifest)
@@ -106,7 +106,7 @@ func TestInstallPluginLocally(t *testing.T) {
 			{"plugin.json", string(manifestJSON)},
 		})
 
-		actualManifest, appError := th.App.ch.installPluginLocally(reader, installationStrategy)
+		actualManifest, appError := th.App.ch.installPluginLocally(reader, nil, installationStrategy)
 		if actualManifest != nil {
 			require.Equal(t, manifest, actualManifest)
 		}
@@ -265,6 +265,46 @@ func TestInstallPluginLocally(t *testing.T) {
 			assertBundleInfoManifests(t, th, []*model.Manifest{manifest})
 		})
 	})
+
+	t.Run("requirements", func(t *testing.T) {
+		th := Setup(t)
+		defer th.TearDown()
+		cleanExistingBundles(t, th)
+
+		installManifest := func(t *testing.T, manifest *model.Manifest) (*model.Manifest, *model.AppError) {
+			t.Helper()
+
+			manifestJSON, jsonErr := json.Marshal(manifest)
+			require.NoError(t, jsonErr)
+			reader := makeInMemoryGzipTarFile(t, []testFile{
+				{"plugin.json", string(manifestJSON)},
+			})
+
+			return th.App.ch.installPluginLocally(reader, nil, installPluginLocallyAlways)
+		}
+
+		_, appErr := installManifest(t, &model.Manifest{Id: "future", Version: "0.0.1", RequiredCapabilities: []string{"teleportation"}})
+		require.NotNil(t, appErr)
+		assert.Equal(t, "app.plugin.unmet_requirements.app_error", appErr.Id)
+		assert.Contains(t, appErr.Error(), "requires the unsupported server capability teleportation")
+
+		_, appErr = installManifest(t, &model.Manifest{Id: "permissions", Version: "0.0.1", RequiredPermissions: []string{"teleport_users"}})
+		require.NotNil(t, appErr)
+		assert.Equal(t, "app.plugin.unmet_requirements.app_error", appErr.Id)
+
+		// A missing dependency can be installed afterwards.
+		manifest, appErr := installManifest(t, &model.Manifest{Id: "app", Version: "0.0.1", Dependencies: []*model.ManifestDependency{{Id: "lib", Version: ">=1.0.0"}}})
+		require.Nil(t, appErr)
+		require.NotNil(t, manifest)
+
+		_, appErr = installManifest(t, &model.Manifest{Id: "lib", Version: "1.0.0"})
+		require.Nil(t, appErr)
+
+		_, appErr = installManifest(t, &model.Manifest{Id: "newer", Version: "0.0.1", Dependencies: []*model.ManifestDependency{{Id: "lib", Version: ">=2.0.0"}}})
+		require.NotNil(t, appErr)
+		assert.Equal(t, "app.plugin.unmet_requirements.app_error", appErr.Id)
+		assert.Contains(t, appErr.Error(), "requires plugin lib >=2.0.0, but version 1.0.0 is installed")
+	})
 }
 
 func TestInstallPluginAlreadyActive(t *testing.T) {
@@ -0,0 +1,156 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package app
+
+import (
+	"context"
+	"net/http"
+	"slices"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/public/shared/mlog"
+	"github.com/mattermost/mattermost/server/public/shared/request"
+	"github.com/mattermost/mattermost/server/v8/channels/jobs"
+	"github.com/mattermost/mattermost/server/v8/channels/jobs/plugins"
+)
+
+// RegisterPluginJobType registers a job type run by the given plugin, replacing its worker and
+// scheduler when the options changed since the job type was last registered.
+func (a *App) RegisterPluginJobType(pluginID, name string, options *model.PluginJobTypeOptions) *model.AppError {
+	if !model.IsValidPluginJobTypeName(name) {
+		return model.NewAppError("RegisterPluginJobType", "app.plugin.register_job_type.invalid_name.app_error", map[string]any{"Name": name}, "", http.StatusBadRequest)
+	}
+
+	if options == nil {
+		options = &model.PluginJobTypeOptions{}
+	}
+	if options.Interval < 0 {
+		return model.NewAppError("RegisterPluginJobType", "app.plugin.register_job_type.invalid_interval.app_error", nil, "", http.StatusBadRequest)
+	}
+
+	jobType := model.PluginJobType(pluginID, name)
+
+	a.ch.pluginJobTypesLock.Lock()
+	defer a.ch.pluginJobTypesLock.Unlock()
+
+	if existing, ok := a.ch.pluginJobTypes[jobType]; ok {
+		if *existing == *options {
+			return nil
+		}
+		a.Srv().Jobs.UnregisterJobType(jobType)
+	}
+
+	var scheduler jobs.Scheduler
+	if options.Interval > 0 {
+		scheduler = plugins.MakeJobScheduler(a.Srv().Jobs, jobType, options.Interval)
+	}
+	a.Srv().Jobs.RegisterJobType(jobType, plugins.MakeJobWorker(a.Srv().Jobs, a.ch, pluginID, jobType), scheduler)
+
+	if a.ch.pluginJobTypes == nil {
+		a.ch.pluginJobTypes = make(map[string]*model.PluginJobTypeOptions)
+	}
+	a.ch.pluginJobTypes[jobType] = options
+
+	return nil
+}
+
+// GetPluginJobTypes returns the job types currently registered by plugins.
+func (a *App) GetPluginJobTypes() []string {
+	a.ch.pluginJobTypesLock.RLock()
+	defer a.ch.pluginJobTypesLock.RUnlock()
+
+	jobTypes := make([]string, 0, len(a.ch.pluginJobTypes))
+	for jobType := range a.ch.pluginJobTypes {
+		jobTypes = append(jobTypes, jobType)
+	}
+	slices.Sort(jobTypes)
+
+	return jobTypes
+}
+
+// unregisterPluginJobTypes unregisters the job types registered by the given plugin. Their pending
+// jobs are left untouched, and run once the plugin registers the job types again.
+func (ch *Channels) unregisterPluginJobTypes(pluginID string) {
+	var jobTypes []string
+	ch.pluginJobTypesLock.Lock()
+	for jobType := range ch.pluginJobTypes {
+		if jobPluginID, _, _ := model.ParsePluginJobType(jobType); jobPluginID == pluginID {
+			jobTypes = append(jobTypes, jobType)
+			delete(ch.pluginJobTypes, jobType)
+		}
+	}
+	ch.pluginJobTypesLock.Unlock()
+
+	// Removing a worker waits for its current job, so it's done without holding the lock.
+	for _, jobType := range jobTypes {
+		ch.srv.Jobs.UnregisterJobType(jobType)
+	}
+}
+
+// RunPluginJob runs the job through the RunJob hook of the given plugin. It returns the error of
+// the context without waiting for the hook once the context is done.
+func (ch *Channels) RunPluginJob(ctx context.Context, pluginID string, job *model.Job) error {
+	hooks, err := ch.HooksForPlugin(pluginID)
+	if err != nil {
+		return err
+	}
+
+	done := make(chan error, 1)
+	go func() {
+		done <- hooks.RunJob(job)
+	}()
+
+	select {
+	case err := <-done:
+		return err
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// CreatePluginJob creates a pending job of a type registered by the given plugin.
+func (a *App) CreatePluginJob(rctx request.CTX, pluginID, name string, data map[string]string) (*model.Job, *model.AppError) {
+	if !model.IsValidPluginJobTypeName(name) {
+		return nil, model.NewAppError("CreatePluginJob", "app.plugin.register_job_type.invalid_name.app_error", map[string]any{"Name": name}, "", http.StatusBadRequest)
+	}
+
+	return a.Srv().Jobs.CreateJob(rctx, model.PluginJobType(pluginID, name), data)
+}
+
+// GetPluginJob gets a job of a type registered by the given plugin.
+func (a *App) GetPluginJob(rctx request.CTX, pluginID, jobID string) (*model.Job, *model.AppError) {
+	job, appErr := a.GetJob(rctx, jobID)
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	if jobPluginID, _, _ := model.ParsePluginJobType(job.Type); jobPluginID != pluginID {
+		return nil, model.NewAppError("GetPluginJob", "app.job.get.app_error", nil, "", http.StatusNotFound)
+	}
+
+	return job, nil
+}
+
+// SetPluginJobProgress sets the progress of a running job of a type registered by the given plugin.
+func (a *App) SetPluginJobProgress(rctx request.CTX, pluginID, jobID string, progress int64) *model.AppError {
+	if progress < 0 || progress > 100 {
+		return model.NewAppError("SetPluginJobProgress", "app.plugin.set_job_progress.invalid_progress.app_error", nil, "", http.StatusBadRequest)
+	}
+
+	job, appErr := a.GetPluginJob(rctx, pluginID, jobID)
+	if appErr != nil {
+		return appErr
+	}
+
+	if job.Status != model.JobStatusInProgress {
+		return model.NewAppError("SetPluginJobProgress", "app.plugin.set_job_progress.not_running.app_error", nil, "status="+job.Status, http.StatusBadRequest)
+	}
+
+	if appErr := a.Srv().Jobs.SetJobProgress(job, progress); appErr != nil {
+		rctx.Logger().Warn("Failed to set the progress of a plugin job", mlog.String("plugin_id", pluginID), mlog.String("job_id", jobID), mlog.Err(appErr))
+		return appErr
+	}
+
+	return nil
+}
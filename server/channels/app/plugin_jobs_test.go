@@ -0,0 +1,173 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package app
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/v8/channels/jobs/plugins"
+)
+
+func TestPluginJobs(t *testing.T) {
+	mainHelper.Parallel(t)
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+
+	tearDown, pluginIDs, activationErrors := SetAppEnvironmentWithPlugins(t, []string{`
+		package main
+
+		import (
+			"errors"
+			"time"
+
+			"github.com/mattermost/mattermost/server/public/plugin"
+			"github.com/mattermost/mattermost/server/public/model"
+		)
+
+		type MyPlugin struct {
+			plugin.MattermostPlugin
+		}
+
+		func (p *MyPlugin) OnActivate() error {
+			return p.API.RegisterJobType("sync", nil)
+		}
+
+		func (p *MyPlugin) RunJob(job *model.Job) error {
+			if err := p.API.SetJobProgress(job.Id, 50); err != nil {
+				return err
+			}
+
+			if job.Data["block"] == "true" {
+				time.Sleep(time.Minute)
+			}
+
+			if job.Data["fail"] == "true" {
+				return errors.New("sync failed")
+			}
+
+			return nil
+		}
+
+		func main() {
+			plugin.ClientMain(&MyPlugin{})
+		}
+	`}, th.App, th.NewPluginAPI)
+	defer tearDown()
+	require.Len(t, activationErrors, 1)
+	require.NoError(t, activationErrors[0])
+
+	pluginID := pluginIDs[0]
+	jobType := model.PluginJobType(pluginID, "sync")
+	worker := plugins.MakeJobWorker(th.App.Srv().Jobs, th.App.ch, pluginID, jobType)
+
+	t.Run("registered job type", func(t *testing.T) {
+		assert.Contains(t, th.App.GetPluginJobTypes(), jobType)
+	})
+
+	t.Run("invalid job type name", func(t *testing.T) {
+		appErr := th.App.RegisterPluginJobType(pluginID, "Sync!", nil)
+		require.NotNil(t, appErr)
+		assert.Equal(t, "app.plugin.register_job_type.invalid_name.app_error", appErr.Id)
+
+		_, appErr = th.App.CreatePluginJob(th.Context, pluginID, "Sync!", nil)
+		require.NotNil(t, appErr)
+	})
+
+	t.Run("unregistered job type", func(t *testing.T) {
+		_, appErr := th.App.CreatePluginJob(th.Context, pluginID, "unknown", nil)
+		require.NotNil(t, appErr)
+	})
+
+	t.Run("successful job", func(t *testing.T) {
+		job, appErr := th.App.CreatePluginJob(th.Context, pluginID, "sync", nil)
+		require.Nil(t, appErr)
+		require.Equal(t, jobType, job.Type)
+
+		worker.DoJob(job)
+
+		job, appErr = th.App.GetPluginJob(th.Context, pluginID, job.Id)
+		require.Nil(t, appErr)
+		assert.Equal(t, model.JobStatusSuccess, job.Status)
+		assert.Equal(t, int64(100), job.Progress)
+	})
+
+	t.Run("failed job", func(t *testing.T) {
+		job, appErr := th.App.CreatePluginJob(th.Context, pluginID, "sync", map[string]string{"fail": "true"})
+		require.Nil(t, appErr)
+
+		worker.DoJob(job)
+
+		job, appErr = th.App.GetPluginJob(th.Context, pluginID, job.Id)
+		require.Nil(t, appErr)
+		assert.Equal(t, model.JobStatusError, job.Status)
+		assert.Contains(t, job.Data["error"], "sync failed")
+	})
+
+	t.Run("canceled job", func(t *testing.T) {
+		job, appErr := th.App.CreatePluginJob(th.Context, pluginID, "sync", map[string]string{"block": "true"})
+		require.Nil(t, appErr)
+
+		done := make(chan struct{})
+		go func() {
+			worker.DoJob(job)
+			close(done)
+		}()
+
+		require.Eventually(t, func() bool {
+			job, appErr = th.App.GetPluginJob(th.Context, pluginID, job.Id)
+			return appErr == nil && job.Status == model.JobStatusInProgress
+		}, 10*time.Second, 100*time.Millisecond)
+
+		appErr = th.App.CancelJob(th.Context, job.Id)
+		require.Nil(t, appErr)
+
+		select {
+		case <-done:
+		case <-time.After(30 * time.Second):
+			require.Fail(t, "DoJob didn't return after the job was canceled")
+		}
+
+		job, appErr = th.App.GetPluginJob(th.Context, pluginID, job.Id)
+		require.Nil(t, appErr)
+		assert.Equal(t, model.JobStatusCanceled, job.Status)
+	})
+
+	t.Run("job of another plugin", func(t *testing.T) {
+		job, appErr := th.App.CreatePluginJob(th.Context, pluginID, "sync", nil)
+		require.Nil(t, appErr)
+
+		_, appErr = th.App.GetPluginJob(th.Context, "com.example.other", job.Id)
+		require.NotNil(t, appErr)
+		assert.Equal(t, http.StatusNotFound, appErr.StatusCode)
+
+		appErr = th.App.SetPluginJobProgress(th.Context, "com.example.other", job.Id, 10)
+		require.NotNil(t, appErr)
+	})
+
+	t.Run("permissions", func(t *testing.T) {
+		hasPermission, permission := th.App.SessionHasPermissionToReadJob(model.Session{UserId: th.SystemAdminUser.Id, Roles: model.SystemAdminRoleId}, jobType)
+		assert.True(t, hasPermission)
+		assert.Equal(t, model.PermissionReadJobs, permission)
+
+		hasPermission, permission = th.App.SessionHasPermissionToCreateJob(model.Session{UserId: th.BasicUser.Id, Roles: model.SystemUserRoleId}, &model.Job{Type: jobType})
+		assert.False(t, hasPermission)
+		assert.Equal(t, model.PermissionManageJobs, permission)
+	})
+
+	t.Run("disabled plugin", func(t *testing.T) {
+		appErr := th.App.DisablePlugin(pluginID)
+		require.Nil(t, appErr)
+
+		assert.NotContains(t, th.App.GetPluginJobTypes(), jobType)
+
+		_, appErr = th.App.CreatePluginJob(th.Context, pluginID, "sync", nil)
+		require.NotNil(t, appErr)
+	})
+}
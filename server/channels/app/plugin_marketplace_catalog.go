@@ -0,0 +1,220 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package app
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+
+	"github.com/blang/semver/v4"
+	"github.com/pkg/errors"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/public/shared/mlog"
+	"github.com/mattermost/mattermost/server/v8/platform/services/marketplace"
+	"github.com/mattermost/mattermost/server/v8/platform/shared/filestore"
+)
+
+// marketplaceCatalogMutexName returns the name of the cluster mutex serializing publishing to the
+// marketplace catalog stored at the given path, for servers sharing the catalog to share the mutex.
+func marketplaceCatalogMutexName(catalogPath string) string {
+	return fmt.Sprintf("marketplace_catalog_%x", sha256.Sum256([]byte(catalogPath)))
+}
+
+// getMarketplaceCatalog returns the marketplace catalog configured by the administrator, or nil if
+// none is configured. An absolute MarketplaceCatalogPath is a directory on the local filesystem,
+// while a relative one is a directory of the file store.
+func (ch *Channels) getMarketplaceCatalog() (*marketplace.Catalog, *model.AppError) {
+	catalogPath := *ch.cfgSvc.Config().PluginSettings.MarketplaceCatalogPath
+	if catalogPath == "" {
+		return nil, nil
+	}
+
+	if !filepath.IsAbs(catalogPath) {
+		return marketplace.NewCatalog(ch.srv.FileBackend(), catalogPath), nil
+	}
+
+	backend, err := filestore.NewFileBackend(filestore.FileBackendSettings{
+		DriverName: model.ImageDriverLocal,
+		Directory:  catalogPath,
+	})
+	if err != nil {
+		return nil, model.NewAppError("getMarketplaceCatalog", "app.plugin.marketplace_catalog.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+
+	return marketplace.NewCatalog(backend, ""), nil
+}
+
+// getMarketplaceCatalogPlugin returns a plugin from the marketplace catalog.
+//
+// If version is empty, the latest compatible version is used.
+func (ch *Channels) getMarketplaceCatalogPlugin(pluginID, version string) (*model.BaseMarketplacePlugin, *model.AppError) {
+	catalog, appErr := ch.getMarketplaceCatalog()
+	if appErr != nil {
+		return nil, appErr
+	}
+	if catalog == nil {
+		return nil, model.NewAppError("getMarketplaceCatalogPlugin", "app.plugin.marketplace_plugins.not_found.app_error", nil, "", http.StatusInternalServerError)
+	}
+
+	filter := ch.getBaseMarketplaceFilter()
+	filter.PluginId = pluginID
+
+	var plugin *model.BaseMarketplacePlugin
+	var err error
+	if version != "" {
+		plugin, err = catalog.GetPlugin(filter, version)
+	} else {
+		plugin, err = catalog.GetLatestPlugin(filter)
+	}
+	if errors.Is(err, marketplace.ErrCatalogPluginNotFound) {
+		return nil, model.NewAppError("getMarketplaceCatalogPlugin", "app.plugin.marketplace_plugins.not_found.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	} else if err != nil {
+		return nil, model.NewAppError("getMarketplaceCatalogPlugin", "app.plugin.marketplace_catalog.failed_to_fetch", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+
+	return plugin, nil
+}
+
+// openMarketplaceCatalogPlugin opens the bundle and signature of a plugin version published to the
+// marketplace catalog. The caller is responsible for closing both.
+func (ch *Channels) openMarketplaceCatalogPlugin(pluginID, version string) (bundle, signature filestore.ReadCloseSeeker, appErr *model.AppError) {
+	catalog, appErr := ch.getMarketplaceCatalog()
+	if appErr != nil {
+		return nil, nil, appErr
+	}
+	if catalog == nil {
+		return nil, nil, model.NewAppError("openMarketplaceCatalogPlugin", "app.plugin.marketplace_plugins.not_found.app_error", nil, "", http.StatusInternalServerError)
+	}
+
+	bundle, signature, err := catalog.OpenPlugin(pluginID, version)
+	if err != nil {
+		return nil, nil, model.NewAppError("openMarketplaceCatalogPlugin", "app.plugin.marketplace_catalog.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+
+	return bundle, signature, nil
+}
+
+// mergeMarketplaceCatalogPlugins merges the plugins of the marketplace catalog to the remote
+// marketplace plugins list, keeping the newest version of plugins listed in both. A catalog that
+// can't be read or a plugin with an invalid version is skipped, for the other plugins to still be
+// listed.
+func (a *App) mergeMarketplaceCatalogPlugins(remoteMarketplacePlugins map[string]*model.MarketplacePlugin) {
+	catalog, appErr := a.ch.getMarketplaceCatalog()
+	if appErr != nil {
+		a.Log().Warn("Failed to open the marketplace catalog", mlog.Err(appErr))
+		return
+	}
+	if catalog == nil {
+		return
+	}
+
+	catalogPlugins, err := catalog.GetPlugins(a.getBaseMarketplaceFilter())
+	if err != nil {
+		a.Log().Warn("Failed to get the plugins of the marketplace catalog", mlog.Err(err))
+		return
+	}
+
+	for _, p := range catalogPlugins {
+		logger := a.Log().With(mlog.String("plugin_id", p.Manifest.Id), mlog.String("version", p.Manifest.Version))
+
+		catalogVersion, err := semver.Parse(p.Manifest.Version)
+		if err != nil {
+			logger.Warn("Skipping marketplace catalog plugin with an invalid version", mlog.Err(err))
+			continue
+		}
+
+		// If available in the marketplace, only overwrite if newer.
+		marketplacePlugin := remoteMarketplacePlugins[p.Manifest.Id]
+		if marketplacePlugin != nil {
+			marketplaceVersion, err := semver.Parse(marketplacePlugin.Manifest.Version)
+			if err == nil && !catalogVersion.GT(marketplaceVersion) {
+				continue
+			}
+		}
+
+		remoteMarketplacePlugins[p.Manifest.Id] = &model.MarketplacePlugin{BaseMarketplacePlugin: p}
+	}
+}
+
+// PublishMarketplaceCatalogPlugin adds a signed plugin bundle to the marketplace catalog, from
+// which it can then be installed without access to the marketplace server.
+func (a *App) PublishMarketplaceCatalogPlugin(bundle, signature io.ReadSeeker) (*model.Manifest, *model.AppError) {
+	return a.ch.publishMarketplaceCatalogPlugin(bundle, signature)
+}
+
+func (ch *Channels) publishMarketplaceCatalogPlugin(bundle, signature io.ReadSeeker) (*model.Manifest, *model.AppError) {
+	catalog, appErr := ch.getMarketplaceCatalog()
+	if appErr != nil {
+		return nil, appErr
+	}
+	if catalog == nil {
+		return nil, model.NewAppError("publishMarketplaceCatalogPlugin", "app.plugin.marketplace_catalog.disabled.app_error", nil, "", http.StatusNotImplemented)
+	}
+
+	tmpDir, err := os.MkdirTemp("", "plugintmp")
+	if err != nil {
+		return nil, model.NewAppError("publishMarketplaceCatalogPlugin", "app.plugin.filesystem.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+	defer os.RemoveAll(tmpDir)
+
+	manifest, pluginDir, appErr := extractPlugin(bundle, tmpDir)
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	// Extracting the plugin read the bundle.
+	if _, err = bundle.Seek(0, io.SeekStart); err != nil {
+		return nil, model.NewAppError("publishMarketplaceCatalogPlugin", "app.plugin.seek.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+
+	if _, err = semver.Parse(manifest.Version); err != nil {
+		return nil, model.NewAppError("publishMarketplaceCatalogPlugin", "app.plugin.invalid_version.app_error", nil, "", http.StatusBadRequest).Wrap(err)
+	}
+
+	logger := ch.srv.Log().With(
+		mlog.String("plugin_id", manifest.Id),
+		mlog.String("version", manifest.Version),
+	)
+
+	// Plugins are always installed from the catalog with their signature, so verify it upfront.
+	pluginSignature, appErr := ch.verifyPlugin(logger, bundle, signature)
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	iconData := ""
+	if manifest.IconPath != "" {
+		iconData, err = getIcon(filepath.Join(pluginDir, manifest.IconPath))
+		if err != nil {
+			logger.Warn("Error loading plugin icon", mlog.String("icon_path", manifest.IconPath), mlog.Err(err))
+		}
+	}
+
+	for _, r := range []io.ReadSeeker{bundle, signature} {
+		if _, err = r.Seek(0, io.SeekStart); err != nil {
+			return nil, model.NewAppError("publishMarketplaceCatalogPlugin", "app.plugin.seek.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+		}
+	}
+
+	// Publishing rewrites the catalog index, which may be shared by the servers of a cluster.
+	mutex, err := ch.srv.newClusterMutex(marketplaceCatalogMutexName(*ch.cfgSvc.Config().PluginSettings.MarketplaceCatalogPath))
+	if err != nil {
+		return nil, model.NewAppError("publishMarketplaceCatalogPlugin", "app.plugin.marketplace_catalog.publish.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+	mutex.Lock()
+	defer mutex.Unlock()
+
+	if err = catalog.Publish(manifest, iconData, bundle, signature, model.GetMillis()); err != nil {
+		return nil, model.NewAppError("publishMarketplaceCatalogPlugin", "app.plugin.marketplace_catalog.publish.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+
+	logger.Info("Published plugin to the marketplace catalog", mlog.String("key_id", pluginSignature.KeyId), mlog.String("key_name", pluginSignature.KeyName))
+
+	return manifest, nil
+}
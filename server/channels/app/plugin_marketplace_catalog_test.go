@@ -0,0 +1,104 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package app
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/v8/channels/utils/fileutils"
+)
+
+func TestPublishMarketplaceCatalogPluginWithoutConfiguredKeys(t *testing.T) {
+	path, _ := fileutils.FindDir("tests")
+
+	th := SetupConfig(t, func(cfg *model.Config) {
+		*cfg.PluginSettings.Enable = true
+		*cfg.PluginSettings.EnableMarketplace = true
+		*cfg.PluginSettings.EnableRemoteMarketplace = false
+		*cfg.PluginSettings.MarketplaceCatalogPath = t.TempDir()
+		cfg.PluginSettings.SignaturePublicKeyFiles = []string{}
+	})
+	defer th.TearDown()
+
+	// The test plugin is signed with the development key, made the hard-coded one here.
+	publicKey, err := os.ReadFile(filepath.Join(path, "development-public-key.asc"))
+	require.NoError(t, err)
+	hardCodedKey := mattermostPluginPublicKey
+	mattermostPluginPublicKey = publicKey
+	defer func() {
+		mattermostPluginPublicKey = hardCodedKey
+	}()
+
+	tarData, err := os.ReadFile(filepath.Join(path, "testplugin2.tar.gz"))
+	require.NoError(t, err)
+	sigData, err := os.ReadFile(filepath.Join(path, "testplugin2.tar.gz.sig"))
+	require.NoError(t, err)
+
+	manifest, appErr := th.App.PublishMarketplaceCatalogPlugin(bytes.NewReader(tarData), bytes.NewReader(sigData))
+	require.Nil(t, appErr)
+	assert.Equal(t, "testplugin2", manifest.Id)
+
+	plugin, appErr := th.App.ch.getMarketplaceCatalogPlugin("testplugin2", "")
+	require.Nil(t, appErr)
+	assert.Equal(t, manifest.Version, plugin.Manifest.Version)
+}
+
+func TestMergeMarketplaceCatalogPlugins(t *testing.T) {
+	path, _ := fileutils.FindDir("tests")
+
+	th := SetupConfig(t, func(cfg *model.Config) {
+		*cfg.PluginSettings.Enable = true
+		*cfg.PluginSettings.EnableMarketplace = true
+		*cfg.PluginSettings.EnableRemoteMarketplace = false
+		*cfg.PluginSettings.MarketplaceCatalogPath = t.TempDir()
+		*cfg.PluginSettings.RequirePluginSignature = false
+	})
+	defer th.TearDown()
+
+	tarData, err := os.ReadFile(filepath.Join(path, "testplugin2.tar.gz"))
+	require.NoError(t, err)
+	sigData, err := os.ReadFile(filepath.Join(path, "testplugin2.tar.gz.sig"))
+	require.NoError(t, err)
+	publicKey, err := os.Open(filepath.Join(path, "development-public-key.asc"))
+	require.NoError(t, err)
+	defer publicKey.Close()
+	appErr := th.App.AddPublicKey("pub_key", publicKey)
+	require.Nil(t, appErr)
+
+	manifest, appErr := th.App.PublishMarketplaceCatalogPlugin(bytes.NewReader(tarData), bytes.NewReader(sigData))
+	require.Nil(t, appErr)
+
+	t.Run("local only", func(t *testing.T) {
+		plugins, appErr := th.App.GetMarketplacePlugins(th.Context, &model.MarketplacePluginFilter{LocalOnly: true})
+		require.Nil(t, appErr)
+
+		var found bool
+		for _, p := range plugins {
+			if p.Manifest.Id == manifest.Id {
+				found = true
+				assert.Equal(t, manifest.Version, p.Manifest.Version)
+			}
+		}
+		assert.True(t, found)
+	})
+
+	t.Run("invalid version in the marketplace", func(t *testing.T) {
+		plugins := map[string]*model.MarketplacePlugin{
+			manifest.Id: {BaseMarketplacePlugin: &model.BaseMarketplacePlugin{Manifest: &model.Manifest{Id: manifest.Id, Version: "invalid"}}},
+			"other":     {BaseMarketplacePlugin: &model.BaseMarketplacePlugin{Manifest: &model.Manifest{Id: "other", Version: "1.0.0"}}},
+		}
+		th.App.mergeMarketplaceCatalogPlugins(plugins)
+
+		require.Len(t, plugins, 2)
+		assert.Equal(t, manifest.Version, plugins[manifest.Id].Manifest.Version)
+		assert.Equal(t, "1.0.0", plugins["other"].Manifest.Version)
+	})
+}
@@ -0,0 +1,517 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package app
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/blang/semver/v4"
+	"github.com/mattermost/morph"
+	"github.com/mattermost/morph/drivers"
+	ms "github.com/mattermost/morph/drivers/mysql"
+	ps "github.com/mattermost/morph/drivers/postgres"
+	sl "github.com/mattermost/morph/drivers/sqlite"
+	"github.com/mattermost/morph/models"
+	mbindata "github.com/mattermost/morph/sources/embedded"
+	"github.com/pkg/errors"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/public/plugin"
+	"github.com/mattermost/mattermost/server/public/shared/mlog"
+)
+
+const (
+	// pluginMigrationName matches a table or index name, possibly quoted or qualified with a schema.
+	pluginMigrationName = `[\w."` + "`" + `]+`
+	// pluginMigrationNames matches a comma-separated list of names.
+	pluginMigrationNames = pluginMigrationName + `(?:\s*,\s*` + pluginMigrationName + `)*`
+	// pluginMigrationAliasedNames matches a comma-separated list of names, each possibly followed
+	// by an alias.
+	pluginMigrationAliasedNames = pluginMigrationName + `(?:\s+(?:AS\s+)?\w+)?(?:\s*,\s*` + pluginMigrationName + `(?:\s+(?:AS\s+)?\w+)?)*`
+)
+
+// pluginMigrationStatements are the statements a plugin migration may run, capturing the names of
+// the tables and indexes they create, modify or write to.
+var pluginMigrationStatements = []*regexp.Regexp{
+	regexp.MustCompile(`(?is)^CREATE\s+(?:TEMPORARY\s+|TEMP\s+)?TABLE\s+(?:IF\s+NOT\s+EXISTS\s+)?(` + pluginMigrationName + `)\s*\(`),
+	regexp.MustCompile(`(?is)^ALTER\s+TABLE\s+(?:IF\s+EXISTS\s+)?(?:ONLY\s+)?(` + pluginMigrationName + `)\s`),
+	regexp.MustCompile(`(?is)^DROP\s+TABLE\s+(?:IF\s+EXISTS\s+)?(` + pluginMigrationNames + `)(?:\s+(?:CASCADE|RESTRICT))?$`),
+	regexp.MustCompile(`(?is)^CREATE\s+(?:UNIQUE\s+)?INDEX\s+(?:CONCURRENTLY\s+)?(?:IF\s+NOT\s+EXISTS\s+)?(` + pluginMigrationName + `)\s+ON\s+(?:ONLY\s+)?(` + pluginMigrationName + `)[\s(]`),
+	regexp.MustCompile(`(?is)^DROP\s+INDEX\s+(?:CONCURRENTLY\s+)?(?:IF\s+EXISTS\s+)?(` + pluginMigrationNames + `)(?:\s+ON\s+(` + pluginMigrationName + `))?(?:\s+(?:CASCADE|RESTRICT))?$`),
+	regexp.MustCompile(`(?is)^INSERT\s+INTO\s+(` + pluginMigrationName + `)[\s(]`),
+	regexp.MustCompile(`(?is)^UPDATE\s+(?:ONLY\s+)?(` + pluginMigrationName + `)\s+SET\s`),
+	regexp.MustCompile(`(?is)^DELETE\s+FROM\s+(?:ONLY\s+)?(` + pluginMigrationName + `)(?:\s|$)`),
+}
+
+// pluginMigrationTableReferences matches the other tables a statement may create, read from or
+// refer to, capturing their names, each possibly followed by an alias.
+var pluginMigrationTableReferences = []*regexp.Regexp{
+	regexp.MustCompile(`(?i)\bRENAME\s+TO\s+(` + pluginMigrationName + `)`),
+	regexp.MustCompile(`(?i)\bREFERENCES\s+(` + pluginMigrationName + `)`),
+	regexp.MustCompile(`(?i)\bFROM\s+(` + pluginMigrationAliasedNames + `)`),
+	regexp.MustCompile(`(?i)\bJOIN\s+(` + pluginMigrationName + `)`),
+	regexp.MustCompile(`(?is)^DELETE\s.*?\bUSING\s+(` + pluginMigrationAliasedNames + `)`),
+}
+
+// pluginMigrationDollarQuote matches the opening tag of a PostgreSQL dollar-quoted string.
+var pluginMigrationDollarQuote = regexp.MustCompile(`^\$(?:[A-Za-z_]\w*)?\$`)
+
+// pluginMigrationsTable returns the name of the table tracking the migrations applied for the plugin.
+func pluginMigrationsTable(pluginID string) string {
+	return model.PluginTablePrefix(pluginID) + "db_migrations"
+}
+
+// splitPluginMigrationStatements splits the query into its statements as the given database
+// driver would, leaving out the comments and replacing the string literals with empty ones for the
+// statements to be checked without them. It returns an error if the query holds a MySQL executable
+// comment, whose content the database would run.
+func splitPluginMigrationStatements(driverName, query string) ([]string, error) {
+	mysql := driverName == model.DatabaseDriverMysql
+	postgres := driverName == model.DatabaseDriverPostgres
+
+	isIdentifierChar := func(c byte) bool {
+		return c == '_' || c == '$' || '0' <= c && c <= '9' || 'a' <= c && c <= 'z' || 'A' <= c && c <= 'Z'
+	}
+
+	// skipQuoted returns the index of the quote closing the string or identifier opened at the
+	// given index, where the quote is escaped by doubling it, or with a backslash if allowed.
+	skipQuoted := func(start int, backslash bool) int {
+		quote := query[start]
+		for i := start + 1; i < len(query); i++ {
+			switch {
+			case backslash && query[i] == '\\':
+				i++
+			case query[i] == quote && i+1 < len(query) && query[i+1] == quote:
+				i++
+			case query[i] == quote:
+				return i
+			}
+		}
+		return len(query)
+	}
+
+	var statements []string
+	var statement strings.Builder
+	for i := 0; i < len(query); i++ {
+		c := query[i]
+		rest := query[i:]
+		switch {
+		case c == ';':
+			statements = append(statements, statement.String())
+			statement.Reset()
+
+		// MySQL only treats a double dash as a comment if followed by a whitespace.
+		case strings.HasPrefix(rest, "--") && (!mysql || len(rest) == 2 || strings.ContainsRune(" \t\r\n\f\v", rune(rest[2]))),
+			mysql && c == '#':
+			end := strings.IndexByte(rest, '\n')
+			if end == -1 {
+				end = len(rest)
+			}
+			i += end - 1
+			statement.WriteByte(' ')
+
+		case strings.HasPrefix(rest, "/*"):
+			if mysql && strings.HasPrefix(rest, "/*!") {
+				return nil, errors.New("executable comments aren't allowed")
+			}
+			end := strings.Index(rest[2:], "*/")
+			if end == -1 {
+				end = len(rest)
+			} else {
+				end += 4
+			}
+			i += end - 1
+			statement.WriteByte(' ')
+
+		case c == '\'' || mysql && c == '"':
+			// MySQL always allows escaping quotes with a backslash, PostgreSQL only in strings
+			// prefixed with E.
+			escaped := mysql || i > 0 && (query[i-1] == 'E' || query[i-1] == 'e') && (i == 1 || !isIdentifierChar(query[i-2]))
+			i = skipQuoted(i, escaped)
+			statement.WriteString("''")
+
+		case c == '"' || c == '`':
+			end := skipQuoted(i, false)
+			statement.WriteString(query[i:min(end+1, len(query))])
+			i = end
+
+		case postgres && c == '$' && (i == 0 || !isIdentifierChar(query[i-1])):
+			tag := pluginMigrationDollarQuote.FindString(rest)
+			if tag == "" {
+				statement.WriteByte(c)
+				continue
+			}
+			end := strings.Index(rest[len(tag):], tag)
+			if end == -1 {
+				end = len(rest)
+			} else {
+				end += 2 * len(tag)
+			}
+			i += end - 1
+			statement.WriteString("''")
+
+		default:
+			statement.WriteByte(c)
+		}
+	}
+
+	return append(statements, statement.String()), nil
+}
+
+// checkPluginMigrationNamespace returns an error if the migration runs a statement other than
+// creating, altering or dropping a table or an index, or writing to a table, or if the statement
+// involves a table or an index not owned by the plugin.
+//
+// It's a safeguard against plugins mistakenly changing the server's schema rather than a sandbox:
+// the expressions within the statements, such as column defaults, aren't checked.
+func checkPluginMigrationNamespace(driverName, pluginID string, migration *models.Migration) error {
+	prefix := model.PluginTablePrefix(pluginID)
+
+	checkName := func(name string) error {
+		// Ignore the schema the table is qualified with, if any.
+		name = strings.ToLower(strings.Trim(name[strings.LastIndex(name, ".")+1:], "\"`"))
+		if !strings.HasPrefix(name, prefix) {
+			return fmt.Errorf("migration %s references %q, which doesn't start with %q", migration.RawName, name, prefix)
+		}
+		return nil
+	}
+
+	// checkNames checks the names of a comma-separated list, ignoring their aliases.
+	checkNames := func(names string) error {
+		for _, name := range strings.Split(names, ",") {
+			if fields := strings.Fields(name); len(fields) > 0 {
+				if err := checkName(fields[0]); err != nil {
+					return err
+				}
+			}
+		}
+		return nil
+	}
+
+	statements, err := splitPluginMigrationStatements(driverName, migration.Query())
+	if err != nil {
+		return fmt.Errorf("migration %s is invalid: %w", migration.RawName, err)
+	}
+
+	for _, statement := range statements {
+		statement = strings.TrimSpace(statement)
+		if statement == "" {
+			continue
+		}
+
+		var match []string
+		for _, re := range pluginMigrationStatements {
+			if match = re.FindStringSubmatch(statement); match != nil {
+				break
+			}
+		}
+		if match == nil {
+			return fmt.Errorf("migration %s runs a statement that isn't allowed: %q", migration.RawName, statement)
+		}
+
+		for _, names := range match[1:] {
+			if err := checkNames(names); err != nil {
+				return err
+			}
+		}
+
+		for _, re := range pluginMigrationTableReferences {
+			for _, match := range re.FindAllStringSubmatch(statement, -1) {
+				if err := checkNames(match[1]); err != nil {
+					return err
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
+// loadPluginMigrations reads the migrations shipped in the plugin bundle for the database driver
+// in use.
+func (ch *Channels) loadPluginMigrations(bundle *model.BundleInfo) ([]*models.Migration, error) {
+	driverName := *ch.cfgSvc.Config().SqlSettings.DriverName
+	dir := filepath.Join(bundle.Path, bundle.Manifest.Server.Migrations, driverName)
+
+	entries, err := os.ReadDir(dir)
+	if os.IsNotExist(err) {
+		return nil, fmt.Errorf("the plugin doesn't ship migrations for the %s database driver", driverName)
+	} else if err != nil {
+		return nil, errors.Wrap(err, "failed to read the migrations directory")
+	}
+
+	var migrations []*models.Migration
+	for _, entry := range entries {
+		if entry.IsDir() {
+			continue
+		}
+
+		f, err := os.Open(filepath.Join(dir, entry.Name()))
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to open migration %s", entry.Name())
+		}
+
+		// NewMigration closes the file.
+		migration, err := models.NewMigration(f, entry.Name())
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid migration %s", entry.Name())
+		}
+
+		if err := checkPluginMigrationNamespace(driverName, bundle.Manifest.Id, migration); err != nil {
+			return nil, err
+		}
+
+		migrations = append(migrations, migration)
+	}
+
+	return migrations, nil
+}
+
+// newPluginMigrationEngine returns a migration engine for the plugin, holding the cluster-wide
+// lock of the plugin's migrations until it's closed.
+func (ch *Channels) newPluginMigrationEngine(pluginID string, migrations []*models.Migration) (*morph.Morph, error) {
+	byName := make(map[string]*models.Migration, len(migrations))
+	names := make([]string, 0, len(migrations))
+	for _, migration := range migrations {
+		byName[migration.RawName] = migration
+		names = append(names, migration.RawName)
+	}
+
+	src, err := mbindata.WithInstance(&mbindata.AssetSource{
+		Names: names,
+		AssetFunc: func(name string) ([]byte, error) {
+			return byName[name].Bytes, nil
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	sqlSettings := ch.cfgSvc.Config().SqlSettings
+	db := ch.srv.Store().GetInternalMasterDB()
+
+	var driver drivers.Driver
+	switch *sqlSettings.DriverName {
+	case model.DatabaseDriverMysql:
+		driver, err = ms.WithInstance(db)
+	case model.DatabaseDriverPostgres:
+		driver, err = ps.WithInstance(db)
+	case model.DatabaseDriverSqlite:
+		driver, err = sl.WithInstance(db)
+	default:
+		err = fmt.Errorf("unsupported database type %s for migration", *sqlSettings.DriverName)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	prefix := model.PluginTablePrefix(pluginID)
+	opts := []morph.EngineOption{
+		morph.WithLogger(ch.srv.Log().With(mlog.String("plugin_id", pluginID)).StdLogger(mlog.LvlDebug)),
+		morph.SetMigrationTableName(pluginMigrationsTable(pluginID)),
+		morph.SetStatementTimeoutInSeconds(*sqlSettings.MigrationsStatementTimeoutSeconds),
+	}
+	// SQLite databases can't be shared between servers, and the database
+	// lock already serializes the migrations.
+	if *sqlSettings.DriverName != model.DatabaseDriverSqlite {
+		opts = append(opts, morph.WithLock(prefix+"migrations"))
+	}
+
+	return morph.New(context.Background(), driver, src, opts...)
+}
+
+// applyPluginMigrations applies the pending migrations shipped in the plugin bundle. It's called
+// by the plugin environment before activating a plugin that ships migrations.
+func (ch *Channels) applyPluginMigrations(bundle *model.BundleInfo) error {
+	migrations, err := ch.loadPluginMigrations(bundle)
+	if err != nil {
+		return err
+	}
+
+	engine, err := ch.newPluginMigrationEngine(bundle.Manifest.Id, migrations)
+	if err != nil {
+		return errors.Wrap(err, "failed to initialize the migration engine")
+	}
+	defer engine.Close()
+
+	if err := engine.ApplyAll(); err != nil {
+		return errors.Wrap(err, "failed to apply migrations")
+	}
+
+	return nil
+}
+
+// rollbackPluginMigrations rolls back the migrations applied for the plugin whose version is above
+// the given one, using the given migrations loaded from the plugin bundle that applied them. The
+// plugin is deactivated on all servers beforehand, as it must not use the tables while they're
+// rolled back.
+func (ch *Channels) rollbackPluginMigrations(pluginID string, migrations []*models.Migration, toVersion uint32) error {
+	engine, err := ch.newPluginMigrationEngine(pluginID, migrations)
+	if err != nil {
+		return errors.Wrap(err, "failed to initialize the migration engine")
+	}
+	defer engine.Close()
+
+	// The down migrations are sorted from the most recent one.
+	downMigrations, err := engine.Diff(models.Down)
+	if err != nil {
+		return errors.Wrap(err, "failed to compute the migrations to roll back")
+	}
+
+	var rollback []*models.Migration
+	for _, migration := range downMigrations {
+		if migration.Version > toVersion {
+			rollback = append(rollback, migration)
+		}
+	}
+	if len(rollback) == 0 {
+		return nil
+	}
+
+	ch.notifyClusterPluginEvent(model.ClusterEventDeactivatePlugin, model.PluginEventData{Id: pluginID})
+	if pluginsEnvironment := ch.GetPluginsEnvironment(); pluginsEnvironment != nil {
+		pluginsEnvironment.Deactivate(pluginID)
+	}
+
+	plan, err := engine.GeneratePlan(rollback, false)
+	if err != nil {
+		return errors.Wrap(err, "failed to plan the rollback")
+	}
+
+	if err := engine.ApplyPlan(plan); err != nil {
+		return errors.Wrap(err, "failed to roll back migrations")
+	}
+
+	return nil
+}
+
+// latestPluginMigrationVersion returns the version of the most recent up migration shipped in the
+// plugin bundle, or zero if it ships none.
+func (ch *Channels) latestPluginMigrationVersion(bundle *model.BundleInfo) (uint32, error) {
+	if !bundle.Manifest.HasServerMigrations() {
+		return 0, nil
+	}
+
+	migrations, err := ch.loadPluginMigrations(bundle)
+	if err != nil {
+		return 0, err
+	}
+
+	var version uint32
+	for _, migration := range migrations {
+		if migration.Direction == models.Up && migration.Version > version {
+			version = migration.Version
+		}
+	}
+
+	return version, nil
+}
+
+// getPluginSchemaVersion returns the version of the last migration applied for the plugin, or zero
+// if none was.
+func (ch *Channels) getPluginSchemaVersion(pluginID string) (uint32, error) {
+	var version uint32
+	query := fmt.Sprintf("SELECT COALESCE(MAX(version), 0) FROM %s", pluginMigrationsTable(pluginID))
+	if err := ch.srv.Store().GetInternalMasterDB().QueryRow(query).Scan(&version); err != nil {
+		return 0, err
+	}
+
+	return version, nil
+}
+
+// setPluginSchemaVersions sets the schema version of the plugins shipping migrations.
+func (ch *Channels) setPluginSchemaVersions(pluginsEnvironment *plugin.Environment, pluginStatuses model.PluginStatuses) {
+	bundles, err := pluginsEnvironment.Available()
+	if err != nil {
+		ch.srv.Log().Warn("Failed to get the available plugins", mlog.Err(err))
+		return
+	}
+
+	withMigrations := make(map[string]bool, len(bundles))
+	for _, bundle := range bundles {
+		if bundle.Manifest != nil && bundle.Manifest.HasServerMigrations() {
+			withMigrations[bundle.Manifest.Id] = true
+		}
+	}
+
+	for _, status := range pluginStatuses {
+		if !withMigrations[status.PluginId] {
+			continue
+		}
+
+		// The migrations table doesn't exist until the plugin is first activated.
+		version, err := ch.getPluginSchemaVersion(status.PluginId)
+		if err != nil {
+			ch.srv.Log().Debug("Failed to get the plugin schema version", mlog.String("plugin_id", status.PluginId), mlog.Err(err))
+			continue
+		}
+		status.SchemaVersion = version
+	}
+}
+
+// pluginMigrationsDowngrade holds the migrations of an installed plugin to roll back once an older
+// version of the plugin replaced it.
+type pluginMigrationsDowngrade struct {
+	manifest         *model.Manifest
+	existingManifest *model.Manifest
+	migrations       []*models.Migration
+	toVersion        uint32
+}
+
+// preparePluginMigrationsDowngrade loads the migrations of the installed plugin if the given
+// version of the plugin is older, before the installed bundle, which is the only one shipping their
+// down migrations, gets replaced. It returns nil if there's nothing to roll back.
+func (ch *Channels) preparePluginMigrationsDowngrade(existingBundle *model.BundleInfo, manifest *model.Manifest, fromPluginDir string) (*pluginMigrationsDowngrade, *model.AppError) {
+	if !existingBundle.Manifest.HasServerMigrations() {
+		return nil, nil
+	}
+
+	// Without comparable versions, a downgrade can't be told apart from an upgrade.
+	version, err := semver.Parse(manifest.Version)
+	if err != nil {
+		return nil, model.NewAppError("preparePluginMigrationsDowngrade", "app.plugin.invalid_version.app_error", nil, "", http.StatusBadRequest).Wrap(err)
+	}
+	existingVersion, err := semver.Parse(existingBundle.Manifest.Version)
+	if err != nil {
+		return nil, model.NewAppError("preparePluginMigrationsDowngrade", "app.plugin.invalid_version.app_error", nil, "", http.StatusBadRequest).Wrap(err)
+	}
+	if version.GTE(existingVersion) {
+		return nil, nil
+	}
+
+	toVersion, err := ch.latestPluginMigrationVersion(&model.BundleInfo{Path: fromPluginDir, Manifest: manifest})
+	if err != nil {
+		return nil, model.NewAppError("preparePluginMigrationsDowngrade", "app.plugin.rollback_migrations.app_error", nil, "", http.StatusBadRequest).Wrap(err)
+	}
+
+	migrations, err := ch.loadPluginMigrations(existingBundle)
+	if err != nil {
+		return nil, model.NewAppError("preparePluginMigrationsDowngrade", "app.plugin.rollback_migrations.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+
+	return &pluginMigrationsDowngrade{
+		manifest:         manifest,
+		existingManifest: existingBundle.Manifest,
+		migrations:       migrations,
+		toVersion:        toVersion,
+	}, nil
+}
+
+// rollbackPluginMigrationsOnDowngrade rolls back the migrations applied for the plugin that the
+// older version of the plugin now installed doesn't ship.
+func (ch *Channels) rollbackPluginMigrationsOnDowngrade(downgrade *pluginMigrationsDowngrade) *model.AppError {
+	ch.srv.Log().Info("Rolling back plugin migrations after downgrade", mlog.String("plugin_id", downgrade.manifest.Id), mlog.String("version", downgrade.manifest.Version), mlog.String("existing_version", downgrade.existingManifest.Version), mlog.Uint("schema_version", downgrade.toVersion))
+
+	if err := ch.rollbackPluginMigrations(downgrade.manifest.Id, downgrade.migrations, downgrade.toVersion); err != nil {
+		return model.NewAppError("rollbackPluginMigrationsOnDowngrade", "app.plugin.rollback_migrations.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+
+	return nil
+}
@@ -0,0 +1,218 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package app
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/mattermost/morph/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/v8/channels/testlib"
+)
+
+func TestCheckPluginMigrationNamespace(t *testing.T) {
+	pluginID := "com.example.migrations"
+
+	for name, tc := range map[string]struct {
+		Query  string
+		Valid  bool
+		Driver string
+	}{
+		"create table":           {"CREATE TABLE IF NOT EXISTS plugin_com_example_migrations_items (id VARCHAR(26) PRIMARY KEY);", true, ""},
+		"quoted table":           {`ALTER TABLE "plugin_com_example_migrations_items" ADD COLUMN name TEXT;`, true, ""},
+		"qualified table":        {"DROP TABLE public.plugin_com_example_migrations_items;", true, ""},
+		"index":                  {"CREATE UNIQUE INDEX plugin_com_example_migrations_idx ON plugin_com_example_migrations_items (name);", true, ""},
+		"insert":                 {"INSERT INTO plugin_com_example_migrations_items VALUES ('a');", true, ""},
+		"on update cascade":      {"CREATE TABLE plugin_com_example_migrations_links (id TEXT REFERENCES plugin_com_example_migrations_items(id) ON UPDATE CASCADE ON DELETE CASCADE);", true, ""},
+		"core table":             {"ALTER TABLE Users ADD COLUMN foo TEXT;", false, ""},
+		"index on core table":    {"CREATE INDEX plugin_com_example_migrations_idx ON Posts (id);", false, ""},
+		"update core table":      {"UPDATE plugin_com_example_migrations_items SET name = 'a';\nUPDATE Users SET Roles = 'system_admin';", false, ""},
+		"delete from core table": {"DELETE FROM Sessions;", false, ""},
+		"reference core table":   {"CREATE TABLE plugin_com_example_migrations_links (user_id TEXT REFERENCES Users(Id));", false, ""},
+		"other plugin":           {"DROP TABLE plugin_com_example_other_items;", false, ""},
+		"rename":                 {"ALTER TABLE plugin_com_example_migrations_items RENAME TO Items;", false, ""},
+		"comments":               {"-- DROP TABLE Users;\n/* GRANT ALL ON Users TO public; */\nDROP INDEX plugin_com_example_migrations_idx ON plugin_com_example_migrations_items;", true, ""},
+		"drop core table listed": {"DROP TABLE plugin_com_example_migrations_items, Users;", false, ""},
+		"create table as select": {"CREATE TABLE plugin_com_example_migrations_users AS SELECT * FROM Users;", false, ""},
+		"other statement":        {"GRANT ALL ON plugin_com_example_migrations_items TO public;", false, ""},
+		"function":               {"CREATE FUNCTION plugin_com_example_migrations_fn() RETURNS void AS $$ DELETE FROM Users $$ LANGUAGE sql;", false, ""},
+		"semicolon in string":    {"INSERT INTO plugin_com_example_migrations_items VALUES ('a; DROP TABLE Users');", true, ""},
+		"comment in string":      {"INSERT INTO plugin_com_example_migrations_items VALUES ('-- a'); DROP TABLE Users;", false, ""},
+		"escaped quote":          {"INSERT INTO plugin_com_example_migrations_items VALUES ('it''s; DROP TABLE Users');", true, ""},
+		"insert select":          {"INSERT INTO plugin_com_example_migrations_labels SELECT id FROM plugin_com_example_migrations_items WHERE id <> '';", true, ""},
+		"insert select core":     {"INSERT INTO plugin_com_example_migrations_items SELECT Id FROM Users;", false, ""},
+		"select list with alias": {"INSERT INTO plugin_com_example_migrations_items SELECT i.id FROM plugin_com_example_migrations_items AS i, Users u;", false, ""},
+		"subquery on core table": {"UPDATE plugin_com_example_migrations_items SET name = (SELECT Username FROM Users LIMIT 1);", false, ""},
+		"join core table":        {"INSERT INTO plugin_com_example_migrations_items SELECT i.id FROM plugin_com_example_migrations_items i JOIN Users u ON u.Id = i.id;", false, ""},
+		"delete using core":      {"DELETE FROM plugin_com_example_migrations_items USING Users WHERE Users.Id = plugin_com_example_migrations_items.id;", false, model.DatabaseDriverPostgres},
+		"dollar quoted string":   {"INSERT INTO plugin_com_example_migrations_items VALUES ($tag$a; DROP TABLE Users$tag$);", true, model.DatabaseDriverPostgres},
+		"escape string":          {`INSERT INTO plugin_com_example_migrations_items VALUES (E'it\'s; DROP TABLE Users');`, true, model.DatabaseDriverPostgres},
+		"backslash in string":    {`INSERT INTO plugin_com_example_migrations_items VALUES ('a\'); DROP TABLE Users; -- ');`, false, model.DatabaseDriverPostgres},
+		"mysql backslash escape": {`INSERT INTO plugin_com_example_migrations_items VALUES ('it\'s'); DROP TABLE Users; -- ');`, false, model.DatabaseDriverMysql},
+		"mysql double dash":      {"INSERT INTO plugin_com_example_migrations_items VALUES (1--1); DROP TABLE Users;", false, model.DatabaseDriverMysql},
+		"mysql hash comment":     {"# DROP TABLE Users;\nDROP TABLE plugin_com_example_migrations_items;", true, model.DatabaseDriverMysql},
+		"mysql executable":       {"/*!50000 DROP TABLE Users */;", false, model.DatabaseDriverMysql},
+	} {
+		t.Run(name, func(t *testing.T) {
+			migration, err := models.NewMigration(nopCloser{strings.NewReader(tc.Query)}, "000001_test.up.sql")
+			require.NoError(t, err)
+
+			driverName := tc.Driver
+			if driverName == "" {
+				driverName = model.DatabaseDriverPostgres
+			}
+
+			err = checkPluginMigrationNamespace(driverName, pluginID, migration)
+			if tc.Valid {
+				assert.NoError(t, err)
+			} else {
+				assert.Error(t, err)
+			}
+		})
+	}
+}
+
+type nopCloser struct {
+	*strings.Reader
+}
+
+func (nopCloser) Close() error { return nil }
+
+func writePluginMigrations(t *testing.T, dir string, migrations map[string]string) {
+	t.Helper()
+
+	driverDir := filepath.Join(dir, "migrations", model.DatabaseDriverSqlite)
+	require.NoError(t, os.MkdirAll(driverDir, 0700))
+	for name, query := range migrations {
+		require.NoError(t, os.WriteFile(filepath.Join(driverDir, name), []byte(query), 0600))
+	}
+}
+
+func TestPluginMigrations(t *testing.T) {
+	mainHelper.Parallel(t)
+	th := Setup(t)
+	defer th.TearDown()
+
+	if *th.App.Config().SqlSettings.DriverName != model.DatabaseDriverSqlite {
+		t.Skip("The test migrations are only written for SQLite")
+	}
+
+	pluginID := "com.example.migrations"
+	migrations := map[string]string{
+		"000001_create_items.up.sql":      "CREATE TABLE plugin_com_example_migrations_items (id TEXT PRIMARY KEY);",
+		"000001_create_items.down.sql":    "DROP TABLE plugin_com_example_migrations_items;",
+		"000002_add_item_name.up.sql":     "ALTER TABLE plugin_com_example_migrations_items ADD COLUMN name TEXT;",
+		"000002_add_item_name.down.sql":   "ALTER TABLE plugin_com_example_migrations_items DROP COLUMN name;",
+		"000003_create_labels.up.sql":     "CREATE TABLE plugin_com_example_migrations_labels (id TEXT PRIMARY KEY);",
+		"000003_create_labels.down.sql":   "DROP TABLE plugin_com_example_migrations_labels;",
+		"000004_insert_defaults.up.sql":   "INSERT INTO plugin_com_example_migrations_labels VALUES ('default');",
+		"000004_insert_defaults.down.sql": "DELETE FROM plugin_com_example_migrations_labels;",
+	}
+
+	newBundle := func(t *testing.T, version string, migrations map[string]string) *model.BundleInfo {
+		dir := t.TempDir()
+		writePluginMigrations(t, dir, migrations)
+		return &model.BundleInfo{
+			Path: dir,
+			Manifest: &model.Manifest{
+				Id:      pluginID,
+				Version: version,
+				Server:  &model.ManifestServer{Executable: "plugin", Migrations: "migrations"},
+			},
+		}
+	}
+
+	bundle := newBundle(t, "2.0.0", migrations)
+
+	t.Run("apply migrations", func(t *testing.T) {
+		require.NoError(t, th.App.ch.applyPluginMigrations(bundle))
+
+		version, err := th.App.ch.getPluginSchemaVersion(pluginID)
+		require.NoError(t, err)
+		assert.Equal(t, uint32(4), version)
+
+		// Applying the migrations again is a no-op.
+		require.NoError(t, th.App.ch.applyPluginMigrations(bundle))
+	})
+
+	t.Run("migration outside the plugin namespace", func(t *testing.T) {
+		invalid := newBundle(t, "2.0.0", map[string]string{
+			"000001_alter_users.up.sql": "ALTER TABLE Users ADD COLUMN foo TEXT;",
+		})
+
+		err := th.App.ch.applyPluginMigrations(invalid)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "users")
+	})
+
+	t.Run("missing database driver", func(t *testing.T) {
+		invalid := newBundle(t, "2.0.0", nil)
+		invalid.Manifest.Server.Migrations = "other"
+
+		require.Error(t, th.App.ch.applyPluginMigrations(invalid))
+	})
+
+	t.Run("no rollback on upgrade", func(t *testing.T) {
+		downgrade, appErr := th.App.ch.preparePluginMigrationsDowngrade(bundle, &model.Manifest{Id: pluginID, Version: "3.0.0"}, t.TempDir())
+		require.Nil(t, appErr)
+		assert.Nil(t, downgrade)
+
+		version, err := th.App.ch.getPluginSchemaVersion(pluginID)
+		require.NoError(t, err)
+		assert.Equal(t, uint32(4), version)
+	})
+
+	t.Run("invalid version", func(t *testing.T) {
+		_, appErr := th.App.ch.preparePluginMigrationsDowngrade(bundle, &model.Manifest{Id: pluginID, Version: "latest"}, t.TempDir())
+		require.NotNil(t, appErr)
+		assert.Equal(t, "app.plugin.invalid_version.app_error", appErr.Id)
+
+		version, err := th.App.ch.getPluginSchemaVersion(pluginID)
+		require.NoError(t, err)
+		assert.Equal(t, uint32(4), version)
+	})
+
+	t.Run("rollback on downgrade", func(t *testing.T) {
+		older := newBundle(t, "1.0.0", map[string]string{
+			"000001_create_items.up.sql":    migrations["000001_create_items.up.sql"],
+			"000001_create_items.down.sql":  migrations["000001_create_items.down.sql"],
+			"000002_add_item_name.up.sql":   migrations["000002_add_item_name.up.sql"],
+			"000002_add_item_name.down.sql": migrations["000002_add_item_name.down.sql"],
+		})
+
+		downgrade, appErr := th.App.ch.preparePluginMigrationsDowngrade(bundle, older.Manifest, older.Path)
+		require.Nil(t, appErr)
+		require.NotNil(t, downgrade)
+
+		// The down migrations are loaded before the bundle that ships them gets replaced.
+		require.NoError(t, os.RemoveAll(bundle.Path))
+
+		testCluster := &testlib.FakeClusterInterface{}
+		th.Server.Platform().SetCluster(testCluster)
+		defer th.Server.Platform().SetCluster(nil)
+
+		appErr = th.App.ch.rollbackPluginMigrationsOnDowngrade(downgrade)
+		require.Nil(t, appErr)
+
+		// The plugin is deactivated on the other servers while the migrations are rolled back.
+		messages := testCluster.GetMessages()
+		require.Len(t, messages, 1)
+		assert.Equal(t, model.ClusterEventDeactivatePlugin, messages[0].Event)
+
+		version, err := th.App.ch.getPluginSchemaVersion(pluginID)
+		require.NoError(t, err)
+		assert.Equal(t, uint32(2), version)
+
+		_, err = th.App.Srv().Store().GetInternalMasterDB().Exec("SELECT id FROM plugin_com_example_migrations_labels")
+		require.Error(t, err)
+		_, err = th.App.Srv().Store().GetInternalMasterDB().Exec("SELECT id, name FROM plugin_com_example_migrations_items")
+		require.NoError(t, err)
+	})
+}
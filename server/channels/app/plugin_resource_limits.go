@@ -0,0 +1,70 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package app
+
+import (
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/public/shared/i18n"
+	"github.com/mattermost/mattermost/server/public/shared/mlog"
+	"github.com/mattermost/mattermost/server/public/shared/request"
+)
+
+// handlePluginResourceLimitExceeded is called by the plugin health check after a plugin exceeding
+// its resource limits was restarted or deactivated. Deactivated plugins are disabled so they stay
+// off across restarts, and the system admins are notified either way.
+func (ch *Channels) handlePluginResourceLimitExceeded(pluginID string, usage *model.PluginResourceUsage, reason, action string) {
+	if action == model.PluginResourceLimitActionDisable {
+		if appErr := ch.disablePlugin(pluginID); appErr != nil {
+			ch.srv.Log().Error("Failed to disable plugin exceeding its resource limits", mlog.String("plugin_id", pluginID), mlog.Err(appErr))
+		}
+	}
+
+	rctx := request.EmptyContext(ch.srv.Log())
+	New(ServerConnector(ch)).notifyAdminsOfPluginResourceLimit(rctx, pluginID, reason, action)
+}
+
+// notifyAdminsOfPluginResourceLimit sends a direct message from the system bot to the system admins
+// about a plugin that exceeded its resource limits.
+func (a *App) notifyAdminsOfPluginResourceLimit(rctx request.CTX, pluginID, reason, action string) {
+	systemBot, appErr := a.GetSystemBot(rctx)
+	if appErr != nil {
+		rctx.Logger().Warn("Failed to get the system bot to notify about plugin resource limits", mlog.Err(appErr))
+		return
+	}
+
+	sysadmins, appErr := a.GetUsersFromProfiles(&model.UserGetOptions{
+		Page:     0,
+		PerPage:  100,
+		Role:     model.SystemAdminRoleId,
+		Inactive: false,
+	})
+	if appErr != nil {
+		rctx.Logger().Warn("Failed to get system admins to notify about plugin resource limits", mlog.Err(appErr))
+		return
+	}
+
+	messageID := "app.plugin.resource_limit_exceeded.restarted"
+	if action == model.PluginResourceLimitActionDisable {
+		messageID = "app.plugin.resource_limit_exceeded.disabled"
+	}
+
+	for _, admin := range sysadmins {
+		T := i18n.GetUserTranslations(admin.Locale)
+
+		channel, appErr := a.GetOrCreateDirectChannel(rctx, systemBot.UserId, admin.Id)
+		if appErr != nil {
+			rctx.Logger().Warn("Error getting direct channel", mlog.Err(appErr))
+			continue
+		}
+
+		post := &model.Post{
+			Message:   T(messageID, map[string]any{"PluginId": pluginID, "Reason": reason}),
+			UserId:    systemBot.UserId,
+			ChannelId: channel.Id,
+		}
+		if _, appErr = a.CreatePost(rctx, post, channel, model.CreatePostFlags{SetOnline: true}); appErr != nil {
+			rctx.Logger().Warn("Error creating post", mlog.Err(appErr))
+		}
+	}
+}
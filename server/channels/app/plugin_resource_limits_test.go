@@ -0,0 +1,44 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package app
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost/server/public/model"
+)
+
+func TestNotifyAdminsOfPluginResourceLimit(t *testing.T) {
+	mainHelper.Parallel(t)
+	th := Setup(t).InitBasic()
+
+	systemBot, appErr := th.App.GetSystemBot(th.Context)
+	require.Nil(t, appErr)
+
+	for _, action := range []string{model.PluginResourceLimitActionRestart, model.PluginResourceLimitActionDisable} {
+		t.Run(action, func(t *testing.T) {
+			th.App.notifyAdminsOfPluginResourceLimit(th.Context, "com.example.plugin", "memory usage of 150 MB exceeds the limit of 100 MB", action)
+
+			channel, appErr := th.App.GetOrCreateDirectChannel(th.Context, systemBot.UserId, th.SystemAdminUser.Id)
+			require.Nil(t, appErr)
+
+			posts, appErr := th.App.GetPosts(th.Context, channel.Id, 0, 1)
+			require.Nil(t, appErr)
+			require.Len(t, posts.Order, 1)
+
+			post := posts.Posts[posts.Order[0]]
+			assert.Equal(t, systemBot.UserId, post.UserId)
+			assert.Contains(t, post.Message, "com.example.plugin")
+			assert.Contains(t, post.Message, "memory usage of 150 MB exceeds the limit of 100 MB")
+			if action == model.PluginResourceLimitActionDisable {
+				assert.Contains(t, post.Message, "was disabled")
+			} else {
+				assert.Contains(t, post.Message, "was restarted")
+			}
+		})
+	}
+}
@@ -0,0 +1,105 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package app
+
+import (
+	"net/http"
+	"sort"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/public/plugin"
+	"github.com/mattermost/mattermost/server/public/shared/mlog"
+	"github.com/mattermost/mattermost/server/public/shared/request"
+)
+
+// runSearchWillBeExecutedHook lets plugins rewrite, restrict or reject a search before it runs,
+// and returns the search params to use. The params searching for everything or for nothing are
+// dropped from the ones plugins return, as they are from the ones of users.
+func (a *App) runSearchWillBeExecutedHook(c request.CTX, search *model.PluginSearch) ([]*model.SearchParams, *model.AppError) {
+	var rejectionReason string
+	pluginContext := pluginContext(c)
+	a.ch.RunMultiHook(func(hooks plugin.Hooks, _ *model.Manifest) bool {
+		var replacementSearch *model.PluginSearch
+		replacementSearch, rejectionReason = hooks.SearchWillBeExecuted(pluginContext, search)
+		if replacementSearch != nil {
+			search.Params = replacementSearch.Params
+		}
+		return rejectionReason == ""
+	}, plugin.SearchWillBeExecutedID)
+	if rejectionReason != "" {
+		return nil, model.NewAppError("SearchWillBeExecuted", "app.search.rejected_by_plugin.app_error", map[string]any{"Reason": rejectionReason}, "", http.StatusBadRequest)
+	}
+
+	paramsList := make([]*model.SearchParams, 0, len(search.Params))
+	for _, params := range search.Params {
+		if params == nil || params.Terms == "*" || isEmptySearchParams(params) {
+			continue
+		}
+		paramsList = append(paramsList, params)
+	}
+
+	return paramsList, nil
+}
+
+func isEmptySearchParams(params *model.SearchParams) bool {
+	return params.Terms == "" && params.ExcludedTerms == "" &&
+		len(params.InChannels) == 0 && len(params.ExcludedChannels) == 0 &&
+		len(params.FromUsers) == 0 && len(params.ExcludedUsers) == 0 &&
+		len(params.Extensions) == 0 && len(params.ExcludedExtensions) == 0 &&
+		params.OnDate == "" && params.AfterDate == "" && params.BeforeDate == "" &&
+		params.ExcludedDate == "" && params.ExcludedAfterDate == "" && params.ExcludedBeforeDate == ""
+}
+
+// addPluginSearchResults merges the virtual posts provided by plugins into the first page of the
+// search results, keeping the results sorted by CreateAt. The results of plugins aren't merged into
+// the following pages, as their order across pages couldn't be kept.
+func (a *App) addPluginSearchResults(c request.CTX, search *model.PluginSearch, results *model.PostSearchResults) {
+	if search.Page > 0 {
+		return
+	}
+
+	var added bool
+	pluginContext := pluginContext(c)
+	a.ch.RunMultiHook(func(hooks plugin.Hooks, manifest *model.Manifest) bool {
+		pluginResults, err := hooks.SearchResultsProvided(pluginContext, search)
+		if err != nil {
+			c.Logger().Warn("Failed to get search results from plugin", mlog.String("plugin_id", manifest.Id), mlog.Err(err))
+			return true
+		}
+		if pluginResults == nil || pluginResults.PostList == nil {
+			return true
+		}
+
+		for _, id := range pluginResults.Order {
+			post, ok := pluginResults.Posts[id]
+			if !ok || post == nil {
+				continue
+			}
+			if post.Id == "" {
+				post.Id = model.NewId()
+			}
+			if _, exists := results.Posts[post.Id]; exists {
+				continue
+			}
+			post.AddProp(model.PostPropsFromPlugin, "true")
+
+			results.AddPost(post)
+			results.AddOrder(post.Id)
+			if matches, ok := pluginResults.Matches[id]; ok {
+				if results.Matches == nil {
+					results.Matches = model.PostSearchMatches{}
+				}
+				results.Matches[post.Id] = matches
+			}
+			added = true
+		}
+		return true
+	}, plugin.SearchResultsProvidedID)
+
+	if added {
+		sort.SliceStable(results.Order, func(i, j int) bool {
+			return results.Posts[results.Order[i]].CreateAt > results.Posts[results.Order[j]].CreateAt
+		})
+	}
+}
@@ -5,14 +5,19 @@ package app
 
 import (
 	"bytes"
+	"encoding/json"
+	"fmt"
 	"io"
 	"net/http"
+	"os"
 	"path/filepath"
 	"slices"
+	"time"
 
 	"github.com/pkg/errors"
-	"golang.org/x/crypto/openpgp"       //nolint:staticcheck
-	"golang.org/x/crypto/openpgp/armor" //nolint:staticcheck
+	"golang.org/x/crypto/openpgp"        //nolint:staticcheck
+	"golang.org/x/crypto/openpgp/armor"  //nolint:staticcheck
+	"golang.org/x/crypto/openpgp/packet" //nolint:staticcheck
 
 	"github.com/mattermost/mattermost/server/public/model"
 	"github.com/mattermost/mattermost/server/public/shared/mlog"
@@ -73,12 +78,108 @@ func (a *App) DeletePublicKey(name string) *model.AppError {
 	return nil
 }
 
-func (ch *Channels) verifyPlugin(logger *mlog.Logger, plugin, signature io.ReadSeeker) *model.AppError {
+// pluginSignatureFile is the file recording the key an installed plugin is signed with, in the
+// directory of the plugin.
+const pluginSignatureFile = ".signature.json"
+
+// savePluginSignature records the key the plugin installed in the given directory is signed with,
+// removing any record shipped in the bundle if the plugin isn't signed with a trusted key.
+func savePluginSignature(pluginDir string, pluginSignature *model.PluginSignature) error {
+	path := filepath.Join(pluginDir, pluginSignatureFile)
+	if pluginSignature == nil {
+		if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+		return nil
+	}
+
+	data, err := json.Marshal(pluginSignature)
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(path, data, 0600)
+}
+
+// readPluginSignature returns the key the plugin installed in the given directory is signed with,
+// or nil if it isn't signed with a trusted key.
+func readPluginSignature(pluginDir string) (*model.PluginSignature, error) {
+	data, err := os.ReadFile(filepath.Join(pluginDir, pluginSignatureFile))
+	if os.IsNotExist(err) {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	var pluginSignature model.PluginSignature
+	if err := json.Unmarshal(data, &pluginSignature); err != nil {
+		return nil, err
+	}
+
+	return &pluginSignature, nil
+}
+
+// setPluginSigningKeyIds sets the ID of the key each plugin is signed with.
+func (ch *Channels) setPluginSigningKeyIds(pluginStatuses model.PluginStatuses) {
+	for _, status := range pluginStatuses {
+		pluginSignature, err := readPluginSignature(status.PluginPath)
+		if err != nil {
+			ch.srv.Log().Warn("Failed to read the plugin signature", mlog.String("plugin_id", status.PluginId), mlog.Err(err))
+			continue
+		}
+		if pluginSignature != nil {
+			status.SigningKeyId = pluginSignature.KeyId
+		}
+	}
+}
+
+// verifyPluginFromFileStore verifies the plugin bundle from the file store against its signature,
+// if any, returning the key it's signed with. A plugin without a valid signature is only rejected
+// when signatures are required, but a plugin signed with a revoked key is always rejected. The
+// bundle is rewound afterwards.
+func (ch *Channels) verifyPluginFromFileStore(logger *mlog.Logger, plugin *pluginSignaturePath, bundle io.ReadSeeker) (*model.PluginSignature, *model.AppError) {
+	required := *ch.cfgSvc.Config().PluginSettings.RequirePluginSignature
+	if plugin.signaturePath == "" {
+		if required {
+			return nil, model.NewAppError("verifyPluginFromFileStore", "api.plugin.verify_plugin.app_error", nil, "signature is missing", http.StatusInternalServerError)
+		}
+		return nil, nil
+	}
+
+	signature, appErr := ch.srv.fileReader(plugin.signaturePath)
+	if appErr != nil {
+		return nil, appErr
+	}
+	defer signature.Close()
+
+	pluginSignature, appErr := ch.verifyPlugin(logger, bundle, signature)
+	if appErr != nil {
+		if required || appErr.Id == "api.plugin.verify_plugin.revoked_key.app_error" {
+			return nil, appErr
+		}
+		logger.Warn("Installing plugin without a valid signature", mlog.Err(appErr))
+		pluginSignature = nil
+	}
+
+	if _, err := bundle.Seek(0, io.SeekStart); err != nil {
+		return nil, model.NewAppError("verifyPluginFromFileStore", "app.plugin.filesystem.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+
+	return pluginSignature, nil
+}
+
+// verifyPlugin verifies the plugin bundle against the built-in public key and the public keys
+// configured by the administrator, returning the key it's signed with. Signatures made with a
+// revoked key, or after the key expired, are rejected.
+func (ch *Channels) verifyPlugin(logger *mlog.Logger, plugin, signature io.ReadSeeker) (*model.PluginSignature, *model.AppError) {
 	// First try verifying using the hard-coded public key.
-	if err := verifySignature(bytes.NewReader(mattermostPluginPublicKey), plugin, signature); err == nil {
+	pluginSignature, err := checkSignature(bytes.NewReader(mattermostPluginPublicKey), plugin, signature)
+	if err == nil {
 		logger.Debug("Plugin signature verified using hard-coded public key")
-		return nil
+		pluginSignature.KeyName = model.PluginSignatureKeyNameMattermost
+		return ch.checkSignatureRevoked(logger, pluginSignature)
 	}
+	expired := errors.Is(err, errPluginSignatureKeyExpired)
 
 	// If that fails, try any of the admin-configured public keys.
 	publicKeys := ch.srv.Config().PluginSettings.SignaturePublicKeyFiles
@@ -97,36 +198,115 @@ func (ch *Channels) verifyPlugin(logger *mlog.Logger, plugin, signature io.ReadS
 			logger.Warn("Unable to seek in signature for public key ", mlog.String("public_key_path", pk))
 			continue
 		}
-		if err := verifySignature(publicKey, plugin, signature); err == nil {
+		pluginSignature, err := checkSignature(publicKey, plugin, signature)
+		if err == nil {
 			logger.Debug("Plugin signature verified using configured public key", mlog.String("public_key_path", pk))
-			return nil
+			pluginSignature.KeyName = pk
+			return ch.checkSignatureRevoked(logger, pluginSignature)
 		}
+		expired = expired || errors.Is(err, errPluginSignatureKeyExpired)
+	}
+
+	if expired {
+		return nil, model.NewAppError("VerifyPlugin", "api.plugin.verify_plugin.expired_key.app_error", nil, "", http.StatusInternalServerError)
 	}
 
-	return model.NewAppError("VerifyPlugin", "api.plugin.verify_plugin.app_error", nil, "", http.StatusInternalServerError)
+	return nil, model.NewAppError("VerifyPlugin", "api.plugin.verify_plugin.app_error", nil, "", http.StatusInternalServerError)
 }
 
+// checkSignatureRevoked returns an error if the key the plugin is signed with was revoked by the
+// administrator.
+func (ch *Channels) checkSignatureRevoked(logger *mlog.Logger, pluginSignature *model.PluginSignature) (*model.PluginSignature, *model.AppError) {
+	if pluginSignature.IsRevoked(ch.srv.Config().PluginSettings.RevokedSignatureKeyIds) {
+		logger.Warn("Plugin is signed with a revoked key", mlog.String("key_id", pluginSignature.KeyId), mlog.String("key_fingerprint", pluginSignature.KeyFingerprint))
+		return pluginSignature, model.NewAppError("VerifyPlugin", "api.plugin.verify_plugin.revoked_key.app_error", map[string]any{"KeyId": pluginSignature.KeyId}, "", http.StatusForbidden)
+	}
+
+	return pluginSignature, nil
+}
+
+// errPluginSignatureKeyExpired is returned when the signature was made after the signing key expired.
+var errPluginSignatureKeyExpired = errors.New("the signing key was expired at signing time")
+
 func verifySignature(publicKey, message, signature io.Reader) error {
+	_, err := checkSignature(publicKey, message, signature)
+	return err
+}
+
+// checkSignature verifies the message against the detached signature, returning the key it's
+// signed with.
+func checkSignature(publicKey, message, signature io.Reader) (*model.PluginSignature, error) {
 	pk, err := decodeIfArmored(publicKey)
 	if err != nil {
-		return errors.Wrap(err, "can't decode public key")
+		return nil, errors.Wrap(err, "can't decode public key")
 	}
 	s, err := decodeIfArmored(signature)
 	if err != nil {
-		return errors.Wrap(err, "can't decode signature")
+		return nil, errors.Wrap(err, "can't decode signature")
 	}
 	return verifyBinarySignature(pk, message, s)
 }
 
-func verifyBinarySignature(publicKey, signedFile, signature io.Reader) error {
+func verifyBinarySignature(publicKey, signedFile, signature io.Reader) (*model.PluginSignature, error) {
 	keyring, err := openpgp.ReadKeyRing(publicKey)
 	if err != nil {
-		return errors.Wrap(err, "can't read public key")
+		return nil, errors.Wrap(err, "can't read public key")
 	}
-	if _, err = openpgp.CheckDetachedSignature(keyring, signedFile, signature); err != nil {
-		return errors.Wrap(err, "error while checking the signature")
+	signatureBytes, err := io.ReadAll(signature)
+	if err != nil {
+		return nil, errors.Wrap(err, "can't read the signature")
+	}
+	signer, err := openpgp.CheckDetachedSignature(keyring, signedFile, bytes.NewReader(signatureBytes))
+	if err != nil {
+		return nil, errors.Wrap(err, "error while checking the signature")
+	}
+
+	keyID, signedAt, err := decodeSignature(signatureBytes)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, key := range keyring.KeysByIdUsage(keyID, packet.KeyFlagSign) {
+		if key.Entity != signer {
+			continue
+		}
+
+		// A lifetime of zero means the key doesn't expire.
+		if key.SelfSignature != nil && key.SelfSignature.KeyLifetimeSecs != nil && *key.SelfSignature.KeyLifetimeSecs != 0 {
+			expiresAt := key.PublicKey.CreationTime.Add(time.Duration(*key.SelfSignature.KeyLifetimeSecs) * time.Second)
+			if signedAt.After(expiresAt) {
+				return nil, errPluginSignatureKeyExpired
+			}
+		}
+		break
+	}
+
+	return &model.PluginSignature{
+		KeyId:          fmt.Sprintf("%016X", keyID),
+		KeyFingerprint: fmt.Sprintf("%X", signer.PrimaryKey.Fingerprint),
+		SignedAt:       model.GetMillisForTime(signedAt),
+	}, nil
+}
+
+// decodeSignature returns the ID of the key that made the binary detached signature, and when it
+// was made.
+func decodeSignature(signature []byte) (uint64, time.Time, error) {
+	p, err := packet.Read(bytes.NewReader(signature))
+	if err != nil {
+		return 0, time.Time{}, errors.Wrap(err, "can't read the signature")
+	}
+
+	switch sig := p.(type) {
+	case *packet.Signature:
+		if sig.IssuerKeyId == nil {
+			return 0, time.Time{}, errors.New("signature doesn't have an issuer")
+		}
+		return *sig.IssuerKeyId, sig.CreationTime, nil
+	case *packet.SignatureV3:
+		return sig.IssuerKeyId, sig.CreationTime, nil
+	default:
+		return 0, time.Time{}, errors.New("non signature packet found")
 	}
-	return nil
 }
 
 func decodeIfArmored(reader io.Reader) (io.Reader, error) {
@@ -145,3 +325,143 @@ func decodeIfArmored(reader io.Reader) (io.Reader, error) {
 func isSamlFile(saml *model.SamlSettings, filename string) bool {
 	return filename == *saml.PublicCertificateFile || filename == *saml.PrivateKeyFile || filename == *saml.IdpCertificateFile
 }
+
+// GetPluginSignatureReports verifies the signatures of the plugins installed on this server against
+// the trusted public keys and the revoked keys currently configured.
+func (a *App) GetPluginSignatureReports() ([]*model.PluginSignatureReport, *model.AppError) {
+	return a.ch.getPluginSignatureReports()
+}
+
+func (ch *Channels) getPluginSignatureReports() ([]*model.PluginSignatureReport, *model.AppError) {
+	pluginsEnvironment := ch.GetPluginsEnvironment()
+	if pluginsEnvironment == nil {
+		return nil, model.NewAppError("GetPluginSignatureReports", "app.plugin.disabled.app_error", nil, "", http.StatusNotImplemented)
+	}
+
+	bundles, err := pluginsEnvironment.Available()
+	if err != nil {
+		return nil, model.NewAppError("GetPluginSignatureReports", "app.plugin.sync.read_local_folder.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+
+	pluginSignaturePathMap, appErr := ch.getPluginsFromFolder()
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	reports := make([]*model.PluginSignatureReport, 0, len(bundles))
+	for _, bundle := range bundles {
+		if bundle.Manifest == nil {
+			continue
+		}
+
+		logger := ch.srv.Log().With(mlog.String("plugin_id", bundle.Manifest.Id))
+		report := &model.PluginSignatureReport{
+			PluginId: bundle.Manifest.Id,
+			Version:  bundle.Manifest.Version,
+			Status:   model.PluginSignatureStatusUnsigned,
+		}
+		reports = append(reports, report)
+
+		// Plugins are installed from the file store, unless prepackaged with the server.
+		var openBundle, openSignature func() (io.ReadSeekCloser, error)
+		if plugin, ok := pluginSignaturePathMap[bundle.Manifest.Id]; ok {
+			if plugin.signaturePath == "" {
+				continue
+			}
+			openBundle = func() (io.ReadSeekCloser, error) { return ch.openFileStoreReader(plugin.bundlePath) }
+			openSignature = func() (io.ReadSeekCloser, error) { return ch.openFileStoreReader(plugin.signaturePath) }
+		} else {
+			for _, plugin := range pluginsEnvironment.PrepackagedPlugins() {
+				if plugin.Manifest.Id == bundle.Manifest.Id && plugin.Manifest.Version == bundle.Manifest.Version && plugin.SignaturePath != "" {
+					openBundle = func() (io.ReadSeekCloser, error) { return os.Open(plugin.Path) }
+					openSignature = func() (io.ReadSeekCloser, error) { return os.Open(plugin.SignaturePath) }
+					break
+				}
+			}
+			if openBundle == nil {
+				continue
+			}
+		}
+
+		if err := ch.verifyPluginSignatureReport(logger, report, openBundle, openSignature); err != nil {
+			report.Status = model.PluginSignatureStatusInvalid
+			report.Error = err.Error()
+		}
+	}
+
+	return reports, nil
+}
+
+func (ch *Channels) openFileStoreReader(path string) (io.ReadSeekCloser, error) {
+	reader, appErr := ch.srv.fileReader(path)
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	return reader, nil
+}
+
+// verifyPluginSignatureReport verifies the plugin bundle against its signature, setting the status
+// and the signature of the report.
+func (ch *Channels) verifyPluginSignatureReport(logger *mlog.Logger, report *model.PluginSignatureReport, openBundle, openSignature func() (io.ReadSeekCloser, error)) error {
+	bundle, err := openBundle()
+	if err != nil {
+		return errors.Wrap(err, "failed to open the plugin bundle")
+	}
+	defer bundle.Close()
+
+	signature, err := openSignature()
+	if err != nil {
+		return errors.Wrap(err, "failed to open the plugin signature")
+	}
+	defer signature.Close()
+
+	pluginSignature, appErr := ch.verifyPlugin(logger, bundle, signature)
+	if appErr == nil {
+		report.Status = model.PluginSignatureStatusVerified
+		report.Signature = pluginSignature
+		return nil
+	}
+
+	switch appErr.Id {
+	case "api.plugin.verify_plugin.revoked_key.app_error":
+		report.Status = model.PluginSignatureStatusRevoked
+	case "api.plugin.verify_plugin.expired_key.app_error":
+		report.Status = model.PluginSignatureStatusExpired
+	default:
+		report.Status = model.PluginSignatureStatusInvalid
+	}
+	report.Error = appErr.Error()
+
+	// Report the key the bundle claims to be signed with, even though it's not trusted.
+	report.Signature = pluginSignature
+	if report.Signature == nil {
+		if _, err := signature.Seek(0, io.SeekStart); err == nil {
+			report.Signature, _ = readSignature(signature)
+		}
+	}
+
+	return nil
+}
+
+// readSignature returns the key the detached signature claims to be made with, and when, without
+// verifying it.
+func readSignature(signature io.Reader) (*model.PluginSignature, error) {
+	s, err := decodeIfArmored(signature)
+	if err != nil {
+		return nil, errors.Wrap(err, "can't decode signature")
+	}
+	signatureBytes, err := io.ReadAll(s)
+	if err != nil {
+		return nil, errors.Wrap(err, "can't read the signature")
+	}
+	keyID, signedAt, err := decodeSignature(signatureBytes)
+	if err != nil {
+		return nil, err
+	}
+
+	return &model.PluginSignature{
+		KeyId:    fmt.Sprintf("%016X", keyID),
+		SignedAt: model.GetMillisForTime(signedAt),
+	}, nil
+}
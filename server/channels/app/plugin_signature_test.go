@@ -4,12 +4,17 @@
 package app
 
 import (
+	"bytes"
 	"os"
 	"path/filepath"
 	"testing"
+	"time"
 
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/openpgp"        //nolint:staticcheck
+	"golang.org/x/crypto/openpgp/packet" //nolint:staticcheck
 
 	"github.com/mattermost/mattermost/server/public/model"
 	"github.com/mattermost/mattermost/server/v8/channels/store/storetest/mocks"
@@ -121,3 +126,189 @@ func TestVerifySignature(t *testing.T) {
 		require.NoError(t, verifySignature(publicKeyFileReader, pluginFileReader, signatureFileReader))
 	})
 }
+
+// newSigningKey returns a new key pair, expiring after the given lifetime unless zero, along with
+// its serialized public key.
+func newSigningKey(t *testing.T, lifetime time.Duration) (*openpgp.Entity, []byte) {
+	t.Helper()
+
+	entity, err := openpgp.NewEntity("Plugin Signer", "", "signer@example.com", nil)
+	require.NoError(t, err)
+
+	if lifetime > 0 {
+		for _, identity := range entity.Identities {
+			identity.SelfSignature.KeyLifetimeSecs = model.NewPointer(uint32(lifetime.Seconds()))
+			require.NoError(t, identity.SelfSignature.SignUserId(identity.UserId.Id, entity.PrimaryKey, entity.PrivateKey, nil))
+		}
+	}
+
+	var publicKey bytes.Buffer
+	require.NoError(t, entity.Serialize(&publicKey))
+
+	return entity, publicKey.Bytes()
+}
+
+func signPlugin(t *testing.T, signer *openpgp.Entity, plugin []byte, signedAt time.Time) []byte {
+	t.Helper()
+
+	var signature bytes.Buffer
+	config := &packet.Config{Time: func() time.Time { return signedAt }}
+	require.NoError(t, openpgp.DetachSign(&signature, signer, bytes.NewReader(plugin), config))
+
+	return signature.Bytes()
+}
+
+func TestCheckSignature(t *testing.T) {
+	mainHelper.Parallel(t)
+	path, _ := fileutils.FindDir("tests")
+	plugin, err := os.ReadFile(filepath.Join(path, "testplugin.tar.gz"))
+	require.NoError(t, err)
+
+	t.Run("signing metadata", func(t *testing.T) {
+		signer, publicKey := newSigningKey(t, 0)
+		signedAt := time.Now().Add(-time.Hour).Truncate(time.Second)
+		signature := signPlugin(t, signer, plugin, signedAt)
+
+		pluginSignature, err := checkSignature(bytes.NewReader(publicKey), bytes.NewReader(plugin), bytes.NewReader(signature))
+		require.NoError(t, err)
+		assert.Equal(t, signer.PrimaryKey.KeyIdString(), pluginSignature.KeyId)
+		assert.Len(t, pluginSignature.KeyFingerprint, 40)
+		assert.Equal(t, model.GetMillisForTime(signedAt), pluginSignature.SignedAt)
+	})
+
+	t.Run("signed before the key expired", func(t *testing.T) {
+		signer, publicKey := newSigningKey(t, 24*time.Hour)
+		signature := signPlugin(t, signer, plugin, signer.PrimaryKey.CreationTime.Add(time.Hour))
+
+		_, err := checkSignature(bytes.NewReader(publicKey), bytes.NewReader(plugin), bytes.NewReader(signature))
+		require.NoError(t, err)
+	})
+
+	t.Run("signed after the key expired", func(t *testing.T) {
+		signer, publicKey := newSigningKey(t, time.Hour)
+		signature := signPlugin(t, signer, plugin, signer.PrimaryKey.CreationTime.Add(2*time.Hour))
+
+		_, err := checkSignature(bytes.NewReader(publicKey), bytes.NewReader(plugin), bytes.NewReader(signature))
+		require.ErrorIs(t, err, errPluginSignatureKeyExpired)
+	})
+
+	t.Run("unknown key", func(t *testing.T) {
+		signer, _ := newSigningKey(t, 0)
+		_, otherPublicKey := newSigningKey(t, 0)
+		signature := signPlugin(t, signer, plugin, time.Now())
+
+		_, err := checkSignature(bytes.NewReader(otherPublicKey), bytes.NewReader(plugin), bytes.NewReader(signature))
+		require.Error(t, err)
+
+		pluginSignature, err := readSignature(bytes.NewReader(signature))
+		require.NoError(t, err)
+		assert.Equal(t, signer.PrimaryKey.KeyIdString(), pluginSignature.KeyId)
+	})
+}
+
+func TestPluginSignatureRevocation(t *testing.T) {
+	mainHelper.Parallel(t)
+	th := Setup(t)
+	defer th.TearDown()
+
+	path, _ := fileutils.FindDir("tests")
+	plugin, err := os.ReadFile(filepath.Join(path, "testplugin.tar.gz"))
+	require.NoError(t, err)
+
+	signer, publicKey := newSigningKey(t, 0)
+	appErr := th.App.AddPublicKey("signer.plugin.gpg", bytes.NewReader(publicKey))
+	require.Nil(t, appErr)
+	signature := signPlugin(t, signer, plugin, time.Now())
+
+	pluginSignature, appErr := th.App.ch.verifyPlugin(th.App.Log(), bytes.NewReader(plugin), bytes.NewReader(signature))
+	require.Nil(t, appErr)
+	manifest, appErr := th.App.ch.installPlugin(bytes.NewReader(plugin), bytes.NewReader(signature), pluginSignature, installPluginLocallyAlways)
+	require.Nil(t, appErr)
+
+	getReport := func(t *testing.T) *model.PluginSignatureReport {
+		t.Helper()
+
+		reports, appErr := th.App.GetPluginSignatureReports()
+		require.Nil(t, appErr)
+		for _, report := range reports {
+			if report.PluginId == manifest.Id {
+				return report
+			}
+		}
+		require.FailNow(t, "no report for the plugin")
+		return nil
+	}
+
+	t.Run("verified", func(t *testing.T) {
+		pluginSignature, appErr := th.App.ch.verifyPlugin(th.App.Log(), bytes.NewReader(plugin), bytes.NewReader(signature))
+		require.Nil(t, appErr)
+		assert.Equal(t, "signer.plugin.gpg", pluginSignature.KeyName)
+
+		report := getReport(t)
+		assert.Equal(t, model.PluginSignatureStatusVerified, report.Status)
+		require.NotNil(t, report.Signature)
+		assert.Equal(t, signer.PrimaryKey.KeyIdString(), report.Signature.KeyId)
+		assert.Equal(t, "signer.plugin.gpg", report.Signature.KeyName)
+
+		status, appErr := th.App.GetPluginStatus(manifest.Id)
+		require.Nil(t, appErr)
+		assert.Equal(t, signer.PrimaryKey.KeyIdString(), status.SigningKeyId)
+	})
+
+	t.Run("revoked", func(t *testing.T) {
+		th.App.UpdateConfig(func(cfg *model.Config) {
+			cfg.PluginSettings.RevokedSignatureKeyIds = []string{signer.PrimaryKey.KeyIdString()}
+		})
+		defer th.App.UpdateConfig(func(cfg *model.Config) {
+			cfg.PluginSettings.RevokedSignatureKeyIds = []string{}
+		})
+
+		_, appErr := th.App.ch.verifyPlugin(th.App.Log(), bytes.NewReader(plugin), bytes.NewReader(signature))
+		require.NotNil(t, appErr)
+		assert.Equal(t, "api.plugin.verify_plugin.revoked_key.app_error", appErr.Id)
+
+		report := getReport(t)
+		assert.Equal(t, model.PluginSignatureStatusRevoked, report.Status)
+		assert.NotEmpty(t, report.Error)
+
+		// A peer doesn't install the plugin, even though signatures aren't required.
+		require.False(t, *th.App.Config().PluginSettings.RequirePluginSignature)
+		require.Nil(t, th.App.ch.removePluginLocally(manifest.Id))
+		th.App.ch.installPluginFromClusterMessage(manifest.Id)
+		_, appErr = th.App.GetPluginStatus(manifest.Id)
+		require.NotNil(t, appErr)
+		assert.Equal(t, "app.plugin.not_installed.app_error", appErr.Id)
+
+		th.App.UpdateConfig(func(cfg *model.Config) {
+			cfg.PluginSettings.RevokedSignatureKeyIds = []string{}
+		})
+		th.App.ch.installPluginFromClusterMessage(manifest.Id)
+		status, appErr := th.App.GetPluginStatus(manifest.Id)
+		require.Nil(t, appErr)
+		assert.Equal(t, signer.PrimaryKey.KeyIdString(), status.SigningKeyId)
+	})
+
+	t.Run("untrusted key", func(t *testing.T) {
+		appErr := th.App.DeletePublicKey("signer.plugin.gpg")
+		require.Nil(t, appErr)
+
+		report := getReport(t)
+		assert.Equal(t, model.PluginSignatureStatusInvalid, report.Status)
+		require.NotNil(t, report.Signature)
+		assert.Equal(t, signer.PrimaryKey.KeyIdString(), report.Signature.KeyId)
+	})
+
+	t.Run("unsigned", func(t *testing.T) {
+		manifest, appErr = th.App.InstallPlugin(bytes.NewReader(plugin), true)
+		require.Nil(t, appErr)
+		require.Nil(t, th.App.ch.removeSignature(manifest.Id))
+
+		report := getReport(t)
+		assert.Equal(t, model.PluginSignatureStatusUnsigned, report.Status)
+		assert.Nil(t, report.Signature)
+
+		status, appErr := th.App.GetPluginStatus(manifest.Id)
+		require.Nil(t, appErr)
+		assert.Empty(t, status.SigningKeyId)
+	})
+}
@@ -27,6 +27,8 @@ func (ch *Channels) GetPluginStatus(id string) (*model.PluginStatus, *model.AppE
 			if ch.srv.platform.Cluster() != nil {
 				status.ClusterId = ch.srv.platform.Cluster().GetClusterId()
 			}
+			ch.setPluginSchemaVersions(pluginsEnvironment, model.PluginStatuses{status})
+			ch.setPluginSigningKeyIds(model.PluginStatuses{status})
 
 			return status, nil
 		}
@@ -60,6 +62,8 @@ func (ch *Channels) GetPluginStatuses() (model.PluginStatuses, *model.AppError)
 			status.ClusterId = ""
 		}
 	}
+	ch.setPluginSchemaVersions(pluginsEnvironment, pluginStatuses)
+	ch.setPluginSigningKeyIds(pluginStatuses)
 
 	return pluginStatuses, nil
 }
@@ -2959,7 +2959,7 @@ func TestFollowThreadSkipsParticipants(t *testing.T) {
 
 	threadMembership, appErr := th.App.GetThreadMembershipForUser(user.Id, p1.Id)
 	require.Nil(t, appErr)
-	thread, appErr := th.App.GetThreadForUser(threadMembership, false)
+	thread, appErr := th.App.GetThreadForUser(th.Context, threadMembership, false)
 	require.Nil(t, appErr)
 	require.Len(t, thread.Participants, 1) // length should be 1, the original poster, since sysadmin was just mentioned but didn't post
 
@@ -2968,7 +2968,7 @@ func TestFollowThreadSkipsParticipants(t *testing.T) {
 
 	threadMembership, appErr = th.App.GetThreadMembershipForUser(user.Id, p1.Id)
 	require.Nil(t, appErr)
-	thread, appErr = th.App.GetThreadForUser(threadMembership, false)
+	thread, appErr = th.App.GetThreadForUser(th.Context, threadMembership, false)
 	require.Nil(t, appErr)
 	require.Len(t, thread.Participants, 2) // length should be 2, the original poster and sysadmin, since sysadmin participated now
 
@@ -2978,7 +2978,7 @@ func TestFollowThreadSkipsParticipants(t *testing.T) {
 
 	threadMembership, appErr = th.App.GetThreadMembershipForUser(user2.Id, p1.Id)
 	require.Nil(t, appErr)
-	thread, appErr = th.App.GetThreadForUser(threadMembership, false)
+	thread, appErr = th.App.GetThreadForUser(th.Context, threadMembership, false)
 	require.Nil(t, appErr)
 	require.Len(t, thread.Participants, 2) // length should be 2, since follow shouldn't update participant list, only user1 and sysadmin are participants
 	for _, p := range thread.Participants {
@@ -2987,13 +2987,13 @@ func TestFollowThreadSkipsParticipants(t *testing.T) {
 
 	oldID := threadMembership.PostId
 	threadMembership.PostId = "notfound"
-	_, appErr = th.App.GetThreadForUser(threadMembership, false)
+	_, appErr = th.App.GetThreadForUser(th.Context, threadMembership, false)
 	require.NotNil(t, appErr)
 	assert.Equal(t, http.StatusNotFound, appErr.StatusCode)
 
 	threadMembership.Following = false
 	threadMembership.PostId = oldID
-	_, appErr = th.App.GetThreadForUser(threadMembership, false)
+	_, appErr = th.App.GetThreadForUser(th.Context, threadMembership, false)
 	require.NotNil(t, appErr)
 	assert.Equal(t, http.StatusNotFound, appErr.StatusCode)
 }
@@ -3199,7 +3199,7 @@ func TestCollapsedThreadFetch(t *testing.T) {
 		require.Len(t, thread.Participants, 1)
 
 		// extended fetch posts page
-		l, appErr := th.App.GetPostsPage(model.GetPostsOptions{
+		l, appErr := th.App.GetPostsPage(th.Context, model.GetPostsOptions{
 			UserId:                   user1.Id,
 			ChannelId:                channel.Id,
 			PerPage:                  int(10),
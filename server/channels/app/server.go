@@ -57,12 +57,15 @@ import (
 	"github.com/mattermost/mattermost/server/v8/channels/jobs/migrations"
 	"github.com/mattermost/mattermost/server/v8/channels/jobs/mobile_session_metadata"
 	"github.com/mattermost/mattermost/server/v8/channels/jobs/notify_admin"
+	"github.com/mattermost/mattermost/server/v8/channels/jobs/partition_maintenance"
 	"github.com/mattermost/mattermost/server/v8/channels/jobs/plugins"
 	"github.com/mattermost/mattermost/server/v8/channels/jobs/post_persistent_notifications"
 	"github.com/mattermost/mattermost/server/v8/channels/jobs/product_notices"
 	"github.com/mattermost/mattermost/server/v8/channels/jobs/refresh_materialized_views"
 	"github.com/mattermost/mattermost/server/v8/channels/jobs/resend_invitation_email"
 	"github.com/mattermost/mattermost/server/v8/channels/jobs/s3_path_migration"
+	"github.com/mattermost/mattermost/server/v8/channels/jobs/user_data_export"
+	"github.com/mattermost/mattermost/server/v8/channels/jobs/user_erasure"
 	"github.com/mattermost/mattermost/server/v8/channels/store"
 	"github.com/mattermost/mattermost/server/v8/channels/utils"
 	"github.com/mattermost/mattermost/server/v8/config"
@@ -358,6 +361,7 @@ func NewServer(options ...Option) (*Server, error) {
 		TemplatesContainer: s.TemplatesContainer(),
 		UserService:        s.userService,
 		Store:              s.GetStore(),
+		HookRunner:         s.ch,
 	})
 	if err != nil {
 		return nil, errors.Wrapf(err, "unable to initialize email service")
@@ -518,6 +522,9 @@ func (s *Server) runJobs() {
 	s.Go(func() {
 		runConfigCleanupJob(s)
 	})
+	s.Go(func() {
+		runAuditRecordCleanupJob(s)
+	})
 	s.Go(func() {
 		runCloudUserCountReportJob(s)
 	})
@@ -696,6 +703,8 @@ func (s *Server) Shutdown() {
 		s.Log().Warn("Failed to stop metrics server", mlog.Err(err))
 	}
 
+	s.platform.ShutdownTracing()
+
 	// Stopping email service after HTTP server has stopped to prevent
 	// any stray notifications from being queued.
 	s.EmailService.Stop()
@@ -1196,6 +1205,13 @@ func runConfigCleanupJob(s *Server) {
 	}, time.Hour*24)
 }
 
+func runAuditRecordCleanupJob(s *Server) {
+	doAuditRecordCleanup(s)
+	model.CreateRecurringTask("Audit Record Cleanup", func() {
+		doAuditRecordCleanup(s)
+	}, time.Hour*24)
+}
+
 func (s *Server) runLicenseExpirationCheckJob() {
 	s.doLicenseExpirationCheck()
 	model.CreateRecurringTask("License Expiration Check", func() {
@@ -1247,8 +1263,9 @@ func doCommandWebhookCleanup(s *Server) {
 }
 
 const (
-	sessionsCleanupBatchSize = 1000
-	jobsCleanupBatchSize     = 1000
+	sessionsCleanupBatchSize     = 1000
+	jobsCleanupBatchSize         = 1000
+	auditRecordsCleanupBatchSize = 1000
 )
 
 func doSessionCleanup(s *Server) {
@@ -1273,6 +1290,27 @@ func doJobsCleanup(s *Server) {
 	}
 }
 
+func doAuditRecordCleanup(s *Server) {
+	retentionDays := *s.platform.Config().ExperimentalAuditSettings.DatabaseRetentionDays
+	if retentionDays <= 0 {
+		return
+	}
+	mlog.Debug("Cleaning up audit record store.")
+
+	expiry := model.GetMillisForTime(time.Now().AddDate(0, 0, -retentionDays))
+	for {
+		deleted, err := s.Store().AuditRecord().PermanentDeleteBatch(expiry, auditRecordsCleanupBatchSize)
+		if err != nil {
+			mlog.Warn("Error while cleaning up audit records", mlog.Err(err))
+			return
+		}
+		if deleted < auditRecordsCleanupBatchSize {
+			return
+		}
+		time.Sleep(100 * time.Millisecond)
+	}
+}
+
 func doConfigCleanup(s *Server) {
 	if *s.platform.Config().JobSettings.CleanupConfigThresholdDays < 0 || !config.IsDatabaseDSN(s.platform.DescribeConfig()) {
 		return
@@ -1548,6 +1586,18 @@ func (s *Server) initJobs() {
 		nil,
 	)
 
+	s.Jobs.RegisterJobType(
+		model.JobTypeUserDataExport,
+		user_data_export.MakeWorker(s.Jobs, New(ServerConnector(s.Channels()))),
+		nil,
+	)
+
+	s.Jobs.RegisterJobType(
+		model.JobTypeUserErasure,
+		user_erasure.MakeWorker(s.Jobs, New(ServerConnector(s.Channels()))),
+		nil,
+	)
+
 	s.Jobs.RegisterJobType(
 		model.JobTypeActiveUsers,
 		active_users.MakeWorker(s.Jobs, s.Store(), func() einterfaces.MetricsInterface { return s.GetMetrics() }),
@@ -1626,6 +1676,12 @@ func (s *Server) initJobs() {
 		refresh_materialized_views.MakeScheduler(s.Jobs, *s.platform.Config().SqlSettings.DriverName),
 	)
 
+	s.Jobs.RegisterJobType(
+		model.JobTypePartitionMaintenance,
+		partition_maintenance.MakeWorker(s.Jobs, *s.platform.Config().SqlSettings.DriverName),
+		partition_maintenance.MakeScheduler(s.Jobs, *s.platform.Config().SqlSettings.DriverName),
+	)
+
 	s.Jobs.RegisterJobType(
 		model.JobTypeExportUsersToCSV,
 		export_users_to_csv.MakeWorker(s.Jobs, s.Store(), New(ServerConnector(s.Channels()))),
@@ -741,6 +741,15 @@ func (a *App) AddUserToTeamByInviteId(c request.CTX, inviteId string, userID str
 }
 
 func (a *App) JoinUserToTeam(c request.CTX, team *model.Team, user *model.User, userRequestorId string) (*model.TeamMember, *model.AppError) {
+	var actor *model.User
+	if userRequestorId != "" {
+		actor, _ = a.GetUser(userRequestorId)
+	}
+
+	if appErr := a.runTeamWillBeJoinedHook(c, team, user, actor); appErr != nil {
+		return nil, appErr
+	}
+
 	teamMember, alreadyAdded, err := a.ch.srv.teamService.JoinUserToTeam(c, team, user)
 	if err != nil {
 		var appErr *model.AppError
@@ -798,11 +807,6 @@ func (a *App) JoinUserToTeam(c request.CTX, team *model.Team, user *model.User,
 	a.InvalidateCacheForUser(user.Id)
 	a.invalidateCacheForUserTeams(user.Id)
 
-	var actor *model.User
-	if userRequestorId != "" {
-		actor, _ = a.GetUser(userRequestorId)
-	}
-
 	a.Srv().Go(func() {
 		pluginContext := pluginContext(c)
 		a.ch.RunMultiHook(func(hooks plugin.Hooks, _ *model.Manifest) bool {
@@ -819,6 +823,30 @@ func (a *App) JoinUserToTeam(c request.CTX, team *model.Team, user *model.User,
 	return teamMember, nil
 }
 
+// runTeamWillBeJoinedHook gives plugins the chance to reject a user joining a team. Plugins
+// aren't asked about users that are already members of the team.
+func (a *App) runTeamWillBeJoinedHook(c request.CTX, team *model.Team, user *model.User, actor *model.User) *model.AppError {
+	var rejectionReason string
+	var checkedMember bool
+	pluginContext := pluginContext(c)
+	a.ch.RunMultiHook(func(hooks plugin.Hooks, _ *model.Manifest) bool {
+		if !checkedMember {
+			checkedMember = true
+			if member, err := a.Srv().Store().Team().GetMember(c, team.Id, user.Id); err == nil && member.DeleteAt == 0 {
+				return false
+			}
+		}
+
+		rejectionReason = hooks.TeamWillBeJoined(pluginContext, team, user, actor)
+		return rejectionReason == ""
+	}, plugin.TeamWillBeJoinedID)
+	if rejectionReason != "" {
+		return model.NewAppError("JoinUserToTeam", "app.team.join_user_to_team.rejected_by_plugin.app_error", map[string]any{"Reason": rejectionReason}, "", http.StatusBadRequest)
+	}
+
+	return nil
+}
+
 func (a *App) GetTeam(teamID string) (*model.Team, *model.AppError) {
 	team, err := a.ch.srv.teamService.GetTeam(teamID)
 	if err != nil {
@@ -1290,6 +1290,27 @@ func (a *App) UpdateUser(c request.CTX, user *model.User, sendNotifications bool
 		}
 	}
 
+	var rejectionReason string
+	pluginContext := pluginContext(c)
+	a.ch.RunMultiHook(func(hooks plugin.Hooks, _ *model.Manifest) bool {
+		var replacementUser *model.User
+		replacementUser, rejectionReason = hooks.UserWillBeUpdated(pluginContext, user, prev)
+		if replacementUser != nil {
+			// Plugins can't change the identity, roles or authentication of the user.
+			replacementUser.Id = prev.Id
+			replacementUser.Roles = user.Roles
+			replacementUser.DeleteAt = user.DeleteAt
+			replacementUser.RemoteId = user.RemoteId
+			replacementUser.AuthService = user.AuthService
+			replacementUser.AuthData = user.AuthData
+			user = replacementUser
+		}
+		return rejectionReason == ""
+	}, plugin.UserWillBeUpdatedID)
+	if rejectionReason != "" {
+		return nil, model.NewAppError("UpdateUser", "app.user.update.rejected_by_plugin.app_error", map[string]any{"Reason": rejectionReason}, "", http.StatusBadRequest)
+	}
+
 	if prev.CreateAt != user.CreateAt {
 		user.CreateAt = prev.CreateAt
 	}
@@ -2661,7 +2682,7 @@ func (a *App) ConvertBotToUser(c request.CTX, bot *model.Bot, userPatch *model.U
 	return user, nil
 }
 
-func (a *App) GetThreadsForUser(userID, teamID string, options model.GetUserThreadsOpts) (*model.Threads, *model.AppError) {
+func (a *App) GetThreadsForUser(rctx request.CTX, userID, teamID string, options model.GetUserThreadsOpts) (*model.Threads, *model.AppError) {
 	var result model.Threads
 	var eg errgroup.Group
 	postPriorityIsEnabled := a.IsPostPriorityEnabled()
@@ -2671,7 +2692,7 @@ func (a *App) GetThreadsForUser(userID, teamID string, options model.GetUserThre
 
 	if !options.ThreadsOnly {
 		eg.Go(func() error {
-			totalUnreadThreads, err := a.Srv().Store().Thread().GetTotalUnreadThreads(userID, teamID, options)
+			totalUnreadThreads, err := a.Srv().Store().Thread().GetTotalUnreadThreads(rctx, userID, teamID, options)
 			if err != nil {
 				return errors.Wrapf(err, "failed to count unread threads for user id=%s", userID)
 			}
@@ -2685,7 +2706,7 @@ func (a *App) GetThreadsForUser(userID, teamID string, options model.GetUserThre
 		// and send back duplicate values down below.
 		if !options.Unread {
 			eg.Go(func() error {
-				totalCount, err := a.Srv().Store().Thread().GetTotalThreads(userID, teamID, options)
+				totalCount, err := a.Srv().Store().Thread().GetTotalThreads(rctx, userID, teamID, options)
 				if err != nil {
 					return errors.Wrapf(err, "failed to count threads for user id=%s", userID)
 				}
@@ -2696,7 +2717,7 @@ func (a *App) GetThreadsForUser(userID, teamID string, options model.GetUserThre
 		}
 
 		eg.Go(func() error {
-			totalUnreadMentions, err := a.Srv().Store().Thread().GetTotalUnreadMentions(userID, teamID, options)
+			totalUnreadMentions, err := a.Srv().Store().Thread().GetTotalUnreadMentions(rctx, userID, teamID, options)
 			if err != nil {
 				return errors.Wrapf(err, "failed to count threads for user id=%s", userID)
 			}
@@ -2707,7 +2728,7 @@ func (a *App) GetThreadsForUser(userID, teamID string, options model.GetUserThre
 
 		if postPriorityIsEnabled {
 			eg.Go(func() error {
-				totalUnreadUrgentMentions, err := a.Srv().Store().Thread().GetTotalUnreadUrgentMentions(userID, teamID, options)
+				totalUnreadUrgentMentions, err := a.Srv().Store().Thread().GetTotalUnreadUrgentMentions(rctx, userID, teamID, options)
 				if err != nil {
 					return errors.Wrapf(err, "failed to count urgent mentioned threads for user id=%s", userID)
 				}
@@ -2720,7 +2741,7 @@ func (a *App) GetThreadsForUser(userID, teamID string, options model.GetUserThre
 
 	if !options.TotalsOnly {
 		eg.Go(func() error {
-			threads, err := a.Srv().Store().Thread().GetThreadsForUser(userID, teamID, options)
+			threads, err := a.Srv().Store().Thread().GetThreadsForUser(rctx, userID, teamID, options)
 			if err != nil {
 				return errors.Wrapf(err, "failed to get threads for user id=%s", userID)
 			}
@@ -2760,8 +2781,8 @@ func (a *App) GetThreadMembershipForUser(userId, threadId string) (*model.Thread
 	return threadMembership, nil
 }
 
-func (a *App) GetThreadForUser(threadMembership *model.ThreadMembership, extended bool) (*model.ThreadResponse, *model.AppError) {
-	thread, nErr := a.Srv().Store().Thread().GetThreadForUser(threadMembership, extended, a.IsPostPriorityEnabled())
+func (a *App) GetThreadForUser(rctx request.CTX, threadMembership *model.ThreadMembership, extended bool) (*model.ThreadResponse, *model.AppError) {
+	thread, nErr := a.Srv().Store().Thread().GetThreadForUser(rctx, threadMembership, extended, a.IsPostPriorityEnabled())
 	if nErr != nil {
 		var nfErr *store.ErrNotFound
 		switch {
@@ -2845,9 +2866,11 @@ func (a *App) UpdateThreadFollowForUserFromChannelAdd(c request.CTX, userID, tea
 	if err != nil {
 		return model.NewAppError("UpdateThreadFollowForUserFromChannelAdd", "app.user.update_thread_follow_for_user.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
 	}
+	// The thread store doesn't take the request context, so record the write here.
+	a.Srv().Platform().RecordWrite(c.Context())
 
 	message := model.NewWebSocketEvent(model.WebsocketEventThreadUpdated, teamID, "", userID, nil, "")
-	userThread, err := a.Srv().Store().Thread().GetThreadForUser(tm, true, a.IsPostPriorityEnabled())
+	userThread, err := a.Srv().Store().Thread().GetThreadForUser(c, tm, true, a.IsPostPriorityEnabled())
 	if err != nil {
 		var errNotFound *store.ErrNotFound
 		if errors.As(err, &errNotFound) {
@@ -2925,7 +2948,9 @@ func (a *App) UpdateThreadReadForUser(c request.CTX, currentSessionId, userID, t
 	if nErr != nil {
 		return nil, model.NewAppError("UpdateThreadReadForUser", "app.user.update_thread_read_for_user.app_error", nil, "", http.StatusInternalServerError).Wrap(nErr)
 	}
-	thread, err := a.GetThreadForUser(membership, false)
+	// The thread store doesn't take the request context, so record the write here.
+	a.Srv().Platform().RecordWrite(c.Context())
+	thread, err := a.GetThreadForUser(c, membership, false)
 	if err != nil {
 		return nil, err
 	}
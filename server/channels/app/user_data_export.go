@@ -0,0 +1,435 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package app
+
+import (
+	"archive/zip"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/public/shared/i18n"
+	"github.com/mattermost/mattermost/server/public/shared/mlog"
+	"github.com/mattermost/mattermost/server/public/shared/request"
+	"github.com/mattermost/mattermost/server/v8/channels/app/imports"
+	"github.com/mattermost/mattermost/server/v8/platform/shared/filestore"
+)
+
+const (
+	userDataExportBatchSize = 1000
+
+	userDataExportPreferencesFilename    = "preferences.json"
+	userDataExportReactionsFilename      = "reactions.json"
+	userDataExportDraftsFilename         = "drafts.json"
+	userDataExportScheduledPostsFilename = "scheduled_posts.json"
+	userDataExportFilesFilename          = "files.json"
+)
+
+// RequestUserDataExport creates a job exporting the data of the user. When
+// rateLimited is set, the request is rejected if an export of the data of the
+// user succeeded less than ExportSettings.UserDataExportIntervalHours ago.
+func (a *App) RequestUserDataExport(rctx request.CTX, userID string, rateLimited bool) (*model.Job, *model.AppError) {
+	if !*a.Config().ExportSettings.EnableUserDataExport {
+		return nil, model.NewAppError("RequestUserDataExport", "app.user_data_export.disabled.app_error", nil, "", http.StatusNotImplemented)
+	}
+
+	if _, appErr := a.GetUser(userID); appErr != nil {
+		return nil, appErr
+	}
+
+	for _, status := range []string{model.JobStatusPending, model.JobStatusInProgress} {
+		jobs, appErr := a.Srv().Jobs.GetJobsByTypeAndStatus(rctx, model.JobTypeUserDataExport, status)
+		if appErr != nil {
+			return nil, appErr
+		}
+		for _, job := range jobs {
+			if job.Data["user_id"] == userID {
+				return nil, model.NewAppError("RequestUserDataExport", "app.user_data_export.job_exists.app_error", nil, "", http.StatusBadRequest)
+			}
+		}
+	}
+
+	intervalHours := *a.Config().ExportSettings.UserDataExportIntervalHours
+	if rateLimited && intervalHours > 0 {
+		since := model.GetMillis() - (time.Duration(intervalHours) * time.Hour).Milliseconds()
+		jobs, appErr := a.getUserDataExportJobs(rctx, userID, since)
+		if appErr != nil {
+			return nil, appErr
+		}
+		for _, job := range jobs {
+			// Failed exports don't count against the user.
+			if job.Status == model.JobStatusSuccess {
+				return nil, model.NewAppError("RequestUserDataExport", "app.user_data_export.rate_limited.app_error", map[string]any{"Hours": intervalHours}, "", http.StatusTooManyRequests)
+			}
+		}
+	}
+
+	return a.Srv().Jobs.CreateJob(rctx, model.JobTypeUserDataExport, map[string]string{
+		"user_id":            userID,
+		"requesting_user_id": rctx.Session().UserId,
+	})
+}
+
+// GetUserDataExports returns the exports of the data of the user that were
+// created within the export retention period, newest first.
+func (a *App) GetUserDataExports(rctx request.CTX, userID string) ([]*model.Job, *model.AppError) {
+	retention := time.Duration(*a.Config().ExportSettings.RetentionDays) * 24 * time.Hour
+	return a.getUserDataExportJobs(rctx, userID, model.GetMillis()-retention.Milliseconds())
+}
+
+// getUserDataExportJobs returns the user data export jobs of the user created
+// since the given time, newest first.
+func (a *App) getUserDataExportJobs(rctx request.CTX, userID string, since int64) ([]*model.Job, *model.AppError) {
+	result := []*model.Job{}
+	for page := 0; ; page++ {
+		jobs, err := a.Srv().Store().Job().GetAllByTypePage(rctx, model.JobTypeUserDataExport, page*userDataExportBatchSize, userDataExportBatchSize)
+		if err != nil {
+			return nil, model.NewAppError("getUserDataExportJobs", "app.job.get_all.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+		}
+
+		for _, job := range jobs {
+			if job.CreateAt < since {
+				return result, nil
+			}
+			if job.Data["user_id"] == userID {
+				result = append(result, job)
+			}
+		}
+
+		if len(jobs) < userDataExportBatchSize {
+			return result, nil
+		}
+	}
+}
+
+// UserDataExportFileReader returns a reader for the file of a successful
+// export of the data of the user along with its name.
+//
+// The caller is responsible for closing the returned ReadCloseSeeker.
+func (a *App) UserDataExportFileReader(rctx request.CTX, userID, jobID string) (filestore.ReadCloseSeeker, string, *model.AppError) {
+	job, appErr := a.GetJob(rctx, jobID)
+	if appErr != nil {
+		return nil, "", appErr
+	}
+
+	filename := job.Data["export_file"]
+	if job.Type != model.JobTypeUserDataExport || job.Data["user_id"] != userID || job.Status != model.JobStatusSuccess || filename == "" {
+		return nil, "", model.NewAppError("UserDataExportFileReader", "app.user_data_export.not_found.app_error", nil, "", http.StatusNotFound)
+	}
+
+	filePath := filepath.Join(*a.Config().ExportSettings.Directory, filename)
+	if ok, appErr := a.ExportFileExists(filePath); appErr != nil {
+		return nil, "", appErr
+	} else if !ok {
+		return nil, "", model.NewAppError("UserDataExportFileReader", "app.user_data_export.not_found.app_error", nil, "", http.StatusNotFound)
+	}
+
+	rd, appErr := a.ExportFileReader(filePath)
+	if appErr != nil {
+		return nil, "", appErr
+	}
+	return rd, filename, nil
+}
+
+// UserDataExport writes a zip archive of the data of the user to writer.
+// Their profile and posts are written to import.jsonl in the bulk import
+// format, replies being exported as standalone posts since the threads they
+// belong to aren't part of the data of the user. Their preferences, reactions,
+// drafts, scheduled posts and the information of their files are written to
+// JSON files, and their files and profile picture are added to the data
+// directory.
+func (a *App) UserDataExport(rctx request.CTX, writer io.Writer, userID string, job *model.Job) *model.AppError {
+	user, appErr := a.GetUser(userID)
+	if appErr != nil {
+		return appErr
+	}
+
+	zipWr := zip.NewWriter(writer)
+	defer func() {
+		if err := zipWr.Close(); err != nil {
+			rctx.Logger().Error("Error closing zip writer", mlog.Err(err))
+		}
+	}()
+
+	if job == nil {
+		job = &model.Job{}
+	}
+	if job.Data == nil {
+		job.Data = make(model.StringMap)
+	}
+
+	importWr, err := zipWr.Create("import.jsonl")
+	if err != nil {
+		return model.NewAppError("UserDataExport", "app.export.zip_create.error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+
+	if appErr = a.exportVersion(importWr, 0); appErr != nil {
+		return appErr
+	}
+
+	cpaFields, appErr := a.exportableCPAFields()
+	if appErr != nil {
+		return appErr
+	}
+	userLine, profilePicture, appErr := a.buildUserLine(rctx, user, cpaFields, true, true)
+	if appErr != nil {
+		return appErr
+	}
+	if appErr = a.exportWriteLine(importWr, userLine); appErr != nil {
+		return appErr
+	}
+
+	if appErr = a.exportUserPosts(rctx, job, importWr, user.Id); appErr != nil {
+		return appErr
+	}
+
+	preferences, appErr := a.GetPreferencesForUser(rctx, user.Id)
+	if appErr != nil {
+		return appErr
+	}
+	if appErr = writeUserDataExportJSON(zipWr, userDataExportPreferencesFilename, preferences); appErr != nil {
+		return appErr
+	}
+
+	reactions, appErr := a.getReactionsForUser(user.Id)
+	if appErr != nil {
+		return appErr
+	}
+	if appErr = writeUserDataExportJSON(zipWr, userDataExportReactionsFilename, reactions); appErr != nil {
+		return appErr
+	}
+
+	drafts, err := a.Srv().Store().Draft().GetDraftsForUser(user.Id, "")
+	if err != nil {
+		return model.NewAppError("UserDataExport", "app.draft.get_drafts.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+	if appErr = writeUserDataExportJSON(zipWr, userDataExportDraftsFilename, drafts); appErr != nil {
+		return appErr
+	}
+
+	scheduledPosts, appErr := a.getScheduledPostsForUser(rctx, user.Id)
+	if appErr != nil {
+		return appErr
+	}
+	if appErr = writeUserDataExportJSON(zipWr, userDataExportScheduledPostsFilename, scheduledPosts); appErr != nil {
+		return appErr
+	}
+
+	files, err := a.Srv().Store().FileInfo().GetForUser(user.Id)
+	if err != nil {
+		return model.NewAppError("UserDataExport", "app.file_info.get.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+	if appErr = writeUserDataExportJSON(zipWr, userDataExportFilesFilename, files); appErr != nil {
+		return appErr
+	}
+
+	var warnings []string
+	exportedFiles := 0
+	for _, file := range files {
+		if appErr := a.exportFile(rctx, "", file.Path, zipWr); appErr != nil {
+			rctx.Logger().Warn("Unable to export file", mlog.String("file_path", file.Path), mlog.Err(appErr))
+			warnings = append(warnings, fmt.Sprintf("Unable to export file, file path: %s , error: %s", file.Path, appErr.Error()))
+		} else {
+			exportedFiles++
+		}
+	}
+	if profilePicture != "" {
+		if appErr := a.exportFile(rctx, "", profilePicture, zipWr); appErr != nil {
+			rctx.Logger().Warn("Unable to export profile picture", mlog.String("profile_picture", profilePicture), mlog.Err(appErr))
+			warnings = append(warnings, fmt.Sprintf("Unable to export profile picture, path: %s , error: %s", profilePicture, appErr.Error()))
+		}
+	}
+	updateJobProgress(rctx.Logger(), a.Srv().Store(), job, "files_exported", exportedFiles)
+
+	if len(warnings) > 0 {
+		warningsFile, err := zipWr.Create(warningsFilename)
+		if err != nil {
+			return model.NewAppError("UserDataExport", "app.export.zip_create.error", nil, "", http.StatusInternalServerError).Wrap(err)
+		}
+		if _, err := warningsFile.Write([]byte(strings.Join(warnings, "\n") + "\n")); err != nil {
+			return model.NewAppError("UserDataExport", "app.export.zip_create.error", nil, "", http.StatusInternalServerError).Wrap(err)
+		}
+		updateJobProgress(rctx.Logger(), a.Srv().Store(), job, "num_warnings", len(warnings))
+	}
+
+	return nil
+}
+
+// exportUserPosts writes a post or direct_post line for each post of the user
+// that wasn't deleted.
+func (a *App) exportUserPosts(rctx request.CTX, job *model.Job, writer io.Writer, userID string) *model.AppError {
+	afterID := strings.Repeat("0", 26)
+	channelMembers := map[string][]string{}
+	cnt := 0
+	for {
+		posts, err := a.Srv().Store().Post().GetForUserExportAfter(userID, userDataExportBatchSize, afterID)
+		if err != nil {
+			return model.NewAppError("exportUserPosts", "app.post.get_posts.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+		}
+
+		if len(posts) == 0 {
+			return nil
+		}
+		cnt += len(posts)
+		updateJobProgress(rctx.Logger(), a.Srv().Store(), job, "posts_exported", cnt)
+
+		for _, post := range posts {
+			afterID = post.Id
+
+			var postLine *imports.LineImportData
+			// Posts in direct channels have no team and are referenced by
+			// the members of their channel instead.
+			if post.TeamName == "" {
+				members, ok := channelMembers[post.ChannelId]
+				if !ok {
+					profiles, err := a.Srv().Store().User().GetAllProfilesInChannel(rctx.Context(), post.ChannelId, false)
+					if err != nil {
+						return model.NewAppError("exportUserPosts", "app.channel.get_members.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+					}
+					for _, profile := range profiles {
+						members = append(members, profile.Username)
+					}
+					slices.Sort(members)
+					channelMembers[post.ChannelId] = members
+				}
+
+				postLine = importLineForDirectPostForExport(post, members)
+			} else {
+				postLine = importLineForPost(post)
+			}
+
+			reactions := &[]imports.ReactionImportData{}
+			var appErr *model.AppError
+			if post.HasReactions {
+				reactions, appErr = a.BuildPostReactions(rctx, post.Id)
+				if appErr != nil {
+					return appErr
+				}
+			}
+
+			var attachments *[]imports.AttachmentImportData
+			if len(post.FileIds) > 0 {
+				postAttachments, appErr := a.buildPostAttachments(post.Id)
+				if appErr != nil {
+					return appErr
+				}
+				attachments = &postAttachments
+			}
+
+			priority, acknowledgements, editHistory, appErr := a.buildPostMetadata(rctx, &post.Post)
+			if appErr != nil {
+				return appErr
+			}
+
+			if postLine.DirectPost != nil {
+				postLine.DirectPost.Reactions = reactions
+				postLine.DirectPost.Attachments = attachments
+				postLine.DirectPost.Priority = priority
+				postLine.DirectPost.Acknowledgements = acknowledgements
+				postLine.DirectPost.EditHistory = editHistory
+			} else {
+				postLine.Post.Reactions = reactions
+				postLine.Post.Attachments = attachments
+				postLine.Post.Priority = priority
+				postLine.Post.Acknowledgements = acknowledgements
+				postLine.Post.EditHistory = editHistory
+			}
+
+			if err := a.exportWriteLine(writer, postLine); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+func (a *App) getReactionsForUser(userID string) ([]*model.Reaction, *model.AppError) {
+	result := []*model.Reaction{}
+	for {
+		reactions, err := a.Srv().Store().Reaction().GetForUser(userID, len(result), userDataExportBatchSize)
+		if err != nil {
+			return nil, model.NewAppError("getReactionsForUser", "app.reaction.get_for_user.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+		}
+		result = append(result, reactions...)
+		if len(reactions) < userDataExportBatchSize {
+			return result, nil
+		}
+	}
+}
+
+// getScheduledPostsForUser returns the scheduled posts of the user in all of
+// their teams and in direct channels.
+func (a *App) getScheduledPostsForUser(rctx request.CTX, userID string) ([]*model.ScheduledPost, *model.AppError) {
+	teams, appErr := a.GetTeamsForUser(userID)
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	teamIDs := []string{""}
+	for _, team := range teams {
+		teamIDs = append(teamIDs, team.Id)
+	}
+
+	result := []*model.ScheduledPost{}
+	for _, teamID := range teamIDs {
+		scheduledPosts, appErr := a.GetUserTeamScheduledPosts(rctx, userID, teamID)
+		if appErr != nil {
+			return nil, appErr
+		}
+		result = append(result, scheduledPosts...)
+	}
+	return result, nil
+}
+
+func writeUserDataExportJSON(zipWr *zip.Writer, filename string, data any) *model.AppError {
+	wr, err := zipWr.Create(filename)
+	if err != nil {
+		return model.NewAppError("UserDataExport", "app.export.zip_create.error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+
+	encoder := json.NewEncoder(wr)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(data); err != nil {
+		return model.NewAppError("UserDataExport", "app.user_data_export.json_encode.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+	return nil
+}
+
+// SendUserDataExportReadyMessage lets the user know through a direct message
+// from the system bot that the export of their data can be downloaded.
+func (a *App) SendUserDataExportReadyMessage(rctx request.CTX, job *model.Job) *model.AppError {
+	user, appErr := a.GetUser(job.Data["user_id"])
+	if appErr != nil {
+		return appErr
+	}
+
+	systemBot, appErr := a.GetSystemBot(rctx)
+	if appErr != nil {
+		return appErr
+	}
+
+	channel, appErr := a.GetOrCreateDirectChannel(rctx, user.Id, systemBot.UserId)
+	if appErr != nil {
+		return appErr
+	}
+
+	T := i18n.GetUserTranslations(user.Locale)
+	post := &model.Post{
+		ChannelId: channel.Id,
+		Message: T("app.user_data_export.ready", map[string]any{
+			"Link": fmt.Sprintf("%s/api/v4/users/%s/data_exports/%s/download", a.GetSiteURL(), user.Id, job.Id),
+			"Days": *a.Config().ExportSettings.RetentionDays,
+		}),
+		Type:   model.PostTypeDefault,
+		UserId: systemBot.UserId,
+	}
+
+	_, appErr = a.CreatePost(rctx, post, channel, model.CreatePostFlags{SetOnline: true})
+	return appErr
+}
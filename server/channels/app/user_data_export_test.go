@@ -0,0 +1,217 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package app
+
+import (
+	"archive/zip"
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/v8/channels/app/imports"
+)
+
+func TestUserDataExport(t *testing.T) {
+	mainHelper.Parallel(t)
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+
+	post := th.CreatePost(th.BasicChannel)
+	reply := th.CreatePost(th.BasicChannel, func(p *model.Post) { p.RootId = post.Id })
+	dm := th.CreateDmChannel(th.BasicUser2)
+	directPost := th.CreatePost(dm)
+
+	// Posts of other users aren't exported.
+	_, appErr := th.App.CreatePost(th.Context, &model.Post{UserId: th.BasicUser2.Id, ChannelId: th.BasicChannel.Id, Message: "other"}, th.BasicChannel, model.CreatePostFlags{})
+	require.Nil(t, appErr)
+
+	_, appErr = th.App.SaveReactionForPost(th.Context, &model.Reaction{UserId: th.BasicUser.Id, PostId: post.Id, EmojiName: "smile"})
+	require.Nil(t, appErr)
+
+	_, appErr = th.App.UpsertDraft(th.Context, &model.Draft{UserId: th.BasicUser.Id, ChannelId: th.BasicChannel.Id, Message: "draft"}, "")
+	require.Nil(t, appErr)
+
+	var buf bytes.Buffer
+	appErr = th.App.UserDataExport(th.Context, &buf, th.BasicUser.Id, nil)
+	require.Nil(t, appErr)
+
+	zipReader, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	require.NoError(t, err)
+
+	files := map[string]*zip.File{}
+	for _, f := range zipReader.File {
+		files[f.Name] = f
+	}
+	for _, name := range []string{"import.jsonl", userDataExportPreferencesFilename, userDataExportReactionsFilename,
+		userDataExportDraftsFilename, userDataExportScheduledPostsFilename, userDataExportFilesFilename} {
+		require.Contains(t, files, name)
+	}
+
+	importFile, err := files["import.jsonl"].Open()
+	require.NoError(t, err)
+	defer importFile.Close()
+
+	var lines []imports.LineImportData
+	scanner := bufio.NewScanner(importFile)
+	for scanner.Scan() {
+		var line imports.LineImportData
+		require.NoError(t, json.Unmarshal(scanner.Bytes(), &line))
+		lines = append(lines, line)
+	}
+	require.NoError(t, scanner.Err())
+
+	require.Len(t, lines, 5)
+	assert.Equal(t, "version", lines[0].Type)
+	assert.Equal(t, "user", lines[1].Type)
+	assert.Equal(t, th.BasicUser.Username, *lines[1].User.Username)
+
+	messages := map[string]string{}
+	for _, line := range lines[2:] {
+		switch line.Type {
+		case "post":
+			assert.Equal(t, th.BasicUser.Username, *line.Post.User)
+			messages[*line.Post.Message] = line.Type
+			if *line.Post.Message == post.Message {
+				require.NotNil(t, line.Post.Reactions)
+				require.Len(t, *line.Post.Reactions, 1)
+				assert.Equal(t, "smile", *(*line.Post.Reactions)[0].EmojiName)
+			}
+		case "direct_post":
+			assert.Equal(t, th.BasicUser.Username, *line.DirectPost.User)
+			assert.ElementsMatch(t, []string{th.BasicUser.Username, th.BasicUser2.Username}, *line.DirectPost.ChannelMembers)
+			messages[*line.DirectPost.Message] = line.Type
+		}
+	}
+	assert.Equal(t, map[string]string{
+		post.Message:       "post",
+		reply.Message:      "post",
+		directPost.Message: "direct_post",
+	}, messages)
+
+	reactionsFile, err := files[userDataExportReactionsFilename].Open()
+	require.NoError(t, err)
+	defer reactionsFile.Close()
+	var reactions []*model.Reaction
+	require.NoError(t, json.NewDecoder(reactionsFile).Decode(&reactions))
+	require.Len(t, reactions, 1)
+	assert.Equal(t, post.Id, reactions[0].PostId)
+
+	draftsFile, err := files[userDataExportDraftsFilename].Open()
+	require.NoError(t, err)
+	defer draftsFile.Close()
+	var drafts []*model.Draft
+	require.NoError(t, json.NewDecoder(draftsFile).Decode(&drafts))
+	require.Len(t, drafts, 1)
+	assert.Equal(t, "draft", drafts[0].Message)
+}
+
+func TestRequestUserDataExport(t *testing.T) {
+	mainHelper.Parallel(t)
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+
+	ctx := th.Context.WithSession(&model.Session{UserId: th.BasicUser.Id})
+
+	t.Run("disabled", func(t *testing.T) {
+		th.App.UpdateConfig(func(cfg *model.Config) { *cfg.ExportSettings.EnableUserDataExport = false })
+		defer th.App.UpdateConfig(func(cfg *model.Config) { *cfg.ExportSettings.EnableUserDataExport = true })
+
+		_, appErr := th.App.RequestUserDataExport(ctx, th.BasicUser.Id, true)
+		require.NotNil(t, appErr)
+		assert.Equal(t, http.StatusNotImplemented, appErr.StatusCode)
+	})
+
+	t.Run("existing job", func(t *testing.T) {
+		job, appErr := th.App.RequestUserDataExport(ctx, th.BasicUser.Id, true)
+		require.Nil(t, appErr)
+		assert.Equal(t, model.JobTypeUserDataExport, job.Type)
+		assert.Equal(t, th.BasicUser.Id, job.Data["user_id"])
+
+		_, appErr = th.App.RequestUserDataExport(ctx, th.BasicUser.Id, false)
+		require.NotNil(t, appErr)
+		assert.Equal(t, "app.user_data_export.job_exists.app_error", appErr.Id)
+
+		jobs, appErr := th.App.GetUserDataExports(ctx, th.BasicUser.Id)
+		require.Nil(t, appErr)
+		require.Len(t, jobs, 1)
+		assert.Equal(t, job.Id, jobs[0].Id)
+	})
+
+	t.Run("rate limited", func(t *testing.T) {
+		_, err := th.App.Srv().Store().Job().Save(&model.Job{
+			Id:       model.NewId(),
+			Type:     model.JobTypeUserDataExport,
+			Status:   model.JobStatusSuccess,
+			CreateAt: model.GetMillis(),
+			Data:     model.StringMap{"user_id": th.BasicUser2.Id},
+		})
+		require.NoError(t, err)
+
+		_, appErr := th.App.RequestUserDataExport(ctx, th.BasicUser2.Id, true)
+		require.NotNil(t, appErr)
+		assert.Equal(t, http.StatusTooManyRequests, appErr.StatusCode)
+
+		th.App.UpdateConfig(func(cfg *model.Config) { *cfg.ExportSettings.UserDataExportIntervalHours = 0 })
+		defer th.App.UpdateConfig(func(cfg *model.Config) { *cfg.ExportSettings.UserDataExportIntervalHours = 24 })
+		_, appErr = th.App.RequestUserDataExport(ctx, th.BasicUser2.Id, true)
+		require.Nil(t, appErr)
+	})
+
+	t.Run("not rate limited", func(t *testing.T) {
+		user := th.CreateUser()
+		_, err := th.App.Srv().Store().Job().Save(&model.Job{
+			Id:       model.NewId(),
+			Type:     model.JobTypeUserDataExport,
+			Status:   model.JobStatusSuccess,
+			CreateAt: model.GetMillis(),
+			Data:     model.StringMap{"user_id": user.Id},
+		})
+		require.NoError(t, err)
+
+		_, appErr := th.App.RequestUserDataExport(ctx, user.Id, false)
+		require.Nil(t, appErr)
+	})
+}
+
+func TestUserDataExportFileReader(t *testing.T) {
+	mainHelper.Parallel(t)
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+
+	filename := model.NewId() + "_user_data_export.zip"
+	job, err := th.App.Srv().Store().Job().Save(&model.Job{
+		Id:       model.NewId(),
+		Type:     model.JobTypeUserDataExport,
+		Status:   model.JobStatusSuccess,
+		CreateAt: model.GetMillis(),
+		Data:     model.StringMap{"user_id": th.BasicUser.Id, "export_file": filename},
+	})
+	require.NoError(t, err)
+
+	_, _, appErr := th.App.UserDataExportFileReader(th.Context, th.BasicUser.Id, job.Id)
+	require.NotNil(t, appErr, "the file doesn't exist yet")
+	assert.Equal(t, http.StatusNotFound, appErr.StatusCode)
+
+	_, appErr = th.App.WriteExportFile(bytes.NewReader([]byte("zip")), *th.App.Config().ExportSettings.Directory+"/"+filename)
+	require.Nil(t, appErr)
+	defer func() {
+		appErr := th.App.RemoveExportFile(*th.App.Config().ExportSettings.Directory + "/" + filename)
+		require.Nil(t, appErr)
+	}()
+
+	rd, name, appErr := th.App.UserDataExportFileReader(th.Context, th.BasicUser.Id, job.Id)
+	require.Nil(t, appErr)
+	defer rd.Close()
+	assert.Equal(t, filename, name)
+
+	_, _, appErr = th.App.UserDataExportFileReader(th.Context, th.BasicUser2.Id, job.Id)
+	require.NotNil(t, appErr, "the export belongs to another user")
+	assert.Equal(t, http.StatusNotFound, appErr.StatusCode)
+}
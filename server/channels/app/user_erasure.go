@@ -0,0 +1,453 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package app
+
+import (
+	"net/http"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/public/shared/mlog"
+	"github.com/mattermost/mattermost/server/public/shared/request"
+	"github.com/mattermost/mattermost/server/public/shared/timezones"
+)
+
+const userErasureBatchSize = 1000
+
+// RequestUserErasure creates a job erasing the user. When dryRun is set, the
+// job only reports what it would erase.
+func (a *App) RequestUserErasure(rctx request.CTX, userID string, dryRun bool) (*model.Job, *model.AppError) {
+	user, appErr := a.GetUser(userID)
+	if appErr != nil {
+		return nil, appErr
+	}
+	if user.IsBot {
+		return nil, model.NewAppError("RequestUserErasure", "app.user_erasure.bot.app_error", nil, "", http.StatusBadRequest)
+	}
+
+	for _, status := range []string{model.JobStatusPending, model.JobStatusInProgress} {
+		jobs, appErr := a.Srv().Jobs.GetJobsByTypeAndStatus(rctx, model.JobTypeUserErasure, status)
+		if appErr != nil {
+			return nil, appErr
+		}
+		for _, job := range jobs {
+			if job.Data[model.UserErasureJobDataUserId] == userID {
+				return nil, model.NewAppError("RequestUserErasure", "app.user_erasure.job_exists.app_error", nil, "", http.StatusBadRequest)
+			}
+		}
+	}
+
+	data := map[string]string{
+		model.UserErasureJobDataUserId: userID,
+		model.UserErasureJobDataDryRun: strconv.FormatBool(dryRun),
+		"requesting_user_id":           rctx.Session().UserId,
+	}
+	if !dryRun {
+		username, appErr := a.userErasureUsername(rctx, userID)
+		if appErr != nil {
+			return nil, appErr
+		}
+		data[model.UserErasureJobDataUsername] = username
+	}
+
+	return a.Srv().Jobs.CreateJob(rctx, model.JobTypeUserErasure, data)
+}
+
+// userErasureUsername returns the username of the tombstone account of the
+// user. A failed erasure may have already replaced some mentions of the user,
+// so the username of the failed job is reused for all mentions to be replaced
+// with the same one.
+func (a *App) userErasureUsername(rctx request.CTX, userID string) (string, *model.AppError) {
+	jobs, appErr := a.Srv().Jobs.GetJobsByTypeAndStatus(rctx, model.JobTypeUserErasure, model.JobStatusError)
+	if appErr != nil {
+		return "", appErr
+	}
+	for _, job := range jobs {
+		if job.Data[model.UserErasureJobDataUserId] == userID && job.Data[model.UserErasureJobDataUsername] != "" {
+			return job.Data[model.UserErasureJobDataUsername], nil
+		}
+	}
+
+	return model.ErasedUsername(), nil
+}
+
+// EraseUser replaces the user with a pseudonymous tombstone account. The
+// account keeps its id for the posts of the user to remain in their
+// conversations, but loses everything identifying the user: profile
+// fields, custom profile attributes, credentials, sessions, preferences,
+// drafts, scheduled posts, unattached files, profile image, data exports,
+// audit data, mentions of the username and search index entries.
+//
+// The tombstone account is named username, which the caller keeps across
+// retries for the mentions replaced by a failed erasure to match the ones
+// replaced by the retry. When dryRun is set, username is ignored, nothing is
+// changed and the returned report lists what would have been erased.
+func (a *App) EraseUser(rctx request.CTX, userID, username string, dryRun bool) (*model.UserErasureReport, *model.AppError) {
+	user, appErr := a.GetUser(userID)
+	if appErr != nil {
+		return nil, appErr
+	}
+	if user.IsBot {
+		return nil, model.NewAppError("EraseUser", "app.user_erasure.bot.app_error", nil, "", http.StatusBadRequest)
+	}
+
+	report := &model.UserErasureReport{
+		UserId:        userID,
+		DryRun:        dryRun,
+		SearchIndexes: []string{},
+		Warnings:      []string{},
+	}
+	if !dryRun {
+		if !strings.HasPrefix(username, model.UserErasureUsernamePrefix) {
+			return nil, model.NewAppError("EraseUser", "app.user_erasure.invalid_username.app_error", nil, "", http.StatusBadRequest)
+		}
+		report.Username = username
+	}
+
+	if appErr := a.eraseUserAccountData(rctx, user, report); appErr != nil {
+		return nil, appErr
+	}
+
+	if appErr := a.eraseUserFiles(rctx, user, report); appErr != nil {
+		return nil, appErr
+	}
+
+	if appErr := a.eraseUserAuditData(user, report); appErr != nil {
+		return nil, appErr
+	}
+
+	if appErr := a.eraseUserMentions(rctx, user, report); appErr != nil {
+		return nil, appErr
+	}
+
+	if dryRun {
+		return report, nil
+	}
+
+	if appErr := a.pseudonymizeUser(rctx, user, report.Username); appErr != nil {
+		return nil, appErr
+	}
+
+	// Updating the user indexes the tombstone account, which no longer
+	// identifies the user, before it's removed from the indexes here.
+	for _, engine := range a.SearchEngine().GetActiveEngines() {
+		if !engine.IsIndexingEnabled() {
+			continue
+		}
+		if appErr := engine.DeleteUser(user); appErr != nil {
+			report.Warnings = append(report.Warnings, "Unable to remove the user from the "+engine.GetName()+" index: "+appErr.Error())
+			continue
+		}
+		report.SearchIndexes = append(report.SearchIndexes, engine.GetName())
+	}
+
+	rctx.Logger().Info("Erased user", mlog.String("user_id", userID))
+
+	return report, nil
+}
+
+// eraseUserAccountData erases the sessions, credentials, preferences, drafts,
+// scheduled posts and custom profile attributes of the user.
+func (a *App) eraseUserAccountData(rctx request.CTX, user *model.User, report *model.UserErasureReport) *model.AppError {
+	sessions, err := a.Srv().Store().Session().GetSessions(rctx, user.Id)
+	if err != nil {
+		return model.NewAppError("EraseUser", "app.session.get_sessions.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+	report.Sessions = len(sessions)
+
+	for page := 0; ; page++ {
+		tokens, err := a.Srv().Store().UserAccessToken().GetByUser(user.Id, page*userErasureBatchSize, userErasureBatchSize)
+		if err != nil {
+			return model.NewAppError("EraseUser", "app.user_access_token.get_by_user.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+		}
+		report.AccessTokens += len(tokens)
+		if len(tokens) < userErasureBatchSize {
+			break
+		}
+	}
+
+	preferences, err := a.Srv().Store().Preference().GetAll(user.Id)
+	if err != nil {
+		return model.NewAppError("EraseUser", "app.preference.get_all.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+	report.Preferences = len(preferences)
+
+	drafts, err := a.Srv().Store().Draft().GetDraftsForUser(user.Id, "")
+	if err != nil {
+		return model.NewAppError("EraseUser", "app.draft.get_drafts.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+	report.Drafts = len(drafts)
+
+	scheduledPosts, appErr := a.getScheduledPostsForUser(rctx, user.Id)
+	if appErr != nil {
+		return appErr
+	}
+	report.ScheduledPosts = len(scheduledPosts)
+
+	values, appErr := a.ListCPAValues(user.Id)
+	if appErr != nil {
+		return appErr
+	}
+	report.CustomProfileAttributes = len(values)
+
+	if report.DryRun {
+		return nil
+	}
+
+	if appErr := a.RevokeAllSessions(rctx, user.Id); appErr != nil {
+		return appErr
+	}
+
+	if err := a.Srv().Store().UserAccessToken().DeleteAllForUser(user.Id); err != nil {
+		return model.NewAppError("EraseUser", "app.user_access_token.delete.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+
+	if err := a.Srv().Store().OAuth().PermanentDeleteAuthDataByUser(user.Id); err != nil {
+		return model.NewAppError("EraseUser", "app.oauth.permanent_delete_auth_data_by_user.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+
+	if err := a.Srv().Store().Preference().PermanentDeleteByUser(user.Id); err != nil {
+		return model.NewAppError("EraseUser", "app.preference.permanent_delete_by_user.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+
+	if err := a.Srv().Store().Draft().PermanentDeleteByUser(user.Id); err != nil {
+		return model.NewAppError("EraseUser", "app.drafts.permanent_delete_by_user.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+
+	if err := a.Srv().Store().ScheduledPost().PermanentDeleteByUser(user.Id); err != nil {
+		return model.NewAppError("EraseUser", "app.scheduled_post.permanent_delete_by_user.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+
+	return a.DeleteCPAValues(user.Id)
+}
+
+// eraseUserFiles erases the files the user uploaded without attaching them to
+// a post, the profile image of the user and the exports of their data. The
+// files attached to posts remain in their conversations, owned by the
+// tombstone account.
+func (a *App) eraseUserFiles(rctx request.CTX, user *model.User, report *model.UserErasureReport) *model.AppError {
+	infos, err := a.Srv().Store().FileInfo().GetForUser(user.Id)
+	if err != nil {
+		return model.NewAppError("EraseUser", "app.user_erasure.get_data.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+
+	unattached := []*model.FileInfo{}
+	for _, info := range infos {
+		if info.PostId == "" {
+			unattached = append(unattached, info)
+		}
+	}
+	report.Files = len(unattached)
+
+	profileImageExists, appErr := a.FileExists(getProfileImagePath(user.Id))
+	if appErr != nil {
+		report.Warnings = append(report.Warnings, "Unable to check the existence of the profile image: "+appErr.Error())
+	}
+	report.ProfileImage = profileImageExists
+
+	exportJobs, appErr := a.getUserDataExportJobs(rctx, user.Id, 0)
+	if appErr != nil {
+		return appErr
+	}
+	exportPaths := []string{}
+	for _, job := range exportJobs {
+		if job.Data["export_file"] == "" {
+			continue
+		}
+		exportPath := filepath.Join(*a.Config().ExportSettings.Directory, job.Data["export_file"])
+		exists, appErr := a.ExportFileExists(exportPath)
+		if appErr != nil {
+			report.Warnings = append(report.Warnings, "Unable to check the existence of the data export "+job.Id+": "+appErr.Error())
+			continue
+		}
+		if exists {
+			exportPaths = append(exportPaths, exportPath)
+		}
+	}
+	report.DataExports = len(exportPaths)
+
+	if report.DryRun {
+		return nil
+	}
+
+	a.RemoveFilesFromFileStore(rctx, unattached)
+	for _, info := range unattached {
+		// Deleting the files through the store removes them from the search
+		// indexes too.
+		if err := a.Srv().Store().FileInfo().PermanentDelete(rctx, info.Id); err != nil {
+			return model.NewAppError("EraseUser", "app.file_info.permanent_delete_by_user.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+		}
+	}
+
+	if profileImageExists {
+		if appErr := a.RemoveDirectory(getProfileImageDirectory(user.Id)); appErr != nil {
+			report.Warnings = append(report.Warnings, "Unable to remove the profile image: "+appErr.Error())
+		}
+	}
+	if err := a.Srv().Store().User().ResetLastPictureUpdate(user.Id); err != nil {
+		return model.NewAppError("EraseUser", "app.user_erasure.erase_data.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+
+	for _, exportPath := range exportPaths {
+		if appErr := a.RemoveExportFile(exportPath); appErr != nil {
+			report.Warnings = append(report.Warnings, "Unable to remove the data export "+filepath.Base(exportPath)+": "+appErr.Error())
+		}
+	}
+
+	return nil
+}
+
+// eraseUserAuditData removes the legacy audits of the user and scrubs the
+// audit records about the user.
+func (a *App) eraseUserAuditData(user *model.User, report *model.UserErasureReport) *model.AppError {
+	for offset := 0; ; offset += userErasureBatchSize {
+		audits, err := a.Srv().Store().Audit().Get(user.Id, offset, userErasureBatchSize)
+		if err != nil {
+			return model.NewAppError("EraseUser", "app.audit.get.finding.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+		}
+		report.Audits += len(audits)
+		if len(audits) < userErasureBatchSize {
+			break
+		}
+	}
+
+	count, err := a.Srv().Store().AuditRecord().GetCountForUser(user.Id)
+	if err != nil {
+		return model.NewAppError("EraseUser", "app.user_erasure.get_data.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+	report.AuditRecords = count
+
+	if report.DryRun {
+		return nil
+	}
+
+	if err := a.Srv().Store().Audit().PermanentDeleteByUser(user.Id); err != nil {
+		return model.NewAppError("EraseUser", "app.audit.permanent_delete_by_user.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+
+	if _, err := a.Srv().Store().AuditRecord().ScrubForUser(user.Id); err != nil {
+		return model.NewAppError("EraseUser", "app.user_erasure.erase_data.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+
+	return nil
+}
+
+// eraseUserMentions replaces the mentions of the username of the user with
+// the username of the tombstone account, including in deleted posts and in
+// the previous versions of edited posts.
+func (a *App) eraseUserMentions(rctx request.CTX, user *model.User, report *model.UserErasureReport) *model.AppError {
+	mentionRegexp := regexp.MustCompile(`(?i)@` + regexp.QuoteMeta(user.Username))
+	channelIDs := map[string]bool{}
+
+	afterID := strings.Repeat("0", 26)
+	for {
+		posts, err := a.Srv().Store().Post().GetPostsMentioningUsernameAfter(user.Username, userErasureBatchSize, afterID)
+		if err != nil {
+			return model.NewAppError("EraseUser", "app.user_erasure.get_data.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+		}
+
+		for _, post := range posts {
+			message, replaced := replaceUsernameMentions(post.Message, mentionRegexp, report.Username)
+			if !replaced {
+				// The username is a prefix of the mentioned username.
+				continue
+			}
+			report.Mentions++
+
+			if report.DryRun {
+				continue
+			}
+
+			post.Message = message
+			for key, value := range post.GetProps() {
+				if s, ok := value.(string); ok && strings.EqualFold(s, user.Username) {
+					post.AddProp(key, report.Username)
+				}
+			}
+			if _, err := a.Srv().Store().Post().Overwrite(rctx, post); err != nil {
+				return model.NewAppError("EraseUser", "app.user_erasure.erase_data.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+			}
+			channelIDs[post.ChannelId] = true
+		}
+
+		if len(posts) < userErasureBatchSize {
+			break
+		}
+		afterID = posts[len(posts)-1].Id
+	}
+
+	for channelID := range channelIDs {
+		a.invalidateCacheForChannelPosts(channelID)
+	}
+
+	return nil
+}
+
+// replaceUsernameMentions replaces the matches of mentionRegexp that are
+// full mentions, i.e. that aren't followed by characters making them the
+// mention of another username, with a mention of replacement.
+func replaceUsernameMentions(message string, mentionRegexp *regexp.Regexp, replacement string) (string, bool) {
+	isUsernameChar := func(c byte) bool {
+		return (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z') || (c >= '0' && c <= '9') || c == '_' || c == '-'
+	}
+
+	var sb strings.Builder
+	replaced := false
+	last := 0
+	for _, loc := range mentionRegexp.FindAllStringIndex(message, -1) {
+		rest := message[loc[1]:]
+		// A trailing period ends the sentence rather than the username.
+		if len(rest) > 0 && (isUsernameChar(rest[0]) || (rest[0] == '.' && len(rest) > 1 && (isUsernameChar(rest[1]) || rest[1] == '.'))) {
+			continue
+		}
+		sb.WriteString(message[last:loc[0]])
+		sb.WriteString("@" + replacement)
+		last = loc[1]
+		replaced = true
+	}
+	sb.WriteString(message[last:])
+
+	return sb.String(), replaced
+}
+
+// pseudonymizeUser replaces the profile of the user with the one of a
+// deactivated tombstone account named username.
+func (a *App) pseudonymizeUser(rctx request.CTX, user *model.User, username string) *model.AppError {
+	user.Username = username
+	user.Email = username + "@localhost"
+	user.Nickname = ""
+	user.FirstName = ""
+	user.LastName = ""
+	user.Position = ""
+	user.Props = model.StringMap{}
+	user.SetDefaultNotifications()
+	user.Timezone = timezones.DefaultUserTimezone()
+	user.AllowMarketing = false
+	user.RemoteId = nil
+
+	if _, err := a.Srv().Store().User().Update(rctx, user, true); err != nil {
+		return model.NewAppError("EraseUser", "app.user_erasure.update_user.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+
+	// Resetting the authentication data clears the password and the MFA
+	// secret of the user too.
+	if _, err := a.Srv().Store().User().UpdateAuthData(user.Id, "", nil, "", true); err != nil {
+		return model.NewAppError("EraseUser", "app.user_erasure.update_user.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+
+	a.InvalidateCacheForUser(user.Id)
+
+	if user.DeleteAt == 0 {
+		if _, appErr := a.UpdateActive(rctx, user, false); appErr != nil {
+			return appErr
+		}
+	} else {
+		a.sendUpdatedUserEvent(user)
+	}
+
+	return nil
+}
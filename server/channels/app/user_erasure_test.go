@@ -0,0 +1,200 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package app
+
+import (
+	"net/http"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost/server/public/model"
+)
+
+func TestReplaceUsernameMentions(t *testing.T) {
+	mentionRegexp := regexp.MustCompile(`(?i)@` + regexp.QuoteMeta("alice.b"))
+
+	for name, tc := range map[string]struct {
+		message  string
+		expected string
+		replaced bool
+	}{
+		"mention":               {"hi @alice.b", "hi @erased", true},
+		"case-insensitive":      {"hi @Alice.B!", "hi @erased!", true},
+		"trailing period":       {"thanks @alice.b.", "thanks @erased.", true},
+		"several mentions":      {"@alice.b @alice.b,@alice.b", "@erased @erased,@erased", true},
+		"longer username":       {"hi @alice.bob and @alice.b_c", "hi @alice.bob and @alice.b_c", false},
+		"username with period":  {"hi @alice.b.c", "hi @alice.b.c", false},
+		"mixed":                 {"@alice.bob, @alice.b", "@alice.bob, @erased", true},
+		"no mention":            {"alice.b", "alice.b", false},
+		"followed by a mention": {"@alice.b@bob", "@erased@bob", true},
+	} {
+		t.Run(name, func(t *testing.T) {
+			message, replaced := replaceUsernameMentions(tc.message, mentionRegexp, "erased")
+			assert.Equal(t, tc.expected, message)
+			assert.Equal(t, tc.replaced, replaced)
+		})
+	}
+}
+
+func TestEraseUser(t *testing.T) {
+	mainHelper.Parallel(t)
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+
+	user := th.CreateUser()
+	th.LinkUserToTeam(user, th.BasicTeam)
+	th.AddUserToChannel(user, th.BasicChannel)
+
+	ownPost, appErr := th.App.CreatePost(th.Context, &model.Post{UserId: user.Id, ChannelId: th.BasicChannel.Id, Message: "my own post"}, th.BasicChannel, model.CreatePostFlags{})
+	require.Nil(t, appErr)
+	mention := th.CreatePost(th.BasicChannel, func(p *model.Post) { p.Message = "hello @" + user.Username + "." })
+
+	err := th.App.Srv().Store().Preference().Save(model.Preferences{{UserId: user.Id, Category: model.PreferenceCategoryDisplaySettings, Name: "name", Value: "value"}})
+	require.NoError(t, err)
+	// Creating the user and joining the team already saved some preferences.
+	preferences, err := th.App.Srv().Store().Preference().GetAll(user.Id)
+	require.NoError(t, err)
+
+	_, appErr = th.App.UpsertDraft(th.Context, &model.Draft{UserId: user.Id, ChannelId: th.BasicChannel.Id, Message: "draft"}, "")
+	require.Nil(t, appErr)
+
+	file, err := th.App.Srv().Store().FileInfo().Save(th.Context, &model.FileInfo{
+		Id:        model.NewId(),
+		CreatorId: user.Id,
+		Path:      "data/" + model.NewId() + "/file.txt",
+		Name:      "file.txt",
+	})
+	require.NoError(t, err)
+
+	_, err = th.App.Srv().Store().AuditRecord().SaveMultiple([]*model.StoredAuditRecord{
+		model.NewStoredAuditRecord("audit-api", model.AuditRecord{
+			EventName: model.AuditEventLogin,
+			Status:    model.AuditStatusSuccess,
+			Actor:     model.AuditEventActor{UserId: user.Id, SessionId: model.NewId(), IpAddress: "127.0.0.1"},
+			EventData: model.AuditEventData{Parameters: map[string]any{"login_id": user.Email}},
+		}),
+	})
+	require.NoError(t, err)
+
+	t.Run("dry run", func(t *testing.T) {
+		report, appErr := th.App.EraseUser(th.Context, user.Id, "", true)
+		require.Nil(t, appErr)
+		assert.True(t, report.DryRun)
+		assert.Empty(t, report.Username)
+		assert.Equal(t, len(preferences), report.Preferences)
+		assert.Equal(t, 1, report.Drafts)
+		assert.Equal(t, 1, report.Files)
+		assert.Equal(t, int64(1), report.AuditRecords)
+		assert.Equal(t, 1, report.Mentions)
+
+		erased, appErr := th.App.GetUser(user.Id)
+		require.Nil(t, appErr)
+		assert.Equal(t, user.Username, erased.Username)
+		assert.Zero(t, erased.DeleteAt)
+
+		post, appErr := th.App.GetSinglePost(th.Context, mention.Id, false)
+		require.Nil(t, appErr)
+		assert.Equal(t, mention.Message, post.Message)
+	})
+
+	t.Run("invalid username", func(t *testing.T) {
+		_, appErr := th.App.EraseUser(th.Context, user.Id, "username", false)
+		require.NotNil(t, appErr)
+		assert.Equal(t, "app.user_erasure.invalid_username.app_error", appErr.Id)
+	})
+
+	t.Run("erasure", func(t *testing.T) {
+		username := model.ErasedUsername()
+		report, appErr := th.App.EraseUser(th.Context, user.Id, username, false)
+		require.Nil(t, appErr)
+		assert.False(t, report.DryRun)
+		assert.Equal(t, username, report.Username)
+		assert.Equal(t, 1, report.Mentions)
+
+		erased, appErr := th.App.GetUser(user.Id)
+		require.Nil(t, appErr)
+		assert.Equal(t, report.Username, erased.Username)
+		assert.Equal(t, report.Username+"@localhost", erased.Email)
+		assert.Empty(t, erased.FirstName)
+		assert.Empty(t, erased.LastName)
+		assert.Empty(t, erased.Nickname)
+		assert.Empty(t, erased.Password)
+		assert.NotZero(t, erased.DeleteAt)
+
+		post, appErr := th.App.GetSinglePost(th.Context, mention.Id, false)
+		require.Nil(t, appErr)
+		assert.Equal(t, "hello @"+report.Username+".", post.Message)
+
+		// The posts of the user remain, authored by the tombstone account.
+		post, appErr = th.App.GetSinglePost(th.Context, ownPost.Id, false)
+		require.Nil(t, appErr)
+		assert.Equal(t, user.Id, post.UserId)
+
+		preferences, err := th.App.Srv().Store().Preference().GetAll(user.Id)
+		require.NoError(t, err)
+		assert.Empty(t, preferences)
+
+		drafts, err := th.App.Srv().Store().Draft().GetDraftsForUser(user.Id, "")
+		require.NoError(t, err)
+		assert.Empty(t, drafts)
+
+		_, err = th.App.Srv().Store().FileInfo().Get(file.Id)
+		require.Error(t, err)
+
+		records, err := th.App.Srv().Store().AuditRecord().Search(model.AuditRecordSearchOptions{UserId: user.Id, PerPage: 10})
+		require.NoError(t, err)
+		require.Len(t, records, 1)
+		assert.Empty(t, records[0].IpAddress)
+		assert.Nil(t, records[0].Record.EventData.Parameters)
+	})
+
+	t.Run("bot", func(t *testing.T) {
+		bot := th.CreateBot()
+		_, appErr := th.App.EraseUser(th.Context, bot.UserId, "", true)
+		require.NotNil(t, appErr)
+		assert.Equal(t, http.StatusBadRequest, appErr.StatusCode)
+	})
+}
+
+func TestRequestUserErasure(t *testing.T) {
+	mainHelper.Parallel(t)
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+
+	ctx := th.Context.WithSession(&model.Session{UserId: th.SystemAdminUser.Id})
+
+	job, appErr := th.App.RequestUserErasure(ctx, th.BasicUser.Id, true)
+	require.Nil(t, appErr)
+	assert.Equal(t, model.JobTypeUserErasure, job.Type)
+	assert.Equal(t, th.BasicUser.Id, job.Data[model.UserErasureJobDataUserId])
+	assert.Equal(t, "true", job.Data[model.UserErasureJobDataDryRun])
+
+	assert.Empty(t, job.Data[model.UserErasureJobDataUsername])
+
+	_, appErr = th.App.RequestUserErasure(ctx, th.BasicUser.Id, false)
+	require.NotNil(t, appErr)
+	assert.Equal(t, "app.user_erasure.job_exists.app_error", appErr.Id)
+
+	// A retry of a failed erasure reuses the username of the failed job.
+	_, err := th.App.Srv().Store().Job().UpdateStatus(job.Id, model.JobStatusError)
+	require.NoError(t, err)
+	job, appErr = th.App.RequestUserErasure(ctx, th.BasicUser.Id, false)
+	require.Nil(t, appErr)
+	username := job.Data[model.UserErasureJobDataUsername]
+	assert.True(t, strings.HasPrefix(username, model.UserErasureUsernamePrefix))
+
+	_, err = th.App.Srv().Store().Job().UpdateStatus(job.Id, model.JobStatusError)
+	require.NoError(t, err)
+	job, appErr = th.App.RequestUserErasure(ctx, th.BasicUser.Id, false)
+	require.Nil(t, appErr)
+	assert.Equal(t, username, job.Data[model.UserErasureJobDataUsername])
+
+	_, appErr = th.App.RequestUserErasure(ctx, model.NewId(), false)
+	require.NotNil(t, appErr)
+	assert.Equal(t, http.StatusNotFound, appErr.StatusCode)
+}
@@ -4,6 +4,8 @@
 package app
 
 import (
+	"net/http"
+
 	"github.com/mattermost/mattermost/server/public/model"
 	"github.com/mattermost/mattermost/server/v8/channels/app/platform"
 )
@@ -53,3 +55,13 @@ func (a *App) UpdateWebConnUserActivity(session model.Session, activityAt int64)
 func (a *App) SessionIsRegistered(session model.Session) bool {
 	return a.Srv().Platform().SessionIsRegistered(session)
 }
+
+// InspectWebConnsForUser returns the diagnostic details of all the websocket
+// connections of the given user across the cluster.
+func (a *App) InspectWebConnsForUser(userID string) ([]*model.WebConnInfo, *model.AppError) {
+	infos, err := a.Srv().Platform().InspectWebConnsForUser(userID)
+	if err != nil {
+		return nil, model.NewAppError("InspectWebConnsForUser", "app.web_conn.inspect.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+	return infos, nil
+}
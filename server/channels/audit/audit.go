@@ -21,6 +21,8 @@ type Audit struct {
 
 	// OnError is called when an error occurs while writing an audit record.
 	OnError func(err error)
+
+	db *databaseTarget
 }
 
 func (a *Audit) Init(maxQueueSize int) {
@@ -30,6 +32,7 @@ func (a *Audit) Init(maxQueueSize int) {
 		mlog.OnQueueFull(a.onQueueFull),
 		mlog.OnTargetQueueFull(a.onTargetQueueFull),
 	)
+	a.db = &databaseTarget{}
 }
 
 // LogRecord emits an audit record with complete info.
@@ -44,15 +47,26 @@ func (a *Audit) LogRecord(level mlog.Level, rec model.AuditRecord) {
 	}
 
 	a.logger.Log(level, "", flds...)
+
+	a.db.enqueue(model.NewStoredAuditRecord(level.Name, rec), a.onDatabaseQueueFull)
 }
 
 // Configure sets zero or more target to output audit logs to.
+// In addition to the built-in mlog targets, the hash chain target type is supported.
 func (a *Audit) Configure(cfg mlog.LoggerConfiguration) error {
-	return a.logger.ConfigureTargets(cfg, nil)
+	return a.logger.ConfigureTargets(cfg, &mlog.Factories{TargetFactory: hashChainTargetFactory})
+}
+
+// ConfigureDatabase sets where audit records are saved so that they can be
+// searched, in addition to the targets. A nil saver stops saving records.
+func (a *Audit) ConfigureDatabase(saver RecordSaver, maxQueueSize int) {
+	a.db.configure(saver, maxQueueSize, a.onLoggerError)
 }
 
 // Flush attempts to write all queued audit records to all targets.
 func (a *Audit) Flush() error {
+	a.db.flush()
+
 	err := a.logger.Flush()
 	if err != nil {
 		a.onLoggerError(err)
@@ -62,6 +76,8 @@ func (a *Audit) Flush() error {
 
 // Shutdown cleanly stops the audit engine after making best efforts to flush all targets.
 func (a *Audit) Shutdown() error {
+	a.db.configure(nil, 0, nil)
+
 	err := a.logger.Shutdown()
 	if err != nil {
 		a.onLoggerError(err)
@@ -85,6 +101,14 @@ func (a *Audit) onTargetQueueFull(target mlog.Target, rec *mlog.LogRec, maxQueue
 	return true
 }
 
+func (a *Audit) onDatabaseQueueFull(qname string, maxQueueSize int) bool {
+	if a.OnQueueFull != nil {
+		return a.OnQueueFull(qname, maxQueueSize)
+	}
+	mlog.Error("Audit logging queue full for database, dropping record.", mlog.Int("queueSize", maxQueueSize))
+	return true
+}
+
 func (a *Audit) onLoggerError(err error) {
 	if a.OnError != nil {
 		a.OnError(err)
@@ -0,0 +1,181 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package audit
+
+import (
+	"sync"
+	"time"
+
+	"github.com/mattermost/mattermost/server/public/model"
+)
+
+const (
+	databaseQueueName     = "database"
+	databaseBatchSize     = 100
+	databaseFlushInterval = time.Second
+)
+
+// RecordSaver persists audit records, typically store.AuditRecordStore.
+type RecordSaver interface {
+	SaveMultiple(records []*model.StoredAuditRecord) ([]error, error)
+}
+
+// databaseTarget holds the database sink of an Audit, which is replaced
+// when the audit is reconfigured. A nil target saves nothing.
+type databaseTarget struct {
+	mux  sync.RWMutex
+	sink *databaseSink
+}
+
+func (t *databaseTarget) configure(saver RecordSaver, maxQueueSize int, onError func(err error)) {
+	if t == nil {
+		return
+	}
+
+	t.mux.Lock()
+	defer t.mux.Unlock()
+
+	if t.sink != nil {
+		t.sink.Shutdown()
+		t.sink = nil
+	}
+	if saver != nil {
+		t.sink = newDatabaseSink(saver, maxQueueSize, onError)
+	}
+}
+
+func (t *databaseTarget) enqueue(rec *model.StoredAuditRecord, onQueueFull func(qname string, maxQueueSize int) bool) {
+	if t == nil {
+		return
+	}
+
+	t.mux.RLock()
+	defer t.mux.RUnlock()
+	if t.sink != nil {
+		t.sink.enqueue(rec, onQueueFull)
+	}
+}
+
+func (t *databaseTarget) flush() {
+	if t == nil {
+		return
+	}
+
+	t.mux.RLock()
+	defer t.mux.RUnlock()
+	if t.sink != nil {
+		t.sink.Flush()
+	}
+}
+
+// databaseSink saves the audit records in batches from its own goroutine,
+// so that logging a record never waits on the database.
+type databaseSink struct {
+	saver   RecordSaver
+	queue   chan *model.StoredAuditRecord
+	flush   chan chan struct{}
+	stop    chan struct{}
+	stopped chan struct{}
+	onError func(err error)
+}
+
+func newDatabaseSink(saver RecordSaver, maxQueueSize int, onError func(err error)) *databaseSink {
+	s := &databaseSink{
+		saver:   saver,
+		queue:   make(chan *model.StoredAuditRecord, maxQueueSize),
+		flush:   make(chan chan struct{}),
+		stop:    make(chan struct{}),
+		stopped: make(chan struct{}),
+		onError: onError,
+	}
+	go s.run()
+	return s
+}
+
+func (s *databaseSink) run() {
+	defer close(s.stopped)
+
+	ticker := time.NewTicker(databaseFlushInterval)
+	defer ticker.Stop()
+
+	batch := make([]*model.StoredAuditRecord, 0, databaseBatchSize)
+	save := func() {
+		if len(batch) == 0 {
+			return
+		}
+		itemErrs, err := s.saver.SaveMultiple(batch)
+		for _, itemErr := range itemErrs {
+			if itemErr != nil {
+				s.onError(itemErr)
+			}
+		}
+		if err != nil {
+			s.onError(err)
+		}
+		batch = make([]*model.StoredAuditRecord, 0, databaseBatchSize)
+	}
+	drain := func() {
+		for {
+			select {
+			case rec := <-s.queue:
+				batch = append(batch, rec)
+				if len(batch) >= databaseBatchSize {
+					save()
+				}
+			default:
+				save()
+				return
+			}
+		}
+	}
+
+	for {
+		select {
+		case rec := <-s.queue:
+			batch = append(batch, rec)
+			if len(batch) >= databaseBatchSize {
+				save()
+			}
+		case <-ticker.C:
+			save()
+		case done := <-s.flush:
+			drain()
+			close(done)
+		case <-s.stop:
+			drain()
+			return
+		}
+	}
+}
+
+// enqueue adds a record to the queue. If the queue is full, onQueueFull
+// decides whether the record is dropped or the caller blocks.
+func (s *databaseSink) enqueue(rec *model.StoredAuditRecord, onQueueFull func(qname string, maxQueueSize int) bool) {
+	select {
+	case s.queue <- rec:
+		return
+	default:
+	}
+
+	if onQueueFull(databaseQueueName, cap(s.queue)) {
+		return
+	}
+	s.queue <- rec
+}
+
+// Flush saves the records queued so far.
+func (s *databaseSink) Flush() {
+	done := make(chan struct{})
+	select {
+	case s.flush <- done:
+		<-done
+	case <-s.stopped:
+	}
+}
+
+// Shutdown saves the queued records and stops the sink.
+func (s *databaseSink) Shutdown() {
+	close(s.stop)
+	<-s.stopped
+}
@@ -0,0 +1,140 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package audit
+
+import (
+	"errors"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/public/shared/mlog"
+)
+
+type testRecordSaver struct {
+	mux     sync.Mutex
+	records []*model.StoredAuditRecord
+	batches int
+	err     error
+}
+
+func (s *testRecordSaver) SaveMultiple(records []*model.StoredAuditRecord) ([]error, error) {
+	s.mux.Lock()
+	defer s.mux.Unlock()
+	s.batches++
+	if s.err != nil {
+		return nil, s.err
+	}
+
+	var itemErrs []error
+	for i, record := range records {
+		if record.EventName == "" {
+			if itemErrs == nil {
+				itemErrs = make([]error, len(records))
+			}
+			itemErrs[i] = errors.New("missing event name")
+			continue
+		}
+		s.records = append(s.records, record)
+	}
+	return itemErrs, nil
+}
+
+func (s *testRecordSaver) saved() []*model.StoredAuditRecord {
+	s.mux.Lock()
+	defer s.mux.Unlock()
+	return s.records
+}
+
+func TestAuditDatabase(t *testing.T) {
+	newAudit := func(t *testing.T, saver RecordSaver) *Audit {
+		audit := &Audit{}
+		audit.Init(DefMaxQueueSize)
+		audit.ConfigureDatabase(saver, DefMaxQueueSize)
+		return audit
+	}
+
+	t.Run("records are saved on flush", func(t *testing.T) {
+		saver := &testRecordSaver{}
+		audit := newAudit(t, saver)
+		defer audit.Shutdown()
+
+		userID := model.NewId()
+		rec := model.AuditRecord{
+			EventName: model.AuditEventUpdateUser,
+			Status:    model.AuditStatusSuccess,
+			Actor:     model.AuditEventActor{UserId: userID, IpAddress: "10.0.0.1"},
+			EventData: model.AuditEventData{ObjectType: "user", ResultState: map[string]any{"id": userID}},
+		}
+		audit.LogRecord(mlog.LvlAuditAPI, rec)
+		require.NoError(t, audit.Flush())
+
+		saved := saver.saved()
+		require.Len(t, saved, 1)
+		assert.Equal(t, "audit-api", saved[0].Level)
+		assert.Equal(t, model.AuditEventUpdateUser, saved[0].EventName)
+		assert.Equal(t, userID, saved[0].UserId)
+		assert.Equal(t, userID, saved[0].ObjectId)
+		assert.Equal(t, "10.0.0.1", saved[0].IpAddress)
+	})
+
+	t.Run("records are batched and saved on shutdown", func(t *testing.T) {
+		saver := &testRecordSaver{}
+		audit := newAudit(t, saver)
+
+		for range databaseBatchSize*2 + 1 {
+			audit.LogRecord(mlog.LvlAuditAPI, model.AuditRecord{EventName: model.AuditEventLogin})
+		}
+		require.NoError(t, audit.Shutdown())
+
+		assert.Len(t, saver.saved(), databaseBatchSize*2+1)
+		assert.LessOrEqual(t, saver.batches, 3)
+	})
+
+	t.Run("errors are reported", func(t *testing.T) {
+		saver := &testRecordSaver{err: errors.New("database unavailable")}
+		var reported []error
+		audit := &Audit{OnError: func(err error) { reported = append(reported, err) }}
+		audit.Init(DefMaxQueueSize)
+		audit.ConfigureDatabase(saver, DefMaxQueueSize)
+
+		audit.LogRecord(mlog.LvlAuditAPI, model.AuditRecord{EventName: model.AuditEventLogin})
+		audit.ConfigureDatabase(nil, 0)
+
+		require.Len(t, reported, 1)
+		assert.EqualError(t, reported[0], "database unavailable")
+		require.NoError(t, audit.Shutdown())
+	})
+
+	t.Run("invalid records are reported without failing the batch", func(t *testing.T) {
+		saver := &testRecordSaver{}
+		var reported []error
+		audit := &Audit{OnError: func(err error) { reported = append(reported, err) }}
+		audit.Init(DefMaxQueueSize)
+		audit.ConfigureDatabase(saver, DefMaxQueueSize)
+
+		audit.LogRecord(mlog.LvlAuditAPI, model.AuditRecord{EventName: model.AuditEventLogin})
+		audit.LogRecord(mlog.LvlAuditAPI, model.AuditRecord{})
+		audit.ConfigureDatabase(nil, 0)
+
+		require.Len(t, reported, 1)
+		assert.EqualError(t, reported[0], "missing event name")
+		require.Len(t, saver.saved(), 1)
+		assert.Equal(t, model.AuditEventLogin, saver.saved()[0].EventName)
+		require.NoError(t, audit.Shutdown())
+	})
+
+	t.Run("nothing is saved once disabled", func(t *testing.T) {
+		saver := &testRecordSaver{}
+		audit := newAudit(t, saver)
+		audit.ConfigureDatabase(nil, 0)
+
+		audit.LogRecord(mlog.LvlAuditAPI, model.AuditRecord{EventName: model.AuditEventLogin})
+		require.NoError(t, audit.Shutdown())
+		assert.Empty(t, saver.saved())
+	})
+}
@@ -0,0 +1,321 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package audit
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/ed25519"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/public/shared/mlog"
+)
+
+// HashChainTargetType is the mlog target type of the hash chain target.
+const HashChainTargetType = "hashchain"
+
+// hashChainGenesis is the previous hash of the first entry of a chain.
+var hashChainGenesis = hex.EncodeToString(make([]byte, sha256.Size))
+
+// HashChainOptions are the options of the hash chain target.
+type HashChainOptions struct {
+	// Filename is the file the chain is appended to. It is never rotated
+	// since rotation would break the chain.
+	Filename string `json:"filename"`
+
+	// SigningKeyFile is a PEM encoded PKCS #8 Ed25519 private key used to
+	// sign the checkpoints.
+	SigningKeyFile string `json:"signing_key_file"`
+
+	// CheckpointInterval is the number of records written between two
+	// signed checkpoints.
+	CheckpointInterval int `json:"checkpoint_interval"`
+}
+
+func (o HashChainOptions) IsValid() error {
+	if o.Filename == "" {
+		return errors.New("filename cannot be empty")
+	}
+	if o.SigningKeyFile == "" {
+		return errors.New("signing key file cannot be empty")
+	}
+	if o.CheckpointInterval <= 0 {
+		return errors.New("checkpoint interval must be greater than zero")
+	}
+	return nil
+}
+
+// HashChainEntry is a single line of a hash chain file. Every entry holds
+// either an audit record or a signed checkpoint of the chain so far, and
+// is linked to the previous one through PrevHash.
+type HashChainEntry struct {
+	Seq        int64                `json:"seq"`
+	PrevHash   string               `json:"prev_hash"`
+	Hash       string               `json:"hash"`
+	Record     json.RawMessage      `json:"record,omitempty"`
+	Checkpoint *HashChainCheckpoint `json:"checkpoint,omitempty"`
+}
+
+// HashChainCheckpoint is a signature of the chain up to the entry it's part of.
+type HashChainCheckpoint struct {
+	CreateAt  int64  `json:"create_at"`
+	Signature string `json:"signature"`
+}
+
+// digest computes the hash of the entry, which covers the previous hash
+// and therefore the whole chain before it.
+func (e *HashChainEntry) digest() string {
+	h := sha256.New()
+	h.Write([]byte(strconv.FormatInt(e.Seq, 10) + "\n" + e.PrevHash + "\n"))
+	if e.Checkpoint != nil {
+		h.Write([]byte("checkpoint\n" + strconv.FormatInt(e.Checkpoint.CreateAt, 10) + "\n" + e.Checkpoint.Signature))
+	} else {
+		h.Write([]byte("record\n"))
+		h.Write(e.Record)
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// checkpointPayload returns the bytes signed by a checkpoint.
+func checkpointPayload(seq int64, prevHash string, createAt int64) []byte {
+	return []byte(strconv.FormatInt(seq, 10) + "\n" + prevHash + "\n" + strconv.FormatInt(createAt, 10))
+}
+
+// HashChainTarget is an mlog target which appends every audit record to a
+// tamper-evident file. Each entry includes the hash of the previous one, so
+// editing, removing or reordering records breaks the chain. A checkpoint
+// signed with the configured key is added every CheckpointInterval records
+// and on shutdown, which prevents the chain from being silently recomputed
+// or truncated.
+type HashChainTarget struct {
+	opts HashChainOptions
+	key  ed25519.PrivateKey
+
+	file     *os.File
+	seq      int64
+	lastHash string
+	// pending is the number of records written since the last checkpoint.
+	pending int
+}
+
+// NewHashChainTarget creates a hash chain target. The chain file is
+// opened when the target is initialized.
+func NewHashChainTarget(opts HashChainOptions) (*HashChainTarget, error) {
+	if err := opts.IsValid(); err != nil {
+		return nil, err
+	}
+
+	key, err := readSigningKey(opts.SigningKeyFile)
+	if err != nil {
+		return nil, err
+	}
+
+	return &HashChainTarget{
+		opts: opts,
+		key:  key,
+	}, nil
+}
+
+// Init opens the chain file and restores the head of the chain from it,
+// so that records keep being chained across restarts.
+func (t *HashChainTarget) Init() error {
+	file, err := os.OpenFile(t.opts.Filename, os.O_RDWR|os.O_CREATE|os.O_APPEND, 0600)
+	if err != nil {
+		return fmt.Errorf("failed to open hash chain file: %w", err)
+	}
+
+	t.seq = 0
+	t.lastHash = hashChainGenesis
+	t.pending = 0
+
+	truncated, err := t.restore(file)
+	if err != nil {
+		file.Close()
+		return fmt.Errorf("failed to read hash chain file %s: %w", t.opts.Filename, err)
+	}
+
+	// A partially written entry is left in place for the verification
+	// to report, but it must not be glued to the next one.
+	if truncated {
+		if _, err := file.Write([]byte("\n")); err != nil {
+			file.Close()
+			return fmt.Errorf("failed to write to hash chain file: %w", err)
+		}
+	}
+
+	t.file = file
+	return nil
+}
+
+func (t *HashChainTarget) restore(r io.Reader) (truncated bool, err error) {
+	reader := bufio.NewReader(r)
+	for {
+		line, err := reader.ReadBytes('\n')
+		if err != nil && err != io.EOF {
+			return false, err
+		}
+		if len(bytes.TrimSpace(line)) > 0 {
+			var entry HashChainEntry
+			if jsonErr := json.Unmarshal(line, &entry); jsonErr == nil {
+				t.seq = entry.Seq
+				t.lastHash = entry.Hash
+				if entry.Checkpoint != nil {
+					t.pending = 0
+				} else {
+					t.pending++
+				}
+			}
+		}
+		if err == io.EOF {
+			return len(line) > 0, nil
+		}
+	}
+}
+
+// Write appends a record to the chain. p is the record as formatted by
+// the target's formatter, which must produce JSON.
+func (t *HashChainTarget) Write(p []byte, rec *mlog.LogRec) (int, error) {
+	// The record is hashed exactly as it's stored in the file.
+	var record bytes.Buffer
+	if err := json.Compact(&record, p); err != nil {
+		return 0, fmt.Errorf("hash chain target requires the json format: %w", err)
+	}
+
+	if err := t.append(&HashChainEntry{Record: json.RawMessage(record.Bytes())}); err != nil {
+		return 0, err
+	}
+	t.pending++
+
+	if t.pending >= t.opts.CheckpointInterval {
+		if err := t.checkpoint(); err != nil {
+			return 0, err
+		}
+	}
+
+	return len(p), nil
+}
+
+// Shutdown signs the end of the chain and closes the file.
+func (t *HashChainTarget) Shutdown() error {
+	if t.file == nil {
+		return nil
+	}
+
+	var err error
+	if t.pending > 0 {
+		err = t.checkpoint()
+	}
+	if closeErr := t.file.Close(); closeErr != nil && err == nil {
+		err = closeErr
+	}
+	t.file = nil
+	return err
+}
+
+func (t *HashChainTarget) checkpoint() error {
+	seq := t.seq + 1
+	createAt := model.GetMillis()
+	sig := ed25519.Sign(t.key, checkpointPayload(seq, t.lastHash, createAt))
+
+	err := t.append(&HashChainEntry{
+		Checkpoint: &HashChainCheckpoint{
+			CreateAt:  createAt,
+			Signature: base64.StdEncoding.EncodeToString(sig),
+		},
+	})
+	if err != nil {
+		return err
+	}
+	t.pending = 0
+	return nil
+}
+
+func (t *HashChainTarget) append(entry *HashChainEntry) error {
+	entry.Seq = t.seq + 1
+	entry.PrevHash = t.lastHash
+	entry.Hash = entry.digest()
+
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	// Escaping would alter the record and invalidate its hash.
+	enc.SetEscapeHTML(false)
+	if err := enc.Encode(entry); err != nil {
+		return fmt.Errorf("failed to marshal hash chain entry: %w", err)
+	}
+
+	// The entry is written with a single call so that a crash can't
+	// interleave it with the next one.
+	if _, err := t.file.Write(buf.Bytes()); err != nil {
+		return fmt.Errorf("failed to write hash chain entry: %w", err)
+	}
+
+	t.seq = entry.Seq
+	t.lastHash = entry.Hash
+	return nil
+}
+
+// hashChainTargetFactory creates the targets of types not built into mlog.
+func hashChainTargetFactory(targetType string, options json.RawMessage) (mlog.Target, error) {
+	if targetType != HashChainTargetType {
+		return nil, fmt.Errorf("target type '%s' is unrecognized", targetType)
+	}
+
+	var opts HashChainOptions
+	if err := json.Unmarshal(options, &opts); err != nil {
+		return nil, fmt.Errorf("invalid options for hash chain target: %w", err)
+	}
+	return NewHashChainTarget(opts)
+}
+
+// NewHashChainTargetCfg returns the configuration of a hash chain target
+// receiving all the audit levels.
+func NewHashChainTargetCfg(opts HashChainOptions, maxQueueSize int) (mlog.TargetCfg, error) {
+	options, err := json.Marshal(opts)
+	if err != nil {
+		return mlog.TargetCfg{}, fmt.Errorf("cannot encode hash chain options: %w", err)
+	}
+
+	return mlog.TargetCfg{
+		Type:          HashChainTargetType,
+		Format:        "json",
+		FormatOptions: json.RawMessage(`{"disable_timestamp": false, "disable_msg": true, "disable_stacktrace": true, "disable_level": true}`),
+		Levels:        []mlog.Level{mlog.LvlAuditAPI, mlog.LvlAuditContent, mlog.LvlAuditPerms, mlog.LvlAuditCLI},
+		Options:       options,
+		MaxQueueSize:  maxQueueSize,
+	}, nil
+}
+
+func readSigningKey(filename string) (ed25519.PrivateKey, error) {
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read hash chain signing key: %w", err)
+	}
+
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, errors.New("hash chain signing key is not PEM encoded")
+	}
+
+	key, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse hash chain signing key: %w", err)
+	}
+
+	edKey, ok := key.(ed25519.PrivateKey)
+	if !ok {
+		return nil, errors.New("hash chain signing key is not an Ed25519 key")
+	}
+	return edKey, nil
+}
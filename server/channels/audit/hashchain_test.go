@@ -0,0 +1,186 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package audit
+
+import (
+	"bytes"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/public/shared/mlog"
+)
+
+func writeHashChainKeys(t *testing.T, dir string) (string, ed25519.PublicKey) {
+	t.Helper()
+
+	pub, priv, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+
+	der, err := x509.MarshalPKCS8PrivateKey(priv)
+	require.NoError(t, err)
+
+	keyFile := filepath.Join(dir, "audit_chain.key")
+	err = os.WriteFile(keyFile, pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der}), 0600)
+	require.NoError(t, err)
+
+	return keyFile, pub
+}
+
+func logHashChainRecords(t *testing.T, opts HashChainOptions, count int) {
+	t.Helper()
+
+	targetCfg, err := NewHashChainTargetCfg(opts, DefMaxQueueSize)
+	require.NoError(t, err)
+
+	audit := Audit{}
+	audit.Init(DefMaxQueueSize)
+	err = audit.Configure(mlog.LoggerConfiguration{"hashchain": targetCfg})
+	require.NoError(t, err)
+
+	for i := range count {
+		rec := model.AuditRecord{EventName: "updateUser", Status: model.AuditStatusSuccess, Meta: map[string]any{}}
+		rec.AddMeta("index", i)
+		rec.AddMeta("html", "<b>escaped</b> & kept")
+		audit.LogRecord(mlog.LvlAuditAPI, rec)
+	}
+
+	require.NoError(t, audit.Shutdown())
+}
+
+func TestHashChainTarget(t *testing.T) {
+	dir := t.TempDir()
+	keyFile, pub := writeHashChainKeys(t, dir)
+	opts := HashChainOptions{
+		Filename:           filepath.Join(dir, "audit_chain.log"),
+		SigningKeyFile:     keyFile,
+		CheckpointInterval: 3,
+	}
+
+	logHashChainRecords(t, opts, 7)
+
+	t.Run("valid chain", func(t *testing.T) {
+		f, err := os.Open(opts.Filename)
+		require.NoError(t, err)
+		defer f.Close()
+
+		report, err := VerifyHashChain(f, pub)
+		require.NoError(t, err)
+		assert.True(t, report.IsValid(), report.Issues)
+		assert.Equal(t, int64(7), report.Records)
+		// Two periodic checkpoints and one on shutdown.
+		assert.Equal(t, int64(3), report.Checkpoints)
+		assert.Equal(t, int64(0), report.UnsignedRecords)
+		assert.Equal(t, int64(10), report.LastSeq)
+	})
+
+	t.Run("chain continues after restart", func(t *testing.T) {
+		logHashChainRecords(t, opts, 2)
+
+		data, err := os.ReadFile(opts.Filename)
+		require.NoError(t, err)
+
+		report, err := VerifyHashChain(bytes.NewReader(data), pub)
+		require.NoError(t, err)
+		assert.True(t, report.IsValid(), report.Issues)
+		assert.Equal(t, int64(9), report.Records)
+		assert.Equal(t, int64(4), report.Checkpoints)
+	})
+
+	lines := func(t *testing.T) []string {
+		data, err := os.ReadFile(opts.Filename)
+		require.NoError(t, err)
+		return strings.Split(strings.TrimSpace(string(data)), "\n")
+	}
+
+	verify := func(t *testing.T, lines []string) *HashChainReport {
+		report, err := VerifyHashChain(strings.NewReader(strings.Join(lines, "\n")+"\n"), pub)
+		require.NoError(t, err)
+		return report
+	}
+
+	t.Run("edited record", func(t *testing.T) {
+		l := lines(t)
+		l[1] = strings.Replace(l[1], "updateUser", "deleteUser", 1)
+
+		report := verify(t, l)
+		require.Len(t, report.Issues, 1)
+		assert.Equal(t, 2, report.Issues[0].Line)
+		assert.Contains(t, report.Issues[0].Message, "hash does not match")
+	})
+
+	t.Run("removed record", func(t *testing.T) {
+		l := lines(t)
+		l = append(l[:1], l[2:]...)
+
+		report := verify(t, l)
+		require.Len(t, report.Issues, 2)
+		assert.Contains(t, report.Issues[0].Message, "expected sequence 2")
+		assert.Contains(t, report.Issues[1].Message, "previous hash does not match")
+	})
+
+	t.Run("rewritten chain", func(t *testing.T) {
+		l := lines(t)
+
+		// Recomputing the hashes after an edit keeps the chain consistent,
+		// but not the signatures of the checkpoints.
+		prevHash := hashChainGenesis
+		for i, line := range l {
+			var entry HashChainEntry
+			require.NoError(t, json.Unmarshal([]byte(line), &entry))
+			if i == 1 {
+				entry.Record = json.RawMessage(strings.Replace(string(entry.Record), "updateUser", "deleteUser", 1))
+			}
+			entry.PrevHash = prevHash
+			entry.Hash = entry.digest()
+			prevHash = entry.Hash
+
+			b, err := json.Marshal(entry)
+			require.NoError(t, err)
+			l[i] = string(b)
+		}
+
+		report := verify(t, l)
+		require.NotEmpty(t, report.Issues)
+		for _, issue := range report.Issues {
+			assert.Equal(t, "invalid checkpoint signature", issue.Message)
+		}
+	})
+
+	t.Run("malformed entry", func(t *testing.T) {
+		l := lines(t)
+		l[2] = l[2][:len(l[2])/2]
+
+		report := verify(t, l)
+		require.Len(t, report.Issues, 1)
+		assert.Equal(t, 3, report.Issues[0].Line)
+		assert.Contains(t, report.Issues[0].Message, "malformed entry")
+	})
+
+	t.Run("wrong public key", func(t *testing.T) {
+		otherPub, _, err := ed25519.GenerateKey(rand.Reader)
+		require.NoError(t, err)
+
+		report, err := VerifyHashChain(strings.NewReader(strings.Join(lines(t), "\n")), otherPub)
+		require.NoError(t, err)
+		require.Len(t, report.Issues, 4)
+	})
+}
+
+func TestHashChainOptionsIsValid(t *testing.T) {
+	require.NoError(t, HashChainOptions{Filename: "audit.log", SigningKeyFile: "audit.key", CheckpointInterval: 1}.IsValid())
+	require.Error(t, HashChainOptions{SigningKeyFile: "audit.key", CheckpointInterval: 1}.IsValid())
+	require.Error(t, HashChainOptions{Filename: "audit.log", CheckpointInterval: 1}.IsValid())
+	require.Error(t, HashChainOptions{Filename: "audit.log", SigningKeyFile: "audit.key"}.IsValid())
+}
@@ -0,0 +1,142 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package audit
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/ed25519"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"io"
+)
+
+// HashChainIssue is an inconsistency found while verifying a hash chain.
+type HashChainIssue struct {
+	Line    int    `json:"line"`
+	Seq     int64  `json:"seq"`
+	Message string `json:"message"`
+}
+
+// HashChainReport is the result of the verification of a hash chain.
+type HashChainReport struct {
+	Records          int64            `json:"records"`
+	Checkpoints      int64            `json:"checkpoints"`
+	LastSeq          int64            `json:"last_seq"`
+	LastCheckpointAt int64            `json:"last_checkpoint_at"`
+	UnsignedRecords  int64            `json:"unsigned_records"`
+	Issues           []HashChainIssue `json:"issues"`
+}
+
+// IsValid returns true if no tampering or gap was detected.
+func (r *HashChainReport) IsValid() bool {
+	return len(r.Issues) == 0
+}
+
+func (r *HashChainReport) addIssue(line int, seq int64, format string, args ...any) {
+	r.Issues = append(r.Issues, HashChainIssue{
+		Line:    line,
+		Seq:     seq,
+		Message: fmt.Sprintf(format, args...),
+	})
+}
+
+// VerifyHashChain reads a file written by the hash chain target and checks
+// that no entry was modified, removed, inserted or reordered, and that every
+// checkpoint was signed by the key matching publicKey.
+//
+// Records written after the last checkpoint can still be truncated without
+// being detected, so they're reported as UnsignedRecords.
+func VerifyHashChain(r io.Reader, publicKey ed25519.PublicKey) (*HashChainReport, error) {
+	report := &HashChainReport{Issues: []HashChainIssue{}}
+
+	expectedSeq := int64(1)
+	lastHash := hashChainGenesis
+	// resync is set after a malformed line, since there's nothing
+	// reliable to link the next entry to.
+	resync := false
+
+	reader := bufio.NewReader(r)
+	for lineNum := 1; ; lineNum++ {
+		line, err := reader.ReadBytes('\n')
+		if err != nil && err != io.EOF {
+			return nil, fmt.Errorf("failed to read hash chain: %w", err)
+		}
+
+		if len(bytes.TrimSpace(line)) > 0 {
+			var entry HashChainEntry
+			if jsonErr := json.Unmarshal(line, &entry); jsonErr != nil {
+				report.addIssue(lineNum, expectedSeq, "malformed entry: %s", jsonErr)
+				resync = true
+			} else {
+				if !resync {
+					if entry.Seq != expectedSeq {
+						report.addIssue(lineNum, entry.Seq, "expected sequence %d, entries are missing or out of order", expectedSeq)
+					}
+					if entry.PrevHash != lastHash {
+						report.addIssue(lineNum, entry.Seq, "previous hash does not match the preceding entry")
+					}
+				}
+				if entry.digest() != entry.Hash {
+					report.addIssue(lineNum, entry.Seq, "hash does not match the content of the entry")
+				}
+
+				if entry.Checkpoint != nil {
+					if !verifyCheckpoint(&entry, publicKey) {
+						report.addIssue(lineNum, entry.Seq, "invalid checkpoint signature")
+					}
+					report.Checkpoints++
+					report.LastCheckpointAt = entry.Checkpoint.CreateAt
+					report.UnsignedRecords = 0
+				} else {
+					report.Records++
+					report.UnsignedRecords++
+				}
+
+				report.LastSeq = entry.Seq
+				expectedSeq = entry.Seq + 1
+				lastHash = entry.Hash
+				resync = false
+			}
+		}
+
+		if err == io.EOF {
+			break
+		}
+	}
+
+	return report, nil
+}
+
+func verifyCheckpoint(entry *HashChainEntry, publicKey ed25519.PublicKey) bool {
+	sig, err := base64.StdEncoding.DecodeString(entry.Checkpoint.Signature)
+	if err != nil {
+		return false
+	}
+	return ed25519.Verify(publicKey, checkpointPayload(entry.Seq, entry.PrevHash, entry.Checkpoint.CreateAt), sig)
+}
+
+// ParseHashChainPublicKey parses a PEM encoded PKIX Ed25519 public key, as
+// produced by `openssl pkey -pubout`.
+func ParseHashChainPublicKey(data []byte) (ed25519.PublicKey, error) {
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, errors.New("public key is not PEM encoded")
+	}
+
+	key, err := x509.ParsePKIXPublicKey(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse public key: %w", err)
+	}
+
+	edKey, ok := key.(ed25519.PublicKey)
+	if !ok {
+		return nil, errors.New("public key is not an Ed25519 key")
+	}
+	return edKey, nil
+}
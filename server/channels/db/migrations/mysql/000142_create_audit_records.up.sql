@@ -0,0 +1,18 @@
+CREATE TABLE IF NOT EXISTS AuditRecords (
+    Id varchar(26) PRIMARY KEY,
+    CreateAt bigint(20) NOT NULL,
+    Level varchar(64) NOT NULL,
+    EventName varchar(128) NOT NULL,
+    Status varchar(32) NOT NULL,
+    UserId varchar(128) NOT NULL,
+    SessionId varchar(26) NOT NULL,
+    IpAddress varchar(64) NOT NULL,
+    ObjectType varchar(64) NOT NULL,
+    ObjectId varchar(64) NOT NULL,
+    Data json NOT NULL,
+    INDEX idx_auditrecords_create_at (CreateAt),
+    INDEX idx_auditrecords_user_id_create_at (UserId, CreateAt),
+    INDEX idx_auditrecords_event_name_create_at (EventName, CreateAt),
+    INDEX idx_auditrecords_object_id_create_at (ObjectId, CreateAt),
+    INDEX idx_auditrecords_ip_address_create_at (IpAddress, CreateAt)
+);
@@ -0,0 +1,16 @@
+DELETE FROM Jobs WHERE CHAR_LENGTH(Type) > 32;
+
+SET @preparedStatement = (SELECT IF(
+    (
+        SELECT COUNT(*) FROM INFORMATION_SCHEMA.COLUMNS
+        WHERE table_name = 'Jobs'
+        AND table_schema = DATABASE()
+        AND column_name = 'Type'
+        AND column_type != 'varchar(32)'
+    ) > 0,
+    'ALTER TABLE Jobs MODIFY COLUMN Type varchar(32) DEFAULT NULL;',
+    'SELECT 1'
+));
+PREPARE alterIfExists FROM @preparedStatement;
+EXECUTE alterIfExists;
+DEALLOCATE PREPARE alterIfExists;
@@ -0,0 +1,14 @@
+SET @preparedStatement = (SELECT IF(
+    (
+        SELECT COUNT(*) FROM INFORMATION_SCHEMA.COLUMNS
+        WHERE table_name = 'Jobs'
+        AND table_schema = DATABASE()
+        AND column_name = 'Type'
+        AND column_type != 'varchar(255)'
+    ) > 0,
+    'ALTER TABLE Jobs MODIFY COLUMN Type varchar(255) DEFAULT NULL;',
+    'SELECT 1'
+));
+PREPARE alterIfExists FROM @preparedStatement;
+EXECUTE alterIfExists;
+DEALLOCATE PREPARE alterIfExists;
@@ -0,0 +1,19 @@
+CREATE TABLE IF NOT EXISTS AuditRecords (
+    Id varchar(26) PRIMARY KEY,
+    CreateAt bigint NOT NULL,
+    Level varchar(64) NOT NULL,
+    EventName varchar(128) NOT NULL,
+    Status varchar(32) NOT NULL,
+    UserId varchar(128) NOT NULL,
+    SessionId varchar(26) NOT NULL,
+    IpAddress varchar(64) NOT NULL,
+    ObjectType varchar(64) NOT NULL,
+    ObjectId varchar(64) NOT NULL,
+    Data jsonb NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_auditrecords_create_at ON AuditRecords (CreateAt);
+CREATE INDEX IF NOT EXISTS idx_auditrecords_user_id_create_at ON AuditRecords (UserId, CreateAt);
+CREATE INDEX IF NOT EXISTS idx_auditrecords_event_name_create_at ON AuditRecords (EventName, CreateAt);
+CREATE INDEX IF NOT EXISTS idx_auditrecords_object_id_create_at ON AuditRecords (ObjectId, CreateAt);
+CREATE INDEX IF NOT EXISTS idx_auditrecords_ip_address_create_at ON AuditRecords (IpAddress, CreateAt);
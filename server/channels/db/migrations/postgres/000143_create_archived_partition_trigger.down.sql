@@ -0,0 +1 @@
+DROP FUNCTION IF EXISTS reject_archived_partition_writes() CASCADE;
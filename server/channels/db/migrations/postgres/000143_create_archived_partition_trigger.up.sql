@@ -0,0 +1,7 @@
+CREATE OR REPLACE FUNCTION reject_archived_partition_writes() RETURNS trigger AS
+$$
+BEGIN
+  RAISE EXCEPTION 'archived partition %.% is read-only', TG_TABLE_SCHEMA, TG_TABLE_NAME;
+END;
+$$
+LANGUAGE plpgsql;
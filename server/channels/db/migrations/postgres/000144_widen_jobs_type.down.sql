@@ -0,0 +1,2 @@
+DELETE FROM jobs WHERE length(type) > 32;
+ALTER TABLE jobs ALTER COLUMN type TYPE VARCHAR(32);
@@ -0,0 +1 @@
+ALTER TABLE jobs ALTER COLUMN type TYPE VARCHAR(255);
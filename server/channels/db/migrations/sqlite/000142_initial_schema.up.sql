@@ -0,0 +1,1125 @@
+-- SQLite databases start from the schema of version 142 instead of replaying
+-- the history of the other drivers. Later migrations are numbered the same
+-- way for every driver. SQLite ignores the length of VARCHAR columns, the
+-- CHECK constraints enforce it the way Postgres does.
+
+CREATE TABLE IF NOT EXISTS teams (
+    id VARCHAR(26) CHECK (length(id) <= 26),
+    createat BIGINT,
+    updateat BIGINT,
+    deleteat BIGINT,
+    displayname VARCHAR(64) CHECK (length(displayname) <= 64),
+    name VARCHAR(64) CHECK (length(name) <= 64),
+    description VARCHAR(255) CHECK (length(description) <= 255),
+    email VARCHAR(128) CHECK (length(email) <= 128),
+    type VARCHAR(255) CHECK (length(type) <= 255),
+    companyname VARCHAR(64) CHECK (length(companyname) <= 64),
+    alloweddomains VARCHAR(1000) CHECK (length(alloweddomains) <= 1000),
+    inviteid VARCHAR(32) CHECK (length(inviteid) <= 32),
+    schemeid VARCHAR(26) CHECK (length(schemeid) <= 26),
+    allowopeninvite BOOLEAN,
+    lastteamiconupdate BIGINT,
+    groupconstrained BOOLEAN,
+    cloudlimitsarchived BOOLEAN NOT NULL DEFAULT false,
+    PRIMARY KEY (id),
+    UNIQUE (name)
+);
+CREATE INDEX IF NOT EXISTS idx_teams_invite_id ON teams (inviteid);
+CREATE INDEX IF NOT EXISTS idx_teams_update_at ON teams (updateat);
+CREATE INDEX IF NOT EXISTS idx_teams_create_at ON teams (createat);
+CREATE INDEX IF NOT EXISTS idx_teams_delete_at ON teams (deleteat);
+CREATE INDEX IF NOT EXISTS idx_teams_scheme_id ON teams (schemeid);
+
+CREATE TABLE IF NOT EXISTS teammembers (
+    teamid VARCHAR(26) NOT NULL CHECK (length(teamid) <= 26),
+    userid VARCHAR(26) NOT NULL CHECK (length(userid) <= 26),
+    roles VARCHAR(256) CHECK (length(roles) <= 256),
+    deleteat BIGINT,
+    schemeuser BOOLEAN,
+    schemeadmin BOOLEAN,
+    schemeguest BOOLEAN,
+    createat BIGINT DEFAULT 0,
+    PRIMARY KEY (teamid, userid)
+);
+CREATE INDEX IF NOT EXISTS idx_teammembers_user_id ON teammembers (userid);
+CREATE INDEX IF NOT EXISTS idx_teammembers_delete_at ON teammembers (deleteat);
+CREATE INDEX IF NOT EXISTS idx_teammembers_createat ON teammembers (createat);
+
+CREATE TABLE IF NOT EXISTS clusterdiscovery (
+    id VARCHAR(26) CHECK (length(id) <= 26),
+    type VARCHAR(64) CHECK (length(type) <= 64),
+    clustername VARCHAR(64) CHECK (length(clustername) <= 64),
+    hostname VARCHAR(512) CHECK (length(hostname) <= 512),
+    gossipport INTEGER,
+    port INTEGER,
+    createat BIGINT,
+    lastpingat BIGINT,
+    PRIMARY KEY (id)
+);
+
+CREATE TABLE IF NOT EXISTS commandwebhooks (
+    id VARCHAR(26) CHECK (length(id) <= 26),
+    createat BIGINT,
+    commandid VARCHAR(26) CHECK (length(commandid) <= 26),
+    userid VARCHAR(26) CHECK (length(userid) <= 26),
+    channelid VARCHAR(26) CHECK (length(channelid) <= 26),
+    rootid VARCHAR(26) CHECK (length(rootid) <= 26),
+    usecount INTEGER,
+    PRIMARY KEY (id)
+);
+CREATE INDEX IF NOT EXISTS idx_command_webhook_create_at ON commandwebhooks (createat);
+
+CREATE TABLE IF NOT EXISTS compliances (
+    id VARCHAR(26) NOT NULL CHECK (length(id) <= 26),
+    createat BIGINT,
+    userid VARCHAR(26) CHECK (length(userid) <= 26),
+    status VARCHAR(64) CHECK (length(status) <= 64),
+    count INTEGER,
+    desc VARCHAR(512) CHECK (length(desc) <= 512),
+    type VARCHAR(64) CHECK (length(type) <= 64),
+    startat BIGINT,
+    endat BIGINT,
+    keywords VARCHAR(512) CHECK (length(keywords) <= 512),
+    emails VARCHAR(1024) CHECK (length(emails) <= 1024),
+    PRIMARY KEY (id)
+);
+
+CREATE TABLE IF NOT EXISTS emoji (
+    id VARCHAR(26) CHECK (length(id) <= 26),
+    createat BIGINT,
+    updateat BIGINT,
+    deleteat BIGINT,
+    creatorid VARCHAR(26) CHECK (length(creatorid) <= 26),
+    name VARCHAR(64) CHECK (length(name) <= 64),
+    PRIMARY KEY (id),
+    UNIQUE (name, deleteat)
+);
+CREATE INDEX IF NOT EXISTS idx_emoji_update_at ON emoji (updateat);
+CREATE INDEX IF NOT EXISTS idx_emoji_create_at ON emoji (createat);
+CREATE INDEX IF NOT EXISTS idx_emoji_delete_at ON emoji (deleteat);
+
+CREATE TABLE IF NOT EXISTS usergroups (
+    id VARCHAR(26) CHECK (length(id) <= 26),
+    name VARCHAR(64) CHECK (length(name) <= 64),
+    displayname VARCHAR(128) CHECK (length(displayname) <= 128),
+    description VARCHAR(1024) CHECK (length(description) <= 1024),
+    source VARCHAR(64) CHECK (length(source) <= 64),
+    remoteid VARCHAR(48) CHECK (length(remoteid) <= 48),
+    createat BIGINT,
+    updateat BIGINT,
+    deleteat BIGINT,
+    allowreference BOOLEAN,
+    PRIMARY KEY (id),
+    UNIQUE (name),
+    UNIQUE (source, remoteid)
+);
+CREATE INDEX IF NOT EXISTS idx_usergroups_remote_id ON usergroups (remoteid);
+CREATE INDEX IF NOT EXISTS idx_usergroups_delete_at ON usergroups (deleteat);
+CREATE INDEX IF NOT EXISTS idx_usergroups_displayname ON usergroups (displayname);
+
+CREATE TABLE IF NOT EXISTS groupmembers (
+    groupid VARCHAR(26) CHECK (length(groupid) <= 26),
+    userid VARCHAR(26) CHECK (length(userid) <= 26),
+    createat BIGINT,
+    deleteat BIGINT,
+    PRIMARY KEY (groupid, userid)
+);
+CREATE INDEX IF NOT EXISTS idx_groupmembers_create_at ON groupmembers (createat);
+
+CREATE TABLE IF NOT EXISTS groupteams (
+    groupid VARCHAR(26) CHECK (length(groupid) <= 26),
+    autoadd BOOLEAN,
+    schemeadmin BOOLEAN,
+    createat BIGINT,
+    deleteat BIGINT,
+    updateat BIGINT,
+    teamid VARCHAR(26) CHECK (length(teamid) <= 26),
+    PRIMARY KEY (groupid, teamid)
+);
+CREATE INDEX IF NOT EXISTS idx_groupteams_schemeadmin ON groupteams (schemeadmin);
+CREATE INDEX IF NOT EXISTS idx_groupteams_teamid ON groupteams (teamid);
+
+CREATE TABLE IF NOT EXISTS groupchannels (
+    groupid VARCHAR(26) CHECK (length(groupid) <= 26),
+    autoadd BOOLEAN,
+    schemeadmin BOOLEAN,
+    createat BIGINT,
+    deleteat BIGINT,
+    updateat BIGINT,
+    channelid VARCHAR(26) CHECK (length(channelid) <= 26),
+    PRIMARY KEY (groupid, channelid)
+);
+CREATE INDEX IF NOT EXISTS idx_groupchannels_channelid ON groupchannels (channelid);
+CREATE INDEX IF NOT EXISTS idx_groupchannels_schemeadmin ON groupchannels (schemeadmin);
+
+CREATE TABLE IF NOT EXISTS linkmetadata (
+    hash BIGINT NOT NULL,
+    url VARCHAR(2048) CHECK (length(url) <= 2048),
+    timestamp BIGINT,
+    type VARCHAR(16) CHECK (length(type) <= 16),
+    data TEXT,
+    PRIMARY KEY (hash)
+);
+CREATE INDEX IF NOT EXISTS idx_link_metadata_url_timestamp ON linkmetadata (url, "timestamp");
+
+CREATE TABLE IF NOT EXISTS commands (
+    id VARCHAR(26) CHECK (length(id) <= 26),
+    token VARCHAR(26) CHECK (length(token) <= 26),
+    createat BIGINT,
+    updateat BIGINT,
+    deleteat BIGINT,
+    creatorid VARCHAR(26) CHECK (length(creatorid) <= 26),
+    teamid VARCHAR(26) CHECK (length(teamid) <= 26),
+    trigger VARCHAR(128) CHECK (length(trigger) <= 128),
+    method VARCHAR(1) CHECK (length(method) <= 1),
+    username VARCHAR(64) CHECK (length(username) <= 64),
+    iconurl VARCHAR(1024) CHECK (length(iconurl) <= 1024),
+    autocomplete BOOLEAN,
+    autocompletedesc VARCHAR(1024) CHECK (length(autocompletedesc) <= 1024),
+    autocompletehint VARCHAR(1024) CHECK (length(autocompletehint) <= 1024),
+    displayname VARCHAR(64) CHECK (length(displayname) <= 64),
+    description VARCHAR(128) CHECK (length(description) <= 128),
+    url VARCHAR(1024) CHECK (length(url) <= 1024),
+    pluginid VARCHAR(190) CHECK (length(pluginid) <= 190),
+    PRIMARY KEY (id)
+);
+CREATE INDEX IF NOT EXISTS idx_command_team_id ON commands (teamid);
+CREATE INDEX IF NOT EXISTS idx_command_update_at ON commands (updateat);
+CREATE INDEX IF NOT EXISTS idx_command_create_at ON commands (createat);
+CREATE INDEX IF NOT EXISTS idx_command_delete_at ON commands (deleteat);
+
+CREATE TABLE IF NOT EXISTS incomingwebhooks (
+    id VARCHAR(26) CHECK (length(id) <= 26),
+    createat BIGINT,
+    updateat BIGINT,
+    deleteat BIGINT,
+    userid VARCHAR(26) CHECK (length(userid) <= 26),
+    channelid VARCHAR(26) CHECK (length(channelid) <= 26),
+    teamid VARCHAR(26) CHECK (length(teamid) <= 26),
+    displayname VARCHAR(64) CHECK (length(displayname) <= 64),
+    description VARCHAR(500) CHECK (length(description) <= 500),
+    username VARCHAR(255) CHECK (length(username) <= 255),
+    iconurl VARCHAR(1024) CHECK (length(iconurl) <= 1024),
+    channellocked BOOLEAN,
+    PRIMARY KEY (id)
+);
+CREATE INDEX IF NOT EXISTS idx_incoming_webhook_user_id ON incomingwebhooks (userid);
+CREATE INDEX IF NOT EXISTS idx_incoming_webhook_team_id ON incomingwebhooks (teamid);
+CREATE INDEX IF NOT EXISTS idx_incoming_webhook_update_at ON incomingwebhooks (updateat);
+CREATE INDEX IF NOT EXISTS idx_incoming_webhook_create_at ON incomingwebhooks (createat);
+CREATE INDEX IF NOT EXISTS idx_incoming_webhook_delete_at ON incomingwebhooks (deleteat);
+
+CREATE TABLE IF NOT EXISTS outgoingwebhooks (
+    id VARCHAR(26) CHECK (length(id) <= 26),
+    token VARCHAR(26) CHECK (length(token) <= 26),
+    createat BIGINT,
+    updateat BIGINT,
+    deleteat BIGINT,
+    creatorid VARCHAR(26) CHECK (length(creatorid) <= 26),
+    channelid VARCHAR(26) CHECK (length(channelid) <= 26),
+    teamid VARCHAR(26) CHECK (length(teamid) <= 26),
+    triggerwords VARCHAR(1024) CHECK (length(triggerwords) <= 1024),
+    callbackurls VARCHAR(1024) CHECK (length(callbackurls) <= 1024),
+    displayname VARCHAR(64) CHECK (length(displayname) <= 64),
+    contenttype VARCHAR(128) CHECK (length(contenttype) <= 128),
+    triggerwhen INTEGER,
+    username VARCHAR(64) CHECK (length(username) <= 64),
+    iconurl VARCHAR(1024) CHECK (length(iconurl) <= 1024),
+    description VARCHAR(500) CHECK (length(description) <= 500),
+    PRIMARY KEY (id)
+);
+CREATE INDEX IF NOT EXISTS idx_outgoing_webhook_team_id ON outgoingwebhooks (teamid);
+CREATE INDEX IF NOT EXISTS idx_outgoing_webhook_update_at ON outgoingwebhooks (updateat);
+CREATE INDEX IF NOT EXISTS idx_outgoing_webhook_create_at ON outgoingwebhooks (createat);
+CREATE INDEX IF NOT EXISTS idx_outgoing_webhook_delete_at ON outgoingwebhooks (deleteat);
+
+CREATE TABLE IF NOT EXISTS systems (
+    name VARCHAR(64) CHECK (length(name) <= 64),
+    value VARCHAR(1024) CHECK (length(value) <= 1024),
+    PRIMARY KEY (name)
+);
+
+CREATE TABLE IF NOT EXISTS reactions (
+    userid VARCHAR(26) NOT NULL CHECK (length(userid) <= 26),
+    postid VARCHAR(26) NOT NULL CHECK (length(postid) <= 26),
+    emojiname VARCHAR(64) NOT NULL CHECK (length(emojiname) <= 64),
+    createat BIGINT,
+    updateat BIGINT,
+    deleteat BIGINT,
+    remoteid VARCHAR(26) CHECK (length(remoteid) <= 26),
+    channelid VARCHAR(26) NOT NULL DEFAULT '' CHECK (length(channelid) <= 26),
+    PRIMARY KEY (postid, userid, emojiname)
+);
+CREATE INDEX IF NOT EXISTS idx_reactions_channel_id ON reactions (channelid);
+
+CREATE TABLE IF NOT EXISTS roles (
+    id VARCHAR(26) CHECK (length(id) <= 26),
+    name VARCHAR(64) CHECK (length(name) <= 64),
+    displayname VARCHAR(128) CHECK (length(displayname) <= 128),
+    description VARCHAR(1024) CHECK (length(description) <= 1024),
+    createat BIGINT,
+    updateat BIGINT,
+    deleteat BIGINT,
+    permissions TEXT,
+    schememanaged BOOLEAN,
+    builtin BOOLEAN,
+    PRIMARY KEY (id),
+    UNIQUE (name)
+);
+
+CREATE TABLE IF NOT EXISTS schemes (
+    id VARCHAR(26) CHECK (length(id) <= 26),
+    name VARCHAR(64) CHECK (length(name) <= 64),
+    displayname VARCHAR(128) CHECK (length(displayname) <= 128),
+    description VARCHAR(1024) CHECK (length(description) <= 1024),
+    createat BIGINT,
+    updateat BIGINT,
+    deleteat BIGINT,
+    scope VARCHAR(32) CHECK (length(scope) <= 32),
+    defaultteamadminrole VARCHAR(64) CHECK (length(defaultteamadminrole) <= 64),
+    defaultteamuserrole VARCHAR(64) CHECK (length(defaultteamuserrole) <= 64),
+    defaultchanneladminrole VARCHAR(64) CHECK (length(defaultchanneladminrole) <= 64),
+    defaultchanneluserrole VARCHAR(64) CHECK (length(defaultchanneluserrole) <= 64),
+    defaultteamguestrole VARCHAR(64) CHECK (length(defaultteamguestrole) <= 64),
+    defaultchannelguestrole VARCHAR(64) CHECK (length(defaultchannelguestrole) <= 64),
+    defaultplaybookadminrole VARCHAR(64) DEFAULT '' CHECK (length(defaultplaybookadminrole) <= 64),
+    defaultplaybookmemberrole VARCHAR(64) DEFAULT '' CHECK (length(defaultplaybookmemberrole) <= 64),
+    defaultrunadminrole VARCHAR(64) DEFAULT '' CHECK (length(defaultrunadminrole) <= 64),
+    defaultrunmemberrole VARCHAR(64) DEFAULT '' CHECK (length(defaultrunmemberrole) <= 64),
+    PRIMARY KEY (id),
+    UNIQUE (name)
+);
+CREATE INDEX IF NOT EXISTS idx_schemes_channel_guest_role ON schemes (defaultchannelguestrole);
+CREATE INDEX IF NOT EXISTS idx_schemes_channel_user_role ON schemes (defaultchanneluserrole);
+CREATE INDEX IF NOT EXISTS idx_schemes_channel_admin_role ON schemes (defaultchanneladminrole);
+
+CREATE TABLE IF NOT EXISTS licenses (
+    id VARCHAR(26) NOT NULL CHECK (length(id) <= 26),
+    createat BIGINT,
+    bytes VARCHAR(10000) CHECK (length(bytes) <= 10000),
+    PRIMARY KEY (id)
+);
+
+CREATE TABLE IF NOT EXISTS posts (
+    id VARCHAR(26) CHECK (length(id) <= 26),
+    createat BIGINT,
+    updateat BIGINT,
+    deleteat BIGINT,
+    userid VARCHAR(26) CHECK (length(userid) <= 26),
+    channelid VARCHAR(26) CHECK (length(channelid) <= 26),
+    rootid VARCHAR(26) CHECK (length(rootid) <= 26),
+    originalid VARCHAR(26) CHECK (length(originalid) <= 26),
+    message VARCHAR(65535) CHECK (length(message) <= 65535),
+    type VARCHAR(26) CHECK (length(type) <= 26),
+    props TEXT,
+    hashtags VARCHAR(1000) CHECK (length(hashtags) <= 1000),
+    filenames VARCHAR(4000) CHECK (length(filenames) <= 4000),
+    fileids VARCHAR(300) CHECK (length(fileids) <= 300),
+    hasreactions BOOLEAN,
+    editat BIGINT,
+    ispinned BOOLEAN,
+    remoteid VARCHAR(26) CHECK (length(remoteid) <= 26),
+    PRIMARY KEY (id)
+);
+CREATE INDEX IF NOT EXISTS idx_posts_update_at ON posts (updateat);
+CREATE INDEX IF NOT EXISTS idx_posts_create_at ON posts (createat);
+CREATE INDEX IF NOT EXISTS idx_posts_delete_at ON posts (deleteat);
+CREATE INDEX IF NOT EXISTS idx_posts_user_id ON posts (userid);
+CREATE INDEX IF NOT EXISTS idx_posts_is_pinned ON posts (ispinned);
+CREATE INDEX IF NOT EXISTS idx_posts_channel_id_update_at ON posts (channelid, updateat);
+CREATE INDEX IF NOT EXISTS idx_posts_channel_id_delete_at_create_at ON posts (channelid, deleteat, createat);
+CREATE INDEX IF NOT EXISTS idx_posts_root_id_delete_at ON posts (rootid, deleteat);
+CREATE INDEX IF NOT EXISTS idx_posts_create_at_id ON posts (createat, id);
+CREATE INDEX IF NOT EXISTS idx_posts_original_id ON posts (originalid);
+
+CREATE TABLE IF NOT EXISTS productnoticeviewstate (
+    userid VARCHAR(26) CHECK (length(userid) <= 26),
+    noticeid VARCHAR(26) CHECK (length(noticeid) <= 26),
+    viewed INTEGER,
+    timestamp BIGINT,
+    PRIMARY KEY (userid, noticeid)
+);
+CREATE INDEX IF NOT EXISTS idx_notice_views_notice_id ON productnoticeviewstate (noticeid);
+CREATE INDEX IF NOT EXISTS idx_notice_views_timestamp ON productnoticeviewstate ("timestamp");
+
+CREATE TABLE IF NOT EXISTS sessions (
+    id VARCHAR(26) CHECK (length(id) <= 26),
+    token VARCHAR(26) CHECK (length(token) <= 26),
+    createat BIGINT,
+    expiresat BIGINT,
+    lastactivityat BIGINT,
+    userid VARCHAR(26) CHECK (length(userid) <= 26),
+    deviceid VARCHAR(512) CHECK (length(deviceid) <= 512),
+    roles VARCHAR(256) CHECK (length(roles) <= 256),
+    isoauth BOOLEAN,
+    props TEXT,
+    expirednotify BOOLEAN,
+    PRIMARY KEY (id)
+);
+CREATE INDEX IF NOT EXISTS idx_sessions_user_id ON sessions (userid);
+CREATE INDEX IF NOT EXISTS idx_sessions_token ON sessions (token);
+CREATE INDEX IF NOT EXISTS idx_sessions_expires_at ON sessions (expiresat);
+CREATE INDEX IF NOT EXISTS idx_sessions_create_at ON sessions (createat);
+CREATE INDEX IF NOT EXISTS idx_sessions_last_activity_at ON sessions (lastactivityat);
+
+CREATE TABLE IF NOT EXISTS termsofservice (
+    id VARCHAR(26) CHECK (length(id) <= 26),
+    createat BIGINT,
+    userid VARCHAR(26) CHECK (length(userid) <= 26),
+    text VARCHAR(65535) CHECK (length(text) <= 65535),
+    PRIMARY KEY (id)
+);
+
+CREATE TABLE IF NOT EXISTS audits (
+    id VARCHAR(26) CHECK (length(id) <= 26),
+    createat BIGINT,
+    userid VARCHAR(26) CHECK (length(userid) <= 26),
+    action VARCHAR(512) CHECK (length(action) <= 512),
+    extrainfo VARCHAR(1024) CHECK (length(extrainfo) <= 1024),
+    ipaddress VARCHAR(64) CHECK (length(ipaddress) <= 64),
+    sessionid VARCHAR(26) CHECK (length(sessionid) <= 26),
+    PRIMARY KEY (id)
+);
+CREATE INDEX IF NOT EXISTS idx_audits_user_id ON audits (userid);
+
+CREATE TABLE IF NOT EXISTS oauthaccessdata (
+    token VARCHAR(26) NOT NULL CHECK (length(token) <= 26),
+    refreshtoken VARCHAR(26) CHECK (length(refreshtoken) <= 26),
+    redirecturi VARCHAR(256) CHECK (length(redirecturi) <= 256),
+    clientid VARCHAR(26) CHECK (length(clientid) <= 26),
+    userid VARCHAR(26) CHECK (length(userid) <= 26),
+    expiresat BIGINT,
+    scope VARCHAR(128) CHECK (length(scope) <= 128),
+    PRIMARY KEY (token),
+    UNIQUE (clientid, userid)
+);
+CREATE INDEX IF NOT EXISTS idx_oauthaccessdata_refresh_token ON oauthaccessdata (refreshtoken);
+CREATE INDEX IF NOT EXISTS idx_oauthaccessdata_user_id ON oauthaccessdata (userid);
+
+CREATE TABLE IF NOT EXISTS preferences (
+    userid VARCHAR(26) NOT NULL CHECK (length(userid) <= 26),
+    category VARCHAR(32) NOT NULL CHECK (length(category) <= 32),
+    name VARCHAR(32) NOT NULL CHECK (length(name) <= 32),
+    value VARCHAR(2000) CHECK (length(value) <= 2000),
+    PRIMARY KEY (userid, category, name)
+);
+CREATE INDEX IF NOT EXISTS idx_preferences_category ON preferences (category);
+CREATE INDEX IF NOT EXISTS idx_preferences_name ON preferences (name);
+
+CREATE TABLE IF NOT EXISTS status (
+    userid VARCHAR(26) CHECK (length(userid) <= 26),
+    status VARCHAR(32) CHECK (length(status) <= 32),
+    manual BOOLEAN,
+    lastactivityat BIGINT,
+    dndendtime BIGINT,
+    prevstatus VARCHAR(32) CHECK (length(prevstatus) <= 32),
+    PRIMARY KEY (userid)
+);
+CREATE INDEX IF NOT EXISTS idx_status_status_dndendtime ON status (status, dndendtime);
+
+CREATE TABLE IF NOT EXISTS tokens (
+    token VARCHAR(64) CHECK (length(token) <= 64),
+    createat BIGINT,
+    type VARCHAR(64) CHECK (length(type) <= 64),
+    extra VARCHAR(2048) CHECK (length(extra) <= 2048),
+    PRIMARY KEY (token)
+);
+
+CREATE TABLE IF NOT EXISTS bots (
+    userid VARCHAR(26) CHECK (length(userid) <= 26),
+    description VARCHAR(1024) CHECK (length(description) <= 1024),
+    ownerid VARCHAR(190) CHECK (length(ownerid) <= 190),
+    createat BIGINT,
+    updateat BIGINT,
+    deleteat BIGINT,
+    lasticonupdate BIGINT,
+    PRIMARY KEY (userid)
+);
+
+CREATE TABLE IF NOT EXISTS useraccesstokens (
+    id VARCHAR(26) CHECK (length(id) <= 26),
+    token VARCHAR(26) CHECK (length(token) <= 26),
+    userid VARCHAR(26) CHECK (length(userid) <= 26),
+    description VARCHAR(512) CHECK (length(description) <= 512),
+    isactive BOOLEAN,
+    PRIMARY KEY (id),
+    UNIQUE (token)
+);
+CREATE INDEX IF NOT EXISTS idx_user_access_tokens_user_id ON useraccesstokens (userid);
+
+CREATE TABLE IF NOT EXISTS remoteclusters (
+    remoteid VARCHAR(26) NOT NULL CHECK (length(remoteid) <= 26),
+    remoteteamid VARCHAR(26) CHECK (length(remoteteamid) <= 26),
+    name VARCHAR(64) NOT NULL CHECK (length(name) <= 64),
+    displayname VARCHAR(64) CHECK (length(displayname) <= 64),
+    siteurl VARCHAR(512) CHECK (length(siteurl) <= 512),
+    createat BIGINT,
+    lastpingat BIGINT,
+    token VARCHAR(26) CHECK (length(token) <= 26),
+    remotetoken VARCHAR(26) CHECK (length(remotetoken) <= 26),
+    topics VARCHAR(512) CHECK (length(topics) <= 512),
+    creatorid VARCHAR(26) CHECK (length(creatorid) <= 26),
+    pluginid VARCHAR(190) NOT NULL DEFAULT '' CHECK (length(pluginid) <= 190),
+    options SMALLINT NOT NULL DEFAULT 0,
+    defaultteamid VARCHAR(26) DEFAULT '' CHECK (length(defaultteamid) <= 26),
+    deleteat BIGINT DEFAULT 0,
+    lastglobalusersyncat BIGINT DEFAULT 0,
+    PRIMARY KEY (remoteid, name)
+);
+
+CREATE TABLE IF NOT EXISTS sharedchannels (
+    channelid VARCHAR(26) NOT NULL CHECK (length(channelid) <= 26),
+    teamid VARCHAR(26) CHECK (length(teamid) <= 26),
+    home BOOLEAN,
+    readonly BOOLEAN,
+    sharename VARCHAR(64) CHECK (length(sharename) <= 64),
+    sharedisplayname VARCHAR(64) CHECK (length(sharedisplayname) <= 64),
+    sharepurpose VARCHAR(250) CHECK (length(sharepurpose) <= 250),
+    shareheader VARCHAR(1024) CHECK (length(shareheader) <= 1024),
+    creatorid VARCHAR(26) CHECK (length(creatorid) <= 26),
+    createat BIGINT,
+    updateat BIGINT,
+    remoteid VARCHAR(26) CHECK (length(remoteid) <= 26),
+    PRIMARY KEY (channelid),
+    UNIQUE (sharename, teamid)
+);
+
+CREATE TABLE IF NOT EXISTS sidebarchannels (
+    channelid VARCHAR(26) CHECK (length(channelid) <= 26),
+    userid VARCHAR(26) CHECK (length(userid) <= 26),
+    categoryid VARCHAR(128) CHECK (length(categoryid) <= 128),
+    sortorder BIGINT,
+    PRIMARY KEY (channelid, userid, categoryid)
+);
+CREATE INDEX IF NOT EXISTS idx_sidebarchannels_categoryid ON sidebarchannels (categoryid);
+
+CREATE TABLE IF NOT EXISTS oauthauthdata (
+    clientid VARCHAR(26) CHECK (length(clientid) <= 26),
+    userid VARCHAR(26) CHECK (length(userid) <= 26),
+    code VARCHAR(128) NOT NULL CHECK (length(code) <= 128),
+    expiresin INTEGER,
+    createat BIGINT,
+    redirecturi VARCHAR(256) CHECK (length(redirecturi) <= 256),
+    state VARCHAR(1024) CHECK (length(state) <= 1024),
+    scope VARCHAR(128) CHECK (length(scope) <= 128),
+    PRIMARY KEY (code)
+);
+
+CREATE TABLE IF NOT EXISTS sharedchannelattachments (
+    id VARCHAR(26) NOT NULL CHECK (length(id) <= 26),
+    fileid VARCHAR(26) CHECK (length(fileid) <= 26),
+    remoteid VARCHAR(26) CHECK (length(remoteid) <= 26),
+    createat BIGINT,
+    lastsyncat BIGINT,
+    PRIMARY KEY (id),
+    UNIQUE (fileid, remoteid)
+);
+
+CREATE TABLE IF NOT EXISTS sharedchannelusers (
+    id VARCHAR(26) NOT NULL CHECK (length(id) <= 26),
+    userid VARCHAR(26) CHECK (length(userid) <= 26),
+    remoteid VARCHAR(26) CHECK (length(remoteid) <= 26),
+    createat BIGINT,
+    lastsyncat BIGINT,
+    channelid VARCHAR(26) CHECK (length(channelid) <= 26),
+    lastmembershipsyncat BIGINT DEFAULT 0,
+    PRIMARY KEY (id),
+    UNIQUE (userid, channelid, remoteid)
+);
+CREATE INDEX IF NOT EXISTS idx_sharedchannelusers_remote_id ON sharedchannelusers (remoteid);
+
+CREATE TABLE IF NOT EXISTS sharedchannelremotes (
+    id VARCHAR(26) NOT NULL CHECK (length(id) <= 26),
+    channelid VARCHAR(26) NOT NULL CHECK (length(channelid) <= 26),
+    creatorid VARCHAR(26) CHECK (length(creatorid) <= 26),
+    createat BIGINT,
+    updateat BIGINT,
+    isinviteaccepted BOOLEAN,
+    isinviteconfirmed BOOLEAN,
+    remoteid VARCHAR(26) CHECK (length(remoteid) <= 26),
+    lastpostupdateat BIGINT,
+    lastpostid VARCHAR(26) CHECK (length(lastpostid) <= 26),
+    lastpostcreateat BIGINT NOT NULL DEFAULT 0,
+    lastpostcreateid VARCHAR(26) CHECK (length(lastpostcreateid) <= 26),
+    deleteat BIGINT DEFAULT 0,
+    lastmemberssyncat BIGINT DEFAULT 0,
+    PRIMARY KEY (id, channelid),
+    UNIQUE (channelid, remoteid)
+);
+
+CREATE TABLE IF NOT EXISTS jobs (
+    id VARCHAR(26) CHECK (length(id) <= 26),
+    type VARCHAR(32) CHECK (length(type) <= 32),
+    priority BIGINT,
+    createat BIGINT,
+    startat BIGINT,
+    lastactivityat BIGINT,
+    status VARCHAR(32) CHECK (length(status) <= 32),
+    progress BIGINT,
+    data TEXT,
+    PRIMARY KEY (id)
+);
+CREATE INDEX IF NOT EXISTS idx_jobs_type ON jobs (type);
+CREATE INDEX IF NOT EXISTS idx_jobs_status_type ON jobs (status, type);
+
+CREATE TABLE IF NOT EXISTS channelmemberhistory (
+    channelid VARCHAR(26) NOT NULL CHECK (length(channelid) <= 26),
+    userid VARCHAR(26) NOT NULL CHECK (length(userid) <= 26),
+    jointime BIGINT NOT NULL,
+    leavetime BIGINT,
+    PRIMARY KEY (channelid, userid, jointime)
+);
+
+CREATE TABLE IF NOT EXISTS sidebarcategories (
+    id VARCHAR(128) CHECK (length(id) <= 128),
+    userid VARCHAR(26) CHECK (length(userid) <= 26),
+    teamid VARCHAR(26) CHECK (length(teamid) <= 26),
+    sortorder BIGINT,
+    sorting VARCHAR(64) CHECK (length(sorting) <= 64),
+    type VARCHAR(64) CHECK (length(type) <= 64),
+    displayname VARCHAR(64) CHECK (length(displayname) <= 64),
+    muted BOOLEAN,
+    collapsed BOOLEAN,
+    PRIMARY KEY (id)
+);
+CREATE INDEX IF NOT EXISTS idx_sidebarcategories_userid_teamid ON sidebarcategories (userid, teamid);
+
+CREATE TABLE IF NOT EXISTS uploadsessions (
+    id VARCHAR(26) CHECK (length(id) <= 26),
+    type VARCHAR(32) CHECK (length(type) <= 32),
+    createat BIGINT,
+    userid VARCHAR(26) CHECK (length(userid) <= 26),
+    channelid VARCHAR(26) CHECK (length(channelid) <= 26),
+    filename VARCHAR(256) CHECK (length(filename) <= 256),
+    path VARCHAR(512) CHECK (length(path) <= 512),
+    filesize BIGINT,
+    fileoffset BIGINT,
+    remoteid VARCHAR(26) CHECK (length(remoteid) <= 26),
+    reqfileid VARCHAR(26) CHECK (length(reqfileid) <= 26),
+    PRIMARY KEY (id)
+);
+CREATE INDEX IF NOT EXISTS idx_uploadsessions_user_id ON uploadsessions (userid);
+CREATE INDEX IF NOT EXISTS idx_uploadsessions_create_at ON uploadsessions (createat);
+CREATE INDEX IF NOT EXISTS idx_uploadsessions_type ON uploadsessions (type);
+
+CREATE TABLE IF NOT EXISTS threads (
+    postid VARCHAR(26) CHECK (length(postid) <= 26),
+    replycount BIGINT,
+    lastreplyat BIGINT,
+    participants TEXT,
+    channelid VARCHAR(26) CHECK (length(channelid) <= 26),
+    threaddeleteat BIGINT,
+    threadteamid VARCHAR(26) CHECK (length(threadteamid) <= 26),
+    PRIMARY KEY (postid)
+);
+CREATE INDEX IF NOT EXISTS idx_threads_channel_id_last_reply_at ON threads (channelid, lastreplyat);
+
+CREATE TABLE IF NOT EXISTS threadmemberships (
+    postid VARCHAR(26) NOT NULL CHECK (length(postid) <= 26),
+    userid VARCHAR(26) NOT NULL CHECK (length(userid) <= 26),
+    following BOOLEAN,
+    lastviewed BIGINT,
+    lastupdated BIGINT,
+    unreadmentions BIGINT,
+    PRIMARY KEY (postid, userid)
+);
+CREATE INDEX IF NOT EXISTS idx_thread_memberships_last_update_at ON threadmemberships (lastupdated);
+CREATE INDEX IF NOT EXISTS idx_thread_memberships_last_view_at ON threadmemberships (lastviewed);
+CREATE INDEX IF NOT EXISTS idx_thread_memberships_user_id ON threadmemberships (userid);
+
+CREATE TABLE IF NOT EXISTS usertermsofservice (
+    userid VARCHAR(26) CHECK (length(userid) <= 26),
+    termsofserviceid VARCHAR(26) CHECK (length(termsofserviceid) <= 26),
+    createat BIGINT,
+    PRIMARY KEY (userid)
+);
+
+CREATE TABLE IF NOT EXISTS pluginkeyvaluestore (
+    pluginid VARCHAR(190) NOT NULL CHECK (length(pluginid) <= 190),
+    pkey VARCHAR(150) NOT NULL CHECK (length(pkey) <= 150),
+    pvalue BLOB,
+    expireat BIGINT DEFAULT 0,
+    PRIMARY KEY (pluginid, pkey)
+);
+
+CREATE TABLE IF NOT EXISTS users (
+    id VARCHAR(26) CHECK (length(id) <= 26),
+    createat BIGINT,
+    updateat BIGINT,
+    deleteat BIGINT,
+    username VARCHAR(64) CHECK (length(username) <= 64),
+    password VARCHAR(128) CHECK (length(password) <= 128),
+    authdata VARCHAR(128) CHECK (length(authdata) <= 128),
+    authservice VARCHAR(32) CHECK (length(authservice) <= 32),
+    email VARCHAR(128) CHECK (length(email) <= 128),
+    emailverified BOOLEAN,
+    nickname VARCHAR(64) CHECK (length(nickname) <= 64),
+    firstname VARCHAR(64) CHECK (length(firstname) <= 64),
+    lastname VARCHAR(64) CHECK (length(lastname) <= 64),
+    roles VARCHAR(256) CHECK (length(roles) <= 256),
+    allowmarketing BOOLEAN,
+    props TEXT,
+    notifyprops TEXT,
+    lastpasswordupdate BIGINT,
+    lastpictureupdate BIGINT,
+    failedattempts INTEGER,
+    locale VARCHAR(5) CHECK (length(locale) <= 5),
+    mfaactive BOOLEAN,
+    mfasecret VARCHAR(128) CHECK (length(mfasecret) <= 128),
+    position VARCHAR(128) CHECK (length(position) <= 128),
+    timezone TEXT,
+    remoteid VARCHAR(26) CHECK (length(remoteid) <= 26),
+    lastlogin BIGINT NOT NULL DEFAULT 0,
+    mfausedtimestamps TEXT,
+    PRIMARY KEY (id),
+    UNIQUE (username),
+    UNIQUE (authdata),
+    UNIQUE (email)
+);
+CREATE INDEX IF NOT EXISTS idx_users_update_at ON users (updateat);
+CREATE INDEX IF NOT EXISTS idx_users_create_at ON users (createat);
+CREATE INDEX IF NOT EXISTS idx_users_delete_at ON users (deleteat);
+CREATE INDEX IF NOT EXISTS idx_users_email_lower_textpattern ON users (lower(email));
+CREATE INDEX IF NOT EXISTS idx_users_username_lower_textpattern ON users (lower(username));
+CREATE INDEX IF NOT EXISTS idx_users_nickname_lower_textpattern ON users (lower(nickname));
+CREATE INDEX IF NOT EXISTS idx_users_firstname_lower_textpattern ON users (lower(firstname));
+CREATE INDEX IF NOT EXISTS idx_users_lastname_lower_textpattern ON users (lower(lastname));
+
+CREATE TABLE IF NOT EXISTS fileinfo (
+    id VARCHAR(26) CHECK (length(id) <= 26),
+    creatorid VARCHAR(26) CHECK (length(creatorid) <= 26),
+    postid VARCHAR(26) CHECK (length(postid) <= 26),
+    createat BIGINT,
+    updateat BIGINT,
+    deleteat BIGINT,
+    path VARCHAR(512) CHECK (length(path) <= 512),
+    thumbnailpath VARCHAR(512) CHECK (length(thumbnailpath) <= 512),
+    previewpath VARCHAR(512) CHECK (length(previewpath) <= 512),
+    name VARCHAR(256) CHECK (length(name) <= 256),
+    extension VARCHAR(64) CHECK (length(extension) <= 64),
+    size BIGINT,
+    mimetype VARCHAR(256) CHECK (length(mimetype) <= 256),
+    width INTEGER,
+    height INTEGER,
+    haspreviewimage BOOLEAN,
+    minipreview BLOB,
+    content TEXT,
+    remoteid VARCHAR(26) CHECK (length(remoteid) <= 26),
+    archived BOOLEAN NOT NULL DEFAULT false,
+    channelid VARCHAR(26) CHECK (length(channelid) <= 26),
+    PRIMARY KEY (id)
+);
+CREATE INDEX IF NOT EXISTS idx_fileinfo_update_at ON fileinfo (updateat);
+CREATE INDEX IF NOT EXISTS idx_fileinfo_create_at ON fileinfo (createat);
+CREATE INDEX IF NOT EXISTS idx_fileinfo_delete_at ON fileinfo (deleteat);
+CREATE INDEX IF NOT EXISTS idx_fileinfo_postid_at ON fileinfo (postid);
+CREATE INDEX IF NOT EXISTS idx_fileinfo_extension_at ON fileinfo (extension);
+CREATE INDEX IF NOT EXISTS idx_fileinfo_channel_id_create_at ON fileinfo (channelid, createat);
+
+CREATE TABLE IF NOT EXISTS oauthapps (
+    id VARCHAR(26) CHECK (length(id) <= 26),
+    creatorid VARCHAR(26) CHECK (length(creatorid) <= 26),
+    createat BIGINT,
+    updateat BIGINT,
+    clientsecret VARCHAR(128) CHECK (length(clientsecret) <= 128),
+    name VARCHAR(64) CHECK (length(name) <= 64),
+    description VARCHAR(512) CHECK (length(description) <= 512),
+    callbackurls VARCHAR(1024) CHECK (length(callbackurls) <= 1024),
+    homepage VARCHAR(256) CHECK (length(homepage) <= 256),
+    istrusted BOOLEAN,
+    iconurl VARCHAR(512) CHECK (length(iconurl) <= 512),
+    mattermostappid VARCHAR(32) NOT NULL DEFAULT '' CHECK (length(mattermostappid) <= 32),
+    PRIMARY KEY (id)
+);
+CREATE INDEX IF NOT EXISTS idx_oauthapps_creator_id ON oauthapps (creatorid);
+
+CREATE TABLE IF NOT EXISTS channels (
+    id VARCHAR(26) CHECK (length(id) <= 26),
+    createat BIGINT,
+    updateat BIGINT,
+    deleteat BIGINT,
+    teamid VARCHAR(26) CHECK (length(teamid) <= 26),
+    type VARCHAR(1) CHECK (length(type) <= 1),
+    displayname VARCHAR(64) CHECK (length(displayname) <= 64),
+    name VARCHAR(64) CHECK (length(name) <= 64),
+    header VARCHAR(1024) CHECK (length(header) <= 1024),
+    purpose VARCHAR(250) CHECK (length(purpose) <= 250),
+    lastpostat BIGINT,
+    totalmsgcount BIGINT,
+    extraupdateat BIGINT,
+    creatorid VARCHAR(26) CHECK (length(creatorid) <= 26),
+    schemeid VARCHAR(26) CHECK (length(schemeid) <= 26),
+    groupconstrained BOOLEAN,
+    shared BOOLEAN,
+    bannerinfo TEXT,
+    defaultcategoryname VARCHAR(64) NOT NULL DEFAULT '' CHECK (length(defaultcategoryname) <= 64),
+    totalmsgcountroot BIGINT,
+    lastrootpostat BIGINT DEFAULT 0,
+    PRIMARY KEY (id),
+    UNIQUE (name, teamid)
+);
+CREATE INDEX IF NOT EXISTS idx_channels_displayname_lower ON channels (lower(displayname));
+CREATE INDEX IF NOT EXISTS idx_channels_name_lower ON channels (lower(name));
+CREATE INDEX IF NOT EXISTS idx_channels_update_at ON channels (updateat);
+CREATE INDEX IF NOT EXISTS idx_channels_delete_at ON channels (deleteat);
+CREATE INDEX IF NOT EXISTS idx_channels_create_at ON channels (createat);
+CREATE INDEX IF NOT EXISTS idx_channels_scheme_id ON channels (schemeid);
+CREATE INDEX IF NOT EXISTS idx_channels_team_id_display_name ON channels (teamid, displayname);
+CREATE INDEX IF NOT EXISTS idx_channels_team_id_type ON channels (teamid, type);
+
+CREATE TABLE IF NOT EXISTS channelmembers (
+    channelid VARCHAR(26) NOT NULL CHECK (length(channelid) <= 26),
+    userid VARCHAR(26) NOT NULL CHECK (length(userid) <= 26),
+    roles VARCHAR(256) CHECK (length(roles) <= 256),
+    lastviewedat BIGINT,
+    msgcount BIGINT,
+    mentioncount BIGINT,
+    notifyprops TEXT,
+    lastupdateat BIGINT,
+    schemeuser BOOLEAN,
+    schemeadmin BOOLEAN,
+    schemeguest BOOLEAN,
+    urgentmentioncount BIGINT,
+    mentioncountroot BIGINT,
+    msgcountroot BIGINT,
+    PRIMARY KEY (channelid, userid)
+);
+CREATE INDEX IF NOT EXISTS idx_channelmembers_user_id_channel_id_last_viewed_at ON channelmembers (userid, channelid, lastviewedat);
+CREATE INDEX IF NOT EXISTS idx_channelmembers_channel_id_scheme_guest_user_id ON channelmembers (channelid, schemeguest, userid);
+
+CREATE TABLE IF NOT EXISTS publicchannels (
+    id VARCHAR(26) CHECK (length(id) <= 26),
+    deleteat BIGINT,
+    teamid VARCHAR(26) CHECK (length(teamid) <= 26),
+    displayname VARCHAR(64) CHECK (length(displayname) <= 64),
+    name VARCHAR(64) CHECK (length(name) <= 64),
+    header VARCHAR(1024) CHECK (length(header) <= 1024),
+    purpose VARCHAR(250) CHECK (length(purpose) <= 250),
+    PRIMARY KEY (id),
+    UNIQUE (name, teamid)
+);
+CREATE INDEX IF NOT EXISTS idx_publicchannels_team_id ON publicchannels (teamid);
+CREATE INDEX IF NOT EXISTS idx_publicchannels_delete_at ON publicchannels (deleteat);
+CREATE INDEX IF NOT EXISTS idx_publicchannels_name_lower ON publicchannels (lower(name));
+CREATE INDEX IF NOT EXISTS idx_publicchannels_displayname_lower ON publicchannels (lower(displayname));
+
+CREATE TABLE IF NOT EXISTS retentionpolicies (
+    id VARCHAR(26) CHECK (length(id) <= 26),
+    displayname VARCHAR(64) CHECK (length(displayname) <= 64),
+    postduration BIGINT,
+    PRIMARY KEY (id)
+);
+CREATE INDEX IF NOT EXISTS idx_retentionpolicies_displayname ON retentionpolicies (displayname);
+
+CREATE TABLE IF NOT EXISTS retentionpoliciesteams (
+    policyid VARCHAR(26) CHECK (length(policyid) <= 26),
+    teamid VARCHAR(26) CHECK (length(teamid) <= 26),
+    PRIMARY KEY (teamid),
+    FOREIGN KEY (policyid) REFERENCES retentionpolicies (id) ON DELETE CASCADE
+);
+CREATE INDEX IF NOT EXISTS idx_retentionpoliciesteams_policyid ON retentionpoliciesteams (policyid);
+
+CREATE TABLE IF NOT EXISTS retentionpolicieschannels (
+    policyid VARCHAR(26) CHECK (length(policyid) <= 26),
+    channelid VARCHAR(26) CHECK (length(channelid) <= 26),
+    PRIMARY KEY (channelid),
+    FOREIGN KEY (policyid) REFERENCES retentionpolicies (id) ON DELETE CASCADE
+);
+CREATE INDEX IF NOT EXISTS idx_retentionpolicieschannels_policyid ON retentionpolicieschannels (policyid);
+
+CREATE TABLE IF NOT EXISTS recentsearches (
+    userid VARCHAR(26) CHECK (length(userid) <= 26),
+    searchpointer INTEGER,
+    query TEXT,
+    createat BIGINT NOT NULL,
+    PRIMARY KEY (userid, searchpointer)
+);
+
+CREATE TABLE IF NOT EXISTS postreminders (
+    postid VARCHAR(26) NOT NULL CHECK (length(postid) <= 26),
+    userid VARCHAR(26) NOT NULL CHECK (length(userid) <= 26),
+    targettime BIGINT,
+    PRIMARY KEY (postid, userid)
+);
+CREATE INDEX IF NOT EXISTS idx_postreminders_targettime ON postreminders (targettime);
+
+CREATE TABLE IF NOT EXISTS notifyadmin (
+    userid VARCHAR(26) NOT NULL CHECK (length(userid) <= 26),
+    createat BIGINT DEFAULT NULL,
+    requiredplan VARCHAR(100) NOT NULL CHECK (length(requiredplan) <= 100),
+    requiredfeature VARCHAR(255) NOT NULL CHECK (length(requiredfeature) <= 255),
+    trial BOOLEAN NOT NULL,
+    sentat BIGINT DEFAULT NULL,
+    PRIMARY KEY (userid, requiredfeature, requiredplan)
+);
+
+CREATE TABLE IF NOT EXISTS postspriority (
+    postid VARCHAR(26) CHECK (length(postid) <= 26),
+    channelid VARCHAR(26) NOT NULL CHECK (length(channelid) <= 26),
+    priority VARCHAR(32) NOT NULL CHECK (length(priority) <= 32),
+    requestedack BOOLEAN,
+    persistentnotifications BOOLEAN,
+    PRIMARY KEY (postid)
+);
+
+CREATE TABLE IF NOT EXISTS postacknowledgements (
+    postid VARCHAR(26) NOT NULL CHECK (length(postid) <= 26),
+    userid VARCHAR(26) NOT NULL CHECK (length(userid) <= 26),
+    acknowledgedat BIGINT,
+    remoteid VARCHAR(26) DEFAULT '' CHECK (length(remoteid) <= 26),
+    channelid VARCHAR(26) DEFAULT '' CHECK (length(channelid) <= 26),
+    PRIMARY KEY (postid, userid)
+);
+
+CREATE TABLE IF NOT EXISTS drafts (
+    createat BIGINT,
+    updateat BIGINT,
+    deleteat BIGINT,
+    userid VARCHAR(26) CHECK (length(userid) <= 26),
+    channelid VARCHAR(26) CHECK (length(channelid) <= 26),
+    rootid VARCHAR(26) DEFAULT '' CHECK (length(rootid) <= 26),
+    message VARCHAR(65535) CHECK (length(message) <= 65535),
+    props VARCHAR(8000) CHECK (length(props) <= 8000),
+    fileids VARCHAR(300) CHECK (length(fileids) <= 300),
+    priority TEXT,
+    PRIMARY KEY (userid, channelid, rootid)
+);
+
+CREATE TABLE IF NOT EXISTS persistentnotifications (
+    postid VARCHAR(26) CHECK (length(postid) <= 26),
+    createat BIGINT,
+    lastsentat BIGINT,
+    deleteat BIGINT,
+    sentcount SMALLINT,
+    PRIMARY KEY (postid)
+);
+
+CREATE TABLE IF NOT EXISTS desktoptokens (
+    token VARCHAR(64) NOT NULL CHECK (length(token) <= 64),
+    createat BIGINT NOT NULL,
+    userid VARCHAR(26) NOT NULL CHECK (length(userid) <= 26),
+    PRIMARY KEY (token)
+);
+CREATE INDEX IF NOT EXISTS idx_desktoptokens_token_createat ON desktoptokens (token, createat);
+
+CREATE TABLE IF NOT EXISTS outgoingoauthconnections (
+    id VARCHAR(26) CHECK (length(id) <= 26),
+    name VARCHAR(64) CHECK (length(name) <= 64),
+    creatorid VARCHAR(26) CHECK (length(creatorid) <= 26),
+    createat BIGINT,
+    updateat BIGINT,
+    clientid VARCHAR(255) CHECK (length(clientid) <= 255),
+    clientsecret VARCHAR(255) CHECK (length(clientsecret) <= 255),
+    credentialsusername VARCHAR(255) CHECK (length(credentialsusername) <= 255),
+    credentialspassword VARCHAR(255) CHECK (length(credentialspassword) <= 255),
+    oauthtokenurl TEXT,
+    granttype VARCHAR(32) DEFAULT 'client_credentials' CHECK (length(granttype) <= 32),
+    audiences VARCHAR(1024) CHECK (length(audiences) <= 1024),
+    PRIMARY KEY (id)
+);
+CREATE INDEX IF NOT EXISTS idx_outgoingoauthconnections_name ON outgoingoauthconnections (name);
+
+CREATE TABLE IF NOT EXISTS channelbookmarks (
+    id VARCHAR(26) CHECK (length(id) <= 26),
+    ownerid VARCHAR(26) NOT NULL CHECK (length(ownerid) <= 26),
+    channelid VARCHAR(26) NOT NULL CHECK (length(channelid) <= 26),
+    fileinfoid VARCHAR(26) DEFAULT NULL CHECK (length(fileinfoid) <= 26),
+    createat BIGINT DEFAULT 0,
+    updateat BIGINT DEFAULT 0,
+    deleteat BIGINT DEFAULT 0,
+    displayname TEXT DEFAULT '',
+    sortorder INTEGER DEFAULT 0,
+    linkurl TEXT DEFAULT NULL,
+    imageurl TEXT DEFAULT NULL,
+    emoji VARCHAR(64) DEFAULT NULL CHECK (length(emoji) <= 64),
+    type VARCHAR(32) DEFAULT 'link' CHECK (length(type) <= 32),
+    originalid VARCHAR(26) DEFAULT NULL CHECK (length(originalid) <= 26),
+    parentid VARCHAR(26) DEFAULT NULL CHECK (length(parentid) <= 26),
+    PRIMARY KEY (id)
+);
+CREATE INDEX IF NOT EXISTS idx_channelbookmarks_channelid ON channelbookmarks (channelid);
+CREATE INDEX IF NOT EXISTS idx_channelbookmarks_update_at ON channelbookmarks (updateat);
+CREATE INDEX IF NOT EXISTS idx_channelbookmarks_delete_at ON channelbookmarks (deleteat);
+
+CREATE TABLE IF NOT EXISTS scheduledposts (
+    id VARCHAR(26) CHECK (length(id) <= 26),
+    createat BIGINT,
+    updateat BIGINT,
+    userid VARCHAR(26) NOT NULL CHECK (length(userid) <= 26),
+    channelid VARCHAR(26) NOT NULL CHECK (length(channelid) <= 26),
+    rootid VARCHAR(26) CHECK (length(rootid) <= 26),
+    message VARCHAR(65535) CHECK (length(message) <= 65535),
+    props VARCHAR(8000) CHECK (length(props) <= 8000),
+    fileids VARCHAR(300) CHECK (length(fileids) <= 300),
+    priority TEXT,
+    scheduledat BIGINT NOT NULL,
+    processedat BIGINT,
+    errorcode VARCHAR(200) CHECK (length(errorcode) <= 200),
+    PRIMARY KEY (id)
+);
+CREATE INDEX IF NOT EXISTS idx_scheduledposts_userid_channel_id_scheduled_at ON scheduledposts (userid, channelid, scheduledat);
+
+CREATE TABLE IF NOT EXISTS propertygroups (
+    id VARCHAR(26) CHECK (length(id) <= 26),
+    name VARCHAR(64) NOT NULL CHECK (length(name) <= 64),
+    PRIMARY KEY (id),
+    UNIQUE (name)
+);
+
+CREATE TABLE IF NOT EXISTS propertyfields (
+    id VARCHAR(26) CHECK (length(id) <= 26),
+    groupid VARCHAR(26) NOT NULL CHECK (length(groupid) <= 26),
+    name VARCHAR(255) NOT NULL CHECK (length(name) <= 255),
+    type VARCHAR(32) CHECK (length(type) <= 32),
+    attrs TEXT,
+    targetid VARCHAR(255) CHECK (length(targetid) <= 255),
+    targettype VARCHAR(255) CHECK (length(targettype) <= 255),
+    createat BIGINT NOT NULL,
+    updateat BIGINT NOT NULL,
+    deleteat BIGINT NOT NULL,
+    PRIMARY KEY (id)
+);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_propertyfields_unique ON propertyfields (groupid, targetid, name) WHERE deleteat = 0;
+CREATE INDEX IF NOT EXISTS idx_propertyfields_create_at_id ON propertyfields (createat, id);
+
+CREATE TABLE IF NOT EXISTS propertyvalues (
+    id VARCHAR(26) CHECK (length(id) <= 26),
+    targetid VARCHAR(255) NOT NULL CHECK (length(targetid) <= 255),
+    targettype VARCHAR(255) NOT NULL CHECK (length(targettype) <= 255),
+    groupid VARCHAR(26) NOT NULL CHECK (length(groupid) <= 26),
+    fieldid VARCHAR(26) NOT NULL CHECK (length(fieldid) <= 26),
+    value TEXT NOT NULL,
+    createat BIGINT NOT NULL,
+    updateat BIGINT NOT NULL,
+    deleteat BIGINT NOT NULL,
+    PRIMARY KEY (id)
+);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_propertyvalues_unique ON propertyvalues (groupid, targetid, fieldid) WHERE deleteat = 0;
+CREATE INDEX IF NOT EXISTS idx_propertyvalues_targetid_groupid ON propertyvalues (targetid, groupid);
+CREATE INDEX IF NOT EXISTS idx_propertyvalues_create_at_id ON propertyvalues (createat, id);
+
+CREATE TABLE IF NOT EXISTS accesscontrolpolicies (
+    id VARCHAR(26) CHECK (length(id) <= 26),
+    name VARCHAR(128) NOT NULL CHECK (length(name) <= 128),
+    type VARCHAR(128) NOT NULL CHECK (length(type) <= 128),
+    active BOOLEAN NOT NULL,
+    createat BIGINT NOT NULL,
+    revision INTEGER NOT NULL,
+    version VARCHAR(8) NOT NULL CHECK (length(version) <= 8),
+    data TEXT,
+    props TEXT,
+    PRIMARY KEY (id)
+);
+
+CREATE TABLE IF NOT EXISTS accesscontrolpolicyhistory (
+    id VARCHAR(26) NOT NULL CHECK (length(id) <= 26),
+    name VARCHAR(128) NOT NULL CHECK (length(name) <= 128),
+    type VARCHAR(128) NOT NULL CHECK (length(type) <= 128),
+    createat BIGINT NOT NULL,
+    revision INTEGER NOT NULL,
+    version VARCHAR(8) NOT NULL CHECK (length(version) <= 8),
+    data TEXT,
+    props TEXT,
+    PRIMARY KEY (id, revision)
+);
+
+CREATE TABLE IF NOT EXISTS auditrecords (
+    id VARCHAR(26) CHECK (length(id) <= 26),
+    createat BIGINT NOT NULL,
+    level VARCHAR(64) NOT NULL CHECK (length(level) <= 64),
+    eventname VARCHAR(128) NOT NULL CHECK (length(eventname) <= 128),
+    status VARCHAR(32) NOT NULL CHECK (length(status) <= 32),
+    userid VARCHAR(128) NOT NULL CHECK (length(userid) <= 128),
+    sessionid VARCHAR(26) NOT NULL CHECK (length(sessionid) <= 26),
+    ipaddress VARCHAR(64) NOT NULL CHECK (length(ipaddress) <= 64),
+    objecttype VARCHAR(64) NOT NULL CHECK (length(objecttype) <= 64),
+    objectid VARCHAR(64) NOT NULL CHECK (length(objectid) <= 64),
+    data TEXT NOT NULL,
+    PRIMARY KEY (id)
+);
+CREATE INDEX IF NOT EXISTS idx_auditrecords_create_at ON auditrecords (createat);
+CREATE INDEX IF NOT EXISTS idx_auditrecords_user_id_create_at ON auditrecords (userid, createat);
+CREATE INDEX IF NOT EXISTS idx_auditrecords_event_name_create_at ON auditrecords (eventname, createat);
+CREATE INDEX IF NOT EXISTS idx_auditrecords_object_id_create_at ON auditrecords (objectid, createat);
+CREATE INDEX IF NOT EXISTS idx_auditrecords_ip_address_create_at ON auditrecords (ipaddress, createat);
+
+CREATE TABLE IF NOT EXISTS retentionidsfordeletion (
+    id VARCHAR(26) CHECK (length(id) <= 26),
+    tablename VARCHAR(64) CHECK (length(tablename) <= 64),
+    ids TEXT,
+    PRIMARY KEY (id)
+);
+CREATE INDEX IF NOT EXISTS idx_retentionidsfordeletion_tablename ON retentionidsfordeletion (tablename);
+
+CREATE VIEW IF NOT EXISTS poststats AS
+SELECT userid, date(createat / 1000, 'unixepoch') AS day, COUNT(*) AS numposts, MAX(createat) AS lastpostdate
+FROM posts
+GROUP BY userid, day;
+
+CREATE VIEW IF NOT EXISTS attributeview AS
+SELECT
+    pv.groupid AS groupid,
+    pv.targetid AS targetid,
+    pv.targettype AS targettype,
+    json_group_object(
+        pf.name,
+        json(CASE
+            WHEN pf.type = 'select' THEN (
+                SELECT json_quote(options.value ->> '$.name')
+                FROM json_each(pf.attrs, '$.options') AS options
+                WHERE options.value ->> '$.id' = pv.value ->> '$'
+                LIMIT 1
+            )
+            WHEN pf.type = 'multiselect' AND json_type(pv.value) = 'array' THEN (
+                SELECT json_group_array(options.value ->> '$.name')
+                FROM json_each(pv.value) AS option_id
+                JOIN json_each(pf.attrs, '$.options') AS options
+                ON option_id.value = options.value ->> '$.id'
+            )
+            ELSE pv.value
+        END)
+    ) AS attributes
+FROM propertyvalues pv
+LEFT JOIN propertyfields pf ON pf.id = pv.fieldid
+WHERE (pv.deleteat = 0 OR pv.deleteat IS NULL) AND (pf.deleteat = 0 OR pf.deleteat IS NULL)
+GROUP BY pv.groupid, pv.targetid, pv.targettype;
+
+CREATE VIRTUAL TABLE IF NOT EXISTS fts_posts USING fts5 (
+    message,
+    hashtags,
+    content = 'posts',
+    tokenize = 'porter unicode61 remove_diacritics 2'
+);
+
+CREATE TRIGGER IF NOT EXISTS posts_fts_insert AFTER INSERT ON posts BEGIN
+    INSERT INTO fts_posts (rowid, message, hashtags) VALUES (new.rowid, new.message, new.hashtags);
+END;
+
+CREATE TRIGGER IF NOT EXISTS posts_fts_delete AFTER DELETE ON posts BEGIN
+    INSERT INTO fts_posts (fts_posts, rowid, message, hashtags) VALUES ('delete', old.rowid, old.message, old.hashtags);
+END;
+
+CREATE TRIGGER IF NOT EXISTS posts_fts_update AFTER UPDATE OF message, hashtags ON posts BEGIN
+    INSERT INTO fts_posts (fts_posts, rowid, message, hashtags) VALUES ('delete', old.rowid, old.message, old.hashtags);
+    INSERT INTO fts_posts (rowid, message, hashtags) VALUES (new.rowid, new.message, new.hashtags);
+END;
+
+CREATE VIRTUAL TABLE IF NOT EXISTS fts_fileinfo USING fts5 (
+    name,
+    content,
+    content = 'fileinfo',
+    tokenize = 'porter unicode61 remove_diacritics 2'
+);
+
+CREATE TRIGGER IF NOT EXISTS fileinfo_fts_insert AFTER INSERT ON fileinfo BEGIN
+    INSERT INTO fts_fileinfo (rowid, name, content) VALUES (new.rowid, new.name, new.content);
+END;
+
+CREATE TRIGGER IF NOT EXISTS fileinfo_fts_delete AFTER DELETE ON fileinfo BEGIN
+    INSERT INTO fts_fileinfo (fts_fileinfo, rowid, name, content) VALUES ('delete', old.rowid, old.name, old.content);
+END;
+
+CREATE TRIGGER IF NOT EXISTS fileinfo_fts_update AFTER UPDATE OF name, content ON fileinfo BEGIN
+    INSERT INTO fts_fileinfo (fts_fileinfo, rowid, name, content) VALUES ('delete', old.rowid, old.name, old.content);
+    INSERT INTO fts_fileinfo (rowid, name, content) VALUES (new.rowid, new.name, new.content);
+END;
@@ -0,0 +1 @@
+-- Nothing to do for SQLite
@@ -0,0 +1,17 @@
+CREATE TABLE jobs_new (
+    id VARCHAR(26) CHECK (length(id) <= 26),
+    type VARCHAR(32) CHECK (length(type) <= 32),
+    priority BIGINT,
+    createat BIGINT,
+    startat BIGINT,
+    lastactivityat BIGINT,
+    status VARCHAR(32) CHECK (length(status) <= 32),
+    progress BIGINT,
+    data TEXT,
+    PRIMARY KEY (id)
+);
+INSERT INTO jobs_new SELECT id, type, priority, createat, startat, lastactivityat, status, progress, data FROM jobs WHERE length(type) <= 32;
+DROP TABLE jobs;
+ALTER TABLE jobs_new RENAME TO jobs;
+CREATE INDEX IF NOT EXISTS idx_jobs_type ON jobs (type);
+CREATE INDEX IF NOT EXISTS idx_jobs_status_type ON jobs (status, type);
@@ -6,7 +6,9 @@ package export_process
 import (
 	"context"
 	"io"
+	"net/http"
 	"path/filepath"
+	"strconv"
 
 	"github.com/mattermost/mattermost/server/public/model"
 	"github.com/mattermost/mattermost/server/public/shared/configservice"
@@ -53,6 +55,12 @@ func MakeWorker(jobServer *jobs.JobServer, app AppIface) *jobs.SimpleWorker {
 			opts.IncludeRolesAndSchemes = true
 		}
 
+		since, err := resolveSince(jobServer, logger, job)
+		if err != nil {
+			return err
+		}
+		opts.Since = since
+
 		outPath := *app.Config().ExportSettings.Directory
 		exportFilename := job.Id + "_export.zip"
 
@@ -81,3 +89,35 @@ func MakeWorker(jobServer *jobs.JobServer, app AppIface) *jobs.SimpleWorker {
 	worker := jobs.NewSimpleWorker(workerName, jobServer, execute, isEnabled)
 	return worker
 }
+
+// resolveSince returns the time from which an incremental export includes
+// changes. It is read from the "since" job data in milliseconds or, when the
+// job refers to a previous export through "previous_job_id", taken from the
+// start of that export. A full export is done when neither is set.
+func resolveSince(jobServer *jobs.JobServer, logger mlog.LoggerIFace, job *model.Job) (int64, error) {
+	if value, ok := job.Data["since"]; ok && value != "" {
+		since, err := strconv.ParseInt(value, 10, 64)
+		if err != nil || since < 0 {
+			return 0, model.NewAppError("ExportProcessWorker", "export_process.worker.do_job.invalid_since", nil, "", http.StatusBadRequest).Wrap(err)
+		}
+		return since, nil
+	}
+
+	previousJobID, ok := job.Data["previous_job_id"]
+	if !ok || previousJobID == "" {
+		return 0, nil
+	}
+
+	previousJob, err := jobServer.Store.Job().Get(request.EmptyContext(logger), previousJobID)
+	if err != nil {
+		return 0, model.NewAppError("ExportProcessWorker", "export_process.worker.do_job.previous_job_not_found", nil, "", http.StatusBadRequest).Wrap(err)
+	}
+	if previousJob.Type != model.JobTypeExportProcess || previousJob.Status != model.JobStatusSuccess {
+		return 0, model.NewAppError("ExportProcessWorker", "export_process.worker.do_job.previous_job_invalid", nil, "", http.StatusBadRequest)
+	}
+
+	// Changes made while the previous export was running may or may not be
+	// part of it, so they are exported again.
+	job.Data["since"] = strconv.FormatInt(previousJob.StartAt, 10)
+	return previousJob.StartAt, nil
+}
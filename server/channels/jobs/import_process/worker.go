@@ -5,6 +5,7 @@ package import_process
 
 import (
 	"archive/zip"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
@@ -19,6 +20,7 @@ import (
 	"github.com/mattermost/mattermost/server/public/shared/configservice"
 	"github.com/mattermost/mattermost/server/public/shared/mlog"
 	"github.com/mattermost/mattermost/server/public/shared/request"
+	"github.com/mattermost/mattermost/server/v8/channels/app/imports"
 	"github.com/mattermost/mattermost/server/v8/channels/jobs"
 	"github.com/mattermost/mattermost/server/v8/platform/shared/filestore"
 )
@@ -29,7 +31,7 @@ type AppIface interface {
 	FileExists(path string) (bool, *model.AppError)
 	FileSize(path string) (int64, *model.AppError)
 	FileReader(path string) (filestore.ReadCloseSeeker, *model.AppError)
-	BulkImportWithPath(c request.CTX, jsonlReader io.Reader, attachmentsReader *zip.Reader, dryRun, extractContent bool, workers int, importPath string) (int, *model.AppError)
+	BulkImportWithOpts(c request.CTX, jsonlReader io.Reader, attachmentsReader *zip.Reader, opts imports.BulkImportOpts) (*imports.ImportReport, int, *model.AppError)
 	Log() *mlog.Logger
 }
 
@@ -123,16 +125,34 @@ func MakeWorker(jobServer *jobs.JobServer, app AppIface) *jobs.SimpleWorker {
 			return model.NewAppError("ImportProcessWorker", "import_process.worker.do_job.missing_jsonl", nil, "jsonFile was nil", http.StatusBadRequest)
 		}
 
-		extractContent := job.Data["extract_content"] == "true"
+		opts := imports.BulkImportOpts{
+			DryRun:         job.Data["dry_run"] == "true",
+			ExtractContent: job.Data["extract_content"] == "true",
+			Workers:        runtime.NumCPU(),
+			ImportPath:     model.ExportDataDir,
+			ConflictPolicy: imports.ConflictPolicy(job.Data["conflict_policy"]),
+		}
+
 		// do the actual import.
-		lineNumber, appErr := app.BulkImportWithPath(appContext, jsonFile, importZipReader, false, extractContent, runtime.NumCPU(), model.ExportDataDir)
+		report, lineNumber, appErr := app.BulkImportWithOpts(appContext, jsonFile, importZipReader, opts)
+		if report != nil {
+			if reportJSON, err := json.Marshal(report); err == nil {
+				job.Data["import_report"] = string(reportJSON)
+			}
+		}
 		if appErr != nil {
 			job.Data["line_number"] = strconv.Itoa(lineNumber)
 			return appErr
 		}
 
-		// No need to remove the file in local mode.
-		if job.Data["local_mode"] != "true" {
+		// Keep the report with the job, as it's what a dry run is for.
+		if appErr := jobServer.UpdateInProgressJobData(job); appErr != nil {
+			return appErr
+		}
+
+		// No need to remove the file in local mode, nor after a dry run as
+		// it's about to be imported for real.
+		if job.Data["local_mode"] != "true" && !opts.DryRun {
 			// remove import file when done.
 			if appErr := app.RemoveFile(importFilePath); appErr != nil {
 				return appErr
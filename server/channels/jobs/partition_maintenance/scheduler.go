@@ -0,0 +1,20 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package partition_maintenance
+
+import (
+	"time"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/v8/channels/jobs"
+)
+
+const schedFreq = 24 * time.Hour
+
+func MakeScheduler(jobServer *jobs.JobServer, sqlDriverName string) *jobs.PeriodicScheduler {
+	isEnabled := func(cfg *model.Config) bool {
+		return sqlDriverName == model.DatabaseDriverPostgres
+	}
+	return jobs.NewPeriodicScheduler(jobServer, model.JobTypePartitionMaintenance, schedFreq, isEnabled)
+}
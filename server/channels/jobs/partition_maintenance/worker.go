@@ -0,0 +1,56 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package partition_maintenance
+
+import (
+	"time"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/public/shared/mlog"
+	"github.com/mattermost/mattermost/server/v8/channels/jobs"
+)
+
+const (
+	jobName = "PartitionMaintenance"
+
+	// monthsAhead is the number of monthly partitions kept ahead of the
+	// current one, so that the job can miss a few runs.
+	monthsAhead = 3
+)
+
+func MakeWorker(jobServer *jobs.JobServer, sqlDriverName string) *jobs.SimpleWorker {
+	isEnabled := func(cfg *model.Config) bool {
+		return sqlDriverName == model.DatabaseDriverPostgres
+	}
+	execute := func(logger mlog.LoggerIFace, job *model.Job) error {
+		defer jobServer.HandleJobPanic(logger, job)
+
+		now := time.Now()
+		created, err := jobServer.Store.Partition().CreatePartitions(now.AddDate(0, monthsAhead, 0).UnixMilli())
+		if err != nil {
+			return err
+		}
+		for _, partition := range created {
+			logger.Info("Created partition", mlog.String("table", partition.Table), mlog.String("partition", partition.Name))
+		}
+
+		archiveAfterDays := *jobServer.Config().SqlSettings.PartitionArchiveAfterDays
+		if archiveAfterDays == 0 {
+			return nil
+		}
+
+		archived, err := jobServer.Store.Partition().ArchivePartitions(now.AddDate(0, 0, -archiveAfterDays).UnixMilli())
+		if err != nil {
+			return err
+		}
+		for _, partition := range archived {
+			logger.Info("Archived partition", mlog.String("table", partition.Table), mlog.String("partition", partition.Name))
+		}
+
+		return nil
+	}
+
+	worker := jobs.NewSimpleWorker(jobName, jobServer, execute, isEnabled)
+	return worker
+}
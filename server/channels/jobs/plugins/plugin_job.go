@@ -0,0 +1,198 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package plugins
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/public/shared/mlog"
+	"github.com/mattermost/mattermost/server/public/shared/request"
+	"github.com/mattermost/mattermost/server/v8/channels/jobs"
+)
+
+// PluginJobAppIface is the subset of the app used to run the jobs registered by plugins.
+type PluginJobAppIface interface {
+	IsPluginActive(pluginID string) (bool, error)
+	RunPluginJob(ctx context.Context, pluginID string, job *model.Job) error
+}
+
+// JobWorker runs the jobs of a job type registered by a plugin by invoking the plugin's RunJob hook.
+type JobWorker struct {
+	pluginID  string
+	stop      chan bool
+	stopped   chan bool
+	jobs      chan model.Job
+	jobServer *jobs.JobServer
+	logger    mlog.LoggerIFace
+	app       PluginJobAppIface
+
+	cancelMut sync.Mutex
+	cancelJob context.CancelFunc
+}
+
+func MakeJobWorker(jobServer *jobs.JobServer, app PluginJobAppIface, pluginID, jobType string) *JobWorker {
+	worker := JobWorker{
+		pluginID:  pluginID,
+		stop:      make(chan bool, 1),
+		stopped:   make(chan bool, 1),
+		jobs:      make(chan model.Job),
+		jobServer: jobServer,
+		logger:    jobServer.Logger().With(mlog.String("worker_name", jobType), mlog.String("plugin_id", pluginID)),
+		app:       app,
+	}
+
+	return &worker
+}
+
+func (worker *JobWorker) Run() {
+	worker.logger.Debug("Worker started")
+
+	defer func() {
+		worker.logger.Debug("Worker finished")
+		worker.stopped <- true
+	}()
+
+	for {
+		select {
+		case <-worker.stop:
+			worker.logger.Debug("Worker received stop signal")
+			return
+		case job := <-worker.jobs:
+			worker.DoJob(&job)
+		}
+	}
+}
+
+func (worker *JobWorker) Stop() {
+	worker.logger.Debug("Worker stopping")
+
+	// The current job is interrupted for the worker not to wait for the plugin to finish it.
+	worker.cancelMut.Lock()
+	if worker.cancelJob != nil {
+		worker.cancelJob()
+	}
+	worker.cancelMut.Unlock()
+
+	worker.stop <- true
+	<-worker.stopped
+}
+
+func (worker *JobWorker) JobChannel() chan<- model.Job {
+	return worker.jobs
+}
+
+func (worker *JobWorker) IsEnabled(cfg *model.Config) bool {
+	return true
+}
+
+func (worker *JobWorker) DoJob(job *model.Job) {
+	logger := worker.logger.With(jobs.JobLoggerFields(job)...)
+	logger.Debug("Worker: Received a new candidate job.")
+
+	// The plugin may not be running on this node yet. Leave the job pending so that it's picked
+	// up again once the plugin is running.
+	if active, _ := worker.app.IsPluginActive(worker.pluginID); !active {
+		logger.Debug("Worker: Plugin is not active, skipping job.")
+		return
+	}
+
+	var appErr *model.AppError
+	job, appErr = worker.jobServer.ClaimJob(job)
+	if appErr != nil {
+		logger.Warn("Worker experienced an error while trying to claim job", mlog.Err(appErr))
+		return
+	} else if job == nil {
+		return
+	}
+
+	var cancelContext request.CTX = request.EmptyContext(worker.logger)
+	cancelCtx, cancelJob := context.WithCancel(context.Background())
+	cancelWatcherChan := make(chan struct{}, 1)
+	cancelContext = cancelContext.WithContext(cancelCtx)
+	go worker.jobServer.CancellationWatcher(cancelContext, job.Id, cancelWatcherChan)
+	go func() {
+		select {
+		case <-cancelWatcherChan:
+			cancelJob()
+		case <-cancelCtx.Done():
+		}
+	}()
+
+	worker.cancelMut.Lock()
+	worker.cancelJob = cancelJob
+	worker.cancelMut.Unlock()
+
+	runErr := worker.app.RunPluginJob(cancelCtx, worker.pluginID, job)
+
+	stopped := cancelCtx.Err() != nil
+	worker.cancelMut.Lock()
+	worker.cancelJob = nil
+	worker.cancelMut.Unlock()
+	cancelJob()
+
+	// The plugin may have updated the job, or its cancellation may have been requested while it ran.
+	c := request.EmptyContext(logger)
+	if latest, err := worker.jobServer.GetJob(c, job.Id); err != nil {
+		logger.Warn("Worker: Failed to reload job", mlog.Err(err))
+	} else {
+		job = latest
+	}
+
+	if job.Status == model.JobStatusCancelRequested {
+		logger.Info("Worker: Job has been canceled")
+		if err := worker.jobServer.SetJobCanceled(job); err != nil {
+			logger.Error("Worker: Failed to mark job as canceled", mlog.Err(err))
+		}
+		return
+	}
+
+	if stopped {
+		logger.Info("Worker: Job has been interrupted via Worker Stop. Setting the job back to pending.")
+		if err := worker.jobServer.SetJobPending(job); err != nil {
+			logger.Error("Worker: Failed to mark job as pending", mlog.Err(err))
+		}
+		return
+	}
+
+	if runErr != nil {
+		logger.Error("Worker: Plugin failed to run job", mlog.Err(runErr))
+		worker.setJobError(logger, job, model.NewAppError("DoJob", "app.plugin.run_job.app_error", nil, "", http.StatusInternalServerError).Wrap(runErr))
+		return
+	}
+
+	logger.Info("Worker: Job is complete")
+	worker.setJobSuccess(logger, job)
+}
+
+func (worker *JobWorker) setJobSuccess(logger mlog.LoggerIFace, job *model.Job) {
+	if err := worker.jobServer.SetJobProgress(job, 100); err != nil {
+		logger.Error("Worker: Failed to update progress for job", mlog.Err(err))
+		worker.setJobError(logger, job, err)
+		return
+	}
+
+	if err := worker.jobServer.SetJobSuccess(job); err != nil {
+		logger.Error("Worker: Failed to set success for job", mlog.Err(err))
+		worker.setJobError(logger, job, err)
+	}
+}
+
+func (worker *JobWorker) setJobError(logger mlog.LoggerIFace, job *model.Job, appError *model.AppError) {
+	if err := worker.jobServer.SetJobError(job, appError); err != nil {
+		logger.Error("Worker: Failed to set job error", mlog.Err(err))
+	}
+}
+
+// MakeJobScheduler returns a scheduler creating a job of the given type at the given interval. The
+// scheduler is unregistered along with the job type when the plugin is deactivated.
+func MakeJobScheduler(jobServer *jobs.JobServer, jobType string, interval time.Duration) *jobs.PeriodicScheduler {
+	isEnabled := func(cfg *model.Config) bool {
+		return true
+	}
+	return jobs.NewPeriodicScheduler(jobServer, jobType, interval, isEnabled)
+}
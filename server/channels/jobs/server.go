@@ -76,6 +76,15 @@ func (srv *JobServer) RegisterJobType(name string, worker model.Worker, schedule
 	}
 }
 
+// UnregisterJobType removes the worker and scheduler of the given job type, stopping the worker if
+// it's running. Pending jobs of the type are left untouched.
+func (srv *JobServer) UnregisterJobType(name string) {
+	srv.mut.Lock()
+	defer srv.mut.Unlock()
+	srv.workers.RemoveWorker(name)
+	srv.schedulers.RemoveScheduler(name)
+}
+
 func (srv *JobServer) StartWorkers() error {
 	srv.mut.Lock()
 	defer srv.mut.Unlock()
@@ -5,10 +5,15 @@ package jobs
 
 import (
 	"os"
+	"sync/atomic"
 	"testing"
 	"time"
 
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/public/plugin/plugintest/mock"
 )
 
 func TestStartWorkers(t *testing.T) {
@@ -138,3 +143,71 @@ func TestStopSchedulers(t *testing.T) {
 		require.NoError(t, err)
 	})
 }
+
+type countingWorker struct {
+	runs  atomic.Int32
+	stops atomic.Int32
+}
+
+func (w *countingWorker) Run()                             { w.runs.Add(1) }
+func (w *countingWorker) Stop()                            { w.stops.Add(1) }
+func (w *countingWorker) JobChannel() chan<- model.Job     { return nil }
+func (w *countingWorker) IsEnabled(cfg *model.Config) bool { return true }
+
+func TestRegisterJobTypeWhileRunning(t *testing.T) {
+	if os.Getenv("ENABLE_FULLY_PARALLEL_TESTS") == "true" {
+		t.Parallel()
+	}
+
+	t.Run("workers", func(t *testing.T) {
+		jobServer, _, _ := makeJobServer(t)
+		jobServer.initWorkers()
+		jobServer.initSchedulers()
+		require.NoError(t, jobServer.StartWorkers())
+		// Parking the go routing to let the worker watcher start
+		time.Sleep(1 * time.Millisecond)
+
+		worker := &countingWorker{}
+		jobServer.RegisterJobType("plugin:com.example.plugin:sync", worker, nil)
+		require.Eventually(t, func() bool { return worker.runs.Load() == 1 }, time.Second, 10*time.Millisecond)
+		require.Equal(t, worker, jobServer.workers.Get("plugin:com.example.plugin:sync"))
+
+		// Replacing the worker stops the previous one.
+		replacement := &countingWorker{}
+		jobServer.RegisterJobType("plugin:com.example.plugin:sync", replacement, nil)
+		require.Equal(t, int32(1), worker.stops.Load())
+		require.Eventually(t, func() bool { return replacement.runs.Load() == 1 }, time.Second, 10*time.Millisecond)
+
+		jobServer.UnregisterJobType("plugin:com.example.plugin:sync")
+		require.Equal(t, int32(1), replacement.stops.Load())
+		require.Nil(t, jobServer.workers.Get("plugin:com.example.plugin:sync"))
+
+		require.NoError(t, jobServer.StopWorkers())
+		assert.Equal(t, int32(1), replacement.stops.Load())
+	})
+
+	t.Run("schedulers", func(t *testing.T) {
+		jobServer, mockStore, _ := makeJobServer(t)
+		mockStore.JobStore.On("GetCountByStatusAndType", model.JobStatusPending, "plugin:com.example.plugin:sync").Return(int64(0), nil)
+		mockStore.JobStore.On("GetNewestJobByStatusesAndType", mock.AnythingOfType("[]string"), "plugin:com.example.plugin:sync").Return(nil, nil)
+
+		jobServer.initWorkers()
+		jobServer.initSchedulers()
+		require.NoError(t, jobServer.StartSchedulers())
+
+		jobServer.RegisterJobType("plugin:com.example.plugin:sync", nil, new(MockScheduler))
+
+		jobServer.schedulers.mut.Lock()
+		assert.NotNil(t, jobServer.schedulers.nextRunTimes["plugin:com.example.plugin:sync"])
+		jobServer.schedulers.mut.Unlock()
+
+		jobServer.UnregisterJobType("plugin:com.example.plugin:sync")
+
+		jobServer.schedulers.mut.Lock()
+		assert.NotContains(t, jobServer.schedulers.schedulers, "plugin:com.example.plugin:sync")
+		assert.NotContains(t, jobServer.schedulers.nextRunTimes, "plugin:com.example.plugin:sync")
+		jobServer.schedulers.mut.Unlock()
+
+		require.NoError(t, jobServer.StopSchedulers())
+	})
+}
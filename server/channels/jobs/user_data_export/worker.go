@@ -0,0 +1,77 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package user_data_export
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"path/filepath"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/public/shared/configservice"
+	"github.com/mattermost/mattermost/server/public/shared/mlog"
+	"github.com/mattermost/mattermost/server/public/shared/request"
+	"github.com/mattermost/mattermost/server/v8/channels/jobs"
+)
+
+type AppIface interface {
+	configservice.ConfigService
+	WriteExportFileContext(ctx context.Context, fr io.Reader, path string) (int64, *model.AppError)
+	UserDataExport(rctx request.CTX, writer io.Writer, userID string, job *model.Job) *model.AppError
+	SendUserDataExportReadyMessage(rctx request.CTX, job *model.Job) *model.AppError
+}
+
+func MakeWorker(jobServer *jobs.JobServer, app AppIface) *jobs.SimpleWorker {
+	const workerName = "UserDataExport"
+
+	isEnabled := func(cfg *model.Config) bool {
+		return *cfg.ExportSettings.EnableUserDataExport
+	}
+	execute := func(logger mlog.LoggerIFace, job *model.Job) error {
+		defer jobServer.HandleJobPanic(logger, job)
+
+		userID := job.Data["user_id"]
+		if userID == "" {
+			return model.NewAppError("UserDataExportWorker", "user_data_export.worker.do_job.missing_user_id", nil, "", http.StatusBadRequest)
+		}
+
+		outPath := *app.Config().ExportSettings.Directory
+		// The name ends like the ones of the bulk exports for the file to be
+		// deleted after the export retention period.
+		exportFilename := job.Id + "_user_data_export.zip"
+
+		rd, wr := io.Pipe()
+
+		go func() {
+			_, appErr := app.WriteExportFileContext(context.Background(), rd, filepath.Join(outPath, exportFilename))
+			if appErr != nil {
+				// Close the reader to prevent the exporter from blocking on
+				// the pipe, see export_process.
+				rd.CloseWithError(appErr) // CloseWithError never returns an error
+			}
+		}()
+
+		rctx := request.EmptyContext(logger)
+		appErr := app.UserDataExport(rctx, wr, userID, job)
+		wr.Close() // Close never returns an error
+
+		if appErr != nil {
+			return appErr
+		}
+
+		job.Data["export_file"] = exportFilename
+		if appErr := jobServer.UpdateInProgressJobData(job); appErr != nil {
+			return appErr
+		}
+
+		if appErr := app.SendUserDataExportReadyMessage(rctx, job); appErr != nil {
+			logger.Warn("Worker: Failed to notify the user of the data export", mlog.String("user_id", userID), mlog.Err(appErr))
+		}
+
+		return nil
+	}
+	worker := jobs.NewSimpleWorker(workerName, jobServer, execute, isEnabled)
+	return worker
+}
@@ -0,0 +1,63 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package user_erasure
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/public/shared/mlog"
+	"github.com/mattermost/mattermost/server/public/shared/request"
+	"github.com/mattermost/mattermost/server/v8/channels/jobs"
+)
+
+type AppIface interface {
+	EraseUser(rctx request.CTX, userID, username string, dryRun bool) (*model.UserErasureReport, *model.AppError)
+}
+
+func MakeWorker(jobServer *jobs.JobServer, app AppIface) *jobs.SimpleWorker {
+	const workerName = "UserErasure"
+
+	isEnabled := func(cfg *model.Config) bool { return true }
+	execute := func(logger mlog.LoggerIFace, job *model.Job) error {
+		defer jobServer.HandleJobPanic(logger, job)
+
+		userID := job.Data[model.UserErasureJobDataUserId]
+		if userID == "" {
+			return model.NewAppError("UserErasureWorker", "user_erasure.worker.do_job.missing_user_id", nil, "", http.StatusBadRequest)
+		}
+		dryRun := job.Data[model.UserErasureJobDataDryRun] == "true"
+
+		// The username is stored before erasing for a retry of the job to
+		// reuse it.
+		username := job.Data[model.UserErasureJobDataUsername]
+		if !dryRun && username == "" {
+			username = model.ErasedUsername()
+			job.Data[model.UserErasureJobDataUsername] = username
+			if appErr := jobServer.UpdateInProgressJobData(job); appErr != nil {
+				return appErr
+			}
+		}
+
+		report, appErr := app.EraseUser(request.EmptyContext(logger), userID, username, dryRun)
+		if appErr != nil {
+			return appErr
+		}
+
+		data, err := json.Marshal(report)
+		if err != nil {
+			return model.NewAppError("UserErasureWorker", "user_erasure.worker.do_job.report", nil, "", http.StatusInternalServerError).Wrap(err)
+		}
+
+		job.Data[model.UserErasureJobDataReport] = string(data)
+		if appErr := jobServer.UpdateInProgressJobData(job); appErr != nil {
+			return appErr
+		}
+
+		return nil
+	}
+	worker := jobs.NewSimpleWorker(workerName, jobServer, execute, isEnabled)
+	return worker
+}
@@ -5,6 +5,7 @@ package jobs
 
 import (
 	"errors"
+	"sync"
 
 	"github.com/mattermost/mattermost/server/public/model"
 	"github.com/mattermost/mattermost/server/public/shared/configservice"
@@ -15,8 +16,9 @@ type Workers struct {
 	ConfigService configservice.ConfigService
 	Watcher       *Watcher
 
-	workers map[string]model.Worker
-
+	// mut is used to protect the following fields from concurrent access.
+	mut        sync.RWMutex
+	workers    map[string]model.Worker
 	listenerId string
 	running    bool
 }
@@ -34,11 +36,50 @@ func NewWorkers(configService configservice.ConfigService) *Workers {
 	}
 }
 
+// AddWorker registers the worker for the given job type, replacing any existing one. If the
+// workers are already running, the previous worker is stopped and the new one started.
 func (workers *Workers) AddWorker(name string, worker model.Worker) {
+	workers.mut.Lock()
+	cfg := workers.ConfigService.Config()
+	old := workers.workers[name]
+	stopOld := old != nil && workers.running && old.IsEnabled(cfg)
+
 	workers.workers[name] = worker
+
+	if workers.running && worker.IsEnabled(cfg) {
+		go worker.Run()
+	}
+	workers.mut.Unlock()
+
+	// Stopping waits for the current job of the worker, so it's done without holding the lock.
+	if stopOld {
+		old.Stop()
+	}
+}
+
+// RemoveWorker unregisters the worker for the given job type, stopping it if the workers are running.
+func (workers *Workers) RemoveWorker(name string) {
+	workers.mut.Lock()
+	worker := workers.workers[name]
+	if worker == nil {
+		workers.mut.Unlock()
+		return
+	}
+
+	stop := workers.running && worker.IsEnabled(workers.ConfigService.Config())
+	delete(workers.workers, name)
+	workers.mut.Unlock()
+
+	// Stopping waits for the current job of the worker, so it's done without holding the lock.
+	if stop {
+		worker.Stop()
+	}
 }
 
 func (workers *Workers) Get(name string) model.Worker {
+	workers.mut.RLock()
+	defer workers.mut.RUnlock()
+
 	return workers.workers[name]
 }
 
@@ -47,6 +88,9 @@ func (workers *Workers) Get(name string) model.Worker {
 func (workers *Workers) Start() {
 	mlog.Info("Starting workers")
 
+	workers.mut.Lock()
+	defer workers.mut.Unlock()
+
 	for _, w := range workers.workers {
 		if w.IsEnabled(workers.ConfigService.Config()) {
 			go w.Run()
@@ -62,6 +106,9 @@ func (workers *Workers) Start() {
 func (workers *Workers) handleConfigChange(oldConfig *model.Config, newConfig *model.Config) {
 	mlog.Debug("Workers received config change.")
 
+	workers.mut.RLock()
+	defer workers.mut.RUnlock()
+
 	for _, w := range workers.workers {
 		if w.IsEnabled(oldConfig) && !w.IsEnabled(newConfig) {
 			w.Stop()
@@ -79,6 +126,9 @@ func (workers *Workers) Stop() {
 
 	workers.Watcher.Stop()
 
+	workers.mut.Lock()
+	defer workers.mut.Unlock()
+
 	for _, w := range workers.workers {
 		if w.IsEnabled(workers.ConfigService.Config()) {
 			w.Stop()
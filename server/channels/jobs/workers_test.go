@@ -0,0 +1,91 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package jobs
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/v8/channels/utils/testutils"
+)
+
+// blockingWorker is a worker whose Stop waits to be released, as a worker finishing its current job does.
+type blockingWorker struct {
+	stopping chan struct{}
+	release  chan struct{}
+}
+
+func newBlockingWorker() *blockingWorker {
+	return &blockingWorker{
+		stopping: make(chan struct{}),
+		release:  make(chan struct{}),
+	}
+}
+
+func (w *blockingWorker) Run() {}
+
+func (w *blockingWorker) Stop() {
+	close(w.stopping)
+	<-w.release
+}
+
+func (w *blockingWorker) JobChannel() chan<- model.Job {
+	return nil
+}
+
+func (w *blockingWorker) IsEnabled(cfg *model.Config) bool {
+	return true
+}
+
+func TestWorkersStopWithoutLock(t *testing.T) {
+	workers := NewWorkers(&testutils.StaticConfigService{Cfg: &model.Config{}})
+	workers.running = true
+
+	t.Run("remove", func(t *testing.T) {
+		worker := newBlockingWorker()
+		workers.AddWorker("blocking", worker)
+
+		removed := make(chan struct{})
+		go func() {
+			workers.RemoveWorker("blocking")
+			close(removed)
+		}()
+		<-worker.stopping
+
+		assert.Nil(t, workers.Get("blocking"))
+
+		close(worker.release)
+		select {
+		case <-removed:
+		case <-time.After(5 * time.Second):
+			require.Fail(t, "RemoveWorker didn't return")
+		}
+	})
+
+	t.Run("replace", func(t *testing.T) {
+		old := newBlockingWorker()
+		workers.AddWorker("blocking", old)
+
+		worker := newBlockingWorker()
+		added := make(chan struct{})
+		go func() {
+			workers.AddWorker("blocking", worker)
+			close(added)
+		}()
+		<-old.stopping
+
+		assert.Equal(t, worker, workers.Get("blocking"))
+
+		close(old.release)
+		select {
+		case <-added:
+		case <-time.After(5 * time.Second):
+			require.Fail(t, "AddWorker didn't return")
+		}
+	})
+}
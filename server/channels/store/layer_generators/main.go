@@ -23,7 +23,7 @@ const (
 )
 
 func isError(typeName string) bool {
-	return strings.Contains(typeName, ErrorType)
+	return typeName == ErrorType
 }
 
 func main() {
@@ -235,6 +235,16 @@ func generateLayer(name, templateFile string) ([]byte, error) {
 			}
 			return ""
 		},
+		"tracingParam": func(params []methodParam) *methodParam {
+			// Only the methods receiving the context of the request can
+			// attach their span to its trace.
+			for i, param := range params {
+				if param.Type == "request.CTX" || param.Type == "context.Context" {
+					return &params[i]
+				}
+			}
+			return nil
+		},
 		"joinParams": func(params []methodParam) string {
 			paramsNames := make([]string, 0, len(params))
 			for _, param := range params {
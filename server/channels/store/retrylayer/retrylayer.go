@@ -26,6 +26,7 @@ type RetryLayer struct {
 	AccessControlPolicyStore        store.AccessControlPolicyStore
 	AttributesStore                 store.AttributesStore
 	AuditStore                      store.AuditStore
+	AuditRecordStore                store.AuditRecordStore
 	BotStore                        store.BotStore
 	ChannelStore                    store.ChannelStore
 	ChannelBookmarkStore            store.ChannelBookmarkStore
@@ -45,6 +46,7 @@ type RetryLayer struct {
 	NotifyAdminStore                store.NotifyAdminStore
 	OAuthStore                      store.OAuthStore
 	OutgoingOAuthConnectionStore    store.OutgoingOAuthConnectionStore
+	PartitionStore                  store.PartitionStore
 	PluginStore                     store.PluginStore
 	PostStore                       store.PostStore
 	PostAcknowledgementStore        store.PostAcknowledgementStore
@@ -88,6 +90,10 @@ func (s *RetryLayer) Audit() store.AuditStore {
 	return s.AuditStore
 }
 
+func (s *RetryLayer) AuditRecord() store.AuditRecordStore {
+	return s.AuditRecordStore
+}
+
 func (s *RetryLayer) Bot() store.BotStore {
 	return s.BotStore
 }
@@ -164,6 +170,10 @@ func (s *RetryLayer) OutgoingOAuthConnection() store.OutgoingOAuthConnectionStor
 	return s.OutgoingOAuthConnectionStore
 }
 
+func (s *RetryLayer) Partition() store.PartitionStore {
+	return s.PartitionStore
+}
+
 func (s *RetryLayer) Plugin() store.PluginStore {
 	return s.PluginStore
 }
@@ -295,6 +305,11 @@ type RetryLayerAuditStore struct {
 	Root *RetryLayer
 }
 
+type RetryLayerAuditRecordStore struct {
+	store.AuditRecordStore
+	Root *RetryLayer
+}
+
 type RetryLayerBotStore struct {
 	store.BotStore
 	Root *RetryLayer
@@ -390,6 +405,11 @@ type RetryLayerOutgoingOAuthConnectionStore struct {
 	Root *RetryLayer
 }
 
+type RetryLayerPartitionStore struct {
+	store.PartitionStore
+	Root *RetryLayer
+}
+
 type RetryLayerPluginStore struct {
 	store.PluginStore
 	Root *RetryLayer
@@ -803,6 +823,111 @@ func (s *RetryLayerAuditStore) Save(audit *model.Audit) error {
 
 }
 
+func (s *RetryLayerAuditRecordStore) GetCountForUser(userID string) (int64, error) {
+
+	tries := 0
+	for {
+		result, err := s.AuditRecordStore.GetCountForUser(userID)
+		if err == nil {
+			return result, nil
+		}
+		if !isRepeatableError(err) {
+			return result, err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return result, err
+		}
+		timepkg.Sleep(100 * timepkg.Millisecond)
+	}
+
+}
+
+func (s *RetryLayerAuditRecordStore) PermanentDeleteBatch(endTime int64, limit int64) (int64, error) {
+
+	tries := 0
+	for {
+		result, err := s.AuditRecordStore.PermanentDeleteBatch(endTime, limit)
+		if err == nil {
+			return result, nil
+		}
+		if !isRepeatableError(err) {
+			return result, err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return result, err
+		}
+		timepkg.Sleep(100 * timepkg.Millisecond)
+	}
+
+}
+
+func (s *RetryLayerAuditRecordStore) SaveMultiple(records []*model.StoredAuditRecord) ([]error, error) {
+
+	tries := 0
+	for {
+		result, err := s.AuditRecordStore.SaveMultiple(records)
+		if err == nil {
+			return result, nil
+		}
+		if !isRepeatableError(err) {
+			return result, err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return result, err
+		}
+		timepkg.Sleep(100 * timepkg.Millisecond)
+	}
+
+}
+
+func (s *RetryLayerAuditRecordStore) ScrubForUser(userID string) (int64, error) {
+
+	tries := 0
+	for {
+		result, err := s.AuditRecordStore.ScrubForUser(userID)
+		if err == nil {
+			return result, nil
+		}
+		if !isRepeatableError(err) {
+			return result, err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return result, err
+		}
+		timepkg.Sleep(100 * timepkg.Millisecond)
+	}
+
+}
+
+func (s *RetryLayerAuditRecordStore) Search(opts model.AuditRecordSearchOptions) ([]*model.StoredAuditRecord, error) {
+
+	tries := 0
+	for {
+		result, err := s.AuditRecordStore.Search(opts)
+		if err == nil {
+			return result, nil
+		}
+		if !isRepeatableError(err) {
+			return result, err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return result, err
+		}
+		timepkg.Sleep(100 * timepkg.Millisecond)
+	}
+
+}
+
 func (s *RetryLayerBotStore) Get(userID string, includeDeleted bool) (*model.Bot, error) {
 
 	tries := 0
@@ -2144,11 +2269,11 @@ func (s *RetryLayerChannelStore) GetMembersByIds(channelID string, userIds []str
 
 }
 
-func (s *RetryLayerChannelStore) GetMembersForUser(teamID string, userID string) (model.ChannelMembers, error) {
+func (s *RetryLayerChannelStore) GetMembersForUser(ctx context.Context, teamID string, userID string) (model.ChannelMembers, error) {
 
 	tries := 0
 	for {
-		result, err := s.ChannelStore.GetMembersForUser(teamID, userID)
+		result, err := s.ChannelStore.GetMembersForUser(ctx, teamID, userID)
 		if err == nil {
 			return result, nil
 		}
@@ -4544,6 +4669,27 @@ func (s *RetryLayerEmojiStore) GetByName(c request.CTX, name string, allowFromCa
 
 }
 
+func (s *RetryLayerEmojiStore) GetDeletedSince(since int64, offset int, limit int) ([]*model.Emoji, error) {
+
+	tries := 0
+	for {
+		result, err := s.EmojiStore.GetDeletedSince(since, offset, limit)
+		if err == nil {
+			return result, nil
+		}
+		if !isRepeatableError(err) {
+			return result, err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return result, err
+		}
+		timepkg.Sleep(100 * timepkg.Millisecond)
+	}
+
+}
+
 func (s *RetryLayerEmojiStore) GetList(offset int, limit int, sort string) ([]*model.Emoji, error) {
 
 	tries := 0
@@ -7391,6 +7537,96 @@ func (s *RetryLayerOutgoingOAuthConnectionStore) UpdateConnection(c request.CTX,
 
 }
 
+func (s *RetryLayerPartitionStore) ArchivePartitions(before int64) ([]*model.TablePartition, error) {
+
+	tries := 0
+	for {
+		result, err := s.PartitionStore.ArchivePartitions(before)
+		if err == nil {
+			return result, nil
+		}
+		if !isRepeatableError(err) {
+			return result, err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return result, err
+		}
+		timepkg.Sleep(100 * timepkg.Millisecond)
+	}
+
+}
+
+func (s *RetryLayerPartitionStore) CreatePartitions(until int64) ([]*model.TablePartition, error) {
+
+	tries := 0
+	for {
+		result, err := s.PartitionStore.CreatePartitions(until)
+		if err == nil {
+			return result, nil
+		}
+		if !isRepeatableError(err) {
+			return result, err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return result, err
+		}
+		timepkg.Sleep(100 * timepkg.Millisecond)
+	}
+
+}
+
+func (s *RetryLayerPartitionStore) GetPartitions(tableName string) ([]*model.TablePartition, error) {
+
+	tries := 0
+	for {
+		result, err := s.PartitionStore.GetPartitions(tableName)
+		if err == nil {
+			return result, nil
+		}
+		if !isRepeatableError(err) {
+			return result, err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return result, err
+		}
+		timepkg.Sleep(100 * timepkg.Millisecond)
+	}
+
+}
+
+func (s *RetryLayerPartitionStore) IsPartitioned(tableName string) bool {
+
+	return s.PartitionStore.IsPartitioned(tableName)
+
+}
+
+func (s *RetryLayerPartitionStore) PartitionTable(tableName string) error {
+
+	tries := 0
+	for {
+		err := s.PartitionStore.PartitionTable(tableName)
+		if err == nil {
+			return nil
+		}
+		if !isRepeatableError(err) {
+			return err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return err
+		}
+		timepkg.Sleep(100 * timepkg.Millisecond)
+	}
+
+}
+
 func (s *RetryLayerPluginStore) CompareAndDelete(keyVal *model.PluginKeyValue, oldValue []byte) (bool, error) {
 
 	tries := 0
@@ -7712,11 +7948,32 @@ func (s *RetryLayerPostStore) Get(ctx context.Context, id string, opts model.Get
 
 }
 
-func (s *RetryLayerPostStore) GetDirectPostParentsForExportAfter(limit int, afterID string, includeArchivedChannels bool) ([]*model.DirectPostForExport, error) {
+func (s *RetryLayerPostStore) GetDeletedForExportAfter(limit int, afterID string, since int64) ([]*model.PostForExport, error) {
 
 	tries := 0
 	for {
-		result, err := s.PostStore.GetDirectPostParentsForExportAfter(limit, afterID, includeArchivedChannels)
+		result, err := s.PostStore.GetDeletedForExportAfter(limit, afterID, since)
+		if err == nil {
+			return result, nil
+		}
+		if !isRepeatableError(err) {
+			return result, err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return result, err
+		}
+		timepkg.Sleep(100 * timepkg.Millisecond)
+	}
+
+}
+
+func (s *RetryLayerPostStore) GetDirectPostParentsForExportAfter(limit int, afterID string, includeArchivedChannels bool, since int64) ([]*model.DirectPostForExport, error) {
+
+	tries := 0
+	for {
+		result, err := s.PostStore.GetDirectPostParentsForExportAfter(limit, afterID, includeArchivedChannels, since)
 		if err == nil {
 			return result, nil
 		}
@@ -7823,6 +8080,27 @@ func (s *RetryLayerPostStore) GetFlaggedPostsForTeam(userID string, teamID strin
 
 }
 
+func (s *RetryLayerPostStore) GetForUserExportAfter(userID string, limit int, afterID string) ([]*model.PostForExport, error) {
+
+	tries := 0
+	for {
+		result, err := s.PostStore.GetForUserExportAfter(userID, limit, afterID)
+		if err == nil {
+			return result, nil
+		}
+		if !isRepeatableError(err) {
+			return result, err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return result, err
+		}
+		timepkg.Sleep(100 * timepkg.Millisecond)
+	}
+
+}
+
 func (s *RetryLayerPostStore) GetMaxPostSize() int {
 
 	return s.PostStore.GetMaxPostSize()
@@ -7892,11 +8170,11 @@ func (s *RetryLayerPostStore) GetOldestEntityCreationTime() (int64, error) {
 
 }
 
-func (s *RetryLayerPostStore) GetParentsForExportAfter(limit int, afterID string, includeArchivedChannels bool) ([]*model.PostForExport, error) {
+func (s *RetryLayerPostStore) GetParentsForExportAfter(limit int, afterID string, includeArchivedChannels bool, since int64) ([]*model.PostForExport, error) {
 
 	tries := 0
 	for {
-		result, err := s.PostStore.GetParentsForExportAfter(limit, afterID, includeArchivedChannels)
+		result, err := s.PostStore.GetParentsForExportAfter(limit, afterID, includeArchivedChannels, since)
 		if err == nil {
 			return result, nil
 		}
@@ -8018,11 +8296,11 @@ func (s *RetryLayerPostStore) GetPostReminders(now int64) ([]*model.PostReminder
 
 }
 
-func (s *RetryLayerPostStore) GetPosts(options model.GetPostsOptions, allowFromCache bool, sanitizeOptions map[string]bool) (*model.PostList, error) {
+func (s *RetryLayerPostStore) GetPosts(rctx request.CTX, options model.GetPostsOptions, allowFromCache bool, sanitizeOptions map[string]bool) (*model.PostList, error) {
 
 	tries := 0
 	for {
-		result, err := s.PostStore.GetPosts(options, allowFromCache, sanitizeOptions)
+		result, err := s.PostStore.GetPosts(rctx, options, allowFromCache, sanitizeOptions)
 		if err == nil {
 			return result, nil
 		}
@@ -8165,11 +8443,32 @@ func (s *RetryLayerPostStore) GetPostsCreatedAt(channelID string, timestamp int6
 
 }
 
-func (s *RetryLayerPostStore) GetPostsSince(options model.GetPostsSinceOptions, allowFromCache bool, sanitizeOptions map[string]bool) (*model.PostList, error) {
+func (s *RetryLayerPostStore) GetPostsMentioningUsernameAfter(username string, limit int, afterID string) ([]*model.Post, error) {
 
 	tries := 0
 	for {
-		result, err := s.PostStore.GetPostsSince(options, allowFromCache, sanitizeOptions)
+		result, err := s.PostStore.GetPostsMentioningUsernameAfter(username, limit, afterID)
+		if err == nil {
+			return result, nil
+		}
+		if !isRepeatableError(err) {
+			return result, err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return result, err
+		}
+		timepkg.Sleep(100 * timepkg.Millisecond)
+	}
+
+}
+
+func (s *RetryLayerPostStore) GetPostsSince(rctx request.CTX, options model.GetPostsSinceOptions, allowFromCache bool, sanitizeOptions map[string]bool) (*model.PostList, error) {
+
+	tries := 0
+	for {
+		result, err := s.PostStore.GetPostsSince(rctx, options, allowFromCache, sanitizeOptions)
 		if err == nil {
 			return result, nil
 		}
@@ -8465,6 +8764,27 @@ func (s *RetryLayerPostStore) Save(rctx request.CTX, post *model.Post) (*model.P
 
 }
 
+func (s *RetryLayerPostStore) SaveEditHistory(posts []*model.Post) error {
+
+	tries := 0
+	for {
+		err := s.PostStore.SaveEditHistory(posts)
+		if err == nil {
+			return nil
+		}
+		if !isRepeatableError(err) {
+			return err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return err
+		}
+		timepkg.Sleep(100 * timepkg.Millisecond)
+	}
+
+}
+
 func (s *RetryLayerPostStore) SaveMultiple(rctx request.CTX, posts []*model.Post) ([]*model.Post, int, error) {
 
 	tries := 0
@@ -9872,6 +10192,27 @@ func (s *RetryLayerReactionStore) GetForPostSince(postID string, since int64, ex
 
 }
 
+func (s *RetryLayerReactionStore) GetForUser(userID string, offset int, limit int) ([]*model.Reaction, error) {
+
+	tries := 0
+	for {
+		result, err := s.ReactionStore.GetForUser(userID, offset, limit)
+		if err == nil {
+			return result, nil
+		}
+		if !isRepeatableError(err) {
+			return result, err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return result, err
+		}
+		timepkg.Sleep(100 * timepkg.Millisecond)
+	}
+
+}
+
 func (s *RetryLayerReactionStore) GetSingle(userID string, postID string, remoteID string, emojiName string) (*model.Reaction, error) {
 
 	tries := 0
@@ -13733,11 +14074,11 @@ func (s *RetryLayerThreadStore) GetThreadFollowers(threadID string, fetchOnlyAct
 
 }
 
-func (s *RetryLayerThreadStore) GetThreadForUser(threadMembership *model.ThreadMembership, extended bool, postPriorityIsEnabled bool) (*model.ThreadResponse, error) {
+func (s *RetryLayerThreadStore) GetThreadForUser(rctx request.CTX, threadMembership *model.ThreadMembership, extended bool, postPriorityIsEnabled bool) (*model.ThreadResponse, error) {
 
 	tries := 0
 	for {
-		result, err := s.ThreadStore.GetThreadForUser(threadMembership, extended, postPriorityIsEnabled)
+		result, err := s.ThreadStore.GetThreadForUser(rctx, threadMembership, extended, postPriorityIsEnabled)
 		if err == nil {
 			return result, nil
 		}
@@ -13796,11 +14137,11 @@ func (s *RetryLayerThreadStore) GetThreadUnreadReplyCount(threadMembership *mode
 
 }
 
-func (s *RetryLayerThreadStore) GetThreadsForUser(userID string, teamID string, opts model.GetUserThreadsOpts) ([]*model.ThreadResponse, error) {
+func (s *RetryLayerThreadStore) GetThreadsForUser(rctx request.CTX, userID string, teamID string, opts model.GetUserThreadsOpts) ([]*model.ThreadResponse, error) {
 
 	tries := 0
 	for {
-		result, err := s.ThreadStore.GetThreadsForUser(userID, teamID, opts)
+		result, err := s.ThreadStore.GetThreadsForUser(rctx, userID, teamID, opts)
 		if err == nil {
 			return result, nil
 		}
@@ -13817,11 +14158,11 @@ func (s *RetryLayerThreadStore) GetThreadsForUser(userID string, teamID string,
 
 }
 
-func (s *RetryLayerThreadStore) GetTotalThreads(userID string, teamID string, opts model.GetUserThreadsOpts) (int64, error) {
+func (s *RetryLayerThreadStore) GetTotalThreads(rctx request.CTX, userID string, teamID string, opts model.GetUserThreadsOpts) (int64, error) {
 
 	tries := 0
 	for {
-		result, err := s.ThreadStore.GetTotalThreads(userID, teamID, opts)
+		result, err := s.ThreadStore.GetTotalThreads(rctx, userID, teamID, opts)
 		if err == nil {
 			return result, nil
 		}
@@ -13838,11 +14179,11 @@ func (s *RetryLayerThreadStore) GetTotalThreads(userID string, teamID string, op
 
 }
 
-func (s *RetryLayerThreadStore) GetTotalUnreadMentions(userID string, teamID string, opts model.GetUserThreadsOpts) (int64, error) {
+func (s *RetryLayerThreadStore) GetTotalUnreadMentions(rctx request.CTX, userID string, teamID string, opts model.GetUserThreadsOpts) (int64, error) {
 
 	tries := 0
 	for {
-		result, err := s.ThreadStore.GetTotalUnreadMentions(userID, teamID, opts)
+		result, err := s.ThreadStore.GetTotalUnreadMentions(rctx, userID, teamID, opts)
 		if err == nil {
 			return result, nil
 		}
@@ -13859,11 +14200,11 @@ func (s *RetryLayerThreadStore) GetTotalUnreadMentions(userID string, teamID str
 
 }
 
-func (s *RetryLayerThreadStore) GetTotalUnreadThreads(userID string, teamID string, opts model.GetUserThreadsOpts) (int64, error) {
+func (s *RetryLayerThreadStore) GetTotalUnreadThreads(rctx request.CTX, userID string, teamID string, opts model.GetUserThreadsOpts) (int64, error) {
 
 	tries := 0
 	for {
-		result, err := s.ThreadStore.GetTotalUnreadThreads(userID, teamID, opts)
+		result, err := s.ThreadStore.GetTotalUnreadThreads(rctx, userID, teamID, opts)
 		if err == nil {
 			return result, nil
 		}
@@ -13880,11 +14221,11 @@ func (s *RetryLayerThreadStore) GetTotalUnreadThreads(userID string, teamID stri
 
 }
 
-func (s *RetryLayerThreadStore) GetTotalUnreadUrgentMentions(userID string, teamID string, opts model.GetUserThreadsOpts) (int64, error) {
+func (s *RetryLayerThreadStore) GetTotalUnreadUrgentMentions(rctx request.CTX, userID string, teamID string, opts model.GetUserThreadsOpts) (int64, error) {
 
 	tries := 0
 	for {
-		result, err := s.ThreadStore.GetTotalUnreadUrgentMentions(userID, teamID, opts)
+		result, err := s.ThreadStore.GetTotalUnreadUrgentMentions(rctx, userID, teamID, opts)
 		if err == nil {
 			return result, nil
 		}
@@ -16840,6 +17181,7 @@ func New(childStore store.Store) *RetryLayer {
 	newStore.AccessControlPolicyStore = &RetryLayerAccessControlPolicyStore{AccessControlPolicyStore: childStore.AccessControlPolicy(), Root: &newStore}
 	newStore.AttributesStore = &RetryLayerAttributesStore{AttributesStore: childStore.Attributes(), Root: &newStore}
 	newStore.AuditStore = &RetryLayerAuditStore{AuditStore: childStore.Audit(), Root: &newStore}
+	newStore.AuditRecordStore = &RetryLayerAuditRecordStore{AuditRecordStore: childStore.AuditRecord(), Root: &newStore}
 	newStore.BotStore = &RetryLayerBotStore{BotStore: childStore.Bot(), Root: &newStore}
 	newStore.ChannelStore = &RetryLayerChannelStore{ChannelStore: childStore.Channel(), Root: &newStore}
 	newStore.ChannelBookmarkStore = &RetryLayerChannelBookmarkStore{ChannelBookmarkStore: childStore.ChannelBookmark(), Root: &newStore}
@@ -16859,6 +17201,7 @@ func New(childStore store.Store) *RetryLayer {
 	newStore.NotifyAdminStore = &RetryLayerNotifyAdminStore{NotifyAdminStore: childStore.NotifyAdmin(), Root: &newStore}
 	newStore.OAuthStore = &RetryLayerOAuthStore{OAuthStore: childStore.OAuth(), Root: &newStore}
 	newStore.OutgoingOAuthConnectionStore = &RetryLayerOutgoingOAuthConnectionStore{OutgoingOAuthConnectionStore: childStore.OutgoingOAuthConnection(), Root: &newStore}
+	newStore.PartitionStore = &RetryLayerPartitionStore{PartitionStore: childStore.Partition(), Root: &newStore}
 	newStore.PluginStore = &RetryLayerPluginStore{PluginStore: childStore.Plugin(), Root: &newStore}
 	newStore.PostStore = &RetryLayerPostStore{PostStore: childStore.Post(), Root: &newStore}
 	newStore.PostAcknowledgementStore = &RetryLayerPostAcknowledgementStore{PostAcknowledgementStore: childStore.PostAcknowledgement(), Root: &newStore}
@@ -17,6 +17,7 @@ import (
 func genStore() *mocks.Store {
 	mock := &mocks.Store{}
 	mock.On("Audit").Return(&mocks.AuditStore{})
+	mock.On("AuditRecord").Return(&mocks.AuditRecordStore{})
 	mock.On("Bot").Return(&mocks.BotStore{})
 	mock.On("Channel").Return(&mocks.ChannelStore{})
 	mock.On("ChannelMemberHistory").Return(&mocks.ChannelMemberHistoryStore{})
@@ -68,6 +69,7 @@ func genStore() *mocks.Store {
 	mock.On("PropertyValue").Return(&mocks.PropertyValueStore{})
 	mock.On("AccessControlPolicy").Return(&mocks.AccessControlPolicyStore{})
 	mock.On("Attributes").Return(&mocks.AttributesStore{})
+	mock.On("Partition").Return(&mocks.PartitionStore{})
 	return mock
 }
 
@@ -265,6 +265,7 @@ func (s *SqlAccessControlPolicyStore) Save(rctx request.CTX, policy *model.Acces
 	if err = tx.Commit(); err != nil {
 		return nil, errors.Wrap(err, "commit_transaction")
 	}
+	s.recordWrite(rctx)
 
 	return cp, nil
 }
@@ -312,6 +313,7 @@ func (s *SqlAccessControlPolicyStore) Delete(rctx request.CTX, id string) error
 	if err = tx.Commit(); err != nil {
 		return errors.Wrap(err, "commit_transaction")
 	}
+	s.recordWrite(rctx)
 
 	return nil
 }
@@ -361,6 +363,8 @@ func (s *SqlAccessControlPolicyStore) SetActiveStatus(rctx request.CTX, id strin
 		var expr sq.Sqlizer
 		if s.DriverName() == model.DatabaseDriverPostgres {
 			expr = sq.Expr("Data->'imports' @> ?::jsonb", fmt.Sprintf("%q", id))
+		} else if s.DriverName() == model.DatabaseDriverSqlite {
+			expr = sq.Expr("EXISTS (SELECT 1 FROM json_each(Data, '$.imports') WHERE value = ?)", id)
 		} else {
 			expr = sq.Expr("JSON_CONTAINS(JSON_EXTRACT(Data, '$.imports'), ?)", fmt.Sprintf("%q", id))
 		}
@@ -377,6 +381,7 @@ func (s *SqlAccessControlPolicyStore) SetActiveStatus(rctx request.CTX, id strin
 	if err = tx.Commit(); err != nil {
 		return nil, errors.Wrap(err, "commit_transaction")
 	}
+	s.recordWrite(rctx)
 
 	return existingPolicy, nil
 }
@@ -463,6 +468,8 @@ func (s *SqlAccessControlPolicyStore) GetAll(_ request.CTX, opts model.GetAccess
 	if opts.ParentID != "" {
 		if s.DriverName() == model.DatabaseDriverPostgres {
 			query = query.Where(sq.Expr("Data->'imports' @> ?", fmt.Sprintf("%q", opts.ParentID)))
+		} else if s.DriverName() == model.DatabaseDriverSqlite {
+			query = query.Where(sq.Expr("EXISTS (SELECT 1 FROM json_each(Data, '$.imports') WHERE value = ?)", opts.ParentID))
 		} else {
 			query = query.Where(sq.Expr("JSON_CONTAINS(JSON_EXTRACT(Data, '$.imports'), ?)", fmt.Sprintf("%q", opts.ParentID)))
 		}
@@ -525,6 +532,12 @@ func (s *SqlAccessControlPolicyStore) SearchPolicies(rctx request.CTX, opts mode
 	 WHERE c.Type != 'parent' 
      AND c.Data->'imports' @> JSONB_BUILD_ARRAY(p.ID)), '[]'::json) AS ChildIDs`
 			columns = append(columns, childIDs)
+		} else if s.DriverName() == model.DatabaseDriverSqlite {
+			childIDs := `COALESCE((SELECT json_group_array(c.ID)
+     FROM AccessControlPolicies c
+     WHERE c.Type != 'parent'
+     AND EXISTS (SELECT 1 FROM json_each(c.Data, '$.imports') WHERE value = p.ID)), '[]') AS ChildIDs`
+			columns = append(columns, childIDs)
 		} else {
 			childIDs := `COALESCE((SELECT JSON_ARRAYAGG(c.ID) 
      FROM AccessControlPolicies c
@@ -556,6 +569,10 @@ func (s *SqlAccessControlPolicyStore) SearchPolicies(rctx request.CTX, opts mode
 			condition := sq.Expr("Data->'imports' @> ?", fmt.Sprintf("%q", opts.ParentID))
 			query = query.Where(condition)
 			count = count.Where(condition)
+		} else if s.DriverName() == model.DatabaseDriverSqlite {
+			condition := sq.Expr("EXISTS (SELECT 1 FROM json_each(Data, '$.imports') WHERE value = ?)", opts.ParentID)
+			query = query.Where(condition)
+			count = count.Where(condition)
 		} else {
 			condition := sq.Expr("JSON_CONTAINS(JSON_EXTRACT(Data, '$.imports'), ?)", fmt.Sprintf("%q", opts.ParentID))
 			query = query.Where(condition)
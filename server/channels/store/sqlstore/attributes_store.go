@@ -150,8 +150,8 @@ func (s *SqlAttributesStore) SearchUsers(rctx request.CTX, opts model.SubjectSea
 	}
 
 	if term := opts.Term; strings.TrimSpace(term) != "" {
-		_, query = generateSearchQueryForExpression(query, strings.Fields(term), searchFields, s.DriverName() == model.DatabaseDriverPostgres, argCount)
-		_, count = generateSearchQueryForExpression(count, strings.Fields(term), searchFields, s.DriverName() == model.DatabaseDriverPostgres, argCount)
+		_, query = generateSearchQueryForExpression(query, strings.Fields(term), searchFields, s.DriverName() != model.DatabaseDriverMysql, argCount)
+		_, count = generateSearchQueryForExpression(count, strings.Fields(term), searchFields, s.DriverName() != model.DatabaseDriverMysql, argCount)
 	}
 
 	q, args, err := query.ToSql()
@@ -0,0 +1,293 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package sqlstore
+
+import (
+	"encoding/json"
+
+	sq "github.com/mattermost/squirrel"
+	"github.com/pkg/errors"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/v8/channels/store"
+)
+
+type SqlAuditRecordStore struct {
+	*SqlStore
+
+	auditRecordQuery sq.SelectBuilder
+}
+
+// auditRecordRow is the database representation of a model.StoredAuditRecord.
+type auditRecordRow struct {
+	Id         string
+	CreateAt   int64
+	Level      string
+	EventName  string
+	Status     string
+	UserId     string
+	SessionId  string
+	IpAddress  string
+	ObjectType string
+	ObjectId   string
+	Data       []byte
+}
+
+func newSqlAuditRecordStore(sqlStore *SqlStore) store.AuditRecordStore {
+	s := &SqlAuditRecordStore{
+		SqlStore: sqlStore,
+	}
+
+	s.auditRecordQuery = s.getQueryBuilder().
+		Select(auditRecordColumns()...).
+		From("AuditRecords")
+
+	return s
+}
+
+func auditRecordColumns() []string {
+	return []string{
+		"Id",
+		"CreateAt",
+		"Level",
+		"EventName",
+		"Status",
+		"UserId",
+		"SessionId",
+		"IpAddress",
+		"ObjectType",
+		"ObjectId",
+		"Data",
+	}
+}
+
+func (s *SqlAuditRecordStore) SaveMultiple(records []*model.StoredAuditRecord) ([]error, error) {
+	builder := s.getQueryBuilder().
+		Insert("AuditRecords").
+		Columns(auditRecordColumns()...)
+
+	var itemErrs []error
+	setItemErr := func(i int, err error) {
+		if itemErrs == nil {
+			itemErrs = make([]error, len(records))
+		}
+		itemErrs[i] = err
+	}
+
+	saved := 0
+	for i, record := range records {
+		record.PreSave()
+		if appErr := record.IsValid(); appErr != nil {
+			setItemErr(i, appErr)
+			continue
+		}
+
+		data, err := json.Marshal(record.Record)
+		if err != nil {
+			setItemErr(i, errors.Wrapf(err, "failed to marshal audit record with id=%s", record.Id))
+			continue
+		}
+
+		builder = builder.Values(
+			record.Id,
+			record.CreateAt,
+			record.Level,
+			record.EventName,
+			record.Status,
+			record.UserId,
+			record.SessionId,
+			record.IpAddress,
+			record.ObjectType,
+			record.ObjectId,
+			string(data),
+		)
+		saved++
+	}
+
+	if saved == 0 {
+		return itemErrs, nil
+	}
+
+	if _, err := s.GetMaster().ExecBuilder(builder); err != nil {
+		return itemErrs, errors.Wrapf(err, "failed to save %d AuditRecords", saved)
+	}
+	return itemErrs, nil
+}
+
+func (s *SqlAuditRecordStore) Search(opts model.AuditRecordSearchOptions) ([]*model.StoredAuditRecord, error) {
+	if opts.PerPage > model.AuditRecordSearchMaxPerPage {
+		return nil, store.NewErrOutOfBounds(opts.PerPage)
+	}
+
+	query := s.auditRecordQuery.
+		OrderBy("CreateAt DESC", "Id DESC").
+		Limit(uint64(opts.PerPage)).
+		Offset(uint64(opts.Page * opts.PerPage))
+
+	if opts.UserId != "" {
+		query = query.Where(sq.Eq{"UserId": opts.UserId})
+	}
+	if opts.EventName != "" {
+		query = query.Where(sq.Eq{"EventName": opts.EventName})
+	}
+	if opts.Status != "" {
+		query = query.Where(sq.Eq{"Status": opts.Status})
+	}
+	if opts.ObjectId != "" {
+		query = query.Where(sq.Eq{"ObjectId": opts.ObjectId})
+	}
+	if opts.IpAddress != "" {
+		query = query.Where(sq.Eq{"IpAddress": opts.IpAddress})
+	}
+	if opts.Since > 0 {
+		query = query.Where(sq.GtOrEq{"CreateAt": opts.Since})
+	}
+	if opts.Until > 0 {
+		query = query.Where(sq.Lt{"CreateAt": opts.Until})
+	}
+
+	rows := []auditRecordRow{}
+	if err := s.GetReplica().SelectBuilder(&rows, query); err != nil {
+		return nil, errors.Wrap(err, "failed to search AuditRecords")
+	}
+
+	records := make([]*model.StoredAuditRecord, 0, len(rows))
+	for _, row := range rows {
+		var rec model.AuditRecord
+		if err := json.Unmarshal(row.Data, &rec); err != nil {
+			return nil, errors.Wrapf(err, "failed to unmarshal AuditRecord with id=%s", row.Id)
+		}
+
+		records = append(records, &model.StoredAuditRecord{
+			Id:         row.Id,
+			CreateAt:   row.CreateAt,
+			Level:      row.Level,
+			EventName:  row.EventName,
+			Status:     row.Status,
+			UserId:     row.UserId,
+			SessionId:  row.SessionId,
+			IpAddress:  row.IpAddress,
+			ObjectType: row.ObjectType,
+			ObjectId:   row.ObjectId,
+			Record:     &rec,
+		})
+	}
+
+	return records, nil
+}
+
+func (s *SqlAuditRecordStore) PermanentDeleteBatch(endTime int64, limit int64) (int64, error) {
+	var query string
+	if s.DriverName() == model.DatabaseDriverPostgres {
+		query = "DELETE FROM AuditRecords WHERE Id = any (array (SELECT Id FROM AuditRecords WHERE CreateAt < ? LIMIT ?))"
+	} else if s.DriverName() == model.DatabaseDriverSqlite {
+		query = "DELETE FROM AuditRecords WHERE Id IN (SELECT Id FROM AuditRecords WHERE CreateAt < ? LIMIT ?)"
+	} else {
+		query = "DELETE FROM AuditRecords WHERE CreateAt < ? LIMIT ?"
+	}
+
+	sqlResult, err := s.GetMaster().Exec(query, endTime, limit)
+	if err != nil {
+		return 0, errors.Wrap(err, "failed to delete AuditRecords")
+	}
+
+	rowsAffected, err := sqlResult.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "failed to delete AuditRecords")
+	}
+	return rowsAffected, nil
+}
+
+// auditRecordDataText returns the expression converting the data of the
+// records to text, for the ids it mentions to be searched.
+func (s *SqlAuditRecordStore) auditRecordDataText() string {
+	if s.DriverName() == model.DatabaseDriverPostgres {
+		return "Data::text"
+	}
+	return "CAST(Data AS CHAR)"
+}
+
+// GetCountForUser returns the number of records the user is the actor or
+// the object of, or that mention the id of the user.
+func (s *SqlAuditRecordStore) GetCountForUser(userID string) (int64, error) {
+	query := s.getQueryBuilder().
+		Select("COUNT(*)").
+		From("AuditRecords").
+		Where(sq.Or{
+			sq.Eq{"UserId": userID},
+			sq.Eq{"ObjectId": userID},
+			sq.Like{s.auditRecordDataText(): "%" + userID + "%"},
+		})
+
+	var count int64
+	if err := s.GetReplica().GetBuilder(&count, query); err != nil {
+		return 0, errors.Wrapf(err, "failed to count AuditRecords for userId=%s", userID)
+	}
+	return count, nil
+}
+
+// ScrubForUser removes the identifying data of the user from the records
+// returned by GetCountForUser. The records of the actions of the user are
+// reduced to the event, its status and the id of the user, which no longer
+// identifies anyone once the user is erased. The other records lose their
+// parameters and object states, but keep their actor for the actions of
+// the other users to remain accountable.
+func (s *SqlAuditRecordStore) ScrubForUser(userID string) (_ int64, err error) {
+	var actorData, objectData string
+	if s.DriverName() == model.DatabaseDriverPostgres {
+		actorData = "jsonb_build_object('event_name', EventName, 'status', Status, 'event', jsonb_build_object('object_type', ObjectType), 'actor', jsonb_build_object('user_id', UserId))"
+		objectData = "Data #- '{event,parameters}' #- '{event,prior_state}' #- '{event,resulting_state}'"
+	} else {
+		actorData = "JSON_OBJECT('event_name', EventName, 'status', Status, 'event', JSON_OBJECT('object_type', ObjectType), 'actor', JSON_OBJECT('user_id', UserId))"
+		objectData = "JSON_REMOVE(Data, '$.event.parameters', '$.event.prior_state', '$.event.resulting_state')"
+	}
+
+	transaction, err := s.GetMaster().Beginx()
+	if err != nil {
+		return 0, errors.Wrap(err, "begin_transaction")
+	}
+	defer finalizeTransactionX(transaction, &err)
+
+	actorQuery := s.getQueryBuilder().
+		Update("AuditRecords").
+		Set("SessionId", "").
+		Set("IpAddress", "").
+		Set("Data", sq.Expr(actorData)).
+		Where(sq.Eq{"UserId": userID})
+
+	result, err := transaction.ExecBuilder(actorQuery)
+	if err != nil {
+		return 0, errors.Wrapf(err, "failed to scrub AuditRecords of userId=%s", userID)
+	}
+	actorCount, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "failed to get the number of scrubbed AuditRecords")
+	}
+
+	objectQuery := s.getQueryBuilder().
+		Update("AuditRecords").
+		Set("Data", sq.Expr(objectData)).
+		Where(sq.And{
+			sq.NotEq{"UserId": userID},
+			sq.Or{
+				sq.Eq{"ObjectId": userID},
+				sq.Like{s.auditRecordDataText(): "%" + userID + "%"},
+			},
+		})
+
+	result, err = transaction.ExecBuilder(objectQuery)
+	if err != nil {
+		return 0, errors.Wrapf(err, "failed to scrub AuditRecords mentioning userId=%s", userID)
+	}
+	objectCount, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "failed to get the number of scrubbed AuditRecords")
+	}
+
+	if err = transaction.Commit(); err != nil {
+		return 0, errors.Wrap(err, "commit_transaction")
+	}
+
+	return actorCount + objectCount, nil
+}
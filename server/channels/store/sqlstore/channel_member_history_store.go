@@ -273,10 +273,17 @@ func (s SqlChannelMemberHistoryStore) PermanentDeleteBatch(endTime int64, limit
 		err   error
 	)
 
-	if s.DriverName() == model.DatabaseDriverPostgres {
+	if s.DriverName() == model.DatabaseDriverPostgres || s.DriverName() == model.DatabaseDriverSqlite {
+		// The table has no single column key, so the rows are matched
+		// on their physical id.
+		rowID := "ctid"
+		if s.DriverName() == model.DatabaseDriverSqlite {
+			rowID = "rowid"
+		}
+
 		var innerSelect string
 		innerSelect, args, err = s.getQueryBuilder().
-			Select("ctid").
+			Select(rowID).
 			From("ChannelMemberHistory").
 			Where(sq.And{
 				sq.NotEq{"LeaveTime": nil},
@@ -289,7 +296,7 @@ func (s SqlChannelMemberHistoryStore) PermanentDeleteBatch(endTime int64, limit
 		query, _, err = s.getQueryBuilder().
 			Delete("ChannelMemberHistory").
 			Where(fmt.Sprintf(
-				"ctid IN (%s)", innerSelect,
+				"%s IN (%s)", rowID, innerSelect,
 			)).ToSql()
 	} else {
 		query, args, err = s.getQueryBuilder().
@@ -650,6 +650,7 @@ func (s SqlChannelStore) Save(rctx request.CTX, channel *model.Channel, maxChann
 	if err = transaction.Commit(); err != nil {
 		return nil, errors.Wrap(err, "commit_transaction")
 	}
+	s.recordWrite(rctx)
 	// There are cases when in case of conflict, the original channel value is returned.
 	// So we return both and let the caller do the checks.
 	return newChannel, err
@@ -712,9 +713,9 @@ func (s SqlChannelStore) SaveDirectChannel(rctx request.CTX, directChannel *mode
 	member2.ChannelId = newChannel.Id
 
 	if member1.UserId != member2.UserId {
-		_, err = s.saveMultipleMembers([]*model.ChannelMember{member1, member2})
+		_, err = s.saveMultipleMembers(transaction, []*model.ChannelMember{member1, member2})
 	} else {
-		_, err = s.saveMemberT(member2)
+		_, err = s.saveMemberT(transaction, member2)
 	}
 	if err != nil {
 		return nil, err
@@ -723,6 +724,7 @@ func (s SqlChannelStore) SaveDirectChannel(rctx request.CTX, directChannel *mode
 	if err := transaction.Commit(); err != nil {
 		return nil, errors.Wrap(err, "commit_transaction")
 	}
+	s.recordWrite(rctx)
 
 	return newChannel, nil
 }
@@ -806,6 +808,8 @@ func (s SqlChannelStore) Update(rctx request.CTX, channel *model.Channel) (_ *mo
 	if err := transaction.Commit(); err != nil {
 		return nil, errors.Wrap(err, "commit_transaction")
 	}
+	s.recordWrite(rctx)
+
 	return updatedChannel, nil
 }
 
@@ -1073,6 +1077,7 @@ func (s SqlChannelStore) PermanentDelete(rctx request.CTX, channelId string) (er
 	if err := transaction.Commit(); err != nil {
 		return errors.Wrap(err, "PermanentDelete: commit_transaction")
 	}
+	s.recordWrite(rctx)
 
 	return nil
 }
@@ -1090,6 +1095,7 @@ func (s SqlChannelStore) PermanentDeleteMembersByChannel(rctx request.CTX, chann
 	if err != nil {
 		return errors.Wrapf(err, "failed to delete Channel with channelId=%s", channelId)
 	}
+	s.recordWrite(rctx)
 
 	return nil
 }
@@ -1687,7 +1693,7 @@ func (s SqlChannelStore) SaveMultipleMembers(members []*model.ChannelMember) ([]
 		defer s.InvalidateAllChannelMembersForUser(member.UserId)
 	}
 
-	newMembers, err := s.saveMultipleMembers(members)
+	newMembers, err := s.saveMultipleMembers(s.GetMaster(), members)
 	if err != nil {
 		return nil, err
 	}
@@ -1700,10 +1706,11 @@ func (s SqlChannelStore) SaveMember(rctx request.CTX, member *model.ChannelMembe
 	if err != nil {
 		return nil, err
 	}
+	s.recordWrite(rctx)
 	return newMembers[0], nil
 }
 
-func (s SqlChannelStore) saveMultipleMembers(members []*model.ChannelMember) ([]*model.ChannelMember, error) {
+func (s SqlChannelStore) saveMultipleMembers(db sqlxExecutor, members []*model.ChannelMember) ([]*model.ChannelMember, error) {
 	newChannelMembers := map[string]int{}
 	users := map[string]bool{}
 	for _, member := range members {
@@ -1754,7 +1761,7 @@ func (s SqlChannelStore) saveMultipleMembers(members []*model.ChannelMember) ([]
 		User  sql.NullString
 		Admin sql.NullString
 	}{}
-	err = s.GetMaster().Select(&defaultChannelsRoles, channelRolesSql, channelRolesArgs...)
+	err = db.Select(&defaultChannelsRoles, channelRolesSql, channelRolesArgs...)
 	if err != nil {
 		return nil, errors.Wrap(err, "default_channel_roles_select")
 	}
@@ -1793,7 +1800,7 @@ func (s SqlChannelStore) saveMultipleMembers(members []*model.ChannelMember) ([]
 		User  sql.NullString
 		Admin sql.NullString
 	}{}
-	err = s.GetMaster().Select(&defaultTeamsRoles, teamRolesSql, teamRolesArgs...)
+	err = db.Select(&defaultTeamsRoles, teamRolesSql, teamRolesArgs...)
 	if err != nil {
 		return nil, errors.Wrap(err, "default_team_roles_select")
 	}
@@ -1812,7 +1819,7 @@ func (s SqlChannelStore) saveMultipleMembers(members []*model.ChannelMember) ([]
 		return nil, errors.Wrap(err, "channel_members_tosql")
 	}
 
-	if _, err := s.GetMaster().Exec(sql, args...); err != nil {
+	if _, err := db.Exec(sql, args...); err != nil {
 		if IsUniqueConstraintError(err, []string{"ChannelId", "channelmembers_pkey", "PRIMARY"}) {
 			return nil, store.NewErrConflict("ChannelMembers", err, "")
 		}
@@ -1844,8 +1851,8 @@ func (s SqlChannelStore) saveMultipleMembers(members []*model.ChannelMember) ([]
 	return newMembers, nil
 }
 
-func (s SqlChannelStore) saveMemberT(member *model.ChannelMember) (*model.ChannelMember, error) {
-	members, err := s.saveMultipleMembers([]*model.ChannelMember{member})
+func (s SqlChannelStore) saveMemberT(db sqlxExecutor, member *model.ChannelMember) (*model.ChannelMember, error) {
+	members, err := s.saveMultipleMembers(db, []*model.ChannelMember{member})
 	if err != nil {
 		return nil, err
 	}
@@ -1918,6 +1925,7 @@ func (s SqlChannelStore) UpdateMember(rctx request.CTX, member *model.ChannelMem
 	if err != nil {
 		return nil, err
 	}
+	s.recordWrite(rctx)
 	return updatedMembers[0], nil
 }
 
@@ -2470,7 +2478,7 @@ func (s SqlChannelStore) GetMemberCountsByGroup(ctx context.Context, channelID s
 					THEN JSON_EXTRACT(Timezone, '$.manualTimezone')
 					END
 				)) AS ChannelMemberTimezonesCount`
-		} else if s.DriverName() == model.DatabaseDriverPostgres {
+		} else if s.DriverName() == model.DatabaseDriverPostgres || s.DriverName() == model.DatabaseDriverSqlite {
 			selectStr += `,
 				COUNT(DISTINCT
 				(
@@ -2569,6 +2577,7 @@ func (s SqlChannelStore) RemoveMembers(rctx request.CTX, channelId string, userI
 	if err != nil {
 		return errors.Wrap(err, "failed to delete ChannelMembers")
 	}
+	s.recordWrite(rctx)
 
 	// cleanup sidebarchannels table if the user is no longer a member of that channel
 	query, args, err = s.getQueryBuilder().
@@ -2613,6 +2622,7 @@ func (s SqlChannelStore) RemoveAllDeactivatedMembers(rctx request.CTX, channelId
 	if err != nil {
 		return errors.Wrapf(err, "failed to delete ChannelMembers with channelId=%s", channelId)
 	}
+	s.recordWrite(rctx)
 	return nil
 }
 
@@ -2620,6 +2630,7 @@ func (s SqlChannelStore) PermanentDeleteMembersByUser(rctx request.CTX, userId s
 	if _, err := s.GetMaster().Exec("DELETE FROM ChannelMembers WHERE UserId = ?", userId); err != nil {
 		return errors.Wrapf(err, "failed to permanent delete ChannelMembers with userId=%s", userId)
 	}
+	s.recordWrite(rctx)
 	return nil
 }
 
@@ -2707,6 +2718,13 @@ func (s SqlChannelStore) UpdateLastViewedAt(channelIds []string, userId string)
 			sq.Expr("GREATEST(LastViewedAt, ?)", t.LastPostAt))
 	}
 
+	// MySQL assigns the columns in order, LastViewedAt already has its new
+	// value when LastUpdateAt is set. SQLite assigns them all from the old row.
+	var lastUpdateQuery any = sq.Expr("LastViewedAt")
+	if s.DriverName() == model.DatabaseDriverSqlite {
+		lastUpdateQuery = lastViewedQuery
+	}
+
 	updateQuery := s.getQueryBuilder().Update("ChannelMembers").
 		Set("MentionCount", 0).
 		Set("MentionCountRoot", 0).
@@ -2714,7 +2732,7 @@ func (s SqlChannelStore) UpdateLastViewedAt(channelIds []string, userId string)
 		Set("MsgCount", msgCountQuery).
 		Set("MsgCountRoot", msgCountQueryRoot).
 		Set("LastViewedAt", lastViewedQuery).
-		Set("LastUpdateAt", sq.Expr("LastViewedAt")).
+		Set("LastUpdateAt", lastUpdateQuery).
 		Where(sq.Eq{
 			"UserId":    userId,
 			"ChannelId": channelIds,
@@ -3094,7 +3112,7 @@ func (s SqlChannelStore) AnalyticsCountAll(teamId string) (map[model.ChannelType
 	return scanRowsIntoMap(rows, scanner, nil)
 }
 
-func (s SqlChannelStore) GetMembersForUser(teamID string, userID string) (model.ChannelMembers, error) {
+func (s SqlChannelStore) GetMembersForUser(ctx context.Context, teamID string, userID string) (model.ChannelMembers, error) {
 	sql, args, err := s.channelMembersForTeamWithSchemeSelectQuery.
 		Where(sq.And{
 			sq.Eq{"ChannelMembers.UserId": userID},
@@ -3109,7 +3127,7 @@ func (s SqlChannelStore) GetMembersForUser(teamID string, userID string) (model.
 	}
 
 	dbMembers := channelMemberWithSchemeRolesList{}
-	err = s.GetReplica().Select(&dbMembers, sql, args...)
+	err = s.DBXFromContext(ctx).Select(&dbMembers, sql, args...)
 	if err != nil {
 		return nil, errors.Wrapf(err, "failed to find ChannelMembers data with teamId=%s and userId=%s", teamID, userID)
 	}
@@ -3613,6 +3631,8 @@ func (s SqlChannelStore) channelSearchQuery(opts *store.ChannelSearchOpts) sq.Se
 	} else if opts.ParentAccessControlPolicyId != "" {
 		if s.DriverName() == model.DatabaseDriverPostgres {
 			query = query.Where(sq.Expr("c.Id IN (SELECT ID From AccessControlPolicies WHERE Type = ? AND Data->'imports' @> ?)", model.AccessControlPolicyTypeChannel, fmt.Sprintf("%q", opts.ParentAccessControlPolicyId)))
+		} else if s.DriverName() == model.DatabaseDriverSqlite {
+			query = query.Where(sq.Expr("c.Id IN (SELECT ID From AccessControlPolicies WHERE Type = ? AND EXISTS (SELECT 1 FROM json_each(Data, '$.imports') WHERE value = ?))", model.AccessControlPolicyTypeChannel, opts.ParentAccessControlPolicyId))
 		} else {
 			query = query.Where(sq.Expr("c.Id IN (SELECT ID From AccessControlPolicies WHERE Type = ? AND JSON_CONTAINS(JSON_EXTRACT(Data, '$.imports'), ?))", model.AccessControlPolicyTypeChannel, fmt.Sprintf("%q", opts.ParentAccessControlPolicyId)))
 		}
@@ -3766,6 +3786,13 @@ func (s SqlChannelStore) buildFulltextClause(term string, searchColumns string)
 		fulltextTerm = strings.Join(splitTerm, " ")
 
 		fulltextClause = fmt.Sprintf("MATCH(%s) AGAINST (:FulltextTerm IN BOOLEAN MODE)", searchColumns)
+	} else if s.DriverName() == model.DatabaseDriverSqlite {
+		// Channels have no full text index in SQLite, the words are
+		// matched in order in the concatenated columns instead.
+		fulltextTerm = strings.ReplaceAll(fulltextTerm, "|", "")
+		fulltextTerm = "%" + strings.Join(strings.Fields(sanitizeSearchTerm(fulltextTerm, "*")), "%") + "%"
+
+		fulltextClause = fmt.Sprintf("(%s) LIKE :FulltextTerm ESCAPE '*'", strings.Join(strings.Split(searchColumns, ", "), " || ' ' || "))
 	}
 
 	return
@@ -3801,6 +3828,14 @@ func (s SqlChannelStore) buildFulltextClauseX(term string, searchColumns ...stri
 		return sq.Expr(expr, fulltextTerm)
 	}
 
+	if s.DriverName() == model.DatabaseDriverSqlite {
+		fulltextTerm = strings.ReplaceAll(fulltextTerm, "|", "")
+		fulltextTerm = "%" + strings.Join(strings.Fields(sanitizeSearchTerm(fulltextTerm, "*")), "%") + "%"
+
+		expr := fmt.Sprintf("(%s) LIKE ? ESCAPE '*'", strings.Join(searchColumns, " || ' ' || "))
+		return sq.Expr(expr, fulltextTerm)
+	}
+
 	splitTerm := strings.Fields(fulltextTerm)
 	for i, t := range splitTerm {
 		splitTerm[i] = "+" + t + "*"
@@ -3840,14 +3875,17 @@ func (s SqlChannelStore) searchClause(term string) sq.Sqlizer {
 	}
 }
 
-func (s SqlChannelStore) searchGroupChannelsQuery(userId, term string, isPostgreSQL bool) sq.SelectBuilder {
+func (s SqlChannelStore) searchGroupChannelsQuery(userId, term string) sq.SelectBuilder {
 	var baseLikeTerm string
 	terms := strings.Fields((strings.ToLower(term)))
 
 	having := sq.And{}
 
-	if isPostgreSQL {
+	if s.DriverName() == model.DatabaseDriverPostgres || s.DriverName() == model.DatabaseDriverSqlite {
 		baseLikeTerm = "ARRAY_TO_STRING(ARRAY_AGG(u.Username), ', ') LIKE ?"
+		if s.DriverName() == model.DatabaseDriverSqlite {
+			baseLikeTerm = "GROUP_CONCAT(u.Username, ', ') LIKE ?" + likeEscape(s.DriverName())
+		}
 		cc := s.getSubQueryBuilder().Select("c.Id").
 			From("Channels c").
 			Join("ChannelMembers cm ON c.Id=cm.ChannelId").
@@ -3903,8 +3941,7 @@ func (s SqlChannelStore) searchGroupChannelsQuery(userId, term string, isPostgre
 }
 
 func (s SqlChannelStore) SearchGroupChannels(userId, term string) (model.ChannelList, error) {
-	isPostgreSQL := s.DriverName() == model.DatabaseDriverPostgres
-	query := s.searchGroupChannelsQuery(userId, term, isPostgreSQL)
+	query := s.searchGroupChannelsQuery(userId, term)
 
 	sql, params, err := query.ToSql()
 	if err != nil {
@@ -4229,7 +4266,7 @@ func (s SqlChannelStore) GetAllChannelsForExportAfter(limit int, afterId string)
 			sq.Gt{"Channels.Id": afterId},
 			sq.Eq{"Channels.Type": []model.ChannelType{model.ChannelTypeOpen, model.ChannelTypePrivate}},
 		}).
-		OrderBy("Id").
+		OrderBy("Channels.Id").
 		Limit(uint64(limit))
 
 	channels := []*model.ChannelForExport{}
@@ -4480,6 +4517,10 @@ func (s SqlChannelStore) GroupSyncedChannelCount() (int64, error) {
 
 // SetShared sets the Shared flag true/false
 func (s SqlChannelStore) SetShared(channelId string, shared bool) error {
+	return s.setSharedT(s.GetMaster(), channelId, shared)
+}
+
+func (s SqlChannelStore) setSharedT(db sqlxExecutor, channelId string, shared bool) error {
 	squery, args, err := s.getQueryBuilder().
 		Update("Channels").
 		Set("Shared", shared).
@@ -4489,7 +4530,7 @@ func (s SqlChannelStore) SetShared(channelId string, shared bool) error {
 		return errors.Wrap(err, "channel_set_shared_tosql")
 	}
 
-	result, err := s.GetMaster().Exec(squery, args...)
+	result, err := db.Exec(squery, args...)
 	if err != nil {
 		return errors.Wrap(err, "failed to update `Shared` for Channels")
 	}
@@ -291,6 +291,15 @@ func (s SqlChannelStore) CreateSidebarCategory(userId, teamId string, newCategor
 					SidebarChannels.UserId = ?
 					AND SidebarChannels.ChannelId IN ` + placeHolder + `
 					AND SidebarCategories.TeamId = ?`
+		} else if s.DriverName() == model.DatabaseDriverSqlite {
+			deleteQuery = `
+				DELETE FROM
+					SidebarChannels
+				WHERE
+					SidebarChannels.UserId = ?
+					AND SidebarChannels.ChannelId IN ` + placeHolder + `
+					AND SidebarChannels.CategoryId IN (
+						SELECT Id FROM SidebarCategories WHERE SidebarCategories.TeamId = ?)`
 		} else {
 			deleteQuery = `
 				DELETE FROM
@@ -917,6 +926,15 @@ func (s SqlChannelStore) removeSidebarEntriesForPreferenceT(transaction *sqlxTxW
 				SidebarChannels.UserId = ?
 				AND SidebarChannels.ChannelId = ?
 				AND SidebarCategories.Type = ?`
+	} else if s.DriverName() == model.DatabaseDriverSqlite {
+		query = `
+			DELETE FROM
+				SidebarChannels
+			WHERE
+				SidebarChannels.UserId = ?
+				AND SidebarChannels.ChannelId = ?
+				AND SidebarChannels.CategoryId IN (
+					SELECT Id FROM SidebarCategories WHERE SidebarCategories.Type = ?)`
 	} else {
 		query = `
 			DELETE FROM
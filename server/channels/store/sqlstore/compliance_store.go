@@ -144,7 +144,7 @@ func (s SqlComplianceStore) ComplianceExport(job *model.Compliance, cursor model
 
 		for i, keyword := range keywords {
 			keyword = sanitizeSearchTerm(keyword, "\\")
-			clauses[i] = "LOWER(Posts.Message) LIKE ?"
+			clauses[i] = "LOWER(Posts.Message) LIKE ?" + likeEscape(s.DriverName())
 			argsKeywords = append(argsKeywords, "%"+keyword+"%")
 		}
 
@@ -5,6 +5,7 @@ package sqlstore
 
 import (
 	"context"
+	"sync/atomic"
 
 	"github.com/mattermost/mattermost/server/public/shared/request"
 )
@@ -17,7 +18,8 @@ type contextValue string
 
 // Different possible values of contextValue.
 const (
-	useMaster contextValue = "useMaster"
+	useMaster      contextValue = "useMaster"
+	readYourWrites contextValue = "readYourWrites"
 )
 
 // WithMaster adds the context value that master DB should be selected for this request.
@@ -45,10 +47,42 @@ func HasMaster(ctx context.Context) bool {
 	return false
 }
 
+// readYourWritesState is the read-your-writes state of a request.
+type readYourWritesState struct {
+	// key is what the reads and writes of the request are tracked with.
+	key string
+	// wrote is set once the request has recorded a write.
+	wrote atomic.Bool
+}
+
+// RequestContextWithReadYourWrites adds the key the reads and writes of this request are tracked
+// with, usually the ID of the user making it. The reads following a write recorded for the same key
+// go to master until the replicas have caught up, see SqlStore.RecordWrite.
+func RequestContextWithReadYourWrites(c request.CTX, key string) request.CTX {
+	ctx := context.WithValue(c.Context(), storeContextKey(readYourWrites), &readYourWritesState{key: key})
+	return c.WithContext(ctx)
+}
+
+// readYourWritesFromContext returns the read-your-writes state of the context, if any.
+func readYourWritesFromContext(ctx context.Context) *readYourWritesState {
+	if v, ok := ctx.Value(storeContextKey(readYourWrites)).(*readYourWritesState); ok {
+		return v
+	}
+	return nil
+}
+
+// readYourWritesKey returns the read-your-writes key of the context, if any.
+func readYourWritesKey(ctx context.Context) string {
+	if state := readYourWritesFromContext(ctx); state != nil {
+		return state.key
+	}
+	return ""
+}
+
 // DBXFromContext is a helper utility that returns the sqlx DB handle from a given context.
 func (ss *SqlStore) DBXFromContext(ctx context.Context) *sqlxDBWrapper {
 	if HasMaster(ctx) {
 		return ss.GetMaster()
 	}
-	return ss.GetReplica()
+	return ss.replicaForContext(ctx)
 }
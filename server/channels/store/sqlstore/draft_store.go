@@ -259,6 +259,11 @@ func (s *SqlDraftStore) determineMaxDraftSize() int {
 		`); err != nil {
 			mlog.Warn("Unable to determine the maximum supported draft size", mlog.Err(err))
 		}
+	} else if s.DriverName() == model.DatabaseDriverSqlite {
+		var err error
+		if maxDraftSizeBytes, err = s.sqliteColumnMaxLength("Drafts", "Message"); err != nil {
+			mlog.Warn("Unable to determine the maximum supported draft size", mlog.Err(err))
+		}
 	} else {
 		mlog.Warn("No implementation found to determine the maximum supported draft size")
 	}
@@ -349,6 +354,22 @@ func (s *SqlDraftStore) DeleteEmptyDraftsByCreateAtAndUserId(createAt int64, use
 				Limit(100).
 				Suffix(") dj ON (d.UserId = dj.UserId AND d.ChannelId = dj.ChannelId AND d.RootId = dj.RootId)"),
 			).Where(sq.Eq{"Message": ""})
+	} else if s.DriverName() == model.DatabaseDriverSqlite {
+		builder = s.getQueryBuilder().
+			Delete("Drafts").
+			Where(sq.Expr("(UserId, ChannelId, RootId) IN (?)", s.getSubQueryBuilder().
+				Select("UserId", "ChannelId", "RootId").
+				From("Drafts").
+				Where(sq.Or{
+					sq.Gt{"CreateAt": createAt},
+					sq.And{
+						sq.Eq{"CreateAt": createAt},
+						sq.Gt{"UserId": userId},
+					},
+				}).
+				OrderBy("CreateAt", "UserId").
+				Limit(100))).
+			Where(sq.Eq{"Message": ""})
 	}
 
 	if _, err := s.GetMaster().ExecBuilder(builder); err != nil {
@@ -405,6 +426,22 @@ func (s *SqlDraftStore) DeleteOrphanDraftsByCreateAtAndUserId(createAt int64, us
 				Suffix(") dj ON (d.UserId = dj.UserId AND d.ChannelId = dj.ChannelId AND d.RootId = dj.RootId)"),
 			).
 			Suffix("AND (d.RootId IN (SELECT Id FROM Posts WHERE DeleteAt <> 0) OR NOT EXISTS (SELECT 1 FROM Posts WHERE Posts.Id = d.RootId))")
+	} else if s.DriverName() == model.DatabaseDriverSqlite {
+		builder = s.getQueryBuilder().
+			Delete("Drafts").
+			Where(sq.Expr("(UserId, ChannelId, RootId) IN (?)", s.getSubQueryBuilder().
+				Select("UserId", "ChannelId", "RootId").
+				From("Drafts").
+				Where(sq.Or{
+					sq.Gt{"CreateAt": createAt},
+					sq.And{
+						sq.Eq{"CreateAt": createAt},
+						sq.Gt{"UserId": userId},
+					},
+				}).
+				OrderBy("CreateAt", "UserId").
+				Limit(100))).
+			Where("(Drafts.RootId IN (SELECT Id FROM Posts WHERE DeleteAt <> 0) OR NOT EXISTS (SELECT 1 FROM Posts WHERE Posts.Id = Drafts.RootId))")
 	}
 
 	if _, err := s.GetMaster().ExecBuilder(builder); err != nil {
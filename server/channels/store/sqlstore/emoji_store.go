@@ -87,6 +87,24 @@ func (es SqlEmojiStore) GetList(offset, limit int, sort string) ([]*model.Emoji,
 	return emojis, nil
 }
 
+func (es SqlEmojiStore) GetDeletedSince(since int64, offset, limit int) ([]*model.Emoji, error) {
+	emojis := []*model.Emoji{}
+
+	query := es.getQueryBuilder().
+		Select("Id", "CreateAt", "UpdateAt", "DeleteAt", "CreatorId", "Name").
+		From("Emoji").
+		Where(sq.GtOrEq{"DeleteAt": since}).
+		Where(sq.NotEq{"DeleteAt": 0}).
+		OrderBy("DeleteAt", "Id").
+		Limit(uint64(limit)).
+		Offset(uint64(offset))
+
+	if err := es.GetReplica().SelectBuilder(&emojis, query); err != nil {
+		return nil, errors.Wrapf(err, "could not get emojis deleted since %d", since)
+	}
+	return emojis, nil
+}
+
 func (es SqlEmojiStore) Delete(emoji *model.Emoji, time int64) error {
 	if sqlResult, err := es.GetMaster().Exec(
 		`UPDATE
@@ -117,7 +135,7 @@ func (es SqlEmojiStore) Search(name string, prefixOnly bool, limit int) ([]*mode
 	term += name + "%"
 
 	query := es.emojiSelectQuery.
-		Where(sq.Like{"Name": term}).
+		Where(sq.Expr("Name LIKE ?"+likeEscape(es.DriverName()), term)).
 		OrderBy("Name").
 		Limit(uint64(limit))
 
@@ -129,6 +129,7 @@ func (fs SqlFileInfoStore) Save(rctx request.CTX, info *model.FileInfo) (*model.
 	if _, err := fs.GetMaster().NamedExec(query, info); err != nil {
 		return nil, errors.Wrap(err, "failed to save FileInfo")
 	}
+	fs.recordWrite(rctx)
 	return info, nil
 }
 
@@ -208,6 +209,7 @@ func (fs SqlFileInfoStore) Upsert(rctx request.CTX, info *model.FileInfo) (*mode
 	if count == 0 {
 		return fs.Save(rctx, info)
 	}
+	fs.recordWrite(rctx)
 	return info, nil
 }
 
@@ -423,6 +425,7 @@ func (fs SqlFileInfoStore) AttachToPost(rctx request.CTX, fileId, postId, channe
 		// Could not attach the file to the post
 		return store.NewErrInvalidInput("FileInfo", "<id, postId, creatorId>", fmt.Sprintf("<%s, %s, %s>", fileId, postId, creatorId))
 	}
+	fs.recordWrite(rctx)
 	return nil
 }
 
@@ -441,6 +444,7 @@ func (fs SqlFileInfoStore) SetContent(rctx request.CTX, fileId, content string)
 	if err != nil {
 		return errors.Wrapf(err, "failed to update FileInfo content with id=%s", fileId)
 	}
+	fs.recordWrite(rctx)
 
 	return nil
 }
@@ -455,6 +459,7 @@ func (fs SqlFileInfoStore) DeleteForPost(rctx request.CTX, postId string) (strin
 				PostId = ?`, model.GetMillis(), postId); err != nil {
 		return "", errors.Wrapf(err, "failed to update FileInfo with postId=%s", postId)
 	}
+	fs.recordWrite(rctx)
 	return postId, nil
 }
 
@@ -475,6 +480,7 @@ func (fs SqlFileInfoStore) DeleteForPostByIds(rctx request.CTX, postId string, f
 	if _, err := fs.GetMaster().Exec(queryString, args...); err != nil {
 		return errors.Wrap(err, "SqlFileInfoStore.DeleteForPostByIds: failed to soft delete FileInfo from database")
 	}
+	fs.recordWrite(rctx)
 
 	return nil
 }
@@ -483,6 +489,7 @@ func (fs SqlFileInfoStore) PermanentDeleteForPost(rctx request.CTX, postID strin
 	if _, err := fs.GetMaster().Exec(`DELETE FROM FileInfo WHERE PostId = ?`, postID); err != nil {
 		return errors.Wrapf(err, "failed to delete FileInfo with PostId=%s", postID)
 	}
+	fs.recordWrite(rctx)
 	return nil
 }
 
@@ -490,18 +497,30 @@ func (fs SqlFileInfoStore) PermanentDelete(rctx request.CTX, fileId string) erro
 	if _, err := fs.GetMaster().Exec(`DELETE FROM FileInfo WHERE Id = ?`, fileId); err != nil {
 		return errors.Wrapf(err, "failed to delete FileInfo with id=%s", fileId)
 	}
+	fs.recordWrite(rctx)
 	return nil
 }
 
 func (fs SqlFileInfoStore) PermanentDeleteBatch(rctx request.CTX, endTime int64, limit int64) (int64, error) {
+	// Whole partitions are dropped rather than deleting their rows one by one,
+	// the bookmark files being kept.
+	dropped, err := fs.dropPartitionsBefore("FileInfo", endTime, false)
+	if err != nil {
+		return 0, errors.Wrap(err, "failed to drop FileInfo partitions")
+	}
+
 	var query string
+	args := []any{endTime, model.BookmarkFileOwner, limit}
 	if fs.DriverName() == "postgres" {
-		query = "DELETE from FileInfo WHERE Id = any (array (SELECT Id FROM FileInfo WHERE CreateAt < ? AND CreatorId != ? LIMIT ?))"
+		query = "DELETE from FileInfo WHERE CreateAt < ? AND Id = any (array (SELECT Id FROM FileInfo WHERE CreateAt < ? AND CreatorId != ? LIMIT ?))"
+		args = []any{endTime, endTime, model.BookmarkFileOwner, limit}
+	} else if fs.DriverName() == model.DatabaseDriverSqlite {
+		query = "DELETE from FileInfo WHERE Id IN (SELECT Id FROM FileInfo WHERE CreateAt < ? AND CreatorId != ? LIMIT ?)"
 	} else {
 		query = "DELETE from FileInfo WHERE CreateAt < ? AND CreatorId != ? LIMIT ?"
 	}
 
-	sqlResult, err := fs.GetMaster().Exec(query, endTime, model.BookmarkFileOwner, limit)
+	sqlResult, err := fs.GetMaster().Exec(query, args...)
 	if err != nil {
 		return 0, errors.Wrap(err, "failed to delete FileInfos in batch")
 	}
@@ -510,8 +529,9 @@ func (fs SqlFileInfoStore) PermanentDeleteBatch(rctx request.CTX, endTime int64,
 	if err != nil {
 		return 0, errors.Wrapf(err, "unable to retrieve rows affected")
 	}
+	fs.recordWrite(rctx)
 
-	return rowsAffected, nil
+	return dropped + rowsAffected, nil
 }
 
 func (fs SqlFileInfoStore) PermanentDeleteByUser(rctx request.CTX, userId string) (int64, error) {
@@ -526,6 +546,7 @@ func (fs SqlFileInfoStore) PermanentDeleteByUser(rctx request.CTX, userId string
 	if err != nil {
 		return 0, errors.Wrapf(err, "unable to retrieve rows affected")
 	}
+	fs.recordWrite(rctx)
 
 	return rowsAffected, nil
 }
@@ -655,6 +676,13 @@ func (fs SqlFileInfoStore) Search(rctx request.CTX, paramsList []*model.SearchPa
 				sq.Expr(fmt.Sprintf("to_tsvector('%[1]s', Translate(FileInfo.Name, '.,-', '   ')) @@  to_tsquery('%[1]s', ?)", fs.pgDefaultTextSearchConfig), queryTerms),
 				sq.Expr(fmt.Sprintf("to_tsvector('%[1]s', FileInfo.Content) @@  to_tsquery('%[1]s', ?)", fs.pgDefaultTextSearchConfig), queryTerms),
 			})
+		} else if fs.DriverName() == model.DatabaseDriverSqlite {
+			if queryTerms := sqliteFullTextQuery(terms, params.OrTerms); queryTerms != "" {
+				query = query.Where("FileInfo.rowid IN (SELECT rowid FROM fts_fileinfo WHERE fts_fileinfo MATCH ?)", queryTerms)
+			}
+			if excludedQueryTerms := sqliteFullTextQuery(excludedTerms, true); excludedQueryTerms != "" {
+				query = query.Where("FileInfo.rowid NOT IN (SELECT rowid FROM fts_fileinfo WHERE fts_fileinfo MATCH ?)", excludedQueryTerms)
+			}
 		} else if fs.DriverName() == model.DatabaseDriverMysql {
 			var err error
 			terms, err = removeMysqlStopWordsFromTerms(terms)
@@ -847,6 +875,7 @@ func (fs SqlFileInfoStore) RestoreForPostByIds(rctx request.CTX, postId string,
 	if _, err := fs.GetMaster().Exec(queryString, args...); err != nil {
 		return errors.Wrap(err, "SqlFileInfoStore.RestoreForPostByIds: failed to undelete FileInfo from database")
 	}
+	fs.recordWrite(rctx)
 
 	return nil
 }
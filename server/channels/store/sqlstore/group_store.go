@@ -1328,10 +1328,10 @@ func (s *SqlGroupStore) groupsBySyncableBaseQuery(st model.GroupSyncableType, t
 	if opts.Q != "" {
 		pattern := fmt.Sprintf("%%%s%%", sanitizeSearchTerm(opts.Q, "\\"))
 		operatorKeyword := "ILIKE"
-		if s.DriverName() == model.DatabaseDriverMysql {
+		if s.DriverName() == model.DatabaseDriverMysql || s.DriverName() == model.DatabaseDriverSqlite {
 			operatorKeyword = "LIKE"
 		}
-		query = query.Where(fmt.Sprintf("(UserGroups.Name %[1]s ? OR UserGroups.DisplayName %[1]s ?)", operatorKeyword), pattern, pattern)
+		query = query.Where(fmt.Sprintf("(UserGroups.Name %[1]s ?%[2]s OR UserGroups.DisplayName %[1]s ?%[2]s)", operatorKeyword, likeEscape(s.DriverName())), pattern, pattern)
 	}
 
 	return query
@@ -1391,10 +1391,10 @@ func (s *SqlGroupStore) getGroupsAssociatedToChannelsByTeam(teamID string, opts
 	if opts.Q != "" {
 		pattern := fmt.Sprintf("%%%s%%", sanitizeSearchTerm(opts.Q, "\\"))
 		operatorKeyword := "ILIKE"
-		if s.DriverName() == model.DatabaseDriverMysql {
+		if s.DriverName() == model.DatabaseDriverMysql || s.DriverName() == model.DatabaseDriverSqlite {
 			operatorKeyword = "LIKE"
 		}
-		query = query.Where(fmt.Sprintf("(UserGroups.Name %[1]s ? OR UserGroups.DisplayName %[1]s ?)", operatorKeyword), pattern, pattern)
+		query = query.Where(fmt.Sprintf("(UserGroups.Name %[1]s ?%[2]s OR UserGroups.DisplayName %[1]s ?%[2]s)", operatorKeyword, likeEscape(s.DriverName())), pattern, pattern)
 	}
 
 	return query
@@ -1500,7 +1500,7 @@ func (s *SqlGroupStore) GetGroups(page, perPage int, opts model.GroupSearchOpts,
 						THEN JSON_EXTRACT(Timezone, '$.manualTimezone')
 						END
 					)) AS ChannelMemberTimezonesCount`
-			} else if s.DriverName() == model.DatabaseDriverPostgres {
+			} else if s.DriverName() == model.DatabaseDriverPostgres || s.DriverName() == model.DatabaseDriverSqlite {
 				selectStr += `,
 					COUNT(DISTINCT
 					(
@@ -1557,10 +1557,10 @@ func (s *SqlGroupStore) GetGroups(page, perPage int, opts model.GroupSearchOpts,
 	if opts.Q != "" {
 		pattern := fmt.Sprintf("%%%s%%", sanitizeSearchTerm(opts.Q, "\\"))
 		operatorKeyword := "ILIKE"
-		if s.DriverName() == model.DatabaseDriverMysql {
+		if s.DriverName() == model.DatabaseDriverMysql || s.DriverName() == model.DatabaseDriverSqlite {
 			operatorKeyword = "LIKE"
 		}
-		groupsQuery = groupsQuery.Where(fmt.Sprintf("(UserGroups.Name %[1]s ? OR UserGroups.DisplayName %[1]s ?)", operatorKeyword), pattern, pattern)
+		groupsQuery = groupsQuery.Where(fmt.Sprintf("(UserGroups.Name %[1]s ?%[2]s OR UserGroups.DisplayName %[1]s ?%[2]s)", operatorKeyword, likeEscape(s.DriverName())), pattern, pattern)
 	}
 
 	if len(opts.NotAssociatedToTeam) == 26 {
@@ -1662,7 +1662,7 @@ func (s *SqlGroupStore) teamMembersMinusGroupMembersQuery(teamID string, groupID
 			Column("TeamMembers.SchemeAdmin").
 			Column("TeamMembers.SchemeUser")
 
-		if s.DriverName() == model.DatabaseDriverMysql {
+		if s.DriverName() == model.DatabaseDriverMysql || s.DriverName() == model.DatabaseDriverSqlite {
 			builder = builder.Column("group_concat(UserGroups.Id) AS GroupIDs")
 		} else {
 			builder = builder.Column("string_agg(UserGroups.Id, ',') AS GroupIDs")
@@ -1741,7 +1741,7 @@ func (s *SqlGroupStore) channelMembersMinusGroupMembersQuery(channelID string, g
 			"ChannelMembers.SchemeUser",
 		)
 
-		if s.DriverName() == model.DatabaseDriverMysql {
+		if s.DriverName() == model.DatabaseDriverMysql || s.DriverName() == model.DatabaseDriverSqlite {
 			builder = builder.Column("group_concat(UserGroups.Id) AS GroupIDs")
 		} else {
 			builder = builder.Column("string_agg(UserGroups.Id, ',') AS GroupIDs")
@@ -1957,7 +1957,7 @@ func (s *SqlGroupStore) buildUpsertMembersQuery(groupID string, userIDs []string
 
 	if s.DriverName() == model.DatabaseDriverMysql {
 		builder = builder.SuffixExpr(sq.Expr("ON DUPLICATE KEY UPDATE CreateAt = ?, DeleteAt = ?", createAt, 0))
-	} else if s.DriverName() == model.DatabaseDriverPostgres {
+	} else if s.DriverName() == model.DatabaseDriverPostgres || s.DriverName() == model.DatabaseDriverSqlite {
 		builder = builder.SuffixExpr(sq.Expr("ON CONFLICT (groupid, userid) DO UPDATE SET CreateAt = ?, DeleteAt = ?", createAt, 0))
 	}
 
@@ -452,7 +452,7 @@ func (jss SqlJobStore) Delete(id string) (string, error) {
 
 func (jss SqlJobStore) Cleanup(expiryTime int64, batchSize int) error {
 	var query string
-	if jss.DriverName() == model.DatabaseDriverPostgres {
+	if jss.DriverName() == model.DatabaseDriverPostgres || jss.DriverName() == model.DatabaseDriverSqlite {
 		query = "DELETE FROM Jobs WHERE Id IN (SELECT Id FROM Jobs WHERE CreateAt < ? AND (Status != ? AND Status != ?) ORDER BY CreateAt ASC LIMIT ?)"
 	} else {
 		query = "DELETE FROM Jobs WHERE CreateAt < ? AND (Status != ? AND Status != ?) ORDER BY CreateAt ASC LIMIT ?"
@@ -495,6 +495,8 @@ func isRepeatableError(err error) bool {
 		if mysqlErr.Number == mySQLDeadlockCode {
 			return true
 		}
+	case isSqliteBusyError(err):
+		return true
 	}
 	return false
 }
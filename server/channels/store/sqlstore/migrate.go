@@ -22,6 +22,7 @@ import (
 	"github.com/mattermost/morph/drivers"
 	ms "github.com/mattermost/morph/drivers/mysql"
 	ps "github.com/mattermost/morph/drivers/postgres"
+	sl "github.com/mattermost/morph/drivers/sqlite"
 	"github.com/mattermost/morph/models"
 	mbindata "github.com/mattermost/morph/sources/embedded"
 )
@@ -143,6 +144,8 @@ func (ss *SqlStore) initMorph(dryRun, enableLogging bool) (*morph.Morph, error)
 		defer db.Close()
 	case model.DatabaseDriverPostgres:
 		driver, err = ps.WithInstance(ss.GetMaster().DB.DB)
+	case model.DatabaseDriverSqlite:
+		driver, err = sl.WithInstance(ss.GetMaster().DB.DB)
 	default:
 		err = fmt.Errorf("unsupported database type %s for migration", ss.DriverName())
 	}
@@ -159,10 +162,14 @@ func (ss *SqlStore) initMorph(dryRun, enableLogging bool) (*morph.Morph, error)
 
 	opts := []morph.EngineOption{
 		morph.WithLogger(log.New(logWriter, "", log.Lshortfile)),
-		morph.WithLock("mm-lock-key"),
 		morph.SetStatementTimeoutInSeconds(*ss.settings.MigrationsStatementTimeoutSeconds),
 		morph.SetDryRun(dryRun),
 	}
+	// SQLite databases can't be shared between servers, and the database
+	// lock already serializes the migrations.
+	if ss.DriverName() != model.DatabaseDriverSqlite {
+		opts = append(opts, morph.WithLock("mm-lock-key"))
+	}
 
 	engine, err := morph.New(context.Background(), driver, src, opts...)
 	if err != nil {
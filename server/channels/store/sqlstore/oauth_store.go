@@ -339,6 +339,8 @@ func (as SqlOAuthStore) deleteOAuthAppSessions(transaction *sqlxTxWrapper, clien
 	query := ""
 	if as.DriverName() == model.DatabaseDriverPostgres {
 		query = "DELETE FROM Sessions s USING OAuthAccessData o WHERE o.Token = s.Token AND o.ClientId = ?"
+	} else if as.DriverName() == model.DatabaseDriverSqlite {
+		query = "DELETE FROM Sessions WHERE Token IN (SELECT Token FROM OAuthAccessData WHERE ClientId = ?)"
 	} else if as.DriverName() == model.DatabaseDriverMysql {
 		query = "DELETE s.* FROM Sessions s INNER JOIN OAuthAccessData o ON o.Token = s.Token WHERE o.ClientId = ?"
 	}
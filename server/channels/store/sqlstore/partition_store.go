@@ -0,0 +1,541 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package sqlstore
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	sq "github.com/mattermost/squirrel"
+	"github.com/pkg/errors"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/v8/channels/store"
+)
+
+const (
+	// partitionMonthsAhead is the number of monthly partitions created after
+	// the current one when a table is partitioned.
+	partitionMonthsAhead = 3
+
+	partitionNameLayout = "2006_01"
+)
+
+// partitionKeptRows are the rows that outlive the partitions they are in when
+// those are archived or dropped, by table. They are moved to the default
+// partition instead.
+var partitionKeptRows = map[string]sq.Eq{
+	"fileinfo": {"CreatorId": model.BookmarkFileOwner},
+}
+
+// partitionedTables caches which tables are partitioned, by lower case name.
+type partitionedTables struct {
+	mut    sync.RWMutex
+	tables map[string]bool
+}
+
+func (pt *partitionedTables) set(tables ...string) {
+	pt.mut.Lock()
+	defer pt.mut.Unlock()
+
+	if pt.tables == nil {
+		pt.tables = make(map[string]bool)
+	}
+	for _, table := range tables {
+		pt.tables[strings.ToLower(table)] = true
+	}
+}
+
+func (pt *partitionedTables) get(table string) bool {
+	pt.mut.RLock()
+	defer pt.mut.RUnlock()
+
+	return pt.tables[strings.ToLower(table)]
+}
+
+type SqlPartitionStore struct {
+	*SqlStore
+}
+
+func newSqlPartitionStore(sqlStore *SqlStore) store.PartitionStore {
+	return &SqlPartitionStore{sqlStore}
+}
+
+// monthOf returns the start of the UTC month of the given time in milliseconds.
+func monthOf(millis int64) time.Time {
+	t := time.UnixMilli(millis).UTC()
+	return time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, time.UTC)
+}
+
+func partitionName(table string, month time.Time) string {
+	return table + "_p" + month.Format(partitionNameLayout)
+}
+
+// partitionRange returns the range of CreateAt of a monthly partition from its
+// name, or false if the name isn't the one of a monthly partition of the table.
+func partitionRange(table, name string) (int64, int64, bool) {
+	suffix, ok := strings.CutPrefix(name, table+"_p")
+	if !ok {
+		return 0, 0, false
+	}
+
+	month, err := time.ParseInLocation(partitionNameLayout, suffix, time.UTC)
+	if err != nil {
+		return 0, 0, false
+	}
+	return month.UnixMilli(), month.AddDate(0, 1, 0).UnixMilli(), true
+}
+
+func createPartition(tx *sqlxTxWrapper, table string, month time.Time) (*model.TablePartition, error) {
+	partition := &model.TablePartition{
+		Name: partitionName(table, month),
+		From: month.UnixMilli(),
+		To:   month.AddDate(0, 1, 0).UnixMilli(),
+	}
+	if _, err := tx.ExecNoTimeout(fmt.Sprintf("CREATE TABLE IF NOT EXISTS %s PARTITION OF %s FOR VALUES FROM (%d) TO (%d)", partition.Name, table, partition.From, partition.To)); err != nil {
+		return nil, errors.Wrapf(err, "failed to create partition %s", partition.Name)
+	}
+	return partition, nil
+}
+
+// detachPartition detaches a partition from its table, moving the rows the
+// table keeps to the default partition.
+func detachPartition(tx *sqlxTxWrapper, table, partition string) error {
+	if _, err := tx.ExecNoTimeout(fmt.Sprintf("ALTER TABLE %s DETACH PARTITION %s", table, partition)); err != nil {
+		return errors.Wrapf(err, "failed to detach partition %s", partition)
+	}
+
+	kept, ok := partitionKeptRows[table]
+	if !ok {
+		return nil
+	}
+	where, args, err := kept.ToSql()
+	if err != nil {
+		return errors.Wrap(err, "kept_rows_tosql")
+	}
+	if _, err := tx.ExecNoTimeout(fmt.Sprintf("INSERT INTO %s SELECT * FROM %s WHERE %s", table, partition, where), args...); err != nil {
+		return errors.Wrapf(err, "failed to keep the rows of partition %s", partition)
+	}
+	if _, err := tx.ExecNoTimeout(fmt.Sprintf("DELETE FROM %s WHERE %s", partition, where), args...); err != nil {
+		return errors.Wrapf(err, "failed to keep the rows of partition %s", partition)
+	}
+	return nil
+}
+
+func (ss *SqlStore) isPartitioned(table string) bool {
+	return ss.partitioned.get(table)
+}
+
+func (ss *SqlStore) loadPartitionedTables() error {
+	if ss.DriverName() != model.DatabaseDriverPostgres {
+		return nil
+	}
+
+	var tables []string
+	if err := ss.GetMaster().Select(&tables, `SELECT c.relname
+		FROM pg_partitioned_table p
+		JOIN pg_class c ON c.oid = p.partrelid
+		WHERE c.relnamespace = current_schema()::regnamespace`); err != nil {
+		return errors.Wrap(err, "failed to get partitioned tables")
+	}
+	ss.partitioned.set(tables...)
+	return nil
+}
+
+// getPartitions returns the monthly partitions of a table, attached and
+// archived, sorted by range.
+func (ss *SqlStore) getPartitions(tableName string) ([]*model.TablePartition, error) {
+	table := strings.ToLower(tableName)
+
+	var attached []string
+	if err := ss.GetMaster().Select(&attached, `SELECT c.relname
+		FROM pg_inherits i
+		JOIN pg_class c ON c.oid = i.inhrelid
+		WHERE i.inhparent = to_regclass(?)`, table); err != nil {
+		return nil, errors.Wrapf(err, "failed to get partitions of %s", tableName)
+	}
+
+	var archived []string
+	if err := ss.GetMaster().Select(&archived, `SELECT tablename
+		FROM pg_tables
+		WHERE schemaname = ? AND tablename LIKE ?`, *ss.settings.PartitionArchiveSchema, table+`\_p%`); err != nil {
+		return nil, errors.Wrapf(err, "failed to get archived partitions of %s", tableName)
+	}
+
+	partitions := []*model.TablePartition{}
+	for i, names := range [][]string{attached, archived} {
+		for _, name := range names {
+			from, to, ok := partitionRange(table, name)
+			if !ok {
+				continue
+			}
+			partitions = append(partitions, &model.TablePartition{
+				Table:    tableName,
+				Name:     name,
+				From:     from,
+				To:       to,
+				Archived: i == 1,
+			})
+		}
+	}
+	sort.Slice(partitions, func(i, j int) bool {
+		return partitions[i].From < partitions[j].From
+	})
+
+	return partitions, nil
+}
+
+// droppedIdsBatchSize is the number of ids of a dropped partition stored per
+// RetentionIdsForDeletion row.
+const droppedIdsBatchSize = 10000
+
+// hasGranularRetentionPolicies returns whether any channel or team is assigned
+// a retention policy, whose records may outlive the global policy.
+func (ss *SqlStore) hasGranularRetentionPolicies() (bool, error) {
+	var exists bool
+	if err := ss.GetMaster().Get(&exists, `SELECT
+		EXISTS (SELECT 1 FROM RetentionPoliciesChannels) OR
+		EXISTS (SELECT 1 FROM RetentionPoliciesTeams)`); err != nil {
+		return false, errors.Wrap(err, "failed to check for granular retention policies")
+	}
+	return exists, nil
+}
+
+// dropPartitionsBefore drops the partitions of a table, archived or not,
+// holding rows created before endTime only. It returns the number of rows
+// dropped.
+//
+// Partitions aren't split by channel, so nothing is dropped while granular
+// retention policies are assigned, and rows are left to be deleted one by one.
+// With storeDeletedIds, the ids of the dropped rows are stored in
+// RetentionIdsForDeletion for their dependent records to be deleted.
+func (ss *SqlStore) dropPartitionsBefore(tableName string, endTime int64, storeDeletedIds bool) (_ int64, err error) {
+	if ss.DriverName() != model.DatabaseDriverPostgres || !ss.isPartitioned(tableName) {
+		return 0, nil
+	}
+
+	granular, err := ss.hasGranularRetentionPolicies()
+	if err != nil {
+		return 0, err
+	}
+	if granular {
+		return 0, nil
+	}
+
+	partitions, err := ss.getPartitions(tableName)
+	if err != nil {
+		return 0, err
+	}
+	var expired []*model.TablePartition
+	for _, partition := range partitions {
+		if partition.To <= endTime {
+			expired = append(expired, partition)
+		}
+	}
+	if len(expired) == 0 {
+		return 0, nil
+	}
+
+	tx, err := ss.GetMaster().Beginx()
+	if err != nil {
+		return 0, errors.Wrap(err, "begin_transaction")
+	}
+	defer finalizeTransactionX(tx, &err)
+
+	table := strings.ToLower(tableName)
+	var dropped int64
+	for _, partition := range expired {
+		name := partition.Name
+		if partition.Archived {
+			name = quoteIdentifier(*ss.settings.PartitionArchiveSchema) + "." + name
+		} else if err = detachPartition(tx, table, name); err != nil {
+			return 0, err
+		}
+
+		var count int64
+		if storeDeletedIds {
+			var ids []string
+			if err = tx.Select(&ids, "SELECT Id FROM "+name); err != nil {
+				return 0, errors.Wrapf(err, "failed to get the ids of partition %s", partition.Name)
+			}
+			for start := 0; start < len(ids); start += droppedIdsBatchSize {
+				end := min(start+droppedIdsBatchSize, len(ids))
+				if err = insertRetentionIdsForDeletion(tx, &model.RetentionIdsForDeletion{
+					TableName: tableName,
+					Ids:       ids[start:end],
+				}, ss); err != nil {
+					return 0, errors.Wrapf(err, "failed to store the ids of partition %s", partition.Name)
+				}
+			}
+			count = int64(len(ids))
+		} else if err = tx.Get(&count, "SELECT COUNT(*) FROM "+name); err != nil {
+			return 0, errors.Wrapf(err, "failed to count the rows of partition %s", partition.Name)
+		}
+		if _, err = tx.ExecNoTimeout("DROP TABLE " + name); err != nil {
+			return 0, errors.Wrapf(err, "failed to drop partition %s", partition.Name)
+		}
+		dropped += count
+	}
+
+	if err = tx.Commit(); err != nil {
+		return 0, errors.Wrap(err, "commit_transaction")
+	}
+
+	return dropped, nil
+}
+
+// partitionableTable returns the name of a table that can be partitioned, as
+// in store.PartitionableTables, or an error if it can't.
+func (s *SqlPartitionStore) partitionableTable(tableName string) (string, error) {
+	if s.DriverName() != model.DatabaseDriverPostgres {
+		return "", store.NewErrNotImplemented("table partitioning is only supported on Postgres")
+	}
+
+	for _, partitionable := range store.PartitionableTables {
+		if strings.EqualFold(partitionable, tableName) {
+			return partitionable, nil
+		}
+	}
+	return "", store.NewErrInvalidInput("Partition", "tableName", tableName)
+}
+
+func (s *SqlPartitionStore) IsPartitioned(tableName string) bool {
+	return s.isPartitioned(tableName)
+}
+
+func (s *SqlPartitionStore) PartitionTable(tableName string) (err error) {
+	tableName, err = s.partitionableTable(tableName)
+	if err != nil {
+		return err
+	}
+	table := strings.ToLower(tableName)
+	if s.isPartitioned(table) {
+		return nil
+	}
+
+	tx, err := s.GetMaster().Beginx()
+	if err != nil {
+		return errors.Wrap(err, "begin_transaction")
+	}
+	defer finalizeTransactionX(tx, &err)
+
+	// The primary key of a partitioned table has to include CreateAt.
+	var primaryKey []string
+	if err = tx.Select(&primaryKey, `SELECT a.attname
+		FROM pg_index i
+		JOIN pg_attribute a ON a.attrelid = i.indrelid AND a.attnum = ANY(i.indkey)
+		WHERE i.indrelid = ?::regclass AND i.indisprimary
+		ORDER BY array_position(i.indkey::int2[], a.attnum)`, table); err != nil {
+		return errors.Wrapf(err, "failed to get the primary key of %s", tableName)
+	}
+	for i, column := range primaryKey {
+		primaryKey[i] = quoteIdentifier(column)
+	}
+	primaryKey = append(primaryKey, "createat")
+
+	var indexes []string
+	if err = tx.Select(&indexes, `SELECT pg_get_indexdef(indexrelid)
+		FROM pg_index
+		WHERE indrelid = ?::regclass AND NOT indisprimary`, table); err != nil {
+		return errors.Wrapf(err, "failed to get the indexes of %s", tableName)
+	}
+
+	// The views reading from the table are recreated on the partitioned one.
+	var views []struct {
+		Oid        int64
+		Name       string
+		Kind       string
+		Definition string
+	}
+	if err = tx.Select(&views, `SELECT DISTINCT v.oid::bigint AS oid, v.oid::regclass::text AS name, v.relkind::text AS kind, pg_get_viewdef(v.oid) AS definition
+		FROM pg_depend d
+		JOIN pg_rewrite r ON r.oid = d.objid
+		JOIN pg_class v ON v.oid = r.ev_class
+		WHERE d.refobjid = ?::regclass AND v.relkind IN ('v', 'm')`, table); err != nil {
+		return errors.Wrapf(err, "failed to get the views of %s", tableName)
+	}
+	var dropViews, createViews []string
+	for _, view := range views {
+		kind := "VIEW"
+		if view.Kind == "m" {
+			kind = "MATERIALIZED VIEW"
+		}
+		dropViews = append(dropViews, fmt.Sprintf("DROP %s %s", kind, view.Name))
+		createViews = append(createViews, fmt.Sprintf("CREATE %s %s AS %s", kind, view.Name, view.Definition))
+
+		var viewIndexes []string
+		if err = tx.Select(&viewIndexes, "SELECT pg_get_indexdef(indexrelid) FROM pg_index WHERE indrelid = ?", view.Oid); err != nil {
+			return errors.Wrapf(err, "failed to get the indexes of view %s", view.Name)
+		}
+		createViews = append(createViews, viewIndexes...)
+	}
+
+	unpartitioned := table + "_unpartitioned"
+	statements := dropViews
+	statements = append(statements,
+		fmt.Sprintf("ALTER TABLE %s RENAME TO %s", table, unpartitioned),
+		fmt.Sprintf("CREATE TABLE %s (LIKE %s INCLUDING DEFAULTS INCLUDING CONSTRAINTS INCLUDING STORAGE INCLUDING COMMENTS) PARTITION BY RANGE (createat)", table, unpartitioned),
+		// The default partition gets the rows outside of the monthly ones,
+		// like the ones imported with an unset CreateAt.
+		fmt.Sprintf("CREATE TABLE %s_default PARTITION OF %s DEFAULT", table, table),
+	)
+	for _, statement := range statements {
+		if _, err = tx.ExecNoTimeout(statement); err != nil {
+			return errors.Wrapf(err, "failed to partition %s", tableName)
+		}
+	}
+
+	var oldest int64
+	if err = tx.Get(&oldest, fmt.Sprintf("SELECT COALESCE(MIN(createat), 0) FROM %s WHERE createat > 0", unpartitioned)); err != nil {
+		return errors.Wrapf(err, "failed to get the oldest row of %s", tableName)
+	}
+	now := model.GetMillis()
+	month := monthOf(now)
+	if oldest > 0 && oldest < now {
+		month = monthOf(oldest)
+	}
+	for last := monthOf(now).AddDate(0, partitionMonthsAhead, 0); !month.After(last); month = month.AddDate(0, 1, 0) {
+		if _, err = createPartition(tx, table, month); err != nil {
+			return err
+		}
+	}
+
+	statements = []string{
+		fmt.Sprintf("INSERT INTO %s SELECT * FROM %s", table, unpartitioned),
+		fmt.Sprintf("DROP TABLE %s", unpartitioned),
+		fmt.Sprintf("ALTER TABLE %s ADD PRIMARY KEY (%s)", table, strings.Join(primaryKey, ", ")),
+	}
+	statements = append(statements, indexes...)
+	statements = append(statements, createViews...)
+	for _, statement := range statements {
+		if _, err = tx.ExecNoTimeout(statement); err != nil {
+			return errors.Wrapf(err, "failed to partition %s", tableName)
+		}
+	}
+
+	if err = tx.Commit(); err != nil {
+		return errors.Wrap(err, "commit_transaction")
+	}
+	s.partitioned.set(table)
+
+	return nil
+}
+
+func (s *SqlPartitionStore) GetPartitions(tableName string) ([]*model.TablePartition, error) {
+	tableName, err := s.partitionableTable(tableName)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.getPartitions(tableName)
+}
+
+func (s *SqlPartitionStore) CreatePartitions(until int64) (_ []*model.TablePartition, err error) {
+	if s.DriverName() != model.DatabaseDriverPostgres {
+		return nil, store.NewErrNotImplemented("table partitioning is only supported on Postgres")
+	}
+
+	tx, err := s.GetMaster().Beginx()
+	if err != nil {
+		return nil, errors.Wrap(err, "begin_transaction")
+	}
+	defer finalizeTransactionX(tx, &err)
+
+	created := []*model.TablePartition{}
+	for _, tableName := range store.PartitionableTables {
+		if !s.isPartitioned(tableName) {
+			continue
+		}
+
+		partitions, err := s.getPartitions(tableName)
+		if err != nil {
+			return nil, err
+		}
+		existing := make(map[string]bool, len(partitions))
+		for _, partition := range partitions {
+			existing[partition.Name] = true
+		}
+
+		table := strings.ToLower(tableName)
+		for month := monthOf(model.GetMillis()); !month.After(monthOf(until)); month = month.AddDate(0, 1, 0) {
+			if existing[partitionName(table, month)] {
+				continue
+			}
+			partition, err := createPartition(tx, table, month)
+			if err != nil {
+				return nil, err
+			}
+			partition.Table = tableName
+			created = append(created, partition)
+		}
+	}
+
+	if err = tx.Commit(); err != nil {
+		return nil, errors.Wrap(err, "commit_transaction")
+	}
+
+	return created, nil
+}
+
+func (s *SqlPartitionStore) ArchivePartitions(before int64) (_ []*model.TablePartition, err error) {
+	if s.DriverName() != model.DatabaseDriverPostgres {
+		return nil, store.NewErrNotImplemented("table partitioning is only supported on Postgres")
+	}
+
+	tx, err := s.GetMaster().Beginx()
+	if err != nil {
+		return nil, errors.Wrap(err, "begin_transaction")
+	}
+	defer finalizeTransactionX(tx, &err)
+
+	schema := quoteIdentifier(*s.settings.PartitionArchiveSchema)
+	if _, err = tx.ExecNoTimeout("CREATE SCHEMA IF NOT EXISTS " + schema); err != nil {
+		return nil, errors.Wrap(err, "failed to create the archive schema")
+	}
+
+	archived := []*model.TablePartition{}
+	for _, tableName := range store.PartitionableTables {
+		if !s.isPartitioned(tableName) {
+			continue
+		}
+
+		partitions, err := s.getPartitions(tableName)
+		if err != nil {
+			return nil, err
+		}
+
+		table := strings.ToLower(tableName)
+		for _, partition := range partitions {
+			if partition.Archived || partition.To > before {
+				continue
+			}
+
+			if err := detachPartition(tx, table, partition.Name); err != nil {
+				return nil, err
+			}
+			archivedName := schema + "." + partition.Name
+			for _, statement := range []string{
+				fmt.Sprintf("ALTER TABLE %s SET SCHEMA %s", partition.Name, schema),
+				fmt.Sprintf("CREATE TRIGGER reject_writes BEFORE INSERT OR UPDATE OR DELETE ON %s FOR EACH ROW EXECUTE FUNCTION reject_archived_partition_writes()", archivedName),
+				fmt.Sprintf("CREATE TRIGGER reject_truncate BEFORE TRUNCATE ON %s FOR EACH STATEMENT EXECUTE FUNCTION reject_archived_partition_writes()", archivedName),
+			} {
+				if _, err := tx.ExecNoTimeout(statement); err != nil {
+					return nil, errors.Wrapf(err, "failed to archive partition %s", partition.Name)
+				}
+			}
+
+			partition.Archived = true
+			archived = append(archived, partition)
+		}
+	}
+
+	if err = tx.Commit(); err != nil {
+		return nil, errors.Wrap(err, "commit_transaction")
+	}
+
+	return archived, nil
+}
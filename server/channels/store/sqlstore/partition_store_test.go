@@ -0,0 +1,247 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package sqlstore
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/public/shared/mlog"
+	"github.com/mattermost/mattermost/server/public/shared/request"
+	"github.com/mattermost/mattermost/server/v8/channels/store"
+	"github.com/mattermost/mattermost/server/v8/channels/store/storetest"
+)
+
+func TestPartitionRange(t *testing.T) {
+	month := time.Date(2024, time.December, 1, 0, 0, 0, 0, time.UTC)
+	name := partitionName("posts", month)
+	assert.Equal(t, "posts_p2024_12", name)
+
+	from, to, ok := partitionRange("posts", name)
+	require.True(t, ok)
+	assert.Equal(t, month.UnixMilli(), from)
+	assert.Equal(t, time.Date(2025, time.January, 1, 0, 0, 0, 0, time.UTC).UnixMilli(), to)
+
+	assert.Equal(t, month, monthOf(month.AddDate(0, 1, 0).UnixMilli()-1))
+
+	for _, name := range []string{"posts_default", "posts_p2024_13", "reactions_p2024_12", "posts_unpartitioned"} {
+		_, _, ok = partitionRange("posts", name)
+		assert.False(t, ok, name)
+	}
+}
+
+func TestPartitionStoreNotSupported(t *testing.T) {
+	StoreTest(t, func(t *testing.T, rctx request.CTX, ss store.Store) {
+		if ss.(*SqlStore).DriverName() == model.DatabaseDriverPostgres {
+			t.Skip("Partitioning is supported on Postgres")
+		}
+
+		var nie *store.ErrNotImplemented
+		assert.ErrorAs(t, ss.Partition().PartitionTable("Posts"), &nie)
+		assert.False(t, ss.Partition().IsPartitioned("Posts"))
+		_, err := ss.Partition().CreatePartitions(model.GetMillis())
+		assert.ErrorAs(t, err, &nie)
+	})
+}
+
+func TestPartitionStore(t *testing.T) {
+	logger := mlog.CreateConsoleTestLogger(t)
+	rctx := request.TestContext(t)
+
+	settings, err := makeSqlSettings(model.DatabaseDriverPostgres)
+	if err != nil {
+		t.Skip(err)
+	}
+
+	ss, err := New(*settings, logger, nil)
+	require.NoError(t, err)
+	defer func() {
+		ss.Close()
+		storetest.CleanupSqlSettings(settings)
+	}()
+
+	now := model.GetMillis()
+	oldMonth := monthOf(now).AddDate(-2, 0, 0)
+	old := oldMonth.UnixMilli() + 1000
+
+	oldPost, err := ss.Post().Save(rctx, &model.Post{ChannelId: model.NewId(), UserId: model.NewId(), Message: "old", CreateAt: old})
+	require.NoError(t, err)
+	recentPost, err := ss.Post().Save(rctx, &model.Post{ChannelId: model.NewId(), UserId: model.NewId(), Message: "recent"})
+	require.NoError(t, err)
+	oldFile, err := ss.FileInfo().Save(rctx, &model.FileInfo{Id: model.NewId(), CreatorId: model.NewId(), Path: "old", CreateAt: old})
+	require.NoError(t, err)
+	bookmarkFile, err := ss.FileInfo().Save(rctx, &model.FileInfo{Id: model.NewId(), CreatorId: model.BookmarkFileOwner, Path: "bookmark", CreateAt: old})
+	require.NoError(t, err)
+	channel, err := ss.Channel().Save(rctx, &model.Channel{TeamId: model.NewId(), DisplayName: "DisplayName", Name: "channel" + model.NewId(), Type: model.ChannelTypeOpen}, -1)
+	require.NoError(t, err)
+	policyPost, err := ss.Post().Save(rctx, &model.Post{ChannelId: channel.Id, UserId: model.NewId(), Message: "policy", CreateAt: oldMonth.AddDate(0, 2, 0).UnixMilli() + 1000})
+	require.NoError(t, err)
+
+	_, err = ss.Partition().GetPartitions("Users")
+	var iie *store.ErrInvalidInput
+	require.ErrorAs(t, err, &iie)
+
+	for _, table := range store.PartitionableTables {
+		require.False(t, ss.Partition().IsPartitioned(table))
+		require.NoError(t, ss.Partition().PartitionTable(table))
+		require.True(t, ss.Partition().IsPartitioned(table))
+	}
+
+	t.Run("the rows are moved to the partitions", func(t *testing.T) {
+		_, err := ss.Post().GetSingle(rctx, oldPost.Id, false)
+		require.NoError(t, err)
+		_, err = ss.Post().GetSingle(rctx, recentPost.Id, false)
+		require.NoError(t, err)
+
+		partitions, err := ss.Partition().GetPartitions("Posts")
+		require.NoError(t, err)
+		require.NotEmpty(t, partitions)
+		assert.Equal(t, oldMonth.UnixMilli(), partitions[0].From)
+		assert.Equal(t, monthOf(now).AddDate(0, partitionMonthsAhead+1, 0).UnixMilli(), partitions[len(partitions)-1].To)
+		assert.Equal(t, "posts_p"+oldMonth.Format(partitionNameLayout), partitions[0].Name)
+	})
+
+	t.Run("the partitioned state is loaded with the store", func(t *testing.T) {
+		ss2, err := New(*settings, logger, nil, SkipMigrations())
+		require.NoError(t, err)
+		defer ss2.Close()
+
+		for _, table := range store.PartitionableTables {
+			assert.True(t, ss2.Partition().IsPartitioned(table))
+		}
+	})
+
+	t.Run("reactions are saved once", func(t *testing.T) {
+		reaction := &model.Reaction{UserId: model.NewId(), PostId: recentPost.Id, EmojiName: "smile", ChannelId: recentPost.ChannelId}
+		_, err := ss.Reaction().Save(reaction)
+		require.NoError(t, err)
+		_, err = ss.Reaction().Delete(reaction)
+		require.NoError(t, err)
+		_, err = ss.Reaction().Save(reaction)
+		require.NoError(t, err)
+
+		reactions, err := ss.Reaction().GetForPost(recentPost.Id, false)
+		require.NoError(t, err)
+		assert.Len(t, reactions, 1)
+	})
+
+	t.Run("concurrent reaction saves insert once", func(t *testing.T) {
+		userID := model.NewId()
+		var wg sync.WaitGroup
+		for range 10 {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				_, err := ss.Reaction().Save(&model.Reaction{UserId: userID, PostId: recentPost.Id, EmojiName: "tada", ChannelId: recentPost.ChannelId})
+				assert.NoError(t, err)
+			}()
+		}
+		wg.Wait()
+
+		var count int64
+		require.NoError(t, ss.GetMaster().Get(&count, "SELECT COUNT(*) FROM Reactions WHERE UserId = ? AND PostId = ? AND EmojiName = 'tada'", userID, recentPost.Id))
+		assert.Equal(t, int64(1), count)
+	})
+
+	t.Run("upcoming partitions are created", func(t *testing.T) {
+		until := monthOf(now).AddDate(0, partitionMonthsAhead+2, 0)
+		created, err := ss.Partition().CreatePartitions(until.UnixMilli())
+		require.NoError(t, err)
+		assert.Len(t, created, 2*len(store.PartitionableTables))
+
+		created, err = ss.Partition().CreatePartitions(until.UnixMilli())
+		require.NoError(t, err)
+		assert.Empty(t, created)
+	})
+
+	t.Run("old partitions are archived", func(t *testing.T) {
+		archived, err := ss.Partition().ArchivePartitions(oldMonth.AddDate(0, 1, 0).UnixMilli())
+		require.NoError(t, err)
+		require.Len(t, archived, len(store.PartitionableTables))
+		for _, partition := range archived {
+			assert.True(t, partition.Archived)
+		}
+
+		_, err = ss.Post().GetSingle(rctx, oldPost.Id, false)
+		var nfErr *store.ErrNotFound
+		require.ErrorAs(t, err, &nfErr)
+		_, err = ss.FileInfo().Get(oldFile.Id)
+		require.ErrorAs(t, err, &nfErr)
+		_, err = ss.FileInfo().Get(bookmarkFile.Id)
+		require.NoError(t, err)
+
+		var count int64
+		require.NoError(t, ss.GetMaster().Get(&count, "SELECT COUNT(*) FROM archive.posts_p"+oldMonth.Format(partitionNameLayout)))
+		assert.Equal(t, int64(1), count)
+		_, err = ss.GetMaster().Exec("DELETE FROM archive.posts_p" + oldMonth.Format(partitionNameLayout))
+		require.Error(t, err)
+
+		partitions, err := ss.Partition().GetPartitions("Posts")
+		require.NoError(t, err)
+		assert.True(t, partitions[0].Archived)
+	})
+
+	t.Run("data retention drops the partitions", func(t *testing.T) {
+		deleted, err := ss.Post().PermanentDeleteBatch(oldMonth.AddDate(0, 2, 0).UnixMilli(), 1000)
+		require.NoError(t, err)
+		assert.Equal(t, int64(1), deleted)
+
+		partitions, err := ss.Partition().GetPartitions("Posts")
+		require.NoError(t, err)
+		assert.Equal(t, oldMonth.AddDate(0, 2, 0).UnixMilli(), partitions[0].From)
+
+		_, err = ss.FileInfo().PermanentDeleteBatch(rctx, oldMonth.AddDate(0, 2, 0).UnixMilli(), 1000)
+		require.NoError(t, err)
+		_, err = ss.FileInfo().Get(bookmarkFile.Id)
+		require.NoError(t, err)
+
+		_, err = ss.Post().GetSingle(rctx, recentPost.Id, false)
+		require.NoError(t, err)
+	})
+
+	t.Run("granular retention policies keep the partitions", func(t *testing.T) {
+		policy, err := ss.RetentionPolicy().Save(&model.RetentionPolicyWithTeamAndChannelIDs{
+			RetentionPolicy: model.RetentionPolicy{
+				DisplayName:      "DisplayName",
+				PostDurationDays: model.NewPointer(int64(-1)),
+			},
+			ChannelIDs: []string{channel.Id},
+		})
+		require.NoError(t, err)
+
+		config := model.RetentionPolicyBatchConfigs{
+			Now:                 now,
+			GlobalPolicyEndTime: oldMonth.AddDate(0, 3, 0).UnixMilli(),
+			Limit:               1000,
+		}
+		_, _, err = ss.Post().PermanentDeleteBatchForRetentionPolicies(config, model.RetentionPolicyCursor{})
+		require.NoError(t, err)
+		_, err = ss.Post().GetSingle(rctx, policyPost.Id, false)
+		require.NoError(t, err)
+
+		partitions, err := ss.Partition().GetPartitions("Posts")
+		require.NoError(t, err)
+		assert.Equal(t, oldMonth.AddDate(0, 2, 0).UnixMilli(), partitions[0].From)
+
+		require.NoError(t, ss.RetentionPolicy().Delete(policy.ID))
+
+		deleted, _, err := ss.Post().PermanentDeleteBatchForRetentionPolicies(config, model.RetentionPolicyCursor{})
+		require.NoError(t, err)
+		assert.Equal(t, int64(1), deleted)
+
+		partitions, err = ss.Partition().GetPartitions("Posts")
+		require.NoError(t, err)
+		assert.Equal(t, oldMonth.AddDate(0, 3, 0).UnixMilli(), partitions[0].From)
+
+		rows, err := ss.RetentionPolicy().GetIdsForDeletionByTableName("Posts", 1000)
+		require.NoError(t, err)
+		require.Len(t, rows, 1)
+		assert.Equal(t, []string{policyPost.Id}, rows[0].Ids)
+	})
+}
@@ -136,7 +136,7 @@ func (s *SqlPostPersistentNotificationStore) DeleteByChannel(channelIds []string
 			Set("PersistentNotifications.DeleteAt", deleteAt)
 	}
 
-	if s.DriverName() == model.DatabaseDriverPostgres {
+	if s.DriverName() == model.DatabaseDriverPostgres || s.DriverName() == model.DatabaseDriverSqlite {
 		builder = builderType.
 			Update("PersistentNotifications").
 			Set("DeleteAt", deleteAt).
@@ -171,7 +171,7 @@ func (s *SqlPostPersistentNotificationStore) DeleteByTeam(teamIds []string) erro
 			Set("PersistentNotifications.DeleteAt", deleteAt)
 	}
 
-	if s.DriverName() == model.DatabaseDriverPostgres {
+	if s.DriverName() == model.DatabaseDriverPostgres || s.DriverName() == model.DatabaseDriverSqlite {
 		builder = builderType.
 			Update("PersistentNotifications").
 			Set("DeleteAt", deleteAt).
@@ -247,6 +247,7 @@ func (s *SqlPostStore) SaveMultiple(rctx request.CTX, posts []*model.Post) ([]*m
 		// don't need to rollback here since the transaction is already closed
 		return posts, -1, errors.Wrap(err, "commit_transaction")
 	}
+	s.recordWrite(rctx)
 
 	for channelId, count := range channelNewPosts {
 		countRoot := channelNewRootPosts[channelId]
@@ -408,6 +409,7 @@ func (s *SqlPostStore) Update(rctx request.CTX, newPost *model.Post, oldPost *mo
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to insert the old post")
 	}
+	s.recordWrite(rctx)
 
 	return newPost, nil
 }
@@ -464,6 +466,7 @@ func (s *SqlPostStore) OverwriteMultiple(rctx request.CTX, posts []*model.Post)
 	if err != nil {
 		return nil, -1, errors.Wrap(err, "commit_transaction")
 	}
+	s.recordWrite(rctx)
 
 	return posts, -1, nil
 }
@@ -984,6 +987,12 @@ func (s *SqlPostStore) Delete(rctx request.CTX, postID string, time int64, delet
 				UpdateAt = $1,
 				Props = jsonb_set(Props, $2, $3)
 			WHERE Id = $4 OR RootId = $4`, time, jsonKeyPath(model.PostPropsDeleteBy), jsonStringVal(deleteByID), postID)
+	} else if s.DriverName() == model.DatabaseDriverSqlite {
+		_, err = transaction.Exec(`UPDATE Posts
+			SET DeleteAt = ?,
+			UpdateAt = ?,
+			Props = JSON_SET(Props, ?, ?)
+			Where Id = ? OR RootId = ?`, time, time, "$."+model.PostPropsDeleteBy, deleteByID, postID, postID)
 	} else {
 		// We use ORDER BY clause for MySQL
 		// to trigger filesort optimization in the index_merge.
@@ -1021,12 +1030,18 @@ func (s *SqlPostStore) Delete(rctx request.CTX, postID string, time int64, delet
 	if err = transaction.Commit(); err != nil {
 		return errors.Wrap(err, "commit_transaction")
 	}
+	s.recordWrite(rctx)
 
 	return nil
 }
 
 func (s *SqlPostStore) PermanentDelete(rctx request.CTX, postID string) (err error) {
-	return s.permanentDelete([]string{postID})
+	if err := s.permanentDelete([]string{postID}); err != nil {
+		return err
+	}
+	s.recordWrite(rctx)
+
+	return nil
 }
 
 func (s *SqlPostStore) permanentDelete(postIds []string) (err error) {
@@ -1142,6 +1157,7 @@ func (s *SqlPostStore) PermanentDeleteByUser(rctx request.CTX, userId string) er
 			return store.NewErrLimitExceeded("permanently deleting posts for user", maxLoops*1000, "userId="+userId)
 		}
 	}
+	s.recordWrite(rctx)
 
 	return nil
 }
@@ -1195,6 +1211,7 @@ func (s *SqlPostStore) PermanentDeleteByChannel(rctx request.CTX, channelId stri
 	if err = transaction.Commit(); err != nil {
 		return errors.Wrap(err, "commit_transaction")
 	}
+	s.recordWrite(rctx)
 
 	return nil
 }
@@ -1262,7 +1279,7 @@ func (s *SqlPostStore) prepareThreadedResponse(posts []*postWithExtra, extended,
 	return list, nil
 }
 
-func (s *SqlPostStore) getPostsCollapsedThreads(options model.GetPostsOptions, sanitizeOptions map[string]bool) (*model.PostList, error) {
+func (s *SqlPostStore) getPostsCollapsedThreads(rctx request.CTX, options model.GetPostsOptions, sanitizeOptions map[string]bool) (*model.PostList, error) {
 	var columns []string
 	for _, c := range postSliceColumns() {
 		columns = append(columns, "Posts."+c)
@@ -1288,7 +1305,7 @@ func (s *SqlPostStore) getPostsCollapsedThreads(options model.GetPostsOptions, s
 		Offset(uint64(offset)).
 		OrderBy("Posts.CreateAt DESC").ToSql()
 
-	err := s.GetReplica().Select(&posts, postFetchQuery, args...)
+	err := s.DBXFromContext(rctx.Context()).Select(&posts, postFetchQuery, args...)
 	if err != nil {
 		return nil, errors.Wrapf(err, "failed to find Posts with channelId=%s", options.ChannelId)
 	}
@@ -1296,24 +1313,25 @@ func (s *SqlPostStore) getPostsCollapsedThreads(options model.GetPostsOptions, s
 	return s.prepareThreadedResponse(posts, options.CollapsedThreadsExtended, false, sanitizeOptions)
 }
 
-func (s *SqlPostStore) GetPosts(options model.GetPostsOptions, _ bool, sanitizeOptions map[string]bool) (*model.PostList, error) {
+func (s *SqlPostStore) GetPosts(rctx request.CTX, options model.GetPostsOptions, _ bool, sanitizeOptions map[string]bool) (*model.PostList, error) {
 	if options.PerPage > 1000 {
 		return nil, store.NewErrInvalidInput("Post", "<options.PerPage>", options.PerPage)
 	}
 	if options.CollapsedThreads {
-		return s.getPostsCollapsedThreads(options, sanitizeOptions)
+		return s.getPostsCollapsedThreads(rctx, options, sanitizeOptions)
 	}
 	offset := options.PerPage * options.Page
+	db := s.DBXFromContext(rctx.Context())
 
 	rpc := make(chan store.StoreResult[[]*model.Post], 1)
 	go func() {
-		posts, err := s.getRootPosts(options.ChannelId, offset, options.PerPage, options.SkipFetchThreads, options.IncludeDeleted)
+		posts, err := s.getRootPosts(db, options.ChannelId, offset, options.PerPage, options.SkipFetchThreads, options.IncludeDeleted)
 		rpc <- store.StoreResult[[]*model.Post]{Data: posts, NErr: err}
 		close(rpc)
 	}()
 	cpc := make(chan store.StoreResult[[]*model.Post], 1)
 	go func() {
-		posts, err := s.getParentsPosts(options.ChannelId, offset, options.PerPage, options.SkipFetchThreads, options.IncludeDeleted)
+		posts, err := s.getParentsPosts(db, options.ChannelId, offset, options.PerPage, options.SkipFetchThreads, options.IncludeDeleted)
 		cpc <- store.StoreResult[[]*model.Post]{Data: posts, NErr: err}
 		close(cpc)
 	}()
@@ -1347,7 +1365,7 @@ func (s *SqlPostStore) GetPosts(options model.GetPostsOptions, _ bool, sanitizeO
 	return list, nil
 }
 
-func (s *SqlPostStore) getPostsSinceCollapsedThreads(options model.GetPostsSinceOptions, sanitizeOptions map[string]bool) (*model.PostList, error) {
+func (s *SqlPostStore) getPostsSinceCollapsedThreads(rctx request.CTX, options model.GetPostsSinceOptions, sanitizeOptions map[string]bool) (*model.PostList, error) {
 	var columns []string
 	for _, c := range postSliceColumns() {
 		columns = append(columns, "Posts."+c)
@@ -1376,7 +1394,7 @@ func (s *SqlPostStore) getPostsSinceCollapsedThreads(options model.GetPostsSince
 		return nil, errors.Wrapf(err, "getPostsSinceCollapsedThreads_ToSql")
 	}
 
-	err = s.GetReplica().Select(&posts, postFetchQuery, args...)
+	err = s.DBXFromContext(rctx.Context()).Select(&posts, postFetchQuery, args...)
 	if err != nil {
 		return nil, errors.Wrapf(err, "failed to find Posts with channelId=%s", options.ChannelId)
 	}
@@ -1384,9 +1402,9 @@ func (s *SqlPostStore) getPostsSinceCollapsedThreads(options model.GetPostsSince
 }
 
 //nolint:unparam
-func (s *SqlPostStore) GetPostsSince(options model.GetPostsSinceOptions, allowFromCache bool, sanitizeOptions map[string]bool) (*model.PostList, error) {
+func (s *SqlPostStore) GetPostsSince(rctx request.CTX, options model.GetPostsSinceOptions, allowFromCache bool, sanitizeOptions map[string]bool) (*model.PostList, error) {
 	if options.CollapsedThreads {
-		return s.getPostsSinceCollapsedThreads(options, sanitizeOptions)
+		return s.getPostsSinceCollapsedThreads(rctx, options, sanitizeOptions)
 	}
 
 	posts := []*model.Post{}
@@ -1449,9 +1467,24 @@ func (s *SqlPostStore) GetPostsSince(options model.GetPostsSinceOptions, allowFr
 		(SELECT *` + replyCountQuery1 + ` FROM Posts p1 WHERE id in (SELECT rootid FROM cte))
 		ORDER BY CreateAt ` + order
 
+		params = []any{options.Time, options.ChannelId}
+	} else if s.DriverName() == model.DatabaseDriverSqlite {
+		// SQLite doesn't allow parentheses around the members of a UNION.
+		query = `WITH cte AS (SELECT
+		       *
+		FROM
+		       Posts
+		WHERE
+		       UpdateAt > ? AND ChannelId = ?
+		       LIMIT 1000)
+		SELECT *` + replyCountQuery2 + ` FROM cte
+		UNION
+		SELECT *` + replyCountQuery1 + ` FROM Posts p1 WHERE id in (SELECT rootid FROM cte)
+		ORDER BY CreateAt ` + order
+
 		params = []any{options.Time, options.ChannelId}
 	}
-	err := s.GetReplica().Select(&posts, query, params...)
+	err := s.DBXFromContext(rctx.Context()).Select(&posts, query, params...)
 	if err != nil {
 		return nil, errors.Wrapf(err, "failed to find Posts with channelId=%s", options.ChannelId)
 	}
@@ -1808,7 +1841,7 @@ func (s *SqlPostStore) GetPostAfterTime(channelId string, time int64, collapsedT
 	return &post, nil
 }
 
-func (s *SqlPostStore) getRootPosts(channelId string, offset int, limit int, skipFetchThreads bool, includeDeleted bool) ([]*model.Post, error) {
+func (s *SqlPostStore) getRootPosts(db sqlxExecutor, channelId string, offset int, limit int, skipFetchThreads bool, includeDeleted bool) ([]*model.Post, error) {
 	posts := []*model.Post{}
 	var fetchQuery string
 	if skipFetchThreads {
@@ -1823,16 +1856,16 @@ func (s *SqlPostStore) getRootPosts(channelId string, offset int, limit int, ski
 		}
 	}
 
-	err := s.GetReplica().Select(&posts, fetchQuery, channelId, limit, offset)
+	err := db.Select(&posts, fetchQuery, channelId, limit, offset)
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to find Posts")
 	}
 	return posts, nil
 }
 
-func (s *SqlPostStore) getParentsPosts(channelId string, offset int, limit int, skipFetchThreads bool, includeDeleted bool) ([]*model.Post, error) {
+func (s *SqlPostStore) getParentsPosts(db sqlxExecutor, channelId string, offset int, limit int, skipFetchThreads bool, includeDeleted bool) ([]*model.Post, error) {
 	if s.DriverName() == model.DatabaseDriverPostgres {
-		return s.getParentsPostsPostgreSQL(channelId, offset, limit, skipFetchThreads, includeDeleted)
+		return s.getParentsPostsPostgreSQL(db, channelId, offset, limit, skipFetchThreads, includeDeleted)
 	}
 
 	deleteAtCondition := "AND DeleteAt = 0"
@@ -1856,7 +1889,7 @@ func (s *SqlPostStore) getParentsPosts(channelId string, offset int, limit int,
 			LIMIT ? OFFSET ?) q
 		WHERE q.RootId != ''`
 
-	err := s.GetReplica().Select(&roots, rootQuery, channelId, limit, offset)
+	err := db.Select(&roots, rootQuery, channelId, limit, offset)
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to find Posts")
 	}
@@ -1899,14 +1932,14 @@ func (s *SqlPostStore) getParentsPosts(channelId string, offset int, limit int,
 	}
 
 	posts := []*model.Post{}
-	err = s.GetReplica().Select(&posts, sql, args...)
+	err = db.Select(&posts, sql, args...)
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to find Posts")
 	}
 	return posts, nil
 }
 
-func (s *SqlPostStore) getParentsPostsPostgreSQL(channelId string, offset int, limit int, skipFetchThreads bool, includeDeleted bool) ([]*model.Post, error) {
+func (s *SqlPostStore) getParentsPostsPostgreSQL(db sqlxExecutor, channelId string, offset int, limit int, skipFetchThreads bool, includeDeleted bool) ([]*model.Post, error) {
 	posts := []*model.Post{}
 	replyCountQuery := ""
 	onStatement := "q1.RootId = q2.Id"
@@ -1925,7 +1958,7 @@ func (s *SqlPostStore) getParentsPostsPostgreSQL(channelId string, offset int, l
 		deleteAtQueryCondition, deleteAtSubQueryCondition = "", ""
 	}
 
-	err := s.GetReplica().Select(&posts,
+	err := db.Select(&posts,
 		`SELECT q2.*`+replyCountQuery+`
         FROM
             Posts q2
@@ -2203,6 +2236,14 @@ func (s *SqlPostStore) search(teamId string, userId string, params *model.Search
 
 		searchClause := fmt.Sprintf("to_tsvector('%[1]s', %[2]s) @@  to_tsquery('%[1]s', ?)", textSearchCfg, searchType)
 		baseQuery = baseQuery.Where(searchClause, tsQueryClause)
+	} else if s.DriverName() == model.DatabaseDriverSqlite {
+		column := strings.ToLower(searchType)
+		if query := sqliteFullTextQuery(terms, params.OrTerms); query != "" {
+			baseQuery = baseQuery.Where("q2.rowid IN (SELECT rowid FROM fts_posts WHERE fts_posts MATCH ?)", column+" : ("+query+")")
+		}
+		if query := sqliteFullTextQuery(excludedTerms, true); query != "" {
+			baseQuery = baseQuery.Where("q2.rowid NOT IN (SELECT rowid FROM fts_posts WHERE fts_posts MATCH ?)", column+" : ("+query+")")
+		}
 	} else if s.DriverName() == model.DatabaseDriverMysql {
 		if searchType == "Message" {
 			terms, err = removeMysqlStopWordsFromTerms(terms)
@@ -2625,6 +2666,39 @@ func (s *SqlPostStore) GetEditHistoryForPost(postId string) ([]*model.Post, erro
 	return posts, nil
 }
 
+// SaveEditHistory inserts previous versions of posts as they are, so each
+// one must already reference the edited post through OriginalId.
+func (s *SqlPostStore) SaveEditHistory(posts []*model.Post) error {
+	if len(posts) == 0 {
+		return nil
+	}
+
+	builder := s.getQueryBuilder().
+		Insert("Posts").
+		Columns(postSliceColumns()...)
+	for _, post := range posts {
+		if post.OriginalId == "" {
+			return store.NewErrInvalidInput("Post", "OriginalId", post.OriginalId)
+		}
+		if post.Id == "" {
+			post.Id = model.NewId()
+		}
+		post.PreCommit()
+		builder = builder.Values(postToSlice(post)...)
+	}
+
+	query, args, err := builder.ToSql()
+	if err != nil {
+		return errors.Wrap(err, "post_tosql")
+	}
+
+	if _, err := s.GetMaster().Exec(query, args...); err != nil {
+		return errors.Wrap(err, "failed to save post edit history")
+	}
+
+	return nil
+}
+
 func (s *SqlPostStore) GetPostsBatchForIndexing(startTime int64, startPostID string, limit int) ([]*model.PostForIndexing, error) {
 	posts := []*model.PostForIndexing{}
 
@@ -2696,7 +2770,18 @@ func (s *SqlPostStore) PermanentDeleteBatchForRetentionPolicies(retentionPolicyB
 		})
 	}
 
-	return genericPermanentDeleteBatchForRetentionPolicies(RetentionPolicyBatchDeletionInfo{
+	// Partitions expired under the global policy are dropped whole, unless
+	// pinned posts, which they may hold, are preserved.
+	var dropped int64
+	if !cursor.GlobalPoliciesDone && retentionPolicyBatchConfigs.GlobalPolicyEndTime > 0 && !retentionPolicyBatchConfigs.PreservePinnedPosts {
+		var err error
+		dropped, err = s.dropPartitionsBefore("Posts", retentionPolicyBatchConfigs.GlobalPolicyEndTime, true)
+		if err != nil {
+			return 0, cursor, errors.Wrap(err, "failed to drop Posts partitions")
+		}
+	}
+
+	deleted, cursor, err := genericPermanentDeleteBatchForRetentionPolicies(RetentionPolicyBatchDeletionInfo{
 		BaseBuilder:         builder,
 		Table:               "Posts",
 		TimeColumn:          "CreateAt",
@@ -2707,17 +2792,33 @@ func (s *SqlPostStore) PermanentDeleteBatchForRetentionPolicies(retentionPolicyB
 		Limit:               retentionPolicyBatchConfigs.Limit,
 		StoreDeletedIds:     true,
 	}, s.SqlStore, cursor)
+	if err != nil {
+		return 0, cursor, err
+	}
+
+	return dropped + deleted, cursor, nil
 }
 
 func (s *SqlPostStore) PermanentDeleteBatch(endTime int64, limit int64) (int64, error) {
+	// Whole partitions are dropped rather than deleting their rows one by one.
+	dropped, err := s.dropPartitionsBefore("Posts", endTime, false)
+	if err != nil {
+		return 0, errors.Wrap(err, "failed to drop Posts partitions")
+	}
+
 	var query string
+	args := []any{endTime, limit}
 	if s.DriverName() == model.DatabaseDriverPostgres {
-		query = "DELETE from Posts WHERE Id = any (array (SELECT Id FROM Posts WHERE CreateAt < ? LIMIT ?))"
+		// The CreateAt condition prunes the newer partitions.
+		query = "DELETE from Posts WHERE CreateAt < ? AND Id = any (array (SELECT Id FROM Posts WHERE CreateAt < ? LIMIT ?))"
+		args = []any{endTime, endTime, limit}
+	} else if s.DriverName() == model.DatabaseDriverSqlite {
+		query = "DELETE from Posts WHERE Id IN (SELECT Id FROM Posts WHERE CreateAt < ? LIMIT ?)"
 	} else {
 		query = "DELETE from Posts WHERE CreateAt < ? LIMIT ?"
 	}
 
-	sqlResult, err := s.GetMaster().Exec(query, endTime, limit)
+	sqlResult, err := s.GetMaster().Exec(query, args...)
 	if err != nil {
 		return 0, errors.Wrap(err, "failed to delete Posts")
 	}
@@ -2726,7 +2827,7 @@ func (s *SqlPostStore) PermanentDeleteBatch(endTime int64, limit int64) (int64,
 	if err != nil {
 		return 0, errors.Wrap(err, "failed to delete Posts")
 	}
-	return rowsAffected, nil
+	return dropped + rowsAffected, nil
 }
 
 func (s *SqlPostStore) GetOldest() (*model.Post, error) {
@@ -2776,6 +2877,11 @@ func (s *SqlPostStore) determineMaxPostSize() int {
 		`); err != nil {
 			mlog.Warn("Unable to determine the maximum supported post size", mlog.Err(err))
 		}
+	} else if s.DriverName() == model.DatabaseDriverSqlite {
+		var err error
+		if maxPostSizeBytes, err = s.sqliteColumnMaxLength("Posts", "Message"); err != nil {
+			mlog.Warn("Unable to determine the maximum supported post size", mlog.Err(err))
+		}
 	} else {
 		mlog.Error("No implementation found to determine the maximum supported post size")
 	}
@@ -2801,7 +2907,7 @@ func (s *SqlPostStore) GetMaxPostSize() int {
 	return s.maxPostSizeCached
 }
 
-func (s *SqlPostStore) GetParentsForExportAfter(limit int, afterId string, includeArchivedChannel bool) ([]*model.PostForExport, error) {
+func (s *SqlPostStore) GetParentsForExportAfter(limit int, afterId string, includeArchivedChannel bool, since int64) ([]*model.PostForExport, error) {
 	for {
 		rootIds := []string{}
 		err := s.GetReplica().Select(&rootIds,
@@ -2813,9 +2919,10 @@ func (s *SqlPostStore) GetParentsForExportAfter(limit int, afterId string, inclu
 				Posts.Id > ?
 				AND Posts.RootId = ''
 				AND Posts.DeleteAt = 0
+				AND Posts.UpdateAt >= ?
 			ORDER BY Posts.Id
 			LIMIT ?`,
-			afterId, limit)
+			afterId, since, limit)
 		if err != nil {
 			return nil, errors.Wrap(err, "failed to find Posts")
 		}
@@ -2835,6 +2942,8 @@ func (s *SqlPostStore) GetParentsForExportAfter(limit int, afterId string, inclu
 		aggFn := "COALESCE(json_agg(u1.username) FILTER (WHERE u1.username IS NOT NULL), '[]')"
 		if s.DriverName() == model.DatabaseDriverMysql {
 			aggFn = "IF (COUNT(u1.Username) = 0, JSON_ARRAY(), JSON_ARRAYAGG(u1.Username))"
+		} else if s.DriverName() == model.DatabaseDriverSqlite {
+			aggFn = "json_group_array(u1.username) FILTER (WHERE u1.username IS NOT NULL)"
 		}
 		result := []*model.PostForExport{}
 
@@ -2871,10 +2980,109 @@ func (s *SqlPostStore) GetParentsForExportAfter(limit int, afterId string, inclu
 	}
 }
 
+// GetDeletedForExportAfter returns the posts and replies deleted at or after
+// since, excluding the previous versions of edited posts. TeamName and
+// ChannelName are empty for posts in direct and group channels.
+func (s *SqlPostStore) GetDeletedForExportAfter(limit int, afterId string, since int64) ([]*model.PostForExport, error) {
+	result := []*model.PostForExport{}
+
+	query := s.getQueryBuilder().
+		Select(strings.Join(postSliceColumnsWithName("p"), ", "), "Users.Username as Username", "COALESCE(Teams.Name, '') as TeamName", "Channels.Name as ChannelName").
+		From("Posts p").
+		InnerJoin("Users ON p.UserId = Users.Id").
+		InnerJoin("Channels ON p.ChannelId = Channels.Id").
+		LeftJoin("Teams ON Channels.TeamId = Teams.Id").
+		Where(sq.And{
+			sq.Gt{"p.Id": afterId},
+			sq.GtOrEq{"p.DeleteAt": since},
+			sq.NotEq{"p.DeleteAt": 0},
+			sq.Eq{"p.OriginalId": ""},
+		}).
+		OrderBy("p.Id").
+		Limit(uint64(limit))
+
+	queryString, args, err := query.ToSql()
+	if err != nil {
+		return nil, errors.Wrap(err, "post_tosql")
+	}
+
+	if err := s.GetReplica().Select(&result, queryString, args...); err != nil {
+		return nil, errors.Wrap(err, "failed to find deleted Posts")
+	}
+
+	return result, nil
+}
+
+// GetForUserExportAfter returns the posts and replies of the given user that
+// were not deleted, ordered by id. The team name is empty for posts in direct
+// and group channels.
+func (s *SqlPostStore) GetForUserExportAfter(userId string, limit int, afterId string) ([]*model.PostForExport, error) {
+	result := []*model.PostForExport{}
+
+	query := s.getQueryBuilder().
+		Select(strings.Join(postSliceColumnsWithName("p"), ", "), "Users.Username as Username", "COALESCE(Teams.Name, '') as TeamName", "Channels.Name as ChannelName").
+		From("Posts p").
+		InnerJoin("Users ON p.UserId = Users.Id").
+		InnerJoin("Channels ON p.ChannelId = Channels.Id").
+		LeftJoin("Teams ON Channels.TeamId = Teams.Id").
+		Where(sq.And{
+			sq.Gt{"p.Id": afterId},
+			sq.Eq{"p.UserId": userId},
+			sq.Eq{"p.DeleteAt": 0},
+			sq.Eq{"p.OriginalId": ""},
+		}).
+		OrderBy("p.Id").
+		Limit(uint64(limit))
+
+	queryString, args, err := query.ToSql()
+	if err != nil {
+		return nil, errors.Wrap(err, "post_tosql")
+	}
+
+	if err := s.GetReplica().Select(&result, queryString, args...); err != nil {
+		return nil, errors.Wrapf(err, "failed to find Posts for userId=%s", userId)
+	}
+
+	return result, nil
+}
+
+// GetPostsMentioningUsernameAfter returns the posts whose message contains
+// an at-mention of the given username, ordered by id. Deleted posts and the
+// previous versions of edited posts are included. The match is
+// case-insensitive and may include false positives, like the mentions of
+// usernames the given one is a prefix of.
+func (s *SqlPostStore) GetPostsMentioningUsernameAfter(username string, limit int, afterId string) ([]*model.Post, error) {
+	posts := []*model.Post{}
+
+	likeTerm := "%@" + sanitizeSearchTerm(strings.ToLower(username), "*") + "%"
+	likeExpr := sq.Expr("Message LIKE ? ESCAPE '*'", likeTerm)
+	if s.DriverName() == model.DatabaseDriverPostgres {
+		likeExpr = sq.Expr("LOWER(Message) LIKE ? ESCAPE '*'", likeTerm)
+	}
+
+	query := s.getQueryBuilder().
+		Select(postSliceColumns()...).
+		From("Posts").
+		Where(sq.And{
+			sq.Gt{"Id": afterId},
+			likeExpr,
+		}).
+		OrderBy("Id").
+		Limit(uint64(limit))
+
+	if err := s.GetReplica().SelectBuilder(&posts, query); err != nil {
+		return nil, errors.Wrapf(err, "failed to find Posts mentioning username=%s", username)
+	}
+
+	return posts, nil
+}
+
 func (s *SqlPostStore) GetRepliesForExport(rootId string) ([]*model.ReplyForExport, error) {
 	aggFn := "COALESCE(json_agg(u1.username) FILTER (WHERE u1.username IS NOT NULL), '[]')"
 	if s.DriverName() == model.DatabaseDriverMysql {
 		aggFn = "IF (COUNT(u1.Username) = 0, JSON_ARRAY(), JSON_ARRAYAGG(u1.Username))"
+	} else if s.DriverName() == model.DatabaseDriverSqlite {
+		aggFn = "json_group_array(u1.username) FILTER (WHERE u1.username IS NOT NULL)"
 	}
 	result := []*model.ReplyForExport{}
 
@@ -2900,10 +3108,12 @@ func (s *SqlPostStore) GetRepliesForExport(rootId string) ([]*model.ReplyForExpo
 	return result, nil
 }
 
-func (s *SqlPostStore) GetDirectPostParentsForExportAfter(limit int, afterId string, includeArchivedChannels bool) ([]*model.DirectPostForExport, error) {
+func (s *SqlPostStore) GetDirectPostParentsForExportAfter(limit int, afterId string, includeArchivedChannels bool, since int64) ([]*model.DirectPostForExport, error) {
 	aggFn := "COALESCE(json_agg(u1.username) FILTER (WHERE u1.username IS NOT NULL), '[]')"
 	if s.DriverName() == model.DatabaseDriverMysql {
 		aggFn = "IF (COUNT(u1.Username) = 0, JSON_ARRAY(), JSON_ARRAYAGG(u1.Username))"
+	} else if s.DriverName() == model.DatabaseDriverSqlite {
+		aggFn = "json_group_array(u1.username) FILTER (WHERE u1.username IS NOT NULL)"
 	}
 	result := []*model.DirectPostForExport{}
 
@@ -2918,6 +3128,7 @@ func (s *SqlPostStore) GetDirectPostParentsForExportAfter(limit int, afterId str
 			sq.Gt{"p.Id": afterId},
 			sq.Eq{"p.RootId": ""},
 			sq.Eq{"p.DeleteAt": 0},
+			sq.GtOrEq{"p.UpdateAt": since},
 			sq.Eq{"Channels.Type": []model.ChannelType{model.ChannelTypeDirect, model.ChannelTypeGroup}},
 		}).
 		GroupBy("p.Id, u2.Username").
@@ -3107,7 +3318,7 @@ func (s *SqlPostStore) deleteThread(transaction *sqlxTxWrapper, postId string, d
 
 func (s *SqlPostStore) deleteThreadFiles(transaction *sqlxTxWrapper, postID string, deleteAtTime int64) error {
 	var query sq.UpdateBuilder
-	if s.DriverName() == model.DatabaseDriverPostgres {
+	if s.DriverName() == model.DatabaseDriverPostgres || s.DriverName() == model.DatabaseDriverSqlite {
 		query = s.getQueryBuilder().Update("FileInfo").
 			Set("DeleteAt", deleteAtTime).
 			From("Posts")
@@ -3160,6 +3371,10 @@ func (s *SqlPostStore) updateThreadAfterReplyDeletion(transaction *sqlxTxWrapper
 		if count == 0 {
 			if s.DriverName() == model.DatabaseDriverPostgres {
 				updateQuery = updateQuery.Set("Participants", sq.Expr("Participants - ?", userId))
+			} else if s.DriverName() == model.DatabaseDriverSqlite {
+				updateQuery = updateQuery.Set("Participants", sq.Expr(
+					`(SELECT json_group_array(value) FROM json_each(Participants) WHERE value != ?)`, userId,
+				))
 			} else {
 				updateQuery = updateQuery.
 					Set("Participants", sq.Expr(
@@ -77,7 +77,7 @@ func (s SqlPreferenceStore) save(transaction *sqlxTxWrapper, preference *model.P
 
 	if s.DriverName() == model.DatabaseDriverMysql {
 		query = query.SuffixExpr(sq.Expr("ON DUPLICATE KEY UPDATE Value = ?", preference.Value))
-	} else if s.DriverName() == model.DatabaseDriverPostgres {
+	} else if s.DriverName() == model.DatabaseDriverPostgres || s.DriverName() == model.DatabaseDriverSqlite {
 		query = query.SuffixExpr(sq.Expr("ON CONFLICT (userid, category, name) DO UPDATE SET Value = ?", preference.Value))
 	} else {
 		return store.NewErrNotImplemented("failed to update preference because of missing driver")
@@ -108,7 +108,7 @@ func (s SqlPreferenceStore) saveTx(transaction *sqlxTxWrapper, preference *model
 
 	if s.DriverName() == model.DatabaseDriverMysql {
 		query = query.SuffixExpr(sq.Expr("ON DUPLICATE KEY UPDATE Value = ?", preference.Value))
-	} else if s.DriverName() == model.DatabaseDriverPostgres {
+	} else if s.DriverName() == model.DatabaseDriverPostgres || s.DriverName() == model.DatabaseDriverSqlite {
 		query = query.SuffixExpr(sq.Expr("ON CONFLICT (userid, category, name) DO UPDATE SET Value = ?", preference.Value))
 	} else {
 		return store.NewErrNotImplemented("failed to update preference because of missing driver")
@@ -313,7 +313,7 @@ func (s SqlPreferenceStore) DeleteInvalidVisibleDmsGms() (int64, error) {
 			sq.Lt{"SUBSTRING(CONCAT('000000000000000', Value), LENGTH(Value) + 1, 15)": "000000000000001"},
 		},
 	}
-	if s.DriverName() == "postgres" {
+	if s.DriverName() == "postgres" || s.DriverName() == model.DatabaseDriverSqlite {
 		subQuery := s.getQueryBuilder().
 			Select("UserId, Category, Name").
 			From("Preferences").
@@ -146,6 +146,26 @@ func (s *SqlReactionStore) GetForPostSince(postId string, since int64, excludeRe
 	return reactions, nil
 }
 
+// GetForUser returns a page of the reactions of the user that were not deleted,
+// oldest first.
+func (s *SqlReactionStore) GetForUser(userId string, offset, limit int) ([]*model.Reaction, error) {
+	query := s.getQueryBuilder().
+		Select("UserId", "PostId", "EmojiName", "CreateAt", "COALESCE(UpdateAt, CreateAt) As UpdateAt",
+			"COALESCE(DeleteAt, 0) As DeleteAt", "RemoteId", "ChannelId").
+		From("Reactions").
+		Where(sq.Eq{"UserId": userId}).
+		Where(sq.Eq{"COALESCE(DeleteAt, 0)": 0}).
+		OrderBy("CreateAt", "PostId", "EmojiName").
+		Offset(uint64(offset)).
+		Limit(uint64(limit))
+
+	var reactions []*model.Reaction
+	if err := s.GetReplica().SelectBuilder(&reactions, query); err != nil {
+		return nil, errors.Wrapf(err, "failed to get Reactions with userId=%s", userId)
+	}
+	return reactions, nil
+}
+
 func (s *SqlReactionStore) GetUniqueCountForPost(postId string) (int, error) {
 	query := s.getQueryBuilder().
 		Select("COUNT(DISTINCT EmojiName)").
@@ -346,9 +366,17 @@ func (s *SqlReactionStore) DeleteOrphanedRowsByIds(r *model.RetentionIdsForDelet
 }
 
 func (s *SqlReactionStore) PermanentDeleteBatch(endTime int64, limit int64) (int64, error) {
+	// Whole partitions are dropped rather than deleting their rows one by one.
+	dropped, err := s.dropPartitionsBefore("Reactions", endTime, false)
+	if err != nil {
+		return 0, errors.Wrap(err, "failed to drop Reactions partitions")
+	}
+
 	var query string
 	if s.DriverName() == "postgres" {
 		query = "DELETE from Reactions WHERE CreateAt = any (array (SELECT CreateAt FROM Reactions WHERE CreateAt < ? LIMIT ?))"
+	} else if s.DriverName() == model.DatabaseDriverSqlite {
+		query = "DELETE from Reactions WHERE CreateAt IN (SELECT CreateAt FROM Reactions WHERE CreateAt < ? LIMIT ?)"
 	} else {
 		query = "DELETE from Reactions WHERE CreateAt < ? LIMIT ?"
 	}
@@ -362,7 +390,7 @@ func (s *SqlReactionStore) PermanentDeleteBatch(endTime int64, limit int64) (int
 	if err != nil {
 		return 0, errors.Wrap(err, "unable to get rows affected for deleted Reactions")
 	}
-	return rowsAffected, nil
+	return dropped + rowsAffected, nil
 }
 
 func (s *SqlReactionStore) saveReactionAndUpdatePost(transaction *sqlxTxWrapper, reaction *model.Reaction) error {
@@ -379,7 +407,38 @@ func (s *SqlReactionStore) saveReactionAndUpdatePost(transaction *sqlxTxWrapper,
 				UpdateAt = :UpdateAt, DeleteAt = :DeleteAt, RemoteId = :RemoteId, ChannelId = :ChannelId`, reaction); err != nil {
 			return err
 		}
-	} else if s.DriverName() == model.DatabaseDriverPostgres {
+	} else if s.isPartitioned("Reactions") {
+		// The primary key of the partitioned table includes CreateAt, so there
+		// is no conflict to upsert on. Concurrent saves of the same reaction
+		// are serialized instead, so that only one of them inserts it.
+		if _, err := transaction.Exec("SELECT pg_advisory_xact_lock(hashtext(? || ':' || ? || ':' || ?))", reaction.UserId, reaction.PostId, reaction.EmojiName); err != nil {
+			return err
+		}
+		result, err := transaction.NamedExec(
+			`UPDATE
+				Reactions
+			SET
+				UpdateAt = :UpdateAt, DeleteAt = :DeleteAt, RemoteId = :RemoteId, ChannelId = :ChannelId
+			WHERE
+				UserId = :UserId AND PostId = :PostId AND EmojiName = :EmojiName`, reaction)
+		if err != nil {
+			return err
+		}
+		updated, err := result.RowsAffected()
+		if err != nil {
+			return err
+		}
+		if updated == 0 {
+			if _, err := transaction.NamedExec(
+				`INSERT INTO
+					Reactions
+					(UserId, PostId, EmojiName, CreateAt, UpdateAt, DeleteAt, RemoteId, ChannelId)
+				VALUES
+					(:UserId, :PostId, :EmojiName, :CreateAt, :UpdateAt, :DeleteAt, :RemoteId, :ChannelId)`, reaction); err != nil {
+				return err
+			}
+		}
+	} else if s.DriverName() == model.DatabaseDriverPostgres || s.DriverName() == model.DatabaseDriverSqlite {
 		if _, err := transaction.NamedExec(
 			`INSERT INTO
 				Reactions
@@ -0,0 +1,169 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package sqlstore
+
+import (
+	"context"
+	"sync"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/public/shared/mlog"
+	"github.com/mattermost/mattermost/server/public/shared/request"
+)
+
+// recentWrite is the last write recorded for a read-your-writes key.
+type recentWrite struct {
+	// expireAt is the time in milliseconds after which the replicas are
+	// assumed to have caught up with the write.
+	expireAt int64
+	// lsn is the position of the write in the Postgres WAL, empty for the
+	// other databases and until RecordWritePosition is called.
+	lsn string
+}
+
+// writeTracker keeps the recent writes by read-your-writes key, until they
+// expire.
+//
+// The tracker is in the memory of each node, so only the reads served by the
+// node that made a write go to master. Behind a load balancer without sticky
+// sessions, the reads served by the other nodes may still be stale.
+type writeTracker struct {
+	mut    sync.Mutex
+	writes map[string]recentWrite
+}
+
+func (wt *writeTracker) record(key string, write recentWrite) {
+	wt.mut.Lock()
+	defer wt.mut.Unlock()
+
+	if wt.writes == nil {
+		wt.writes = make(map[string]recentWrite)
+	}
+	wt.writes[key] = write
+}
+
+// setPosition sets the WAL position of the write recorded for the key, unless
+// another write has been recorded since.
+func (wt *writeTracker) setPosition(key string, expireAt int64, lsn string) {
+	wt.mut.Lock()
+	defer wt.mut.Unlock()
+
+	if write, ok := wt.writes[key]; ok && write.expireAt == expireAt {
+		write.lsn = lsn
+		wt.writes[key] = write
+	}
+}
+
+func (wt *writeTracker) get(key string, now int64) (recentWrite, bool) {
+	wt.mut.Lock()
+	defer wt.mut.Unlock()
+
+	write, ok := wt.writes[key]
+	if !ok || write.expireAt <= now {
+		return recentWrite{}, false
+	}
+	return write, true
+}
+
+func (wt *writeTracker) removeExpired(now int64) {
+	wt.mut.Lock()
+	defer wt.mut.Unlock()
+
+	for key, write := range wt.writes {
+		if write.expireAt <= now {
+			delete(wt.writes, key)
+		}
+	}
+}
+
+func (ss *SqlStore) readYourWritesWindow() int64 {
+	if ss.settings.ReadYourWritesWindowMilliseconds == nil {
+		return 0
+	}
+	return int64(*ss.settings.ReadYourWritesWindowMilliseconds)
+}
+
+// RecordWrite records a write made for the read-your-writes key of the
+// context. The reads of the same key are then served by master for
+// SqlSettings.ReadYourWritesWindowMilliseconds, or on Postgres until the
+// replica has replayed the write once RecordWritePosition has been called,
+// whichever comes first.
+//
+// The store records the writes of its methods taking a request context. The
+// writes made through other methods must be recorded by the caller, as the
+// web handlers do for the successful non-GET requests.
+func (ss *SqlStore) RecordWrite(ctx context.Context) {
+	state := readYourWritesFromContext(ctx)
+	window := ss.readYourWritesWindow()
+	if state == nil || state.key == "" || window <= 0 || len(ss.ReplicaXs) == 0 {
+		return
+	}
+
+	state.wrote.Store(true)
+	ss.recentWrites.record(state.key, recentWrite{expireAt: model.GetMillis() + window})
+}
+
+// recordWrite records a write made with the request context, if any.
+func (ss *SqlStore) recordWrite(rctx request.CTX) {
+	if rctx == nil {
+		return
+	}
+	ss.RecordWrite(rctx.Context())
+}
+
+// RecordWritePosition records the position in the Postgres WAL of the writes
+// recorded with the context, so that the following reads of the same key go
+// back to the replica as soon as it has replayed them. It's meant to be called
+// once the request is done writing, and does nothing if it hasn't written.
+func (ss *SqlStore) RecordWritePosition(ctx context.Context) {
+	state := readYourWritesFromContext(ctx)
+	if state == nil || !state.wrote.Load() || ss.DriverName() != model.DatabaseDriverPostgres {
+		return
+	}
+
+	write, ok := ss.recentWrites.get(state.key, model.GetMillis())
+	if !ok {
+		return
+	}
+
+	var lsn string
+	if err := ss.GetMaster().Get(&lsn, "SELECT pg_current_wal_lsn()"); err != nil {
+		ss.Logger().Warn("Failed to get the WAL position of a write", mlog.Err(err))
+		return
+	}
+	ss.recentWrites.setPosition(state.key, write.expireAt, lsn)
+}
+
+// replicaForContext returns a replica to read from, unless the
+// read-your-writes key of the context has a recent write the replica may not
+// have yet, in which case it returns master.
+func (ss *SqlStore) replicaForContext(ctx context.Context) *sqlxDBWrapper {
+	replica := ss.GetReplica()
+	key := readYourWritesKey(ctx)
+	if key == "" || replica == ss.GetMaster() {
+		return replica
+	}
+
+	write, ok := ss.recentWrites.get(key, model.GetMillis())
+	if !ok {
+		return replica
+	}
+
+	if write.lsn != "" {
+		// The replay position is NULL when the database isn't a replica.
+		var caughtUp bool
+		err := replica.Get(&caughtUp, "SELECT COALESCE(pg_last_wal_replay_lsn() >= $1::pg_lsn, true)", write.lsn)
+		if err == nil && caughtUp {
+			return replica
+		}
+		if err != nil {
+			ss.Logger().Warn("Failed to get the WAL position of a replica", mlog.Err(err))
+		}
+	}
+
+	if ss.metrics != nil {
+		ss.metrics.IncrementReadYourWritesMasterFallback()
+	}
+	return ss.GetMaster()
+}
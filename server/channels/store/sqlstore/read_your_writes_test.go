@@ -0,0 +1,215 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package sqlstore
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/public/shared/mlog"
+	"github.com/mattermost/mattermost/server/public/shared/request"
+	"github.com/mattermost/mattermost/server/v8/channels/store/storetest"
+	"github.com/mattermost/mattermost/server/v8/einterfaces/mocks"
+)
+
+func newOnlineDBWrapper() *sqlxDBWrapper {
+	w := &sqlxDBWrapper{isOnline: &atomic.Bool{}}
+	w.isOnline.Store(true)
+	return w
+}
+
+func TestReadYourWrites(t *testing.T) {
+	newStore := func(window int) (*SqlStore, *mocks.MetricsInterface) {
+		metrics := &mocks.MetricsInterface{}
+		replica := &atomic.Pointer[sqlxDBWrapper]{}
+		replica.Store(newOnlineDBWrapper())
+
+		store := &SqlStore{
+			masterX:   newOnlineDBWrapper(),
+			ReplicaXs: []*atomic.Pointer[sqlxDBWrapper]{replica},
+			settings: &model.SqlSettings{
+				DriverName:                       model.NewPointer(model.DatabaseDriverMysql),
+				DataSourceReplicas:               []string{"replica"},
+				ReadYourWritesWindowMilliseconds: model.NewPointer(window),
+			},
+			metrics: metrics,
+			logger:  mlog.CreateConsoleTestLogger(t),
+		}
+		store.UpdateLicense(&model.License{})
+		return store, metrics
+	}
+
+	rctx := request.TestContext(t)
+
+	t.Run("reads go to the replica without a write", func(t *testing.T) {
+		store, _ := newStore(5000)
+		c := RequestContextWithReadYourWrites(rctx, "user1")
+
+		assert.NotSame(t, store.GetMaster(), store.DBXFromContext(c.Context()))
+	})
+
+	t.Run("reads go to master after a write of the same key", func(t *testing.T) {
+		store, metrics := newStore(5000)
+		metrics.On("IncrementReadYourWritesMasterFallback").Once()
+
+		store.RecordWrite(RequestContextWithReadYourWrites(rctx, "user1").Context())
+
+		assert.Same(t, store.GetMaster(), store.DBXFromContext(RequestContextWithReadYourWrites(rctx, "user1").Context()))
+		assert.NotSame(t, store.GetMaster(), store.DBXFromContext(RequestContextWithReadYourWrites(rctx, "user2").Context()))
+		assert.NotSame(t, store.GetMaster(), store.DBXFromContext(rctx.Context()))
+		metrics.AssertExpectations(t)
+	})
+
+	t.Run("reads go back to the replica once the window has passed", func(t *testing.T) {
+		store, _ := newStore(1)
+		c := RequestContextWithReadYourWrites(rctx, "user1")
+
+		store.RecordWrite(c.Context())
+		time.Sleep(2 * time.Millisecond)
+
+		assert.NotSame(t, store.GetMaster(), store.DBXFromContext(c.Context()))
+
+		store.recentWrites.removeExpired(model.GetMillis())
+		assert.Empty(t, store.recentWrites.writes)
+	})
+
+	t.Run("writes are not recorded when the window is disabled", func(t *testing.T) {
+		store, _ := newStore(0)
+		c := RequestContextWithReadYourWrites(rctx, "user1")
+
+		store.RecordWrite(c.Context())
+
+		assert.NotSame(t, store.GetMaster(), store.DBXFromContext(c.Context()))
+		assert.Empty(t, store.recentWrites.writes)
+	})
+
+	t.Run("writes without a key are not recorded", func(t *testing.T) {
+		store, _ := newStore(5000)
+
+		store.RecordWrite(rctx.Context())
+
+		assert.Empty(t, store.recentWrites.writes)
+	})
+
+	t.Run("the write position is only recorded after a write", func(t *testing.T) {
+		store, _ := newStore(5000)
+		// Getting the WAL position would fail without a database.
+		store.settings.DriverName = model.NewPointer(model.DatabaseDriverPostgres)
+
+		store.RecordWritePosition(RequestContextWithReadYourWrites(rctx, "user1").Context())
+		store.RecordWritePosition(rctx.Context())
+
+		assert.Empty(t, store.recentWrites.writes)
+	})
+
+	t.Run("the write position isn't set over a newer write", func(t *testing.T) {
+		var wt writeTracker
+		wt.record("user1", recentWrite{expireAt: 10})
+		wt.record("user1", recentWrite{expireAt: 20})
+
+		wt.setPosition("user1", 10, "0/1")
+		write, ok := wt.get("user1", 0)
+		require.True(t, ok)
+		assert.Empty(t, write.lsn)
+
+		wt.setPosition("user1", 20, "0/2")
+		write, ok = wt.get("user1", 0)
+		require.True(t, ok)
+		assert.Equal(t, "0/2", write.lsn)
+	})
+}
+
+func TestReadYourWritesReplicaPosition(t *testing.T) {
+	logger := mlog.CreateConsoleTestLogger(t)
+
+	settings, err := makeSqlSettings(model.DatabaseDriverPostgres)
+	if err != nil {
+		t.Skip(err)
+	}
+	settings.DataSourceReplicas = []string{*settings.DataSource}
+	settings.ReadYourWritesWindowMilliseconds = model.NewPointer(60000)
+
+	store, err := New(*settings, logger, nil)
+	require.NoError(t, err)
+	defer func() {
+		store.Close()
+		storetest.CleanupSqlSettings(settings)
+	}()
+	store.UpdateLicense(&model.License{})
+
+	ctx := RequestContextWithReadYourWrites(request.TestContext(t), "user1").Context()
+	store.RecordWrite(ctx)
+
+	// The position of the write isn't known until the request is done writing.
+	assert.Same(t, store.GetMaster(), store.DBXFromContext(ctx))
+
+	store.RecordWritePosition(ctx)
+
+	write, ok := store.recentWrites.get("user1", model.GetMillis())
+	require.True(t, ok)
+	assert.NotEmpty(t, write.lsn)
+
+	// The replica is the master database, which has the write already.
+	assert.NotSame(t, store.GetMaster(), store.DBXFromContext(ctx))
+	assert.Same(t, store.GetMaster(), store.DBXFromContext(WithMaster(context.Background())))
+}
+
+func TestReadYourWritesStoreWrites(t *testing.T) {
+	for _, driver := range []string{model.DatabaseDriverPostgres, model.DatabaseDriverMysql, model.DatabaseDriverSqlite} {
+		t.Run(driver, func(t *testing.T) {
+			logger := mlog.CreateConsoleTestLogger(t)
+
+			settings, err := makeSqlSettings(driver)
+			if err != nil {
+				t.Skip(err)
+			}
+			settings.DataSourceReplicas = []string{*settings.DataSource}
+			settings.ReadYourWritesWindowMilliseconds = model.NewPointer(60000)
+
+			store, err := New(*settings, logger, nil)
+			require.NoError(t, err)
+			defer func() {
+				store.Close()
+				storetest.CleanupSqlSettings(settings)
+			}()
+			store.UpdateLicense(&model.License{})
+
+			t.Run("channel", func(t *testing.T) {
+				rctx := RequestContextWithReadYourWrites(request.TestContext(t), model.NewId())
+				require.NotSame(t, store.GetMaster(), store.DBXFromContext(rctx.Context()))
+
+				_, err := store.Channel().Save(rctx, &model.Channel{
+					TeamId:      model.NewId(),
+					DisplayName: "Channel",
+					Name:        "channel-" + model.NewId(),
+					Type:        model.ChannelTypeOpen,
+				}, -1)
+				require.NoError(t, err)
+
+				assert.Same(t, store.GetMaster(), store.DBXFromContext(rctx.Context()))
+			})
+
+			t.Run("user", func(t *testing.T) {
+				rctx := RequestContextWithReadYourWrites(request.TestContext(t), model.NewId())
+				require.NotSame(t, store.GetMaster(), store.DBXFromContext(rctx.Context()))
+
+				user, err := store.User().Save(rctx, &model.User{
+					Email:    storetest.MakeEmail(),
+					Username: "user" + model.NewId(),
+				})
+				require.NoError(t, err)
+
+				assert.Same(t, store.GetMaster(), store.DBXFromContext(rctx.Context()))
+				_, err = store.User().Get(rctx.Context(), user.Id)
+				require.NoError(t, err)
+			})
+		})
+	}
+}
@@ -14,6 +14,7 @@ import (
 	"github.com/lib/pq"
 	sq "github.com/mattermost/squirrel"
 	"github.com/pkg/errors"
+	"modernc.org/sqlite"
 
 	"github.com/mattermost/mattermost/server/public/model"
 	"github.com/mattermost/mattermost/server/v8/channels/store"
@@ -374,8 +375,8 @@ func (s *SqlRetentionPolicyStore) buildGetPoliciesQuery(id string, offset, limit
 		return "", nil, errors.Wrap(err, "retention_policies_tosql")
 	}
 
-	// MySQL does not support positional params, so we add one param for each WHERE clause.
-	if s.DriverName() == model.DatabaseDriverMysql {
+	// MySQL and SQLite don't support positional params, so we add one param for each WHERE clause.
+	if s.DriverName() == model.DatabaseDriverMysql || s.DriverName() == model.DatabaseDriverSqlite {
 		args = append(args, args...)
 	}
 
@@ -520,6 +521,10 @@ func (s *SqlRetentionPolicyStore) AddChannels(policyId string, channelIds []stri
 			if dbErr.Number == MySQLForeignKeyViolationErrorCode {
 				return store.NewErrNotFound("RetentionPolicy", policyId)
 			}
+		case *sqlite.Error:
+			if dbErr.Code() == SqliteForeignKeyViolationErrorCode {
+				return store.NewErrNotFound("RetentionPolicy", policyId)
+			}
 		}
 	}
 
@@ -1063,7 +1068,7 @@ func genericRetentionPoliciesDeletion(
 		}
 		defer finalizeTransactionX(txn, &err)
 
-		if s.DriverName() == model.DatabaseDriverPostgres {
+		if s.DriverName() == model.DatabaseDriverPostgres || s.DriverName() == model.DatabaseDriverSqlite {
 			primaryKeysStr := "(" + strings.Join(r.PrimaryKeys, ",") + ")"
 
 			query = fmt.Sprintf("DELETE FROM %s WHERE %s IN (%s) RETURNING %s.%s", r.Table, primaryKeysStr, query, r.Table, r.PrimaryKeys[0])
@@ -1133,7 +1138,7 @@ func genericRetentionPoliciesDeletion(
 			return 0, err
 		}
 	} else {
-		if s.DriverName() == model.DatabaseDriverPostgres {
+		if s.DriverName() == model.DatabaseDriverPostgres || s.DriverName() == model.DatabaseDriverSqlite {
 			primaryKeysStr := "(" + strings.Join(r.PrimaryKeys, ",") + ")"
 			query = fmt.Sprintf("DELETE FROM %s WHERE %s IN (%s)", r.Table, primaryKeysStr, query)
 		} else {
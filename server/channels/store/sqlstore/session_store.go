@@ -209,6 +209,8 @@ func (me SqlSessionStore) GetMobileSessionMetadata() ([]*model.MobileSessionMeta
 		versionProp = "$." + versionProp
 		notificationDisabledProp = "$." + notificationDisabledProp
 		platformQuery = "NULLIF(SUBSTRING_INDEX(deviceid, ':', 1), deviceid)"
+	} else if me.DriverName() == model.DatabaseDriverSqlite {
+		platformQuery = "NULLIF(SUBSTR(deviceid, 1, INSTR(deviceid, ':') - 1), '')"
 	}
 
 	query, args, err := me.getQueryBuilder().
@@ -379,7 +381,7 @@ func (me SqlSessionStore) AnalyticsSessionCount() (int64, error) {
 
 func (me SqlSessionStore) Cleanup(expiryTime int64, batchSize int64) error {
 	var query string
-	if me.DriverName() == model.DatabaseDriverPostgres {
+	if me.DriverName() == model.DatabaseDriverPostgres || me.DriverName() == model.DatabaseDriverSqlite {
 		query = "DELETE FROM Sessions WHERE Id IN (SELECT Id FROM Sessions WHERE ExpiresAt != 0 AND ? > ExpiresAt LIMIT ?)"
 	} else {
 		query = "DELETE FROM Sessions WHERE ExpiresAt != 0 AND ? > ExpiresAt LIMIT ?"
@@ -63,7 +63,7 @@ func (s SqlSharedChannelStore) Save(sc *model.SharedChannel) (sh *model.SharedCh
 
 	// set `Shared` flag in Channels table if needed
 	if channel.Shared == nil || !*channel.Shared {
-		if err := s.stores.channel.SetShared(channel.Id, true); err != nil {
+		if err := s.stores.channel.(*SqlChannelStore).setSharedT(transaction, channel.Id, true); err != nil {
 			return nil, err
 		}
 	}
@@ -317,7 +317,7 @@ func (s SqlSharedChannelStore) Delete(channelId string) (ok bool, err error) {
 
 	if count > 0 {
 		// unset the channel's Shared flag
-		if err = s.Channel().SetShared(channelId, false); err != nil {
+		if err = s.stores.channel.(*SqlChannelStore).setSharedT(transaction, channelId, false); err != nil {
 			return false, errors.Wrap(err, "error unsetting channel share flag")
 		}
 	}
@@ -922,7 +922,7 @@ func (s SqlSharedChannelStore) UpsertAttachment(attachment *model.SharedChannelA
 
 	if s.DriverName() == model.DatabaseDriverMysql {
 		query = query.SuffixExpr(sq.Expr("ON DUPLICATE KEY UPDATE LastSyncAt = ?", attachment.LastSyncAt))
-	} else if s.DriverName() == model.DatabaseDriverPostgres {
+	} else if s.DriverName() == model.DatabaseDriverPostgres || s.DriverName() == model.DatabaseDriverSqlite {
 		query = query.SuffixExpr(sq.Expr("ON CONFLICT (id) DO UPDATE SET LastSyncAt = ?", attachment.LastSyncAt))
 	}
 
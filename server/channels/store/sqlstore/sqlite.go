@@ -0,0 +1,456 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package sqlstore
+
+import (
+	"cmp"
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"io"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/jmoiron/sqlx/reflectx"
+	"github.com/pkg/errors"
+	"modernc.org/sqlite"
+	sqlite3 "modernc.org/sqlite/lib"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/public/shared/mlog"
+	sqlUtils "github.com/mattermost/mattermost/server/public/utils/sql"
+)
+
+// sqliteDefaultPragmas are applied to every SQLite connection unless the data
+// source already sets them. WAL lets readers run concurrently with the single
+// writer, and the busy timeout makes writers wait for each other instead of
+// failing right away.
+var sqliteDefaultPragmas = map[string]string{
+	"busy_timeout": "busy_timeout(10000)",
+	"journal_mode": "journal_mode(WAL)",
+	"synchronous":  "synchronous(NORMAL)",
+	"foreign_keys": "foreign_keys(ON)",
+}
+
+var (
+	sqliteVarcharRegexp      = regexp.MustCompile(`(?i)^varchar\((\d+)\)$`)
+	sqliteFullTextTermRegexp = regexp.MustCompile(`"[^"]*"\*?|\S+`)
+)
+
+// sqliteMapper matches the struct fields to the lower case column names
+// returned by the SQLite connections, including the fields whose tag keeps
+// the case of a quoted Postgres alias.
+var sqliteMapper = reflectx.NewMapperTagFunc("db", strings.ToLower, strings.ToLower)
+
+var (
+	sqliteDriverOnce sync.Once
+	sqliteDriver     driver.Driver
+)
+
+func init() {
+	sqlx.BindDriver(model.DatabaseDriverSqlite, sqlx.QUESTION)
+
+	// GREATEST and LEAST exist in both Postgres and MySQL, the queries
+	// using them are shared with SQLite. NULL arguments are ignored, like
+	// Postgres does.
+	sqlite.MustRegisterDeterministicScalarFunction("greatest", -1, func(_ *sqlite.FunctionContext, args []driver.Value) (driver.Value, error) {
+		return sqliteExtremum(args, 1)
+	})
+	sqlite.MustRegisterDeterministicScalarFunction("least", -1, func(_ *sqlite.FunctionContext, args []driver.Value) (driver.Value, error) {
+		return sqliteExtremum(args, -1)
+	})
+
+	// FROM_UNIXTIME is used by the MySQL analytics queries, which SQLite
+	// shares. The dates are in UTC.
+	sqlite.MustRegisterDeterministicScalarFunction("from_unixtime", 1, func(_ *sqlite.FunctionContext, args []driver.Value) (driver.Value, error) {
+		switch seconds := args[0].(type) {
+		case int64:
+			return time.Unix(seconds, 0).UTC().Format(time.DateTime), nil
+		case float64:
+			return time.UnixMilli(int64(seconds * 1000)).UTC().Format(time.DateTime), nil
+		case nil:
+			return nil, nil
+		}
+		return nil, errors.Errorf("can't convert %T to a date", args[0])
+	})
+}
+
+// likeEscape returns the ESCAPE clause of the LIKE expressions whose pattern
+// is escaped with a backslash. The backslash is the default escape character
+// of Postgres and MySQL, while SQLite has none.
+func likeEscape(driverName string) string {
+	if driverName == model.DatabaseDriverSqlite {
+		return ` ESCAPE '\'`
+	}
+	return ""
+}
+
+// sqliteExtremum returns the argument comparing the furthest in the given
+// direction to the others.
+func sqliteExtremum(args []driver.Value, direction int) (driver.Value, error) {
+	var result driver.Value
+	for _, arg := range args {
+		if arg == nil {
+			continue
+		}
+		if result == nil {
+			result = arg
+			continue
+		}
+		cmp, err := sqliteCompare(arg, result)
+		if err != nil {
+			return nil, err
+		}
+		if cmp*direction > 0 {
+			result = arg
+		}
+	}
+	return result, nil
+}
+
+func sqliteCompare(a, b driver.Value) (int, error) {
+	toFloat := func(v driver.Value) (float64, bool) {
+		switch n := v.(type) {
+		case int64:
+			return float64(n), true
+		case float64:
+			return n, true
+		}
+		return 0, false
+	}
+
+	if x, ok := a.(int64); ok {
+		if y, ok := b.(int64); ok {
+			return cmp.Compare(x, y), nil
+		}
+	}
+	if x, ok := toFloat(a); ok {
+		if y, ok := toFloat(b); ok {
+			return cmp.Compare(x, y), nil
+		}
+	}
+
+	x, xOk := a.(string)
+	y, yOk := b.(string)
+	if !xOk || !yOk {
+		return 0, errors.Errorf("can't compare %T and %T", a, b)
+	}
+	return strings.Compare(x, y), nil
+}
+
+// sqliteDataSource returns the data source with the default pragmas and the
+// immediate transaction locking appended. Transactions take the write lock
+// when they begin: SQLite can't upgrade a read transaction to a write
+// transaction once another connection wrote to the database.
+func sqliteDataSource(dataSource string) (string, error) {
+	name, rawQuery, _ := strings.Cut(dataSource, "?")
+	params, err := url.ParseQuery(rawQuery)
+	if err != nil {
+		return "", err
+	}
+
+	for pragma, value := range sqliteDefaultPragmas {
+		found := false
+		for _, existing := range params["_pragma"] {
+			if strings.HasPrefix(strings.ToLower(strings.TrimSpace(existing)), pragma) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			params.Add("_pragma", value)
+		}
+	}
+	if params.Get("_txlock") == "" {
+		params.Set("_txlock", "immediate")
+	}
+
+	return name + "?" + params.Encode(), nil
+}
+
+// setupSqliteConnection is the SQLite counterpart of sqlUtils.SetupConnection.
+// The connections it opens return lower case column names, the way Postgres
+// folds unquoted identifiers, so that the same struct mappings apply to both
+// databases.
+func setupSqliteConnection(logger mlog.LoggerIFace, connType string, dataSource string, settings *model.SqlSettings) (*sql.DB, error) {
+	dsn, err := sqliteDataSource(dataSource)
+	if err != nil {
+		return nil, err
+	}
+
+	sqliteDriverOnce.Do(func() {
+		// The driver registers itself on import, we only need a handle
+		// on it to open the connections ourselves.
+		var db *sql.DB
+		db, err = sql.Open(model.DatabaseDriverSqlite, "")
+		if err != nil {
+			return
+		}
+		sqliteDriver = db.Driver()
+		db.Close()
+	})
+	if sqliteDriver == nil {
+		return nil, errors.New("failed to load the sqlite driver")
+	}
+
+	db := sql.OpenDB(&sqliteConnector{dsn: dsn})
+
+	logger = logger.With(
+		mlog.String("database", connType),
+		mlog.String("dataSource", dataSource),
+	)
+
+	ctx, cancel := context.WithTimeout(context.Background(), sqlUtils.DBPingTimeout)
+	defer cancel()
+	if err = db.PingContext(ctx); err != nil {
+		logger.Error("Failed to ping DB", mlog.Err(err))
+		db.Close()
+		return nil, err
+	}
+
+	db.SetMaxIdleConns(*settings.MaxIdleConns)
+	db.SetMaxOpenConns(*settings.MaxOpenConns)
+	db.SetConnMaxLifetime(time.Duration(*settings.ConnMaxLifetimeMilliseconds) * time.Millisecond)
+	db.SetConnMaxIdleTime(time.Duration(*settings.ConnMaxIdleTimeMilliseconds) * time.Millisecond)
+
+	return db, nil
+}
+
+// sqliteColumnMaxLength returns the length declared for a VARCHAR column,
+// which the schema enforces with a CHECK constraint.
+func (ss *SqlStore) sqliteColumnMaxLength(tableName, columnName string) (int32, error) {
+	var columnType string
+	if err := ss.GetReplica().Get(&columnType, `SELECT type FROM pragma_table_info(?) WHERE name = ?`, strings.ToLower(tableName), strings.ToLower(columnName)); err != nil {
+		return 0, err
+	}
+
+	matches := sqliteVarcharRegexp.FindStringSubmatch(columnType)
+	if matches == nil {
+		return 0, nil
+	}
+	length, err := strconv.ParseInt(matches[1], 10, 32)
+	if err != nil {
+		return 0, err
+	}
+	return int32(length), nil
+}
+
+// sqliteFullTextQuery returns the FTS5 query matching the search terms, all
+// of them or any of them. Quoted terms are searched as phrases, and the terms
+// ending with a star as prefixes. The terms are quoted for their punctuation
+// not to be taken for FTS5 operators.
+func sqliteFullTextQuery(terms string, anyTerm bool) string {
+	var phrases []string
+	for _, term := range sqliteFullTextTermRegexp.FindAllString(terms, -1) {
+		prefix := strings.HasSuffix(term, "*")
+		term = strings.Trim(term, `"*`)
+		if !strings.ContainsFunc(term, func(r rune) bool { return unicode.IsLetter(r) || unicode.IsDigit(r) }) {
+			continue
+		}
+
+		phrase := `"` + strings.ReplaceAll(term, `"`, `""`) + `"`
+		if prefix {
+			phrase += "*"
+		}
+		phrases = append(phrases, phrase)
+	}
+
+	if anyTerm {
+		return strings.Join(phrases, " OR ")
+	}
+	return strings.Join(phrases, " AND ")
+}
+
+// isSqliteConstraintError returns whether the error is a SQLite unique
+// constraint violation, and whether the violated constraint is the primary
+// key of the table.
+func isSqliteConstraintError(err error) (bool, bool) {
+	var sqliteErr *sqlite.Error
+	if !errors.As(err, &sqliteErr) {
+		return false, false
+	}
+
+	switch sqliteErr.Code() {
+	case sqlite3.SQLITE_CONSTRAINT_UNIQUE:
+		return true, false
+	case sqlite3.SQLITE_CONSTRAINT_PRIMARYKEY:
+		return true, true
+	}
+	return false, false
+}
+
+type sqliteConnector struct {
+	dsn string
+}
+
+func (c *sqliteConnector) Connect(context.Context) (driver.Conn, error) {
+	conn, err := sqliteDriver.Open(c.dsn)
+	if err != nil {
+		return nil, err
+	}
+
+	sc, ok := conn.(sqliteDriverConn)
+	if !ok {
+		conn.Close()
+		return nil, errors.New("unexpected sqlite connection type")
+	}
+	return &sqliteConn{sc}, nil
+}
+
+func (c *sqliteConnector) Driver() driver.Driver {
+	return sqliteDriver
+}
+
+// sqliteDriverConn lists the interfaces implemented by the connections of the
+// SQLite driver, for sqliteConn to expose them all.
+type sqliteDriverConn interface {
+	driver.Conn
+	driver.ConnBeginTx
+	driver.ConnPrepareContext
+	driver.ExecerContext
+	driver.QueryerContext
+	driver.Pinger
+	driver.SessionResetter
+	driver.Validator
+}
+
+// sqliteConn lower cases the column names of the rows returned by the
+// SQLite connection.
+type sqliteConn struct {
+	sqliteDriverConn
+}
+
+// CheckNamedValue binds the byte slices holding valid UTF-8, JSON documents
+// most of the time, as text for the SQLite JSON functions to accept them.
+// They would be taken for binary JSON otherwise.
+func (c *sqliteConn) CheckNamedValue(nv *driver.NamedValue) error {
+	value, err := driver.DefaultParameterConverter.ConvertValue(nv.Value)
+	if err != nil {
+		return err
+	}
+	if b, ok := value.([]byte); ok && utf8.Valid(b) {
+		value = string(b)
+	}
+	nv.Value = value
+	return nil
+}
+
+func (c *sqliteConn) Prepare(query string) (driver.Stmt, error) {
+	return c.PrepareContext(context.Background(), query)
+}
+
+func (c *sqliteConn) PrepareContext(ctx context.Context, query string) (driver.Stmt, error) {
+	stmt, err := c.sqliteDriverConn.PrepareContext(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+
+	ss, ok := stmt.(sqliteDriverStmt)
+	if !ok {
+		stmt.Close()
+		return nil, errors.New("unexpected sqlite statement type")
+	}
+	return &sqliteStmt{ss}, nil
+}
+
+func (c *sqliteConn) QueryContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Rows, error) {
+	rows, err := c.sqliteDriverConn.QueryContext(ctx, query, args)
+	if err != nil {
+		return nil, err
+	}
+	return &sqliteRows{Rows: rows}, nil
+}
+
+type sqliteDriverStmt interface {
+	driver.Stmt
+	driver.StmtExecContext
+	driver.StmtQueryContext
+}
+
+type sqliteStmt struct {
+	sqliteDriverStmt
+}
+
+func (s *sqliteStmt) QueryContext(ctx context.Context, args []driver.NamedValue) (driver.Rows, error) {
+	rows, err := s.sqliteDriverStmt.QueryContext(ctx, args)
+	if err != nil {
+		return nil, err
+	}
+	return &sqliteRows{Rows: rows}, nil
+}
+
+type sqliteRows struct {
+	driver.Rows
+	columns []string
+}
+
+func (r *sqliteRows) Columns() []string {
+	if r.columns == nil {
+		columns := r.Rows.Columns()
+		r.columns = make([]string, len(columns))
+		for i, column := range columns {
+			r.columns[i] = strings.ToLower(column)
+		}
+	}
+	return r.columns
+}
+
+// Next returns the text values as bytes, the way the Postgres driver returns
+// the JSON columns, for them to be scanned into json.RawMessage fields.
+func (r *sqliteRows) Next(dest []driver.Value) error {
+	if err := r.Rows.Next(dest); err != nil {
+		return err
+	}
+	for i, value := range dest {
+		if text, ok := value.(string); ok {
+			dest[i] = []byte(text)
+		}
+	}
+	return nil
+}
+
+// HasNextResultSet and NextResultSet implement driver.RowsNextResultSet, as
+// expected by the plugin database driver. SQLite queries return a single result set.
+func (r *sqliteRows) HasNextResultSet() bool {
+	return false
+}
+
+func (r *sqliteRows) NextResultSet() error {
+	return io.EOF
+}
+
+func (r *sqliteRows) ColumnTypeDatabaseTypeName(index int) string {
+	if rows, ok := r.Rows.(driver.RowsColumnTypeDatabaseTypeName); ok {
+		return rows.ColumnTypeDatabaseTypeName(index)
+	}
+	return ""
+}
+
+func (r *sqliteRows) ColumnTypePrecisionScale(index int) (int64, int64, bool) {
+	if rows, ok := r.Rows.(driver.RowsColumnTypePrecisionScale); ok {
+		return rows.ColumnTypePrecisionScale(index)
+	}
+	return 0, 0, false
+}
+
+func (r *sqliteRows) ColumnTypeNullable(index int) (bool, bool) {
+	if rows, ok := r.Rows.(driver.RowsColumnTypeNullable); ok {
+		return rows.ColumnTypeNullable(index)
+	}
+	return false, false
+}
+
+// isSqliteBusyError reports whether the statement failed because another
+// connection held a conflicting lock, in which case it can be retried.
+func isSqliteBusyError(err error) bool {
+	var sqliteErr *sqlite.Error
+	return errors.As(err, &sqliteErr) && sqliteErr.Code()&0xff == sqlite3.SQLITE_BUSY
+}
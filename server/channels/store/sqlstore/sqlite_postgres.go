@@ -0,0 +1,221 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package sqlstore
+
+import (
+	"context"
+	"database/sql"
+	"strings"
+
+	"github.com/jmoiron/sqlx"
+	sq "github.com/mattermost/squirrel"
+	"github.com/pkg/errors"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/public/shared/mlog"
+)
+
+// postgresMaxParams is the number of bind parameters Postgres accepts in a
+// single statement.
+const postgresMaxParams = 65535
+
+// TableCopy is the number of rows copied for a table by CopySqliteToPostgres.
+type TableCopy struct {
+	Table string `json:"table"`
+	Rows  int64  `json:"rows"`
+}
+
+type postgresColumn struct {
+	Name string `db:"column_name"`
+	Type string `db:"data_type"`
+}
+
+// CopySqliteToPostgres copies every table of a SQLite database into a
+// Postgres database. Both stores must have been migrated to the same schema
+// version, which New takes care of. The destination is written in a single
+// transaction, so a failed copy leaves it untouched, and it must not have any
+// users yet. Rows conflicting with the ones created by the migrations, like
+// the system values, are skipped.
+func CopySqliteToPostgres(ctx context.Context, src, dst *SqlStore, batchSize int) ([]TableCopy, error) {
+	if src.DriverName() != model.DatabaseDriverSqlite {
+		return nil, errors.Errorf("the source database must be SQLite, not %s", src.DriverName())
+	}
+	if dst.DriverName() != model.DatabaseDriverPostgres {
+		return nil, errors.Errorf("the destination database must be Postgres, not %s", dst.DriverName())
+	}
+	if batchSize <= 0 {
+		return nil, errors.New("the batch size must be positive")
+	}
+
+	var users int64
+	if err := dst.GetMaster().Get(&users, "SELECT COUNT(*) FROM Users"); err != nil {
+		return nil, errors.Wrap(err, "failed to count the users of the destination database")
+	}
+	if users > 0 {
+		return nil, errors.New("the destination database already has users")
+	}
+
+	// The full text search tables are filled by the triggers of the tables
+	// they index, and the migrations table is kept by each database.
+	var tables []string
+	if err := src.GetMaster().Select(&tables, `SELECT name FROM sqlite_master
+		WHERE type = 'table' AND name NOT LIKE 'sqlite%' AND name NOT LIKE 'fts%' AND name != 'db_migrations'
+		ORDER BY name`); err != nil {
+		return nil, errors.Wrap(err, "failed to list the tables of the source database")
+	}
+
+	tx, err := dst.GetMaster().DB.BeginTxx(ctx, nil)
+	if err != nil {
+		return nil, errors.Wrap(err, "begin_transaction")
+	}
+	defer func() {
+		if err := tx.Rollback(); err != nil && !errors.Is(err, sql.ErrTxDone) {
+			dst.Logger().Error("Failed to rollback the copy transaction", mlog.Err(err))
+		}
+	}()
+
+	copies := make([]TableCopy, 0, len(tables))
+	for _, table := range tables {
+		rows, err := copySqliteTable(ctx, src, dst, tx, table, batchSize)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to copy table %s", table)
+		}
+		if rows >= 0 {
+			copies = append(copies, TableCopy{Table: table, Rows: rows})
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, errors.Wrap(err, "commit_transaction")
+	}
+
+	return copies, nil
+}
+
+// copySqliteTable copies the columns a SQLite table shares with its Postgres
+// counterpart, returning the number of rows inserted, or -1 when the table
+// doesn't exist in Postgres.
+func copySqliteTable(ctx context.Context, src, dst *SqlStore, tx *sqlx.Tx, table string, batchSize int) (int64, error) {
+	var dstColumns []postgresColumn
+	if err := dst.GetMaster().Select(&dstColumns, `SELECT column_name, data_type FROM information_schema.columns
+		WHERE table_schema = current_schema() AND table_name = $1`, table); err != nil {
+		return 0, errors.Wrap(err, "failed to get the destination columns")
+	}
+	if len(dstColumns) == 0 {
+		src.Logger().Warn("Skipping a table missing from the destination database", mlog.String("table", table))
+		return -1, nil
+	}
+	dstTypes := make(map[string]string, len(dstColumns))
+	for _, column := range dstColumns {
+		dstTypes[column.Name] = column.Type
+	}
+
+	var srcColumns []string
+	if err := src.GetMaster().Select(&srcColumns, "SELECT name FROM pragma_table_info(?) ORDER BY cid", table); err != nil {
+		return 0, errors.Wrap(err, "failed to get the source columns")
+	}
+
+	var columns, quoted, types []string
+	for _, column := range srcColumns {
+		column = strings.ToLower(column)
+		if _, ok := dstTypes[column]; !ok {
+			src.Logger().Warn("Skipping a column missing from the destination database", mlog.String("table", table), mlog.String("column", column))
+			continue
+		}
+		columns = append(columns, column)
+		quoted = append(quoted, quoteIdentifier(column))
+		types = append(types, dstTypes[column])
+	}
+	if len(columns) == 0 {
+		return 0, nil
+	}
+
+	batchSize = min(batchSize, postgresMaxParams/len(columns))
+
+	rows, err := src.GetMaster().DB.QueryContext(ctx, "SELECT "+strings.Join(quoted, ", ")+" FROM "+quoteIdentifier(table))
+	if err != nil {
+		return 0, errors.Wrap(err, "failed to read the source rows")
+	}
+	defer rows.Close()
+
+	var copied int64
+	insert := func(builder sq.InsertBuilder) error {
+		query, args, err := builder.ToSql()
+		if err != nil {
+			return errors.Wrap(err, "insert_tosql")
+		}
+		result, err := tx.ExecContext(ctx, query, args...)
+		if err != nil {
+			return errors.Wrap(err, "failed to insert the rows")
+		}
+		inserted, err := result.RowsAffected()
+		if err != nil {
+			return errors.Wrap(err, "failed to get the inserted rows")
+		}
+		copied += inserted
+		return nil
+	}
+
+	newBuilder := func() sq.InsertBuilder {
+		return dst.getQueryBuilder().Insert(quoteIdentifier(table)).Columns(quoted...).Suffix("ON CONFLICT DO NOTHING")
+	}
+	builder, pending := newBuilder(), 0
+	for rows.Next() {
+		values := make([]any, len(columns))
+		dest := make([]any, len(columns))
+		for i := range values {
+			dest[i] = &values[i]
+		}
+		if err := rows.Scan(dest...); err != nil {
+			return 0, errors.Wrap(err, "failed to scan the source row")
+		}
+		for i, value := range values {
+			values[i] = postgresValue(value, types[i])
+		}
+
+		builder = builder.Values(values...)
+		pending++
+		if pending == batchSize {
+			if err := insert(builder); err != nil {
+				return 0, err
+			}
+			builder, pending = newBuilder(), 0
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return 0, errors.Wrap(err, "failed to read the source rows")
+	}
+	if pending > 0 {
+		if err := insert(builder); err != nil {
+			return 0, err
+		}
+	}
+
+	return copied, nil
+}
+
+// postgresValue converts a value read from SQLite to the type of the Postgres
+// column it's copied to. SQLite stores booleans as integers, and the text
+// columns are read as bytes, which Postgres would only accept for bytea.
+func postgresValue(value any, columnType string) any {
+	switch columnType {
+	case "boolean":
+		if v, ok := value.(int64); ok {
+			return v != 0
+		}
+	case "bytea":
+		if v, ok := value.(string); ok {
+			return []byte(v)
+		}
+	default:
+		if v, ok := value.([]byte); ok {
+			return string(v)
+		}
+	}
+	return value
+}
+
+func quoteIdentifier(name string) string {
+	return `"` + strings.ReplaceAll(name, `"`, `""`) + `"`
+}
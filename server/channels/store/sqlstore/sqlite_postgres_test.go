@@ -0,0 +1,82 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package sqlstore
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/public/shared/mlog"
+	"github.com/mattermost/mattermost/server/public/shared/request"
+	"github.com/mattermost/mattermost/server/v8/channels/store/storetest"
+)
+
+func TestCopySqliteToPostgres(t *testing.T) {
+	logger := mlog.CreateTestLogger(t)
+	rctx := request.TestContext(t)
+
+	srcSettings, err := makeSqlSettings(model.DatabaseDriverSqlite)
+	if err != nil {
+		t.Skip(err)
+	}
+	dstSettings, err := makeSqlSettings(model.DatabaseDriverPostgres)
+	if err != nil {
+		t.Skip(err)
+	}
+
+	src, err := New(*srcSettings, logger, nil)
+	require.NoError(t, err)
+	defer func() {
+		src.Close()
+		storetest.CleanupSqlSettings(srcSettings)
+	}()
+
+	dst, err := New(*dstSettings, logger, nil)
+	require.NoError(t, err)
+	defer func() {
+		dst.Close()
+		storetest.CleanupSqlSettings(dstSettings)
+	}()
+
+	user, err := src.User().Save(rctx, &model.User{Username: model.NewUsername(), Email: storetest.MakeEmail()})
+	require.NoError(t, err)
+	channel, err := src.Channel().Save(rctx, &model.Channel{TeamId: model.NewId(), Name: model.NewId(), DisplayName: "Name", Type: model.ChannelTypeOpen}, -1)
+	require.NoError(t, err)
+	post, err := src.Post().Save(rctx, &model.Post{ChannelId: channel.Id, UserId: user.Id, Message: "copied message", Props: model.StringInterface{"key": "value"}})
+	require.NoError(t, err)
+
+	copies, err := CopySqliteToPostgres(context.Background(), src, dst, 1)
+	require.NoError(t, err)
+	assert.Contains(t, copies, TableCopy{Table: "users", Rows: 1})
+	assert.Contains(t, copies, TableCopy{Table: "posts", Rows: 1})
+
+	copiedUser, err := dst.User().Get(context.Background(), user.Id)
+	require.NoError(t, err)
+	assert.Equal(t, user.Username, copiedUser.Username)
+	assert.False(t, copiedUser.EmailVerified)
+
+	copiedPost, err := dst.Post().GetSingle(rctx, post.Id, false)
+	require.NoError(t, err)
+	assert.Equal(t, "copied message", copiedPost.Message)
+	assert.Equal(t, "value", copiedPost.GetProp("key"))
+
+	t.Run("should refuse a destination with users", func(t *testing.T) {
+		_, err := CopySqliteToPostgres(context.Background(), src, dst, 100)
+		require.Error(t, err)
+	})
+}
+
+func TestPostgresValue(t *testing.T) {
+	assert.Equal(t, true, postgresValue(int64(1), "boolean"))
+	assert.Equal(t, false, postgresValue(int64(0), "boolean"))
+	assert.Equal(t, "text", postgresValue([]byte("text"), "character varying"))
+	assert.Equal(t, `{"key":"value"}`, postgresValue([]byte(`{"key":"value"}`), "jsonb"))
+	assert.Equal(t, []byte("bytes"), postgresValue("bytes", "bytea"))
+	assert.Equal(t, int64(42), postgresValue(int64(42), "bigint"))
+	assert.Nil(t, postgresValue(nil, "boolean"))
+}
@@ -134,7 +134,7 @@ func (w *sqlxDBWrapper) GetBuilder(dest any, builder Builder) error {
 }
 
 func (w *sqlxDBWrapper) NamedExec(query string, arg any) (sql.Result, error) {
-	if w.DB.DriverName() == model.DatabaseDriverPostgres {
+	if w.DB.DriverName() == model.DatabaseDriverPostgres || w.DB.DriverName() == model.DatabaseDriverSqlite {
 		query = namedParamRegex.ReplaceAllStringFunc(query, strings.ToLower)
 	}
 	ctx, cancel := context.WithTimeout(context.Background(), w.queryTimeout)
@@ -192,7 +192,7 @@ func (w *sqlxDBWrapper) ExecRaw(query string, args ...any) (sql.Result, error) {
 }
 
 func (w *sqlxDBWrapper) NamedQuery(query string, arg any) (*sqlx.Rows, error) {
-	if w.DB.DriverName() == model.DatabaseDriverPostgres {
+	if w.DB.DriverName() == model.DatabaseDriverPostgres || w.DB.DriverName() == model.DatabaseDriverSqlite {
 		query = namedParamRegex.ReplaceAllStringFunc(query, strings.ToLower)
 	}
 	ctx, cancel := context.WithTimeout(context.Background(), w.queryTimeout)
@@ -348,7 +348,7 @@ func (w *sqlxTxWrapper) ExecRaw(query string, args ...any) (sql.Result, error) {
 }
 
 func (w *sqlxTxWrapper) NamedExec(query string, arg any) (sql.Result, error) {
-	if w.Tx.DriverName() == model.DatabaseDriverPostgres {
+	if w.Tx.DriverName() == model.DatabaseDriverPostgres || w.Tx.DriverName() == model.DatabaseDriverSqlite {
 		query = namedParamRegex.ReplaceAllStringFunc(query, strings.ToLower)
 	}
 	ctx, cancel := context.WithTimeout(context.Background(), w.queryTimeout)
@@ -364,7 +364,7 @@ func (w *sqlxTxWrapper) NamedExec(query string, arg any) (sql.Result, error) {
 }
 
 func (w *sqlxTxWrapper) NamedQuery(query string, arg any) (*sqlx.Rows, error) {
-	if w.Tx.DriverName() == model.DatabaseDriverPostgres {
+	if w.Tx.DriverName() == model.DatabaseDriverPostgres || w.Tx.DriverName() == model.DatabaseDriverSqlite {
 		query = namedParamRegex.ReplaceAllStringFunc(query, strings.ToLower)
 	}
 	ctx, cancel := context.WithTimeout(context.Background(), w.queryTimeout)
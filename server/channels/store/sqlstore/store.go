@@ -37,17 +37,18 @@ type migrationDirection string
 type Option func(s *SqlStore) error
 
 const (
-	IndexTypeFullText                 = "full_text"
-	IndexTypeFullTextFunc             = "full_text_func"
-	IndexTypeDefault                  = "default"
-	PGDupTableErrorCode               = "42P07"      // see https://github.com/lib/pq/blob/master/error.go#L268
-	MySQLDupTableErrorCode            = uint16(1050) // see https://dev.mysql.com/doc/mysql-errors/5.7/en/server-error-reference.html#error_er_table_exists_error
-	PGForeignKeyViolationErrorCode    = "23503"
-	MySQLForeignKeyViolationErrorCode = 1452
-	PGDuplicateObjectErrorCode        = "42710"
-	MySQLDuplicateObjectErrorCode     = 1022
-	DBPingAttempts                    = 5
-	DBReplicaPingAttempts             = 2
+	IndexTypeFullText                  = "full_text"
+	IndexTypeFullTextFunc              = "full_text_func"
+	IndexTypeDefault                   = "default"
+	PGDupTableErrorCode                = "42P07"      // see https://github.com/lib/pq/blob/master/error.go#L268
+	MySQLDupTableErrorCode             = uint16(1050) // see https://dev.mysql.com/doc/mysql-errors/5.7/en/server-error-reference.html#error_er_table_exists_error
+	PGForeignKeyViolationErrorCode     = "23503"
+	MySQLForeignKeyViolationErrorCode  = 1452
+	SqliteForeignKeyViolationErrorCode = 787 // see https://www.sqlite.org/rescode.html#constraint_foreignkey
+	PGDuplicateObjectErrorCode         = "42710"
+	MySQLDuplicateObjectErrorCode      = 1022
+	DBPingAttempts                     = 5
+	DBReplicaPingAttempts              = 2
 	// This is a numerical version string by postgres. The format is
 	// 2 characters for major, minor, and patch version prior to 10.
 	// After 10, it's major and minor only.
@@ -76,6 +77,7 @@ type SqlStoreStores struct {
 	user                       store.UserStore
 	bot                        store.BotStore
 	audit                      store.AuditStore
+	auditRecord                store.AuditRecordStore
 	cluster                    store.ClusterDiscoveryStore
 	remoteCluster              store.RemoteClusterStore
 	compliance                 store.ComplianceStore
@@ -119,6 +121,7 @@ type SqlStoreStores struct {
 	propertyValue              store.PropertyValueStore
 	accessControlPolicy        store.AccessControlPolicyStore
 	Attributes                 store.AttributesStore
+	partition                  store.PartitionStore
 }
 
 type SqlStore struct {
@@ -137,6 +140,8 @@ type SqlStore struct {
 	stores            SqlStoreStores
 	settings          *model.SqlSettings
 	lockedToMaster    bool
+	recentWrites      writeTracker
+	partitioned       partitionedTables
 	context           context.Context
 	license           *model.License
 	licenseMutex      sync.RWMutex
@@ -223,6 +228,10 @@ func New(settings model.SqlSettings, logger mlog.LoggerIFace, metrics einterface
 		return nil, errors.Wrap(err, "failed to compute default text search config")
 	}
 
+	if err = store.loadPartitionedTables(); err != nil {
+		return nil, errors.Wrap(err, "failed to load partitioned tables")
+	}
+
 	store.stores.team = newSqlTeamStore(store)
 	store.stores.channel = newSqlChannelStore(store, metrics)
 	store.stores.post = newSqlPostStore(store, metrics)
@@ -230,6 +239,7 @@ func New(settings model.SqlSettings, logger mlog.LoggerIFace, metrics einterface
 	store.stores.user = newSqlUserStore(store, metrics)
 	store.stores.bot = newSqlBotStore(store, metrics)
 	store.stores.audit = newSqlAuditStore(store)
+	store.stores.auditRecord = newSqlAuditRecordStore(store)
 	store.stores.cluster = newSqlClusterDiscoveryStore(store)
 	store.stores.remoteCluster = newSqlRemoteClusterStore(store)
 	store.stores.compliance = newSqlComplianceStore(store)
@@ -274,6 +284,7 @@ func New(settings model.SqlSettings, logger mlog.LoggerIFace, metrics einterface
 	store.stores.propertyValue = newPropertyValueStore(store)
 	store.stores.accessControlPolicy = newSqlAccessControlPolicyStore(store, metrics)
 	store.stores.Attributes = newSqlAttributesStore(store, metrics)
+	store.stores.partition = newSqlPartitionStore(store)
 
 	store.stores.preference.(*SqlPreferenceStore).deleteUnusedFeatures()
 
@@ -307,7 +318,13 @@ func (ss *SqlStore) initConnection() error {
 		}
 	}
 
-	handle, err := sqlUtils.SetupConnection(ss.Logger(), "master", dataSource, ss.settings, DBPingAttempts)
+	var handle *sql.DB
+	var err error
+	if ss.DriverName() == model.DatabaseDriverSqlite {
+		handle, err = setupSqliteConnection(ss.Logger(), "master", dataSource, ss.settings)
+	} else {
+		handle, err = sqlUtils.SetupConnection(ss.Logger(), "master", dataSource, ss.settings, DBPingAttempts)
+	}
 	if err != nil {
 		return err
 	}
@@ -317,6 +334,9 @@ func (ss *SqlStore) initConnection() error {
 	if ss.DriverName() == model.DatabaseDriverMysql {
 		ss.masterX.MapperFunc(noOpMapper)
 	}
+	if ss.DriverName() == model.DatabaseDriverSqlite {
+		ss.masterX.Mapper = sqliteMapper
+	}
 	if ss.metrics != nil {
 		ss.metrics.RegisterDBCollector(ss.masterX.DB.DB, "master")
 	}
@@ -431,6 +451,8 @@ func (ss *SqlStore) GetDbVersion(numerical bool) (string, error) {
 		}
 	} else if ss.DriverName() == model.DatabaseDriverMysql {
 		sqlVersion = `SELECT version()`
+	} else if ss.DriverName() == model.DatabaseDriverSqlite {
+		sqlVersion = `SELECT sqlite_version()`
 	} else {
 		return "", errors.New("Not supported driver")
 	}
@@ -455,6 +477,9 @@ func (ss *SqlStore) SetMasterX(db *sql.DB) {
 	if ss.DriverName() == model.DatabaseDriverMysql {
 		ss.masterX.MapperFunc(noOpMapper)
 	}
+	if ss.DriverName() == model.DatabaseDriverSqlite {
+		ss.masterX.Mapper = sqliteMapper
+	}
 }
 
 func (ss *SqlStore) GetInternalMasterDB() *sql.DB {
@@ -508,6 +533,8 @@ func (ss *SqlStore) monitorReplicas() {
 		case <-ss.quitMonitor:
 			return
 		case <-t.C:
+			ss.recentWrites.removeExpired(model.GetMillis())
+
 			setupReplica := func(r *atomic.Pointer[sqlxDBWrapper], dsn, name string) {
 				if r.Load().Online() {
 					return
@@ -541,6 +568,9 @@ func (ss *SqlStore) setDB(replica *atomic.Pointer[sqlxDBWrapper], handle *sql.DB
 	if ss.DriverName() == model.DatabaseDriverMysql {
 		replica.Load().MapperFunc(noOpMapper)
 	}
+	if ss.DriverName() == model.DatabaseDriverSqlite {
+		replica.Load().Mapper = sqliteMapper
+	}
 	if ss.metrics != nil {
 		ss.metrics.RegisterDBCollector(replica.Load().DB.DB, name)
 	}
@@ -671,6 +701,17 @@ func (ss *SqlStore) DoesTableExist(tableName string) bool {
 			mlog.Fatal("Failed to check if table exists", mlog.Err(err))
 		}
 
+		return count > 0
+	} else if ss.DriverName() == model.DatabaseDriverSqlite {
+		var count int64
+		err := ss.GetMaster().Get(&count,
+			`SELECT COUNT(0) FROM sqlite_master WHERE type = 'table' AND name = ?`,
+			strings.ToLower(tableName),
+		)
+		if err != nil {
+			mlog.Fatal("Failed to check if table exists", mlog.Err(err))
+		}
+
 		return count > 0
 	}
 	mlog.Fatal("Failed to check if column exists because of missing driver")
@@ -716,6 +757,18 @@ func (ss *SqlStore) DoesColumnExist(tableName string, columnName string) bool {
 			mlog.Fatal("Failed to check if column exists", mlog.Err(err))
 		}
 
+		return count > 0
+	} else if ss.DriverName() == model.DatabaseDriverSqlite {
+		var count int64
+		err := ss.GetMaster().Get(&count,
+			`SELECT COUNT(0) FROM pragma_table_info(?) WHERE name = ?`,
+			strings.ToLower(tableName),
+			strings.ToLower(columnName),
+		)
+		if err != nil {
+			mlog.Fatal("Failed to check if column exists", mlog.Err(err))
+		}
+
 		return count > 0
 	}
 	mlog.Fatal("Failed to check if column exists because of missing driver")
@@ -753,6 +806,14 @@ func (ss *SqlStore) DoesTriggerExist(triggerName string) bool {
 			mlog.Fatal("Failed to check if trigger exists", mlog.Err(err))
 		}
 
+		return count > 0
+	} else if ss.DriverName() == model.DatabaseDriverSqlite {
+		var count int64
+		err := ss.GetMaster().Get(&count, `SELECT COUNT(0) FROM sqlite_master WHERE type = 'trigger' AND name = ?`, triggerName)
+		if err != nil {
+			mlog.Fatal("Failed to check if trigger exists", mlog.Err(err))
+		}
+
 		return count > 0
 	}
 	mlog.Fatal("Failed to check if column exists because of missing driver")
@@ -764,7 +825,7 @@ func (ss *SqlStore) CreateColumnIfNotExists(tableName string, columnName string,
 		return false
 	}
 
-	if ss.DriverName() == model.DatabaseDriverPostgres {
+	if ss.DriverName() == model.DatabaseDriverPostgres || ss.DriverName() == model.DatabaseDriverSqlite {
 		_, err := ss.GetMaster().ExecNoTimeout("ALTER TABLE " + tableName + " ADD " + columnName + " " + postgresColType + " DEFAULT '" + defaultValue + "'")
 		if err != nil {
 			mlog.Fatal("Failed to create column", mlog.Err(err))
@@ -820,6 +881,20 @@ func IsUniqueConstraintError(err error, indexName []string) bool {
 		unique = true
 	}
 
+	// SQLite doesn't name the violated constraint, but lists the columns
+	// of the unique index, e.g. "UNIQUE constraint failed: users.email".
+	if sqliteUnique, primaryKey := isSqliteConstraintError(err); sqliteUnique {
+		for _, contain := range indexName {
+			if primaryKey && (contain == "PRIMARY" || strings.HasSuffix(contain, "pkey")) {
+				return true
+			}
+			if strings.Contains(strings.ToLower(err.Error()), "."+strings.ToLower(contain)) {
+				return true
+			}
+		}
+		return false
+	}
+
 	field := false
 	for _, contain := range indexName {
 		if strings.Contains(err.Error(), contain) {
@@ -924,6 +999,10 @@ func (ss *SqlStore) Audit() store.AuditStore {
 	return ss.stores.audit
 }
 
+func (ss *SqlStore) AuditRecord() store.AuditRecordStore {
+	return ss.stores.auditRecord
+}
+
 func (ss *SqlStore) ClusterDiscovery() store.ClusterDiscoveryStore {
 	return ss.stores.cluster
 }
@@ -1092,6 +1171,10 @@ func (ss *SqlStore) Attributes() store.AttributesStore {
 	return ss.stores.Attributes
 }
 
+func (ss *SqlStore) Partition() store.PartitionStore {
+	return ss.stores.partition
+}
+
 func (ss *SqlStore) DropAllTables() {
 	if ss.DriverName() == model.DatabaseDriverPostgres {
 		ss.masterX.Exec(`DO
@@ -1106,6 +1189,12 @@ func (ss *SqlStore) DropAllTables() {
 			   );
 			END
 			$func$;`)
+	} else if ss.DriverName() == model.DatabaseDriverSqlite {
+		tables := []string{}
+		ss.masterX.Select(&tables, `SELECT name FROM sqlite_master WHERE type = 'table' AND name NOT LIKE 'sqlite!_%' ESCAPE '!' AND name NOT LIKE 'fts!_%' ESCAPE '!' AND name != 'db_migrations'`)
+		for _, t := range tables {
+			ss.masterX.Exec(`DELETE FROM ` + t)
+		}
 	} else {
 		tables := []string{}
 		ss.masterX.Select(&tables, `show tables`)
@@ -1380,6 +1469,12 @@ func (ss *SqlStore) determineMaxColumnSize(tableName, columnName string) (int, e
 			mlog.Warn("Unable to determine the maximum supported column size for MySQL", mlog.Err(err))
 			return 0, err
 		}
+	} else if ss.DriverName() == model.DatabaseDriverSqlite {
+		var err error
+		if columnSizeBytes, err = ss.sqliteColumnMaxLength(tableName, columnName); err != nil {
+			mlog.Warn("Unable to determine the maximum supported column size for SQLite", mlog.Err(err))
+			return 0, err
+		}
 	} else {
 		mlog.Warn("No implementation found to determine the maximum supported column size")
 	}
@@ -180,11 +180,14 @@ func initStores(logger mlog.LoggerIFace, parallelism int) {
 				}
 				storePools = append(storePools, pgStorePool)
 			}
+		case "sqlite":
+			storeTypes = append(storeTypes, newStoreType("SQLite", model.DatabaseDriverSqlite))
 		}
 	} else {
 		storeTypes = append(storeTypes,
 			newStoreType("MySQL", model.DatabaseDriverMysql),
 			newStoreType("PostgreSQL", model.DatabaseDriverPostgres),
+			newStoreType("SQLite", model.DatabaseDriverSqlite),
 		)
 
 		if enableFullyParallelTests {
@@ -519,6 +522,7 @@ func TestGetDbVersion(t *testing.T) {
 	testDrivers := []string{
 		model.DatabaseDriverPostgres,
 		model.DatabaseDriverMysql,
+		model.DatabaseDriverSqlite,
 	}
 
 	for _, d := range testDrivers {
@@ -975,6 +979,8 @@ func makeSqlSettings(driver string) (*model.SqlSettings, error) {
 		return storetest.MakeSqlSettings(driver, false), nil
 	case model.DatabaseDriverMysql:
 		return storetest.MakeSqlSettings(driver, false), nil
+	case model.DatabaseDriverSqlite:
+		return storetest.MakeSqlSettings(driver, false), nil
 	}
 
 	return nil, errDriverUnsupported
@@ -1033,6 +1039,7 @@ func TestGetDBSchemaVersion(t *testing.T) {
 	testDrivers := []string{
 		model.DatabaseDriverPostgres,
 		model.DatabaseDriverMysql,
+		model.DatabaseDriverSqlite,
 	}
 
 	logger := mlog.CreateConsoleTestLogger(t)
@@ -1077,6 +1084,7 @@ func TestGetLocalSchemaVersion(t *testing.T) {
 	testDrivers := []string{
 		model.DatabaseDriverPostgres,
 		model.DatabaseDriverMysql,
+		model.DatabaseDriverSqlite,
 	}
 
 	logger := mlog.CreateConsoleTestLogger(t)
@@ -1109,6 +1117,7 @@ func TestGetAppliedMigrations(t *testing.T) {
 	testDrivers := []string{
 		model.DatabaseDriverPostgres,
 		model.DatabaseDriverMysql,
+		model.DatabaseDriverSqlite,
 	}
 
 	logger := mlog.CreateConsoleTestLogger(t)
@@ -1161,6 +1170,7 @@ func TestSkipMigrationsOption(t *testing.T) {
 	testDrivers := []string{
 		model.DatabaseDriverPostgres,
 		model.DatabaseDriverMysql,
+		model.DatabaseDriverSqlite,
 	}
 
 	logger := mlog.CreateConsoleTestLogger(t)
@@ -1186,6 +1196,8 @@ func TestSkipMigrationsOption(t *testing.T) {
 			// And we know what each db will return:
 			if driver == model.DatabaseDriverPostgres {
 				assert.Contains(t, err.Error(), "pq: relation \"db_migrations\" does not exist")
+			} else if driver == model.DatabaseDriverSqlite {
+				assert.Contains(t, err.Error(), "no such table: db_migrations")
 			} else {
 				// Mysql includes the random db name, so test the end:
 				assert.Contains(t, err.Error(), ".db_migrations' doesn't exist")
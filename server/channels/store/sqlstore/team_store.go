@@ -468,11 +468,11 @@ func (s SqlTeamStore) teamSearchQuery(opts *model.TeamSearch, countQuery bool) s
 		term = wildcardSearchTerm(term)
 
 		operatorKeyword := "ILIKE"
-		if s.DriverName() == model.DatabaseDriverMysql {
+		if s.DriverName() == model.DatabaseDriverMysql || s.DriverName() == model.DatabaseDriverSqlite {
 			operatorKeyword = "LIKE"
 		}
 
-		query = query.Where(fmt.Sprintf("(Name %[1]s ? OR DisplayName %[1]s ?)", operatorKeyword), term, term)
+		query = query.Where(fmt.Sprintf("(Name %[1]s ?%[2]s OR DisplayName %[1]s ?%[2]s)", operatorKeyword, likeEscape(s.DriverName())), term, term)
 	}
 
 	if opts.PolicyID != nil && *opts.PolicyID != "" {
@@ -880,6 +880,7 @@ func (s SqlTeamStore) SaveMember(rctx request.CTX, member *model.TeamMember, max
 	if err != nil {
 		return nil, err
 	}
+	s.recordWrite(rctx)
 	return members[0], nil
 }
 
@@ -963,6 +964,7 @@ func (s SqlTeamStore) UpdateMember(rctx request.CTX, member *model.TeamMember) (
 	if err != nil {
 		return nil, err
 	}
+	s.recordWrite(rctx)
 	return members[0], nil
 }
 
@@ -1216,6 +1218,7 @@ func (s SqlTeamStore) RemoveMembers(rctx request.CTX, teamId string, userIds []s
 	if err != nil {
 		return errors.Wrapf(err, "failed to delete TeamMembers with teamId=%s and userId in %v", teamId, userIds)
 	}
+	s.recordWrite(rctx)
 	return nil
 }
 
@@ -1252,6 +1255,7 @@ func (s SqlTeamStore) RemoveAllMembersByUser(rctx request.CTX, userId string) er
 	if err != nil {
 		return errors.Wrapf(err, "failed to delete TeamMembers with userId=%s", userId)
 	}
+	s.recordWrite(rctx)
 	return nil
 }
 
@@ -1472,7 +1476,7 @@ func (s SqlTeamStore) GetAllForExportAfter(limit int, afterId string) ([]*model.
 		From("Teams").
 		LeftJoin("Schemes ON Teams.SchemeId = Schemes.Id").
 		Where(sq.Gt{"Teams.Id": afterId}).
-		OrderBy("Id").
+		OrderBy("Teams.Id").
 		Limit(uint64(limit)).ToSql()
 	if err != nil {
 		return nil, errors.Wrap(err, "team_tosql")
@@ -1577,7 +1581,7 @@ func (s SqlTeamStore) GetCommonTeamIDsForMultipleUsers(userIDs []string) ([]stri
 func (s SqlTeamStore) GetTeamMembersForExport(userId string) ([]*model.TeamMemberForExport, error) {
 	members := []*model.TeamMemberForExport{}
 	query, args, err := s.getQueryBuilder().
-		Select("TeamMembers.TeamId", "TeamMembers.UserId", "TeamMembers.Roles", "TeamMembers.DeleteAt",
+		Select("TeamMembers.TeamId", "TeamMembers.UserId", "TeamMembers.Roles", "TeamMembers.DeleteAt", "TeamMembers.CreateAt",
 			"(TeamMembers.SchemeGuest IS NOT NULL AND TeamMembers.SchemeGuest) as SchemeGuest",
 			"TeamMembers.SchemeUser", "TeamMembers.SchemeAdmin", "Teams.Name as TeamName").
 		From("TeamMembers").
@@ -14,6 +14,7 @@ import (
 	"golang.org/x/sync/errgroup"
 
 	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/public/shared/request"
 	"github.com/mattermost/mattermost/server/v8/channels/store"
 	"github.com/mattermost/mattermost/server/v8/channels/utils"
 )
@@ -155,12 +156,12 @@ func (s *SqlThreadStore) getTotalThreadsQuery(userId, teamId string, opts model.
 
 // GetTotalUnreadThreads counts the number of unread threads for the given user, optionally
 // constrained to the given team + DMs/GMs.
-func (s *SqlThreadStore) GetTotalUnreadThreads(userId, teamId string, opts model.GetUserThreadsOpts) (int64, error) {
+func (s *SqlThreadStore) GetTotalUnreadThreads(rctx request.CTX, userId, teamId string, opts model.GetUserThreadsOpts) (int64, error) {
 	query := s.getTotalThreadsQuery(userId, teamId, opts).
 		Where(sq.Expr("ThreadMemberships.LastViewed < Threads.LastReplyAt"))
 
 	var totalUnreadThreads int64
-	err := s.GetReplica().GetBuilder(&totalUnreadThreads, query)
+	err := s.DBXFromContext(rctx.Context()).GetBuilder(&totalUnreadThreads, query)
 	if err != nil {
 		return 0, errors.Wrapf(err, "failed to count unread threads for user id=%s", userId)
 	}
@@ -170,7 +171,7 @@ func (s *SqlThreadStore) GetTotalUnreadThreads(userId, teamId string, opts model
 
 // GetTotalUnreadThreads counts the number of threads for the given user, optionally constrained
 // to the given team + DMs/GMs.
-func (s *SqlThreadStore) GetTotalThreads(userId, teamId string, opts model.GetUserThreadsOpts) (int64, error) {
+func (s *SqlThreadStore) GetTotalThreads(rctx request.CTX, userId, teamId string, opts model.GetUserThreadsOpts) (int64, error) {
 	if opts.Unread {
 		return 0, errors.New("GetTotalThreads does not support the Unread flag; use GetTotalUnreadThreads instead")
 	}
@@ -178,7 +179,7 @@ func (s *SqlThreadStore) GetTotalThreads(userId, teamId string, opts model.GetUs
 	query := s.getTotalThreadsQuery(userId, teamId, opts)
 
 	var totalThreads int64
-	err := s.GetReplica().GetBuilder(&totalThreads, query)
+	err := s.DBXFromContext(rctx.Context()).GetBuilder(&totalThreads, query)
 	if err != nil {
 		return 0, errors.Wrapf(err, "failed to count threads for user id=%s", userId)
 	}
@@ -188,7 +189,7 @@ func (s *SqlThreadStore) GetTotalThreads(userId, teamId string, opts model.GetUs
 
 // GetTotalUnreadMentions counts the number of unread mentions for the given user, optionally
 // constrained to the given team + DMs/GMs.
-func (s *SqlThreadStore) GetTotalUnreadMentions(userId, teamId string, opts model.GetUserThreadsOpts) (int64, error) {
+func (s *SqlThreadStore) GetTotalUnreadMentions(rctx request.CTX, userId, teamId string, opts model.GetUserThreadsOpts) (int64, error) {
 	var totalUnreadMentions int64
 
 	query := s.getQueryBuilder().
@@ -217,7 +218,7 @@ func (s *SqlThreadStore) GetTotalUnreadMentions(userId, teamId string, opts mode
 		query = query.Where(sq.Eq{"COALESCE(Threads.ThreadDeleteAt, 0)": 0})
 	}
 
-	err := s.GetReplica().GetBuilder(&totalUnreadMentions, query)
+	err := s.DBXFromContext(rctx.Context()).GetBuilder(&totalUnreadMentions, query)
 	if err != nil {
 		return 0, errors.Wrapf(err, "failed to count unread mentions for user id=%s", userId)
 	}
@@ -227,7 +228,7 @@ func (s *SqlThreadStore) GetTotalUnreadMentions(userId, teamId string, opts mode
 
 // GetTotalUnreadUrgentMentions counts the number of unread mentions for the given user, optionally
 // constrained to the given team + DMs/GMs.
-func (s *SqlThreadStore) GetTotalUnreadUrgentMentions(userId, teamId string, opts model.GetUserThreadsOpts) (int64, error) {
+func (s *SqlThreadStore) GetTotalUnreadUrgentMentions(rctx request.CTX, userId, teamId string, opts model.GetUserThreadsOpts) (int64, error) {
 	var totalUnreadUrgentMentions int64
 
 	query := s.getQueryBuilder().
@@ -262,7 +263,7 @@ func (s *SqlThreadStore) GetTotalUnreadUrgentMentions(userId, teamId string, opt
 			Where(sq.Eq{"COALESCE(Threads.ThreadDeleteAt, 0)": 0})
 	}
 
-	err := s.GetReplica().GetBuilder(&totalUnreadUrgentMentions, query)
+	err := s.DBXFromContext(rctx.Context()).GetBuilder(&totalUnreadUrgentMentions, query)
 	if err != nil {
 		return 0, errors.Wrapf(err, "failed to count unread urgent mentions for user id=%s", userId)
 	}
@@ -270,7 +271,7 @@ func (s *SqlThreadStore) GetTotalUnreadUrgentMentions(userId, teamId string, opt
 	return totalUnreadUrgentMentions, nil
 }
 
-func (s *SqlThreadStore) GetThreadsForUser(userId, teamId string, opts model.GetUserThreadsOpts) ([]*model.ThreadResponse, error) {
+func (s *SqlThreadStore) GetThreadsForUser(rctx request.CTX, userId, teamId string, opts model.GetUserThreadsOpts) ([]*model.ThreadResponse, error) {
 	pageSize := uint64(30)
 	if opts.PageSize != 0 {
 		pageSize = opts.PageSize
@@ -359,7 +360,7 @@ func (s *SqlThreadStore) GetThreadsForUser(userId, teamId string, opts model.Get
 		Limit(pageSize)
 
 	var threads []*JoinedThread
-	err := s.GetReplica().SelectBuilder(&threads, query)
+	err := s.DBXFromContext(rctx.Context()).SelectBuilder(&threads, query)
 	if err != nil {
 		return nil, errors.Wrapf(err, "failed to fetch threads for user id=%s", userId)
 	}
@@ -547,7 +548,7 @@ func (s *SqlThreadStore) GetThreadMembershipsForExport(postID string) ([]*model.
 	return members, nil
 }
 
-func (s *SqlThreadStore) GetThreadForUser(threadMembership *model.ThreadMembership, extended, postPriorityEnabled bool) (*model.ThreadResponse, error) {
+func (s *SqlThreadStore) GetThreadForUser(rctx request.CTX, threadMembership *model.ThreadMembership, extended, postPriorityEnabled bool) (*model.ThreadResponse, error) {
 	if !threadMembership.Following {
 		return nil, store.NewErrNotFound("ThreadMembership", "<following>")
 	}
@@ -584,7 +585,7 @@ func (s *SqlThreadStore) GetThreadForUser(threadMembership *model.ThreadMembersh
 			LeftJoin("PostsPriority ON PostsPriority.PostId = Threads.PostId")
 	}
 
-	err := s.GetReplica().GetBuilder(&thread, query)
+	err := s.DBXFromContext(rctx.Context()).GetBuilder(&thread, query)
 	if err != nil {
 		if err == sql.ErrNoRows {
 			return nil, store.NewErrNotFound("Thread", threadMembership.PostId)
@@ -627,7 +628,7 @@ func (s *SqlThreadStore) MarkAllAsReadByChannels(userID string, channelIDs []str
 	now := model.GetMillis()
 
 	var query sq.UpdateBuilder
-	if s.DriverName() == model.DatabaseDriverPostgres {
+	if s.DriverName() == model.DatabaseDriverPostgres || s.DriverName() == model.DatabaseDriverSqlite {
 		query = s.getQueryBuilder().Update("ThreadMemberships").From("Threads")
 	} else {
 		query = s.getQueryBuilder().Update("ThreadMemberships", "Threads")
@@ -673,7 +674,7 @@ func (s *SqlThreadStore) MarkAllAsReadByTeam(userId, teamId string) error {
 	timestamp := model.GetMillis()
 
 	var query sq.UpdateBuilder
-	if s.DriverName() == model.DatabaseDriverPostgres {
+	if s.DriverName() == model.DatabaseDriverPostgres || s.DriverName() == model.DatabaseDriverSqlite {
 		query = s.getQueryBuilder().Update("ThreadMemberships").From("Threads")
 	} else {
 		query = s.getQueryBuilder().Update("ThreadMemberships", "Threads")
@@ -1126,6 +1127,13 @@ func (s *SqlThreadStore) updateThreadParticipantsForUserTx(trx *sqlxTxWrapper, p
 					AND NOT participants ? $3`, userIdParam, postID, userID); err != nil {
 			return err
 		}
+	} else if s.DriverName() == model.DatabaseDriverSqlite {
+		if _, err := trx.Exec(`UPDATE Threads
+			SET Participants = json_insert(Participants, '$[#]', ?)
+			WHERE PostId=?
+			AND NOT EXISTS (SELECT 1 FROM json_each(Participants) WHERE value = ?)`, userID, postID, userID); err != nil {
+			return err
+		}
 	} else {
 		// CONCAT('$[', JSON_LENGTH(Participants), ']') just generates $[n]
 		// which is the positional syntax required for appending.
@@ -80,6 +80,8 @@ func (s SqlUserAccessTokenStore) deleteSessionsAndTokensById(transaction *sqlxTx
 	query := ""
 	if s.DriverName() == model.DatabaseDriverPostgres {
 		query = "DELETE FROM Sessions s USING UserAccessTokens o WHERE o.Token = s.Token AND o.Id = ?"
+	} else if s.DriverName() == model.DatabaseDriverSqlite {
+		query = "DELETE FROM Sessions WHERE Token IN (SELECT Token FROM UserAccessTokens WHERE Id = ?)"
 	} else if s.DriverName() == model.DatabaseDriverMysql {
 		query = "DELETE s.* FROM Sessions s INNER JOIN UserAccessTokens o ON o.Token = s.Token WHERE o.Id = ?"
 	}
@@ -120,6 +122,8 @@ func (s SqlUserAccessTokenStore) deleteSessionsandTokensByUser(transaction *sqlx
 	query := ""
 	if s.DriverName() == model.DatabaseDriverPostgres {
 		query = "DELETE FROM Sessions s USING UserAccessTokens o WHERE o.Token = s.Token AND o.UserId = ?"
+	} else if s.DriverName() == model.DatabaseDriverSqlite {
+		query = "DELETE FROM Sessions WHERE Token IN (SELECT Token FROM UserAccessTokens WHERE UserId = ?)"
 	} else if s.DriverName() == model.DatabaseDriverMysql {
 		query = "DELETE s.* FROM Sessions s INNER JOIN UserAccessTokens o ON o.Token = s.Token WHERE o.UserId = ?"
 	}
@@ -205,9 +209,9 @@ func (s SqlUserAccessTokenStore) Search(term string) ([]*model.UserAccessToken,
 	query := s.userAccessTokensSelectQuery.
 		InnerJoin("Users ON UserAccessTokens.UserId = Users.Id").
 		Where(sq.Or{
-			sq.Like{"UserAccessTokens.Id": term},
-			sq.Like{"UserAccessTokens.UserId": term},
-			sq.Like{"Users.Username": term},
+			sq.Expr("UserAccessTokens.Id LIKE ?"+likeEscape(s.DriverName()), term),
+			sq.Expr("UserAccessTokens.UserId LIKE ?"+likeEscape(s.DriverName()), term),
+			sq.Expr("Users.Username LIKE ?"+likeEscape(s.DriverName()), term),
 		})
 
 	if err := s.GetReplica().SelectBuilder(&tokens, query); err != nil {
@@ -245,6 +249,8 @@ func (s SqlUserAccessTokenStore) deleteSessionsAndDisableToken(transaction *sqlx
 	query := ""
 	if s.DriverName() == model.DatabaseDriverPostgres {
 		query = "DELETE FROM Sessions s USING UserAccessTokens o WHERE o.Token = s.Token AND o.Id = ?"
+	} else if s.DriverName() == model.DatabaseDriverSqlite {
+		query = "DELETE FROM Sessions WHERE Token IN (SELECT Token FROM UserAccessTokens WHERE Id = ?)"
 	} else if s.DriverName() == model.DatabaseDriverMysql {
 		query = "DELETE s.* FROM Sessions s INNER JOIN UserAccessTokens o ON o.Token = s.Token WHERE o.Id = ?"
 	}
@@ -175,6 +175,7 @@ func (us SqlUserStore) Save(rctx request.CTX, user *model.User) (*model.User, er
 		}
 		return nil, errors.Wrapf(err, "failed to save User with userId=%s", user.Id)
 	}
+	us.recordWrite(rctx)
 
 	return user, nil
 }
@@ -291,9 +292,11 @@ func (us SqlUserStore) Update(rctx request.CTX, user *model.User, trustedUpdateD
 	if count > 1 {
 		return nil, fmt.Errorf("multiple users were update: userId=%s, count=%d", user.Id, count)
 	}
+	us.recordWrite(rctx)
 
 	user.Sanitize(map[string]bool{})
 	oldUser.Sanitize(map[string]bool{})
+
 	return &model.UserUpdate{New: user.DeepCopy(), Old: &oldUser}, nil
 }
 
@@ -1377,6 +1380,7 @@ func (us SqlUserStore) PermanentDelete(rctx request.CTX, userId string) error {
 	if _, err := us.GetMaster().Exec("DELETE FROM Users WHERE Id = ?", userId); err != nil {
 		return errors.Wrapf(err, "failed to delete User with userId=%s", userId)
 	}
+	us.recordWrite(rctx)
 	return nil
 }
 
@@ -2371,8 +2375,10 @@ func (us SqlUserStore) GetUserCountForReport(filter *model.UserReportOptions) (i
 
 func (us SqlUserStore) GetUserReport(filter *model.UserReportOptions) ([]*model.UserReportQuery, error) {
 	isPostgres := us.DriverName() == model.DatabaseDriverPostgres
+	// The post stats view doesn't exist in MySQL.
+	withPostStats := isPostgres || us.DriverName() == model.DatabaseDriverSqlite
 	selectColumns := append(getUsersColumns(), "MAX(s.LastActivityAt) AS LastStatusAt")
-	if isPostgres {
+	if withPostStats {
 		selectColumns = append(selectColumns,
 			"MAX(ps.LastPostDate) AS LastPostDate",
 			"COUNT(ps.Day) AS DaysActive",
@@ -2424,7 +2430,7 @@ func (us SqlUserStore) GetUserReport(filter *model.UserReportOptions) ([]*model.
 		query = query.Limit(uint64(filter.PageSize))
 	}
 
-	if isPostgres {
+	if withPostStats {
 		joinSql := sq.And{}
 		if filter.StartAt > 0 {
 			startDate := time.UnixMilli(filter.StartAt)
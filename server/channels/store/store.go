@@ -31,6 +31,7 @@ type Store interface {
 	User() UserStore
 	Bot() BotStore
 	Audit() AuditStore
+	AuditRecord() AuditRecordStore
 	ClusterDiscovery() ClusterDiscoveryStore
 	RemoteCluster() RemoteClusterStore
 	Compliance() ComplianceStore
@@ -97,6 +98,7 @@ type Store interface {
 	PropertyValue() PropertyValueStore
 	AccessControlPolicy() AccessControlPolicyStore
 	Attributes() AttributesStore
+	Partition() PartitionStore
 	GetSchemaDefinition() (*model.SupportPacketDatabaseSchema, error)
 }
 
@@ -265,7 +267,7 @@ type ChannelStore interface {
 	AnalyticsTypeCount(teamID string, channelType model.ChannelType) (int64, error)
 	AnalyticsDeletedTypeCount(teamID string, channelType model.ChannelType) (int64, error)
 	AnalyticsCountAll(teamID string) (map[model.ChannelType]int64, error)
-	GetMembersForUser(teamID string, userID string) (model.ChannelMembers, error)
+	GetMembersForUser(ctx context.Context, teamID string, userID string) (model.ChannelMembers, error)
 	GetTeamMembersForChannel(channelID string) ([]string, error)
 	GetMembersForUserWithPagination(userID string, page, perPage int) (model.ChannelMembersWithTeamData, error)
 	GetMembersForUserWithCursorPagination(userId string, perPage int, fromChanneID string) (model.ChannelMembersWithTeamData, error)
@@ -338,12 +340,12 @@ type ThreadStore interface {
 	GetThreadMembershipsForExport(postID string) ([]*model.ThreadMembershipForExport, error)
 
 	Get(id string) (*model.Thread, error)
-	GetTotalUnreadThreads(userID, teamID string, opts model.GetUserThreadsOpts) (int64, error)
-	GetTotalThreads(userID, teamID string, opts model.GetUserThreadsOpts) (int64, error)
-	GetTotalUnreadMentions(userID, teamID string, opts model.GetUserThreadsOpts) (int64, error)
-	GetTotalUnreadUrgentMentions(userID, teamID string, opts model.GetUserThreadsOpts) (int64, error)
-	GetThreadsForUser(userID, teamID string, opts model.GetUserThreadsOpts) ([]*model.ThreadResponse, error)
-	GetThreadForUser(threadMembership *model.ThreadMembership, extended, postPriorityIsEnabled bool) (*model.ThreadResponse, error)
+	GetTotalUnreadThreads(rctx request.CTX, userID, teamID string, opts model.GetUserThreadsOpts) (int64, error)
+	GetTotalThreads(rctx request.CTX, userID, teamID string, opts model.GetUserThreadsOpts) (int64, error)
+	GetTotalUnreadMentions(rctx request.CTX, userID, teamID string, opts model.GetUserThreadsOpts) (int64, error)
+	GetTotalUnreadUrgentMentions(rctx request.CTX, userID, teamID string, opts model.GetUserThreadsOpts) (int64, error)
+	GetThreadsForUser(rctx request.CTX, userID, teamID string, opts model.GetUserThreadsOpts) ([]*model.ThreadResponse, error)
+	GetThreadForUser(rctx request.CTX, threadMembership *model.ThreadMembership, extended, postPriorityIsEnabled bool) (*model.ThreadResponse, error)
 	GetTeamsUnreadForUser(userID string, teamIDs []string, includeUrgentMentionCount bool) (map[string]*model.TeamUnread, error)
 
 	MarkAllAsRead(userID string, threadIds []string) error
@@ -377,13 +379,13 @@ type PostStore interface {
 	PermanentDelete(rctx request.CTX, postID string) error
 	PermanentDeleteByUser(rctx request.CTX, userID string) error
 	PermanentDeleteByChannel(rctx request.CTX, channelID string) error
-	GetPosts(options model.GetPostsOptions, allowFromCache bool, sanitizeOptions map[string]bool) (*model.PostList, error)
+	GetPosts(rctx request.CTX, options model.GetPostsOptions, allowFromCache bool, sanitizeOptions map[string]bool) (*model.PostList, error)
 	GetFlaggedPosts(userID string, offset int, limit int) (*model.PostList, error)
 	GetFlaggedPostsForTeam(userID, teamID string, offset int, limit int) (*model.PostList, error)
 	GetFlaggedPostsForChannel(userID, channelID string, offset int, limit int) (*model.PostList, error)
 	GetPostsBefore(options model.GetPostsOptions, sanitizeOptions map[string]bool) (*model.PostList, error)
 	GetPostsAfter(options model.GetPostsOptions, sanitizeOptions map[string]bool) (*model.PostList, error)
-	GetPostsSince(options model.GetPostsSinceOptions, allowFromCache bool, sanitizeOptions map[string]bool) (*model.PostList, error)
+	GetPostsSince(rctx request.CTX, options model.GetPostsSinceOptions, allowFromCache bool, sanitizeOptions map[string]bool) (*model.PostList, error)
 	GetPostsByThread(threadID string, since int64) ([]*model.Post, error)
 	GetPostAfterTime(channelID string, timestamp int64, collapsedThreads bool) (*model.Post, error)
 	GetPostIdAfterTime(channelID string, timestamp int64, collapsedThreads bool) (string, error)
@@ -401,14 +403,18 @@ type PostStore interface {
 	OverwriteMultiple(rctx request.CTX, posts []*model.Post) ([]*model.Post, int, error)
 	GetPostsByIds(postIds []string) ([]*model.Post, error)
 	GetEditHistoryForPost(postID string) ([]*model.Post, error)
+	SaveEditHistory(posts []*model.Post) error
 	GetPostsBatchForIndexing(startTime int64, startPostID string, limit int) ([]*model.PostForIndexing, error)
 	PermanentDeleteBatchForRetentionPolicies(retentionPolicyBatchConfigs model.RetentionPolicyBatchConfigs, cursor model.RetentionPolicyCursor) (int64, model.RetentionPolicyCursor, error)
 	PermanentDeleteBatch(endTime int64, limit int64) (int64, error)
 	GetOldest() (*model.Post, error)
 	GetMaxPostSize() int
-	GetParentsForExportAfter(limit int, afterID string, includeArchivedChannels bool) ([]*model.PostForExport, error)
+	GetParentsForExportAfter(limit int, afterID string, includeArchivedChannels bool, since int64) ([]*model.PostForExport, error)
 	GetRepliesForExport(parentID string) ([]*model.ReplyForExport, error)
-	GetDirectPostParentsForExportAfter(limit int, afterID string, includeArchivedChannels bool) ([]*model.DirectPostForExport, error)
+	GetDirectPostParentsForExportAfter(limit int, afterID string, includeArchivedChannels bool, since int64) ([]*model.DirectPostForExport, error)
+	GetDeletedForExportAfter(limit int, afterID string, since int64) ([]*model.PostForExport, error)
+	GetForUserExportAfter(userID string, limit int, afterID string) ([]*model.PostForExport, error)
+	GetPostsMentioningUsernameAfter(username string, limit int, afterID string) ([]*model.Post, error)
 	SearchPostsForUser(rctx request.CTX, paramsList []*model.SearchParams, userID, teamID string, page, perPage int) (*model.PostSearchResults, error)
 	GetOldestEntityCreationTime() (int64, error)
 	HasAutoResponsePostByUserSince(options model.GetPostsSinceOptions, userID string) (bool, error)
@@ -545,6 +551,19 @@ type AuditStore interface {
 	PermanentDeleteByUser(userID string) error
 }
 
+// AuditRecordStore persists the records of channels/audit so that they can be searched,
+// unlike AuditStore which holds the legacy per-user audits.
+type AuditRecordStore interface {
+	// SaveMultiple saves the valid records. Invalid records are skipped and their errors returned
+	// at the same index as the record, while the error is only set if the valid records couldn't be
+	// saved.
+	SaveMultiple(records []*model.StoredAuditRecord) ([]error, error)
+	Search(opts model.AuditRecordSearchOptions) ([]*model.StoredAuditRecord, error)
+	PermanentDeleteBatch(endTime int64, limit int64) (int64, error)
+	GetCountForUser(userID string) (int64, error)
+	ScrubForUser(userID string) (int64, error)
+}
+
 type ClusterDiscoveryStore interface {
 	Save(discovery *model.ClusterDiscovery) error
 	Delete(discovery *model.ClusterDiscovery) (bool, error)
@@ -712,6 +731,8 @@ type EmojiStore interface {
 	GetByName(c request.CTX, name string, allowFromCache bool) (*model.Emoji, error)
 	GetMultipleByName(c request.CTX, names []string) ([]*model.Emoji, error)
 	GetList(offset, limit int, sort string) ([]*model.Emoji, error)
+	// GetDeletedSince returns the emoji deleted at or after since, oldest deletion first.
+	GetDeletedSince(since int64, offset, limit int) ([]*model.Emoji, error)
 	Delete(emoji *model.Emoji, timestamp int64) error
 	Search(name string, prefixOnly bool, limit int) ([]*model.Emoji, error)
 }
@@ -771,6 +792,7 @@ type ReactionStore interface {
 	Delete(reaction *model.Reaction) (*model.Reaction, error)
 	GetForPost(postID string, allowFromCache bool) ([]*model.Reaction, error)
 	GetForPostSince(postID string, since int64, excludeRemoteID string, inclDeleted bool) ([]*model.Reaction, error)
+	GetForUser(userID string, offset, limit int) ([]*model.Reaction, error)
 	GetUniqueCountForPost(postID string) (int, error)
 	ExistsOnPost(postID string, emojiName string) (bool, error)
 	DeleteAllWithEmojiName(emojiName string) error
@@ -1096,6 +1118,26 @@ type ScheduledPostStore interface {
 	PermanentDeleteByUser(userId string) error
 }
 
+// PartitionableTables are the tables that can be range partitioned by CreateAt.
+var PartitionableTables = []string{"Posts", "Reactions", "FileInfo"}
+
+// PartitionStore manages the monthly CreateAt partitions of the
+// PartitionableTables. Partitioning is only supported on Postgres.
+type PartitionStore interface {
+	// PartitionTable converts an existing table to a partitioned one, moving
+	// its rows to monthly partitions. It locks the table for the whole
+	// conversion and is meant to run while the servers are stopped.
+	PartitionTable(tableName string) error
+	IsPartitioned(tableName string) bool
+	GetPartitions(tableName string) ([]*model.TablePartition, error)
+	// CreatePartitions creates the missing monthly partitions of the
+	// partitioned tables, from the current month up to the month of until.
+	CreatePartitions(until int64) ([]*model.TablePartition, error)
+	// ArchivePartitions detaches the partitions holding rows created before
+	// the given time and moves them to the read-only archive schema.
+	ArchivePartitions(before int64) ([]*model.TablePartition, error)
+}
+
 type PropertyGroupStore interface {
 	Register(name string) (*model.PropertyGroup, error)
 	Get(name string) (*model.PropertyGroup, error)
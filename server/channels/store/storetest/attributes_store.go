@@ -216,6 +216,8 @@ func testAttributesStoreSearchUsers(t *testing.T, rctx request.CTX, ss store.Sto
 		var query string
 		if s.DriverName() == model.DatabaseDriverMysql {
 			query = "Attributes ->> '$." + testPropertyB + "' = ?"
+		} else if s.DriverName() == model.DatabaseDriverSqlite {
+			query = "Attributes ->> '" + testPropertyB + "' = $1"
 		} else {
 			query = "Attributes ->> '" + testPropertyB + "' = $1::text"
 		}
@@ -233,6 +235,8 @@ func testAttributesStoreSearchUsers(t *testing.T, rctx request.CTX, ss store.Sto
 		var query string
 		if s.DriverName() == model.DatabaseDriverMysql {
 			query = "Attributes ->> '$." + testPropertyA + "' = ?"
+		} else if s.DriverName() == model.DatabaseDriverSqlite {
+			query = "Attributes ->> '" + testPropertyA + "' = $1"
 		} else {
 			query = "Attributes ->> '" + testPropertyA + "' = $1::text"
 		}
@@ -255,6 +259,8 @@ func testAttributesStoreSearchUsers(t *testing.T, rctx request.CTX, ss store.Sto
 		var query string
 		if s.DriverName() == model.DatabaseDriverMysql {
 			query = "Attributes ->> '$." + testPropertyA + "' = ?"
+		} else if s.DriverName() == model.DatabaseDriverSqlite {
+			query = "Attributes ->> '" + testPropertyA + "' = $1"
 		} else {
 			query = "Attributes ->> '" + testPropertyA + "' = $1::text"
 		}
@@ -0,0 +1,221 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package storetest
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/public/shared/request"
+	"github.com/mattermost/mattermost/server/v8/channels/store"
+)
+
+func TestAuditRecordStore(t *testing.T, rctx request.CTX, ss store.Store) {
+	t.Run("SaveMultipleAndSearch", func(t *testing.T) { testAuditRecordStoreSaveMultipleAndSearch(t, rctx, ss) })
+	t.Run("PermanentDeleteBatch", func(t *testing.T) { testAuditRecordStorePermanentDeleteBatch(t, rctx, ss) })
+	t.Run("ScrubForUser", func(t *testing.T) { testAuditRecordStoreScrubForUser(t, rctx, ss) })
+}
+
+func newTestStoredAuditRecord(userID, eventName, status string, createAt int64) *model.StoredAuditRecord {
+	rec := model.AuditRecord{
+		EventName: eventName,
+		Status:    status,
+		Actor: model.AuditEventActor{
+			UserId:    userID,
+			SessionId: model.NewId(),
+			IpAddress: "127.0.0.1",
+		},
+		EventData: model.AuditEventData{
+			ObjectType: "user",
+			ResultState: map[string]any{
+				"id": userID,
+			},
+		},
+		Meta: map[string]any{},
+	}
+
+	record := model.NewStoredAuditRecord("audit-api", rec)
+	record.CreateAt = createAt
+	return record
+}
+
+func testAuditRecordStoreSaveMultipleAndSearch(t *testing.T, rctx request.CTX, ss store.Store) {
+	userID := model.NewId()
+	otherUserID := model.NewId()
+
+	records := []*model.StoredAuditRecord{
+		newTestStoredAuditRecord(userID, model.AuditEventLogin, model.AuditStatusSuccess, 1000),
+		newTestStoredAuditRecord(userID, model.AuditEventUpdateUser, model.AuditStatusFail, 2000),
+		newTestStoredAuditRecord(userID, model.AuditEventUpdateUser, model.AuditStatusSuccess, 3000),
+		newTestStoredAuditRecord(otherUserID, model.AuditEventLogin, model.AuditStatusSuccess, 2500),
+	}
+	itemErrs, err := ss.AuditRecord().SaveMultiple(records)
+	require.NoError(t, err)
+	require.Nil(t, itemErrs)
+	itemErrs, err = ss.AuditRecord().SaveMultiple(nil)
+	require.NoError(t, err)
+	require.Nil(t, itemErrs)
+
+	t.Run("by user, newest first", func(t *testing.T) {
+		found, err := ss.AuditRecord().Search(model.AuditRecordSearchOptions{UserId: userID, PerPage: 10})
+		require.NoError(t, err)
+		require.Len(t, found, 3)
+		assert.Equal(t, records[2].Id, found[0].Id)
+		assert.Equal(t, records[1].Id, found[1].Id)
+		assert.Equal(t, records[0].Id, found[2].Id)
+
+		assert.Equal(t, "audit-api", found[0].Level)
+		assert.Equal(t, userID, found[0].ObjectId)
+		require.NotNil(t, found[0].Record)
+		assert.Equal(t, model.AuditEventUpdateUser, found[0].Record.EventName)
+		assert.Equal(t, records[2].SessionId, found[0].Record.Actor.SessionId)
+	})
+
+	t.Run("by event and status", func(t *testing.T) {
+		found, err := ss.AuditRecord().Search(model.AuditRecordSearchOptions{
+			UserId:    userID,
+			EventName: model.AuditEventUpdateUser,
+			Status:    model.AuditStatusFail,
+			PerPage:   10,
+		})
+		require.NoError(t, err)
+		require.Len(t, found, 1)
+		assert.Equal(t, records[1].Id, found[0].Id)
+	})
+
+	t.Run("by object and ip address", func(t *testing.T) {
+		found, err := ss.AuditRecord().Search(model.AuditRecordSearchOptions{ObjectId: otherUserID, IpAddress: "127.0.0.1", PerPage: 10})
+		require.NoError(t, err)
+		require.Len(t, found, 1)
+		assert.Equal(t, records[3].Id, found[0].Id)
+	})
+
+	t.Run("by time range", func(t *testing.T) {
+		found, err := ss.AuditRecord().Search(model.AuditRecordSearchOptions{UserId: userID, Since: 2000, Until: 3000, PerPage: 10})
+		require.NoError(t, err)
+		require.Len(t, found, 1)
+		assert.Equal(t, records[1].Id, found[0].Id)
+	})
+
+	t.Run("paging", func(t *testing.T) {
+		found, err := ss.AuditRecord().Search(model.AuditRecordSearchOptions{UserId: userID, Page: 1, PerPage: 2})
+		require.NoError(t, err)
+		require.Len(t, found, 1)
+		assert.Equal(t, records[0].Id, found[0].Id)
+
+		_, err = ss.AuditRecord().Search(model.AuditRecordSearchOptions{PerPage: model.AuditRecordSearchMaxPerPage + 1})
+		var oobErr *store.ErrOutOfBounds
+		require.ErrorAs(t, err, &oobErr)
+	})
+
+	t.Run("no match", func(t *testing.T) {
+		found, err := ss.AuditRecord().Search(model.AuditRecordSearchOptions{UserId: model.NewId(), PerPage: 10})
+		require.NoError(t, err)
+		assert.Empty(t, found)
+	})
+
+	t.Run("invalid records don't fail the batch", func(t *testing.T) {
+		batchUserID := model.NewId()
+		invalid := newTestStoredAuditRecord(batchUserID, model.AuditEventLogin, model.AuditStatusSuccess, 1000)
+		invalid.Record = nil
+		valid := newTestStoredAuditRecord(batchUserID, model.AuditEventLogin, model.AuditStatusSuccess, 2000)
+
+		itemErrs, err := ss.AuditRecord().SaveMultiple([]*model.StoredAuditRecord{invalid, valid})
+		require.NoError(t, err)
+		require.Len(t, itemErrs, 2)
+		assert.Error(t, itemErrs[0])
+		assert.NoError(t, itemErrs[1])
+
+		found, err := ss.AuditRecord().Search(model.AuditRecordSearchOptions{UserId: batchUserID, PerPage: 10})
+		require.NoError(t, err)
+		require.Len(t, found, 1)
+		assert.Equal(t, valid.Id, found[0].Id)
+
+		itemErrs, err = ss.AuditRecord().SaveMultiple([]*model.StoredAuditRecord{invalid})
+		require.NoError(t, err)
+		require.Len(t, itemErrs, 1)
+		assert.Error(t, itemErrs[0])
+	})
+}
+
+func testAuditRecordStorePermanentDeleteBatch(t *testing.T, rctx request.CTX, ss store.Store) {
+	userID := model.NewId()
+	now := model.GetMillis()
+
+	records := []*model.StoredAuditRecord{
+		newTestStoredAuditRecord(userID, model.AuditEventLogin, model.AuditStatusSuccess, now-3000),
+		newTestStoredAuditRecord(userID, model.AuditEventLogin, model.AuditStatusSuccess, now-2000),
+		newTestStoredAuditRecord(userID, model.AuditEventLogin, model.AuditStatusSuccess, now),
+	}
+	_, err := ss.AuditRecord().SaveMultiple(records)
+	require.NoError(t, err)
+
+	deleted, err := ss.AuditRecord().PermanentDeleteBatch(now-1000, 1000)
+	require.NoError(t, err)
+	assert.GreaterOrEqual(t, deleted, int64(2))
+
+	found, err := ss.AuditRecord().Search(model.AuditRecordSearchOptions{UserId: userID, PerPage: 10})
+	require.NoError(t, err)
+	require.Len(t, found, 1)
+	assert.Equal(t, records[2].Id, found[0].Id)
+}
+
+func testAuditRecordStoreScrubForUser(t *testing.T, rctx request.CTX, ss store.Store) {
+	userID := model.NewId()
+	adminID := model.NewId()
+	now := model.GetMillis()
+
+	ownRecord := newTestStoredAuditRecord(userID, model.AuditEventLogin, model.AuditStatusSuccess, now)
+
+	objectRecord := newTestStoredAuditRecord(adminID, model.AuditEventUpdateUser, model.AuditStatusSuccess, now)
+	objectRecord.ObjectId = userID
+	objectRecord.Record.EventData.ResultState = map[string]any{"id": userID, "email": "user@example.com"}
+
+	mentionRecord := newTestStoredAuditRecord(adminID, model.AuditEventAddChannelMember, model.AuditStatusSuccess, now)
+	mentionRecord.Record.EventData.Parameters = map[string]any{"user_id": userID}
+
+	otherRecord := newTestStoredAuditRecord(adminID, model.AuditEventLogin, model.AuditStatusSuccess, now)
+
+	_, err := ss.AuditRecord().SaveMultiple([]*model.StoredAuditRecord{ownRecord, objectRecord, mentionRecord, otherRecord})
+	require.NoError(t, err)
+
+	count, err := ss.AuditRecord().GetCountForUser(userID)
+	require.NoError(t, err)
+	assert.Equal(t, int64(3), count)
+
+	scrubbed, err := ss.AuditRecord().ScrubForUser(userID)
+	require.NoError(t, err)
+	assert.Equal(t, int64(3), scrubbed)
+
+	found, err := ss.AuditRecord().Search(model.AuditRecordSearchOptions{UserId: userID, PerPage: 10})
+	require.NoError(t, err)
+	require.Len(t, found, 1)
+	assert.Empty(t, found[0].SessionId)
+	assert.Empty(t, found[0].IpAddress)
+	assert.Equal(t, model.AuditEventLogin, found[0].Record.EventName)
+	assert.Equal(t, userID, found[0].Record.Actor.UserId)
+	assert.Empty(t, found[0].Record.Actor.SessionId)
+	assert.Empty(t, found[0].Record.Actor.IpAddress)
+	assert.Nil(t, found[0].Record.EventData.ResultState)
+
+	found, err = ss.AuditRecord().Search(model.AuditRecordSearchOptions{UserId: adminID, PerPage: 10})
+	require.NoError(t, err)
+	require.Len(t, found, 3)
+	for _, record := range found {
+		// The actions of the other users remain accountable.
+		assert.Equal(t, "127.0.0.1", record.IpAddress)
+		assert.NotEmpty(t, record.Record.Actor.SessionId)
+
+		switch record.Id {
+		case objectRecord.Id, mentionRecord.Id:
+			assert.Nil(t, record.Record.EventData.ResultState)
+			assert.Nil(t, record.Record.EventData.Parameters)
+		case otherRecord.Id:
+			assert.Equal(t, adminID, record.Record.EventData.ResultState["id"])
+		}
+	}
+}
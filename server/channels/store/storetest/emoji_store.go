@@ -21,6 +21,7 @@ func TestEmojiStore(t *testing.T, rctx request.CTX, ss store.Store) {
 	t.Run("EmojiGetByName", func(t *testing.T) { testEmojiGetByName(t, rctx, ss) })
 	t.Run("EmojiGetMultipleByName", func(t *testing.T) { testEmojiGetMultipleByName(t, rctx, ss) })
 	t.Run("EmojiGetList", func(t *testing.T) { testEmojiGetList(t, rctx, ss) })
+	t.Run("EmojiGetDeletedSince", func(t *testing.T) { testEmojiGetDeletedSince(t, rctx, ss) })
 	t.Run("EmojiSearch", func(t *testing.T) { testEmojiSearch(t, rctx, ss) })
 }
 
@@ -237,6 +238,34 @@ func testEmojiGetList(t *testing.T, rctx request.CTX, ss store.Store) {
 	assert.Equal(t, emojis[2].Name, remojis[1].Name)
 }
 
+func testEmojiGetDeletedSince(t *testing.T, rctx request.CTX, ss store.Store) {
+	emojis := make([]*model.Emoji, 3)
+	for i := range emojis {
+		emoji, err := ss.Emoji().Save(&model.Emoji{
+			CreatorId: model.NewId(),
+			Name:      "deleted" + model.NewId(),
+		})
+		require.NoError(t, err)
+		emojis[i] = emoji
+	}
+
+	since := model.GetMillis()
+	require.NoError(t, ss.Emoji().Delete(emojis[0], since-1000))
+	require.NoError(t, ss.Emoji().Delete(emojis[2], since+2000))
+	require.NoError(t, ss.Emoji().Delete(emojis[1], since+1000))
+
+	deleted, err := ss.Emoji().GetDeletedSince(since, 0, 100)
+	require.NoError(t, err)
+	require.Len(t, deleted, 2)
+	assert.Equal(t, emojis[1].Id, deleted[0].Id)
+	assert.Equal(t, emojis[2].Id, deleted[1].Id)
+
+	deleted, err = ss.Emoji().GetDeletedSince(since, 1, 100)
+	require.NoError(t, err)
+	require.Len(t, deleted, 1)
+	assert.Equal(t, emojis[2].Id, deleted[0].Id)
+}
+
 func testEmojiSearch(t *testing.T, rctx request.CTX, ss store.Store) {
 	emojis := []model.Emoji{
 		{
@@ -301,4 +330,24 @@ func testEmojiSearch(t *testing.T, rctx request.CTX, ss store.Store) {
 
 		assert.Equal(t, shouldFind[i], found, emoji.Name)
 	}
+
+	// The wildcards of the term are matched literally.
+	wildcard, err := ss.Emoji().Save(&model.Emoji{
+		CreatorId: model.NewId(),
+		Name:      "blarghx" + model.NewId(),
+	})
+	require.NoError(t, err)
+	defer func() {
+		err := ss.Emoji().Delete(wildcard, time.Now().Unix())
+		require.NoError(t, err)
+	}()
+
+	result, err = ss.Emoji().Search("blargh_", true, 100)
+	require.NoError(t, err)
+	var names []string
+	for _, emoji := range result {
+		names = append(names, emoji.Name)
+	}
+	assert.Contains(t, names, emojis[0].Name)
+	assert.NotContains(t, names, wildcard.Name)
 }
@@ -733,6 +733,7 @@ func testGroupStoreUpdate(t *testing.T, rctx request.CTX, ss store.Store) {
 	require.Contains(t, err.Error(), "unique constraint: Name")
 
 	// Cannot update CreateAt
+	time.Sleep(time.Millisecond)
 	someVal := model.GetMillis()
 	d1.CreateAt = someVal
 	d3, err := ss.Group().Update(d1)
@@ -1967,6 +1968,7 @@ func testTeamMembersToAdd(t *testing.T, rctx request.CTX, ss store.Store) {
 	require.Empty(t, teamMembers)
 
 	// Delete and restore GroupMember should return result
+	time.Sleep(2 * time.Millisecond)
 	_, err = ss.Group().DeleteMember(group.Id, user.Id)
 	require.NoError(t, err)
 	_, err = ss.Group().UpsertMember(group.Id, user.Id)
@@ -2232,6 +2234,7 @@ func testChannelMembersToAdd(t *testing.T, rctx request.CTX, ss store.Store) {
 	require.Empty(t, channelMembers)
 
 	// Delete and restore GroupMember should return result
+	time.Sleep(2 * time.Millisecond)
 	_, err = ss.Group().DeleteMember(group.Id, user.Id)
 	require.NoError(t, err)
 	_, err = ss.Group().UpsertMember(group.Id, user.Id)
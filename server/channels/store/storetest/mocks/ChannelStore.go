@@ -136,7 +136,7 @@ func (_m *ChannelStore) Autocomplete(rctx request.CTX, userID string, term strin
 	return r0, r1
 }
 
-// AutocompleteInTeam provides a mock function with given fields: rctx, teamID, userID, term, includeDeleted, isGuest
+// AutocompleteInTeam provides a mock function with given fields: ctx, teamID, userID, term, includeDeleted, isGuest
 func (_m *ChannelStore) AutocompleteInTeam(rctx request.CTX, teamID string, userID string, term string, includeDeleted bool, isGuest bool) (model.ChannelList, error) {
 	ret := _m.Called(rctx, teamID, userID, term, includeDeleted, isGuest)
 
@@ -1670,9 +1670,9 @@ func (_m *ChannelStore) GetMembersByIds(channelID string, userIds []string) (mod
 	return r0, r1
 }
 
-// GetMembersForUser provides a mock function with given fields: teamID, userID
-func (_m *ChannelStore) GetMembersForUser(teamID string, userID string) (model.ChannelMembers, error) {
-	ret := _m.Called(teamID, userID)
+// GetMembersForUser provides a mock function with given fields: ctx, teamID, userID
+func (_m *ChannelStore) GetMembersForUser(ctx context.Context, teamID string, userID string) (model.ChannelMembers, error) {
+	ret := _m.Called(ctx, teamID, userID)
 
 	if len(ret) == 0 {
 		panic("no return value specified for GetMembersForUser")
@@ -1680,19 +1680,19 @@ func (_m *ChannelStore) GetMembersForUser(teamID string, userID string) (model.C
 
 	var r0 model.ChannelMembers
 	var r1 error
-	if rf, ok := ret.Get(0).(func(string, string) (model.ChannelMembers, error)); ok {
-		return rf(teamID, userID)
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) (model.ChannelMembers, error)); ok {
+		return rf(ctx, teamID, userID)
 	}
-	if rf, ok := ret.Get(0).(func(string, string) model.ChannelMembers); ok {
-		r0 = rf(teamID, userID)
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) model.ChannelMembers); ok {
+		r0 = rf(ctx, teamID, userID)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(model.ChannelMembers)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(string, string) error); ok {
-		r1 = rf(teamID, userID)
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, teamID, userID)
 	} else {
 		r1 = ret.Error(1)
 	}
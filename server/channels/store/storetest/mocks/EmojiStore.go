@@ -93,6 +93,36 @@ func (_m *EmojiStore) GetByName(c request.CTX, name string, allowFromCache bool)
 	return r0, r1
 }
 
+// GetDeletedSince provides a mock function with given fields: since, offset, limit
+func (_m *EmojiStore) GetDeletedSince(since int64, offset int, limit int) ([]*model.Emoji, error) {
+	ret := _m.Called(since, offset, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetDeletedSince")
+	}
+
+	var r0 []*model.Emoji
+	var r1 error
+	if rf, ok := ret.Get(0).(func(int64, int, int) ([]*model.Emoji, error)); ok {
+		return rf(since, offset, limit)
+	}
+	if rf, ok := ret.Get(0).(func(int64, int, int) []*model.Emoji); ok {
+		r0 = rf(since, offset, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*model.Emoji)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(int64, int, int) error); ok {
+		r1 = rf(since, offset, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetList provides a mock function with given fields: offset, limit, sort
 func (_m *EmojiStore) GetList(offset int, limit int, sort string) ([]*model.Emoji, error) {
 	ret := _m.Called(offset, limit, sort)
@@ -189,9 +189,39 @@ func (_m *PostStore) Get(ctx context.Context, id string, opts model.GetPostsOpti
 	return r0, r1
 }
 
-// GetDirectPostParentsForExportAfter provides a mock function with given fields: limit, afterID, includeArchivedChannels
-func (_m *PostStore) GetDirectPostParentsForExportAfter(limit int, afterID string, includeArchivedChannels bool) ([]*model.DirectPostForExport, error) {
-	ret := _m.Called(limit, afterID, includeArchivedChannels)
+// GetDeletedForExportAfter provides a mock function with given fields: limit, afterID, since
+func (_m *PostStore) GetDeletedForExportAfter(limit int, afterID string, since int64) ([]*model.PostForExport, error) {
+	ret := _m.Called(limit, afterID, since)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetDeletedForExportAfter")
+	}
+
+	var r0 []*model.PostForExport
+	var r1 error
+	if rf, ok := ret.Get(0).(func(int, string, int64) ([]*model.PostForExport, error)); ok {
+		return rf(limit, afterID, since)
+	}
+	if rf, ok := ret.Get(0).(func(int, string, int64) []*model.PostForExport); ok {
+		r0 = rf(limit, afterID, since)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*model.PostForExport)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(int, string, int64) error); ok {
+		r1 = rf(limit, afterID, since)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetDirectPostParentsForExportAfter provides a mock function with given fields: limit, afterID, includeArchivedChannels, since
+func (_m *PostStore) GetDirectPostParentsForExportAfter(limit int, afterID string, includeArchivedChannels bool, since int64) ([]*model.DirectPostForExport, error) {
+	ret := _m.Called(limit, afterID, includeArchivedChannels, since)
 
 	if len(ret) == 0 {
 		panic("no return value specified for GetDirectPostParentsForExportAfter")
@@ -199,19 +229,19 @@ func (_m *PostStore) GetDirectPostParentsForExportAfter(limit int, afterID strin
 
 	var r0 []*model.DirectPostForExport
 	var r1 error
-	if rf, ok := ret.Get(0).(func(int, string, bool) ([]*model.DirectPostForExport, error)); ok {
-		return rf(limit, afterID, includeArchivedChannels)
+	if rf, ok := ret.Get(0).(func(int, string, bool, int64) ([]*model.DirectPostForExport, error)); ok {
+		return rf(limit, afterID, includeArchivedChannels, since)
 	}
-	if rf, ok := ret.Get(0).(func(int, string, bool) []*model.DirectPostForExport); ok {
-		r0 = rf(limit, afterID, includeArchivedChannels)
+	if rf, ok := ret.Get(0).(func(int, string, bool, int64) []*model.DirectPostForExport); ok {
+		r0 = rf(limit, afterID, includeArchivedChannels, since)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).([]*model.DirectPostForExport)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(int, string, bool) error); ok {
-		r1 = rf(limit, afterID, includeArchivedChannels)
+	if rf, ok := ret.Get(1).(func(int, string, bool, int64) error); ok {
+		r1 = rf(limit, afterID, includeArchivedChannels, since)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -357,6 +387,36 @@ func (_m *PostStore) GetFlaggedPostsForTeam(userID string, teamID string, offset
 	return r0, r1
 }
 
+// GetForUserExportAfter provides a mock function with given fields: userID, limit, afterID
+func (_m *PostStore) GetForUserExportAfter(userID string, limit int, afterID string) ([]*model.PostForExport, error) {
+	ret := _m.Called(userID, limit, afterID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetForUserExportAfter")
+	}
+
+	var r0 []*model.PostForExport
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string, int, string) ([]*model.PostForExport, error)); ok {
+		return rf(userID, limit, afterID)
+	}
+	if rf, ok := ret.Get(0).(func(string, int, string) []*model.PostForExport); ok {
+		r0 = rf(userID, limit, afterID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*model.PostForExport)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string, int, string) error); ok {
+		r1 = rf(userID, limit, afterID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetMaxPostSize provides a mock function with no fields
 func (_m *PostStore) GetMaxPostSize() int {
 	ret := _m.Called()
@@ -461,9 +521,9 @@ func (_m *PostStore) GetOldestEntityCreationTime() (int64, error) {
 	return r0, r1
 }
 
-// GetParentsForExportAfter provides a mock function with given fields: limit, afterID, includeArchivedChannels
-func (_m *PostStore) GetParentsForExportAfter(limit int, afterID string, includeArchivedChannels bool) ([]*model.PostForExport, error) {
-	ret := _m.Called(limit, afterID, includeArchivedChannels)
+// GetParentsForExportAfter provides a mock function with given fields: limit, afterID, includeArchivedChannels, since
+func (_m *PostStore) GetParentsForExportAfter(limit int, afterID string, includeArchivedChannels bool, since int64) ([]*model.PostForExport, error) {
+	ret := _m.Called(limit, afterID, includeArchivedChannels, since)
 
 	if len(ret) == 0 {
 		panic("no return value specified for GetParentsForExportAfter")
@@ -471,19 +531,19 @@ func (_m *PostStore) GetParentsForExportAfter(limit int, afterID string, include
 
 	var r0 []*model.PostForExport
 	var r1 error
-	if rf, ok := ret.Get(0).(func(int, string, bool) ([]*model.PostForExport, error)); ok {
-		return rf(limit, afterID, includeArchivedChannels)
+	if rf, ok := ret.Get(0).(func(int, string, bool, int64) ([]*model.PostForExport, error)); ok {
+		return rf(limit, afterID, includeArchivedChannels, since)
 	}
-	if rf, ok := ret.Get(0).(func(int, string, bool) []*model.PostForExport); ok {
-		r0 = rf(limit, afterID, includeArchivedChannels)
+	if rf, ok := ret.Get(0).(func(int, string, bool, int64) []*model.PostForExport); ok {
+		r0 = rf(limit, afterID, includeArchivedChannels, since)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).([]*model.PostForExport)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(int, string, bool) error); ok {
-		r1 = rf(limit, afterID, includeArchivedChannels)
+	if rf, ok := ret.Get(1).(func(int, string, bool, int64) error); ok {
+		r1 = rf(limit, afterID, includeArchivedChannels, since)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -637,9 +697,9 @@ func (_m *PostStore) GetPostReminders(now int64) ([]*model.PostReminder, error)
 	return r0, r1
 }
 
-// GetPosts provides a mock function with given fields: options, allowFromCache, sanitizeOptions
-func (_m *PostStore) GetPosts(options model.GetPostsOptions, allowFromCache bool, sanitizeOptions map[string]bool) (*model.PostList, error) {
-	ret := _m.Called(options, allowFromCache, sanitizeOptions)
+// GetPosts provides a mock function with given fields: rctx, options, allowFromCache, sanitizeOptions
+func (_m *PostStore) GetPosts(rctx request.CTX, options model.GetPostsOptions, allowFromCache bool, sanitizeOptions map[string]bool) (*model.PostList, error) {
+	ret := _m.Called(rctx, options, allowFromCache, sanitizeOptions)
 
 	if len(ret) == 0 {
 		panic("no return value specified for GetPosts")
@@ -647,19 +707,19 @@ func (_m *PostStore) GetPosts(options model.GetPostsOptions, allowFromCache bool
 
 	var r0 *model.PostList
 	var r1 error
-	if rf, ok := ret.Get(0).(func(model.GetPostsOptions, bool, map[string]bool) (*model.PostList, error)); ok {
-		return rf(options, allowFromCache, sanitizeOptions)
+	if rf, ok := ret.Get(0).(func(request.CTX, model.GetPostsOptions, bool, map[string]bool) (*model.PostList, error)); ok {
+		return rf(rctx, options, allowFromCache, sanitizeOptions)
 	}
-	if rf, ok := ret.Get(0).(func(model.GetPostsOptions, bool, map[string]bool) *model.PostList); ok {
-		r0 = rf(options, allowFromCache, sanitizeOptions)
+	if rf, ok := ret.Get(0).(func(request.CTX, model.GetPostsOptions, bool, map[string]bool) *model.PostList); ok {
+		r0 = rf(rctx, options, allowFromCache, sanitizeOptions)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*model.PostList)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(model.GetPostsOptions, bool, map[string]bool) error); ok {
-		r1 = rf(options, allowFromCache, sanitizeOptions)
+	if rf, ok := ret.Get(1).(func(request.CTX, model.GetPostsOptions, bool, map[string]bool) error); ok {
+		r1 = rf(rctx, options, allowFromCache, sanitizeOptions)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -847,9 +907,39 @@ func (_m *PostStore) GetPostsCreatedAt(channelID string, timestamp int64) ([]*mo
 	return r0, r1
 }
 
-// GetPostsSince provides a mock function with given fields: options, allowFromCache, sanitizeOptions
-func (_m *PostStore) GetPostsSince(options model.GetPostsSinceOptions, allowFromCache bool, sanitizeOptions map[string]bool) (*model.PostList, error) {
-	ret := _m.Called(options, allowFromCache, sanitizeOptions)
+// GetPostsMentioningUsernameAfter provides a mock function with given fields: username, limit, afterID
+func (_m *PostStore) GetPostsMentioningUsernameAfter(username string, limit int, afterID string) ([]*model.Post, error) {
+	ret := _m.Called(username, limit, afterID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetPostsMentioningUsernameAfter")
+	}
+
+	var r0 []*model.Post
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string, int, string) ([]*model.Post, error)); ok {
+		return rf(username, limit, afterID)
+	}
+	if rf, ok := ret.Get(0).(func(string, int, string) []*model.Post); ok {
+		r0 = rf(username, limit, afterID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*model.Post)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string, int, string) error); ok {
+		r1 = rf(username, limit, afterID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetPostsSince provides a mock function with given fields: rctx, options, allowFromCache, sanitizeOptions
+func (_m *PostStore) GetPostsSince(rctx request.CTX, options model.GetPostsSinceOptions, allowFromCache bool, sanitizeOptions map[string]bool) (*model.PostList, error) {
+	ret := _m.Called(rctx, options, allowFromCache, sanitizeOptions)
 
 	if len(ret) == 0 {
 		panic("no return value specified for GetPostsSince")
@@ -857,19 +947,19 @@ func (_m *PostStore) GetPostsSince(options model.GetPostsSinceOptions, allowFrom
 
 	var r0 *model.PostList
 	var r1 error
-	if rf, ok := ret.Get(0).(func(model.GetPostsSinceOptions, bool, map[string]bool) (*model.PostList, error)); ok {
-		return rf(options, allowFromCache, sanitizeOptions)
+	if rf, ok := ret.Get(0).(func(request.CTX, model.GetPostsSinceOptions, bool, map[string]bool) (*model.PostList, error)); ok {
+		return rf(rctx, options, allowFromCache, sanitizeOptions)
 	}
-	if rf, ok := ret.Get(0).(func(model.GetPostsSinceOptions, bool, map[string]bool) *model.PostList); ok {
-		r0 = rf(options, allowFromCache, sanitizeOptions)
+	if rf, ok := ret.Get(0).(func(request.CTX, model.GetPostsSinceOptions, bool, map[string]bool) *model.PostList); ok {
+		r0 = rf(rctx, options, allowFromCache, sanitizeOptions)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*model.PostList)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(model.GetPostsSinceOptions, bool, map[string]bool) error); ok {
-		r1 = rf(options, allowFromCache, sanitizeOptions)
+	if rf, ok := ret.Get(1).(func(request.CTX, model.GetPostsSinceOptions, bool, map[string]bool) error); ok {
+		r1 = rf(rctx, options, allowFromCache, sanitizeOptions)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -1239,6 +1329,24 @@ func (_m *PostStore) Save(rctx request.CTX, post *model.Post) (*model.Post, erro
 	return r0, r1
 }
 
+// SaveEditHistory provides a mock function with given fields: posts
+func (_m *PostStore) SaveEditHistory(posts []*model.Post) error {
+	ret := _m.Called(posts)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SaveEditHistory")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func([]*model.Post) error); ok {
+		r0 = rf(posts)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // SaveMultiple provides a mock function with given fields: rctx, posts
 func (_m *PostStore) SaveMultiple(rctx request.CTX, posts []*model.Post) ([]*model.Post, int, error) {
 	ret := _m.Called(rctx, posts)
@@ -208,6 +208,36 @@ func (_m *ReactionStore) GetForPostSince(postID string, since int64, excludeRemo
 	return r0, r1
 }
 
+// GetForUser provides a mock function with given fields: userID, offset, limit
+func (_m *ReactionStore) GetForUser(userID string, offset int, limit int) ([]*model.Reaction, error) {
+	ret := _m.Called(userID, offset, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetForUser")
+	}
+
+	var r0 []*model.Reaction
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string, int, int) ([]*model.Reaction, error)); ok {
+		return rf(userID, offset, limit)
+	}
+	if rf, ok := ret.Get(0).(func(string, int, int) []*model.Reaction); ok {
+		r0 = rf(userID, offset, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*model.Reaction)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string, int, int) error); ok {
+		r1 = rf(userID, offset, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetSingle provides a mock function with given fields: userID, postID, remoteID, emojiName
 func (_m *ReactionStore) GetSingle(userID string, postID string, remoteID string, emojiName string) (*model.Reaction, error) {
 	ret := _m.Called(userID, postID, remoteID, emojiName)
@@ -84,6 +84,26 @@ func (_m *Store) Audit() store.AuditStore {
 	return r0
 }
 
+// AuditRecord provides a mock function with no fields
+func (_m *Store) AuditRecord() store.AuditRecordStore {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for AuditRecord")
+	}
+
+	var r0 store.AuditRecordStore
+	if rf, ok := ret.Get(0).(func() store.AuditRecordStore); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(store.AuditRecordStore)
+		}
+	}
+
+	return r0
+}
+
 // Bot provides a mock function with no fields
 func (_m *Store) Bot() store.BotStore {
 	ret := _m.Called()
@@ -728,6 +748,26 @@ func (_m *Store) OutgoingOAuthConnection() store.OutgoingOAuthConnectionStore {
 	return r0
 }
 
+// Partition provides a mock function with no fields
+func (_m *Store) Partition() store.PartitionStore {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for Partition")
+	}
+
+	var r0 store.PartitionStore
+	if rf, ok := ret.Get(0).(func() store.PartitionStore); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(store.PartitionStore)
+		}
+	}
+
+	return r0
+}
+
 // Plugin provides a mock function with no fields
 func (_m *Store) Plugin() store.PluginStore {
 	ret := _m.Called()
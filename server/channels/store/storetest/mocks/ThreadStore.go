@@ -6,6 +6,8 @@ package mocks
 
 import (
 	model "github.com/mattermost/mattermost/server/public/model"
+
+	request "github.com/mattermost/mattermost/server/public/shared/request"
 	store "github.com/mattermost/mattermost/server/v8/channels/store"
 	mock "github.com/stretchr/testify/mock"
 )
@@ -229,9 +231,9 @@ func (_m *ThreadStore) GetThreadFollowers(threadID string, fetchOnlyActive bool)
 	return r0, r1
 }
 
-// GetThreadForUser provides a mock function with given fields: threadMembership, extended, postPriorityIsEnabled
-func (_m *ThreadStore) GetThreadForUser(threadMembership *model.ThreadMembership, extended bool, postPriorityIsEnabled bool) (*model.ThreadResponse, error) {
-	ret := _m.Called(threadMembership, extended, postPriorityIsEnabled)
+// GetThreadForUser provides a mock function with given fields: rctx, threadMembership, extended, postPriorityIsEnabled
+func (_m *ThreadStore) GetThreadForUser(rctx request.CTX, threadMembership *model.ThreadMembership, extended bool, postPriorityIsEnabled bool) (*model.ThreadResponse, error) {
+	ret := _m.Called(rctx, threadMembership, extended, postPriorityIsEnabled)
 
 	if len(ret) == 0 {
 		panic("no return value specified for GetThreadForUser")
@@ -239,19 +241,19 @@ func (_m *ThreadStore) GetThreadForUser(threadMembership *model.ThreadMembership
 
 	var r0 *model.ThreadResponse
 	var r1 error
-	if rf, ok := ret.Get(0).(func(*model.ThreadMembership, bool, bool) (*model.ThreadResponse, error)); ok {
-		return rf(threadMembership, extended, postPriorityIsEnabled)
+	if rf, ok := ret.Get(0).(func(request.CTX, *model.ThreadMembership, bool, bool) (*model.ThreadResponse, error)); ok {
+		return rf(rctx, threadMembership, extended, postPriorityIsEnabled)
 	}
-	if rf, ok := ret.Get(0).(func(*model.ThreadMembership, bool, bool) *model.ThreadResponse); ok {
-		r0 = rf(threadMembership, extended, postPriorityIsEnabled)
+	if rf, ok := ret.Get(0).(func(request.CTX, *model.ThreadMembership, bool, bool) *model.ThreadResponse); ok {
+		r0 = rf(rctx, threadMembership, extended, postPriorityIsEnabled)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*model.ThreadResponse)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(*model.ThreadMembership, bool, bool) error); ok {
-		r1 = rf(threadMembership, extended, postPriorityIsEnabled)
+	if rf, ok := ret.Get(1).(func(request.CTX, *model.ThreadMembership, bool, bool) error); ok {
+		r1 = rf(rctx, threadMembership, extended, postPriorityIsEnabled)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -317,9 +319,9 @@ func (_m *ThreadStore) GetThreadUnreadReplyCount(threadMembership *model.ThreadM
 	return r0, r1
 }
 
-// GetThreadsForUser provides a mock function with given fields: userID, teamID, opts
-func (_m *ThreadStore) GetThreadsForUser(userID string, teamID string, opts model.GetUserThreadsOpts) ([]*model.ThreadResponse, error) {
-	ret := _m.Called(userID, teamID, opts)
+// GetThreadsForUser provides a mock function with given fields: rctx, userID, teamID, opts
+func (_m *ThreadStore) GetThreadsForUser(rctx request.CTX, userID string, teamID string, opts model.GetUserThreadsOpts) ([]*model.ThreadResponse, error) {
+	ret := _m.Called(rctx, userID, teamID, opts)
 
 	if len(ret) == 0 {
 		panic("no return value specified for GetThreadsForUser")
@@ -327,19 +329,19 @@ func (_m *ThreadStore) GetThreadsForUser(userID string, teamID string, opts mode
 
 	var r0 []*model.ThreadResponse
 	var r1 error
-	if rf, ok := ret.Get(0).(func(string, string, model.GetUserThreadsOpts) ([]*model.ThreadResponse, error)); ok {
-		return rf(userID, teamID, opts)
+	if rf, ok := ret.Get(0).(func(request.CTX, string, string, model.GetUserThreadsOpts) ([]*model.ThreadResponse, error)); ok {
+		return rf(rctx, userID, teamID, opts)
 	}
-	if rf, ok := ret.Get(0).(func(string, string, model.GetUserThreadsOpts) []*model.ThreadResponse); ok {
-		r0 = rf(userID, teamID, opts)
+	if rf, ok := ret.Get(0).(func(request.CTX, string, string, model.GetUserThreadsOpts) []*model.ThreadResponse); ok {
+		r0 = rf(rctx, userID, teamID, opts)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).([]*model.ThreadResponse)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(string, string, model.GetUserThreadsOpts) error); ok {
-		r1 = rf(userID, teamID, opts)
+	if rf, ok := ret.Get(1).(func(request.CTX, string, string, model.GetUserThreadsOpts) error); ok {
+		r1 = rf(rctx, userID, teamID, opts)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -347,9 +349,9 @@ func (_m *ThreadStore) GetThreadsForUser(userID string, teamID string, opts mode
 	return r0, r1
 }
 
-// GetTotalThreads provides a mock function with given fields: userID, teamID, opts
-func (_m *ThreadStore) GetTotalThreads(userID string, teamID string, opts model.GetUserThreadsOpts) (int64, error) {
-	ret := _m.Called(userID, teamID, opts)
+// GetTotalThreads provides a mock function with given fields: rctx, userID, teamID, opts
+func (_m *ThreadStore) GetTotalThreads(rctx request.CTX, userID string, teamID string, opts model.GetUserThreadsOpts) (int64, error) {
+	ret := _m.Called(rctx, userID, teamID, opts)
 
 	if len(ret) == 0 {
 		panic("no return value specified for GetTotalThreads")
@@ -357,17 +359,17 @@ func (_m *ThreadStore) GetTotalThreads(userID string, teamID string, opts model.
 
 	var r0 int64
 	var r1 error
-	if rf, ok := ret.Get(0).(func(string, string, model.GetUserThreadsOpts) (int64, error)); ok {
-		return rf(userID, teamID, opts)
+	if rf, ok := ret.Get(0).(func(request.CTX, string, string, model.GetUserThreadsOpts) (int64, error)); ok {
+		return rf(rctx, userID, teamID, opts)
 	}
-	if rf, ok := ret.Get(0).(func(string, string, model.GetUserThreadsOpts) int64); ok {
-		r0 = rf(userID, teamID, opts)
+	if rf, ok := ret.Get(0).(func(request.CTX, string, string, model.GetUserThreadsOpts) int64); ok {
+		r0 = rf(rctx, userID, teamID, opts)
 	} else {
 		r0 = ret.Get(0).(int64)
 	}
 
-	if rf, ok := ret.Get(1).(func(string, string, model.GetUserThreadsOpts) error); ok {
-		r1 = rf(userID, teamID, opts)
+	if rf, ok := ret.Get(1).(func(request.CTX, string, string, model.GetUserThreadsOpts) error); ok {
+		r1 = rf(rctx, userID, teamID, opts)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -375,9 +377,9 @@ func (_m *ThreadStore) GetTotalThreads(userID string, teamID string, opts model.
 	return r0, r1
 }
 
-// GetTotalUnreadMentions provides a mock function with given fields: userID, teamID, opts
-func (_m *ThreadStore) GetTotalUnreadMentions(userID string, teamID string, opts model.GetUserThreadsOpts) (int64, error) {
-	ret := _m.Called(userID, teamID, opts)
+// GetTotalUnreadMentions provides a mock function with given fields: rctx, userID, teamID, opts
+func (_m *ThreadStore) GetTotalUnreadMentions(rctx request.CTX, userID string, teamID string, opts model.GetUserThreadsOpts) (int64, error) {
+	ret := _m.Called(rctx, userID, teamID, opts)
 
 	if len(ret) == 0 {
 		panic("no return value specified for GetTotalUnreadMentions")
@@ -385,17 +387,17 @@ func (_m *ThreadStore) GetTotalUnreadMentions(userID string, teamID string, opts
 
 	var r0 int64
 	var r1 error
-	if rf, ok := ret.Get(0).(func(string, string, model.GetUserThreadsOpts) (int64, error)); ok {
-		return rf(userID, teamID, opts)
+	if rf, ok := ret.Get(0).(func(request.CTX, string, string, model.GetUserThreadsOpts) (int64, error)); ok {
+		return rf(rctx, userID, teamID, opts)
 	}
-	if rf, ok := ret.Get(0).(func(string, string, model.GetUserThreadsOpts) int64); ok {
-		r0 = rf(userID, teamID, opts)
+	if rf, ok := ret.Get(0).(func(request.CTX, string, string, model.GetUserThreadsOpts) int64); ok {
+		r0 = rf(rctx, userID, teamID, opts)
 	} else {
 		r0 = ret.Get(0).(int64)
 	}
 
-	if rf, ok := ret.Get(1).(func(string, string, model.GetUserThreadsOpts) error); ok {
-		r1 = rf(userID, teamID, opts)
+	if rf, ok := ret.Get(1).(func(request.CTX, string, string, model.GetUserThreadsOpts) error); ok {
+		r1 = rf(rctx, userID, teamID, opts)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -403,9 +405,9 @@ func (_m *ThreadStore) GetTotalUnreadMentions(userID string, teamID string, opts
 	return r0, r1
 }
 
-// GetTotalUnreadThreads provides a mock function with given fields: userID, teamID, opts
-func (_m *ThreadStore) GetTotalUnreadThreads(userID string, teamID string, opts model.GetUserThreadsOpts) (int64, error) {
-	ret := _m.Called(userID, teamID, opts)
+// GetTotalUnreadThreads provides a mock function with given fields: rctx, userID, teamID, opts
+func (_m *ThreadStore) GetTotalUnreadThreads(rctx request.CTX, userID string, teamID string, opts model.GetUserThreadsOpts) (int64, error) {
+	ret := _m.Called(rctx, userID, teamID, opts)
 
 	if len(ret) == 0 {
 		panic("no return value specified for GetTotalUnreadThreads")
@@ -413,17 +415,17 @@ func (_m *ThreadStore) GetTotalUnreadThreads(userID string, teamID string, opts
 
 	var r0 int64
 	var r1 error
-	if rf, ok := ret.Get(0).(func(string, string, model.GetUserThreadsOpts) (int64, error)); ok {
-		return rf(userID, teamID, opts)
+	if rf, ok := ret.Get(0).(func(request.CTX, string, string, model.GetUserThreadsOpts) (int64, error)); ok {
+		return rf(rctx, userID, teamID, opts)
 	}
-	if rf, ok := ret.Get(0).(func(string, string, model.GetUserThreadsOpts) int64); ok {
-		r0 = rf(userID, teamID, opts)
+	if rf, ok := ret.Get(0).(func(request.CTX, string, string, model.GetUserThreadsOpts) int64); ok {
+		r0 = rf(rctx, userID, teamID, opts)
 	} else {
 		r0 = ret.Get(0).(int64)
 	}
 
-	if rf, ok := ret.Get(1).(func(string, string, model.GetUserThreadsOpts) error); ok {
-		r1 = rf(userID, teamID, opts)
+	if rf, ok := ret.Get(1).(func(request.CTX, string, string, model.GetUserThreadsOpts) error); ok {
+		r1 = rf(rctx, userID, teamID, opts)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -431,9 +433,9 @@ func (_m *ThreadStore) GetTotalUnreadThreads(userID string, teamID string, opts
 	return r0, r1
 }
 
-// GetTotalUnreadUrgentMentions provides a mock function with given fields: userID, teamID, opts
-func (_m *ThreadStore) GetTotalUnreadUrgentMentions(userID string, teamID string, opts model.GetUserThreadsOpts) (int64, error) {
-	ret := _m.Called(userID, teamID, opts)
+// GetTotalUnreadUrgentMentions provides a mock function with given fields: rctx, userID, teamID, opts
+func (_m *ThreadStore) GetTotalUnreadUrgentMentions(rctx request.CTX, userID string, teamID string, opts model.GetUserThreadsOpts) (int64, error) {
+	ret := _m.Called(rctx, userID, teamID, opts)
 
 	if len(ret) == 0 {
 		panic("no return value specified for GetTotalUnreadUrgentMentions")
@@ -441,17 +443,17 @@ func (_m *ThreadStore) GetTotalUnreadUrgentMentions(userID string, teamID string
 
 	var r0 int64
 	var r1 error
-	if rf, ok := ret.Get(0).(func(string, string, model.GetUserThreadsOpts) (int64, error)); ok {
-		return rf(userID, teamID, opts)
+	if rf, ok := ret.Get(0).(func(request.CTX, string, string, model.GetUserThreadsOpts) (int64, error)); ok {
+		return rf(rctx, userID, teamID, opts)
 	}
-	if rf, ok := ret.Get(0).(func(string, string, model.GetUserThreadsOpts) int64); ok {
-		r0 = rf(userID, teamID, opts)
+	if rf, ok := ret.Get(0).(func(request.CTX, string, string, model.GetUserThreadsOpts) int64); ok {
+		r0 = rf(rctx, userID, teamID, opts)
 	} else {
 		r0 = ret.Get(0).(int64)
 	}
 
-	if rf, ok := ret.Get(1).(func(string, string, model.GetUserThreadsOpts) error); ok {
-		r1 = rf(userID, teamID, opts)
+	if rf, ok := ret.Get(1).(func(request.CTX, string, string, model.GetUserThreadsOpts) error); ok {
+		r1 = rf(rctx, userID, teamID, opts)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -5,6 +5,7 @@ package storetest
 
 import (
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 
@@ -316,6 +317,7 @@ func testPostAcknowledgementsStoreBatchSave(t *testing.T, rctx request.CTX, ss s
 		currentPost, err := ss.Post().GetSingle(rctx, post.Id, false)
 		require.NoError(t, err)
 		oldUpdateAt := currentPost.UpdateAt
+		time.Sleep(time.Millisecond)
 
 		// Create a batch of new acknowledgements
 		acks := []*model.PostAcknowledgement{
@@ -389,6 +391,7 @@ func testPostAcknowledgementsStoreBatchDelete(t *testing.T, rctx request.CTX, ss
 		currentPost, err := ss.Post().GetSingle(rctx, post.Id, false)
 		require.NoError(t, err)
 		oldUpdateAt := currentPost.UpdateAt
+		time.Sleep(time.Millisecond)
 
 		// Delete acknowledgements in batch
 		err = ss.PostAcknowledgement().BatchDelete([]*model.PostAcknowledgement{ack1, ack2})
@@ -54,6 +54,7 @@ func TestPostStore(t *testing.T, rctx request.CTX, ss store.Store, s SqlStore) {
 	t.Run("TestGetMaxPostSize", func(t *testing.T) { testGetMaxPostSize(t, rctx, ss) })
 	t.Run("GetParentsForExportAfter", func(t *testing.T) { testPostStoreGetParentsForExportAfter(t, rctx, ss) })
 	t.Run("GetRepliesForExport", func(t *testing.T) { testPostStoreGetRepliesForExport(t, rctx, ss) })
+	t.Run("GetPostsMentioningUsernameAfter", func(t *testing.T) { testPostStoreGetPostsMentioningUsernameAfter(t, rctx, ss) })
 	t.Run("GetDirectPostParentsForExportAfter", func(t *testing.T) { testPostStoreGetDirectPostParentsForExportAfter(t, rctx, ss, s) })
 	t.Run("GetDirectPostParentsForExportAfterDeleted", func(t *testing.T) { testPostStoreGetDirectPostParentsForExportAfterDeleted(t, rctx, ss, s) })
 	t.Run("GetDirectPostParentsForExportAfterBatched", func(t *testing.T) { testPostStoreGetDirectPostParentsForExportAfterBatched(t, rctx, ss, s) })
@@ -67,6 +68,7 @@ func TestPostStore(t *testing.T, rctx request.CTX, ss store.Store, s SqlStore) {
 	t.Run("GetPostReminderMetadata", func(t *testing.T) { testGetPostReminderMetadata(t, rctx, ss, s) })
 	t.Run("GetNthRecentPostTime", func(t *testing.T) { testGetNthRecentPostTime(t, rctx, ss) })
 	t.Run("GetEditHistoryForPost", func(t *testing.T) { testGetEditHistoryForPost(t, rctx, ss) })
+	t.Run("SaveEditHistory", func(t *testing.T) { testSaveEditHistory(t, rctx, ss) })
 }
 
 func testPostStoreSave(t *testing.T, rctx request.CTX, ss store.Store) {
@@ -1413,6 +1415,7 @@ func testPostStoreDelete(t *testing.T, rctx request.CTX, ss store.Store) {
 		require.NoError(t, err)
 
 		beforeDeleteTime := updatedRootPost.UpdateAt
+		time.Sleep(time.Millisecond)
 
 		// Delete the reply previous to last
 		err = ss.Post().Delete(rctx, replyPost2.Id, model.GetMillis(), "")
@@ -1951,7 +1954,7 @@ func testPostStoreGetPostsWithDetails(t *testing.T, rctx request.CTX, ss store.S
 	o5, err = ss.Post().Save(rctx, o5)
 	require.NoError(t, err)
 
-	r1, err := ss.Post().GetPosts(model.GetPostsOptions{ChannelId: o1.ChannelId, Page: 0, PerPage: 4}, false, map[string]bool{})
+	r1, err := ss.Post().GetPosts(rctx, model.GetPostsOptions{ChannelId: o1.ChannelId, Page: 0, PerPage: 4}, false, map[string]bool{})
 	require.NoError(t, err)
 
 	require.Equal(t, r1.Order[0], o5.Id, "invalid order")
@@ -1964,7 +1967,7 @@ func testPostStoreGetPostsWithDetails(t *testing.T, rctx request.CTX, ss store.S
 
 	require.Equal(t, r1.Posts[o1.Id].Message, o1.Message, "Missing parent")
 
-	r2, err := ss.Post().GetPosts(model.GetPostsOptions{ChannelId: o1.ChannelId, Page: 0, PerPage: 4}, false, map[string]bool{})
+	r2, err := ss.Post().GetPosts(rctx, model.GetPostsOptions{ChannelId: o1.ChannelId, Page: 0, PerPage: 4}, false, map[string]bool{})
 	require.NoError(t, err)
 
 	require.Equal(t, r2.Order[0], o5.Id, "invalid order")
@@ -1978,7 +1981,7 @@ func testPostStoreGetPostsWithDetails(t *testing.T, rctx request.CTX, ss store.S
 	require.Equal(t, r2.Posts[o1.Id].Message, o1.Message, "Missing parent")
 
 	// Run once to fill cache
-	_, err = ss.Post().GetPosts(model.GetPostsOptions{ChannelId: o1.ChannelId, Page: 0, PerPage: 30}, false, map[string]bool{})
+	_, err = ss.Post().GetPosts(rctx, model.GetPostsOptions{ChannelId: o1.ChannelId, Page: 0, PerPage: 30}, false, map[string]bool{})
 	require.NoError(t, err)
 
 	o6 := &model.Post{}
@@ -1988,7 +1991,7 @@ func testPostStoreGetPostsWithDetails(t *testing.T, rctx request.CTX, ss store.S
 	_, err = ss.Post().Save(rctx, o6)
 	require.NoError(t, err)
 
-	r3, err := ss.Post().GetPosts(model.GetPostsOptions{ChannelId: o1.ChannelId, Page: 0, PerPage: 30}, false, map[string]bool{})
+	r3, err := ss.Post().GetPosts(rctx, model.GetPostsOptions{ChannelId: o1.ChannelId, Page: 0, PerPage: 30}, false, map[string]bool{})
 	require.NoError(t, err)
 	assert.Equal(t, 7, len(r3.Order))
 }
@@ -2487,7 +2490,7 @@ func testPostStoreGetPostsSince(t *testing.T, rctx request.CTX, ss store.Store)
 		require.NoError(t, err)
 		time.Sleep(time.Millisecond)
 
-		postList, err := ss.Post().GetPostsSince(model.GetPostsSinceOptions{ChannelId: channelID, Time: post3.CreateAt}, false, map[string]bool{})
+		postList, err := ss.Post().GetPostsSince(rctx, model.GetPostsSinceOptions{ChannelId: channelID, Time: post3.CreateAt}, false, map[string]bool{})
 		require.NoError(t, err)
 
 		assert.Equal(t, []string{
@@ -2527,7 +2530,7 @@ func testPostStoreGetPostsSince(t *testing.T, rctx request.CTX, ss store.Store)
 		require.NoError(t, err)
 		time.Sleep(time.Millisecond)
 
-		postList, err := ss.Post().GetPostsSince(model.GetPostsSinceOptions{ChannelId: channelID, Time: post1.CreateAt}, false, map[string]bool{})
+		postList, err := ss.Post().GetPostsSince(rctx, model.GetPostsSinceOptions{ChannelId: channelID, Time: post1.CreateAt}, false, map[string]bool{})
 		assert.NoError(t, err)
 
 		assert.Equal(t, []string{}, postList.Order)
@@ -2558,12 +2561,12 @@ func testPostStoreGetPostsSince(t *testing.T, rctx request.CTX, ss store.Store)
 		time.Sleep(time.Millisecond)
 
 		// Make a request that returns no results
-		postList, err := ss.Post().GetPostsSince(model.GetPostsSinceOptions{ChannelId: channelID, Time: post1.CreateAt}, true, map[string]bool{})
+		postList, err := ss.Post().GetPostsSince(rctx, model.GetPostsSinceOptions{ChannelId: channelID, Time: post1.CreateAt}, true, map[string]bool{})
 		require.NoError(t, err)
 		require.Equal(t, model.NewPostList(), postList)
 
 		// And then ensure that it doesn't cause future requests to also return no results
-		postList, err = ss.Post().GetPostsSince(model.GetPostsSinceOptions{ChannelId: channelID, Time: post1.CreateAt - 1}, true, map[string]bool{})
+		postList, err = ss.Post().GetPostsSince(rctx, model.GetPostsSinceOptions{ChannelId: channelID, Time: post1.CreateAt - 1}, true, map[string]bool{})
 		require.NoError(t, err)
 
 		assert.Equal(t, []string{post1.Id}, postList.Order)
@@ -2636,7 +2639,7 @@ func testPostStoreGetPosts(t *testing.T, rctx request.CTX, ss store.Store) {
 	require.NoError(t, err)
 
 	t.Run("should return the last posts created in a channel", func(t *testing.T) {
-		postList, err := ss.Post().GetPosts(model.GetPostsOptions{ChannelId: channelID, Page: 0, PerPage: 30, SkipFetchThreads: false}, false, map[string]bool{})
+		postList, err := ss.Post().GetPosts(rctx, model.GetPostsOptions{ChannelId: channelID, Page: 0, PerPage: 30, SkipFetchThreads: false}, false, map[string]bool{})
 		assert.NoError(t, err)
 
 		assert.Equal(t, []string{
@@ -2658,7 +2661,7 @@ func testPostStoreGetPosts(t *testing.T, rctx request.CTX, ss store.Store) {
 	})
 
 	t.Run("should return the last posts created in a channel and the threads and the reply count must be 0", func(t *testing.T) {
-		postList, err := ss.Post().GetPosts(model.GetPostsOptions{ChannelId: channelID, Page: 0, PerPage: 2, SkipFetchThreads: false}, false, map[string]bool{})
+		postList, err := ss.Post().GetPosts(rctx, model.GetPostsOptions{ChannelId: channelID, Page: 0, PerPage: 2, SkipFetchThreads: false}, false, map[string]bool{})
 		assert.NoError(t, err)
 
 		assert.Equal(t, []string{
@@ -2678,7 +2681,7 @@ func testPostStoreGetPosts(t *testing.T, rctx request.CTX, ss store.Store) {
 	})
 
 	t.Run("should return the last posts created in a channel without the threads and the reply count must be correct", func(t *testing.T) {
-		postList, err := ss.Post().GetPosts(model.GetPostsOptions{ChannelId: channelID, Page: 0, PerPage: 2, SkipFetchThreads: true}, false, map[string]bool{})
+		postList, err := ss.Post().GetPosts(rctx, model.GetPostsOptions{ChannelId: channelID, Page: 0, PerPage: 2, SkipFetchThreads: true}, false, map[string]bool{})
 		require.NoError(t, err)
 
 		assert.Equal(t, []string{
@@ -2697,7 +2700,7 @@ func testPostStoreGetPosts(t *testing.T, rctx request.CTX, ss store.Store) {
 		err := ss.Post().Delete(rctx, post1.Id, 1, userID)
 		require.NoError(t, err)
 
-		postList, err := ss.Post().GetPosts(model.GetPostsOptions{ChannelId: channelID, Page: 0, PerPage: 30, SkipFetchThreads: false, IncludeDeleted: true}, false, map[string]bool{})
+		postList, err := ss.Post().GetPosts(rctx, model.GetPostsOptions{ChannelId: channelID, Page: 0, PerPage: 30, SkipFetchThreads: false, IncludeDeleted: true}, false, map[string]bool{})
 		require.NoError(t, err)
 
 		assert.Equal(t, []string{
@@ -2722,7 +2725,7 @@ func testPostStoreGetPosts(t *testing.T, rctx request.CTX, ss store.Store) {
 		err := ss.Post().Delete(rctx, post5.Id, 1, userID)
 		require.NoError(t, err)
 
-		postList, err := ss.Post().GetPosts(model.GetPostsOptions{ChannelId: channelID, Page: 0, PerPage: 30, SkipFetchThreads: true, IncludeDeleted: true}, false, map[string]bool{})
+		postList, err := ss.Post().GetPosts(rctx, model.GetPostsOptions{ChannelId: channelID, Page: 0, PerPage: 30, SkipFetchThreads: true, IncludeDeleted: true}, false, map[string]bool{})
 		require.NoError(t, err)
 
 		assert.Equal(t, []string{
@@ -2745,7 +2748,7 @@ func testPostStoreGetPosts(t *testing.T, rctx request.CTX, ss store.Store) {
 		err := ss.Post().Delete(rctx, post6.Id, 1, userID)
 		require.NoError(t, err)
 
-		postList, err := ss.Post().GetPosts(model.GetPostsOptions{ChannelId: channelID, Page: 0, PerPage: 30, SkipFetchThreads: true, IncludeDeleted: false}, false, map[string]bool{})
+		postList, err := ss.Post().GetPosts(rctx, model.GetPostsOptions{ChannelId: channelID, Page: 0, PerPage: 30, SkipFetchThreads: true, IncludeDeleted: false}, false, map[string]bool{})
 		require.NoError(t, err)
 
 		assert.Equal(t, []string{
@@ -3442,7 +3445,7 @@ func testPostStoreGetFlaggedPostsForTeam(t *testing.T, rctx request.CTX, ss stor
 	require.Len(t, r4.Order, 3, "should have 3 posts")
 
 	// Manually truncate Channels table until testlib can handle cleanups
-	s.GetMaster().Exec("TRUNCATE Channels")
+	s.GetMaster().Exec("DELETE FROM Channels")
 }
 
 func testPostStoreGetFlaggedPosts(t *testing.T, rctx request.CTX, ss store.Store) {
@@ -4709,7 +4712,7 @@ func testPostStoreGetParentsForExportAfter(t *testing.T, rctx request.CTX, ss st
 	require.NoError(t, nErr)
 
 	t.Run("without archived channels", func(t *testing.T) {
-		posts, err := ss.Post().GetParentsForExportAfter(10000, strings.Repeat("0", 26), false)
+		posts, err := ss.Post().GetParentsForExportAfter(10000, strings.Repeat("0", 26), false, 0)
 		assert.NoError(t, err)
 
 		found := false
@@ -4732,7 +4735,7 @@ func testPostStoreGetParentsForExportAfter(t *testing.T, rctx request.CTX, ss st
 	})
 
 	t.Run("with archived channels", func(t *testing.T) {
-		posts, err := ss.Post().GetParentsForExportAfter(10000, strings.Repeat("0", 26), true)
+		posts, err := ss.Post().GetParentsForExportAfter(10000, strings.Repeat("0", 26), true, 0)
 		assert.NoError(t, err)
 
 		found := false
@@ -4760,7 +4763,7 @@ func testPostStoreGetParentsForExportAfter(t *testing.T, rctx request.CTX, ss st
 		}))
 		require.NoError(t, err)
 
-		posts, err := ss.Post().GetParentsForExportAfter(10000, strings.Repeat("0", 26), false)
+		posts, err := ss.Post().GetParentsForExportAfter(10000, strings.Repeat("0", 26), false, 0)
 		assert.NoError(t, err)
 
 		for _, p := range posts {
@@ -4770,6 +4773,123 @@ func testPostStoreGetParentsForExportAfter(t *testing.T, rctx request.CTX, ss st
 			}
 		}
 	})
+
+	t.Run("since", func(t *testing.T) {
+		posts, err := ss.Post().GetParentsForExportAfter(10000, strings.Repeat("0", 26), false, p1.UpdateAt+1)
+		assert.NoError(t, err)
+
+		for _, p := range posts {
+			assert.NotEqual(t, p1.Id, p.Id, "posts not updated since should not be returned")
+		}
+	})
+
+	t.Run("deleted", func(t *testing.T) {
+		p3, err := ss.Post().Save(rctx, &model.Post{ChannelId: c1.Id, UserId: u1.Id, Message: NewTestID(), CreateAt: 2000})
+		require.NoError(t, err)
+		deleteAt := model.GetMillis()
+		require.NoError(t, ss.Post().Delete(rctx, p3.Id, deleteAt, u1.Id))
+
+		posts, err := ss.Post().GetDeletedForExportAfter(10000, strings.Repeat("0", 26), deleteAt)
+		require.NoError(t, err)
+
+		found := false
+		for _, p := range posts {
+			assert.NotEqual(t, p1.Id, p.Id, "posts that aren't deleted should not be returned")
+			if p.Id == p3.Id {
+				found = true
+				assert.Equal(t, p3.Message, p.Message)
+				assert.Equal(t, u1.Username, p.Username)
+				assert.Equal(t, t1.Name, p.TeamName)
+				assert.Equal(t, c1.Name, p.ChannelName)
+			}
+		}
+		assert.True(t, found)
+
+		posts, err = ss.Post().GetDeletedForExportAfter(10000, strings.Repeat("0", 26), deleteAt+1)
+		require.NoError(t, err)
+		for _, p := range posts {
+			assert.NotEqual(t, p3.Id, p.Id, "posts deleted before since should not be returned")
+		}
+	})
+
+	t.Run("for user", func(t *testing.T) {
+		u2, err := ss.User().Save(rctx, &model.User{Username: model.NewUsername(), Email: MakeEmail()})
+		require.NoError(t, err)
+
+		dm, err := ss.Channel().CreateDirectChannel(rctx, &u1, u2)
+		require.NoError(t, err)
+
+		root, err := ss.Post().Save(rctx, &model.Post{ChannelId: c1.Id, UserId: u2.Id, Message: NewTestID()})
+		require.NoError(t, err)
+		reply, err := ss.Post().Save(rctx, &model.Post{ChannelId: c1.Id, UserId: u2.Id, RootId: root.Id, Message: NewTestID()})
+		require.NoError(t, err)
+		direct, err := ss.Post().Save(rctx, &model.Post{ChannelId: dm.Id, UserId: u2.Id, Message: NewTestID()})
+		require.NoError(t, err)
+		deleted, err := ss.Post().Save(rctx, &model.Post{ChannelId: c1.Id, UserId: u2.Id, Message: NewTestID()})
+		require.NoError(t, err)
+		require.NoError(t, ss.Post().Delete(rctx, deleted.Id, model.GetMillis(), u2.Id))
+
+		posts, err := ss.Post().GetForUserExportAfter(u2.Id, 10000, strings.Repeat("0", 26))
+		require.NoError(t, err)
+		require.Len(t, posts, 3)
+
+		byId := map[string]*model.PostForExport{}
+		for _, p := range posts {
+			byId[p.Id] = p
+		}
+		require.Contains(t, byId, root.Id)
+		assert.Equal(t, t1.Name, byId[root.Id].TeamName)
+		assert.Equal(t, c1.Name, byId[root.Id].ChannelName)
+		assert.Equal(t, u2.Username, byId[root.Id].Username)
+		require.Contains(t, byId, reply.Id)
+		assert.Equal(t, root.Id, byId[reply.Id].RootId)
+		require.Contains(t, byId, direct.Id)
+		assert.Empty(t, byId[direct.Id].TeamName)
+		assert.Equal(t, dm.Name, byId[direct.Id].ChannelName)
+
+		posts, err = ss.Post().GetForUserExportAfter(u2.Id, 1, strings.Repeat("0", 26))
+		require.NoError(t, err)
+		require.Len(t, posts, 1)
+		posts, err = ss.Post().GetForUserExportAfter(u2.Id, 10000, posts[0].Id)
+		require.NoError(t, err)
+		require.Len(t, posts, 2)
+
+		// The direct channel would otherwise leak into the direct post export tests.
+		require.NoError(t, ss.Channel().PermanentDelete(rctx, dm.Id))
+	})
+}
+
+func testPostStoreGetPostsMentioningUsernameAfter(t *testing.T, rctx request.CTX, ss store.Store) {
+	username := "mention_" + model.NewUsername()
+	channelID := model.NewId()
+
+	mention, err := ss.Post().Save(rctx, &model.Post{ChannelId: channelID, UserId: model.NewId(), Message: "hello @" + username + "!"})
+	require.NoError(t, err)
+	upperMention, err := ss.Post().Save(rctx, &model.Post{ChannelId: channelID, UserId: model.NewId(), Message: "@" + strings.ToUpper(username)})
+	require.NoError(t, err)
+	deleted, err := ss.Post().Save(rctx, &model.Post{ChannelId: channelID, UserId: model.NewId(), Message: "bye @" + username})
+	require.NoError(t, err)
+	require.NoError(t, ss.Post().Delete(rctx, deleted.Id, model.GetMillis(), deleted.UserId))
+	_, err = ss.Post().Save(rctx, &model.Post{ChannelId: channelID, UserId: model.NewId(), Message: "no mention of " + username})
+	require.NoError(t, err)
+	// The underscore of the username isn't a wildcard.
+	_, err = ss.Post().Save(rctx, &model.Post{ChannelId: channelID, UserId: model.NewId(), Message: "@" + strings.Replace(username, "_", "x", 1)})
+	require.NoError(t, err)
+
+	posts, err := ss.Post().GetPostsMentioningUsernameAfter(username, 100, strings.Repeat("0", 26))
+	require.NoError(t, err)
+	ids := []string{}
+	for _, p := range posts {
+		ids = append(ids, p.Id)
+	}
+	assert.ElementsMatch(t, []string{mention.Id, upperMention.Id, deleted.Id}, ids)
+
+	posts, err = ss.Post().GetPostsMentioningUsernameAfter(username, 1, strings.Repeat("0", 26))
+	require.NoError(t, err)
+	require.Len(t, posts, 1)
+	posts, err = ss.Post().GetPostsMentioningUsernameAfter(username, 100, posts[0].Id)
+	require.NoError(t, err)
+	require.Len(t, posts, 2)
 }
 
 func testPostStoreGetRepliesForExport(t *testing.T, rctx request.CTX, ss store.Store) {
@@ -4883,13 +5003,13 @@ func testPostStoreGetDirectPostParentsForExportAfter(t *testing.T, rctx request.
 	p1, nErr = ss.Post().Save(rctx, p1)
 	require.NoError(t, nErr)
 
-	r1, nErr := ss.Post().GetDirectPostParentsForExportAfter(10000, strings.Repeat("0", 26), false)
+	r1, nErr := ss.Post().GetDirectPostParentsForExportAfter(10000, strings.Repeat("0", 26), false, 0)
 	assert.NoError(t, nErr)
 
 	assert.Equal(t, p1.Message, r1[0].Message)
 
 	// Manually truncate Channels table until testlib can handle cleanups
-	s.GetMaster().Exec("TRUNCATE Channels")
+	s.GetMaster().Exec("DELETE FROM Channels")
 }
 
 func testPostStoreGetDirectPostParentsForExportAfterDeleted(t *testing.T, rctx request.CTX, ss store.Store, s SqlStore) {
@@ -4943,16 +5063,16 @@ func testPostStoreGetDirectPostParentsForExportAfterDeleted(t *testing.T, rctx r
 	_, nErr = ss.Post().Save(rctx, p1)
 	require.NoError(t, nErr)
 
-	r1, nErr := ss.Post().GetDirectPostParentsForExportAfter(10000, strings.Repeat("0", 26), false)
+	r1, nErr := ss.Post().GetDirectPostParentsForExportAfter(10000, strings.Repeat("0", 26), false, 0)
 	assert.NoError(t, nErr)
 	assert.Equal(t, 0, len(r1))
 
-	r1, nErr = ss.Post().GetDirectPostParentsForExportAfter(10000, strings.Repeat("0", 26), true)
+	r1, nErr = ss.Post().GetDirectPostParentsForExportAfter(10000, strings.Repeat("0", 26), true, 0)
 	assert.NoError(t, nErr)
 	assert.Equal(t, 1, len(r1))
 
 	// Manually truncate Channels table until testlib can handle cleanups
-	s.GetMaster().Exec("TRUNCATE Channels")
+	s.GetMaster().Exec("DELETE FROM Channels")
 }
 
 func testPostStoreGetDirectPostParentsForExportAfterBatched(t *testing.T, rctx request.CTX, ss store.Store, s SqlStore) {
@@ -5006,7 +5126,7 @@ func testPostStoreGetDirectPostParentsForExportAfterBatched(t *testing.T, rctx r
 	sort.Slice(postIds, func(i, j int) bool { return postIds[i] < postIds[j] })
 
 	// Get all posts
-	r1, err := ss.Post().GetDirectPostParentsForExportAfter(10000, strings.Repeat("0", 26), false)
+	r1, err := ss.Post().GetDirectPostParentsForExportAfter(10000, strings.Repeat("0", 26), false, 0)
 	assert.NoError(t, err)
 	assert.Equal(t, len(postIds), len(r1))
 	var exportedPostIds []string
@@ -5017,7 +5137,7 @@ func testPostStoreGetDirectPostParentsForExportAfterBatched(t *testing.T, rctx r
 	assert.ElementsMatch(t, postIds, exportedPostIds)
 
 	// Get 100
-	r1, err = ss.Post().GetDirectPostParentsForExportAfter(100, strings.Repeat("0", 26), false)
+	r1, err = ss.Post().GetDirectPostParentsForExportAfter(100, strings.Repeat("0", 26), false, 0)
 	assert.NoError(t, err)
 	assert.Equal(t, 100, len(r1))
 	exportedPostIds = []string{}
@@ -5028,7 +5148,7 @@ func testPostStoreGetDirectPostParentsForExportAfterBatched(t *testing.T, rctx r
 	assert.ElementsMatch(t, postIds[:100], exportedPostIds)
 
 	// Manually truncate Channels table until testlib can handle cleanups
-	s.GetMaster().Exec("TRUNCATE Channels")
+	s.GetMaster().Exec("DELETE FROM Channels")
 }
 
 func testHasAutoResponsePostByUserSince(t *testing.T, rctx request.CTX, ss store.Store) {
@@ -5636,6 +5756,40 @@ func testGetEditHistoryForPost(t *testing.T, rctx request.CTX, ss store.Store) {
 	})
 }
 
+func testSaveEditHistory(t *testing.T, rctx request.CTX, ss store.Store) {
+	t.Run("should save edit history for post", func(t *testing.T) {
+		post, err := ss.Post().Save(rctx, &model.Post{
+			ChannelId: model.NewId(),
+			UserId:    model.NewId(),
+			Message:   "test edited",
+			EditAt:    3000,
+		})
+		require.NoError(t, err)
+
+		history := []*model.Post{
+			{OriginalId: post.Id, ChannelId: post.ChannelId, UserId: post.UserId, Message: "test", CreateAt: post.CreateAt, UpdateAt: 2000, DeleteAt: 2000},
+			{OriginalId: post.Id, ChannelId: post.ChannelId, UserId: post.UserId, Message: "test again", CreateAt: post.CreateAt, EditAt: 2000, UpdateAt: 3000, DeleteAt: 3000},
+		}
+		err = ss.Post().SaveEditHistory(history)
+		require.NoError(t, err)
+
+		edits, err := ss.Post().GetEditHistoryForPost(post.Id)
+		require.NoError(t, err)
+		require.Len(t, edits, 2)
+		require.Equal(t, "test again", edits[0].Message)
+		require.Equal(t, "test", edits[1].Message)
+		require.NotEmpty(t, edits[0].Id)
+
+		_, err = ss.Post().GetSingle(rctx, edits[0].Id, false)
+		require.Error(t, err, "edit history should not be returned as a live post")
+	})
+
+	t.Run("should reject entries without an original post", func(t *testing.T) {
+		err := ss.Post().SaveEditHistory([]*model.Post{{ChannelId: model.NewId(), UserId: model.NewId(), Message: "test"}})
+		require.Error(t, err)
+	})
+}
+
 // testGetPostsSinceForSyncExcludeMetadata tests the ExcludeChannelMetadataSystemPosts option
 // in the GetPostsSinceForSync function to verify that database-level filtering works correctly
 func testGetPostsSinceForSyncExcludeMetadata(t *testing.T, rctx request.CTX, ss store.Store, s SqlStore) {
@@ -24,6 +24,7 @@ func TestReactionStore(t *testing.T, rctx request.CTX, ss store.Store, s SqlStor
 	t.Run("ReactionDelete", func(t *testing.T) { testReactionDelete(t, rctx, ss) })
 	t.Run("ReactionGetForPost", func(t *testing.T) { testReactionGetForPost(t, rctx, ss) })
 	t.Run("ReactionGetForPostSince", func(t *testing.T) { testReactionGetForPostSince(t, rctx, ss, s) })
+	t.Run("ReactionGetForUser", func(t *testing.T) { testReactionGetForUser(t, rctx, ss) })
 	t.Run("ReactionDeleteAllWithEmojiName", func(t *testing.T) { testReactionDeleteAllWithEmojiName(t, rctx, ss, s) })
 	t.Run("PermanentDeleteByUser", func(t *testing.T) { testPermanentDeleteByUser(t, rctx, ss) })
 	t.Run("PermanentDeleteBatch", func(t *testing.T) { testReactionStorePermanentDeleteBatch(t, rctx, ss) })
@@ -156,6 +157,7 @@ func testReactionDelete(t *testing.T, rctx request.CTX, ss store.Store) {
 		require.NoError(t, err)
 
 		firstUpdateAt := result.Posts[post.Id].UpdateAt
+		time.Sleep(time.Millisecond)
 
 		_, nErr = ss.Reaction().Delete(reaction)
 		require.NoError(t, nErr)
@@ -307,6 +309,40 @@ func testReactionGetForPost(t *testing.T, rctx request.CTX, ss store.Store) {
 	}
 }
 
+func testReactionGetForUser(t *testing.T, rctx request.CTX, ss store.Store) {
+	userId := model.NewId()
+	otherUserId := model.NewId()
+	post, err := ss.Post().Save(rctx, &model.Post{
+		ChannelId: model.NewId(),
+		UserId:    otherUserId,
+	})
+	require.NoError(t, err)
+
+	reactions := []*model.Reaction{
+		{UserId: userId, PostId: post.Id, EmojiName: "smile", CreateAt: 1000},
+		{UserId: userId, PostId: post.Id, EmojiName: "sad", CreateAt: 2000},
+		{UserId: otherUserId, PostId: post.Id, EmojiName: "smile", CreateAt: 3000},
+		{UserId: userId, PostId: post.Id, EmojiName: "grin", CreateAt: 4000},
+	}
+	for _, reaction := range reactions {
+		_, err = ss.Reaction().Save(reaction)
+		require.NoError(t, err)
+	}
+	_, err = ss.Reaction().Delete(reactions[3])
+	require.NoError(t, err)
+
+	returned, err := ss.Reaction().GetForUser(userId, 0, 10)
+	require.NoError(t, err)
+	require.Len(t, returned, 2, "should only return the reactions of the user that were not deleted")
+	assert.Equal(t, "smile", returned[0].EmojiName)
+	assert.Equal(t, "sad", returned[1].EmojiName)
+
+	returned, err = ss.Reaction().GetForUser(userId, 1, 10)
+	require.NoError(t, err)
+	require.Len(t, returned, 1)
+	assert.Equal(t, "sad", returned[0].EmojiName)
+}
+
 func testReactionGetForPostSince(t *testing.T, rctx request.CTX, ss store.Store, s SqlStore) {
 	now := model.GetMillis()
 	later := now + 1800000 // add 30 minutes
@@ -9,6 +9,7 @@ import (
 	"net/url"
 	"os"
 	"path"
+	"path/filepath"
 	"strings"
 
 	"github.com/go-sql-driver/mysql"
@@ -95,6 +96,17 @@ func PostgreSQLSettings() *model.SqlSettings {
 	return databaseSettings("postgres", dsnURL.String())
 }
 
+// SQLiteSettings returns the database settings to use a SQLite unittesting database.
+// The database is a randomly named file in the temporary directory, created on first use.
+func SQLiteSettings() *model.SqlSettings {
+	dir := os.Getenv("TEST_DATABASE_SQLITE_DIR")
+	if dir == "" {
+		dir = os.TempDir()
+	}
+
+	return databaseSettings("sqlite", filepath.Join(dir, "db"+model.NewId()+".sqlite"))
+}
+
 func mySQLRootDSN(dsn string) string {
 	rootPwd := getEnv("TEST_DATABASE_MYSQL_ROOT_PASSWD", defaultMysqlRootPWD)
 	cfg, err := mysql.ParseDSN(dsn)
@@ -222,6 +234,11 @@ func MakeSqlSettings(driver string, withReplica bool) *model.SqlSettings {
 	case model.DatabaseDriverPostgres:
 		settings = PostgreSQLSettings()
 		dbName = postgreSQLDSNDatabase(*settings.DataSource)
+	case model.DatabaseDriverSqlite:
+		// The database file is created when the store first connects to it.
+		settings = SQLiteSettings()
+		settings.ReplicaMonitorIntervalSeconds = model.NewPointer(5)
+		return settings
 	default:
 		panic("unsupported driver " + driver)
 	}
@@ -257,6 +274,13 @@ func CleanupSqlSettings(settings *model.SqlSettings) {
 		dbName = mySQLDSNDatabase(*settings.DataSource)
 	case model.DatabaseDriverPostgres:
 		dbName = postgreSQLDSNDatabase(*settings.DataSource)
+	case model.DatabaseDriverSqlite:
+		for _, suffix := range []string{"", "-wal", "-shm"} {
+			if err := os.Remove(*settings.DataSource + suffix); err != nil && !os.IsNotExist(err) {
+				panic("failed to remove temporary database " + *settings.DataSource + ": " + err.Error())
+			}
+		}
+		return
 	default:
 		panic("unsupported driver " + driver)
 	}
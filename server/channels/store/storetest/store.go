@@ -25,6 +25,7 @@ type Store struct {
 	RetentionPolicyStore            mocks.RetentionPolicyStore
 	BotStore                        mocks.BotStore
 	AuditStore                      mocks.AuditStore
+	AuditRecordStore                mocks.AuditRecordStore
 	ClusterDiscoveryStore           mocks.ClusterDiscoveryStore
 	RemoteClusterStore              mocks.RemoteClusterStore
 	ComplianceStore                 mocks.ComplianceStore
@@ -71,6 +72,7 @@ type Store struct {
 	PropertyValueStore              mocks.PropertyValueStore
 	AccessControlPolicyStore        mocks.AccessControlPolicyStore
 	AttributesStore                 mocks.AttributesStore
+	PartitionStore                  mocks.PartitionStore
 }
 
 func (s *Store) SetContext(context context.Context)            { s.context = context }
@@ -84,6 +86,7 @@ func (s *Store) RetentionPolicy() store.RetentionPolicyStore   { return &s.Reten
 func (s *Store) Bot() store.BotStore                           { return &s.BotStore }
 func (s *Store) ProductNotices() store.ProductNoticesStore     { return &s.ProductNoticesStore }
 func (s *Store) Audit() store.AuditStore                       { return &s.AuditStore }
+func (s *Store) AuditRecord() store.AuditRecordStore           { return &s.AuditRecordStore }
 func (s *Store) ClusterDiscovery() store.ClusterDiscoveryStore { return &s.ClusterDiscoveryStore }
 func (s *Store) RemoteCluster() store.RemoteClusterStore       { return &s.RemoteClusterStore }
 func (s *Store) Compliance() store.ComplianceStore             { return &s.ComplianceStore }
@@ -162,6 +165,9 @@ func (s *Store) AccessControlPolicy() store.AccessControlPolicyStore {
 func (s *Store) Attributes() store.AttributesStore {
 	return &s.AttributesStore
 }
+func (s *Store) Partition() store.PartitionStore {
+	return &s.PartitionStore
+}
 
 func (s *Store) GetSchemaDefinition() (*model.SupportPacketDatabaseSchema, error) {
 	return &model.SupportPacketDatabaseSchema{
@@ -177,6 +183,7 @@ func (s *Store) AssertExpectations(t mock.TestingT) bool {
 		&s.UserStore,
 		&s.BotStore,
 		&s.AuditStore,
+		&s.AuditRecordStore,
 		&s.ClusterDiscoveryStore,
 		&s.RemoteClusterStore,
 		&s.ComplianceStore,
@@ -213,5 +220,6 @@ func (s *Store) AssertExpectations(t mock.TestingT) bool {
 		&s.ScheduledPostStore,
 		&s.AccessControlPolicyStore,
 		&s.AttributesStore,
+		&s.PartitionStore,
 	)
 }
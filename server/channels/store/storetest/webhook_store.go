@@ -342,6 +342,7 @@ func testWebhookStoreGetOutgoingList(t *testing.T, rctx request.CTX, ss store.St
 	o1.CallbackURLs = []string{"http://nowhere.com/"}
 
 	o1, _ = ss.Webhook().SaveOutgoing(o1)
+	time.Sleep(time.Millisecond)
 
 	o2 := &model.OutgoingWebhook{}
 	o2.ChannelId = model.NewId()
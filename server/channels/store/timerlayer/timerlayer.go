@@ -14,6 +14,7 @@ import (
 	"github.com/mattermost/mattermost/server/public/shared/request"
 	"github.com/mattermost/mattermost/server/v8/channels/store"
 	"github.com/mattermost/mattermost/server/v8/einterfaces"
+	"github.com/mattermost/mattermost/server/v8/platform/services/tracing"
 )
 
 type TimerLayer struct {
@@ -22,6 +23,7 @@ type TimerLayer struct {
 	AccessControlPolicyStore        store.AccessControlPolicyStore
 	AttributesStore                 store.AttributesStore
 	AuditStore                      store.AuditStore
+	AuditRecordStore                store.AuditRecordStore
 	BotStore                        store.BotStore
 	ChannelStore                    store.ChannelStore
 	ChannelBookmarkStore            store.ChannelBookmarkStore
@@ -41,6 +43,7 @@ type TimerLayer struct {
 	NotifyAdminStore                store.NotifyAdminStore
 	OAuthStore                      store.OAuthStore
 	OutgoingOAuthConnectionStore    store.OutgoingOAuthConnectionStore
+	PartitionStore                  store.PartitionStore
 	PluginStore                     store.PluginStore
 	PostStore                       store.PostStore
 	PostAcknowledgementStore        store.PostAcknowledgementStore
@@ -84,6 +87,10 @@ func (s *TimerLayer) Audit() store.AuditStore {
 	return s.AuditStore
 }
 
+func (s *TimerLayer) AuditRecord() store.AuditRecordStore {
+	return s.AuditRecordStore
+}
+
 func (s *TimerLayer) Bot() store.BotStore {
 	return s.BotStore
 }
@@ -160,6 +167,10 @@ func (s *TimerLayer) OutgoingOAuthConnection() store.OutgoingOAuthConnectionStor
 	return s.OutgoingOAuthConnectionStore
 }
 
+func (s *TimerLayer) Partition() store.PartitionStore {
+	return s.PartitionStore
+}
+
 func (s *TimerLayer) Plugin() store.PluginStore {
 	return s.PluginStore
 }
@@ -291,6 +302,11 @@ type TimerLayerAuditStore struct {
 	Root *TimerLayer
 }
 
+type TimerLayerAuditRecordStore struct {
+	store.AuditRecordStore
+	Root *TimerLayer
+}
+
 type TimerLayerBotStore struct {
 	store.BotStore
 	Root *TimerLayer
@@ -386,6 +402,11 @@ type TimerLayerOutgoingOAuthConnectionStore struct {
 	Root *TimerLayer
 }
 
+type TimerLayerPartitionStore struct {
+	store.PartitionStore
+	Root *TimerLayer
+}
+
 type TimerLayerPluginStore struct {
 	store.PluginStore
 	Root *TimerLayer
@@ -532,11 +553,13 @@ type TimerLayerWebhookStore struct {
 }
 
 func (s *TimerLayerAccessControlPolicyStore) Delete(c request.CTX, id string) error {
+	c, span := tracing.StartRequestSpan(c, "store:AccessControlPolicyStore.Delete")
 	start := time.Now()
 
 	err := s.AccessControlPolicyStore.Delete(c, id)
 
 	elapsed := float64(time.Since(start)) / float64(time.Second)
+	tracing.EndSpan(span, err)
 	if s.Root.Metrics != nil {
 		success := "false"
 		if err == nil {
@@ -548,11 +571,13 @@ func (s *TimerLayerAccessControlPolicyStore) Delete(c request.CTX, id string) er
 }
 
 func (s *TimerLayerAccessControlPolicyStore) Get(c request.CTX, id string) (*model.AccessControlPolicy, error) {
+	c, span := tracing.StartRequestSpan(c, "store:AccessControlPolicyStore.Get")
 	start := time.Now()
 
 	result, err := s.AccessControlPolicyStore.Get(c, id)
 
 	elapsed := float64(time.Since(start)) / float64(time.Second)
+	tracing.EndSpan(span, err)
 	if s.Root.Metrics != nil {
 		success := "false"
 		if err == nil {
@@ -564,11 +589,13 @@ func (s *TimerLayerAccessControlPolicyStore) Get(c request.CTX, id string) (*mod
 }
 
 func (s *TimerLayerAccessControlPolicyStore) Save(c request.CTX, policy *model.AccessControlPolicy) (*model.AccessControlPolicy, error) {
+	c, span := tracing.StartRequestSpan(c, "store:AccessControlPolicyStore.Save")
 	start := time.Now()
 
 	result, err := s.AccessControlPolicyStore.Save(c, policy)
 
 	elapsed := float64(time.Since(start)) / float64(time.Second)
+	tracing.EndSpan(span, err)
 	if s.Root.Metrics != nil {
 		success := "false"
 		if err == nil {
@@ -580,11 +607,13 @@ func (s *TimerLayerAccessControlPolicyStore) Save(c request.CTX, policy *model.A
 }
 
 func (s *TimerLayerAccessControlPolicyStore) SearchPolicies(rctx request.CTX, opts model.AccessControlPolicySearch) ([]*model.AccessControlPolicy, int64, error) {
+	rctx, span := tracing.StartRequestSpan(rctx, "store:AccessControlPolicyStore.SearchPolicies")
 	start := time.Now()
 
 	result, resultVar1, err := s.AccessControlPolicyStore.SearchPolicies(rctx, opts)
 
 	elapsed := float64(time.Since(start)) / float64(time.Second)
+	tracing.EndSpan(span, err)
 	if s.Root.Metrics != nil {
 		success := "false"
 		if err == nil {
@@ -596,11 +625,13 @@ func (s *TimerLayerAccessControlPolicyStore) SearchPolicies(rctx request.CTX, op
 }
 
 func (s *TimerLayerAccessControlPolicyStore) SetActiveStatus(c request.CTX, id string, active bool) (*model.AccessControlPolicy, error) {
+	c, span := tracing.StartRequestSpan(c, "store:AccessControlPolicyStore.SetActiveStatus")
 	start := time.Now()
 
 	result, err := s.AccessControlPolicyStore.SetActiveStatus(c, id, active)
 
 	elapsed := float64(time.Since(start)) / float64(time.Second)
+	tracing.EndSpan(span, err)
 	if s.Root.Metrics != nil {
 		success := "false"
 		if err == nil {
@@ -612,11 +643,13 @@ func (s *TimerLayerAccessControlPolicyStore) SetActiveStatus(c request.CTX, id s
 }
 
 func (s *TimerLayerAttributesStore) GetChannelMembersToRemove(rctx request.CTX, channelID string, opts model.SubjectSearchOptions) ([]*model.ChannelMember, error) {
+	rctx, span := tracing.StartRequestSpan(rctx, "store:AttributesStore.GetChannelMembersToRemove")
 	start := time.Now()
 
 	result, err := s.AttributesStore.GetChannelMembersToRemove(rctx, channelID, opts)
 
 	elapsed := float64(time.Since(start)) / float64(time.Second)
+	tracing.EndSpan(span, err)
 	if s.Root.Metrics != nil {
 		success := "false"
 		if err == nil {
@@ -628,11 +661,13 @@ func (s *TimerLayerAttributesStore) GetChannelMembersToRemove(rctx request.CTX,
 }
 
 func (s *TimerLayerAttributesStore) GetSubject(rctx request.CTX, ID string, groupID string) (*model.Subject, error) {
+	rctx, span := tracing.StartRequestSpan(rctx, "store:AttributesStore.GetSubject")
 	start := time.Now()
 
 	result, err := s.AttributesStore.GetSubject(rctx, ID, groupID)
 
 	elapsed := float64(time.Since(start)) / float64(time.Second)
+	tracing.EndSpan(span, err)
 	if s.Root.Metrics != nil {
 		success := "false"
 		if err == nil {
@@ -660,11 +695,13 @@ func (s *TimerLayerAttributesStore) RefreshAttributes() error {
 }
 
 func (s *TimerLayerAttributesStore) SearchUsers(rctx request.CTX, opts model.SubjectSearchOptions) ([]*model.User, int64, error) {
+	rctx, span := tracing.StartRequestSpan(rctx, "store:AttributesStore.SearchUsers")
 	start := time.Now()
 
 	result, resultVar1, err := s.AttributesStore.SearchUsers(rctx, opts)
 
 	elapsed := float64(time.Since(start)) / float64(time.Second)
+	tracing.EndSpan(span, err)
 	if s.Root.Metrics != nil {
 		success := "false"
 		if err == nil {
@@ -723,6 +760,86 @@ func (s *TimerLayerAuditStore) Save(audit *model.Audit) error {
 	return err
 }
 
+func (s *TimerLayerAuditRecordStore) GetCountForUser(userID string) (int64, error) {
+	start := time.Now()
+
+	result, err := s.AuditRecordStore.GetCountForUser(userID)
+
+	elapsed := float64(time.Since(start)) / float64(time.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("AuditRecordStore.GetCountForUser", success, elapsed)
+	}
+	return result, err
+}
+
+func (s *TimerLayerAuditRecordStore) PermanentDeleteBatch(endTime int64, limit int64) (int64, error) {
+	start := time.Now()
+
+	result, err := s.AuditRecordStore.PermanentDeleteBatch(endTime, limit)
+
+	elapsed := float64(time.Since(start)) / float64(time.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("AuditRecordStore.PermanentDeleteBatch", success, elapsed)
+	}
+	return result, err
+}
+
+func (s *TimerLayerAuditRecordStore) SaveMultiple(records []*model.StoredAuditRecord) ([]error, error) {
+	start := time.Now()
+
+	result, err := s.AuditRecordStore.SaveMultiple(records)
+
+	elapsed := float64(time.Since(start)) / float64(time.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("AuditRecordStore.SaveMultiple", success, elapsed)
+	}
+	return result, err
+}
+
+func (s *TimerLayerAuditRecordStore) ScrubForUser(userID string) (int64, error) {
+	start := time.Now()
+
+	result, err := s.AuditRecordStore.ScrubForUser(userID)
+
+	elapsed := float64(time.Since(start)) / float64(time.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("AuditRecordStore.ScrubForUser", success, elapsed)
+	}
+	return result, err
+}
+
+func (s *TimerLayerAuditRecordStore) Search(opts model.AuditRecordSearchOptions) ([]*model.StoredAuditRecord, error) {
+	start := time.Now()
+
+	result, err := s.AuditRecordStore.Search(opts)
+
+	elapsed := float64(time.Since(start)) / float64(time.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("AuditRecordStore.Search", success, elapsed)
+	}
+	return result, err
+}
+
 func (s *TimerLayerBotStore) Get(userID string, includeDeleted bool) (*model.Bot, error) {
 	start := time.Now()
 
@@ -884,11 +1001,13 @@ func (s *TimerLayerChannelStore) AnalyticsTypeCount(teamID string, channelType m
 }
 
 func (s *TimerLayerChannelStore) Autocomplete(rctx request.CTX, userID string, term string, includeDeleted bool, isGuest bool) (model.ChannelListWithTeamData, error) {
+	rctx, span := tracing.StartRequestSpan(rctx, "store:ChannelStore.Autocomplete")
 	start := time.Now()
 
 	result, err := s.ChannelStore.Autocomplete(rctx, userID, term, includeDeleted, isGuest)
 
 	elapsed := float64(time.Since(start)) / float64(time.Second)
+	tracing.EndSpan(span, err)
 	if s.Root.Metrics != nil {
 		success := "false"
 		if err == nil {
@@ -900,11 +1019,13 @@ func (s *TimerLayerChannelStore) Autocomplete(rctx request.CTX, userID string, t
 }
 
 func (s *TimerLayerChannelStore) AutocompleteInTeam(rctx request.CTX, teamID string, userID string, term string, includeDeleted bool, isGuest bool) (model.ChannelList, error) {
+	rctx, span := tracing.StartRequestSpan(rctx, "store:ChannelStore.AutocompleteInTeam")
 	start := time.Now()
 
 	result, err := s.ChannelStore.AutocompleteInTeam(rctx, teamID, userID, term, includeDeleted, isGuest)
 
 	elapsed := float64(time.Since(start)) / float64(time.Second)
+	tracing.EndSpan(span, err)
 	if s.Root.Metrics != nil {
 		success := "false"
 		if err == nil {
@@ -1026,11 +1147,13 @@ func (s *TimerLayerChannelStore) CountUrgentPostsAfter(channelID string, timesta
 }
 
 func (s *TimerLayerChannelStore) CreateDirectChannel(ctx request.CTX, userID *model.User, otherUserID *model.User, channelOptions ...model.ChannelOption) (*model.Channel, error) {
+	ctx, span := tracing.StartRequestSpan(ctx, "store:ChannelStore.CreateDirectChannel")
 	start := time.Now()
 
 	result, err := s.ChannelStore.CreateDirectChannel(ctx, userID, otherUserID, channelOptions...)
 
 	elapsed := float64(time.Since(start)) / float64(time.Second)
+	tracing.EndSpan(span, err)
 	if s.Root.Metrics != nil {
 		success := "false"
 		if err == nil {
@@ -1042,11 +1165,13 @@ func (s *TimerLayerChannelStore) CreateDirectChannel(ctx request.CTX, userID *mo
 }
 
 func (s *TimerLayerChannelStore) CreateInitialSidebarCategories(c request.CTX, userID string, teamID string) (*model.OrderedSidebarCategories, error) {
+	c, span := tracing.StartRequestSpan(c, "store:ChannelStore.CreateInitialSidebarCategories")
 	start := time.Now()
 
 	result, err := s.ChannelStore.CreateInitialSidebarCategories(c, userID, teamID)
 
 	elapsed := float64(time.Since(start)) / float64(time.Second)
+	tracing.EndSpan(span, err)
 	if s.Root.Metrics != nil {
 		success := "false"
 		if err == nil {
@@ -1186,11 +1311,13 @@ func (s *TimerLayerChannelStore) GetAllChannelMemberIdsByChannelId(id string) ([
 }
 
 func (s *TimerLayerChannelStore) GetAllChannelMembersForUser(ctx request.CTX, userID string, allowFromCache bool, includeDeleted bool) (map[string]string, error) {
+	ctx, span := tracing.StartRequestSpan(ctx, "store:ChannelStore.GetAllChannelMembersForUser")
 	start := time.Now()
 
 	result, err := s.ChannelStore.GetAllChannelMembersForUser(ctx, userID, allowFromCache, includeDeleted)
 
 	elapsed := float64(time.Since(start)) / float64(time.Second)
+	tracing.EndSpan(span, err)
 	if s.Root.Metrics != nil {
 		success := "false"
 		if err == nil {
@@ -1522,11 +1649,13 @@ func (s *TimerLayerChannelStore) GetChannelsWithTeamDataByIds(channelIds []strin
 }
 
 func (s *TimerLayerChannelStore) GetChannelsWithUnreadsAndWithMentions(ctx context.Context, channelIDs []string, userID string, userNotifyProps model.StringMap) ([]string, []string, map[string]int64, error) {
+	ctx, span := tracing.StartChildSpan(ctx, "store:ChannelStore.GetChannelsWithUnreadsAndWithMentions")
 	start := time.Now()
 
 	result, resultVar1, resultVar2, err := s.ChannelStore.GetChannelsWithUnreadsAndWithMentions(ctx, channelIDs, userID, userNotifyProps)
 
 	elapsed := float64(time.Since(start)) / float64(time.Second)
+	tracing.EndSpan(span, err)
 	if s.Root.Metrics != nil {
 		success := "false"
 		if err == nil {
@@ -1634,11 +1763,13 @@ func (s *TimerLayerChannelStore) GetMany(ids []string, allowFromCache bool) (mod
 }
 
 func (s *TimerLayerChannelStore) GetMember(ctx context.Context, channelID string, userID string) (*model.ChannelMember, error) {
+	ctx, span := tracing.StartChildSpan(ctx, "store:ChannelStore.GetMember")
 	start := time.Now()
 
 	result, err := s.ChannelStore.GetMember(ctx, channelID, userID)
 
 	elapsed := float64(time.Since(start)) / float64(time.Second)
+	tracing.EndSpan(span, err)
 	if s.Root.Metrics != nil {
 		success := "false"
 		if err == nil {
@@ -1682,11 +1813,13 @@ func (s *TimerLayerChannelStore) GetMemberCountFromCache(channelID string) int64
 }
 
 func (s *TimerLayerChannelStore) GetMemberCountsByGroup(ctx context.Context, channelID string, includeTimezones bool) ([]*model.ChannelMemberCountByGroup, error) {
+	ctx, span := tracing.StartChildSpan(ctx, "store:ChannelStore.GetMemberCountsByGroup")
 	start := time.Now()
 
 	result, err := s.ChannelStore.GetMemberCountsByGroup(ctx, channelID, includeTimezones)
 
 	elapsed := float64(time.Since(start)) / float64(time.Second)
+	tracing.EndSpan(span, err)
 	if s.Root.Metrics != nil {
 		success := "false"
 		if err == nil {
@@ -1714,11 +1847,13 @@ func (s *TimerLayerChannelStore) GetMemberForPost(postID string, userID string,
 }
 
 func (s *TimerLayerChannelStore) GetMemberLastViewedAt(ctx context.Context, channelID string, userID string) (int64, error) {
+	ctx, span := tracing.StartChildSpan(ctx, "store:ChannelStore.GetMemberLastViewedAt")
 	start := time.Now()
 
 	result, err := s.ChannelStore.GetMemberLastViewedAt(ctx, channelID, userID)
 
 	elapsed := float64(time.Since(start)) / float64(time.Second)
+	tracing.EndSpan(span, err)
 	if s.Root.Metrics != nil {
 		success := "false"
 		if err == nil {
@@ -1777,12 +1912,14 @@ func (s *TimerLayerChannelStore) GetMembersByIds(channelID string, userIds []str
 	return result, err
 }
 
-func (s *TimerLayerChannelStore) GetMembersForUser(teamID string, userID string) (model.ChannelMembers, error) {
+func (s *TimerLayerChannelStore) GetMembersForUser(ctx context.Context, teamID string, userID string) (model.ChannelMembers, error) {
+	ctx, span := tracing.StartChildSpan(ctx, "store:ChannelStore.GetMembersForUser")
 	start := time.Now()
 
-	result, err := s.ChannelStore.GetMembersForUser(teamID, userID)
+	result, err := s.ChannelStore.GetMembersForUser(ctx, teamID, userID)
 
 	elapsed := float64(time.Since(start)) / float64(time.Second)
+	tracing.EndSpan(span, err)
 	if s.Root.Metrics != nil {
 		success := "false"
 		if err == nil {
@@ -2251,11 +2388,13 @@ func (s *TimerLayerChannelStore) PatchMultipleMembersNotifyProps(members []*mode
 }
 
 func (s *TimerLayerChannelStore) PermanentDelete(ctx request.CTX, channelID string) error {
+	ctx, span := tracing.StartRequestSpan(ctx, "store:ChannelStore.PermanentDelete")
 	start := time.Now()
 
 	err := s.ChannelStore.PermanentDelete(ctx, channelID)
 
 	elapsed := float64(time.Since(start)) / float64(time.Second)
+	tracing.EndSpan(span, err)
 	if s.Root.Metrics != nil {
 		success := "false"
 		if err == nil {
@@ -2283,11 +2422,13 @@ func (s *TimerLayerChannelStore) PermanentDeleteByTeam(teamID string) error {
 }
 
 func (s *TimerLayerChannelStore) PermanentDeleteMembersByChannel(ctx request.CTX, channelID string) error {
+	ctx, span := tracing.StartRequestSpan(ctx, "store:ChannelStore.PermanentDeleteMembersByChannel")
 	start := time.Now()
 
 	err := s.ChannelStore.PermanentDeleteMembersByChannel(ctx, channelID)
 
 	elapsed := float64(time.Since(start)) / float64(time.Second)
+	tracing.EndSpan(span, err)
 	if s.Root.Metrics != nil {
 		success := "false"
 		if err == nil {
@@ -2299,11 +2440,13 @@ func (s *TimerLayerChannelStore) PermanentDeleteMembersByChannel(ctx request.CTX
 }
 
 func (s *TimerLayerChannelStore) PermanentDeleteMembersByUser(ctx request.CTX, userID string) error {
+	ctx, span := tracing.StartRequestSpan(ctx, "store:ChannelStore.PermanentDeleteMembersByUser")
 	start := time.Now()
 
 	err := s.ChannelStore.PermanentDeleteMembersByUser(ctx, userID)
 
 	elapsed := float64(time.Since(start)) / float64(time.Second)
+	tracing.EndSpan(span, err)
 	if s.Root.Metrics != nil {
 		success := "false"
 		if err == nil {
@@ -2315,11 +2458,13 @@ func (s *TimerLayerChannelStore) PermanentDeleteMembersByUser(ctx request.CTX, u
 }
 
 func (s *TimerLayerChannelStore) RemoveAllDeactivatedMembers(ctx request.CTX, channelID string) error {
+	ctx, span := tracing.StartRequestSpan(ctx, "store:ChannelStore.RemoveAllDeactivatedMembers")
 	start := time.Now()
 
 	err := s.ChannelStore.RemoveAllDeactivatedMembers(ctx, channelID)
 
 	elapsed := float64(time.Since(start)) / float64(time.Second)
+	tracing.EndSpan(span, err)
 	if s.Root.Metrics != nil {
 		success := "false"
 		if err == nil {
@@ -2331,11 +2476,13 @@ func (s *TimerLayerChannelStore) RemoveAllDeactivatedMembers(ctx request.CTX, ch
 }
 
 func (s *TimerLayerChannelStore) RemoveMember(ctx request.CTX, channelID string, userID string) error {
+	ctx, span := tracing.StartRequestSpan(ctx, "store:ChannelStore.RemoveMember")
 	start := time.Now()
 
 	err := s.ChannelStore.RemoveMember(ctx, channelID, userID)
 
 	elapsed := float64(time.Since(start)) / float64(time.Second)
+	tracing.EndSpan(span, err)
 	if s.Root.Metrics != nil {
 		success := "false"
 		if err == nil {
@@ -2347,11 +2494,13 @@ func (s *TimerLayerChannelStore) RemoveMember(ctx request.CTX, channelID string,
 }
 
 func (s *TimerLayerChannelStore) RemoveMembers(ctx request.CTX, channelID string, userIds []string) error {
+	ctx, span := tracing.StartRequestSpan(ctx, "store:ChannelStore.RemoveMembers")
 	start := time.Now()
 
 	err := s.ChannelStore.RemoveMembers(ctx, channelID, userIds)
 
 	elapsed := float64(time.Since(start)) / float64(time.Second)
+	tracing.EndSpan(span, err)
 	if s.Root.Metrics != nil {
 		success := "false"
 		if err == nil {
@@ -2395,11 +2544,13 @@ func (s *TimerLayerChannelStore) Restore(channelID string, timestamp int64) erro
 }
 
 func (s *TimerLayerChannelStore) Save(rctx request.CTX, channel *model.Channel, maxChannelsPerTeam int64, channelOptions ...model.ChannelOption) (*model.Channel, error) {
+	rctx, span := tracing.StartRequestSpan(rctx, "store:ChannelStore.Save")
 	start := time.Now()
 
 	result, err := s.ChannelStore.Save(rctx, channel, maxChannelsPerTeam, channelOptions...)
 
 	elapsed := float64(time.Since(start)) / float64(time.Second)
+	tracing.EndSpan(span, err)
 	if s.Root.Metrics != nil {
 		success := "false"
 		if err == nil {
@@ -2411,11 +2562,13 @@ func (s *TimerLayerChannelStore) Save(rctx request.CTX, channel *model.Channel,
 }
 
 func (s *TimerLayerChannelStore) SaveDirectChannel(ctx request.CTX, channel *model.Channel, member1 *model.ChannelMember, member2 *model.ChannelMember) (*model.Channel, error) {
+	ctx, span := tracing.StartRequestSpan(ctx, "store:ChannelStore.SaveDirectChannel")
 	start := time.Now()
 
 	result, err := s.ChannelStore.SaveDirectChannel(ctx, channel, member1, member2)
 
 	elapsed := float64(time.Since(start)) / float64(time.Second)
+	tracing.EndSpan(span, err)
 	if s.Root.Metrics != nil {
 		success := "false"
 		if err == nil {
@@ -2427,11 +2580,13 @@ func (s *TimerLayerChannelStore) SaveDirectChannel(ctx request.CTX, channel *mod
 }
 
 func (s *TimerLayerChannelStore) SaveMember(rctx request.CTX, member *model.ChannelMember) (*model.ChannelMember, error) {
+	rctx, span := tracing.StartRequestSpan(rctx, "store:ChannelStore.SaveMember")
 	start := time.Now()
 
 	result, err := s.ChannelStore.SaveMember(rctx, member)
 
 	elapsed := float64(time.Since(start)) / float64(time.Second)
+	tracing.EndSpan(span, err)
 	if s.Root.Metrics != nil {
 		success := "false"
 		if err == nil {
@@ -2587,11 +2742,13 @@ func (s *TimerLayerChannelStore) SetShared(channelID string, shared bool) error
 }
 
 func (s *TimerLayerChannelStore) Update(ctx request.CTX, channel *model.Channel) (*model.Channel, error) {
+	ctx, span := tracing.StartRequestSpan(ctx, "store:ChannelStore.Update")
 	start := time.Now()
 
 	result, err := s.ChannelStore.Update(ctx, channel)
 
 	elapsed := float64(time.Since(start)) / float64(time.Second)
+	tracing.EndSpan(span, err)
 	if s.Root.Metrics != nil {
 		success := "false"
 		if err == nil {
@@ -2635,11 +2792,13 @@ func (s *TimerLayerChannelStore) UpdateLastViewedAtPost(unreadPost *model.Post,
 }
 
 func (s *TimerLayerChannelStore) UpdateMember(ctx request.CTX, member *model.ChannelMember) (*model.ChannelMember, error) {
+	ctx, span := tracing.StartRequestSpan(ctx, "store:ChannelStore.UpdateMember")
 	start := time.Now()
 
 	result, err := s.ChannelStore.UpdateMember(ctx, member)
 
 	elapsed := float64(time.Since(start)) / float64(time.Second)
+	tracing.EndSpan(span, err)
 	if s.Root.Metrics != nil {
 		success := "false"
 		if err == nil {
@@ -3370,11 +3529,13 @@ func (s *TimerLayerComplianceStore) GetAll(offset int, limit int) (model.Complia
 }
 
 func (s *TimerLayerComplianceStore) MessageExport(c request.CTX, cursor model.MessageExportCursor, limit int) ([]*model.MessageExport, model.MessageExportCursor, error) {
+	c, span := tracing.StartRequestSpan(c, "store:ComplianceStore.MessageExport")
 	start := time.Now()
 
 	result, resultVar1, err := s.ComplianceStore.MessageExport(c, cursor, limit)
 
 	elapsed := float64(time.Since(start)) / float64(time.Second)
+	tracing.EndSpan(span, err)
 	if s.Root.Metrics != nil {
 		success := "false"
 		if err == nil {
@@ -3658,11 +3819,13 @@ func (s *TimerLayerEmojiStore) Delete(emoji *model.Emoji, timestamp int64) error
 }
 
 func (s *TimerLayerEmojiStore) Get(c request.CTX, id string, allowFromCache bool) (*model.Emoji, error) {
+	c, span := tracing.StartRequestSpan(c, "store:EmojiStore.Get")
 	start := time.Now()
 
 	result, err := s.EmojiStore.Get(c, id, allowFromCache)
 
 	elapsed := float64(time.Since(start)) / float64(time.Second)
+	tracing.EndSpan(span, err)
 	if s.Root.Metrics != nil {
 		success := "false"
 		if err == nil {
@@ -3674,11 +3837,13 @@ func (s *TimerLayerEmojiStore) Get(c request.CTX, id string, allowFromCache bool
 }
 
 func (s *TimerLayerEmojiStore) GetByName(c request.CTX, name string, allowFromCache bool) (*model.Emoji, error) {
+	c, span := tracing.StartRequestSpan(c, "store:EmojiStore.GetByName")
 	start := time.Now()
 
 	result, err := s.EmojiStore.GetByName(c, name, allowFromCache)
 
 	elapsed := float64(time.Since(start)) / float64(time.Second)
+	tracing.EndSpan(span, err)
 	if s.Root.Metrics != nil {
 		success := "false"
 		if err == nil {
@@ -3689,6 +3854,22 @@ func (s *TimerLayerEmojiStore) GetByName(c request.CTX, name string, allowFromCa
 	return result, err
 }
 
+func (s *TimerLayerEmojiStore) GetDeletedSince(since int64, offset int, limit int) ([]*model.Emoji, error) {
+	start := time.Now()
+
+	result, err := s.EmojiStore.GetDeletedSince(since, offset, limit)
+
+	elapsed := float64(time.Since(start)) / float64(time.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("EmojiStore.GetDeletedSince", success, elapsed)
+	}
+	return result, err
+}
+
 func (s *TimerLayerEmojiStore) GetList(offset int, limit int, sort string) ([]*model.Emoji, error) {
 	start := time.Now()
 
@@ -3706,11 +3887,13 @@ func (s *TimerLayerEmojiStore) GetList(offset int, limit int, sort string) ([]*m
 }
 
 func (s *TimerLayerEmojiStore) GetMultipleByName(c request.CTX, names []string) ([]*model.Emoji, error) {
+	c, span := tracing.StartRequestSpan(c, "store:EmojiStore.GetMultipleByName")
 	start := time.Now()
 
 	result, err := s.EmojiStore.GetMultipleByName(c, names)
 
 	elapsed := float64(time.Since(start)) / float64(time.Second)
+	tracing.EndSpan(span, err)
 	if s.Root.Metrics != nil {
 		success := "false"
 		if err == nil {
@@ -3754,11 +3937,13 @@ func (s *TimerLayerEmojiStore) Search(name string, prefixOnly bool, limit int) (
 }
 
 func (s *TimerLayerFileInfoStore) AttachToPost(c request.CTX, fileID string, postID string, channelID string, creatorID string) error {
+	c, span := tracing.StartRequestSpan(c, "store:FileInfoStore.AttachToPost")
 	start := time.Now()
 
 	err := s.FileInfoStore.AttachToPost(c, fileID, postID, channelID, creatorID)
 
 	elapsed := float64(time.Since(start)) / float64(time.Second)
+	tracing.EndSpan(span, err)
 	if s.Root.Metrics != nil {
 		success := "false"
 		if err == nil {
@@ -3801,11 +3986,13 @@ func (s *TimerLayerFileInfoStore) CountAll() (int64, error) {
 }
 
 func (s *TimerLayerFileInfoStore) DeleteForPost(c request.CTX, postID string) (string, error) {
+	c, span := tracing.StartRequestSpan(c, "store:FileInfoStore.DeleteForPost")
 	start := time.Now()
 
 	result, err := s.FileInfoStore.DeleteForPost(c, postID)
 
 	elapsed := float64(time.Since(start)) / float64(time.Second)
+	tracing.EndSpan(span, err)
 	if s.Root.Metrics != nil {
 		success := "false"
 		if err == nil {
@@ -3817,11 +4004,13 @@ func (s *TimerLayerFileInfoStore) DeleteForPost(c request.CTX, postID string) (s
 }
 
 func (s *TimerLayerFileInfoStore) DeleteForPostByIds(rctx request.CTX, postId string, fileIDs []string) error {
+	rctx, span := tracing.StartRequestSpan(rctx, "store:FileInfoStore.DeleteForPostByIds")
 	start := time.Now()
 
 	err := s.FileInfoStore.DeleteForPostByIds(rctx, postId, fileIDs)
 
 	elapsed := float64(time.Since(start)) / float64(time.Second)
+	tracing.EndSpan(span, err)
 	if s.Root.Metrics != nil {
 		success := "false"
 		if err == nil {
@@ -4008,11 +4197,13 @@ func (s *TimerLayerFileInfoStore) InvalidateFileInfosForPostCache(postID string,
 }
 
 func (s *TimerLayerFileInfoStore) PermanentDelete(c request.CTX, fileID string) error {
+	c, span := tracing.StartRequestSpan(c, "store:FileInfoStore.PermanentDelete")
 	start := time.Now()
 
 	err := s.FileInfoStore.PermanentDelete(c, fileID)
 
 	elapsed := float64(time.Since(start)) / float64(time.Second)
+	tracing.EndSpan(span, err)
 	if s.Root.Metrics != nil {
 		success := "false"
 		if err == nil {
@@ -4024,11 +4215,13 @@ func (s *TimerLayerFileInfoStore) PermanentDelete(c request.CTX, fileID string)
 }
 
 func (s *TimerLayerFileInfoStore) PermanentDeleteBatch(ctx request.CTX, endTime int64, limit int64) (int64, error) {
+	ctx, span := tracing.StartRequestSpan(ctx, "store:FileInfoStore.PermanentDeleteBatch")
 	start := time.Now()
 
 	result, err := s.FileInfoStore.PermanentDeleteBatch(ctx, endTime, limit)
 
 	elapsed := float64(time.Since(start)) / float64(time.Second)
+	tracing.EndSpan(span, err)
 	if s.Root.Metrics != nil {
 		success := "false"
 		if err == nil {
@@ -4040,11 +4233,13 @@ func (s *TimerLayerFileInfoStore) PermanentDeleteBatch(ctx request.CTX, endTime
 }
 
 func (s *TimerLayerFileInfoStore) PermanentDeleteByUser(ctx request.CTX, userID string) (int64, error) {
+	ctx, span := tracing.StartRequestSpan(ctx, "store:FileInfoStore.PermanentDeleteByUser")
 	start := time.Now()
 
 	result, err := s.FileInfoStore.PermanentDeleteByUser(ctx, userID)
 
 	elapsed := float64(time.Since(start)) / float64(time.Second)
+	tracing.EndSpan(span, err)
 	if s.Root.Metrics != nil {
 		success := "false"
 		if err == nil {
@@ -4056,11 +4251,13 @@ func (s *TimerLayerFileInfoStore) PermanentDeleteByUser(ctx request.CTX, userID
 }
 
 func (s *TimerLayerFileInfoStore) PermanentDeleteForPost(rctx request.CTX, postID string) error {
+	rctx, span := tracing.StartRequestSpan(rctx, "store:FileInfoStore.PermanentDeleteForPost")
 	start := time.Now()
 
 	err := s.FileInfoStore.PermanentDeleteForPost(rctx, postID)
 
 	elapsed := float64(time.Since(start)) / float64(time.Second)
+	tracing.EndSpan(span, err)
 	if s.Root.Metrics != nil {
 		success := "false"
 		if err == nil {
@@ -4088,11 +4285,13 @@ func (s *TimerLayerFileInfoStore) RefreshFileStats() error {
 }
 
 func (s *TimerLayerFileInfoStore) RestoreForPostByIds(rctx request.CTX, postId string, fileIDs []string) error {
+	rctx, span := tracing.StartRequestSpan(rctx, "store:FileInfoStore.RestoreForPostByIds")
 	start := time.Now()
 
 	err := s.FileInfoStore.RestoreForPostByIds(rctx, postId, fileIDs)
 
 	elapsed := float64(time.Since(start)) / float64(time.Second)
+	tracing.EndSpan(span, err)
 	if s.Root.Metrics != nil {
 		success := "false"
 		if err == nil {
@@ -4104,11 +4303,13 @@ func (s *TimerLayerFileInfoStore) RestoreForPostByIds(rctx request.CTX, postId s
 }
 
 func (s *TimerLayerFileInfoStore) Save(ctx request.CTX, info *model.FileInfo) (*model.FileInfo, error) {
+	ctx, span := tracing.StartRequestSpan(ctx, "store:FileInfoStore.Save")
 	start := time.Now()
 
 	result, err := s.FileInfoStore.Save(ctx, info)
 
 	elapsed := float64(time.Since(start)) / float64(time.Second)
+	tracing.EndSpan(span, err)
 	if s.Root.Metrics != nil {
 		success := "false"
 		if err == nil {
@@ -4120,11 +4321,13 @@ func (s *TimerLayerFileInfoStore) Save(ctx request.CTX, info *model.FileInfo) (*
 }
 
 func (s *TimerLayerFileInfoStore) Search(ctx request.CTX, paramsList []*model.SearchParams, userID string, teamID string, page int, perPage int) (*model.FileInfoList, error) {
+	ctx, span := tracing.StartRequestSpan(ctx, "store:FileInfoStore.Search")
 	start := time.Now()
 
 	result, err := s.FileInfoStore.Search(ctx, paramsList, userID, teamID, page, perPage)
 
 	elapsed := float64(time.Since(start)) / float64(time.Second)
+	tracing.EndSpan(span, err)
 	if s.Root.Metrics != nil {
 		success := "false"
 		if err == nil {
@@ -4136,11 +4339,13 @@ func (s *TimerLayerFileInfoStore) Search(ctx request.CTX, paramsList []*model.Se
 }
 
 func (s *TimerLayerFileInfoStore) SetContent(ctx request.CTX, fileID string, content string) error {
+	ctx, span := tracing.StartRequestSpan(ctx, "store:FileInfoStore.SetContent")
 	start := time.Now()
 
 	err := s.FileInfoStore.SetContent(ctx, fileID, content)
 
 	elapsed := float64(time.Since(start)) / float64(time.Second)
+	tracing.EndSpan(span, err)
 	if s.Root.Metrics != nil {
 		success := "false"
 		if err == nil {
@@ -4152,11 +4357,13 @@ func (s *TimerLayerFileInfoStore) SetContent(ctx request.CTX, fileID string, con
 }
 
 func (s *TimerLayerFileInfoStore) Upsert(rctx request.CTX, info *model.FileInfo) (*model.FileInfo, error) {
+	rctx, span := tracing.StartRequestSpan(rctx, "store:FileInfoStore.Upsert")
 	start := time.Now()
 
 	result, err := s.FileInfoStore.Upsert(rctx, info)
 
 	elapsed := float64(time.Since(start)) / float64(time.Second)
+	tracing.EndSpan(span, err)
 	if s.Root.Metrics != nil {
 		success := "false"
 		if err == nil {
@@ -5064,11 +5271,13 @@ func (s *TimerLayerJobStore) Delete(id string) (string, error) {
 }
 
 func (s *TimerLayerJobStore) Get(c request.CTX, id string) (*model.Job, error) {
+	c, span := tracing.StartRequestSpan(c, "store:JobStore.Get")
 	start := time.Now()
 
 	result, err := s.JobStore.Get(c, id)
 
 	elapsed := float64(time.Since(start)) / float64(time.Second)
+	tracing.EndSpan(span, err)
 	if s.Root.Metrics != nil {
 		success := "false"
 		if err == nil {
@@ -5080,11 +5289,13 @@ func (s *TimerLayerJobStore) Get(c request.CTX, id string) (*model.Job, error) {
 }
 
 func (s *TimerLayerJobStore) GetAllByStatus(c request.CTX, status string) ([]*model.Job, error) {
+	c, span := tracing.StartRequestSpan(c, "store:JobStore.GetAllByStatus")
 	start := time.Now()
 
 	result, err := s.JobStore.GetAllByStatus(c, status)
 
 	elapsed := float64(time.Since(start)) / float64(time.Second)
+	tracing.EndSpan(span, err)
 	if s.Root.Metrics != nil {
 		success := "false"
 		if err == nil {
@@ -5096,11 +5307,13 @@ func (s *TimerLayerJobStore) GetAllByStatus(c request.CTX, status string) ([]*mo
 }
 
 func (s *TimerLayerJobStore) GetAllByType(c request.CTX, jobType string) ([]*model.Job, error) {
+	c, span := tracing.StartRequestSpan(c, "store:JobStore.GetAllByType")
 	start := time.Now()
 
 	result, err := s.JobStore.GetAllByType(c, jobType)
 
 	elapsed := float64(time.Since(start)) / float64(time.Second)
+	tracing.EndSpan(span, err)
 	if s.Root.Metrics != nil {
 		success := "false"
 		if err == nil {
@@ -5112,11 +5325,13 @@ func (s *TimerLayerJobStore) GetAllByType(c request.CTX, jobType string) ([]*mod
 }
 
 func (s *TimerLayerJobStore) GetAllByTypeAndStatus(c request.CTX, jobType string, status string) ([]*model.Job, error) {
+	c, span := tracing.StartRequestSpan(c, "store:JobStore.GetAllByTypeAndStatus")
 	start := time.Now()
 
 	result, err := s.JobStore.GetAllByTypeAndStatus(c, jobType, status)
 
 	elapsed := float64(time.Since(start)) / float64(time.Second)
+	tracing.EndSpan(span, err)
 	if s.Root.Metrics != nil {
 		success := "false"
 		if err == nil {
@@ -5128,11 +5343,13 @@ func (s *TimerLayerJobStore) GetAllByTypeAndStatus(c request.CTX, jobType string
 }
 
 func (s *TimerLayerJobStore) GetAllByTypePage(c request.CTX, jobType string, offset int, limit int) ([]*model.Job, error) {
+	c, span := tracing.StartRequestSpan(c, "store:JobStore.GetAllByTypePage")
 	start := time.Now()
 
 	result, err := s.JobStore.GetAllByTypePage(c, jobType, offset, limit)
 
 	elapsed := float64(time.Since(start)) / float64(time.Second)
+	tracing.EndSpan(span, err)
 	if s.Root.Metrics != nil {
 		success := "false"
 		if err == nil {
@@ -5144,11 +5361,13 @@ func (s *TimerLayerJobStore) GetAllByTypePage(c request.CTX, jobType string, off
 }
 
 func (s *TimerLayerJobStore) GetAllByTypesAndStatusesPage(c request.CTX, jobType []string, status []string, offset int, limit int) ([]*model.Job, error) {
+	c, span := tracing.StartRequestSpan(c, "store:JobStore.GetAllByTypesAndStatusesPage")
 	start := time.Now()
 
 	result, err := s.JobStore.GetAllByTypesAndStatusesPage(c, jobType, status, offset, limit)
 
 	elapsed := float64(time.Since(start)) / float64(time.Second)
+	tracing.EndSpan(span, err)
 	if s.Root.Metrics != nil {
 		success := "false"
 		if err == nil {
@@ -5160,11 +5379,13 @@ func (s *TimerLayerJobStore) GetAllByTypesAndStatusesPage(c request.CTX, jobType
 }
 
 func (s *TimerLayerJobStore) GetAllByTypesPage(c request.CTX, jobTypes []string, offset int, limit int) ([]*model.Job, error) {
+	c, span := tracing.StartRequestSpan(c, "store:JobStore.GetAllByTypesPage")
 	start := time.Now()
 
 	result, err := s.JobStore.GetAllByTypesPage(c, jobTypes, offset, limit)
 
 	elapsed := float64(time.Since(start)) / float64(time.Second)
+	tracing.EndSpan(span, err)
 	if s.Root.Metrics != nil {
 		success := "false"
 		if err == nil {
@@ -5304,11 +5525,13 @@ func (s *TimerLayerJobStore) UpdateStatusOptimistically(id string, currentStatus
 }
 
 func (s *TimerLayerLicenseStore) Get(c request.CTX, id string) (*model.LicenseRecord, error) {
+	c, span := tracing.StartRequestSpan(c, "store:LicenseStore.Get")
 	start := time.Now()
 
 	result, err := s.LicenseStore.Get(c, id)
 
 	elapsed := float64(time.Since(start)) / float64(time.Second)
+	tracing.EndSpan(span, err)
 	if s.Root.Metrics != nil {
 		success := "false"
 		if err == nil {
@@ -5800,11 +6023,13 @@ func (s *TimerLayerOAuthStore) UpdateApp(app *model.OAuthApp) (*model.OAuthApp,
 }
 
 func (s *TimerLayerOutgoingOAuthConnectionStore) DeleteConnection(c request.CTX, id string) error {
+	c, span := tracing.StartRequestSpan(c, "store:OutgoingOAuthConnectionStore.DeleteConnection")
 	start := time.Now()
 
 	err := s.OutgoingOAuthConnectionStore.DeleteConnection(c, id)
 
 	elapsed := float64(time.Since(start)) / float64(time.Second)
+	tracing.EndSpan(span, err)
 	if s.Root.Metrics != nil {
 		success := "false"
 		if err == nil {
@@ -5816,11 +6041,13 @@ func (s *TimerLayerOutgoingOAuthConnectionStore) DeleteConnection(c request.CTX,
 }
 
 func (s *TimerLayerOutgoingOAuthConnectionStore) GetConnection(c request.CTX, id string) (*model.OutgoingOAuthConnection, error) {
+	c, span := tracing.StartRequestSpan(c, "store:OutgoingOAuthConnectionStore.GetConnection")
 	start := time.Now()
 
 	result, err := s.OutgoingOAuthConnectionStore.GetConnection(c, id)
 
 	elapsed := float64(time.Since(start)) / float64(time.Second)
+	tracing.EndSpan(span, err)
 	if s.Root.Metrics != nil {
 		success := "false"
 		if err == nil {
@@ -5832,11 +6059,13 @@ func (s *TimerLayerOutgoingOAuthConnectionStore) GetConnection(c request.CTX, id
 }
 
 func (s *TimerLayerOutgoingOAuthConnectionStore) GetConnections(c request.CTX, filters model.OutgoingOAuthConnectionGetConnectionsFilter) ([]*model.OutgoingOAuthConnection, error) {
+	c, span := tracing.StartRequestSpan(c, "store:OutgoingOAuthConnectionStore.GetConnections")
 	start := time.Now()
 
 	result, err := s.OutgoingOAuthConnectionStore.GetConnections(c, filters)
 
 	elapsed := float64(time.Since(start)) / float64(time.Second)
+	tracing.EndSpan(span, err)
 	if s.Root.Metrics != nil {
 		success := "false"
 		if err == nil {
@@ -5848,11 +6077,13 @@ func (s *TimerLayerOutgoingOAuthConnectionStore) GetConnections(c request.CTX, f
 }
 
 func (s *TimerLayerOutgoingOAuthConnectionStore) SaveConnection(c request.CTX, conn *model.OutgoingOAuthConnection) (*model.OutgoingOAuthConnection, error) {
+	c, span := tracing.StartRequestSpan(c, "store:OutgoingOAuthConnectionStore.SaveConnection")
 	start := time.Now()
 
 	result, err := s.OutgoingOAuthConnectionStore.SaveConnection(c, conn)
 
 	elapsed := float64(time.Since(start)) / float64(time.Second)
+	tracing.EndSpan(span, err)
 	if s.Root.Metrics != nil {
 		success := "false"
 		if err == nil {
@@ -5864,11 +6095,13 @@ func (s *TimerLayerOutgoingOAuthConnectionStore) SaveConnection(c request.CTX, c
 }
 
 func (s *TimerLayerOutgoingOAuthConnectionStore) UpdateConnection(c request.CTX, conn *model.OutgoingOAuthConnection) (*model.OutgoingOAuthConnection, error) {
+	c, span := tracing.StartRequestSpan(c, "store:OutgoingOAuthConnectionStore.UpdateConnection")
 	start := time.Now()
 
 	result, err := s.OutgoingOAuthConnectionStore.UpdateConnection(c, conn)
 
 	elapsed := float64(time.Since(start)) / float64(time.Second)
+	tracing.EndSpan(span, err)
 	if s.Root.Metrics != nil {
 		success := "false"
 		if err == nil {
@@ -5879,6 +6112,86 @@ func (s *TimerLayerOutgoingOAuthConnectionStore) UpdateConnection(c request.CTX,
 	return result, err
 }
 
+func (s *TimerLayerPartitionStore) ArchivePartitions(before int64) ([]*model.TablePartition, error) {
+	start := time.Now()
+
+	result, err := s.PartitionStore.ArchivePartitions(before)
+
+	elapsed := float64(time.Since(start)) / float64(time.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("PartitionStore.ArchivePartitions", success, elapsed)
+	}
+	return result, err
+}
+
+func (s *TimerLayerPartitionStore) CreatePartitions(until int64) ([]*model.TablePartition, error) {
+	start := time.Now()
+
+	result, err := s.PartitionStore.CreatePartitions(until)
+
+	elapsed := float64(time.Since(start)) / float64(time.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("PartitionStore.CreatePartitions", success, elapsed)
+	}
+	return result, err
+}
+
+func (s *TimerLayerPartitionStore) GetPartitions(tableName string) ([]*model.TablePartition, error) {
+	start := time.Now()
+
+	result, err := s.PartitionStore.GetPartitions(tableName)
+
+	elapsed := float64(time.Since(start)) / float64(time.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("PartitionStore.GetPartitions", success, elapsed)
+	}
+	return result, err
+}
+
+func (s *TimerLayerPartitionStore) IsPartitioned(tableName string) bool {
+	start := time.Now()
+
+	result := s.PartitionStore.IsPartitioned(tableName)
+
+	elapsed := float64(time.Since(start)) / float64(time.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if true {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("PartitionStore.IsPartitioned", success, elapsed)
+	}
+	return result
+}
+
+func (s *TimerLayerPartitionStore) PartitionTable(tableName string) error {
+	start := time.Now()
+
+	err := s.PartitionStore.PartitionTable(tableName)
+
+	elapsed := float64(time.Since(start)) / float64(time.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("PartitionStore.PartitionTable", success, elapsed)
+	}
+	return err
+}
+
 func (s *TimerLayerPluginStore) CompareAndDelete(keyVal *model.PluginKeyValue, oldValue []byte) (bool, error) {
 	start := time.Now()
 
@@ -6103,11 +6416,13 @@ func (s *TimerLayerPostStore) ClearCaches() {
 }
 
 func (s *TimerLayerPostStore) Delete(rctx request.CTX, postID string, timestamp int64, deleteByID string) error {
+	rctx, span := tracing.StartRequestSpan(rctx, "store:PostStore.Delete")
 	start := time.Now()
 
 	err := s.PostStore.Delete(rctx, postID, timestamp, deleteByID)
 
 	elapsed := float64(time.Since(start)) / float64(time.Second)
+	tracing.EndSpan(span, err)
 	if s.Root.Metrics != nil {
 		success := "false"
 		if err == nil {
@@ -6119,11 +6434,13 @@ func (s *TimerLayerPostStore) Delete(rctx request.CTX, postID string, timestamp
 }
 
 func (s *TimerLayerPostStore) Get(ctx context.Context, id string, opts model.GetPostsOptions, userID string, sanitizeOptions map[string]bool) (*model.PostList, error) {
+	ctx, span := tracing.StartChildSpan(ctx, "store:PostStore.Get")
 	start := time.Now()
 
 	result, err := s.PostStore.Get(ctx, id, opts, userID, sanitizeOptions)
 
 	elapsed := float64(time.Since(start)) / float64(time.Second)
+	tracing.EndSpan(span, err)
 	if s.Root.Metrics != nil {
 		success := "false"
 		if err == nil {
@@ -6134,10 +6451,26 @@ func (s *TimerLayerPostStore) Get(ctx context.Context, id string, opts model.Get
 	return result, err
 }
 
-func (s *TimerLayerPostStore) GetDirectPostParentsForExportAfter(limit int, afterID string, includeArchivedChannels bool) ([]*model.DirectPostForExport, error) {
+func (s *TimerLayerPostStore) GetDeletedForExportAfter(limit int, afterID string, since int64) ([]*model.PostForExport, error) {
 	start := time.Now()
 
-	result, err := s.PostStore.GetDirectPostParentsForExportAfter(limit, afterID, includeArchivedChannels)
+	result, err := s.PostStore.GetDeletedForExportAfter(limit, afterID, since)
+
+	elapsed := float64(time.Since(start)) / float64(time.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("PostStore.GetDeletedForExportAfter", success, elapsed)
+	}
+	return result, err
+}
+
+func (s *TimerLayerPostStore) GetDirectPostParentsForExportAfter(limit int, afterID string, includeArchivedChannels bool, since int64) ([]*model.DirectPostForExport, error) {
+	start := time.Now()
+
+	result, err := s.PostStore.GetDirectPostParentsForExportAfter(limit, afterID, includeArchivedChannels, since)
 
 	elapsed := float64(time.Since(start)) / float64(time.Second)
 	if s.Root.Metrics != nil {
@@ -6230,6 +6563,22 @@ func (s *TimerLayerPostStore) GetFlaggedPostsForTeam(userID string, teamID strin
 	return result, err
 }
 
+func (s *TimerLayerPostStore) GetForUserExportAfter(userID string, limit int, afterID string) ([]*model.PostForExport, error) {
+	start := time.Now()
+
+	result, err := s.PostStore.GetForUserExportAfter(userID, limit, afterID)
+
+	elapsed := float64(time.Since(start)) / float64(time.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("PostStore.GetForUserExportAfter", success, elapsed)
+	}
+	return result, err
+}
+
 func (s *TimerLayerPostStore) GetMaxPostSize() int {
 	start := time.Now()
 
@@ -6294,10 +6643,10 @@ func (s *TimerLayerPostStore) GetOldestEntityCreationTime() (int64, error) {
 	return result, err
 }
 
-func (s *TimerLayerPostStore) GetParentsForExportAfter(limit int, afterID string, includeArchivedChannels bool) ([]*model.PostForExport, error) {
+func (s *TimerLayerPostStore) GetParentsForExportAfter(limit int, afterID string, includeArchivedChannels bool, since int64) ([]*model.PostForExport, error) {
 	start := time.Now()
 
-	result, err := s.PostStore.GetParentsForExportAfter(limit, afterID, includeArchivedChannels)
+	result, err := s.PostStore.GetParentsForExportAfter(limit, afterID, includeArchivedChannels, since)
 
 	elapsed := float64(time.Since(start)) / float64(time.Second)
 	if s.Root.Metrics != nil {
@@ -6390,12 +6739,14 @@ func (s *TimerLayerPostStore) GetPostReminders(now int64) ([]*model.PostReminder
 	return result, err
 }
 
-func (s *TimerLayerPostStore) GetPosts(options model.GetPostsOptions, allowFromCache bool, sanitizeOptions map[string]bool) (*model.PostList, error) {
+func (s *TimerLayerPostStore) GetPosts(rctx request.CTX, options model.GetPostsOptions, allowFromCache bool, sanitizeOptions map[string]bool) (*model.PostList, error) {
+	rctx, span := tracing.StartRequestSpan(rctx, "store:PostStore.GetPosts")
 	start := time.Now()
 
-	result, err := s.PostStore.GetPosts(options, allowFromCache, sanitizeOptions)
+	result, err := s.PostStore.GetPosts(rctx, options, allowFromCache, sanitizeOptions)
 
 	elapsed := float64(time.Since(start)) / float64(time.Second)
+	tracing.EndSpan(span, err)
 	if s.Root.Metrics != nil {
 		success := "false"
 		if err == nil {
@@ -6502,12 +6853,30 @@ func (s *TimerLayerPostStore) GetPostsCreatedAt(channelID string, timestamp int6
 	return result, err
 }
 
-func (s *TimerLayerPostStore) GetPostsSince(options model.GetPostsSinceOptions, allowFromCache bool, sanitizeOptions map[string]bool) (*model.PostList, error) {
+func (s *TimerLayerPostStore) GetPostsMentioningUsernameAfter(username string, limit int, afterID string) ([]*model.Post, error) {
+	start := time.Now()
+
+	result, err := s.PostStore.GetPostsMentioningUsernameAfter(username, limit, afterID)
+
+	elapsed := float64(time.Since(start)) / float64(time.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("PostStore.GetPostsMentioningUsernameAfter", success, elapsed)
+	}
+	return result, err
+}
+
+func (s *TimerLayerPostStore) GetPostsSince(rctx request.CTX, options model.GetPostsSinceOptions, allowFromCache bool, sanitizeOptions map[string]bool) (*model.PostList, error) {
+	rctx, span := tracing.StartRequestSpan(rctx, "store:PostStore.GetPostsSince")
 	start := time.Now()
 
-	result, err := s.PostStore.GetPostsSince(options, allowFromCache, sanitizeOptions)
+	result, err := s.PostStore.GetPostsSince(rctx, options, allowFromCache, sanitizeOptions)
 
 	elapsed := float64(time.Since(start)) / float64(time.Second)
+	tracing.EndSpan(span, err)
 	if s.Root.Metrics != nil {
 		success := "false"
 		if err == nil {
@@ -6551,11 +6920,13 @@ func (s *TimerLayerPostStore) GetRepliesForExport(parentID string) ([]*model.Rep
 }
 
 func (s *TimerLayerPostStore) GetSingle(rctx request.CTX, id string, inclDeleted bool) (*model.Post, error) {
+	rctx, span := tracing.StartRequestSpan(rctx, "store:PostStore.GetSingle")
 	start := time.Now()
 
 	result, err := s.PostStore.GetSingle(rctx, id, inclDeleted)
 
 	elapsed := float64(time.Since(start)) / float64(time.Second)
+	tracing.EndSpan(span, err)
 	if s.Root.Metrics != nil {
 		success := "false"
 		if err == nil {
@@ -6598,11 +6969,13 @@ func (s *TimerLayerPostStore) InvalidateLastPostTimeCache(channelID string) {
 }
 
 func (s *TimerLayerPostStore) Overwrite(rctx request.CTX, post *model.Post) (*model.Post, error) {
+	rctx, span := tracing.StartRequestSpan(rctx, "store:PostStore.Overwrite")
 	start := time.Now()
 
 	result, err := s.PostStore.Overwrite(rctx, post)
 
 	elapsed := float64(time.Since(start)) / float64(time.Second)
+	tracing.EndSpan(span, err)
 	if s.Root.Metrics != nil {
 		success := "false"
 		if err == nil {
@@ -6614,11 +6987,13 @@ func (s *TimerLayerPostStore) Overwrite(rctx request.CTX, post *model.Post) (*mo
 }
 
 func (s *TimerLayerPostStore) OverwriteMultiple(rctx request.CTX, posts []*model.Post) ([]*model.Post, int, error) {
+	rctx, span := tracing.StartRequestSpan(rctx, "store:PostStore.OverwriteMultiple")
 	start := time.Now()
 
 	result, resultVar1, err := s.PostStore.OverwriteMultiple(rctx, posts)
 
 	elapsed := float64(time.Since(start)) / float64(time.Second)
+	tracing.EndSpan(span, err)
 	if s.Root.Metrics != nil {
 		success := "false"
 		if err == nil {
@@ -6630,11 +7005,13 @@ func (s *TimerLayerPostStore) OverwriteMultiple(rctx request.CTX, posts []*model
 }
 
 func (s *TimerLayerPostStore) PermanentDelete(rctx request.CTX, postID string) error {
+	rctx, span := tracing.StartRequestSpan(rctx, "store:PostStore.PermanentDelete")
 	start := time.Now()
 
 	err := s.PostStore.PermanentDelete(rctx, postID)
 
 	elapsed := float64(time.Since(start)) / float64(time.Second)
+	tracing.EndSpan(span, err)
 	if s.Root.Metrics != nil {
 		success := "false"
 		if err == nil {
@@ -6678,11 +7055,13 @@ func (s *TimerLayerPostStore) PermanentDeleteBatchForRetentionPolicies(retention
 }
 
 func (s *TimerLayerPostStore) PermanentDeleteByChannel(rctx request.CTX, channelID string) error {
+	rctx, span := tracing.StartRequestSpan(rctx, "store:PostStore.PermanentDeleteByChannel")
 	start := time.Now()
 
 	err := s.PostStore.PermanentDeleteByChannel(rctx, channelID)
 
 	elapsed := float64(time.Since(start)) / float64(time.Second)
+	tracing.EndSpan(span, err)
 	if s.Root.Metrics != nil {
 		success := "false"
 		if err == nil {
@@ -6694,11 +7073,13 @@ func (s *TimerLayerPostStore) PermanentDeleteByChannel(rctx request.CTX, channel
 }
 
 func (s *TimerLayerPostStore) PermanentDeleteByUser(rctx request.CTX, userID string) error {
+	rctx, span := tracing.StartRequestSpan(rctx, "store:PostStore.PermanentDeleteByUser")
 	start := time.Now()
 
 	err := s.PostStore.PermanentDeleteByUser(rctx, userID)
 
 	elapsed := float64(time.Since(start)) / float64(time.Second)
+	tracing.EndSpan(span, err)
 	if s.Root.Metrics != nil {
 		success := "false"
 		if err == nil {
@@ -6726,11 +7107,13 @@ func (s *TimerLayerPostStore) RefreshPostStats() error {
 }
 
 func (s *TimerLayerPostStore) Save(rctx request.CTX, post *model.Post) (*model.Post, error) {
+	rctx, span := tracing.StartRequestSpan(rctx, "store:PostStore.Save")
 	start := time.Now()
 
 	result, err := s.PostStore.Save(rctx, post)
 
 	elapsed := float64(time.Since(start)) / float64(time.Second)
+	tracing.EndSpan(span, err)
 	if s.Root.Metrics != nil {
 		success := "false"
 		if err == nil {
@@ -6741,12 +7124,30 @@ func (s *TimerLayerPostStore) Save(rctx request.CTX, post *model.Post) (*model.P
 	return result, err
 }
 
+func (s *TimerLayerPostStore) SaveEditHistory(posts []*model.Post) error {
+	start := time.Now()
+
+	err := s.PostStore.SaveEditHistory(posts)
+
+	elapsed := float64(time.Since(start)) / float64(time.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("PostStore.SaveEditHistory", success, elapsed)
+	}
+	return err
+}
+
 func (s *TimerLayerPostStore) SaveMultiple(rctx request.CTX, posts []*model.Post) ([]*model.Post, int, error) {
+	rctx, span := tracing.StartRequestSpan(rctx, "store:PostStore.SaveMultiple")
 	start := time.Now()
 
 	result, resultVar1, err := s.PostStore.SaveMultiple(rctx, posts)
 
 	elapsed := float64(time.Since(start)) / float64(time.Second)
+	tracing.EndSpan(span, err)
 	if s.Root.Metrics != nil {
 		success := "false"
 		if err == nil {
@@ -6774,11 +7175,13 @@ func (s *TimerLayerPostStore) Search(teamID string, userID string, params *model
 }
 
 func (s *TimerLayerPostStore) SearchPostsForUser(rctx request.CTX, paramsList []*model.SearchParams, userID string, teamID string, page int, perPage int) (*model.PostSearchResults, error) {
+	rctx, span := tracing.StartRequestSpan(rctx, "store:PostStore.SearchPostsForUser")
 	start := time.Now()
 
 	result, err := s.PostStore.SearchPostsForUser(rctx, paramsList, userID, teamID, page, perPage)
 
 	elapsed := float64(time.Since(start)) / float64(time.Second)
+	tracing.EndSpan(span, err)
 	if s.Root.Metrics != nil {
 		success := "false"
 		if err == nil {
@@ -6806,11 +7209,13 @@ func (s *TimerLayerPostStore) SetPostReminder(reminder *model.PostReminder) erro
 }
 
 func (s *TimerLayerPostStore) Update(rctx request.CTX, newPost *model.Post, oldPost *model.Post) (*model.Post, error) {
+	rctx, span := tracing.StartRequestSpan(rctx, "store:PostStore.Update")
 	start := time.Now()
 
 	result, err := s.PostStore.Update(rctx, newPost, oldPost)
 
 	elapsed := float64(time.Since(start)) / float64(time.Second)
+	tracing.EndSpan(span, err)
 	if s.Root.Metrics != nil {
 		success := "false"
 		if err == nil {
@@ -7813,6 +8218,22 @@ func (s *TimerLayerReactionStore) GetForPostSince(postID string, since int64, ex
 	return result, err
 }
 
+func (s *TimerLayerReactionStore) GetForUser(userID string, offset int, limit int) ([]*model.Reaction, error) {
+	start := time.Now()
+
+	result, err := s.ReactionStore.GetForUser(userID, offset, limit)
+
+	elapsed := float64(time.Since(start)) / float64(time.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("ReactionStore.GetForUser", success, elapsed)
+	}
+	return result, err
+}
+
 func (s *TimerLayerReactionStore) GetSingle(userID string, postID string, remoteID string, emojiName string) (*model.Reaction, error) {
 	start := time.Now()
 
@@ -8454,11 +8875,13 @@ func (s *TimerLayerRoleStore) GetAll() ([]*model.Role, error) {
 }
 
 func (s *TimerLayerRoleStore) GetByName(ctx context.Context, name string) (*model.Role, error) {
+	ctx, span := tracing.StartChildSpan(ctx, "store:RoleStore.GetByName")
 	start := time.Now()
 
 	result, err := s.RoleStore.GetByName(ctx, name)
 
 	elapsed := float64(time.Since(start)) / float64(time.Second)
+	tracing.EndSpan(span, err)
 	if s.Root.Metrics != nil {
 		success := "false"
 		if err == nil {
@@ -8822,11 +9245,13 @@ func (s *TimerLayerSessionStore) Cleanup(expiryTime int64, batchSize int64) erro
 }
 
 func (s *TimerLayerSessionStore) Get(c request.CTX, sessionIDOrToken string) (*model.Session, error) {
+	c, span := tracing.StartRequestSpan(c, "store:SessionStore.Get")
 	start := time.Now()
 
 	result, err := s.SessionStore.Get(c, sessionIDOrToken)
 
 	elapsed := float64(time.Since(start)) / float64(time.Second)
+	tracing.EndSpan(span, err)
 	if s.Root.Metrics != nil {
 		success := "false"
 		if err == nil {
@@ -8838,11 +9263,13 @@ func (s *TimerLayerSessionStore) Get(c request.CTX, sessionIDOrToken string) (*m
 }
 
 func (s *TimerLayerSessionStore) GetLRUSessions(c request.CTX, userID string, limit uint64, offset uint64) ([]*model.Session, error) {
+	c, span := tracing.StartRequestSpan(c, "store:SessionStore.GetLRUSessions")
 	start := time.Now()
 
 	result, err := s.SessionStore.GetLRUSessions(c, userID, limit, offset)
 
 	elapsed := float64(time.Since(start)) / float64(time.Second)
+	tracing.EndSpan(span, err)
 	if s.Root.Metrics != nil {
 		success := "false"
 		if err == nil {
@@ -8870,11 +9297,13 @@ func (s *TimerLayerSessionStore) GetMobileSessionMetadata() ([]*model.MobileSess
 }
 
 func (s *TimerLayerSessionStore) GetSessions(c request.CTX, userID string) ([]*model.Session, error) {
+	c, span := tracing.StartRequestSpan(c, "store:SessionStore.GetSessions")
 	start := time.Now()
 
 	result, err := s.SessionStore.GetSessions(c, userID)
 
 	elapsed := float64(time.Since(start)) / float64(time.Second)
+	tracing.EndSpan(span, err)
 	if s.Root.Metrics != nil {
 		success := "false"
 		if err == nil {
@@ -8966,11 +9395,13 @@ func (s *TimerLayerSessionStore) RemoveAllSessions() error {
 }
 
 func (s *TimerLayerSessionStore) Save(c request.CTX, session *model.Session) (*model.Session, error) {
+	c, span := tracing.StartRequestSpan(c, "store:SessionStore.Save")
 	start := time.Now()
 
 	result, err := s.SessionStore.Save(c, session)
 
 	elapsed := float64(time.Since(start)) / float64(time.Second)
+	tracing.EndSpan(span, err)
 	if s.Root.Metrics != nil {
 		success := "false"
 		if err == nil {
@@ -10101,11 +10532,13 @@ func (s *TimerLayerTeamStore) GetMany(ids []string) ([]*model.Team, error) {
 }
 
 func (s *TimerLayerTeamStore) GetMember(c request.CTX, teamID string, userID string) (*model.TeamMember, error) {
+	c, span := tracing.StartRequestSpan(c, "store:TeamStore.GetMember")
 	start := time.Now()
 
 	result, err := s.TeamStore.GetMember(c, teamID, userID)
 
 	elapsed := float64(time.Since(start)) / float64(time.Second)
+	tracing.EndSpan(span, err)
 	if s.Root.Metrics != nil {
 		success := "false"
 		if err == nil {
@@ -10197,11 +10630,13 @@ func (s *TimerLayerTeamStore) GetTeamsByUserId(userID string) ([]*model.Team, er
 }
 
 func (s *TimerLayerTeamStore) GetTeamsForUser(c request.CTX, userID string, excludeTeamID string, includeDeleted bool) ([]*model.TeamMember, error) {
+	c, span := tracing.StartRequestSpan(c, "store:TeamStore.GetTeamsForUser")
 	start := time.Now()
 
 	result, err := s.TeamStore.GetTeamsForUser(c, userID, excludeTeamID, includeDeleted)
 
 	elapsed := float64(time.Since(start)) / float64(time.Second)
+	tracing.EndSpan(span, err)
 	if s.Root.Metrics != nil {
 		success := "false"
 		if err == nil {
@@ -10340,11 +10775,13 @@ func (s *TimerLayerTeamStore) RemoveAllMembersByTeam(teamID string) error {
 }
 
 func (s *TimerLayerTeamStore) RemoveAllMembersByUser(ctx request.CTX, userID string) error {
+	ctx, span := tracing.StartRequestSpan(ctx, "store:TeamStore.RemoveAllMembersByUser")
 	start := time.Now()
 
 	err := s.TeamStore.RemoveAllMembersByUser(ctx, userID)
 
 	elapsed := float64(time.Since(start)) / float64(time.Second)
+	tracing.EndSpan(span, err)
 	if s.Root.Metrics != nil {
 		success := "false"
 		if err == nil {
@@ -10356,11 +10793,13 @@ func (s *TimerLayerTeamStore) RemoveAllMembersByUser(ctx request.CTX, userID str
 }
 
 func (s *TimerLayerTeamStore) RemoveMember(rctx request.CTX, teamID string, userID string) error {
+	rctx, span := tracing.StartRequestSpan(rctx, "store:TeamStore.RemoveMember")
 	start := time.Now()
 
 	err := s.TeamStore.RemoveMember(rctx, teamID, userID)
 
 	elapsed := float64(time.Since(start)) / float64(time.Second)
+	tracing.EndSpan(span, err)
 	if s.Root.Metrics != nil {
 		success := "false"
 		if err == nil {
@@ -10372,11 +10811,13 @@ func (s *TimerLayerTeamStore) RemoveMember(rctx request.CTX, teamID string, user
 }
 
 func (s *TimerLayerTeamStore) RemoveMembers(rctx request.CTX, teamID string, userIds []string) error {
+	rctx, span := tracing.StartRequestSpan(rctx, "store:TeamStore.RemoveMembers")
 	start := time.Now()
 
 	err := s.TeamStore.RemoveMembers(rctx, teamID, userIds)
 
 	elapsed := float64(time.Since(start)) / float64(time.Second)
+	tracing.EndSpan(span, err)
 	if s.Root.Metrics != nil {
 		success := "false"
 		if err == nil {
@@ -10420,11 +10861,13 @@ func (s *TimerLayerTeamStore) Save(team *model.Team) (*model.Team, error) {
 }
 
 func (s *TimerLayerTeamStore) SaveMember(rctx request.CTX, member *model.TeamMember, maxUsersPerTeam int) (*model.TeamMember, error) {
+	rctx, span := tracing.StartRequestSpan(rctx, "store:TeamStore.SaveMember")
 	start := time.Now()
 
 	result, err := s.TeamStore.SaveMember(rctx, member, maxUsersPerTeam)
 
 	elapsed := float64(time.Since(start)) / float64(time.Second)
+	tracing.EndSpan(span, err)
 	if s.Root.Metrics != nil {
 		success := "false"
 		if err == nil {
@@ -10548,11 +10991,13 @@ func (s *TimerLayerTeamStore) UpdateLastTeamIconUpdate(teamID string, curTime in
 }
 
 func (s *TimerLayerTeamStore) UpdateMember(rctx request.CTX, member *model.TeamMember) (*model.TeamMember, error) {
+	rctx, span := tracing.StartRequestSpan(rctx, "store:TeamStore.UpdateMember")
 	start := time.Now()
 
 	result, err := s.TeamStore.UpdateMember(rctx, member)
 
 	elapsed := float64(time.Since(start)) / float64(time.Second)
+	tracing.EndSpan(span, err)
 	if s.Root.Metrics != nil {
 		success := "false"
 		if err == nil {
@@ -10787,12 +11232,14 @@ func (s *TimerLayerThreadStore) GetThreadFollowers(threadID string, fetchOnlyAct
 	return result, err
 }
 
-func (s *TimerLayerThreadStore) GetThreadForUser(threadMembership *model.ThreadMembership, extended bool, postPriorityIsEnabled bool) (*model.ThreadResponse, error) {
+func (s *TimerLayerThreadStore) GetThreadForUser(rctx request.CTX, threadMembership *model.ThreadMembership, extended bool, postPriorityIsEnabled bool) (*model.ThreadResponse, error) {
+	rctx, span := tracing.StartRequestSpan(rctx, "store:ThreadStore.GetThreadForUser")
 	start := time.Now()
 
-	result, err := s.ThreadStore.GetThreadForUser(threadMembership, extended, postPriorityIsEnabled)
+	result, err := s.ThreadStore.GetThreadForUser(rctx, threadMembership, extended, postPriorityIsEnabled)
 
 	elapsed := float64(time.Since(start)) / float64(time.Second)
+	tracing.EndSpan(span, err)
 	if s.Root.Metrics != nil {
 		success := "false"
 		if err == nil {
@@ -10835,12 +11282,14 @@ func (s *TimerLayerThreadStore) GetThreadUnreadReplyCount(threadMembership *mode
 	return result, err
 }
 
-func (s *TimerLayerThreadStore) GetThreadsForUser(userID string, teamID string, opts model.GetUserThreadsOpts) ([]*model.ThreadResponse, error) {
+func (s *TimerLayerThreadStore) GetThreadsForUser(rctx request.CTX, userID string, teamID string, opts model.GetUserThreadsOpts) ([]*model.ThreadResponse, error) {
+	rctx, span := tracing.StartRequestSpan(rctx, "store:ThreadStore.GetThreadsForUser")
 	start := time.Now()
 
-	result, err := s.ThreadStore.GetThreadsForUser(userID, teamID, opts)
+	result, err := s.ThreadStore.GetThreadsForUser(rctx, userID, teamID, opts)
 
 	elapsed := float64(time.Since(start)) / float64(time.Second)
+	tracing.EndSpan(span, err)
 	if s.Root.Metrics != nil {
 		success := "false"
 		if err == nil {
@@ -10851,12 +11300,14 @@ func (s *TimerLayerThreadStore) GetThreadsForUser(userID string, teamID string,
 	return result, err
 }
 
-func (s *TimerLayerThreadStore) GetTotalThreads(userID string, teamID string, opts model.GetUserThreadsOpts) (int64, error) {
+func (s *TimerLayerThreadStore) GetTotalThreads(rctx request.CTX, userID string, teamID string, opts model.GetUserThreadsOpts) (int64, error) {
+	rctx, span := tracing.StartRequestSpan(rctx, "store:ThreadStore.GetTotalThreads")
 	start := time.Now()
 
-	result, err := s.ThreadStore.GetTotalThreads(userID, teamID, opts)
+	result, err := s.ThreadStore.GetTotalThreads(rctx, userID, teamID, opts)
 
 	elapsed := float64(time.Since(start)) / float64(time.Second)
+	tracing.EndSpan(span, err)
 	if s.Root.Metrics != nil {
 		success := "false"
 		if err == nil {
@@ -10867,12 +11318,14 @@ func (s *TimerLayerThreadStore) GetTotalThreads(userID string, teamID string, op
 	return result, err
 }
 
-func (s *TimerLayerThreadStore) GetTotalUnreadMentions(userID string, teamID string, opts model.GetUserThreadsOpts) (int64, error) {
+func (s *TimerLayerThreadStore) GetTotalUnreadMentions(rctx request.CTX, userID string, teamID string, opts model.GetUserThreadsOpts) (int64, error) {
+	rctx, span := tracing.StartRequestSpan(rctx, "store:ThreadStore.GetTotalUnreadMentions")
 	start := time.Now()
 
-	result, err := s.ThreadStore.GetTotalUnreadMentions(userID, teamID, opts)
+	result, err := s.ThreadStore.GetTotalUnreadMentions(rctx, userID, teamID, opts)
 
 	elapsed := float64(time.Since(start)) / float64(time.Second)
+	tracing.EndSpan(span, err)
 	if s.Root.Metrics != nil {
 		success := "false"
 		if err == nil {
@@ -10883,12 +11336,14 @@ func (s *TimerLayerThreadStore) GetTotalUnreadMentions(userID string, teamID str
 	return result, err
 }
 
-func (s *TimerLayerThreadStore) GetTotalUnreadThreads(userID string, teamID string, opts model.GetUserThreadsOpts) (int64, error) {
+func (s *TimerLayerThreadStore) GetTotalUnreadThreads(rctx request.CTX, userID string, teamID string, opts model.GetUserThreadsOpts) (int64, error) {
+	rctx, span := tracing.StartRequestSpan(rctx, "store:ThreadStore.GetTotalUnreadThreads")
 	start := time.Now()
 
-	result, err := s.ThreadStore.GetTotalUnreadThreads(userID, teamID, opts)
+	result, err := s.ThreadStore.GetTotalUnreadThreads(rctx, userID, teamID, opts)
 
 	elapsed := float64(time.Since(start)) / float64(time.Second)
+	tracing.EndSpan(span, err)
 	if s.Root.Metrics != nil {
 		success := "false"
 		if err == nil {
@@ -10899,12 +11354,14 @@ func (s *TimerLayerThreadStore) GetTotalUnreadThreads(userID string, teamID stri
 	return result, err
 }
 
-func (s *TimerLayerThreadStore) GetTotalUnreadUrgentMentions(userID string, teamID string, opts model.GetUserThreadsOpts) (int64, error) {
+func (s *TimerLayerThreadStore) GetTotalUnreadUrgentMentions(rctx request.CTX, userID string, teamID string, opts model.GetUserThreadsOpts) (int64, error) {
+	rctx, span := tracing.StartRequestSpan(rctx, "store:ThreadStore.GetTotalUnreadUrgentMentions")
 	start := time.Now()
 
-	result, err := s.ThreadStore.GetTotalUnreadUrgentMentions(userID, teamID, opts)
+	result, err := s.ThreadStore.GetTotalUnreadUrgentMentions(rctx, userID, teamID, opts)
 
 	elapsed := float64(time.Since(start)) / float64(time.Second)
+	tracing.EndSpan(span, err)
 	if s.Root.Metrics != nil {
 		success := "false"
 		if err == nil {
@@ -11203,11 +11660,13 @@ func (s *TimerLayerUploadSessionStore) Delete(id string) error {
 }
 
 func (s *TimerLayerUploadSessionStore) Get(c request.CTX, id string) (*model.UploadSession, error) {
+	c, span := tracing.StartRequestSpan(c, "store:UploadSessionStore.Get")
 	start := time.Now()
 
 	result, err := s.UploadSessionStore.Get(c, id)
 
 	elapsed := float64(time.Since(start)) / float64(time.Second)
+	tracing.EndSpan(span, err)
 	if s.Root.Metrics != nil {
 		success := "false"
 		if err == nil {
@@ -11363,11 +11822,13 @@ func (s *TimerLayerUserStore) AnalyticsGetSystemAdminCount() (int64, error) {
 }
 
 func (s *TimerLayerUserStore) AutocompleteUsersInChannel(rctx request.CTX, teamID string, channelID string, term string, options *model.UserSearchOptions) (*model.UserAutocompleteInChannel, error) {
+	rctx, span := tracing.StartRequestSpan(rctx, "store:UserStore.AutocompleteUsersInChannel")
 	start := time.Now()
 
 	result, err := s.UserStore.AutocompleteUsersInChannel(rctx, teamID, channelID, term, options)
 
 	elapsed := float64(time.Since(start)) / float64(time.Second)
+	tracing.EndSpan(span, err)
 	if s.Root.Metrics != nil {
 		success := "false"
 		if err == nil {
@@ -11458,11 +11919,13 @@ func (s *TimerLayerUserStore) DemoteUserToGuest(userID string) (*model.User, err
 }
 
 func (s *TimerLayerUserStore) Get(ctx context.Context, id string) (*model.User, error) {
+	ctx, span := tracing.StartChildSpan(ctx, "store:UserStore.Get")
 	start := time.Now()
 
 	result, err := s.UserStore.Get(ctx, id)
 
 	elapsed := float64(time.Since(start)) / float64(time.Second)
+	tracing.EndSpan(span, err)
 	if s.Root.Metrics != nil {
 		success := "false"
 		if err == nil {
@@ -11538,11 +12001,13 @@ func (s *TimerLayerUserStore) GetAllProfiles(options *model.UserGetOptions) ([]*
 }
 
 func (s *TimerLayerUserStore) GetAllProfilesInChannel(ctx context.Context, channelID string, allowFromCache bool) (map[string]*model.User, error) {
+	ctx, span := tracing.StartChildSpan(ctx, "store:UserStore.GetAllProfilesInChannel")
 	start := time.Now()
 
 	result, err := s.UserStore.GetAllProfilesInChannel(ctx, channelID, allowFromCache)
 
 	elapsed := float64(time.Since(start)) / float64(time.Second)
+	tracing.EndSpan(span, err)
 	if s.Root.Metrics != nil {
 		success := "false"
 		if err == nil {
@@ -11746,11 +12211,13 @@ func (s *TimerLayerUserStore) GetKnownUsers(userID string) ([]string, error) {
 }
 
 func (s *TimerLayerUserStore) GetMany(ctx context.Context, ids []string) ([]*model.User, error) {
+	ctx, span := tracing.StartChildSpan(ctx, "store:UserStore.GetMany")
 	start := time.Now()
 
 	result, err := s.UserStore.GetMany(ctx, ids)
 
 	elapsed := float64(time.Since(start)) / float64(time.Second)
+	tracing.EndSpan(span, err)
 	if s.Root.Metrics != nil {
 		success := "false"
 		if err == nil {
@@ -11810,11 +12277,13 @@ func (s *TimerLayerUserStore) GetProfileByGroupChannelIdsForUser(userID string,
 }
 
 func (s *TimerLayerUserStore) GetProfileByIds(ctx context.Context, userIds []string, options *store.UserGetByIdsOpts, allowFromCache bool) ([]*model.User, error) {
+	ctx, span := tracing.StartChildSpan(ctx, "store:UserStore.GetProfileByIds")
 	start := time.Now()
 
 	result, err := s.UserStore.GetProfileByIds(ctx, userIds, options, allowFromCache)
 
 	elapsed := float64(time.Since(start)) / float64(time.Second)
+	tracing.EndSpan(span, err)
 	if s.Root.Metrics != nil {
 		success := "false"
 		if err == nil {
@@ -12191,11 +12660,13 @@ func (s *TimerLayerUserStore) IsEmpty(excludeBots bool) (bool, error) {
 }
 
 func (s *TimerLayerUserStore) PermanentDelete(rctx request.CTX, userID string) error {
+	rctx, span := tracing.StartRequestSpan(rctx, "store:UserStore.PermanentDelete")
 	start := time.Now()
 
 	err := s.UserStore.PermanentDelete(rctx, userID)
 
 	elapsed := float64(time.Since(start)) / float64(time.Second)
+	tracing.EndSpan(span, err)
 	if s.Root.Metrics != nil {
 		success := "false"
 		if err == nil {
@@ -12271,11 +12742,13 @@ func (s *TimerLayerUserStore) ResetLastPictureUpdate(userID string) error {
 }
 
 func (s *TimerLayerUserStore) Save(rctx request.CTX, user *model.User) (*model.User, error) {
+	rctx, span := tracing.StartRequestSpan(rctx, "store:UserStore.Save")
 	start := time.Now()
 
 	result, err := s.UserStore.Save(rctx, user)
 
 	elapsed := float64(time.Since(start)) / float64(time.Second)
+	tracing.EndSpan(span, err)
 	if s.Root.Metrics != nil {
 		success := "false"
 		if err == nil {
@@ -12287,11 +12760,13 @@ func (s *TimerLayerUserStore) Save(rctx request.CTX, user *model.User) (*model.U
 }
 
 func (s *TimerLayerUserStore) Search(rctx request.CTX, teamID string, term string, options *model.UserSearchOptions) ([]*model.User, error) {
+	rctx, span := tracing.StartRequestSpan(rctx, "store:UserStore.Search")
 	start := time.Now()
 
 	result, err := s.UserStore.Search(rctx, teamID, term, options)
 
 	elapsed := float64(time.Since(start)) / float64(time.Second)
+	tracing.EndSpan(span, err)
 	if s.Root.Metrics != nil {
 		success := "false"
 		if err == nil {
@@ -12415,11 +12890,13 @@ func (s *TimerLayerUserStore) StoreMfaUsedTimestamps(userID string, ts []int) er
 }
 
 func (s *TimerLayerUserStore) Update(rctx request.CTX, user *model.User, allowRoleUpdate bool) (*model.UserUpdate, error) {
+	rctx, span := tracing.StartRequestSpan(rctx, "store:UserStore.Update")
 	start := time.Now()
 
 	result, err := s.UserStore.Update(rctx, user, allowRoleUpdate)
 
 	elapsed := float64(time.Since(start)) / float64(time.Second)
+	tracing.EndSpan(span, err)
 	if s.Root.Metrics != nil {
 		success := "false"
 		if err == nil {
@@ -13273,6 +13750,7 @@ func New(childStore store.Store, metrics einterfaces.MetricsInterface) *TimerLay
 	newStore.AccessControlPolicyStore = &TimerLayerAccessControlPolicyStore{AccessControlPolicyStore: childStore.AccessControlPolicy(), Root: &newStore}
 	newStore.AttributesStore = &TimerLayerAttributesStore{AttributesStore: childStore.Attributes(), Root: &newStore}
 	newStore.AuditStore = &TimerLayerAuditStore{AuditStore: childStore.Audit(), Root: &newStore}
+	newStore.AuditRecordStore = &TimerLayerAuditRecordStore{AuditRecordStore: childStore.AuditRecord(), Root: &newStore}
 	newStore.BotStore = &TimerLayerBotStore{BotStore: childStore.Bot(), Root: &newStore}
 	newStore.ChannelStore = &TimerLayerChannelStore{ChannelStore: childStore.Channel(), Root: &newStore}
 	newStore.ChannelBookmarkStore = &TimerLayerChannelBookmarkStore{ChannelBookmarkStore: childStore.ChannelBookmark(), Root: &newStore}
@@ -13292,6 +13770,7 @@ func New(childStore store.Store, metrics einterfaces.MetricsInterface) *TimerLay
 	newStore.NotifyAdminStore = &TimerLayerNotifyAdminStore{NotifyAdminStore: childStore.NotifyAdmin(), Root: &newStore}
 	newStore.OAuthStore = &TimerLayerOAuthStore{OAuthStore: childStore.OAuth(), Root: &newStore}
 	newStore.OutgoingOAuthConnectionStore = &TimerLayerOutgoingOAuthConnectionStore{OutgoingOAuthConnectionStore: childStore.OutgoingOAuthConnection(), Root: &newStore}
+	newStore.PartitionStore = &TimerLayerPartitionStore{PartitionStore: childStore.Partition(), Root: &newStore}
 	newStore.PluginStore = &TimerLayerPluginStore{PluginStore: childStore.Plugin(), Root: &newStore}
 	newStore.PostStore = &TimerLayerPostStore{PostStore: childStore.Post(), Root: &newStore}
 	newStore.PostAcknowledgementStore = &TimerLayerPostAcknowledgementStore{PostAcknowledgementStore: childStore.PostAcknowledgement(), Root: &newStore}
@@ -116,3 +116,7 @@ func (c *FakeClusterInterface) WebConnCountForUser(userID string) (int, *model.A
 func (c *FakeClusterInterface) GetWSQueues(userID, connectionID string, seqNum int64) (map[string]*model.WSQueues, error) {
 	return nil, nil
 }
+
+func (c *FakeClusterInterface) GetWebConnInfosForUser(userID string) ([]*model.WebConnInfo, error) {
+	return nil, nil
+}
@@ -639,7 +639,7 @@ func (c *Context) RequireJobType() *Context {
 		return c
 	}
 
-	if c.Params.JobType == "" || len(c.Params.JobType) > 32 {
+	if c.Params.JobType == "" || len(c.Params.JobType) > model.JobTypeMaxLength {
 		c.SetInvalidURLParam("job_type")
 	}
 	return c
@@ -16,6 +16,7 @@ import (
 	"time"
 
 	"github.com/klauspost/compress/gzhttp"
+	"go.opentelemetry.io/otel/attribute"
 
 	"github.com/mattermost/mattermost/server/public/model"
 	"github.com/mattermost/mattermost/server/public/shared/i18n"
@@ -23,6 +24,7 @@ import (
 	"github.com/mattermost/mattermost/server/public/shared/request"
 	"github.com/mattermost/mattermost/server/v8/channels/app"
 	"github.com/mattermost/mattermost/server/v8/channels/utils"
+	"github.com/mattermost/mattermost/server/v8/platform/services/tracing"
 )
 
 func GetHandlerName(h func(*Context, http.ResponseWriter, *http.Request)) string {
@@ -154,6 +156,7 @@ func (h Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	}
 
 	requestID := model.NewId()
+	ctx, span := tracing.StartHTTPServerSpan(context.Background(), r, "web:"+h.HandlerName, attribute.String("request_id", requestID))
 	var rateLimitExceeded bool
 	defer func() {
 		responseLogFields := []mlog.Field{
@@ -164,8 +167,10 @@ func (h Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		// if there is a valid session and userID then include the user_id
 		if c.AppContext.Session() != nil && c.AppContext.Session().UserId != "" {
 			responseLogFields = append(responseLogFields, mlog.String("user_id", c.AppContext.Session().UserId))
+			span.SetAttributes(attribute.String("user_id", c.AppContext.Session().UserId))
 		}
 
+		tracing.EndHTTPServerSpan(span, w.(*responseWriterWrapper).StatusCode())
 		statusCode := strconv.Itoa(w.(*responseWriterWrapper).StatusCode())
 
 		// Websockets are returning status code 0 to requests after closing the socket
@@ -181,7 +186,7 @@ func (h Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 
 	t, _ := i18n.GetTranslationsAndLocaleFromRequest(r)
 	c.AppContext = request.NewContext(
-		context.Background(),
+		ctx,
 		requestID,
 		utils.GetIPAddress(r, c.App.Config().ServiceSettings.TrustedProxyIPHeader),
 		r.Header.Get("X-Forwarded-For"),
@@ -347,6 +352,7 @@ func (h Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	}
 
 	if c.Err == nil {
+		c.AppContext = app.RequestContextWithReadYourWrites(c.AppContext)
 		h.HandleFunc(c, w, r)
 	}
 
@@ -355,6 +361,15 @@ func (h Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		h.handleContextError(c, w, r)
 		return
 	}
+
+	// The store records the writes of its methods taking a request context,
+	// the other writes of a successful non-GET request are recorded here, so
+	// that the following reads of the user go to master until the replicas
+	// have replayed them.
+	if r.Method != http.MethodGet && r.Method != http.MethodHead && w.(*responseWriterWrapper).StatusCode() < http.StatusBadRequest {
+		c.App.Srv().Platform().RecordWrite(c.AppContext.Context())
+	}
+	c.App.Srv().Platform().RecordWritePosition(c.AppContext.Context())
 }
 
 func (h Handler) recordMetrics(c *Context, r *http.Request, now time.Time, statusCode string) {
@@ -15,6 +15,7 @@ import (
 
 	"github.com/mattermost/mattermost/server/public/model"
 	"github.com/mattermost/mattermost/server/public/shared/mlog"
+	"github.com/mattermost/mattermost/server/v8/channels/store"
 	"github.com/mattermost/mattermost/server/v8/channels/store/sqlstore"
 	"github.com/mattermost/mattermost/server/v8/config"
 	"github.com/mattermost/mattermost/server/v8/platform/shared/filestore"
@@ -68,6 +69,20 @@ var DowngradeCmd = &cobra.Command{
 	Args: cobra.ExactArgs(1),
 }
 
+var PartitionCmd = &cobra.Command{
+	Use:   "partition [tables...]",
+	Short: "Partition tables by creation time",
+	Long: `Convert tables to monthly partitions on their creation time, so that data retention can drop whole partitions and old partitions can be archived. The Posts, Reactions and FileInfo tables are partitioned unless some of them are given.
+
+Only Postgres databases are supported. The tables are rewritten and locked during the conversion, so the servers should be stopped and a database backup performed beforehand.`,
+	Example: `  # partition all the supported tables
+  $ mattermost db partition
+
+  # partition the Posts table only
+  $ mattermost db partition Posts`,
+	RunE: partitionCmdF,
+}
+
 var DBVersionCmd = &cobra.Command{
 	Use:   "version",
 	Short: "Returns the recent applied version number",
@@ -84,11 +99,14 @@ func init() {
 	DowngradeCmd.Flags().Bool("auto-recover", false, "Recover the database to it's existing state after a failed migration.")
 	DowngradeCmd.Flags().Bool("dry-run", false, "Runs the migration plan without applying it.")
 
+	PartitionCmd.Flags().Bool("confirm", false, "Confirm the servers are stopped and a DB backup has been performed.")
+
 	DbCmd.AddCommand(
 		InitDbCmd,
 		ResetCmd,
 		MigrateCmd,
 		DowngradeCmd,
+		PartitionCmd,
 		DBVersionCmd,
 	)
 
@@ -290,6 +308,52 @@ func downgradeCmdF(command *cobra.Command, args []string) error {
 	return nil
 }
 
+func partitionCmdF(command *cobra.Command, args []string) error {
+	logger := mlog.CreateConsoleLogger()
+	defer logger.Shutdown()
+
+	tables := store.PartitionableTables
+	if len(args) > 0 {
+		tables = args
+	}
+
+	confirmFlag, _ := command.Flags().GetBool("confirm")
+	if !confirmFlag {
+		var confirm string
+		CommandPrettyPrintln("Are the servers stopped and have you performed a database backup? (YES/NO): ")
+		fmt.Scanln(&confirm)
+		if confirm != "YES" {
+			return errors.New("ABORTED: You did not answer YES exactly, in all capitals.")
+		}
+	}
+
+	ss, err := initStoreCommandContextCobra(logger, command)
+	if err != nil {
+		return errors.Wrap(err, "could not initialize store")
+	}
+	defer ss.Close()
+
+	for _, table := range tables {
+		if ss.Partition().IsPartitioned(table) {
+			CommandPrettyPrintln(fmt.Sprintf("Table %s is already partitioned", table))
+			continue
+		}
+
+		CommandPrettyPrintln(fmt.Sprintf("Partitioning table %s...", table))
+		if err := ss.Partition().PartitionTable(table); err != nil {
+			return errors.Wrapf(err, "failed to partition table %s", table)
+		}
+
+		partitions, err := ss.Partition().GetPartitions(table)
+		if err != nil {
+			return errors.Wrapf(err, "failed to get the partitions of table %s", table)
+		}
+		CommandPrettyPrintln(fmt.Sprintf("Table %s partitioned into %d monthly partitions", table, len(partitions)))
+	}
+
+	return nil
+}
+
 func dbVersionCmdF(command *cobra.Command, args []string) error {
 	logger := mlog.CreateConsoleLogger()
 	defer logger.Shutdown()
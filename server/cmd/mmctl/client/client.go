@@ -60,14 +60,20 @@ type Client interface {
 	EnablePlugin(ctx context.Context, id string) (*model.Response, error)
 	DisablePlugin(ctx context.Context, id string) (*model.Response, error)
 	GetPlugins(ctx context.Context) (*model.PluginsResponse, *model.Response, error)
+	GetPluginStatuses(ctx context.Context) (model.PluginStatuses, *model.Response, error)
+	GetPluginSignatureReports(ctx context.Context) ([]*model.PluginSignatureReport, *model.Response, error)
 	GetUser(ctx context.Context, userID, etag string) (*model.User, *model.Response, error)
 	GetUserByUsername(ctx context.Context, userName, etag string) (*model.User, *model.Response, error)
 	GetUserByEmail(ctx context.Context, email, etag string) (*model.User, *model.Response, error)
 	GetUsersByIds(ctx context.Context, userIDs []string) ([]*model.User, *model.Response, error)
+	GetUserWebSocketConnections(ctx context.Context, userID string) ([]*model.WebConnInfo, *model.Response, error)
+	SearchAuditRecords(ctx context.Context, opts model.AuditRecordSearchOptions) ([]*model.StoredAuditRecord, *model.Response, error)
+	ExportAuditRecords(ctx context.Context, opts model.AuditRecordSearchOptions, format string, wr io.Writer) (int64, *model.Response, error)
 	GetUsersWithCustomQueryParameters(ctx context.Context, page, perPage int, queryParameters string, etag string) ([]*model.User, *model.Response, error)
 	GetUsersInTeam(ctx context.Context, teamID string, page, perPage int, etag string) ([]*model.User, *model.Response, error)
 	PermanentDeleteUser(ctx context.Context, userID string) (*model.Response, error)
 	PermanentDeleteAllUsers(ctx context.Context) (*model.Response, error)
+	EraseUser(ctx context.Context, userID string, dryRun bool) (*model.Job, *model.Response, error)
 	CreateUser(ctx context.Context, user *model.User) (*model.User, *model.Response, error)
 	VerifyUserEmailWithoutToken(ctx context.Context, userID string) (*model.User, *model.Response, error)
 	UpdateUserRoles(ctx context.Context, userID, roles string) (*model.Response, error)
@@ -117,6 +123,7 @@ type Client interface {
 	CheckIntegrity(ctx context.Context) ([]model.IntegrityCheckResult, *model.Response, error)
 	InstallPluginFromURL(context.Context, string, bool) (*model.Manifest, *model.Response, error)
 	InstallMarketplacePlugin(context.Context, *model.InstallMarketplacePluginRequest) (*model.Manifest, *model.Response, error)
+	PublishMarketplaceCatalogPlugin(ctx context.Context, file, signature io.Reader) (*model.Manifest, *model.Response, error)
 	GetMarketplacePlugins(context.Context, *model.MarketplacePluginFilter) ([]*model.MarketplacePlugin, *model.Response, error)
 	MigrateAuthToLdap(ctx context.Context, fromAuthService string, matchField string, force bool) (*model.Response, error)
 	MigrateAuthToSaml(ctx context.Context, fromAuthService string, usersMap map[string]string, auto bool) (*model.Response, error)
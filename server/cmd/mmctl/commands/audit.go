@@ -0,0 +1,209 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package commands
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/v8/channels/audit"
+	"github.com/mattermost/mattermost/server/v8/cmd/mmctl/client"
+	"github.com/mattermost/mattermost/server/v8/cmd/mmctl/printer"
+)
+
+var AuditCmd = &cobra.Command{
+	Use:   "audit",
+	Short: "Management of audit logs",
+}
+
+var AuditVerifyCmd = &cobra.Command{
+	Use:   "verify [file]",
+	Short: "Verify a hash chained audit log",
+	Long: `Verify that no record of an audit log written by the hash chain target was modified, removed or reordered,
+and that its checkpoints were signed by the key matching the given public key.
+This command works on a local copy of the audit log and doesn't connect to a server.`,
+	Example: `  audit verify audit_chain.log --public-key audit_chain.pub`,
+	Args:    cobra.ExactArgs(1),
+	RunE:    auditVerifyCmdF,
+}
+
+var AuditSearchCmd = &cobra.Command{
+	Use:   "search",
+	Short: "Search audit records",
+	Long: `Search the audit records saved to the database, newest first.
+Audit records are only saved to the database when ExperimentalAuditSettings.DatabaseEnabled is set.`,
+	Example: `  audit search --user 4xp9fdt77pncbef59f4k1qe83o --since 2025-01-01T00:00:00Z
+  audit search --event updateUser --status fail --per-page 20`,
+	Args: cobra.NoArgs,
+	RunE: withClient(auditSearchCmdF),
+}
+
+var AuditExportCmd = &cobra.Command{
+	Use:   "export",
+	Short: "Export audit records",
+	Long:  "Export every audit record saved to the database matching the filters, as CSV or JSON.",
+	Example: `  audit export --format csv --output audit.csv
+  audit export --event login --since 2025-01-01T00:00:00Z > logins.json`,
+	Args: cobra.NoArgs,
+	RunE: withClient(auditExportCmdF),
+}
+
+func addAuditRecordFilterFlags(cmd *cobra.Command) {
+	cmd.Flags().String("user", "", "Only include records of the given user id.")
+	cmd.Flags().String("event", "", "Only include records of the given event name.")
+	cmd.Flags().String("status", "", "Only include records with the given status: success, attempt or fail.")
+	cmd.Flags().String("object", "", "Only include records of events affecting the given object id.")
+	cmd.Flags().String("ip", "", "Only include records of requests from the given IP address.")
+	cmd.Flags().String("since", "", "Only include records created at or after this time (ISO 8601).")
+	cmd.Flags().String("until", "", "Only include records created before this time (ISO 8601).")
+}
+
+func init() {
+	addAuditRecordFilterFlags(AuditSearchCmd)
+	AuditSearchCmd.Flags().Int("page", 0, "Page number to fetch.")
+	AuditSearchCmd.Flags().Int("per-page", model.AuditRecordSearchDefaultPerPage, "Number of records to fetch per page.")
+
+	addAuditRecordFilterFlags(AuditExportCmd)
+	AuditExportCmd.Flags().String("format", model.AuditRecordExportFormatJSON, "Format of the export: csv or json.")
+	AuditExportCmd.Flags().StringP("output", "o", "", "File to write the export to. Defaults to the standard output.")
+
+	AuditVerifyCmd.Flags().String("public-key", "", "Path to the PEM encoded Ed25519 public key of the key used to sign the checkpoints.")
+	_ = AuditVerifyCmd.MarkFlagRequired("public-key")
+
+	AuditCmd.AddCommand(
+		AuditVerifyCmd,
+		AuditSearchCmd,
+		AuditExportCmd,
+	)
+	RootCmd.AddCommand(AuditCmd)
+}
+
+func auditVerifyCmdF(cmd *cobra.Command, args []string) error {
+	printer.SetSingle(true)
+
+	publicKeyFile, err := cmd.Flags().GetString("public-key")
+	if err != nil {
+		return err
+	}
+
+	keyData, err := os.ReadFile(publicKeyFile)
+	if err != nil {
+		return fmt.Errorf("failed to read public key: %w", err)
+	}
+
+	publicKey, err := audit.ParseHashChainPublicKey(keyData)
+	if err != nil {
+		return err
+	}
+
+	file, err := os.Open(args[0])
+	if err != nil {
+		return fmt.Errorf("failed to open audit log: %w", err)
+	}
+	defer file.Close()
+
+	report, err := audit.VerifyHashChain(file, publicKey)
+	if err != nil {
+		return err
+	}
+
+	for _, issue := range report.Issues {
+		printer.PrintError(fmt.Sprintf("line %d (seq %d): %s", issue.Line, issue.Seq, issue.Message))
+	}
+
+	printer.PrintT("Verified {{.Records}} records and {{.Checkpoints}} checkpoints up to sequence {{.LastSeq}}.", report)
+
+	if report.UnsignedRecords > 0 {
+		printer.PrintWarning(fmt.Sprintf("%d records were written after the last checkpoint. Their removal can't be detected.", report.UnsignedRecords))
+	}
+
+	if !report.IsValid() {
+		return fmt.Errorf("audit log %s failed verification with %d issues", args[0], len(report.Issues))
+	}
+
+	return nil
+}
+
+func auditRecordSearchOptionsFromFlags(cmd *cobra.Command) (model.AuditRecordSearchOptions, error) {
+	opts := model.AuditRecordSearchOptions{}
+	opts.UserId, _ = cmd.Flags().GetString("user")
+	opts.EventName, _ = cmd.Flags().GetString("event")
+	opts.Status, _ = cmd.Flags().GetString("status")
+	opts.ObjectId, _ = cmd.Flags().GetString("object")
+	opts.IpAddress, _ = cmd.Flags().GetString("ip")
+
+	for flag, millis := range map[string]*int64{"since": &opts.Since, "until": &opts.Until} {
+		value, _ := cmd.Flags().GetString(flag)
+		if value == "" {
+			continue
+		}
+		t, err := time.Parse(ISO8601Layout, value)
+		if err != nil {
+			return opts, fmt.Errorf("invalid %s time '%s'", flag, value)
+		}
+		*millis = model.GetMillisForTime(t)
+	}
+
+	return opts, nil
+}
+
+func auditSearchCmdF(c client.Client, cmd *cobra.Command, args []string) error {
+	opts, err := auditRecordSearchOptionsFromFlags(cmd)
+	if err != nil {
+		return err
+	}
+	opts.Page, _ = cmd.Flags().GetInt("page")
+	opts.PerPage, _ = cmd.Flags().GetInt("per-page")
+
+	records, _, err := c.SearchAuditRecords(context.TODO(), opts)
+	if err != nil {
+		return fmt.Errorf("failed to search audit records: %w", err)
+	}
+
+	for _, record := range records {
+		createAt := time.UnixMilli(record.CreateAt).UTC().Format(ISO8601Layout)
+		printer.PrintT(createAt+" {{.EventName}} {{.Status}} user={{.UserId}} ip={{.IpAddress}} object={{.ObjectId}}", record)
+	}
+
+	return nil
+}
+
+func auditExportCmdF(c client.Client, cmd *cobra.Command, args []string) error {
+	opts, err := auditRecordSearchOptionsFromFlags(cmd)
+	if err != nil {
+		return err
+	}
+
+	format, _ := cmd.Flags().GetString("format")
+	if format != model.AuditRecordExportFormatCSV && format != model.AuditRecordExportFormatJSON {
+		return fmt.Errorf("invalid format '%s', must be csv or json", format)
+	}
+
+	output, _ := cmd.Flags().GetString("output")
+	var wr io.Writer = os.Stdout
+	if output != "" {
+		file, err := os.Create(output)
+		if err != nil {
+			return fmt.Errorf("failed to create export file: %w", err)
+		}
+		defer file.Close()
+		wr = file
+	}
+
+	if _, _, err := c.ExportAuditRecords(context.TODO(), opts, format, wr); err != nil {
+		return fmt.Errorf("failed to export audit records: %w", err)
+	}
+
+	if output != "" {
+		printer.Print(fmt.Sprintf("Audit records exported to %q", output))
+	}
+
+	return nil
+}
@@ -0,0 +1,231 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package commands
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	gomock "github.com/golang/mock/gomock"
+	"github.com/spf13/cobra"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/public/shared/mlog"
+	"github.com/mattermost/mattermost/server/v8/channels/audit"
+	"github.com/mattermost/mattermost/server/v8/cmd/mmctl/printer"
+)
+
+func (s *MmctlUnitTestSuite) TestAuditVerifyCmd() {
+	dir := s.T().TempDir()
+
+	pub, priv, err := ed25519.GenerateKey(rand.Reader)
+	s.Require().NoError(err)
+
+	privDER, err := x509.MarshalPKCS8PrivateKey(priv)
+	s.Require().NoError(err)
+	keyFile := filepath.Join(dir, "audit_chain.key")
+	s.Require().NoError(os.WriteFile(keyFile, pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: privDER}), 0600))
+
+	pubDER, err := x509.MarshalPKIXPublicKey(pub)
+	s.Require().NoError(err)
+	pubFile := filepath.Join(dir, "audit_chain.pub")
+	s.Require().NoError(os.WriteFile(pubFile, pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: pubDER}), 0600))
+
+	chainFile := filepath.Join(dir, "audit_chain.log")
+	targetCfg, err := audit.NewHashChainTargetCfg(audit.HashChainOptions{
+		Filename:           chainFile,
+		SigningKeyFile:     keyFile,
+		CheckpointInterval: 2,
+	}, audit.DefMaxQueueSize)
+	s.Require().NoError(err)
+
+	adt := &audit.Audit{}
+	adt.Init(audit.DefMaxQueueSize)
+	s.Require().NoError(adt.Configure(mlog.LoggerConfiguration{"hashchain": targetCfg}))
+	for range 3 {
+		adt.LogRecord(mlog.LvlAuditAPI, model.AuditRecord{EventName: "updateUser", Status: model.AuditStatusSuccess})
+	}
+	s.Require().NoError(adt.Shutdown())
+
+	newCmd := func() *cobra.Command {
+		cmd := &cobra.Command{}
+		cmd.Flags().String("public-key", pubFile, "")
+		return cmd
+	}
+
+	s.Run("Verify a valid audit log", func() {
+		printer.Clean()
+
+		err := auditVerifyCmdF(newCmd(), []string{chainFile})
+		s.Require().NoError(err)
+		s.Require().Len(printer.GetLines(), 1)
+		report := printer.GetLines()[0].(*audit.HashChainReport)
+		s.Require().Equal(int64(3), report.Records)
+		s.Require().Equal(int64(2), report.Checkpoints)
+		s.Require().Len(printer.GetErrorLines(), 0)
+	})
+
+	s.Run("Verify a tampered audit log", func() {
+		printer.Clean()
+
+		data, err := os.ReadFile(chainFile)
+		s.Require().NoError(err)
+		tamperedFile := filepath.Join(dir, "tampered.log")
+		s.Require().NoError(os.WriteFile(tamperedFile, []byte(strings.Replace(string(data), "updateUser", "deleteUser", 1)), 0600))
+
+		err = auditVerifyCmdF(newCmd(), []string{tamperedFile})
+		s.Require().Error(err)
+		s.Require().Len(printer.GetErrorLines(), 1)
+		s.Require().Contains(printer.GetErrorLines()[0], "line 1 (seq 1): hash does not match")
+	})
+
+	s.Run("Invalid public key", func() {
+		printer.Clean()
+
+		cmd := &cobra.Command{}
+		cmd.Flags().String("public-key", keyFile, "")
+
+		err := auditVerifyCmdF(cmd, []string{chainFile})
+		s.Require().Error(err)
+		s.Require().Len(printer.GetLines(), 0)
+	})
+}
+
+func (s *MmctlUnitTestSuite) TestAuditSearchCmd() {
+	newCmd := func() *cobra.Command {
+		cmd := &cobra.Command{}
+		addAuditRecordFilterFlags(cmd)
+		cmd.Flags().Int("page", 0, "")
+		cmd.Flags().Int("per-page", model.AuditRecordSearchDefaultPerPage, "")
+		return cmd
+	}
+
+	s.Run("Search with filters", func() {
+		printer.Clean()
+
+		userID := model.NewId()
+		records := []*model.StoredAuditRecord{
+			{Id: model.NewId(), CreateAt: 1735689600000, EventName: model.AuditEventLogin, Status: model.AuditStatusFail, UserId: userID},
+		}
+
+		s.client.
+			EXPECT().
+			SearchAuditRecords(context.TODO(), model.AuditRecordSearchOptions{
+				UserId:  userID,
+				Status:  model.AuditStatusFail,
+				Since:   1735689600000,
+				Page:    1,
+				PerPage: 10,
+			}).
+			Return(records, &model.Response{}, nil).
+			Times(1)
+
+		cmd := newCmd()
+		_ = cmd.Flags().Set("user", userID)
+		_ = cmd.Flags().Set("status", model.AuditStatusFail)
+		_ = cmd.Flags().Set("since", "2025-01-01T00:00:00+00:00")
+		_ = cmd.Flags().Set("page", "1")
+		_ = cmd.Flags().Set("per-page", "10")
+
+		err := auditSearchCmdF(s.client, cmd, []string{})
+		s.Require().NoError(err)
+		s.Require().Len(printer.GetLines(), 1)
+		s.Require().Equal(records[0], printer.GetLines()[0])
+		s.Require().Len(printer.GetErrorLines(), 0)
+	})
+
+	s.Run("Invalid time", func() {
+		printer.Clean()
+
+		cmd := newCmd()
+		_ = cmd.Flags().Set("until", "yesterday")
+
+		err := auditSearchCmdF(s.client, cmd, []string{})
+		s.Require().EqualError(err, "invalid until time 'yesterday'")
+	})
+
+	s.Run("Search fails", func() {
+		printer.Clean()
+
+		s.client.
+			EXPECT().
+			SearchAuditRecords(context.TODO(), model.AuditRecordSearchOptions{PerPage: model.AuditRecordSearchDefaultPerPage}).
+			Return(nil, &model.Response{}, errors.New("mock error")).
+			Times(1)
+
+		err := auditSearchCmdF(s.client, newCmd(), []string{})
+		s.Require().EqualError(err, "failed to search audit records: mock error")
+		s.Require().Len(printer.GetLines(), 0)
+	})
+}
+
+func (s *MmctlUnitTestSuite) TestAuditExportCmd() {
+	newCmd := func(format string) *cobra.Command {
+		cmd := &cobra.Command{}
+		addAuditRecordFilterFlags(cmd)
+		cmd.Flags().String("format", format, "")
+		cmd.Flags().StringP("output", "o", "", "")
+		return cmd
+	}
+
+	s.Run("Export to a file", func() {
+		printer.Clean()
+
+		output := filepath.Join(s.T().TempDir(), "audit.csv")
+
+		s.client.
+			EXPECT().
+			ExportAuditRecords(context.TODO(), model.AuditRecordSearchOptions{EventName: model.AuditEventLogin}, model.AuditRecordExportFormatCSV, gomock.Any()).
+			DoAndReturn(func(_ context.Context, _ model.AuditRecordSearchOptions, _ string, wr io.Writer) (int64, *model.Response, error) {
+				n, err := io.WriteString(wr, "id,create_at\n")
+				return int64(n), &model.Response{}, err
+			}).
+			Times(1)
+
+		cmd := newCmd(model.AuditRecordExportFormatCSV)
+		_ = cmd.Flags().Set("event", model.AuditEventLogin)
+		_ = cmd.Flags().Set("output", output)
+
+		err := auditExportCmdF(s.client, cmd, []string{})
+		s.Require().NoError(err)
+		s.Require().Len(printer.GetLines(), 1)
+		s.Require().Equal(`Audit records exported to "`+output+`"`, printer.GetLines()[0])
+
+		data, err := os.ReadFile(output)
+		s.Require().NoError(err)
+		s.Require().Equal("id,create_at\n", string(data))
+	})
+
+	s.Run("Invalid format", func() {
+		printer.Clean()
+
+		err := auditExportCmdF(s.client, newCmd("xml"), []string{})
+		s.Require().EqualError(err, "invalid format 'xml', must be csv or json")
+	})
+
+	s.Run("Export fails", func() {
+		printer.Clean()
+
+		s.client.
+			EXPECT().
+			ExportAuditRecords(context.TODO(), model.AuditRecordSearchOptions{}, model.AuditRecordExportFormatJSON, gomock.Any()).
+			Return(int64(0), &model.Response{}, errors.New("mock error")).
+			Times(1)
+
+		cmd := newCmd(model.AuditRecordExportFormatJSON)
+		_ = cmd.Flags().Set("output", filepath.Join(s.T().TempDir(), "audit.json"))
+
+		err := auditExportCmdF(s.client, cmd, []string{})
+		s.Require().EqualError(err, "failed to export audit records: mock error")
+		s.Require().Len(printer.GetLines(), 0)
+	})
+}
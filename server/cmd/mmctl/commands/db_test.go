@@ -0,0 +1,37 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package commands
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/mattermost/mattermost/server/public/model"
+)
+
+func (s *MmctlUnitTestSuite) TestDbMigrateToPostgresCmdF() {
+	newCommand := func(batchSize string) *cobra.Command {
+		cmd := &cobra.Command{}
+		cmd.Flags().Int("batch-size", 1000, "")
+		_ = cmd.Flags().Set("batch-size", batchSize)
+		return cmd
+	}
+
+	s.Run("should fail with a destination that isn't Postgres", func() {
+		err := dbMigrateToPostgresCmdF(newCommand("1000"), []string{"mattermost.sqlite", "mmuser:password@tcp(localhost:3306)/mattermost"})
+		s.Require().EqualError(err, "the destination must be a Postgres data source, starting with postgres://")
+	})
+
+	s.Run("should fail with a batch size that isn't positive", func() {
+		err := dbMigrateToPostgresCmdF(newCommand("0"), []string{"mattermost.sqlite", "postgres://localhost/mattermost"})
+		s.Require().EqualError(err, "batch-size must be positive")
+	})
+}
+
+func (s *MmctlUnitTestSuite) TestDbSettings() {
+	settings := dbSettings(model.DatabaseDriverSqlite, "mattermost.sqlite")
+	s.Require().Equal(model.DatabaseDriverSqlite, *settings.DriverName)
+	s.Require().Equal("mattermost.sqlite", *settings.DataSource)
+	s.Require().NotNil(settings.QueryTimeout)
+	s.Require().NotNil(settings.MaxOpenConns)
+}
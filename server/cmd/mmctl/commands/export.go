@@ -8,6 +8,8 @@ import (
 	"fmt"
 	"io"
 	"os"
+	"strconv"
+	"time"
 
 	"github.com/mattermost/mattermost/server/v8/cmd/mmctl/client"
 	"github.com/mattermost/mattermost/server/v8/cmd/mmctl/printer"
@@ -103,6 +105,7 @@ func init() {
 	ExportCreateCmd.Flags().Bool("include-archived-channels", false, "Include archived channels in the export file.")
 	ExportCreateCmd.Flags().Bool("include-profile-pictures", false, "Include profile pictures in the export file.")
 	ExportCreateCmd.Flags().Bool("no-roles-and-schemes", false, "Exclude roles and custom permission schemes from the export file.")
+	ExportCreateCmd.Flags().String("since", "", "Only export the changes made since a time, given in RFC3339 format or in milliseconds, or since the start of a previous export job, given by its ID.")
 
 	ExportDownloadCmd.Flags().Bool("resume", false, "Set to true to resume an export download.")
 	_ = ExportDownloadCmd.Flags().MarkHidden("resume")
@@ -154,6 +157,13 @@ func exportCreateCmdF(c client.Client, command *cobra.Command, args []string) er
 		data["include_profile_pictures"] = "true"
 	}
 
+	since, _ := command.Flags().GetString("since")
+	if since != "" {
+		if err := addExportSinceData(data, since); err != nil {
+			return err
+		}
+	}
+
 	job, _, err := c.CreateJob(context.TODO(), &model.Job{
 		Type: model.JobTypeExportProcess,
 		Data: data,
@@ -167,6 +177,27 @@ func exportCreateCmdF(c client.Client, command *cobra.Command, args []string) er
 	return nil
 }
 
+// addExportSinceData sets the job data of an incremental export from the
+// value of the --since flag, which is either an export job ID or a time.
+func addExportSinceData(data map[string]string, since string) error {
+	if model.IsValidId(since) {
+		data["previous_job_id"] = since
+		return nil
+	}
+
+	if t, err := time.Parse(time.RFC3339, since); err == nil {
+		data["since"] = strconv.FormatInt(model.GetMillisForTime(t), 10)
+		return nil
+	}
+
+	if millis, err := strconv.ParseInt(since, 10, 64); err == nil && millis >= 0 {
+		data["since"] = strconv.FormatInt(millis, 10)
+		return nil
+	}
+
+	return fmt.Errorf("invalid --since value %q: must be an export job ID, an RFC3339 time or a time in milliseconds", since)
+}
+
 func exportListCmdF(c client.Client, command *cobra.Command, args []string) error {
 	exports, _, err := c.ListExports(context.TODO())
 	if err != nil {
@@ -4,10 +4,13 @@
 package commands
 
 import (
+	"archive/zip"
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
+	"io/fs"
 	"os"
 	"path"
 	"path/filepath"
@@ -19,6 +22,7 @@ import (
 	"github.com/mattermost/mattermost/server/public/model"
 	"github.com/spf13/cobra"
 
+	"github.com/mattermost/mattermost/server/v8/channels/app/imports"
 	"github.com/mattermost/mattermost/server/v8/cmd/mmctl/client"
 	"github.com/mattermost/mattermost/server/v8/cmd/mmctl/commands/importer"
 	"github.com/mattermost/mattermost/server/v8/cmd/mmctl/printer"
@@ -108,6 +112,36 @@ var ImportValidateCmd = &cobra.Command{
 	},
 }
 
+var ImportConvertCmd = &cobra.Command{
+	Use:   "convert",
+	Short: "Convert exports of other chat services into import files",
+}
+
+var ImportConvertTeamsCmd = &cobra.Command{
+	Use:     "teams [export] [output]",
+	Example: "  import convert teams msteams_export.zip import_file.zip",
+	Short:   "Convert a Microsoft Teams export into an import file",
+	Long: `Convert a Microsoft Teams export into an import file. The export is a directory or a zip file holding the Microsoft Graph
+resources of the users, teams, channels and chats: users.json, teams/<id>/team.json, teams/<id>/members.json,
+teams/<id>/channels/<id>/channel.json, members.json, messages.json and files/, and chats/<id>/chat.json, messages.json and files/.`,
+	Args: cobra.ExactArgs(2),
+	RunE: func(command *cobra.Command, args []string) error {
+		return importConvertCmdF(command, args, importer.ConvertMSTeams)
+	},
+}
+
+var ImportConvertDiscordCmd = &cobra.Command{
+	Use:     "discord [export] [output]",
+	Example: "  import convert discord discord_export/ import_file.zip --team myteam",
+	Short:   "Convert a Discord export into an import file",
+	Long: `Convert a Discord export into an import file. The export is a directory or a zip file holding either the JSON
+exports of DiscordChatExporter, with their media, or a Discord data package.`,
+	Args: cobra.ExactArgs(2),
+	RunE: func(command *cobra.Command, args []string) error {
+		return importConvertCmdF(command, args, importer.ConvertDiscord)
+	},
+}
+
 func init() {
 	ImportUploadCmd.Flags().Bool("resume", false, "Set to true to resume an incomplete import upload.")
 	ImportUploadCmd.Flags().String("upload", "", "The ID of the import upload to resume.")
@@ -121,7 +155,15 @@ func init() {
 	ImportValidateCmd.Flags().Bool("ignore-attachments", false, "Don't check if the attached files are present in the archive")
 	ImportValidateCmd.Flags().Bool("check-server-duplicates", true, "Set to false to ignore teams, channels, and users already present on the server")
 
+	for _, cmd := range []*cobra.Command{ImportConvertTeamsCmd, ImportConvertDiscordCmd} {
+		cmd.Flags().Bool("no-attachments", false, "Leave the attached files out of the import file")
+		cmd.Flags().Bool("validate", true, "Validate the import file once converted")
+	}
+	ImportConvertDiscordCmd.Flags().String("team", "", "Existing team to import all the channels into, instead of creating a team per Discord server")
+
 	ImportProcessCmd.Flags().Bool("bypass-upload", false, "If this is set, the file is not processed from the server, but rather directly read from the filesystem. Works only in --local mode.")
+	ImportProcessCmd.Flags().Bool("dry-run", false, "Validate the import file against the server and report what the import would create, update or skip, without importing anything. The report is shown by \"import job show\" once the job is done.")
+	ImportProcessCmd.Flags().String("conflict-policy", string(imports.ConflictPolicyOverwrite), "What to do with the teams, channels, users and emoji that already exist: \"overwrite\" them, \"skip\" them, \"merge\" the imported memberships into them, or \"rename\" the imported ones.")
 	ImportProcessCmd.Flags().Bool("extract-content", true, "If this is set, document attachments will be extracted and indexed during the import process. It is advised to disable it to improve performance.")
 
 	ImportListCmd.AddCommand(
@@ -132,12 +174,17 @@ func init() {
 		ImportJobListCmd,
 		ImportJobShowCmd,
 	)
+	ImportConvertCmd.AddCommand(
+		ImportConvertTeamsCmd,
+		ImportConvertDiscordCmd,
+	)
 	ImportCmd.AddCommand(
 		ImportUploadCmd,
 		ImportListCmd,
 		ImportProcessCmd,
 		ImportJobCmd,
 		ImportValidateCmd,
+		ImportConvertCmd,
 		ImportDeleteCmd,
 	)
 	RootCmd.AddCommand(ImportCmd)
@@ -310,14 +357,27 @@ func importProcessCmdF(c client.Client, command *cobra.Command, args []string) e
 	}
 
 	extractContent, _ := command.Flags().GetBool("extract-content")
+	dryRun, _ := command.Flags().GetBool("dry-run")
+	conflictPolicy, _ := command.Flags().GetString("conflict-policy")
+	if conflictPolicy != "" && !imports.ConflictPolicy(conflictPolicy).IsValid() {
+		return fmt.Errorf("invalid conflict policy %q, must be one of overwrite, skip, merge or rename", conflictPolicy)
+	}
+
+	data := map[string]string{
+		"import_file":     importFile,
+		"local_mode":      strconv.FormatBool(isLocal && bypassUpload),
+		"extract_content": strconv.FormatBool(extractContent),
+	}
+	if dryRun {
+		data["dry_run"] = "true"
+	}
+	if conflictPolicy != "" {
+		data["conflict_policy"] = conflictPolicy
+	}
 
 	job, _, err := c.CreateJob(context.TODO(), &model.Job{
 		Type: model.JobTypeImportProcess,
-		Data: map[string]string{
-			"import_file":     importFile,
-			"local_mode":      strconv.FormatBool(isLocal && bypassUpload),
-			"extract_content": strconv.FormatBool(extractContent),
-		},
+		Data: data,
 	})
 	if err != nil {
 		return fmt.Errorf("failed to create import process job: %w", err)
@@ -336,6 +396,14 @@ func importJobShowCmdF(c client.Client, command *cobra.Command, args []string) e
 
 	printJob(job)
 
+	if reportJSON := job.Data["import_report"]; reportJSON != "" {
+		var report imports.ImportReport
+		if err := json.Unmarshal([]byte(reportJSON), &report); err != nil {
+			return fmt.Errorf("failed to decode the import report: %w", err)
+		}
+		printImportReport(&report)
+	}
+
 	return nil
 }
 
@@ -540,6 +608,135 @@ func importValidateCmdF(c client.Client, command *cobra.Command, args []string)
 	return nil
 }
 
+type convertFunc func(src fs.FS, w io.Writer, opts importer.ConvertOptions) (*importer.ConvertStats, error)
+
+func importConvertCmdF(command *cobra.Command, args []string, convert convertFunc) error {
+	configurePrinter()
+
+	var opts importer.ConvertOptions
+	opts.SkipAttachments, _ = command.Flags().GetBool("no-attachments")
+	if command.Flags().Lookup("team") != nil {
+		opts.Team, _ = command.Flags().GetString("team")
+	}
+	validate, _ := command.Flags().GetBool("validate")
+
+	src, closeSrc, err := openConvertSource(args[0])
+	if err != nil {
+		return err
+	}
+	defer closeSrc()
+
+	output, err := os.Create(args[1])
+	if err != nil {
+		return fmt.Errorf("failed to create output file: %w", err)
+	}
+
+	stats, err := convert(src, output, opts)
+	if closeErr := output.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		os.Remove(args[1])
+		return fmt.Errorf("failed to convert %s: %w", args[0], err)
+	}
+
+	printer.PrintT("Converted {{ .Teams }} teams, {{ .Channels }} channels, {{ .Users }} users, "+
+		"{{ .Posts }} posts with {{ .Replies }} replies, {{ .DirectChannels }} direct channels, {{ .DirectPosts }} direct posts, "+
+		"{{ .Reactions }} reactions and {{ .Attachments }} attachments\n"+
+		"{{ range .Warnings }}  {{ . }}\n{{ end }}", stats)
+
+	if !validate {
+		return nil
+	}
+
+	return validateConvertedFile(args[1], opts)
+}
+
+// openConvertSource opens an export to convert, either a directory or a zip
+// file.
+func openConvertSource(name string) (fs.FS, func(), error) {
+	info, err := os.Stat(name)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to open export: %w", err)
+	}
+	if info.IsDir() {
+		return os.DirFS(name), func() {}, nil
+	}
+
+	zr, err := zip.OpenReader(name)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to open export: %w", err)
+	}
+	return zr, func() { zr.Close() }, nil
+}
+
+// validateConvertedFile validates a converted import file offline, as the
+// converted teams, channels and users are expected to be new.
+func validateConvertedFile(name string, opts importer.ConvertOptions) error {
+	serverTeams := map[string]*model.Team{}
+	if opts.Team != "" {
+		serverTeams[opts.Team] = &model.Team{
+			Id:          "<predefined>",
+			Name:        opts.Team,
+			DisplayName: "team was predefined",
+		}
+	}
+
+	validator := importer.NewValidator(
+		name,
+		opts.SkipAttachments,
+		opts.Team == "",
+		false,
+		serverTeams,
+		map[importer.ChannelTeam]*model.Channel{},
+		map[string]*model.User{},
+		map[string]*model.User{},
+		model.PostMessageMaxRunesV2,
+	)
+
+	var errors []*importer.ImportValidationError
+	templateError := template.Must(template.New("").Parse("{{ .Error }}\n"))
+	validator.OnError(func(ive *importer.ImportValidationError) error {
+		printer.PrintPreparedT(templateError, ive)
+		errors = append(errors, ive)
+		return nil
+	})
+
+	if err := validator.Validate(); err != nil {
+		return err
+	}
+
+	printStatistics(Statistics{
+		Teams:          validator.TeamCount(),
+		Channels:       validator.ChannelCount(),
+		Users:          validator.UserCount(),
+		Posts:          validator.PostCount(),
+		DirectChannels: validator.DirectChannelCount(),
+		DirectPosts:    validator.DirectPostCount(),
+		Attachments:    uint64(len(validator.Attachments())),
+	})
+
+	printer.PrintT("It took {{ .Elapsed }} to validate {{ .TotalLines }} lines in {{ .FileName }}\n", ImportValidationResult{name, validator.Lines(), validator.Duration(), errors})
+
+	if len(errors) > 0 {
+		return fmt.Errorf("the converted import file has %d validation errors", len(errors))
+	}
+
+	return nil
+}
+
+func printImportReport(report *imports.ImportReport) {
+	tmpl := "\n" +
+		"{{ if .DryRun }}Dry run{{ else }}Import{{ end }} report, conflict policy {{ .ConflictPolicy }}:\n" +
+		"  Entity        Create   Update   Skip     Merge    Rename\n" +
+		"{{ range $entity, $counts := .Entities }}" +
+		"  {{ printf \"%-13s %-8d %-8d %-8d %-8d %d\" $entity $counts.Create $counts.Update $counts.Skip $counts.Merge $counts.Rename }}\n" +
+		"{{ end }}" +
+		"{{ if .Renames }}Renamed:\n{{ range .Renames }}  {{ .Entity }} {{ if .Team }}{{ .Team }}/{{ end }}{{ .From }} -> {{ .To }}\n{{ end }}{{ end }}"
+
+	printer.PrintT(tmpl, report)
+}
+
 func configurePrinter() {
 	// we want to manage the newlines ourselves
 	printer.SetNoNewline(true)
@@ -7,6 +7,7 @@ import (
 	"archive/zip"
 	"context"
 	"fmt"
+	"io/fs"
 	"net/http"
 	"os"
 	"path/filepath"
@@ -15,6 +16,8 @@ import (
 	"github.com/pkg/errors"
 	"github.com/spf13/cobra"
 
+	"github.com/mattermost/mattermost/server/v8/channels/app/imports"
+	"github.com/mattermost/mattermost/server/v8/cmd/mmctl/commands/importer"
 	"github.com/mattermost/mattermost/server/v8/cmd/mmctl/printer"
 
 	"github.com/mattermost/mattermost/server/public/model"
@@ -148,6 +151,33 @@ func (s *MmctlUnitTestSuite) TestImportJobShowCmdF() {
 		s.Empty(printer.GetErrorLines())
 		s.Equal(mockJob, printer.GetLines()[0].(*model.Job))
 	})
+
+	s.Run("with an import report", func() {
+		printer.Clean()
+		mockJob := &model.Job{
+			Id: model.NewId(),
+			Data: map[string]string{
+				"import_report": `{"dry_run":true,"conflict_policy":"rename","entities":{"team":{"create":1,"update":0,"skip":0,"merge":0,"rename":1}},"renames":[{"entity":"team","from":"ad-1","to":"ad-1-2"}]}`,
+			},
+		}
+
+		s.client.
+			EXPECT().
+			GetJob(context.TODO(), mockJob.Id).
+			Return(mockJob, &model.Response{}, nil).
+			Times(1)
+
+		err := importJobShowCmdF(s.client, &cobra.Command{}, []string{mockJob.Id})
+		s.Require().Nil(err)
+		s.Len(printer.GetLines(), 2)
+		s.Empty(printer.GetErrorLines())
+
+		report := printer.GetLines()[1].(*imports.ImportReport)
+		s.True(report.DryRun)
+		s.Equal(imports.ConflictPolicyRename, report.ConflictPolicy)
+		s.Equal(&imports.ImportEntityReport{Create: 1, Rename: 1}, report.Entities["team"])
+		s.Equal([]imports.ImportRename{{Entity: "team", From: "ad-1", To: "ad-1-2"}}, report.Renames)
+	})
 }
 
 func (s *MmctlUnitTestSuite) TestImportJobListCmdF() {
@@ -233,6 +263,46 @@ func (s *MmctlUnitTestSuite) TestImportProcessCmdF() {
 	s.Len(printer.GetLines(), 1)
 	s.Empty(printer.GetErrorLines())
 	s.Equal(mockJob, printer.GetLines()[0].(*model.Job))
+
+	s.Run("dry run with a conflict policy", func() {
+		printer.Clean()
+		mockJob := &model.Job{
+			Type: model.JobTypeImportProcess,
+			Data: map[string]string{
+				"import_file":     importFile,
+				"local_mode":      "false",
+				"extract_content": "false",
+				"dry_run":         "true",
+				"conflict_policy": "rename",
+			},
+		}
+
+		s.client.
+			EXPECT().
+			CreateJob(context.TODO(), mockJob).
+			Return(mockJob, &model.Response{}, nil).
+			Times(1)
+
+		cmd := &cobra.Command{}
+		cmd.Flags().Bool("dry-run", true, "")
+		cmd.Flags().String("conflict-policy", "rename", "")
+
+		err := importProcessCmdF(s.client, cmd, []string{importFile})
+		s.Require().Nil(err)
+		s.Empty(printer.GetErrorLines())
+		s.Equal(mockJob, printer.GetLines()[0].(*model.Job))
+	})
+
+	s.Run("invalid conflict policy", func() {
+		printer.Clean()
+
+		cmd := &cobra.Command{}
+		cmd.Flags().String("conflict-policy", "replace", "")
+
+		err := importProcessCmdF(s.client, cmd, []string{importFile})
+		s.Require().EqualError(err, `invalid conflict policy "replace", must be one of overwrite, skip, merge or rename`)
+		s.Empty(printer.GetLines())
+	})
 }
 
 func (s *MmctlUnitTestSuite) TestImportValidateCmdF() {
@@ -537,6 +607,155 @@ func (s *MmctlUnitTestSuite) TestImportValidateCmdF() {
 	})
 }
 
+func writeConvertFiles(dir string, files map[string]string) error {
+	for name, content := range files {
+		name = filepath.Join(dir, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(name), 0700); err != nil {
+			return err
+		}
+		if err := os.WriteFile(name, []byte(content), 0600); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *MmctlUnitTestSuite) TestImportConvertCmdF() {
+	s.Run("microsoft teams", func() {
+		dir := s.T().TempDir()
+		err := writeConvertFiles(filepath.Join(dir, "export"), map[string]string{
+			"users.json": `{"value":[
+				{"id":"u1","displayName":"Ada Lovelace","givenName":"Ada","surname":"Lovelace","mail":"ada@example.com","userPrincipalName":"ada@example.com"},
+				{"id":"u2","displayName":"Alan Turing","userPrincipalName":"alan@example.com"}]}`,
+			"teams/t1/team.json":                 `{"id":"t1","displayName":"Engineering","visibility":"private"}`,
+			"teams/t1/members.json":              `[{"userId":"u1","roles":["owner"]},{"userId":"u2"}]`,
+			"teams/t1/channels/c1/channel.json":  `{"id":"c1","displayName":"General","membershipType":"standard"}`,
+			"teams/t1/channels/c1/files/doc.txt": `hello`,
+			"teams/t1/channels/c1/messages.json": `[
+				{"id":"m1","messageType":"message","createdDateTime":"2024-01-02T10:00:00Z","from":{"user":{"id":"u1","displayName":"Ada Lovelace"}},
+				 "body":{"contentType":"html","content":"<p>Hello <at id=\"0\">Alan Turing</at>, <b>welcome</b></p>"},
+				 "mentions":[{"id":0,"mentioned":{"user":{"id":"u2","displayName":"Alan Turing"}}}],
+				 "attachments":[{"id":"a1","contentType":"reference","name":"doc.txt"}],
+				 "reactions":[{"reactionType":"like","createdDateTime":"2024-01-02T10:05:00Z","user":{"user":{"id":"u2"}}}],
+				 "replies":[{"id":"m2","replyToId":"m1","messageType":"message","createdDateTime":"2024-01-02T10:10:00Z","from":{"user":{"id":"u2","displayName":"Alan Turing"}},"body":{"contentType":"text","content":"Thanks"}}]},
+				{"id":"m3","messageType":"systemEventMessage","createdDateTime":"2024-01-02T11:00:00Z","body":{"contentType":"html","content":""}}]`,
+			"chats/ch1/chat.json":     `{"id":"ch1","chatType":"oneOnOne","members":[{"userId":"u1"},{"userId":"u2"}]}`,
+			"chats/ch1/messages.json": `[{"id":"d1","messageType":"message","createdDateTime":"2024-01-03T09:00:00Z","from":{"user":{"id":"u2"}},"body":{"contentType":"text","content":"Ping"}}]`,
+		})
+		s.Require().NoError(err)
+
+		output := filepath.Join(dir, "import.zip")
+		printer.Clean()
+		err = importConvertCmdF(newConvertCommand(false), []string{filepath.Join(dir, "export"), output}, importer.ConvertMSTeams)
+		s.Require().NoError(err)
+		s.Empty(printer.GetErrorLines())
+
+		stats := printer.GetLines()[0].(*importer.ConvertStats)
+		s.Equal(1, stats.Teams)
+		s.Equal(1, stats.Channels)
+		s.Equal(2, stats.Users)
+		s.Equal(1, stats.Posts)
+		s.Equal(1, stats.Replies)
+		s.Equal(1, stats.DirectChannels)
+		s.Equal(1, stats.DirectPosts)
+		s.Equal(1, stats.Reactions)
+		s.Equal(1, stats.Attachments)
+		s.Empty(stats.Warnings)
+
+		printer.Clean()
+		err = validateConvertedFile(output, importer.ConvertOptions{})
+		s.Require().NoError(err)
+		s.Equal(Statistics{
+			Teams:          1,
+			Channels:       1,
+			Users:          2,
+			Posts:          1,
+			DirectChannels: 1,
+			DirectPosts:    1,
+			Attachments:    1,
+		}, printer.GetLines()[0].(Statistics))
+		s.Empty(printer.GetLines()[1].(ImportValidationResult).Errors)
+
+		lines := readConvertedLines(s, output)
+		s.Contains(lines, `"name":"town-square"`)
+		s.Contains(lines, `"message":"Hello @alan, **welcome**"`)
+		s.Contains(lines, `"emoji_name":"+1"`)
+	})
+
+	s.Run("discord chat exporter", func() {
+		dir := s.T().TempDir()
+		author := `{"id":"100","name":"ada","discriminator":"0000","nickname":"Ada"}`
+		other := `{"id":"200","name":"alan","discriminator":"0000","nickname":"alan"}`
+		err := writeConvertFiles(filepath.Join(dir, "export"), map[string]string{
+			"general.json": `{"guild":{"id":"1","name":"Computing Club"},"channel":{"id":"10","type":"GuildTextChat","name":"general","topic":"Chat"},"messages":[
+				{"id":"11","type":"Default","timestamp":"2024-01-02T10:00:00+00:00","content":"Hi <@200>","author":` + author + `,"mentions":[` + other + `],
+				 "attachments":[{"id":"a1","url":"general.json_Files/doc.txt","fileName":"doc.txt"}],
+				 "reactions":[{"emoji":{"id":"","name":"👍","code":"thumbsup"},"count":1,"users":[` + other + `]}]},
+				{"id":"12","type":"Reply","timestamp":"2024-01-02T10:01:00+00:00","content":"Hello","author":` + other + `,"reference":{"messageId":"11","channelId":"10"}},
+				{"id":"13","type":"ChannelPinnedMessage","timestamp":"2024-01-02T10:02:00+00:00","content":"","author":` + author + `}]}`,
+			"general.json_Files/doc.txt": "hello",
+			"thread.json": `{"guild":{"id":"1","name":"Computing Club"},"channel":{"id":"11","type":"GuildPublicThread","categoryId":"10","category":"general","name":"Hi"},"messages":[
+				{"id":"14","type":"Default","timestamp":"2024-01-02T11:00:00+00:00","content":"In the thread","author":` + other + `}]}`,
+			"dm.json": `{"guild":{"id":"0","name":"Direct Messages"},"channel":{"id":"20","type":"DirectTextChat","name":"alan"},"messages":[
+				{"id":"21","type":"Default","timestamp":"2024-01-03T09:00:00+00:00","content":"Ping","author":` + author + `}]}`,
+		})
+		s.Require().NoError(err)
+
+		output := filepath.Join(dir, "import.zip")
+		printer.Clean()
+		err = importConvertCmdF(newConvertCommand(false), []string{filepath.Join(dir, "export"), output}, importer.ConvertDiscord)
+		s.Require().NoError(err)
+		s.Empty(printer.GetErrorLines())
+
+		stats := printer.GetLines()[0].(*importer.ConvertStats)
+		s.Equal(1, stats.Teams)
+		s.Equal(1, stats.Channels)
+		s.Equal(2, stats.Users)
+		s.Equal(1, stats.Posts)
+		s.Equal(2, stats.Replies)
+		s.Equal(1, stats.DirectChannels)
+		s.Equal(1, stats.DirectPosts)
+		s.Equal(1, stats.Reactions)
+		s.Equal(1, stats.Attachments)
+		s.Empty(stats.Warnings)
+
+		printer.Clean()
+		err = validateConvertedFile(output, importer.ConvertOptions{})
+		s.Require().NoError(err)
+		s.Empty(printer.GetLines()[1].(ImportValidationResult).Errors)
+
+		lines := readConvertedLines(s, output)
+		s.Contains(lines, `"name":"computing-club"`)
+		s.Contains(lines, `"message":"Hi @alan"`)
+		s.Contains(lines, `"emoji_name":"thumbsup"`)
+	})
+
+	s.Run("missing export", func() {
+		dir := s.T().TempDir()
+		printer.Clean()
+		err := importConvertCmdF(newConvertCommand(true), []string{filepath.Join(dir, "missing"), filepath.Join(dir, "import.zip")}, importer.ConvertDiscord)
+		s.Require().Error(err)
+		s.NoFileExists(filepath.Join(dir, "import.zip"))
+	})
+}
+
+func newConvertCommand(validate bool) *cobra.Command {
+	cmd := &cobra.Command{}
+	cmd.Flags().Bool("no-attachments", false, "")
+	cmd.Flags().Bool("validate", validate, "")
+	return cmd
+}
+
+func readConvertedLines(s *MmctlUnitTestSuite, name string) string {
+	zr, err := zip.OpenReader(name)
+	s.Require().NoError(err)
+	defer zr.Close()
+
+	data, err := fs.ReadFile(zr, "import.jsonl")
+	s.Require().NoError(err)
+	return string(data)
+}
+
 func (s *MmctlUnitTestSuite) TestDeleteImportCmdF() {
 	s.Run("delete command succeeds", func() {
 		printer.Clean()
@@ -0,0 +1,278 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package importer
+
+import (
+	"archive/zip"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/fs"
+	"path"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"unicode"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/v8/channels/app/imports"
+)
+
+// ConvertOptions are the options shared by the converters of third party
+// exports into bulk import archives.
+type ConvertOptions struct {
+	// Team, when set, is the name of the team all the converted channels
+	// are imported into, instead of a team per Discord server.
+	Team string
+	// SkipAttachments leaves the attached files out of the archive.
+	SkipAttachments bool
+}
+
+// ConvertStats counts what a converter wrote to the archive and what it had
+// to leave out.
+type ConvertStats struct {
+	Teams          int      `json:"teams"`
+	Channels       int      `json:"channels"`
+	Users          int      `json:"users"`
+	Posts          int      `json:"posts"`
+	Replies        int      `json:"replies"`
+	DirectChannels int      `json:"direct_channels"`
+	DirectPosts    int      `json:"direct_posts"`
+	Reactions      int      `json:"reactions"`
+	Attachments    int      `json:"attachments"`
+	Warnings       []string `json:"warnings"`
+}
+
+func (s *ConvertStats) warnf(format string, args ...any) {
+	s.Warnings = append(s.Warnings, fmt.Sprintf(format, args...))
+}
+
+// bulkImportData accumulates the converted lines, as the import file must
+// list them grouped by type.
+type bulkImportData struct {
+	teams          []imports.LineImportData
+	channels       []imports.LineImportData
+	userLines      []imports.LineImportData
+	posts          []imports.LineImportData
+	directChannels []imports.LineImportData
+	directPosts    []imports.LineImportData
+
+	attachments []convertedAttachment
+	stats       ConvertStats
+}
+
+type convertedAttachment struct {
+	src  fs.FS
+	from string
+	to   string
+}
+
+// addAttachment records a file of the source to be copied to the archive and
+// returns its import data, or nil if the file doesn't exist.
+func (d *bulkImportData) addAttachment(src fs.FS, from, id, name string) *imports.AttachmentImportData {
+	if _, err := fs.Stat(src, from); err != nil {
+		d.stats.warnf("attachment %q not found in the export", from)
+		return nil
+	}
+
+	to := path.Join("attachments", sanitizeFileName(id), sanitizeFileName(name))
+	d.attachments = append(d.attachments, convertedAttachment{src: src, from: from, to: to})
+	d.stats.Attachments++
+
+	return &imports.AttachmentImportData{Path: model.NewPointer(to)}
+}
+
+// write writes the import archive: the import.jsonl file first, followed by
+// the attachments under the data directory.
+func (d *bulkImportData) write(w io.Writer) error {
+	zw := zip.NewWriter(w)
+
+	jsonl, err := zw.Create("import.jsonl")
+	if err != nil {
+		return fmt.Errorf("error creating the import file: %w", err)
+	}
+
+	enc := json.NewEncoder(jsonl)
+	if err := enc.Encode(imports.LineImportData{Type: LineTypeVersion, Version: model.NewPointer(1)}); err != nil {
+		return fmt.Errorf("error writing the import file: %w", err)
+	}
+	for _, group := range [][]imports.LineImportData{d.teams, d.channels, d.userLines, d.posts, d.directChannels, d.directPosts} {
+		for i := range group {
+			if err := enc.Encode(&group[i]); err != nil {
+				return fmt.Errorf("error writing the import file: %w", err)
+			}
+		}
+	}
+
+	for _, attachment := range d.attachments {
+		if err := copyToArchive(zw, attachment); err != nil {
+			return err
+		}
+	}
+
+	return zw.Close()
+}
+
+func copyToArchive(zw *zip.Writer, attachment convertedAttachment) error {
+	in, err := attachment.src.Open(attachment.from)
+	if err != nil {
+		return fmt.Errorf("error opening attachment %q: %w", attachment.from, err)
+	}
+	defer in.Close()
+
+	out, err := zw.Create(path.Join(model.ExportDataDir, attachment.to))
+	if err != nil {
+		return fmt.Errorf("error adding attachment %q to the archive: %w", attachment.from, err)
+	}
+
+	if _, err := io.Copy(out, in); err != nil {
+		return fmt.Errorf("error copying attachment %q: %w", attachment.from, err)
+	}
+
+	return nil
+}
+
+// nameRegistry hands out unique names, as different entities of the source
+// may map to the same name once sanitized.
+type nameRegistry map[string]bool
+
+func (r nameRegistry) unique(name string, maxLen int) string {
+	candidate := name
+	for i := 2; r[candidate]; i++ {
+		suffix := "-" + strconv.Itoa(i)
+		base := name
+		if len(base)+len(suffix) > maxLen {
+			base = base[:maxLen-len(suffix)]
+		}
+		candidate = base + suffix
+	}
+	r[candidate] = true
+	return candidate
+}
+
+var invalidNameChars = regexp.MustCompile(`[^a-z0-9_-]+`)
+
+// sanitizeName turns a display name into a valid team or channel name.
+func sanitizeName(displayName, fallback string, maxLen int) string {
+	name := invalidNameChars.ReplaceAllString(strings.ToLower(strings.TrimSpace(displayName)), "-")
+	name = strings.Trim(name, "-_")
+	if len(name) > maxLen {
+		name = strings.Trim(name[:maxLen], "-_")
+	}
+	if len(name) < 2 {
+		name = fallback
+	}
+	return name
+}
+
+var invalidUsernameChars = regexp.MustCompile(`[^a-z0-9._-]+`)
+
+// sanitizeUsername turns a name into a valid username.
+func sanitizeUsername(name string) string {
+	username := invalidUsernameChars.ReplaceAllString(strings.ToLower(name), "")
+	username = strings.TrimLeftFunc(username, func(r rune) bool { return !unicode.IsLetter(r) })
+	if len(username) > model.UserNameMaxLength {
+		username = username[:model.UserNameMaxLength]
+	}
+	if !model.IsValidUsername(username) {
+		return ""
+	}
+	return username
+}
+
+// uniqueUsername returns a username that is valid and not yet taken.
+func (r nameRegistry) uniqueUsername(name, fallback string) string {
+	username := sanitizeUsername(name)
+	if username == "" {
+		username = sanitizeUsername(fallback)
+	}
+	if username == "" {
+		username = "user"
+	}
+
+	candidate := username
+	for i := 2; r[candidate] || !model.IsValidUsername(candidate); i++ {
+		suffix := strconv.Itoa(i)
+		base := username
+		if len(base)+len(suffix) > model.UserNameMaxLength {
+			base = base[:model.UserNameMaxLength-len(suffix)]
+		}
+		candidate = base + suffix
+	}
+	r[candidate] = true
+	return candidate
+}
+
+func sanitizeFileName(name string) string {
+	name = strings.Map(func(r rune) rune {
+		if r == '/' || r == '\\' || r < ' ' {
+			return '_'
+		}
+		return r
+	}, name)
+	if name == "" || name == "." || name == ".." {
+		return "_"
+	}
+	return name
+}
+
+// truncateMessage cuts messages longer than the default maximum post size.
+func (d *bulkImportData) truncateMessage(message, ref string) string {
+	if len([]rune(message)) <= model.PostMessageMaxRunesV2 {
+		return message
+	}
+	d.stats.warnf("message %s was truncated to %d characters", ref, model.PostMessageMaxRunesV2)
+	return string([]rune(message)[:model.PostMessageMaxRunesV2])
+}
+
+// sortedReactions returns the reactions sorted by time so that the output
+// is stable.
+func sortedReactions(reactions []imports.ReactionImportData) *[]imports.ReactionImportData {
+	if len(reactions) == 0 {
+		return nil
+	}
+	sort.SliceStable(reactions, func(i, j int) bool {
+		return *reactions[i].CreateAt < *reactions[j].CreateAt
+	})
+	return &reactions
+}
+
+var (
+	systemEmojiNamesOnce sync.Once
+	systemEmojiNames     map[string]string
+)
+
+// emojiNameFromUnicode returns the name of the system emoji written as the
+// given unicode characters.
+func emojiNameFromUnicode(emoji string) (string, bool) {
+	systemEmojiNamesOnce.Do(func() {
+		systemEmojiNames = make(map[string]string, len(model.SystemEmojis))
+		for name, code := range model.SystemEmojis {
+			// Variation selectors aren't part of the lookup, as they are
+			// optional in the characters of an emoji.
+			code = strings.ReplaceAll(code, "-fe0f", "")
+			// Several names share a code, the shortest one is kept so that
+			// the mapping is stable.
+			if existing, ok := systemEmojiNames[code]; !ok || len(name) < len(existing) || (len(name) == len(existing) && name < existing) {
+				systemEmojiNames[code] = name
+			}
+		}
+	})
+
+	var codes []string
+	for _, r := range emoji {
+		if r == 0xfe0f {
+			continue
+		}
+		codes = append(codes, strconv.FormatInt(int64(r), 16))
+	}
+	if len(codes) == 0 {
+		return "", false
+	}
+
+	name, ok := systemEmojiNames[strings.Join(codes, "-")]
+	return name, ok
+}
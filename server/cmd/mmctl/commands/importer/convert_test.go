@@ -0,0 +1,214 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package importer
+
+import (
+	"archive/zip"
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"io"
+	"io/fs"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/v8/channels/app/imports"
+)
+
+type convertFunc func(src fs.FS, w io.Writer, opts ConvertOptions) (*ConvertStats, error)
+
+// convertedArchive is the content of an archive written by a converter.
+type convertedArchive struct {
+	stats *ConvertStats
+	lines []imports.LineImportData
+	files map[string]string
+}
+
+func (a *convertedArchive) linesOfType(lineType string) []imports.LineImportData {
+	var lines []imports.LineImportData
+	for _, line := range a.lines {
+		if line.Type == lineType {
+			lines = append(lines, line)
+		}
+	}
+	return lines
+}
+
+func (a *convertedArchive) user(username string) *imports.UserImportData {
+	for _, line := range a.linesOfType(LineTypeUser) {
+		if *line.User.Username == username {
+			return line.User
+		}
+	}
+	return nil
+}
+
+func (a *convertedArchive) posts() []*imports.PostImportData {
+	var posts []*imports.PostImportData
+	for _, line := range a.linesOfType(LineTypePost) {
+		posts = append(posts, line.Post)
+	}
+	return posts
+}
+
+func (a *convertedArchive) directPosts() []*imports.DirectPostImportData {
+	var posts []*imports.DirectPostImportData
+	for _, line := range a.linesOfType(LineTypeDirectPost) {
+		posts = append(posts, line.DirectPost)
+	}
+	return posts
+}
+
+func convertArchive(t *testing.T, convert convertFunc, src fs.FS, opts ConvertOptions) *convertedArchive {
+	t.Helper()
+
+	var buf bytes.Buffer
+	stats, err := convert(src, &buf, opts)
+	require.NoError(t, err)
+
+	zr, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	require.NoError(t, err)
+
+	archive := &convertedArchive{stats: stats, files: map[string]string{}}
+	for _, f := range zr.File {
+		r, err := f.Open()
+		require.NoError(t, err)
+		data, err := io.ReadAll(r)
+		require.NoError(t, err)
+		require.NoError(t, r.Close())
+		archive.files[f.Name] = string(data)
+	}
+
+	require.Contains(t, archive.files, "import.jsonl")
+	scanner := bufio.NewScanner(strings.NewReader(archive.files["import.jsonl"]))
+	for scanner.Scan() {
+		var line imports.LineImportData
+		require.NoError(t, json.Unmarshal(scanner.Bytes(), &line))
+		archive.lines = append(archive.lines, line)
+	}
+	require.NoError(t, scanner.Err())
+
+	require.NotEmpty(t, archive.lines)
+	require.Equal(t, LineTypeVersion, archive.lines[0].Type)
+
+	return archive
+}
+
+func mustParseTime(t *testing.T, value string) time.Time {
+	t.Helper()
+	parsed, err := time.Parse(time.RFC3339Nano, value)
+	require.NoError(t, err)
+	return parsed
+}
+
+func TestSanitizeName(t *testing.T) {
+	testCases := []struct {
+		name        string
+		displayName string
+		maxLen      int
+		expected    string
+	}{
+		{"lower cased", "General", 64, "general"},
+		{"invalid characters replaced", " Off-topic & Fun! ", 64, "off-topic-fun"},
+		{"underscores kept", "dev_ops", 64, "dev_ops"},
+		{"truncated", "a-very-long-channel-name", 8, "a-very-l"},
+		{"separator trimmed after truncation", "abcdefg hij", 8, "abcdefg"},
+		{"too short", "a", 64, "fallback"},
+		{"no valid characters", "🎉🎉", 64, "fallback"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, sanitizeName(tc.displayName, "fallback", tc.maxLen))
+		})
+	}
+}
+
+func TestNameRegistryUnique(t *testing.T) {
+	names := nameRegistry{}
+	assert.Equal(t, "general", names.unique("general", 64))
+	assert.Equal(t, "general-2", names.unique("general", 64))
+	assert.Equal(t, "general-3", names.unique("general", 64))
+
+	assert.Equal(t, "abcdef", names.unique("abcdef", 6))
+	assert.Equal(t, "abcd-2", names.unique("abcdef", 6))
+}
+
+func TestNameRegistryUniqueUsername(t *testing.T) {
+	testCases := []struct {
+		name     string
+		taken    []string
+		username string
+		fallback string
+		expected string
+	}{
+		{"sanitized", nil, "Ada.Lovelace", "", "ada.lovelace"},
+		{"leading digits dropped", nil, "42ada", "", "ada"},
+		{"fallback", nil, "🎉", "Ada", "ada"},
+		{"no valid name", nil, "🎉", "42", "user"},
+		{"taken", []string{"ada"}, "ada", "", "ada2"},
+		{"taken twice", []string{"ada", "ada2"}, "ada", "", "ada3"},
+		{"reserved", nil, "all", "", "user"},
+		{"truncated", nil, strings.Repeat("a", model.UserNameMaxLength+5), "", strings.Repeat("a", model.UserNameMaxLength)},
+		{"truncated when taken", []string{strings.Repeat("a", model.UserNameMaxLength)}, strings.Repeat("a", model.UserNameMaxLength), "", strings.Repeat("a", model.UserNameMaxLength-1) + "2"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			names := nameRegistry{}
+			for _, name := range tc.taken {
+				names[name] = true
+			}
+			username := names.uniqueUsername(tc.username, tc.fallback)
+			assert.Equal(t, tc.expected, username)
+			assert.True(t, model.IsValidUsername(username))
+		})
+	}
+}
+
+func TestSanitizeFileName(t *testing.T) {
+	assert.Equal(t, "a_b_c.txt", sanitizeFileName("a/b\\c.txt"))
+	assert.Equal(t, "_", sanitizeFileName(""))
+	assert.Equal(t, "_", sanitizeFileName(".."))
+	assert.Equal(t, "report.pdf", sanitizeFileName("report.pdf"))
+}
+
+func TestEmojiNameFromUnicode(t *testing.T) {
+	testCases := []struct {
+		name     string
+		emoji    string
+		expected string
+		ok       bool
+	}{
+		{"shortest name of a shared code", "👍", "+1", true},
+		{"variation selector ignored", "❤️", "heart", true},
+		{"multiple code points", "🇫🇷", "fr", true},
+		{"not an emoji", "a", "", false},
+		{"empty", "", "", false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			name, ok := emojiNameFromUnicode(tc.emoji)
+			assert.Equal(t, tc.ok, ok)
+			assert.Equal(t, tc.expected, name)
+		})
+	}
+}
+
+func TestTruncateMessage(t *testing.T) {
+	var data bulkImportData
+
+	assert.Equal(t, "short", data.truncateMessage("short", "1"))
+	assert.Empty(t, data.stats.Warnings)
+
+	long := strings.Repeat("é", model.PostMessageMaxRunesV2+1)
+	assert.Equal(t, strings.Repeat("é", model.PostMessageMaxRunesV2), data.truncateMessage(long, "2"))
+	assert.Len(t, data.stats.Warnings, 1)
+}
@@ -0,0 +1,792 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package importer
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"net/url"
+	"path"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/v8/channels/app/imports"
+)
+
+// Discord exports come in two flavours:
+//
+//   - DiscordChatExporter JSON files, one per channel or thread, with the
+//     downloaded media next to them. Every JSON file in the export is read.
+//   - The data package Discord provides on request, which only holds the
+//     messages of its owner: account/user.json, messages/index.json and a
+//     messages/c<channel id> directory per channel with channel.json and
+//     messages.json or messages.csv.
+
+type discordUser struct {
+	ID            string `json:"id"`
+	Name          string `json:"name"`
+	Discriminator string `json:"discriminator"`
+	Nickname      string `json:"nickname"`
+}
+
+type discordExport struct {
+	Guild struct {
+		ID   string `json:"id"`
+		Name string `json:"name"`
+	} `json:"guild"`
+	Channel struct {
+		ID         string `json:"id"`
+		Type       string `json:"type"`
+		CategoryID string `json:"categoryId"`
+		Category   string `json:"category"`
+		Name       string `json:"name"`
+		Topic      string `json:"topic"`
+	} `json:"channel"`
+	Messages []discordMessage `json:"messages"`
+}
+
+type discordMessage struct {
+	ID              string      `json:"id"`
+	Type            string      `json:"type"`
+	Timestamp       time.Time   `json:"timestamp"`
+	TimestampEdited *time.Time  `json:"timestampEdited"`
+	IsPinned        bool        `json:"isPinned"`
+	Content         string      `json:"content"`
+	Author          discordUser `json:"author"`
+	Attachments     []struct {
+		ID       string `json:"id"`
+		URL      string `json:"url"`
+		FileName string `json:"fileName"`
+	} `json:"attachments"`
+	Reactions []struct {
+		Emoji struct {
+			ID   string `json:"id"`
+			Name string `json:"name"`
+			Code string `json:"code"`
+		} `json:"emoji"`
+		Users []discordUser `json:"users"`
+	} `json:"reactions"`
+	Mentions  []discordUser `json:"mentions"`
+	Reference *struct {
+		MessageID string `json:"messageId"`
+		ChannelID string `json:"channelId"`
+	} `json:"reference"`
+
+	// dir is the directory of the export file, which media paths are
+	// relative to.
+	dir string
+}
+
+// discordChannel is a converted channel and the messages posted in it,
+// including the ones of its threads.
+type discordChannel struct {
+	team     string
+	name     string
+	direct   bool
+	members  []string
+	messages []discordMessage
+	// rootOf maps message ids to the id of the root of their thread.
+	rootOf map[string]string
+}
+
+type discordConverter struct {
+	bulkImportData
+
+	src  fs.FS
+	opts ConvertOptions
+
+	users       map[string]*imports.UserImportData
+	userNames   nameRegistry
+	memberships map[string]map[string]map[string]bool // user id -> team -> channels
+	teamNames   map[string]string                     // guild id -> team name
+	teamNameSet nameRegistry
+	channelSets map[string]nameRegistry // team -> channel names
+	channels    map[string]*discordChannel
+	channelIDs  []string
+}
+
+// ConvertDiscord converts a Discord export into a bulk import archive
+// written to w.
+func ConvertDiscord(src fs.FS, w io.Writer, opts ConvertOptions) (*ConvertStats, error) {
+	c := &discordConverter{
+		src:         src,
+		opts:        opts,
+		users:       map[string]*imports.UserImportData{},
+		userNames:   nameRegistry{},
+		memberships: map[string]map[string]map[string]bool{},
+		teamNames:   map[string]string{},
+		teamNameSet: nameRegistry{},
+		channelSets: map[string]nameRegistry{},
+		channels:    map[string]*discordChannel{},
+	}
+
+	var err error
+	if _, statErr := fs.Stat(src, "messages/index.json"); statErr == nil {
+		err = c.readPackage()
+	} else {
+		err = c.readExports()
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	c.convertMessages()
+	c.writeUsers()
+
+	if err := c.write(w); err != nil {
+		return nil, err
+	}
+
+	return &c.stats, nil
+}
+
+func (c *discordConverter) readExports() error {
+	var exports []discordExport
+	err := fs.WalkDir(c.src, ".", func(name string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() || path.Ext(name) != ".json" {
+			return nil
+		}
+
+		var export discordExport
+		if err := readJSON(c.src, name, &export); err != nil {
+			return err
+		}
+		if export.Channel.ID == "" {
+			c.stats.warnf("skipping %q which isn't a DiscordChatExporter export", name)
+			return nil
+		}
+		for i := range export.Messages {
+			export.Messages[i].dir = path.Dir(name)
+		}
+		exports = append(exports, export)
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	// Channels are converted before threads so that threads find their
+	// parent channel, and direct channels last so that their members are
+	// known by name.
+	sort.SliceStable(exports, func(i, j int) bool {
+		return discordExportOrder(exports[i].Channel.Type) < discordExportOrder(exports[j].Channel.Type)
+	})
+
+	for _, export := range exports {
+		c.addExport(export)
+	}
+	return nil
+}
+
+func discordExportOrder(channelType string) int {
+	switch {
+	case strings.HasPrefix(channelType, "Direct"):
+		return 2
+	case isDiscordThread(channelType):
+		return 1
+	default:
+		return 0
+	}
+}
+
+func isDiscordThread(channelType string) bool {
+	return strings.HasSuffix(channelType, "Thread")
+}
+
+func (c *discordConverter) addExport(export discordExport) {
+	for _, message := range export.Messages {
+		c.userFor(message.Author)
+	}
+
+	if strings.HasPrefix(export.Channel.Type, "Direct") {
+		var members []string
+		for _, message := range export.Messages {
+			members = append(members, c.userFor(message.Author))
+		}
+		// The name of a direct channel is the name of the other user.
+		if export.Channel.Type == "DirectTextChat" {
+			members = append(members, c.userByName(export.Channel.Name))
+		}
+		c.addDirectChannel(export.Channel.ID, members, export.Messages)
+		return
+	}
+
+	team := c.team(export.Guild.ID, export.Guild.Name)
+
+	if isDiscordThread(export.Channel.Type) {
+		parent, ok := c.channels[export.Channel.CategoryID]
+		if !ok {
+			// Threads of forums or of channels left out of the export are
+			// imported into a channel named after their parent.
+			parent = c.addChannel(export.Channel.CategoryID, team, export.Channel.Category, "")
+		}
+		c.addThread(parent, export.Channel.ID, export.Messages)
+		return
+	}
+
+	channel := c.addChannel(export.Channel.ID, team, export.Channel.Name, export.Channel.Topic)
+	channel.messages = append(channel.messages, export.Messages...)
+}
+
+func (c *discordConverter) team(guildID, guildName string) string {
+	if c.opts.Team != "" {
+		return c.opts.Team
+	}
+	if name, ok := c.teamNames[guildID]; ok {
+		return name
+	}
+
+	name := c.teamNameSet.unique(sanitizeName(guildName, "discord", model.TeamNameMaxLength), model.TeamNameMaxLength)
+	c.teamNames[guildID] = name
+	c.teams = append(c.teams, imports.LineImportData{
+		Type: LineTypeTeam,
+		Team: &imports.TeamImportData{
+			Name:        model.NewPointer(name),
+			DisplayName: model.NewPointer(truncateRunes(guildName, model.TeamDisplayNameMaxRunes)),
+			Type:        model.NewPointer(model.TeamInvite),
+		},
+	})
+	c.stats.Teams++
+	return name
+}
+
+func (c *discordConverter) addChannel(id, team, displayName, topic string) *discordChannel {
+	if channel, ok := c.channels[id]; ok {
+		return channel
+	}
+
+	names, ok := c.channelSets[team]
+	if !ok {
+		names = nameRegistry{}
+		c.channelSets[team] = names
+	}
+	name := names.unique(sanitizeName(displayName, "channel", model.ChannelNameMaxLength), model.ChannelNameMaxLength)
+
+	channelType := model.ChannelTypeOpen
+	c.bulkImportData.channels = append(c.bulkImportData.channels, imports.LineImportData{
+		Type: LineTypeChannel,
+		Channel: &imports.ChannelImportData{
+			Team:        model.NewPointer(team),
+			Name:        model.NewPointer(name),
+			DisplayName: model.NewPointer(truncateRunes(displayName, model.ChannelDisplayNameMaxRunes)),
+			Type:        &channelType,
+			Header:      model.NewPointer(truncateRunes(topic, model.ChannelHeaderMaxRunes)),
+		},
+	})
+	c.stats.Channels++
+
+	channel := &discordChannel{team: team, name: name, rootOf: map[string]string{}}
+	c.channels[id] = channel
+	c.channelIDs = append(c.channelIDs, id)
+	return channel
+}
+
+func (c *discordConverter) addDirectChannel(id string, members []string, messages []discordMessage) {
+	slices.Sort(members)
+	members = slices.Compact(members)
+	if len(members) == 1 {
+		members = append(members, members[0])
+	}
+	if len(members) > model.ChannelGroupMaxUsers {
+		c.stats.warnf("skipping direct channel %s with %d members", id, len(members))
+		return
+	}
+
+	c.directChannels = append(c.directChannels, imports.LineImportData{
+		Type: LineTypeDirectChannel,
+		DirectChannel: &imports.DirectChannelImportData{
+			Members: &members,
+		},
+	})
+	c.stats.DirectChannels++
+
+	channel := &discordChannel{direct: true, members: members, messages: messages, rootOf: map[string]string{}}
+	c.channels[id] = channel
+	c.channelIDs = append(c.channelIDs, id)
+}
+
+// addThread adds the messages of a thread to its parent channel as replies
+// to the message the thread was started from or, when that message isn't
+// part of the export, to the first message of the thread.
+func (c *discordConverter) addThread(parent *discordChannel, threadID string, messages []discordMessage) {
+	var imported []discordMessage
+	for _, message := range messages {
+		if isDiscordUserMessage(message) {
+			imported = append(imported, message)
+		}
+	}
+	if len(imported) == 0 {
+		return
+	}
+
+	rootID := threadID
+	if !slices.ContainsFunc(parent.messages, func(m discordMessage) bool { return m.ID == threadID }) {
+		rootID = imported[0].ID
+		imported[0].Reference = nil
+	}
+	for _, message := range imported {
+		if message.ID != rootID {
+			parent.rootOf[message.ID] = rootID
+		}
+	}
+	parent.messages = append(parent.messages, imported...)
+}
+
+func isDiscordUserMessage(message discordMessage) bool {
+	return message.Type == "" || message.Type == "Default" || message.Type == "Reply"
+}
+
+func (c *discordConverter) userFor(user discordUser) string {
+	if data, ok := c.users[user.ID]; ok {
+		return *data.Username
+	}
+
+	name := user.Name
+	if user.Discriminator != "" && user.Discriminator != "0" && user.Discriminator != "0000" {
+		name += user.Discriminator
+	}
+	username := c.userNames.uniqueUsername(name, "discord"+user.ID)
+	data := &imports.UserImportData{
+		Username: model.NewPointer(username),
+		Email:    model.NewPointer(username + "@discord.invalid"),
+	}
+	if user.Nickname != "" && user.Nickname != user.Name {
+		data.Nickname = model.NewPointer(truncateRunes(user.Nickname, model.UserNicknameMaxRunes))
+	}
+	c.users[user.ID] = data
+	return username
+}
+
+// userByName returns the user known by the given Discord name, adding it
+// if it isn't known yet.
+func (c *discordConverter) userByName(name string) string {
+	ids := make([]string, 0, len(c.users))
+	for id := range c.users {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	for _, id := range ids {
+		if user := c.users[id]; user.Nickname != nil && *user.Nickname == name || *user.Username == sanitizeUsername(name) {
+			return *user.Username
+		}
+	}
+	return c.userFor(discordUser{ID: "name:" + name, Name: name})
+}
+
+func (c *discordConverter) addMembership(userID, team, channel string) {
+	teams, ok := c.memberships[userID]
+	if !ok {
+		teams = map[string]map[string]bool{}
+		c.memberships[userID] = teams
+	}
+	if teams[team] == nil {
+		teams[team] = map[string]bool{}
+	}
+	teams[team][channel] = true
+}
+
+// convertMessages adds the post lines of all the channels. Discord replies
+// become replies to the root of the thread of the message they reply to.
+func (c *discordConverter) convertMessages() {
+	for _, id := range c.channelIDs {
+		channel := c.channels[id]
+
+		messages := slices.Clone(channel.messages)
+		sort.SliceStable(messages, func(i, j int) bool { return messages[i].Timestamp.Before(messages[j].Timestamp) })
+
+		byID := map[string]bool{}
+		for _, message := range messages {
+			if isDiscordUserMessage(message) {
+				byID[message.ID] = true
+			}
+		}
+
+		var roots []string
+		replies := map[string][]discordMessage{}
+		rootMessages := map[string]discordMessage{}
+		for _, message := range messages {
+			if !isDiscordUserMessage(message) {
+				continue
+			}
+
+			rootID, ok := channel.rootOf[message.ID]
+			if !ok && message.Reference != nil && byID[message.Reference.MessageID] {
+				rootID, ok = message.Reference.MessageID, true
+				if parentRoot, isReply := channel.rootOf[rootID]; isReply {
+					rootID = parentRoot
+				}
+			}
+			if ok && rootID != message.ID && channel.rootOf[rootID] == "" && byID[rootID] {
+				channel.rootOf[message.ID] = rootID
+				replies[rootID] = append(replies[rootID], message)
+				continue
+			}
+
+			roots = append(roots, message.ID)
+			rootMessages[message.ID] = message
+		}
+
+		for _, rootID := range roots {
+			c.convertThread(channel, rootMessages[rootID], replies[rootID])
+		}
+	}
+}
+
+func (c *discordConverter) convertThread(channel *discordChannel, root discordMessage, replies []discordMessage) {
+	username := c.userFor(root.Author)
+	createAt := model.GetMillisForTime(root.Timestamp)
+
+	var editAt *int64
+	if root.TimestampEdited != nil {
+		editAt = model.NewPointer(model.GetMillisForTime(*root.TimestampEdited))
+	}
+
+	if channel.direct {
+		if !slices.Contains(channel.members, username) {
+			c.stats.warnf("skipping message %s of %q who isn't a member of the direct channel", root.ID, username)
+			return
+		}
+		post := &imports.DirectPostImportData{
+			ChannelMembers: &channel.members,
+			User:           model.NewPointer(username),
+			Message:        model.NewPointer(c.message(root)),
+			CreateAt:       model.NewPointer(createAt),
+			EditAt:         editAt,
+			IsPinned:       model.NewPointer(root.IsPinned),
+			Reactions:      c.reactions(root, createAt),
+			Attachments:    c.attachments(root),
+		}
+		if replyData := c.replies(replies, channel, createAt); len(replyData) > 0 {
+			post.Replies = &replyData
+		}
+		c.directPosts = append(c.directPosts, imports.LineImportData{Type: LineTypeDirectPost, DirectPost: post})
+		c.stats.DirectPosts++
+		return
+	}
+
+	c.addMembership(root.Author.ID, channel.team, channel.name)
+	post := &imports.PostImportData{
+		Team:        model.NewPointer(channel.team),
+		Channel:     model.NewPointer(channel.name),
+		User:        model.NewPointer(username),
+		Message:     model.NewPointer(c.message(root)),
+		CreateAt:    model.NewPointer(createAt),
+		EditAt:      editAt,
+		IsPinned:    model.NewPointer(root.IsPinned),
+		Reactions:   c.reactions(root, createAt),
+		Attachments: c.attachments(root),
+	}
+	if replyData := c.replies(replies, channel, createAt); len(replyData) > 0 {
+		post.Replies = &replyData
+	}
+	c.posts = append(c.posts, imports.LineImportData{Type: LineTypePost, Post: post})
+	c.stats.Posts++
+}
+
+func (c *discordConverter) replies(messages []discordMessage, channel *discordChannel, parentCreateAt int64) []imports.ReplyImportData {
+	var replies []imports.ReplyImportData
+	for _, message := range messages {
+		username := c.userFor(message.Author)
+		if channel.direct && !slices.Contains(channel.members, username) {
+			c.stats.warnf("skipping message %s of %q who isn't a member of the direct channel", message.ID, username)
+			continue
+		}
+		if !channel.direct {
+			c.addMembership(message.Author.ID, channel.team, channel.name)
+		}
+
+		createAt := max(model.GetMillisForTime(message.Timestamp), parentCreateAt)
+		reply := imports.ReplyImportData{
+			User:        model.NewPointer(username),
+			Message:     model.NewPointer(c.message(message)),
+			CreateAt:    model.NewPointer(createAt),
+			IsPinned:    model.NewPointer(message.IsPinned),
+			Reactions:   c.reactions(message, createAt),
+			Attachments: c.attachments(message),
+		}
+		if message.TimestampEdited != nil {
+			reply.EditAt = model.NewPointer(model.GetMillisForTime(*message.TimestampEdited))
+		}
+		replies = append(replies, reply)
+		c.stats.Replies++
+	}
+	return replies
+}
+
+// message returns the content of a message with the mentions of users
+// rewritten to their usernames.
+func (c *discordConverter) message(message discordMessage) string {
+	content := message.Content
+	for _, mention := range message.Mentions {
+		username := "@" + c.userFor(mention)
+		content = strings.NewReplacer(
+			"<@"+mention.ID+">", username,
+			"<@!"+mention.ID+">", username,
+		).Replace(content)
+		for _, name := range []string{mention.Nickname, mention.Name} {
+			if name != "" && "@"+name != username {
+				content = strings.ReplaceAll(content, "@"+name, username)
+				break
+			}
+		}
+	}
+	return c.truncateMessage(content, message.ID)
+}
+
+func (c *discordConverter) reactions(message discordMessage, parentCreateAt int64) *[]imports.ReactionImportData {
+	var reactions []imports.ReactionImportData
+	for _, reaction := range message.Reactions {
+		emojiName := strings.ToLower(reaction.Emoji.Code)
+		if !model.IsSystemEmojiName(emojiName) {
+			var ok bool
+			if emojiName, ok = emojiNameFromUnicode(reaction.Emoji.Name); !ok {
+				c.stats.warnf("skipping custom emoji reaction %q on message %s", reaction.Emoji.Name, message.ID)
+				continue
+			}
+		}
+		for _, user := range reaction.Users {
+			// Discord doesn't record when reactions were added.
+			reactions = append(reactions, imports.ReactionImportData{
+				User:      model.NewPointer(c.userFor(user)),
+				EmojiName: model.NewPointer(emojiName),
+				CreateAt:  model.NewPointer(parentCreateAt),
+			})
+			c.stats.Reactions++
+		}
+	}
+	return sortedReactions(reactions)
+}
+
+func (c *discordConverter) attachments(message discordMessage) *[]imports.AttachmentImportData {
+	if c.opts.SkipAttachments {
+		return nil
+	}
+
+	var attachments []imports.AttachmentImportData
+	for _, attachment := range message.Attachments {
+		if strings.HasPrefix(attachment.URL, "http://") || strings.HasPrefix(attachment.URL, "https://") {
+			c.stats.warnf("attachment %q of message %s wasn't downloaded with the export", attachment.FileName, message.ID)
+			continue
+		}
+
+		from := attachment.URL
+		if unescaped, err := url.PathUnescape(from); err == nil {
+			from = unescaped
+		}
+		from = path.Join(message.dir, strings.ReplaceAll(from, "\\", "/"))
+		if data := c.addAttachment(c.src, from, attachment.ID, attachment.FileName); data != nil {
+			attachments = append(attachments, *data)
+		}
+	}
+	if len(attachments) == 0 {
+		return nil
+	}
+	return &attachments
+}
+
+func (c *discordConverter) writeUsers() {
+	ids := make([]string, 0, len(c.users))
+	for id := range c.users {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool { return *c.users[ids[i]].Username < *c.users[ids[j]].Username })
+
+	for _, id := range ids {
+		user := c.users[id]
+		if teams := c.memberships[id]; len(teams) > 0 {
+			user.Teams = userTeamsImportData(teams)
+		}
+		c.userLines = append(c.userLines, imports.LineImportData{Type: LineTypeUser, User: user})
+		c.stats.Users++
+	}
+}
+
+type discordPackageUser struct {
+	ID            string `json:"id"`
+	Username      string `json:"username"`
+	Discriminator any    `json:"discriminator"`
+	GlobalName    string `json:"global_name"`
+	Email         string `json:"email"`
+}
+
+type discordPackageChannel struct {
+	ID         string          `json:"id"`
+	Type       json.RawMessage `json:"type"`
+	Name       string          `json:"name"`
+	Recipients []string        `json:"recipients"`
+	Guild      *struct {
+		ID   string `json:"id"`
+		Name string `json:"name"`
+	} `json:"guild"`
+}
+
+type discordPackageMessage struct {
+	ID          json.Number `json:"ID"`
+	Timestamp   string      `json:"Timestamp"`
+	Contents    string      `json:"Contents"`
+	Attachments string      `json:"Attachments"`
+}
+
+// readPackage reads a Discord data package. Only the messages of the owner
+// of the package are part of it.
+func (c *discordConverter) readPackage() error {
+	var account discordPackageUser
+	if err := readJSON(c.src, "account/user.json", &account); err != nil {
+		return err
+	}
+	owner := discordUser{ID: account.ID, Name: account.Username, Nickname: account.GlobalName}
+	if account.Discriminator != nil {
+		owner.Discriminator = fmt.Sprint(account.Discriminator)
+	}
+	username := c.userFor(owner)
+	if account.Email != "" {
+		c.users[account.ID].Email = model.NewPointer(strings.ToLower(account.Email))
+	}
+
+	var index map[string]string
+	if err := readJSON(c.src, "messages/index.json", &index); err != nil {
+		return err
+	}
+
+	dirs, err := fs.Glob(c.src, "messages/c*/channel.json")
+	if err != nil {
+		return err
+	}
+	sort.Strings(dirs)
+	for _, channelFile := range dirs {
+		dir := path.Dir(channelFile)
+
+		var channel discordPackageChannel
+		if err := readJSON(c.src, channelFile, &channel); err != nil {
+			return err
+		}
+
+		messages, err := c.readPackageMessages(dir, owner)
+		if err != nil {
+			return err
+		}
+
+		switch channelType := strings.Trim(string(channel.Type), `"`); channelType {
+		case "1", "DM", "3", "GROUP_DM":
+			members := []string{username}
+			for _, recipient := range channel.Recipients {
+				if recipient == account.ID {
+					continue
+				}
+				// The package has the names of the other users of direct
+				// messages only, in the index.
+				name, ok := strings.CutPrefix(index[channel.ID], "Direct Message with ")
+				if !ok || len(channel.Recipients) > 2 {
+					name = "discord" + recipient
+				}
+				if i := strings.LastIndex(name, "#"); i > 0 {
+					name = name[:i] + name[i+1:]
+				}
+				members = append(members, c.userFor(discordUser{ID: recipient, Name: name}))
+			}
+			c.addDirectChannel(channel.ID, members, messages)
+		case "0", "GUILD_TEXT", "5", "GUILD_ANNOUNCEMENT", "GUILD_NEWS", "2", "GUILD_VOICE":
+			if channel.Guild == nil {
+				c.stats.warnf("skipping channel %s without a server", channel.ID)
+				continue
+			}
+			name := channel.Name
+			if name == "" {
+				name = index[channel.ID]
+			}
+			team := c.team(channel.Guild.ID, channel.Guild.Name)
+			ch := c.addChannel(channel.ID, team, name, "")
+			ch.messages = append(ch.messages, messages...)
+		default:
+			if len(messages) > 0 {
+				c.stats.warnf("skipping %d messages of channel %s of type %s", len(messages), channel.ID, channelType)
+			}
+		}
+	}
+
+	return nil
+}
+
+func (c *discordConverter) readPackageMessages(dir string, owner discordUser) ([]discordMessage, error) {
+	var raw []discordPackageMessage
+	if data, err := fs.ReadFile(c.src, path.Join(dir, "messages.json")); err == nil {
+		if err := json.Unmarshal(data, &raw); err != nil {
+			return nil, fmt.Errorf("error parsing %q: %w", path.Join(dir, "messages.json"), err)
+		}
+	} else if !errors.Is(err, fs.ErrNotExist) {
+		return nil, err
+	} else if raw, err = readPackageMessagesCSV(c.src, path.Join(dir, "messages.csv")); err != nil {
+		return nil, err
+	}
+
+	messages := make([]discordMessage, 0, len(raw))
+	for _, m := range raw {
+		timestamp, err := parseDiscordPackageTime(m.Timestamp)
+		if err != nil {
+			c.stats.warnf("skipping message %s with an invalid timestamp %q", m.ID, m.Timestamp)
+			continue
+		}
+		message := discordMessage{
+			ID:        m.ID.String(),
+			Timestamp: timestamp,
+			Content:   m.Contents,
+			Author:    owner,
+		}
+		if m.Attachments != "" {
+			// The package only has links to the attachments.
+			for _, link := range strings.Fields(m.Attachments) {
+				message.Content = strings.TrimSpace(message.Content + "\n" + link)
+			}
+		}
+		messages = append(messages, message)
+	}
+	return messages, nil
+}
+
+func readPackageMessagesCSV(src fs.FS, name string) ([]discordPackageMessage, error) {
+	data, err := fs.ReadFile(src, name)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	records, err := csv.NewReader(bytes.NewReader(data)).ReadAll()
+	if err != nil {
+		return nil, fmt.Errorf("error parsing %q: %w", name, err)
+	}
+
+	var messages []discordPackageMessage
+	for i, record := range records {
+		if i == 0 || len(record) < 3 {
+			continue
+		}
+		message := discordPackageMessage{ID: json.Number(record[0]), Timestamp: record[1], Contents: record[2]}
+		if len(record) > 3 {
+			message.Attachments = record[3]
+		}
+		messages = append(messages, message)
+	}
+	return messages, nil
+}
+
+func parseDiscordPackageTime(value string) (time.Time, error) {
+	for _, layout := range []string{time.RFC3339Nano, "2006-01-02 15:04:05.999999-07:00", "2006-01-02 15:04:05-07:00"} {
+		if t, err := time.Parse(layout, value); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("invalid time %q", value)
+}
@@ -0,0 +1,329 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package importer
+
+import (
+	"path"
+	"testing"
+	"testing/fstest"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost/server/public/model"
+)
+
+const (
+	discordAda  = `{"id":"100","name":"ada","discriminator":"0000","nickname":"Ada"}`
+	discordAlan = `{"id":"200","name":"alan","discriminator":"0000","nickname":"Alan"}`
+	discordBob  = `{"id":"300","name":"bob","discriminator":"4321","nickname":"bob"}`
+)
+
+func discordChannelExport(channel, messages string) *fstest.MapFile {
+	return &fstest.MapFile{Data: []byte(`{"guild":{"id":"1","name":"Computing Club"},"channel":` + channel + `,"messages":[` + messages + `]}`)}
+}
+
+func discordGeneral(messages string) *fstest.MapFile {
+	return discordChannelExport(`{"id":"10","type":"GuildTextChat","name":"general","topic":"Chat"}`, messages)
+}
+
+func TestConvertDiscordExports(t *testing.T) {
+	testCases := []struct {
+		name  string
+		files fstest.MapFS
+		opts  ConvertOptions
+		check func(t *testing.T, archive *convertedArchive)
+	}{
+		{
+			name: "team, channel and users",
+			files: fstest.MapFS{
+				"general.json": discordGeneral(`
+					{"id":"11","type":"Default","timestamp":"2024-01-02T10:00:00+00:00","content":"Hi","author":` + discordAda + `},
+					{"id":"12","type":"Default","timestamp":"2024-01-02T10:01:00+00:00","content":"Hello","author":` + discordBob + `}`),
+			},
+			check: func(t *testing.T, archive *convertedArchive) {
+				teams := archive.linesOfType(LineTypeTeam)
+				require.Len(t, teams, 1)
+				assert.Equal(t, "computing-club", *teams[0].Team.Name)
+				assert.Equal(t, "Computing Club", *teams[0].Team.DisplayName)
+				assert.Equal(t, model.TeamInvite, *teams[0].Team.Type)
+
+				channels := archive.linesOfType(LineTypeChannel)
+				require.Len(t, channels, 1)
+				assert.Equal(t, "computing-club", *channels[0].Channel.Team)
+				assert.Equal(t, "general", *channels[0].Channel.Name)
+				assert.Equal(t, "Chat", *channels[0].Channel.Header)
+				assert.Equal(t, model.ChannelTypeOpen, *channels[0].Channel.Type)
+
+				ada := archive.user("ada")
+				require.NotNil(t, ada)
+				assert.Equal(t, "ada@discord.invalid", *ada.Email)
+				assert.Equal(t, "Ada", *ada.Nickname)
+				require.NotNil(t, ada.Teams)
+				require.Len(t, *ada.Teams, 1)
+				assert.Equal(t, "computing-club", *(*ada.Teams)[0].Name)
+				require.Len(t, *(*ada.Teams)[0].Channels, 1)
+				assert.Equal(t, "general", *(*(*ada.Teams)[0].Channels)[0].Name)
+
+				// The discriminator is part of the username, and nicknames
+				// matching the name are left out.
+				bob := archive.user("bob4321")
+				require.NotNil(t, bob)
+				assert.Nil(t, bob.Nickname)
+
+				require.Len(t, archive.posts(), 2)
+				assert.Equal(t, model.GetMillisForTime(mustParseTime(t, "2024-01-02T10:00:00Z")), *archive.posts()[0].CreateAt)
+			},
+		},
+		{
+			name: "team option",
+			files: fstest.MapFS{
+				"general.json": discordGeneral(`{"id":"11","type":"Default","timestamp":"2024-01-02T10:00:00+00:00","content":"Hi","author":` + discordAda + `}`),
+			},
+			opts: ConvertOptions{Team: "main"},
+			check: func(t *testing.T, archive *convertedArchive) {
+				assert.Empty(t, archive.linesOfType(LineTypeTeam))
+				channels := archive.linesOfType(LineTypeChannel)
+				require.Len(t, channels, 1)
+				assert.Equal(t, "main", *channels[0].Channel.Team)
+				assert.Equal(t, "main", *archive.posts()[0].Team)
+			},
+		},
+		{
+			name: "replies to replies belong to the thread of the root",
+			files: fstest.MapFS{
+				"general.json": discordGeneral(`
+					{"id":"11","type":"Default","timestamp":"2024-01-02T10:00:00+00:00","content":"Root","author":` + discordAda + `},
+					{"id":"12","type":"Reply","timestamp":"2024-01-02T10:01:00+00:00","content":"First","author":` + discordAlan + `,"reference":{"messageId":"11"}},
+					{"id":"13","type":"Reply","timestamp":"2024-01-02T10:02:00+00:00","content":"Second","author":` + discordAda + `,"reference":{"messageId":"12"}},
+					{"id":"14","type":"Reply","timestamp":"2024-01-02T10:03:00+00:00","content":"Orphan","author":` + discordAda + `,"reference":{"messageId":"1"}},
+					{"id":"15","type":"ChannelPinnedMessage","timestamp":"2024-01-02T10:04:00+00:00","content":"","author":` + discordAda + `}`),
+			},
+			check: func(t *testing.T, archive *convertedArchive) {
+				posts := archive.posts()
+				require.Len(t, posts, 2)
+				assert.Equal(t, "Root", *posts[0].Message)
+				require.NotNil(t, posts[0].Replies)
+				require.Len(t, *posts[0].Replies, 2)
+				assert.Equal(t, "First", *(*posts[0].Replies)[0].Message)
+				assert.Equal(t, "Second", *(*posts[0].Replies)[1].Message)
+				assert.Equal(t, "Orphan", *posts[1].Message)
+				assert.Nil(t, posts[1].Replies)
+				assert.Equal(t, 2, archive.stats.Posts)
+				assert.Equal(t, 2, archive.stats.Replies)
+			},
+		},
+		{
+			name: "threads",
+			files: fstest.MapFS{
+				"general.json": discordGeneral(`{"id":"11","type":"Default","timestamp":"2024-01-02T10:00:00+00:00","content":"Started a thread","author":` + discordAda + `}`),
+				"thread.json": discordChannelExport(`{"id":"11","type":"GuildPublicThread","categoryId":"10","category":"general","name":"Started a thread"}`,
+					`{"id":"21","type":"Default","timestamp":"2024-01-02T11:00:00+00:00","content":"In the thread","author":`+discordAlan+`}`),
+				"forum.json": discordChannelExport(`{"id":"30","type":"GuildPublicThread","categoryId":"40","category":"Help Forum","name":"Question"}`,
+					`{"id":"31","type":"Default","timestamp":"2024-01-02T12:00:00+00:00","content":"Question","author":`+discordAda+`},
+					 {"id":"32","type":"Default","timestamp":"2024-01-02T12:01:00+00:00","content":"Answer","author":`+discordAlan+`}`),
+			},
+			check: func(t *testing.T, archive *convertedArchive) {
+				channels := archive.linesOfType(LineTypeChannel)
+				require.Len(t, channels, 2)
+				assert.Equal(t, "general", *channels[0].Channel.Name)
+				// Threads without their parent channel get a channel named
+				// after it.
+				assert.Equal(t, "help-forum", *channels[1].Channel.Name)
+
+				posts := archive.posts()
+				require.Len(t, posts, 2)
+				assert.Equal(t, "general", *posts[0].Channel)
+				assert.Equal(t, "Started a thread", *posts[0].Message)
+				require.NotNil(t, posts[0].Replies)
+				require.Len(t, *posts[0].Replies, 1)
+				assert.Equal(t, "In the thread", *(*posts[0].Replies)[0].Message)
+
+				assert.Equal(t, "help-forum", *posts[1].Channel)
+				assert.Equal(t, "Question", *posts[1].Message)
+				require.NotNil(t, posts[1].Replies)
+				require.Len(t, *posts[1].Replies, 1)
+				assert.Equal(t, "Answer", *(*posts[1].Replies)[0].Message)
+			},
+		},
+		{
+			name: "mentions",
+			files: fstest.MapFS{
+				"general.json": discordGeneral(`{"id":"11","type":"Default","timestamp":"2024-01-02T10:00:00+00:00","content":"<@200>, <@!200> and @Alan","author":` + discordAda + `,"mentions":[` + discordAlan + `]}`),
+			},
+			check: func(t *testing.T, archive *convertedArchive) {
+				require.Len(t, archive.posts(), 1)
+				assert.Equal(t, "@alan, @alan and @alan", *archive.posts()[0].Message)
+			},
+		},
+		{
+			name: "reactions",
+			files: fstest.MapFS{
+				"general.json": discordGeneral(`{"id":"11","type":"Default","timestamp":"2024-01-02T10:00:00+00:00","content":"Hi","author":` + discordAda + `,"reactions":[
+					{"emoji":{"id":"","name":"👍","code":"thumbsup"},"users":[` + discordAlan + `]},
+					{"emoji":{"id":"","name":"❤️","code":"red_heart"},"users":[` + discordAda + `,` + discordAlan + `]},
+					{"emoji":{"id":"5","name":"party_blob","code":"party_blob"},"users":[` + discordAlan + `]}]}`),
+			},
+			check: func(t *testing.T, archive *convertedArchive) {
+				post := archive.posts()[0]
+				require.NotNil(t, post.Reactions)
+				reactions := *post.Reactions
+				require.Len(t, reactions, 3)
+				assert.Equal(t, "thumbsup", *reactions[0].EmojiName)
+				assert.Equal(t, "alan", *reactions[0].User)
+				// Codes unknown to Mattermost are mapped from the unicode
+				// characters.
+				assert.Equal(t, "heart", *reactions[1].EmojiName)
+				assert.Equal(t, "heart", *reactions[2].EmojiName)
+				assert.Equal(t, *post.CreateAt, *reactions[0].CreateAt)
+
+				assert.Equal(t, 3, archive.stats.Reactions)
+				require.Len(t, archive.stats.Warnings, 1)
+				assert.Contains(t, archive.stats.Warnings[0], "party_blob")
+			},
+		},
+		{
+			name: "attachments",
+			files: fstest.MapFS{
+				"export/general.json": discordGeneral(`{"id":"11","type":"Default","timestamp":"2024-01-02T10:00:00+00:00","content":"Files","author":` + discordAda + `,"attachments":[
+					{"id":"a1","url":"general.json_Files/my%20doc.txt","fileName":"my doc.txt"},
+					{"id":"a2","url":"https://cdn.discordapp.com/attachments/image.png","fileName":"image.png"},
+					{"id":"a3","url":"general.json_Files/missing.txt","fileName":"missing.txt"}]}`),
+				"export/general.json_Files/my doc.txt": {Data: []byte("hello")},
+			},
+			check: func(t *testing.T, archive *convertedArchive) {
+				post := archive.posts()[0]
+				require.NotNil(t, post.Attachments)
+				require.Len(t, *post.Attachments, 1)
+				assert.Equal(t, "attachments/a1/my doc.txt", *(*post.Attachments)[0].Path)
+				assert.Equal(t, "hello", archive.files[path.Join(model.ExportDataDir, "attachments/a1/my doc.txt")])
+
+				assert.Equal(t, 1, archive.stats.Attachments)
+				require.Len(t, archive.stats.Warnings, 2)
+				assert.Contains(t, archive.stats.Warnings[0], "image.png")
+				assert.Contains(t, archive.stats.Warnings[1], "missing.txt")
+			},
+		},
+		{
+			name: "skipped attachments",
+			files: fstest.MapFS{
+				"general.json": discordGeneral(`{"id":"11","type":"Default","timestamp":"2024-01-02T10:00:00+00:00","content":"Files","author":` + discordAda + `,"attachments":[
+					{"id":"a1","url":"general.json_Files/doc.txt","fileName":"doc.txt"}]}`),
+				"general.json_Files/doc.txt": {Data: []byte("hello")},
+			},
+			opts: ConvertOptions{SkipAttachments: true},
+			check: func(t *testing.T, archive *convertedArchive) {
+				assert.Nil(t, archive.posts()[0].Attachments)
+				assert.Equal(t, 0, archive.stats.Attachments)
+				assert.Len(t, archive.files, 1)
+			},
+		},
+		{
+			name: "direct channels",
+			files: fstest.MapFS{
+				"general.json": discordGeneral(`{"id":"11","type":"Default","timestamp":"2024-01-02T10:00:00+00:00","content":"Hi","author":` + discordAlan + `}`),
+				"dm.json": discordChannelExport(`{"id":"20","type":"DirectTextChat","name":"Alan"}`, `
+					{"id":"21","type":"Default","timestamp":"2024-01-03T09:00:00+00:00","content":"Ping","author":`+discordAda+`},
+					{"id":"22","type":"Reply","timestamp":"2024-01-03T09:01:00+00:00","content":"Pong","author":`+discordAda+`,"reference":{"messageId":"21"}}`),
+				"group.json": discordChannelExport(`{"id":"30","type":"DirectGroupTextChat","name":"Group"}`, `
+					{"id":"31","type":"Default","timestamp":"2024-01-03T10:00:00+00:00","content":"Hello all","author":`+discordAda+`},
+					{"id":"32","type":"Default","timestamp":"2024-01-03T10:01:00+00:00","content":"Hello","author":`+discordBob+`}`),
+			},
+			check: func(t *testing.T, archive *convertedArchive) {
+				directChannels := archive.linesOfType(LineTypeDirectChannel)
+				require.Len(t, directChannels, 2)
+				// The other member of a direct channel is found by name.
+				assert.Equal(t, []string{"ada", "alan"}, *directChannels[0].DirectChannel.Members)
+				assert.Equal(t, []string{"ada", "bob4321"}, *directChannels[1].DirectChannel.Members)
+
+				posts := archive.directPosts()
+				require.Len(t, posts, 3)
+				assert.Equal(t, "Ping", *posts[0].Message)
+				assert.Equal(t, []string{"ada", "alan"}, *posts[0].ChannelMembers)
+				require.NotNil(t, posts[0].Replies)
+				assert.Equal(t, "Pong", *(*posts[0].Replies)[0].Message)
+				assert.Equal(t, "Hello all", *posts[1].Message)
+				assert.Equal(t, "bob4321", *posts[2].User)
+
+				// Direct channels don't add team memberships.
+				assert.Nil(t, archive.user("bob4321").Teams)
+				assert.Equal(t, 3, archive.stats.Users)
+				assert.Empty(t, archive.stats.Warnings)
+			},
+		},
+		{
+			name: "files that aren't exports",
+			files: fstest.MapFS{
+				"general.json":  discordGeneral(`{"id":"11","type":"Default","timestamp":"2024-01-02T10:00:00+00:00","content":"Hi","author":` + discordAda + `}`),
+				"settings.json": {Data: []byte(`{"theme":"dark"}`)},
+				"notes.txt":     {Data: []byte("notes")},
+			},
+			check: func(t *testing.T, archive *convertedArchive) {
+				require.Len(t, archive.stats.Warnings, 1)
+				assert.Contains(t, archive.stats.Warnings[0], "settings.json")
+				assert.Len(t, archive.posts(), 1)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.check(t, convertArchive(t, ConvertDiscord, tc.files, tc.opts))
+		})
+	}
+}
+
+func TestConvertDiscordPackage(t *testing.T) {
+	files := fstest.MapFS{
+		"account/user.json":          {Data: []byte(`{"id":"100","username":"ada","discriminator":0,"global_name":"Ada L","email":"Ada@Example.com"}`)},
+		"messages/index.json":        {Data: []byte(`{"200":"Direct Message with alan#1234","300":"general in Computing Club","400":null,"500":"group"}`)},
+		"messages/c200/channel.json": {Data: []byte(`{"id":"200","type":1,"recipients":["100","201"]}`)},
+		"messages/c200/messages.json": {Data: []byte(`[
+			{"ID":2,"Timestamp":"2024-01-02 10:00:00.123000+00:00","Contents":"Ping","Attachments":""},
+			{"ID":3,"Timestamp":"yesterday","Contents":"Invalid","Attachments":""}]`)},
+		"messages/c300/channel.json": {Data: []byte(`{"id":"300","type":"GUILD_TEXT","name":"general","guild":{"id":"1","name":"Computing Club"}}`)},
+		"messages/c300/messages.csv": {Data: []byte("ID,Timestamp,Contents,Attachments\n" +
+			"4,2024-01-02 11:00:00+00:00,Hello,https://cdn.discordapp.com/attachments/image.png\n" +
+			"5,2024-01-02T12:00:00Z,\"Multi\nline\",\n")},
+		"messages/c400/channel.json":  {Data: []byte(`{"id":"400","type":0,"name":"orphan"}`)},
+		"messages/c500/channel.json":  {Data: []byte(`{"id":"500","type":13,"name":"stage"}`)},
+		"messages/c500/messages.json": {Data: []byte(`[{"ID":6,"Timestamp":"2024-01-02 10:00:00+00:00","Contents":"On stage"}]`)},
+	}
+
+	archive := convertArchive(t, ConvertDiscord, files, ConvertOptions{})
+
+	ada := archive.user("ada")
+	require.NotNil(t, ada)
+	assert.Equal(t, "ada@example.com", *ada.Email)
+	assert.Equal(t, "Ada L", *ada.Nickname)
+	// Other users are only known from the names of the direct messages.
+	assert.NotNil(t, archive.user("alan1234"))
+	assert.Equal(t, 2, archive.stats.Users)
+
+	directChannels := archive.linesOfType(LineTypeDirectChannel)
+	require.Len(t, directChannels, 1)
+	assert.Equal(t, []string{"ada", "alan1234"}, *directChannels[0].DirectChannel.Members)
+
+	directPosts := archive.directPosts()
+	require.Len(t, directPosts, 1)
+	assert.Equal(t, "Ping", *directPosts[0].Message)
+	assert.Equal(t, "ada", *directPosts[0].User)
+	assert.Equal(t, model.GetMillisForTime(mustParseTime(t, "2024-01-02T10:00:00.123Z")), *directPosts[0].CreateAt)
+
+	teams := archive.linesOfType(LineTypeTeam)
+	require.Len(t, teams, 1)
+	assert.Equal(t, "computing-club", *teams[0].Team.Name)
+
+	posts := archive.posts()
+	require.Len(t, posts, 2)
+	assert.Equal(t, "general", *posts[0].Channel)
+	// The package only has links to attachments.
+	assert.Equal(t, "Hello\nhttps://cdn.discordapp.com/attachments/image.png", *posts[0].Message)
+	assert.Equal(t, "Multi\nline", *posts[1].Message)
+
+	require.Len(t, archive.stats.Warnings, 3)
+	assert.Contains(t, archive.stats.Warnings[0], "yesterday")
+	assert.Contains(t, archive.stats.Warnings[1], "channel 400 without a server")
+	assert.Contains(t, archive.stats.Warnings[2], "channel 500")
+}
@@ -0,0 +1,772 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package importer
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"path"
+	"regexp"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"golang.org/x/net/html"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/v8/channels/app/imports"
+)
+
+// The Microsoft Teams export is read from the following layout, where each
+// JSON file holds the Graph API representation of the resources, either as
+// an array or as a Graph collection response with a "value" array:
+//
+//	users.json
+//	teams/<team id>/team.json
+//	teams/<team id>/members.json                              (optional)
+//	teams/<team id>/channels/<channel id>/channel.json
+//	teams/<team id>/channels/<channel id>/members.json        (optional)
+//	teams/<team id>/channels/<channel id>/messages.json
+//	teams/<team id>/channels/<channel id>/files/<file name>
+//	chats/<chat id>/chat.json
+//	chats/<chat id>/messages.json
+//	chats/<chat id>/files/<file name>
+//
+// Replies are read either from the "replies" of their parent message or from
+// the messages with a "replyToId".
+
+type msTeamsIdentity struct {
+	ID          string `json:"id"`
+	DisplayName string `json:"displayName"`
+}
+
+type msTeamsUser struct {
+	ID                string `json:"id"`
+	DisplayName       string `json:"displayName"`
+	GivenName         string `json:"givenName"`
+	Surname           string `json:"surname"`
+	Mail              string `json:"mail"`
+	UserPrincipalName string `json:"userPrincipalName"`
+	JobTitle          string `json:"jobTitle"`
+}
+
+type msTeamsTeam struct {
+	ID          string `json:"id"`
+	DisplayName string `json:"displayName"`
+	Description string `json:"description"`
+	Visibility  string `json:"visibility"`
+}
+
+type msTeamsChannel struct {
+	ID             string `json:"id"`
+	DisplayName    string `json:"displayName"`
+	Description    string `json:"description"`
+	MembershipType string `json:"membershipType"`
+}
+
+type msTeamsMember struct {
+	UserID      string   `json:"userId"`
+	DisplayName string   `json:"displayName"`
+	Email       string   `json:"email"`
+	Roles       []string `json:"roles"`
+}
+
+type msTeamsChat struct {
+	ID       string          `json:"id"`
+	Topic    string          `json:"topic"`
+	ChatType string          `json:"chatType"`
+	Members  []msTeamsMember `json:"members"`
+}
+
+type msTeamsMessage struct {
+	ID                 string     `json:"id"`
+	ReplyToID          string     `json:"replyToId"`
+	MessageType        string     `json:"messageType"`
+	CreatedDateTime    time.Time  `json:"createdDateTime"`
+	LastEditedDateTime *time.Time `json:"lastEditedDateTime"`
+	DeletedDateTime    *time.Time `json:"deletedDateTime"`
+	From               *struct {
+		User        *msTeamsIdentity `json:"user"`
+		Application *msTeamsIdentity `json:"application"`
+	} `json:"from"`
+	Body struct {
+		ContentType string `json:"contentType"`
+		Content     string `json:"content"`
+	} `json:"body"`
+	Attachments []struct {
+		ID          string `json:"id"`
+		ContentType string `json:"contentType"`
+		Name        string `json:"name"`
+	} `json:"attachments"`
+	Mentions []struct {
+		ID        int `json:"id"`
+		Mentioned struct {
+			User *msTeamsIdentity `json:"user"`
+		} `json:"mentioned"`
+	} `json:"mentions"`
+	Reactions []struct {
+		ReactionType    string    `json:"reactionType"`
+		CreatedDateTime time.Time `json:"createdDateTime"`
+		User            struct {
+			User *msTeamsIdentity `json:"user"`
+		} `json:"user"`
+	} `json:"reactions"`
+	Replies []msTeamsMessage `json:"replies"`
+}
+
+// msTeamsReactions maps the Teams reaction types to emoji names.
+var msTeamsReactions = map[string]string{
+	"like":      "+1",
+	"heart":     "heart",
+	"laugh":     "laughing",
+	"surprised": "open_mouth",
+	"sad":       "cry",
+	"angry":     "angry",
+}
+
+type msTeamsConverter struct {
+	bulkImportData
+
+	src  fs.FS
+	opts ConvertOptions
+
+	users       map[string]*imports.UserImportData
+	userNames   nameRegistry
+	memberships map[string]map[string]map[string]bool // user id -> team -> channels
+	teamNames   nameRegistry
+}
+
+// ConvertMSTeams converts a Microsoft Teams export into a bulk import
+// archive written to w.
+func ConvertMSTeams(src fs.FS, w io.Writer, opts ConvertOptions) (*ConvertStats, error) {
+	c := &msTeamsConverter{
+		src:         src,
+		opts:        opts,
+		users:       map[string]*imports.UserImportData{},
+		userNames:   nameRegistry{},
+		memberships: map[string]map[string]map[string]bool{},
+		teamNames:   nameRegistry{},
+	}
+
+	var users []msTeamsUser
+	if err := readGraphList(src, "users.json", &users); err != nil {
+		return nil, err
+	}
+	for _, user := range users {
+		c.addUser(user)
+	}
+
+	teamDirs, err := fs.Glob(src, "teams/*/team.json")
+	if err != nil {
+		return nil, err
+	}
+	for _, teamFile := range teamDirs {
+		if err := c.convertTeam(path.Dir(teamFile)); err != nil {
+			return nil, err
+		}
+	}
+
+	chatDirs, err := fs.Glob(src, "chats/*/chat.json")
+	if err != nil {
+		return nil, err
+	}
+	for _, chatFile := range chatDirs {
+		if err := c.convertChat(path.Dir(chatFile)); err != nil {
+			return nil, err
+		}
+	}
+
+	c.writeUsers()
+
+	if err := c.write(w); err != nil {
+		return nil, err
+	}
+
+	return &c.stats, nil
+}
+
+// readGraphList reads a JSON file holding either an array or a Graph
+// collection response.
+func readGraphList(src fs.FS, name string, out any) error {
+	data, err := fs.ReadFile(src, name)
+	if err != nil {
+		return fmt.Errorf("error reading %q: %w", name, err)
+	}
+
+	data = bytes.TrimSpace(data)
+	if len(data) > 0 && data[0] == '{' {
+		var collection struct {
+			Value json.RawMessage `json:"value"`
+		}
+		if err := json.Unmarshal(data, &collection); err != nil {
+			return fmt.Errorf("error parsing %q: %w", name, err)
+		}
+		data = collection.Value
+	}
+
+	if err := json.Unmarshal(data, out); err != nil {
+		return fmt.Errorf("error parsing %q: %w", name, err)
+	}
+	return nil
+}
+
+func readJSON(src fs.FS, name string, out any) error {
+	data, err := fs.ReadFile(src, name)
+	if err != nil {
+		return fmt.Errorf("error reading %q: %w", name, err)
+	}
+	if err := json.Unmarshal(data, out); err != nil {
+		return fmt.Errorf("error parsing %q: %w", name, err)
+	}
+	return nil
+}
+
+func (c *msTeamsConverter) addUser(user msTeamsUser) *imports.UserImportData {
+	if existing, ok := c.users[user.ID]; ok {
+		return existing
+	}
+
+	email := user.Mail
+	if email == "" && strings.Contains(user.UserPrincipalName, "@") {
+		email = user.UserPrincipalName
+	}
+	localPart, _, _ := strings.Cut(email, "@")
+
+	data := &imports.UserImportData{
+		Username: model.NewPointer(c.userNames.uniqueUsername(localPart, user.DisplayName)),
+	}
+	if email == "" {
+		email = *data.Username + "@msteams.invalid"
+		c.stats.warnf("user %q has no email address, using %q", user.DisplayName, email)
+	}
+	data.Email = model.NewPointer(strings.ToLower(email))
+
+	firstName, lastName := user.GivenName, user.Surname
+	if firstName == "" && lastName == "" {
+		firstName, lastName, _ = strings.Cut(user.DisplayName, " ")
+	}
+	data.FirstName = model.NewPointer(truncateRunes(firstName, model.UserFirstNameMaxRunes))
+	data.LastName = model.NewPointer(truncateRunes(lastName, model.UserLastNameMaxRunes))
+	if user.JobTitle != "" {
+		data.Position = model.NewPointer(truncateRunes(user.JobTitle, model.UserPositionMaxRunes))
+	}
+
+	c.users[user.ID] = data
+	return data
+}
+
+// userFor returns the username of an identity, adding the users missing
+// from users.json, such as guests and applications.
+func (c *msTeamsConverter) userFor(identity *msTeamsIdentity) string {
+	if user, ok := c.users[identity.ID]; ok {
+		return *user.Username
+	}
+	c.stats.warnf("user %q is not part of users.json", identity.DisplayName)
+	return *c.addUser(msTeamsUser{ID: identity.ID, DisplayName: identity.DisplayName}).Username
+}
+
+func (c *msTeamsConverter) addMembership(userID, team, channel string) {
+	teams, ok := c.memberships[userID]
+	if !ok {
+		teams = map[string]map[string]bool{}
+		c.memberships[userID] = teams
+	}
+	if teams[team] == nil {
+		teams[team] = map[string]bool{}
+	}
+	if channel != "" {
+		teams[team][channel] = true
+	}
+}
+
+func (c *msTeamsConverter) convertTeam(dir string) error {
+	var team msTeamsTeam
+	if err := readJSON(c.src, path.Join(dir, "team.json"), &team); err != nil {
+		return err
+	}
+
+	teamName := c.teamNames.unique(sanitizeName(team.DisplayName, "team", model.TeamNameMaxLength), model.TeamNameMaxLength)
+	teamType := model.TeamInvite
+	if team.Visibility == "public" {
+		teamType = model.TeamOpen
+	}
+	c.teams = append(c.teams, imports.LineImportData{
+		Type: LineTypeTeam,
+		Team: &imports.TeamImportData{
+			Name:        model.NewPointer(teamName),
+			DisplayName: model.NewPointer(truncateRunes(team.DisplayName, model.TeamDisplayNameMaxRunes)),
+			Type:        model.NewPointer(teamType),
+			Description: model.NewPointer(truncateRunes(team.Description, model.TeamDescriptionMaxLength)),
+		},
+	})
+	c.stats.Teams++
+
+	var members []msTeamsMember
+	if err := readOptionalGraphList(c.src, path.Join(dir, "members.json"), &members); err != nil {
+		return err
+	}
+	for _, member := range members {
+		c.addMembership(member.UserID, teamName, "")
+	}
+
+	channelFiles, err := fs.Glob(c.src, path.Join(dir, "channels", "*", "channel.json"))
+	if err != nil {
+		return err
+	}
+	channelNames := nameRegistry{}
+	for _, channelFile := range channelFiles {
+		if err := c.convertChannel(path.Dir(channelFile), teamName, channelNames); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func readOptionalGraphList(src fs.FS, name string, out any) error {
+	if _, err := fs.Stat(src, name); errors.Is(err, fs.ErrNotExist) {
+		return nil
+	}
+	return readGraphList(src, name, out)
+}
+
+func (c *msTeamsConverter) convertChannel(dir, teamName string, channelNames nameRegistry) error {
+	var channel msTeamsChannel
+	if err := readJSON(c.src, path.Join(dir, "channel.json"), &channel); err != nil {
+		return err
+	}
+
+	// The General channel of a team maps to its default channel.
+	channelName := model.DefaultChannelName
+	if channel.DisplayName != "General" || channelNames[model.DefaultChannelName] {
+		channelName = sanitizeName(channel.DisplayName, "channel", model.ChannelNameMaxLength)
+	}
+	channelName = channelNames.unique(channelName, model.ChannelNameMaxLength)
+
+	channelType := model.ChannelTypeOpen
+	if channel.MembershipType == "private" || channel.MembershipType == "shared" {
+		channelType = model.ChannelTypePrivate
+	}
+	c.channels = append(c.channels, imports.LineImportData{
+		Type: LineTypeChannel,
+		Channel: &imports.ChannelImportData{
+			Team:        model.NewPointer(teamName),
+			Name:        model.NewPointer(channelName),
+			DisplayName: model.NewPointer(truncateRunes(channel.DisplayName, model.ChannelDisplayNameMaxRunes)),
+			Type:        &channelType,
+			Purpose:     model.NewPointer(truncateRunes(channel.Description, model.ChannelPurposeMaxRunes)),
+		},
+	})
+	c.stats.Channels++
+
+	var members []msTeamsMember
+	if err := readOptionalGraphList(c.src, path.Join(dir, "members.json"), &members); err != nil {
+		return err
+	}
+	for _, member := range members {
+		c.addMembership(member.UserID, teamName, channelName)
+	}
+
+	var messages []msTeamsMessage
+	if err := readOptionalGraphList(c.src, path.Join(dir, "messages.json"), &messages); err != nil {
+		return err
+	}
+
+	for _, thread := range msTeamsThreads(messages) {
+		root := thread[0]
+		user, ok := c.author(root)
+		if !ok {
+			continue
+		}
+		c.addMembership(root.From.User.ID, teamName, channelName)
+
+		createAt := model.GetMillisForTime(root.CreatedDateTime)
+		post := &imports.PostImportData{
+			Team:        model.NewPointer(teamName),
+			Channel:     model.NewPointer(channelName),
+			User:        model.NewPointer(user),
+			Message:     model.NewPointer(c.message(root)),
+			CreateAt:    model.NewPointer(createAt),
+			Reactions:   c.reactions(root, createAt),
+			Attachments: c.attachments(dir, root),
+		}
+		if root.LastEditedDateTime != nil {
+			post.EditAt = model.NewPointer(model.GetMillisForTime(*root.LastEditedDateTime))
+		}
+
+		var replies []imports.ReplyImportData
+		for _, message := range thread[1:] {
+			replyUser, ok := c.author(message)
+			if !ok {
+				continue
+			}
+			c.addMembership(message.From.User.ID, teamName, channelName)
+
+			replyCreateAt := model.GetMillisForTime(message.CreatedDateTime)
+			reply := imports.ReplyImportData{
+				User:        model.NewPointer(replyUser),
+				Message:     model.NewPointer(c.message(message)),
+				CreateAt:    model.NewPointer(replyCreateAt),
+				Reactions:   c.reactions(message, replyCreateAt),
+				Attachments: c.attachments(dir, message),
+			}
+			if message.LastEditedDateTime != nil {
+				reply.EditAt = model.NewPointer(model.GetMillisForTime(*message.LastEditedDateTime))
+			}
+			replies = append(replies, reply)
+			c.stats.Replies++
+		}
+		if len(replies) > 0 {
+			post.Replies = &replies
+		}
+
+		c.posts = append(c.posts, imports.LineImportData{Type: LineTypePost, Post: post})
+		c.stats.Posts++
+	}
+
+	return nil
+}
+
+// msTeamsThreads groups the messages into threads sorted by time, each
+// starting with its root message.
+func msTeamsThreads(messages []msTeamsMessage) [][]msTeamsMessage {
+	var flat []msTeamsMessage
+	for _, message := range messages {
+		for _, reply := range message.Replies {
+			if reply.ReplyToID == "" {
+				reply.ReplyToID = message.ID
+			}
+			flat = append(flat, reply)
+		}
+		message.Replies = nil
+		flat = append(flat, message)
+	}
+
+	threads := map[string][]msTeamsMessage{}
+	var roots []string
+	for _, message := range flat {
+		if message.ReplyToID == "" {
+			roots = append(roots, message.ID)
+			threads[message.ID] = append([]msTeamsMessage{message}, threads[message.ID]...)
+		}
+	}
+	for _, message := range flat {
+		if message.ReplyToID == "" {
+			continue
+		}
+		if _, ok := threads[message.ReplyToID]; !ok {
+			// Replies to messages that aren't part of the export become
+			// root posts.
+			roots = append(roots, message.ID)
+			threads[message.ID] = []msTeamsMessage{message}
+			continue
+		}
+		threads[message.ReplyToID] = append(threads[message.ReplyToID], message)
+	}
+
+	result := make([][]msTeamsMessage, 0, len(roots))
+	for _, id := range roots {
+		thread := threads[id]
+		sort.SliceStable(thread[1:], func(i, j int) bool {
+			return thread[1+i].CreatedDateTime.Before(thread[1+j].CreatedDateTime)
+		})
+		result = append(result, thread)
+	}
+	sort.SliceStable(result, func(i, j int) bool {
+		return result[i][0].CreatedDateTime.Before(result[j][0].CreatedDateTime)
+	})
+
+	return result
+}
+
+// author returns the username of the author of a message, or false if the
+// message isn't a user message that can be imported.
+func (c *msTeamsConverter) author(message msTeamsMessage) (string, bool) {
+	if message.DeletedDateTime != nil || (message.MessageType != "" && message.MessageType != "message") {
+		return "", false
+	}
+	if message.From == nil {
+		c.stats.warnf("skipping message %s without an author", message.ID)
+		return "", false
+	}
+	if message.From.User == nil && message.From.Application != nil {
+		message.From.User = message.From.Application
+	}
+	if message.From.User == nil {
+		c.stats.warnf("skipping message %s without an author", message.ID)
+		return "", false
+	}
+	return c.userFor(message.From.User), true
+}
+
+func (c *msTeamsConverter) message(message msTeamsMessage) string {
+	text := message.Body.Content
+	if message.Body.ContentType == "html" {
+		mentions := map[string]string{}
+		for _, mention := range message.Mentions {
+			if mention.Mentioned.User != nil {
+				mentions[fmt.Sprint(mention.ID)] = c.userFor(mention.Mentioned.User)
+			}
+		}
+		text = htmlToMarkdown(text, mentions)
+	}
+	return c.truncateMessage(text, message.ID)
+}
+
+func (c *msTeamsConverter) reactions(message msTeamsMessage, parentCreateAt int64) *[]imports.ReactionImportData {
+	var reactions []imports.ReactionImportData
+	for _, reaction := range message.Reactions {
+		if reaction.User.User == nil {
+			continue
+		}
+		emojiName, ok := msTeamsReactions[reaction.ReactionType]
+		if !ok {
+			if emojiName, ok = emojiNameFromUnicode(reaction.ReactionType); !ok {
+				c.stats.warnf("skipping unknown reaction %q on message %s", reaction.ReactionType, message.ID)
+				continue
+			}
+		}
+		reactions = append(reactions, imports.ReactionImportData{
+			User:      model.NewPointer(c.userFor(reaction.User.User)),
+			EmojiName: model.NewPointer(emojiName),
+			CreateAt:  model.NewPointer(max(model.GetMillisForTime(reaction.CreatedDateTime), parentCreateAt)),
+		})
+		c.stats.Reactions++
+	}
+	return sortedReactions(reactions)
+}
+
+func (c *msTeamsConverter) attachments(dir string, message msTeamsMessage) *[]imports.AttachmentImportData {
+	if c.opts.SkipAttachments {
+		return nil
+	}
+
+	var attachments []imports.AttachmentImportData
+	for _, attachment := range message.Attachments {
+		if attachment.ContentType != "reference" {
+			continue
+		}
+		if data := c.addAttachment(c.src, path.Join(dir, "files", attachment.Name), attachment.ID, attachment.Name); data != nil {
+			attachments = append(attachments, *data)
+		}
+	}
+	if len(attachments) == 0 {
+		return nil
+	}
+	return &attachments
+}
+
+func (c *msTeamsConverter) convertChat(dir string) error {
+	var chat msTeamsChat
+	if err := readJSON(c.src, path.Join(dir, "chat.json"), &chat); err != nil {
+		return err
+	}
+
+	var members []string
+	for _, member := range chat.Members {
+		members = append(members, c.userFor(&msTeamsIdentity{ID: member.UserID, DisplayName: member.DisplayName}))
+	}
+	slices.Sort(members)
+	members = slices.Compact(members)
+	if len(members) == 1 {
+		members = append(members, members[0])
+	}
+	if len(members) < 2 || len(members) > model.ChannelGroupMaxUsers {
+		c.stats.warnf("skipping chat %q with %d members", chat.ID, len(members))
+		return nil
+	}
+
+	c.directChannels = append(c.directChannels, imports.LineImportData{
+		Type: LineTypeDirectChannel,
+		DirectChannel: &imports.DirectChannelImportData{
+			Members: &members,
+		},
+	})
+	c.stats.DirectChannels++
+
+	var messages []msTeamsMessage
+	if err := readOptionalGraphList(c.src, path.Join(dir, "messages.json"), &messages); err != nil {
+		return err
+	}
+
+	// Chats have no threads, so replies are imported as messages.
+	for _, thread := range msTeamsThreads(messages) {
+		for _, message := range thread {
+			user, ok := c.author(message)
+			if !ok {
+				continue
+			}
+			if !slices.Contains(members, user) {
+				c.stats.warnf("skipping message %s of %q who isn't a member of chat %q", message.ID, user, chat.ID)
+				continue
+			}
+
+			createAt := model.GetMillisForTime(message.CreatedDateTime)
+			post := &imports.DirectPostImportData{
+				ChannelMembers: &members,
+				User:           model.NewPointer(user),
+				Message:        model.NewPointer(c.message(message)),
+				CreateAt:       model.NewPointer(createAt),
+				Reactions:      c.reactions(message, createAt),
+				Attachments:    c.attachments(dir, message),
+			}
+			if message.LastEditedDateTime != nil {
+				post.EditAt = model.NewPointer(model.GetMillisForTime(*message.LastEditedDateTime))
+			}
+			c.directPosts = append(c.directPosts, imports.LineImportData{Type: LineTypeDirectPost, DirectPost: post})
+			c.stats.DirectPosts++
+		}
+	}
+
+	return nil
+}
+
+// writeUsers adds the user lines, including the team and channel
+// memberships gathered while converting the teams.
+func (c *msTeamsConverter) writeUsers() {
+	ids := make([]string, 0, len(c.users))
+	for id := range c.users {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool { return *c.users[ids[i]].Username < *c.users[ids[j]].Username })
+
+	for _, id := range ids {
+		user := c.users[id]
+		if teams := c.memberships[id]; len(teams) > 0 {
+			user.Teams = userTeamsImportData(teams)
+		}
+		c.userLines = append(c.userLines, imports.LineImportData{Type: LineTypeUser, User: user})
+		c.stats.Users++
+	}
+}
+
+func userTeamsImportData(teams map[string]map[string]bool) *[]imports.UserTeamImportData {
+	teamNames := make([]string, 0, len(teams))
+	for team := range teams {
+		teamNames = append(teamNames, team)
+	}
+	sort.Strings(teamNames)
+
+	data := make([]imports.UserTeamImportData, 0, len(teamNames))
+	for _, team := range teamNames {
+		channelNames := make([]string, 0, len(teams[team]))
+		for channel := range teams[team] {
+			channelNames = append(channelNames, channel)
+		}
+		sort.Strings(channelNames)
+
+		channels := make([]imports.UserChannelImportData, 0, len(channelNames))
+		for _, channel := range channelNames {
+			channels = append(channels, imports.UserChannelImportData{Name: model.NewPointer(channel)})
+		}
+		data = append(data, imports.UserTeamImportData{
+			Name:     model.NewPointer(team),
+			Channels: &channels,
+		})
+	}
+	return &data
+}
+
+var (
+	blockElements   = []string{"p", "div", "br", "li", "tr", "h1", "h2", "h3", "h4", "h5", "h6", "blockquote"}
+	repeatedNewline = regexp.MustCompile(`\n{3,}`)
+)
+
+// htmlToMarkdown converts the HTML body of a message to Markdown. Mention
+// tags are replaced by the usernames in mentions, keyed by mention id.
+func htmlToMarkdown(content string, mentions map[string]string) string {
+	var sb strings.Builder
+	var links []string
+	skipText := false
+
+	z := html.NewTokenizer(strings.NewReader(content))
+	for {
+		tt := z.Next()
+		switch tt {
+		case html.ErrorToken:
+			return strings.TrimSpace(repeatedNewline.ReplaceAllString(sb.String(), "\n\n"))
+		case html.TextToken:
+			if !skipText {
+				sb.Write(z.Text())
+			}
+		case html.StartTagToken, html.SelfClosingTagToken:
+			name, hasAttr := z.TagName()
+			attrs := map[string]string{}
+			for hasAttr {
+				var key, value []byte
+				key, value, hasAttr = z.TagAttr()
+				attrs[string(key)] = string(value)
+			}
+
+			switch string(name) {
+			case "at":
+				if username, ok := mentions[attrs["id"]]; ok {
+					sb.WriteString("@" + username)
+					skipText = true
+				}
+			case "b", "strong":
+				sb.WriteString("**")
+			case "i", "em":
+				sb.WriteString("_")
+			case "s", "strike", "del":
+				sb.WriteString("~~")
+			case "code":
+				sb.WriteString("`")
+			case "pre":
+				sb.WriteString("\n```\n")
+			case "a":
+				sb.WriteString("[")
+				links = append(links, attrs["href"])
+			case "li":
+				sb.WriteString("\n- ")
+			case "blockquote":
+				sb.WriteString("\n> ")
+			case "img":
+				if alt := attrs["alt"]; alt != "" {
+					sb.WriteString(alt)
+				}
+			case "br":
+				sb.WriteString("\n")
+			}
+		case html.EndTagToken:
+			name, _ := z.TagName()
+			switch tag := string(name); tag {
+			case "at":
+				skipText = false
+			case "b", "strong":
+				sb.WriteString("**")
+			case "i", "em":
+				sb.WriteString("_")
+			case "s", "strike", "del":
+				sb.WriteString("~~")
+			case "code":
+				sb.WriteString("`")
+			case "pre":
+				sb.WriteString("\n```\n")
+			case "a":
+				href := ""
+				if len(links) > 0 {
+					href, links = links[len(links)-1], links[:len(links)-1]
+				}
+				sb.WriteString("](" + href + ")")
+			default:
+				if slices.Contains(blockElements, tag) {
+					sb.WriteString("\n")
+				}
+			}
+		}
+	}
+}
+
+func truncateRunes(s string, maxRunes int) string {
+	if runes := []rune(s); len(runes) > maxRunes {
+		return string(runes[:maxRunes])
+	}
+	return s
+}
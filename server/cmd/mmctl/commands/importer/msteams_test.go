@@ -0,0 +1,339 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package importer
+
+import (
+	"io"
+	"path"
+	"testing"
+	"testing/fstest"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost/server/public/model"
+)
+
+const msTeamsUsers = `{"value":[
+	{"id":"u1","displayName":"Ada Lovelace","givenName":"Ada","surname":"Lovelace","mail":"Ada@Example.com","userPrincipalName":"ada.l@example.com","jobTitle":"Analyst"},
+	{"id":"u2","displayName":"Alan Turing","userPrincipalName":"alan@example.com"},
+	{"id":"u3","displayName":"Grace Hopper","userPrincipalName":"grace"}]}`
+
+func msTeamsFiles(files map[string]string) fstest.MapFS {
+	src := fstest.MapFS{
+		"users.json":         {Data: []byte(msTeamsUsers)},
+		"teams/t1/team.json": {Data: []byte(`{"id":"t1","displayName":"Engineering","description":"Builders","visibility":"private"}`)},
+	}
+	for name, content := range files {
+		src[name] = &fstest.MapFile{Data: []byte(content)}
+	}
+	return src
+}
+
+func TestConvertMSTeams(t *testing.T) {
+	testCases := []struct {
+		name  string
+		files map[string]string
+		opts  ConvertOptions
+		check func(t *testing.T, archive *convertedArchive)
+	}{
+		{
+			name: "users",
+			check: func(t *testing.T, archive *convertedArchive) {
+				assert.Equal(t, 3, archive.stats.Users)
+
+				ada := archive.user("ada")
+				require.NotNil(t, ada)
+				assert.Equal(t, "ada@example.com", *ada.Email)
+				assert.Equal(t, "Ada", *ada.FirstName)
+				assert.Equal(t, "Lovelace", *ada.LastName)
+				assert.Equal(t, "Analyst", *ada.Position)
+
+				// The user principal name is used without a mail, and the
+				// display name is split without a given name and surname.
+				alan := archive.user("alan")
+				require.NotNil(t, alan)
+				assert.Equal(t, "alan@example.com", *alan.Email)
+				assert.Equal(t, "Alan", *alan.FirstName)
+				assert.Equal(t, "Turing", *alan.LastName)
+				assert.Nil(t, alan.Position)
+
+				// Without an email address, the username is derived from the
+				// display name.
+				grace := archive.user("gracehopper")
+				require.NotNil(t, grace)
+				assert.Equal(t, "gracehopper@msteams.invalid", *grace.Email)
+				require.Len(t, archive.stats.Warnings, 1)
+				assert.Contains(t, archive.stats.Warnings[0], "Grace Hopper")
+			},
+		},
+		{
+			name: "teams and channels",
+			files: map[string]string{
+				"teams/t1/members.json":              `[{"userId":"u1","roles":["owner"]},{"userId":"u2"}]`,
+				"teams/t1/channels/c1/channel.json":  `{"id":"c1","displayName":"General","membershipType":"standard"}`,
+				"teams/t1/channels/c2/channel.json":  `{"id":"c2","displayName":"Design Reviews","description":"Reviews","membershipType":"private"}`,
+				"teams/t1/channels/c2/members.json":  `{"value":[{"userId":"u1"}]}`,
+				"teams/t1/channels/c3/channel.json":  `{"id":"c3","displayName":"Partners","membershipType":"shared"}`,
+				"teams/t2/team.json":                 `{"id":"t2","displayName":"Engineering","visibility":"public"}`,
+				"teams/t2/channels/c4/channel.json":  `{"id":"c4","displayName":"General"}`,
+				"teams/t2/channels/c4/messages.json": `[]`,
+			},
+			check: func(t *testing.T, archive *convertedArchive) {
+				teams := archive.linesOfType(LineTypeTeam)
+				require.Len(t, teams, 2)
+				assert.Equal(t, "engineering", *teams[0].Team.Name)
+				assert.Equal(t, model.TeamInvite, *teams[0].Team.Type)
+				assert.Equal(t, "Builders", *teams[0].Team.Description)
+				assert.Equal(t, "engineering-2", *teams[1].Team.Name)
+				assert.Equal(t, model.TeamOpen, *teams[1].Team.Type)
+
+				channels := archive.linesOfType(LineTypeChannel)
+				require.Len(t, channels, 4)
+				assert.Equal(t, model.DefaultChannelName, *channels[0].Channel.Name)
+				assert.Equal(t, "General", *channels[0].Channel.DisplayName)
+				assert.Equal(t, model.ChannelTypeOpen, *channels[0].Channel.Type)
+				assert.Equal(t, "design-reviews", *channels[1].Channel.Name)
+				assert.Equal(t, "Reviews", *channels[1].Channel.Purpose)
+				assert.Equal(t, model.ChannelTypePrivate, *channels[1].Channel.Type)
+				assert.Equal(t, model.ChannelTypePrivate, *channels[2].Channel.Type)
+				assert.Equal(t, "engineering-2", *channels[3].Channel.Team)
+				assert.Equal(t, model.DefaultChannelName, *channels[3].Channel.Name)
+
+				ada := archive.user("ada")
+				require.NotNil(t, ada.Teams)
+				require.Len(t, *ada.Teams, 1)
+				assert.Equal(t, "engineering", *(*ada.Teams)[0].Name)
+				require.Len(t, *(*ada.Teams)[0].Channels, 1)
+				assert.Equal(t, "design-reviews", *(*(*ada.Teams)[0].Channels)[0].Name)
+
+				// Team members without channel memberships are still added
+				// to the team.
+				alan := archive.user("alan")
+				require.NotNil(t, alan.Teams)
+				assert.Empty(t, *(*alan.Teams)[0].Channels)
+
+				assert.Nil(t, archive.user("gracehopper").Teams)
+			},
+		},
+		{
+			name: "posts and replies",
+			files: map[string]string{
+				"teams/t1/channels/c1/channel.json": `{"id":"c1","displayName":"General"}`,
+				"teams/t1/channels/c1/messages.json": `[
+					{"id":"m1","messageType":"message","createdDateTime":"2024-01-02T10:00:00Z","lastEditedDateTime":"2024-01-02T10:30:00Z","from":{"user":{"id":"u1"}},"body":{"contentType":"text","content":"Root"},
+					 "replies":[{"id":"m2","createdDateTime":"2024-01-02T10:20:00Z","from":{"user":{"id":"u2"}},"body":{"contentType":"text","content":"Nested reply"}}]},
+					{"id":"m3","replyToId":"m1","messageType":"message","createdDateTime":"2024-01-02T10:10:00Z","from":{"user":{"id":"u3"}},"body":{"contentType":"text","content":"Flat reply"}},
+					{"id":"m4","replyToId":"m0","messageType":"message","createdDateTime":"2024-01-02T09:00:00Z","from":{"user":{"id":"u2"}},"body":{"contentType":"text","content":"Reply to a missing message"}},
+					{"id":"m5","messageType":"systemEventMessage","createdDateTime":"2024-01-02T11:00:00Z","body":{"contentType":"html","content":""}},
+					{"id":"m6","messageType":"message","createdDateTime":"2024-01-02T11:00:00Z","deletedDateTime":"2024-01-02T12:00:00Z","from":{"user":{"id":"u1"}},"body":{"contentType":"text","content":""}},
+					{"id":"m7","messageType":"message","createdDateTime":"2024-01-02T12:00:00Z","from":{"application":{"id":"app1","displayName":"Build Bot"}},"body":{"contentType":"text","content":"Build passed"}},
+					{"id":"m8","messageType":"message","createdDateTime":"2024-01-02T13:00:00Z","body":{"contentType":"text","content":"Anonymous"}}]`,
+			},
+			check: func(t *testing.T, archive *convertedArchive) {
+				posts := archive.posts()
+				require.Len(t, posts, 3)
+
+				assert.Equal(t, "Reply to a missing message", *posts[0].Message)
+
+				assert.Equal(t, "Root", *posts[1].Message)
+				assert.Equal(t, "ada", *posts[1].User)
+				assert.Equal(t, model.GetMillisForTime(mustParseTime(t, "2024-01-02T10:30:00Z")), *posts[1].EditAt)
+				require.NotNil(t, posts[1].Replies)
+				require.Len(t, *posts[1].Replies, 2)
+				assert.Equal(t, "Flat reply", *(*posts[1].Replies)[0].Message)
+				assert.Equal(t, "Nested reply", *(*posts[1].Replies)[1].Message)
+
+				// Applications are imported as users.
+				assert.Equal(t, "Build passed", *posts[2].Message)
+				assert.Equal(t, "buildbot", *posts[2].User)
+				assert.NotNil(t, archive.user("buildbot"))
+
+				assert.Equal(t, 3, archive.stats.Posts)
+				assert.Equal(t, 2, archive.stats.Replies)
+
+				// Authors become channel members.
+				grace := archive.user("gracehopper")
+				require.NotNil(t, grace.Teams)
+				assert.Equal(t, model.DefaultChannelName, *(*(*grace.Teams)[0].Channels)[0].Name)
+
+				assert.Contains(t, archive.stats.Warnings, `user "Build Bot" is not part of users.json`)
+				assert.Contains(t, archive.stats.Warnings, "skipping message m8 without an author")
+			},
+		},
+		{
+			name: "html and mentions",
+			files: map[string]string{
+				"teams/t1/channels/c1/channel.json": `{"id":"c1","displayName":"General"}`,
+				"teams/t1/channels/c1/messages.json": `[{"id":"m1","messageType":"message","createdDateTime":"2024-01-02T10:00:00Z","from":{"user":{"id":"u1"}},
+					"body":{"contentType":"html","content":"<p>Hi <at id=\"0\">Alan Turing</at> and <at id=\"1\">Someone</at>, see <a href=\"https://example.com\">this</a></p>"},
+					"mentions":[{"id":0,"mentioned":{"user":{"id":"u2","displayName":"Alan Turing"}}},{"id":1,"mentioned":{}}]}]`,
+			},
+			check: func(t *testing.T, archive *convertedArchive) {
+				require.Len(t, archive.posts(), 1)
+				assert.Equal(t, "Hi @alan and Someone, see [this](https://example.com)", *archive.posts()[0].Message)
+			},
+		},
+		{
+			name: "reactions",
+			files: map[string]string{
+				"teams/t1/channels/c1/channel.json": `{"id":"c1","displayName":"General"}`,
+				"teams/t1/channels/c1/messages.json": `[{"id":"m1","messageType":"message","createdDateTime":"2024-01-02T10:00:00Z","from":{"user":{"id":"u1"}},"body":{"contentType":"text","content":"Hi"},
+					"reactions":[
+						{"reactionType":"heart","createdDateTime":"2024-01-02T10:06:00Z","user":{"user":{"id":"u1"}}},
+						{"reactionType":"like","createdDateTime":"2024-01-02T10:05:00Z","user":{"user":{"id":"u2"}}},
+						{"reactionType":"😂","createdDateTime":"2024-01-02T09:00:00Z","user":{"user":{"id":"u3"}}},
+						{"reactionType":"custom","createdDateTime":"2024-01-02T10:07:00Z","user":{"user":{"id":"u3"}}},
+						{"reactionType":"like","createdDateTime":"2024-01-02T10:08:00Z","user":{}}]}]`,
+			},
+			check: func(t *testing.T, archive *convertedArchive) {
+				post := archive.posts()[0]
+				require.NotNil(t, post.Reactions)
+				reactions := *post.Reactions
+				require.Len(t, reactions, 3)
+				// Reactions are sorted by time, and can't be older than the
+				// post.
+				assert.Equal(t, "joy", *reactions[0].EmojiName)
+				assert.Equal(t, *post.CreateAt, *reactions[0].CreateAt)
+				assert.Equal(t, "+1", *reactions[1].EmojiName)
+				assert.Equal(t, "alan", *reactions[1].User)
+				assert.Equal(t, "heart", *reactions[2].EmojiName)
+
+				assert.Equal(t, 3, archive.stats.Reactions)
+				require.Len(t, archive.stats.Warnings, 2)
+				assert.Contains(t, archive.stats.Warnings[1], `"custom"`)
+			},
+		},
+		{
+			name: "attachments",
+			files: map[string]string{
+				"teams/t1/channels/c1/channel.json": `{"id":"c1","displayName":"General"}`,
+				"teams/t1/channels/c1/messages.json": `[{"id":"m1","messageType":"message","createdDateTime":"2024-01-02T10:00:00Z","from":{"user":{"id":"u1"}},"body":{"contentType":"text","content":"Files"},
+					"attachments":[
+						{"id":"a1","contentType":"reference","name":"doc.txt"},
+						{"id":"a2","contentType":"reference","name":"missing.txt"},
+						{"id":"a3","contentType":"messageReference","name":"quoted"}]}]`,
+				"teams/t1/channels/c1/files/doc.txt": "hello",
+			},
+			check: func(t *testing.T, archive *convertedArchive) {
+				post := archive.posts()[0]
+				require.NotNil(t, post.Attachments)
+				require.Len(t, *post.Attachments, 1)
+				assert.Equal(t, "attachments/a1/doc.txt", *(*post.Attachments)[0].Path)
+				assert.Equal(t, "hello", archive.files[path.Join(model.ExportDataDir, "attachments/a1/doc.txt")])
+				assert.Equal(t, 1, archive.stats.Attachments)
+
+				require.Len(t, archive.stats.Warnings, 2)
+				assert.Contains(t, archive.stats.Warnings[1], "missing.txt")
+			},
+		},
+		{
+			name: "skipped attachments",
+			files: map[string]string{
+				"teams/t1/channels/c1/channel.json": `{"id":"c1","displayName":"General"}`,
+				"teams/t1/channels/c1/messages.json": `[{"id":"m1","messageType":"message","createdDateTime":"2024-01-02T10:00:00Z","from":{"user":{"id":"u1"}},"body":{"contentType":"text","content":"Files"},
+					"attachments":[{"id":"a1","contentType":"reference","name":"doc.txt"}]}]`,
+				"teams/t1/channels/c1/files/doc.txt": "hello",
+			},
+			opts: ConvertOptions{SkipAttachments: true},
+			check: func(t *testing.T, archive *convertedArchive) {
+				assert.Nil(t, archive.posts()[0].Attachments)
+				assert.Equal(t, 0, archive.stats.Attachments)
+				assert.Len(t, archive.files, 1)
+			},
+		},
+		{
+			name: "chats",
+			files: map[string]string{
+				"chats/ch1/chat.json": `{"id":"ch1","chatType":"oneOnOne","members":[{"userId":"u2"},{"userId":"u1"}]}`,
+				"chats/ch1/messages.json": `[
+					{"id":"d1","messageType":"message","createdDateTime":"2024-01-03T09:00:00Z","from":{"user":{"id":"u2"}},"body":{"contentType":"text","content":"Ping"},
+					 "replies":[{"id":"d2","createdDateTime":"2024-01-03T09:01:00Z","from":{"user":{"id":"u1"}},"body":{"contentType":"text","content":"Pong"}}]},
+					{"id":"d3","messageType":"message","createdDateTime":"2024-01-03T09:02:00Z","from":{"user":{"id":"u3"}},"body":{"contentType":"text","content":"Intruder"}}]`,
+				"chats/ch2/chat.json":     `{"id":"ch2","chatType":"oneOnOne","members":[{"userId":"u1"}]}`,
+				"chats/ch2/messages.json": `[{"id":"d4","messageType":"message","createdDateTime":"2024-01-03T10:00:00Z","from":{"user":{"id":"u1"}},"body":{"contentType":"text","content":"Note to self"}}]`,
+				"chats/ch3/chat.json":     `{"id":"ch3","chatType":"group","members":[]}`,
+			},
+			check: func(t *testing.T, archive *convertedArchive) {
+				directChannels := archive.linesOfType(LineTypeDirectChannel)
+				require.Len(t, directChannels, 2)
+				assert.Equal(t, []string{"ada", "alan"}, *directChannels[0].DirectChannel.Members)
+				assert.Equal(t, []string{"ada", "ada"}, *directChannels[1].DirectChannel.Members)
+
+				// Chats have no threads, so replies are imported as posts.
+				posts := archive.directPosts()
+				require.Len(t, posts, 3)
+				assert.Equal(t, "Ping", *posts[0].Message)
+				assert.Equal(t, "alan", *posts[0].User)
+				assert.Equal(t, "Pong", *posts[1].Message)
+				assert.Equal(t, "Note to self", *posts[2].Message)
+				assert.Equal(t, []string{"ada", "ada"}, *posts[2].ChannelMembers)
+
+				require.Len(t, archive.stats.Warnings, 3)
+				assert.Contains(t, archive.stats.Warnings[1], "d3")
+				assert.Contains(t, archive.stats.Warnings[2], `"ch3"`)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.check(t, convertArchive(t, ConvertMSTeams, msTeamsFiles(tc.files), tc.opts))
+		})
+	}
+}
+
+func TestConvertMSTeamsMissingUsers(t *testing.T) {
+	_, err := ConvertMSTeams(fstest.MapFS{}, io.Discard, ConvertOptions{})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "users.json")
+}
+
+func TestMSTeamsThreads(t *testing.T) {
+	messages := []msTeamsMessage{
+		{ID: "2", CreatedDateTime: mustParseTime(t, "2024-01-02T11:00:00Z")},
+		{ID: "1", CreatedDateTime: mustParseTime(t, "2024-01-02T10:00:00Z"), Replies: []msTeamsMessage{
+			{ID: "4", CreatedDateTime: mustParseTime(t, "2024-01-02T10:30:00Z")},
+		}},
+		{ID: "3", ReplyToID: "1", CreatedDateTime: mustParseTime(t, "2024-01-02T10:15:00Z")},
+	}
+
+	var ids [][]string
+	for _, thread := range msTeamsThreads(messages) {
+		var threadIDs []string
+		for _, message := range thread {
+			threadIDs = append(threadIDs, message.ID)
+		}
+		ids = append(ids, threadIDs)
+	}
+	assert.Equal(t, [][]string{{"1", "3", "4"}, {"2"}}, ids)
+}
+
+func TestHTMLToMarkdown(t *testing.T) {
+	testCases := []struct {
+		name     string
+		html     string
+		mentions map[string]string
+		expected string
+	}{
+		{"plain text", "Hello", nil, "Hello"},
+		{"paragraphs", "<p>One</p><p>Two</p>", nil, "One\nTwo"},
+		{"line breaks", "One<br>Two<br/>Three", nil, "One\nTwo\nThree"},
+		{"repeated blank lines collapsed", "<div>One</div><br><br><br><div>Two</div>", nil, "One\n\nTwo"},
+		{"emphasis", "<b>bold</b> <strong>strong</strong> <i>italic</i> <em>em</em> <s>gone</s>", nil, "**bold** **strong** _italic_ _em_ ~~gone~~"},
+		{"code", "Run <code>make</code><pre>go test</pre>", nil, "Run `make`\n```\ngo test\n```"},
+		{"links", `<a href="https://example.com">Example</a>`, nil, "[Example](https://example.com)"},
+		{"lists", "<ul><li>One</li><li>Two</li></ul>", nil, "- One\n\n- Two"},
+		{"quotes", "<blockquote>Quoted</blockquote>", nil, "> Quoted"},
+		{"images", `<img alt="diagram" src="x.png">`, nil, "diagram"},
+		{"entities", "Fish &amp; chips", nil, "Fish & chips"},
+		{"mentions", `<at id="0">Ada Lovelace</at> and <at id="1">Alan</at>`, map[string]string{"0": "ada"}, "@ada and Alan"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, htmlToMarkdown(tc.html, tc.mentions))
+		})
+	}
+}
@@ -27,7 +27,8 @@ var listJobsCmd = &cobra.Command{
 	Example: `  job list
 	job list --ids jobID1,jobID2
 	job list --type ldap_sync --status success
-	job list --type ldap_sync --status success --page 0 --per-page 10`,
+	job list --type ldap_sync --status success --page 0 --per-page 10
+	job list --type plugin:com.example.plugin:sync`,
 	Args: cobra.NoArgs,
 	RunE: withClient(listJobsCmdF),
 }
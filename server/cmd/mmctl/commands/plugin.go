@@ -7,7 +7,10 @@ import (
 	"context"
 	"fmt"
 	"os"
+	"slices"
+	"time"
 
+	"github.com/mattermost/mattermost/server/public/model"
 	"github.com/mattermost/mattermost/server/v8/cmd/mmctl/client"
 	"github.com/mattermost/mattermost/server/v8/cmd/mmctl/printer"
 
@@ -78,6 +81,24 @@ var PluginListCmd = &cobra.Command{
 	RunE:    withClient(pluginListCmdF),
 }
 
+var PluginStatusCmd = &cobra.Command{
+	Use:   "status [plugins]",
+	Short: "Show the status of plugins",
+	Long:  "Show the state and database schema version of the plugins installed on each server of the cluster, optionally limited to the given plugins.",
+	Example: `  plugin status
+  plugin status hovercardexample`,
+	RunE: withClient(pluginStatusCmdF),
+}
+
+var PluginVerifyCmd = &cobra.Command{
+	Use:     "verify",
+	Short:   "Verify the signatures of plugins",
+	Long:    "Verify the signatures of the plugins installed on the server against the trusted public keys and the revoked keys, reporting the key each plugin is signed with. Fails if any plugin has an invalid, expired or revoked signature.",
+	Example: `  plugin verify`,
+	Args:    cobra.NoArgs,
+	RunE:    withClient(pluginVerifyCmdF),
+}
+
 func init() {
 	PluginAddCmd.Flags().BoolP("force", "f", false, "overwrite a previously installed plugin with the same ID, if any")
 	PluginInstallURLCmd.Flags().BoolP("force", "f", false, "overwrite a previously installed plugin with the same ID, if any")
@@ -89,6 +110,8 @@ func init() {
 		PluginEnableCmd,
 		PluginDisableCmd,
 		PluginListCmd,
+		PluginStatusCmd,
+		PluginVerifyCmd,
 	)
 	RootCmd.AddCommand(PluginCmd)
 }
@@ -205,3 +228,58 @@ func pluginListCmdF(c client.Client, cmd *cobra.Command, args []string) error {
 
 	return nil
 }
+
+var pluginStateNames = map[int]string{
+	model.PluginStateNotRunning:          "not running",
+	model.PluginStateStarting:            "starting",
+	model.PluginStateRunning:             "running",
+	model.PluginStateFailedToStart:       "failed to start",
+	model.PluginStateFailedToStayRunning: "failed to stay running",
+	model.PluginStateStopping:            "stopping",
+}
+
+func pluginStatusCmdF(c client.Client, cmd *cobra.Command, args []string) error {
+	statuses, _, err := c.GetPluginStatuses(context.TODO())
+	if err != nil {
+		return errors.New("Unable to get plugin statuses. Error: " + err.Error())
+	}
+
+	for _, status := range statuses {
+		if len(args) > 0 && !slices.Contains(args, status.PluginId) {
+			continue
+		}
+
+		printer.PrintT(fmt.Sprintf("{{.PluginId}}{{if .ClusterId}} ({{.ClusterId}}){{end}}: Version: {{.Version}}, State: %s, Schema version: {{.SchemaVersion}}", pluginStateNames[status.State]), status)
+	}
+
+	return nil
+}
+
+func pluginVerifyCmdF(c client.Client, cmd *cobra.Command, args []string) error {
+	reports, _, err := c.GetPluginSignatureReports(context.TODO())
+	if err != nil {
+		return errors.New("Unable to verify plugin signatures. Error: " + err.Error())
+	}
+
+	var failed int
+	for _, report := range reports {
+		tpl := "{{.PluginId}} {{.Version}}: {{.Status}}"
+		if report.Signature != nil {
+			signedAt := model.GetTimeForMillis(report.Signature.SignedAt).UTC().Format(time.RFC3339)
+			tpl += ", key {{.Signature.KeyId}}{{if .Signature.KeyName}} ({{.Signature.KeyName}}){{end}}, signed at " + signedAt
+		}
+		tpl += "{{if .Error}}, error: {{.Error}}{{end}}"
+		printer.PrintT(tpl, report)
+
+		switch report.Status {
+		case model.PluginSignatureStatusInvalid, model.PluginSignatureStatusExpired, model.PluginSignatureStatusRevoked:
+			failed++
+		}
+	}
+
+	if failed > 0 {
+		return fmt.Errorf("%d plugin(s) failed signature verification", failed)
+	}
+
+	return nil
+}
@@ -5,6 +5,7 @@ package commands
 
 import (
 	"context"
+	"os"
 
 	"github.com/mattermost/mattermost/server/public/model"
 
@@ -29,6 +30,19 @@ var PluginMarketplaceInstallCmd = &cobra.Command{
 	RunE:    withClient(pluginMarketplaceInstallCmdF),
 }
 
+var PluginMarketplacePublishCmd = &cobra.Command{
+	Use:   "publish <bundle>",
+	Short: "Publish a plugin to the marketplace catalog",
+	Long:  "Adds a signed plugin bundle to the marketplace catalog configured in PluginSettings.MarketplaceCatalogPath, so it can be installed from the marketplace without access to the marketplace server",
+	Example: `  # The signature is read from the bundle path with the .sig extension by default
+  $ mmctl plugin marketplace publish jitsi-2.0.0.tar.gz
+
+  # The signature can be given explicitly as well
+  $ mmctl plugin marketplace publish jitsi-2.0.0.tar.gz --signature jitsi.sig`,
+	Args: cobra.ExactArgs(1),
+	RunE: withClient(pluginMarketplacePublishCmdF),
+}
+
 var PluginMarketplaceListCmd = &cobra.Command{
 	Use:   "list",
 	Short: "List marketplace plugins",
@@ -55,9 +69,12 @@ func init() {
 	PluginMarketplaceListCmd.Flags().String("filter", "", "Filter plugins by ID, name or description")
 	PluginMarketplaceListCmd.Flags().Bool("local-only", false, "Only retrieve local plugins")
 
+	PluginMarketplacePublishCmd.Flags().String("signature", "", "Path to the signature of the bundle. Defaults to the bundle path with the .sig extension")
+
 	PluginMarketplaceCmd.AddCommand(
 		PluginMarketplaceInstallCmd,
 		PluginMarketplaceListCmd,
+		PluginMarketplacePublishCmd,
 	)
 
 	PluginCmd.AddCommand(
@@ -79,6 +96,35 @@ func pluginMarketplaceInstallCmdF(c client.Client, _ *cobra.Command, args []stri
 	return nil
 }
 
+func pluginMarketplacePublishCmdF(c client.Client, cmd *cobra.Command, args []string) error {
+	bundlePath := args[0]
+	signaturePath, _ := cmd.Flags().GetString("signature")
+	if signaturePath == "" {
+		signaturePath = bundlePath + ".sig"
+	}
+
+	bundle, err := os.Open(bundlePath)
+	if err != nil {
+		return errors.Wrap(err, "couldn't open plugin bundle")
+	}
+	defer bundle.Close()
+
+	signature, err := os.Open(signaturePath)
+	if err != nil {
+		return errors.Wrap(err, "couldn't open plugin signature")
+	}
+	defer signature.Close()
+
+	manifest, _, err := c.PublishMarketplaceCatalogPlugin(context.TODO(), bundle, signature)
+	if err != nil {
+		return errors.Wrap(err, "couldn't publish plugin to the marketplace catalog")
+	}
+
+	printer.PrintT("Plugin {{.Name}} {{.Version}} successfully published", manifest)
+
+	return nil
+}
+
 func pluginMarketplaceListCmdF(c client.Client, cmd *cobra.Command, _ []string) error {
 	page, _ := cmd.Flags().GetInt("page")
 	perPage, _ := cmd.Flags().GetInt("per-page")
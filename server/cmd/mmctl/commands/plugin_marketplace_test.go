@@ -5,7 +5,10 @@ package commands
 
 import (
 	"context"
+	"os"
+	"path/filepath"
 
+	gomock "github.com/golang/mock/gomock"
 	"github.com/mattermost/mattermost/server/public/model"
 	"github.com/pkg/errors"
 
@@ -166,3 +169,79 @@ func (s *MmctlUnitTestSuite) TestPluginMarketplaceListCmd() {
 		s.Require().Equal(mockPlugin, printer.GetLines()[0])
 	})
 }
+
+func (s *MmctlUnitTestSuite) TestPluginMarketplacePublishCmd() {
+	dir := s.T().TempDir()
+	bundlePath := filepath.Join(dir, "myplugin.tar.gz")
+	s.Require().NoError(os.WriteFile(bundlePath, []byte("bundle"), 0600))
+	s.Require().NoError(os.WriteFile(bundlePath+".sig", []byte("signature"), 0600))
+	otherSignaturePath := filepath.Join(dir, "other.sig")
+	s.Require().NoError(os.WriteFile(otherSignaturePath, []byte("signature"), 0600))
+
+	s.Run("Publish with the default signature path", func() {
+		printer.Clean()
+
+		cmd := &cobra.Command{}
+		cmd.Flags().String("signature", "", "")
+		manifest := &model.Manifest{Name: "My Plugin", Id: "myplugin", Version: "1.0.0"}
+
+		s.client.
+			EXPECT().
+			PublishMarketplaceCatalogPlugin(context.TODO(), gomock.Any(), gomock.Any()).
+			Return(manifest, &model.Response{}, nil).
+			Times(1)
+
+		err := pluginMarketplacePublishCmdF(s.client, cmd, []string{bundlePath})
+		s.Require().NoError(err)
+		s.Require().Len(printer.GetErrorLines(), 0)
+		s.Require().Len(printer.GetLines(), 1)
+		s.Require().Equal(manifest, printer.GetLines()[0])
+	})
+
+	s.Run("Publish with an explicit signature path", func() {
+		printer.Clean()
+
+		cmd := &cobra.Command{}
+		cmd.Flags().String("signature", otherSignaturePath, "")
+		manifest := &model.Manifest{Name: "My Plugin", Id: "myplugin", Version: "1.0.0"}
+
+		s.client.
+			EXPECT().
+			PublishMarketplaceCatalogPlugin(context.TODO(), gomock.Any(), gomock.Any()).
+			Return(manifest, &model.Response{}, nil).
+			Times(1)
+
+		err := pluginMarketplacePublishCmdF(s.client, cmd, []string{bundlePath})
+		s.Require().NoError(err)
+		s.Require().Len(printer.GetLines(), 1)
+	})
+
+	s.Run("Fail to find the signature", func() {
+		printer.Clean()
+
+		cmd := &cobra.Command{}
+		cmd.Flags().String("signature", filepath.Join(dir, "missing.sig"), "")
+
+		err := pluginMarketplacePublishCmdF(s.client, cmd, []string{bundlePath})
+		s.Require().Error(err)
+		s.Require().Len(printer.GetLines(), 0)
+	})
+
+	s.Run("Fail to publish", func() {
+		printer.Clean()
+
+		cmd := &cobra.Command{}
+		cmd.Flags().String("signature", "", "")
+
+		s.client.
+			EXPECT().
+			PublishMarketplaceCatalogPlugin(context.TODO(), gomock.Any(), gomock.Any()).
+			Return(nil, &model.Response{}, errors.New("mock error")).
+			Times(1)
+
+		err := pluginMarketplacePublishCmdF(s.client, cmd, []string{bundlePath})
+		s.Require().Error(err)
+		s.Require().Len(printer.GetErrorLines(), 0)
+		s.Require().Len(printer.GetLines(), 0)
+	})
+}
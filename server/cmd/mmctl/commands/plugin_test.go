@@ -534,6 +534,156 @@ func (s *MmctlUnitTestSuite) TestPluginListCmd() {
 	})
 }
 
+func (s *MmctlUnitTestSuite) TestPluginStatusCmd() {
+	mockStatuses := model.PluginStatuses{
+		{
+			PluginId:      "id1",
+			Version:       "v1",
+			State:         model.PluginStateRunning,
+			SchemaVersion: 3,
+		},
+		{
+			PluginId:  "id2",
+			ClusterId: "node2",
+			Version:   "v2",
+			State:     model.PluginStateFailedToStart,
+		},
+	}
+
+	s.Run("Show plugin statuses", func() {
+		printer.Clean()
+		printer.SetFormat(printer.FormatPlain)
+		defer printer.SetFormat(printer.FormatJSON)
+
+		s.client.
+			EXPECT().
+			GetPluginStatuses(context.TODO()).
+			Return(mockStatuses, &model.Response{}, nil).
+			Times(1)
+
+		err := pluginStatusCmdF(s.client, &cobra.Command{}, nil)
+		s.Require().NoError(err)
+		s.Require().Len(printer.GetErrorLines(), 0)
+		s.Require().Len(printer.GetLines(), 2)
+		s.Require().Equal("id1: Version: v1, State: running, Schema version: 3", printer.GetLines()[0])
+		s.Require().Equal("id2 (node2): Version: v2, State: failed to start, Schema version: 0", printer.GetLines()[1])
+	})
+
+	s.Run("Show the status of the given plugins", func() {
+		printer.Clean()
+
+		s.client.
+			EXPECT().
+			GetPluginStatuses(context.TODO()).
+			Return(mockStatuses, &model.Response{}, nil).
+			Times(1)
+
+		err := pluginStatusCmdF(s.client, &cobra.Command{}, []string{"id2"})
+		s.Require().NoError(err)
+		s.Require().Len(printer.GetLines(), 1)
+		s.Require().Equal(mockStatuses[1], printer.GetLines()[0])
+	})
+
+	s.Run("GetPluginStatuses returns error", func() {
+		printer.Clean()
+		mockError := errors.New("mock error")
+
+		s.client.
+			EXPECT().
+			GetPluginStatuses(context.TODO()).
+			Return(nil, &model.Response{}, mockError).
+			Times(1)
+
+		err := pluginStatusCmdF(s.client, &cobra.Command{}, nil)
+		s.Require().EqualError(err, "Unable to get plugin statuses. Error: "+mockError.Error())
+		s.Require().Len(printer.GetLines(), 0)
+	})
+}
+
+func (s *MmctlUnitTestSuite) TestPluginVerifyCmd() {
+	s.Run("All plugins verified", func() {
+		printer.Clean()
+		printer.SetFormat(printer.FormatPlain)
+		defer printer.SetFormat(printer.FormatJSON)
+
+		mockReports := []*model.PluginSignatureReport{
+			{
+				PluginId: "id1",
+				Version:  "v1",
+				Status:   model.PluginSignatureStatusVerified,
+				Signature: &model.PluginSignature{
+					KeyId:    "D1B54B47A5CEFEC4",
+					KeyName:  model.PluginSignatureKeyNameMattermost,
+					SignedAt: 1700000000000,
+				},
+			},
+			{
+				PluginId: "id2",
+				Version:  "v2",
+				Status:   model.PluginSignatureStatusUnsigned,
+			},
+		}
+
+		s.client.
+			EXPECT().
+			GetPluginSignatureReports(context.TODO()).
+			Return(mockReports, &model.Response{}, nil).
+			Times(1)
+
+		err := pluginVerifyCmdF(s.client, &cobra.Command{}, nil)
+		s.Require().NoError(err)
+		s.Require().Len(printer.GetErrorLines(), 0)
+		s.Require().Len(printer.GetLines(), 2)
+		s.Require().Equal("id1 v1: verified, key D1B54B47A5CEFEC4 (mattermost), signed at 2023-11-14T22:13:20Z", printer.GetLines()[0])
+		s.Require().Equal("id2 v2: unsigned", printer.GetLines()[1])
+	})
+
+	s.Run("Plugin signed with a revoked key", func() {
+		printer.Clean()
+		printer.SetFormat(printer.FormatPlain)
+		defer printer.SetFormat(printer.FormatJSON)
+
+		mockReports := []*model.PluginSignatureReport{
+			{
+				PluginId: "id1",
+				Version:  "v1",
+				Status:   model.PluginSignatureStatusRevoked,
+				Signature: &model.PluginSignature{
+					KeyId:    "D1B54B47A5CEFEC4",
+					SignedAt: 1700000000000,
+				},
+				Error: "revoked",
+			},
+		}
+
+		s.client.
+			EXPECT().
+			GetPluginSignatureReports(context.TODO()).
+			Return(mockReports, &model.Response{}, nil).
+			Times(1)
+
+		err := pluginVerifyCmdF(s.client, &cobra.Command{}, nil)
+		s.Require().EqualError(err, "1 plugin(s) failed signature verification")
+		s.Require().Len(printer.GetLines(), 1)
+		s.Require().Equal("id1 v1: revoked, key D1B54B47A5CEFEC4, signed at 2023-11-14T22:13:20Z, error: revoked", printer.GetLines()[0])
+	})
+
+	s.Run("GetPluginSignatureReports returns error", func() {
+		printer.Clean()
+		mockError := errors.New("mock error")
+
+		s.client.
+			EXPECT().
+			GetPluginSignatureReports(context.TODO()).
+			Return(nil, &model.Response{}, mockError).
+			Times(1)
+
+		err := pluginVerifyCmdF(s.client, &cobra.Command{}, nil)
+		s.Require().EqualError(err, "Unable to verify plugin signatures. Error: "+mockError.Error())
+		s.Require().Len(printer.GetLines(), 0)
+	})
+}
+
 func (s *MmctlUnitTestSuite) TestPluginDeleteCmd() {
 	s.Run("Delete one plugin with error", func() {
 		printer.Clean()
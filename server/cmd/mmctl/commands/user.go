@@ -148,6 +148,17 @@ Permanently deletes one or multiple users along with all related information inc
 	RunE:    withClient(deleteUsersCmdF),
 }
 
+var EraseUsersCmd = &cobra.Command{
+	Use:   "erase [users]",
+	Short: "Erase users",
+	Long: `Replace users with pseudonymous tombstone accounts.
+Starts a job per user removing the profile, credentials, preferences, drafts, unattached files, data exports, audit data, mentions and search index entries of the user. The posts of the user remain, authored by the deactivated tombstone account. The erasure report is stored in the data of the job, see "job list --ids".`,
+	Example: `  user erase user@example.com
+  user erase --dry-run user@example.com`,
+	Args: cobra.MinimumNArgs(1),
+	RunE: withClient(eraseUsersCmdF),
+}
+
 var DeleteAllUsersCmd = &cobra.Command{
 	Use:     "deleteall",
 	Short:   "Delete all users and all posts. Local command only.",
@@ -345,6 +356,8 @@ func init() {
 
 	DeleteUsersCmd.Flags().Bool("confirm", false, "Confirm you really want to delete the user and a DB backup has been performed")
 	DeleteAllUsersCmd.Flags().Bool("confirm", false, "Confirm you really want to delete the user and a DB backup has been performed")
+	EraseUsersCmd.Flags().Bool("confirm", false, "Confirm you really want to erase the users and a DB backup has been performed")
+	EraseUsersCmd.Flags().Bool("dry-run", false, "Only report what would be erased, without changing anything")
 
 	ListUsersCmd.Flags().Int("page", 0, "Page number to fetch for the list of users")
 	ListUsersCmd.Flags().Int("per-page", DefaultPageSize, "Number of users to be fetched")
@@ -415,6 +428,7 @@ Global Flags:
 		ResetUserMfaCmd,
 		DeleteUsersCmd,
 		DeleteAllUsersCmd,
+		EraseUsersCmd,
 		SearchUserCmd,
 		ListUsersCmd,
 		VerifyUserEmailWithoutTokenCmd,
@@ -764,6 +778,38 @@ func deleteUsersCmdF(c client.Client, cmd *cobra.Command, args []string) error {
 	return errs.ErrorOrNil()
 }
 
+func eraseUsersCmdF(c client.Client, cmd *cobra.Command, args []string) error {
+	dryRun, _ := cmd.Flags().GetBool("dry-run")
+	confirmFlag, _ := cmd.Flags().GetBool("confirm")
+	if !dryRun && !confirmFlag {
+		if err := getConfirmation("Are you sure you want to erase the users specified? Their data will be permanently erased?", true); err != nil {
+			return err
+		}
+	}
+
+	users, err := getUsersFromArgs(c, args)
+	if err != nil {
+		return err
+	}
+
+	var errs *multierror.Error
+	for i, user := range users {
+		if user == nil {
+			printer.PrintError("Unable to find user '" + args[i] + "'")
+			continue
+		}
+		job, _, err := c.EraseUser(context.TODO(), user.Id, dryRun)
+		if err != nil {
+			errs = multierror.Append(errs,
+				fmt.Errorf("unable to erase user %s error: %w", user.Username, err))
+			continue
+		}
+		printer.PrintT("Erasure job for user '"+user.Username+"' successfully created, ID: {{.Id}}", job)
+	}
+
+	return errs.ErrorOrNil()
+}
+
 func deleteAllUsersCmdF(c client.Client, cmd *cobra.Command, args []string) error {
 	confirmFlag, _ := cmd.Flags().GetBool("confirm")
 	if !confirmFlag {
@@ -561,6 +561,86 @@ func (s *MmctlUnitTestSuite) TestDeleteUsersCmd() {
 	})
 }
 
+func (s *MmctlUnitTestSuite) TestEraseUsersCmd() {
+	email1 := "user1@example.com"
+	email2 := "user2@example.com"
+	mockUser1 := model.User{Username: "User1", Email: email1, Id: model.NewId()}
+	mockUser2 := model.User{Username: "User2", Email: email2, Id: model.NewId()}
+
+	s.Run("Erase users with confirm false returns an error", func() {
+		cmd := &cobra.Command{}
+		cmd.Flags().Bool("confirm", false, "")
+		cmd.Flags().Bool("dry-run", false, "")
+		err := eraseUsersCmdF(s.client, cmd, []string{"some"})
+		s.Require().NotNil(err)
+		s.Require().Equal("could not proceed, either enable --confirm flag or use an interactive shell to complete operation: this is not an interactive shell", err.Error())
+	})
+
+	s.Run("Dry run doesn't require confirmation", func() {
+		printer.Clean()
+		mockJob := &model.Job{Id: model.NewId(), Type: model.JobTypeUserErasure}
+
+		s.client.
+			EXPECT().
+			GetUserByEmail(context.TODO(), email1, "").
+			Return(&mockUser1, &model.Response{}, nil).
+			Times(1)
+		s.client.
+			EXPECT().
+			EraseUser(context.TODO(), mockUser1.Id, true).
+			Return(mockJob, &model.Response{StatusCode: http.StatusCreated}, nil).
+			Times(1)
+
+		cmd := &cobra.Command{}
+		cmd.Flags().Bool("confirm", false, "")
+		cmd.Flags().Bool("dry-run", true, "")
+
+		err := eraseUsersCmdF(s.client, cmd, []string{email1})
+		s.Require().Nil(err)
+		s.Require().Len(printer.GetLines(), 1)
+		s.Require().Equal(mockJob, printer.GetLines()[0])
+	})
+
+	s.Run("Erase two users, first fails with error other passes", func() {
+		printer.Clean()
+		mockError := errors.New("an error occurred on erasing a user")
+		mockJob := &model.Job{Id: model.NewId(), Type: model.JobTypeUserErasure}
+
+		var expectedErr *multierror.Error
+		expectedErr = multierror.Append(expectedErr, fmt.Errorf("unable to erase user %s error: %w", mockUser1.Username, mockError))
+
+		s.client.
+			EXPECT().
+			GetUserByEmail(context.TODO(), email1, "").
+			Return(&mockUser1, &model.Response{}, nil).
+			Times(1)
+		s.client.
+			EXPECT().
+			GetUserByEmail(context.TODO(), email2, "").
+			Return(&mockUser2, &model.Response{}, nil).
+			Times(1)
+		s.client.
+			EXPECT().
+			EraseUser(context.TODO(), mockUser1.Id, false).
+			Return(nil, &model.Response{StatusCode: http.StatusBadRequest}, mockError).
+			Times(1)
+		s.client.
+			EXPECT().
+			EraseUser(context.TODO(), mockUser2.Id, false).
+			Return(mockJob, &model.Response{StatusCode: http.StatusCreated}, nil).
+			Times(1)
+
+		cmd := &cobra.Command{}
+		cmd.Flags().Bool("confirm", true, "")
+		cmd.Flags().Bool("dry-run", false, "")
+
+		err := eraseUsersCmdF(s.client, cmd, []string{email1, email2})
+		s.Require().EqualError(err, expectedErr.Error())
+		s.Require().Len(printer.GetLines(), 1)
+		s.Require().Equal(mockJob, printer.GetLines()[0])
+	})
+}
+
 func (s *MmctlUnitTestSuite) TestDeleteAllUsersCmd() {
 	s.Run("Delete all users", func() {
 		printer.Clean()
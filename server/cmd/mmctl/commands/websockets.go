@@ -4,11 +4,15 @@
 package commands
 
 import (
+	"context"
 	"fmt"
 	"os"
 
 	"github.com/pkg/errors"
 	"github.com/spf13/cobra"
+
+	"github.com/mattermost/mattermost/server/v8/cmd/mmctl/client"
+	"github.com/mattermost/mattermost/server/v8/cmd/mmctl/printer"
 )
 
 var WebsocketCmd = &cobra.Command{
@@ -17,7 +21,18 @@ var WebsocketCmd = &cobra.Command{
 	RunE:  websocketCmdF,
 }
 
+var WebsocketInspectCmd = &cobra.Command{
+	Use:   "inspect [user]",
+	Short: "Inspect the websocket connections of a user",
+	Long: `Prints the diagnostic details of all the websocket connections of a user across the cluster,
+including the send queue depth, the position in the dead queue and the last acknowledged sequence.`,
+	Example: `  websocket inspect john.doe@example.com`,
+	Args:    cobra.ExactArgs(1),
+	RunE:    withClient(websocketInspectCmdF),
+}
+
 func init() {
+	WebsocketCmd.AddCommand(WebsocketInspectCmd)
 	RootCmd.AddCommand(WebsocketCmd)
 }
 
@@ -43,3 +58,32 @@ func websocketCmdF(cmd *cobra.Command, args []string) error {
 		}
 	}
 }
+
+func websocketInspectCmdF(c client.Client, cmd *cobra.Command, args []string) error {
+	user, err := getUserFromArg(c, args[0])
+	if err != nil {
+		return err
+	}
+
+	infos, _, err := c.GetUserWebSocketConnections(context.TODO(), user.Id)
+	if err != nil {
+		return fmt.Errorf("unable to get websocket connections for user %q: %w", args[0], err)
+	}
+
+	if len(infos) == 0 {
+		printer.Print(fmt.Sprintf("User %s has no websocket connections", user.Username))
+		return nil
+	}
+
+	for _, info := range infos {
+		printer.PrintT(`Connection {{.ConnectionId}} on {{.Hostname}} (hub {{.HubIndex}})
+  Transport: {{.Transport}}  Origin: {{.OriginClient}}  Active: {{.Active}}  Reuse count: {{.ReuseCount}}
+  Remote address: {{.RemoteAddress}}  X-Forwarded-For: {{.XForwardedFor}}
+  Session: {{.SessionId}}
+  Sequence: {{.Sequence}}  Posted ack: {{.PostedAck}}
+  Send queue: {{.SendQueueLength}}/{{.SendQueueCapacity}}  Dead queue pointer: {{.DeadQueuePointer}}/{{.DeadQueueCapacity}}
+  Last activity: {{.LastUserActivityAt}}  Channel: {{.ActiveChannelId}}  Team: {{.ActiveTeamId}}`, info)
+	}
+
+	return nil
+}
@@ -0,0 +1,83 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package commands
+
+import (
+	"context"
+	"errors"
+
+	"github.com/spf13/cobra"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/v8/cmd/mmctl/printer"
+)
+
+func (s *MmctlUnitTestSuite) TestWebsocketInspectCmd() {
+	user := &model.User{Id: model.NewId(), Username: "johndoe", Email: "john.doe@example.com"}
+
+	s.Run("Inspect connections of a user", func() {
+		printer.Clean()
+		infos := []*model.WebConnInfo{
+			{Hostname: "node1", ConnectionId: model.NewId(), UserId: user.Id, Transport: model.WebConnTransportWebSocket, Active: true},
+			{Hostname: "node2", ConnectionId: model.NewId(), UserId: user.Id, Transport: model.WebConnTransportSSE},
+		}
+
+		s.client.
+			EXPECT().
+			GetUserByEmail(context.TODO(), user.Email, "").
+			Return(user, &model.Response{}, nil).
+			Times(1)
+		s.client.
+			EXPECT().
+			GetUserWebSocketConnections(context.TODO(), user.Id).
+			Return(infos, &model.Response{}, nil).
+			Times(1)
+
+		err := websocketInspectCmdF(s.client, &cobra.Command{}, []string{user.Email})
+		s.Require().NoError(err)
+		s.Require().Len(printer.GetLines(), 2)
+		s.Require().Equal(infos[0], printer.GetLines()[0])
+		s.Require().Equal(infos[1], printer.GetLines()[1])
+		s.Require().Len(printer.GetErrorLines(), 0)
+	})
+
+	s.Run("User without connections", func() {
+		printer.Clean()
+
+		s.client.
+			EXPECT().
+			GetUserByEmail(context.TODO(), user.Email, "").
+			Return(user, &model.Response{}, nil).
+			Times(1)
+		s.client.
+			EXPECT().
+			GetUserWebSocketConnections(context.TODO(), user.Id).
+			Return([]*model.WebConnInfo{}, &model.Response{}, nil).
+			Times(1)
+
+		err := websocketInspectCmdF(s.client, &cobra.Command{}, []string{user.Email})
+		s.Require().NoError(err)
+		s.Require().Len(printer.GetLines(), 1)
+		s.Require().Equal("User johndoe has no websocket connections", printer.GetLines()[0])
+	})
+
+	s.Run("Error getting connections", func() {
+		printer.Clean()
+
+		s.client.
+			EXPECT().
+			GetUserByEmail(context.TODO(), user.Email, "").
+			Return(user, &model.Response{}, nil).
+			Times(1)
+		s.client.
+			EXPECT().
+			GetUserWebSocketConnections(context.TODO(), user.Id).
+			Return(nil, &model.Response{}, errors.New("mock error")).
+			Times(1)
+
+		err := websocketInspectCmdF(s.client, &cobra.Command{}, []string{user.Email})
+		s.Require().Error(err)
+		s.Require().Len(printer.GetLines(), 0)
+	})
+}
@@ -585,6 +585,38 @@ func (mr *MockClientMockRecorder) EnablePlugin(arg0, arg1 interface{}) *gomock.C
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EnablePlugin", reflect.TypeOf((*MockClient)(nil).EnablePlugin), arg0, arg1)
 }
 
+// EraseUser mocks base method.
+func (m *MockClient) EraseUser(arg0 context.Context, arg1 string, arg2 bool) (*model.Job, *model.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EraseUser", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*model.Job)
+	ret1, _ := ret[1].(*model.Response)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// EraseUser indicates an expected call of EraseUser.
+func (mr *MockClientMockRecorder) EraseUser(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EraseUser", reflect.TypeOf((*MockClient)(nil).EraseUser), arg0, arg1, arg2)
+}
+
+// ExportAuditRecords mocks base method.
+func (m *MockClient) ExportAuditRecords(arg0 context.Context, arg1 model.AuditRecordSearchOptions, arg2 string, arg3 io.Writer) (int64, *model.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExportAuditRecords", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(*model.Response)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// ExportAuditRecords indicates an expected call of ExportAuditRecords.
+func (mr *MockClientMockRecorder) ExportAuditRecords(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExportAuditRecords", reflect.TypeOf((*MockClient)(nil).ExportAuditRecords), arg0, arg1, arg2, arg3)
+}
+
 // GeneratePresignedURL mocks base method.
 func (m *MockClient) GeneratePresignedURL(arg0 context.Context, arg1 string) (*model.PresignURLResponse, *model.Response, error) {
 	m.ctrl.T.Helper()
@@ -1148,6 +1180,38 @@ func (mr *MockClientMockRecorder) GetPingWithOptions(arg0, arg1 interface{}) *go
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPingWithOptions", reflect.TypeOf((*MockClient)(nil).GetPingWithOptions), arg0, arg1)
 }
 
+// GetPluginSignatureReports mocks base method.
+func (m *MockClient) GetPluginSignatureReports(arg0 context.Context) ([]*model.PluginSignatureReport, *model.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPluginSignatureReports", arg0)
+	ret0, _ := ret[0].([]*model.PluginSignatureReport)
+	ret1, _ := ret[1].(*model.Response)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetPluginSignatureReports indicates an expected call of GetPluginSignatureReports.
+func (mr *MockClientMockRecorder) GetPluginSignatureReports(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPluginSignatureReports", reflect.TypeOf((*MockClient)(nil).GetPluginSignatureReports), arg0)
+}
+
+// GetPluginStatuses mocks base method.
+func (m *MockClient) GetPluginStatuses(arg0 context.Context) (model.PluginStatuses, *model.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPluginStatuses", arg0)
+	ret0, _ := ret[0].(model.PluginStatuses)
+	ret1, _ := ret[1].(*model.Response)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetPluginStatuses indicates an expected call of GetPluginStatuses.
+func (mr *MockClientMockRecorder) GetPluginStatuses(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPluginStatuses", reflect.TypeOf((*MockClient)(nil).GetPluginStatuses), arg0)
+}
+
 // GetPlugins mocks base method.
 func (m *MockClient) GetPlugins(arg0 context.Context) (*model.PluginsResponse, *model.Response, error) {
 	m.ctrl.T.Helper()
@@ -1452,6 +1516,22 @@ func (mr *MockClientMockRecorder) GetUserByUsername(arg0, arg1, arg2 interface{}
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserByUsername", reflect.TypeOf((*MockClient)(nil).GetUserByUsername), arg0, arg1, arg2)
 }
 
+// GetUserWebSocketConnections mocks base method.
+func (m *MockClient) GetUserWebSocketConnections(arg0 context.Context, arg1 string) ([]*model.WebConnInfo, *model.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserWebSocketConnections", arg0, arg1)
+	ret0, _ := ret[0].([]*model.WebConnInfo)
+	ret1, _ := ret[1].(*model.Response)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetUserWebSocketConnections indicates an expected call of GetUserWebSocketConnections.
+func (mr *MockClientMockRecorder) GetUserWebSocketConnections(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserWebSocketConnections", reflect.TypeOf((*MockClient)(nil).GetUserWebSocketConnections), arg0, arg1)
+}
+
 // GetUsers mocks base method.
 func (m *MockClient) GetUsers(arg0 context.Context, arg1, arg2 int, arg3 string) ([]*model.User, *model.Response, error) {
 	m.ctrl.T.Helper()
@@ -1872,6 +1952,22 @@ func (mr *MockClientMockRecorder) PromoteGuestToUser(arg0, arg1 interface{}) *go
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PromoteGuestToUser", reflect.TypeOf((*MockClient)(nil).PromoteGuestToUser), arg0, arg1)
 }
 
+// PublishMarketplaceCatalogPlugin mocks base method.
+func (m *MockClient) PublishMarketplaceCatalogPlugin(arg0 context.Context, arg1, arg2 io.Reader) (*model.Manifest, *model.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PublishMarketplaceCatalogPlugin", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*model.Manifest)
+	ret1, _ := ret[1].(*model.Response)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// PublishMarketplaceCatalogPlugin indicates an expected call of PublishMarketplaceCatalogPlugin.
+func (mr *MockClientMockRecorder) PublishMarketplaceCatalogPlugin(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PublishMarketplaceCatalogPlugin", reflect.TypeOf((*MockClient)(nil).PublishMarketplaceCatalogPlugin), arg0, arg1, arg2)
+}
+
 // RegenOutgoingHookToken mocks base method.
 func (m *MockClient) RegenOutgoingHookToken(arg0 context.Context, arg1 string) (*model.OutgoingWebhook, *model.Response, error) {
 	m.ctrl.T.Helper()
@@ -2042,6 +2138,22 @@ func (mr *MockClientMockRecorder) RevokeUserAccessToken(arg0, arg1 interface{})
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeUserAccessToken", reflect.TypeOf((*MockClient)(nil).RevokeUserAccessToken), arg0, arg1)
 }
 
+// SearchAuditRecords mocks base method.
+func (m *MockClient) SearchAuditRecords(arg0 context.Context, arg1 model.AuditRecordSearchOptions) ([]*model.StoredAuditRecord, *model.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SearchAuditRecords", arg0, arg1)
+	ret0, _ := ret[0].([]*model.StoredAuditRecord)
+	ret1, _ := ret[1].(*model.Response)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// SearchAuditRecords indicates an expected call of SearchAuditRecords.
+func (mr *MockClientMockRecorder) SearchAuditRecords(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SearchAuditRecords", reflect.TypeOf((*MockClient)(nil).SearchAuditRecords), arg0, arg1)
+}
+
 // SearchTeams mocks base method.
 func (m *MockClient) SearchTeams(arg0 context.Context, arg1 *model.TeamSearch) ([]*model.Team, *model.Response, error) {
 	m.ctrl.T.Helper()
@@ -37,4 +37,7 @@ type ClusterInterface interface {
 	// GetWSQueues returns the necessary websocket queues from a cluster for a given
 	// connectionID and sequence number.
 	GetWSQueues(userID, connectionID string, seqNum int64) (map[string]*model.WSQueues, error)
+	// GetWebConnInfosForUser returns the diagnostic details of the websocket
+	// connections of a given userID on the other nodes of the cluster.
+	GetWebConnInfosForUser(userID string) ([]*model.WebConnInfo, error)
 }
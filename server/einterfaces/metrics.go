@@ -72,6 +72,8 @@ type MetricsInterface interface {
 	ObservePluginMultiHookIterationDuration(pluginID string, elapsed float64)
 	ObservePluginMultiHookDuration(elapsed float64)
 	ObservePluginAPIDuration(pluginID, apiName string, success bool, elapsed float64)
+	ObservePluginResourceUsage(pluginID string, usage *model.PluginResourceUsage)
+	ClearPluginResourceUsage(pluginID string)
 
 	ObserveEnabledUsers(users int64)
 	GetLoggerMetricsCollector() mlog.MetricsCollector
@@ -96,6 +98,7 @@ type MetricsInterface interface {
 
 	SetReplicaLagAbsolute(node string, value float64)
 	SetReplicaLagTime(node string, value float64)
+	IncrementReadYourWritesMasterFallback()
 
 	IncrementNotificationCounter(notificationType model.NotificationType, platform string)
 	IncrementNotificationAckCounter(notificationType model.NotificationType, platform string)
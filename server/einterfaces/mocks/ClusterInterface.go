@@ -262,6 +262,36 @@ func (_m *ClusterInterface) GetWSQueues(userID string, connectionID string, seqN
 	return r0, r1
 }
 
+// GetWebConnInfosForUser provides a mock function with given fields: userID
+func (_m *ClusterInterface) GetWebConnInfosForUser(userID string) ([]*model.WebConnInfo, error) {
+	ret := _m.Called(userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetWebConnInfosForUser")
+	}
+
+	var r0 []*model.WebConnInfo
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string) ([]*model.WebConnInfo, error)); ok {
+		return rf(userID)
+	}
+	if rf, ok := ret.Get(0).(func(string) []*model.WebConnInfo); ok {
+		r0 = rf(userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*model.WebConnInfo)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // HealthScore provides a mock function with no fields
 func (_m *ClusterInterface) HealthScore() int {
 	ret := _m.Called()
@@ -33,6 +33,11 @@ func (_m *MetricsInterface) ClearMobileClientSessionMetadata() {
 	_m.Called()
 }
 
+// ClearPluginResourceUsage provides a mock function with given fields: pluginID
+func (_m *MetricsInterface) ClearPluginResourceUsage(pluginID string) {
+	_m.Called(pluginID)
+}
+
 // DecrementHTTPWebSockets provides a mock function with given fields: originClient
 func (_m *MetricsInterface) DecrementHTTPWebSockets(originClient string) {
 	_m.Called(originClient)
@@ -243,6 +248,11 @@ func (_m *MetricsInterface) IncrementPostsSearchCounter() {
 	_m.Called()
 }
 
+// IncrementReadYourWritesMasterFallback provides a mock function with no fields
+func (_m *MetricsInterface) IncrementReadYourWritesMasterFallback() {
+	_m.Called()
+}
+
 // IncrementRemoteClusterConnStateChangeCounter provides a mock function with given fields: remoteID, online
 func (_m *MetricsInterface) IncrementRemoteClusterConnStateChangeCounter(remoteID string, online bool) {
 	_m.Called(remoteID, online)
@@ -498,6 +508,11 @@ func (_m *MetricsInterface) ObservePluginMultiHookIterationDuration(pluginID str
 	_m.Called(pluginID, elapsed)
 }
 
+// ObservePluginResourceUsage provides a mock function with given fields: pluginID, usage
+func (_m *MetricsInterface) ObservePluginResourceUsage(pluginID string, usage *model.PluginResourceUsage) {
+	_m.Called(pluginID, usage)
+}
+
 // ObservePostsSearchDuration provides a mock function with given fields: elapsed
 func (_m *MetricsInterface) ObservePostsSearchDuration(elapsed float64) {
 	_m.Called(elapsed)
@@ -0,0 +1,332 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.enterprise for license information.
+
+package eml_export
+
+import (
+	"archive/zip"
+	"cmp"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"path"
+	"slices"
+	"strings"
+	"time"
+
+	gomail "gopkg.in/mail.v2"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/public/shared/mlog"
+	"github.com/mattermost/mattermost/server/public/shared/request"
+	"github.com/mattermost/mattermost/server/v8/enterprise/internal/file"
+	"github.com/mattermost/mattermost/server/v8/enterprise/message_export/shared"
+	"github.com/mattermost/mattermost/server/v8/platform/shared/filestore"
+)
+
+const (
+	PostIdHeader          = "X-Mattermost-PostID"
+	UpdateTypeHeader      = "X-Mattermost-UpdateType"
+	EditedByPostIdHeader  = "X-Mattermost-EditedByPostID"
+	TeamNameHeader        = "X-Mattermost-TeamName"
+	ChannelIdHeader       = "X-Mattermost-ChannelID"
+	ChannelNameHeader     = "X-Mattermost-ChannelName"
+	ChannelTypeHeader     = "X-Mattermost-ChannelType"
+	EMLWarningFilename    = "warning.txt"
+	DefaultMessageIdHost  = "mattermost"
+	undisclosedRecipients = "undisclosed-recipients:;"
+	mboxDateFormat        = "Mon Jan _2 15:04:05 2006"
+)
+
+// EmlExport exports every post of the batch as an RFC 5322 message. Replies carry the In-Reply-To and References
+// headers of their thread, and the files uploaded with a post are attached to it as MIME parts.
+//
+// The ComplianceExportTypeEml format writes one .eml file per message, in a directory per channel. The
+// ComplianceExportTypeMbox format writes the messages of each channel to a single mboxrd file.
+func EmlExport(rctx request.CTX, p shared.ExportParams) (shared.RunExportResults, error) {
+	exportData, err := shared.GetGenericExportData(p)
+	results := exportData.Results
+	if err != nil {
+		return results, err
+	}
+
+	// Sort the channels so the export is reproducible; they come out of a map.
+	slices.SortFunc(exportData.Exports, func(a, b shared.ChannelExport) int {
+		return strings.Compare(a.ChannelId, b.ChannelId)
+	})
+
+	temp, err := os.CreateTemp("", "compliance-export-batch-*.zip")
+	if err != nil {
+		return results, fmt.Errorf("unable to create temporary EML export file: %w", err)
+	}
+	defer file.DeleteTemp(rctx.Logger(), temp)
+
+	zipFile := zip.NewWriter(temp)
+	exporter := &messageExporter{
+		rctx:                  rctx,
+		fileAttachmentBackend: p.FileAttachmentBackend,
+		messageIdHost:         messageIdHost(p.Config),
+	}
+
+	for _, channel := range exportData.Exports {
+		if len(channel.Posts) == 0 {
+			// Only joins and leaves, which are recorded in the recipients of the messages.
+			continue
+		}
+
+		if p.ExportType == model.ComplianceExportTypeMbox {
+			err = exporter.writeMbox(zipFile, channel)
+		} else {
+			err = exporter.writeEml(zipFile, channel)
+		}
+		if err != nil {
+			return results, err
+		}
+	}
+
+	results.NumWarnings = len(exporter.warnings)
+	if results.NumWarnings > 0 {
+		warningFile, _ := zipFile.Create(EMLWarningFilename)
+		for _, value := range exporter.warnings {
+			_, err = warningFile.Write([]byte(value + "\n"))
+			if err != nil {
+				return results, fmt.Errorf("unable to create the warning file: %w", err)
+			}
+		}
+	}
+
+	metadataFile, err := zipFile.Create("metadata.json")
+	if err != nil {
+		return results, fmt.Errorf("unable to create the zip file: %w", err)
+	}
+	data, err := json.MarshalIndent(exportData.Metadata, "", "  ")
+	if err != nil {
+		return results, fmt.Errorf("unable to convert metadata to json: %w", err)
+	}
+	_, err = metadataFile.Write(data)
+	if err != nil {
+		return results, fmt.Errorf("unable to add metadata file to the zip file: %w", err)
+	}
+	err = zipFile.Close()
+	if err != nil {
+		return results, fmt.Errorf("unable to close the zip file: %w", err)
+	}
+
+	_, err = temp.Seek(0, 0)
+	if err != nil {
+		return results, fmt.Errorf("unable to seek to start of export file: %w", err)
+	}
+
+	// Try to write the file without a timeout due to the potential size of the file.
+	_, err = filestore.TryWriteFileContext(rctx.Context(), p.ExportBackend, temp, p.BatchPath)
+	if err != nil {
+		return results, fmt.Errorf("unable to write the eml export file: %w", err)
+	}
+	return results, nil
+}
+
+type messageExporter struct {
+	rctx                  request.CTX
+	fileAttachmentBackend filestore.FileBackend
+	messageIdHost         string
+	warnings              []string
+}
+
+func (e *messageExporter) writeEml(zipFile *zip.Writer, channel shared.ChannelExport) error {
+	recipients := shared.GetPostRecipients(channel)
+	for _, i := range postsByCreateAt(channel.Posts) {
+		post := channel.Posts[i]
+		emlFile, err := zipFile.Create(path.Join(channelDirName(channel), messageKey(post)+".eml"))
+		if err != nil {
+			return fmt.Errorf("unable to create the eml file: %w", err)
+		}
+		if err = e.writeMessage(emlFile, channel, post, recipients[i]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (e *messageExporter) writeMbox(zipFile *zip.Writer, channel shared.ChannelExport) error {
+	mboxFile, err := zipFile.Create(channelDirName(channel) + ".mbox")
+	if err != nil {
+		return fmt.Errorf("unable to create the mbox file: %w", err)
+	}
+
+	recipients := shared.GetPostRecipients(channel)
+	for _, i := range postsByCreateAt(channel.Posts) {
+		post := channel.Posts[i]
+		sentAt := time.UnixMilli(model.SafeDereference(post.PostCreateAt)).UTC()
+		_, err = fmt.Fprintf(mboxFile, "From %s %s\r\n", mboxSender(post), sentAt.Format(mboxDateFormat))
+		if err != nil {
+			return fmt.Errorf("unable to write to the mbox file: %w", err)
+		}
+
+		w := &mboxrdWriter{w: mboxFile, lineStart: true}
+		if err = e.writeMessage(w, channel, post, recipients[i]); err != nil {
+			return err
+		}
+		if err = w.Close(); err != nil {
+			return fmt.Errorf("unable to write to the mbox file: %w", err)
+		}
+	}
+	return nil
+}
+
+func (e *messageExporter) writeMessage(w io.Writer, channel shared.ChannelExport, post shared.PostExport, recipients []shared.MembershipMapUser) error {
+	m := gomail.NewMessage(gomail.SetCharset("UTF-8"))
+
+	to := make([]string, 0, len(recipients))
+	for _, recipient := range recipients {
+		to = append(to, m.FormatAddress(recipient.Email, recipient.Username))
+	}
+	if len(to) == 0 {
+		to = append(to, undisclosedRecipients)
+	}
+
+	subject := channel.DisplayName
+	if subject == "" {
+		subject = channel.ChannelName
+	}
+
+	headers := map[string][]string{
+		"To":              to,
+		"Message-ID":      {e.messageId(messageKey(post))},
+		"Auto-Submitted":  {"auto-generated"},
+		PostIdHeader:      {model.SafeDereference(post.PostId)},
+		ChannelIdHeader:   {channel.ChannelId},
+		ChannelNameHeader: {channel.ChannelName},
+		ChannelTypeHeader: {shared.ChannelTypeDisplayName(channel.ChannelType)},
+	}
+	if teamName := model.SafeDereference(post.TeamName); teamName != "" {
+		headers[TeamNameHeader] = []string{teamName}
+	}
+
+	// Thread the message: replies refer to the root post, updates to the post they update.
+	var references []string
+	if rootId := model.SafeDereference(post.PostRootId); rootId != "" {
+		references = append(references, e.messageId(rootId))
+		subject = "Re: " + subject
+	}
+	if post.UpdatedType != "" && post.UpdatedType != shared.EditedOriginalMsg {
+		references = append(references, e.messageId(model.SafeDereference(post.PostId)))
+	}
+	if len(references) > 0 {
+		headers["In-Reply-To"] = []string{references[len(references)-1]}
+		headers["References"] = []string{strings.Join(references, " ")}
+	}
+	if post.UpdatedType != "" {
+		headers[UpdateTypeHeader] = []string{string(post.UpdatedType)}
+	}
+	if post.EditedNewMsgId != "" {
+		headers[EditedByPostIdHeader] = []string{post.EditedNewMsgId}
+	}
+	headers["Subject"] = []string{subject}
+
+	m.SetHeaders(headers)
+	m.SetAddressHeader("From", model.SafeDereference(post.UserEmail), model.SafeDereference(post.Username))
+	m.SetDateHeader("Date", time.UnixMilli(model.SafeDereference(post.PostCreateAt)).UTC())
+
+	body := post.Message
+	for _, deleted := range post.AttachmentDeletes {
+		body += fmt.Sprintf("\n\nDeleted file %s", deleted.FileInfo.Name)
+	}
+	m.SetBody("text/plain", body)
+
+	for _, upload := range post.AttachmentCreates {
+		e.attach(m, post, upload.FileInfo)
+	}
+
+	if _, err := m.WriteTo(w); err != nil {
+		return fmt.Errorf("unable to generate eml file data: %w", err)
+	}
+	return nil
+}
+
+func (e *messageExporter) attach(m *gomail.Message, post shared.PostExport, fileInfo *model.FileInfo) {
+	postId := model.SafeDereference(post.PostId)
+
+	// Open the file right away: a missing file is a warning, not a broken message.
+	r, err := e.fileAttachmentBackend.Reader(fileInfo.Path)
+	if err != nil {
+		e.warnings = append(e.warnings, "Warning:"+shared.MissingFileMessageDuringBackendRead+" - Post: "+postId+" - "+fileInfo.Path)
+		e.rctx.Logger().Warn(shared.MissingFileMessageDuringBackendRead,
+			mlog.String("post_id", postId),
+			mlog.String("filename", fileInfo.Path),
+			mlog.Err(err),
+		)
+		return
+	}
+
+	settings := []gomail.FileSetting{gomail.SetCopyFunc(func(w io.Writer) error {
+		defer r.Close()
+		if _, copyErr := io.Copy(w, r); copyErr != nil {
+			// s3 only errors _here_ if the object key wasn't found, see csv_export.go.
+			e.warnings = append(e.warnings, "Warning:"+shared.MissingFileMessageDuringCopy+" - Post: "+postId+" - "+fileInfo.Path)
+			e.rctx.Logger().Warn(shared.MissingFileMessageDuringCopy,
+				mlog.String("post_id", postId),
+				mlog.String("filename", fileInfo.Path),
+				mlog.Err(copyErr),
+			)
+		}
+		return nil
+	})}
+	if fileInfo.MimeType != "" {
+		settings = append(settings, gomail.SetHeader(map[string][]string{"Content-Type": {fileInfo.MimeType}}))
+	}
+	m.Attach(fileInfo.Name, settings...)
+}
+
+func (e *messageExporter) messageId(key string) string {
+	return fmt.Sprintf("<%s@%s>", key, e.messageIdHost)
+}
+
+// messageKey identifies the message of a post export. A post can be exported more than once, for instance when it's
+// created then updated in the same batch, so updates are told apart by their update time.
+func messageKey(post shared.PostExport) string {
+	postId := model.SafeDereference(post.PostId)
+	if post.UpdatedType == "" || post.UpdatedType == shared.EditedOriginalMsg {
+		return postId
+	}
+	return fmt.Sprintf("%s.%d", postId, post.UpdateAt)
+}
+
+func messageIdHost(config *model.Config) string {
+	if config == nil || model.SafeDereference(config.ServiceSettings.SiteURL) == "" {
+		return DefaultMessageIdHost
+	}
+	siteURL, err := url.Parse(*config.ServiceSettings.SiteURL)
+	if err != nil || siteURL.Hostname() == "" {
+		return DefaultMessageIdHost
+	}
+	return siteURL.Hostname()
+}
+
+func channelDirName(channel shared.ChannelExport) string {
+	return fmt.Sprintf("%s - (%s)", channel.ChannelName, channel.ChannelId)
+}
+
+func mboxSender(post shared.PostExport) string {
+	if email := model.SafeDereference(post.UserEmail); email != "" && !strings.ContainsAny(email, " \t") {
+		return email
+	}
+	return "MAILER-DAEMON"
+}
+
+// postsByCreateAt returns the indexes of the posts sorted by (CreateAt, PostId), as posts are exported by update time.
+func postsByCreateAt(posts []shared.PostExport) []int {
+	order := make([]int, len(posts))
+	for i := range order {
+		order[i] = i
+	}
+	slices.SortStableFunc(order, func(a, b int) int {
+		createA, createB := model.SafeDereference(posts[a].PostCreateAt), model.SafeDereference(posts[b].PostCreateAt)
+		if createA == createB {
+			return strings.Compare(messageKey(posts[a]), messageKey(posts[b]))
+		}
+		return cmp.Compare(createA, createB)
+	})
+	return order
+}
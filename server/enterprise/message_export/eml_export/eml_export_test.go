@@ -0,0 +1,297 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.enterprise for license information.
+
+package eml_export
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/base64"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net/mail"
+	"os"
+	"path"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/public/shared/request"
+	"github.com/mattermost/mattermost/server/v8/channels/store/storetest"
+	"github.com/mattermost/mattermost/server/v8/enterprise/message_export/shared"
+	"github.com/mattermost/mattermost/server/v8/platform/shared/filestore"
+)
+
+func newTestPost(id, rootId, userId, message string, createAt int64, fileIds []string) *model.MessageExport {
+	chanTypeOpen := model.ChannelTypeOpen
+	return &model.MessageExport{
+		PostId:             model.NewPointer(id),
+		PostOriginalId:     model.NewPointer(""),
+		PostRootId:         model.NewPointer(rootId),
+		TeamId:             model.NewPointer("team-id"),
+		TeamName:           model.NewPointer("team-name"),
+		TeamDisplayName:    model.NewPointer("team-display-name"),
+		ChannelId:          model.NewPointer("channel-id"),
+		ChannelName:        model.NewPointer("town-square"),
+		ChannelDisplayName: model.NewPointer("Town Square"),
+		ChannelType:        &chanTypeOpen,
+		PostCreateAt:       model.NewPointer(createAt),
+		PostUpdateAt:       model.NewPointer(createAt),
+		PostMessage:        model.NewPointer(message),
+		PostType:           model.NewPointer(""),
+		UserEmail:          model.NewPointer(userId + "@example.com"),
+		UserId:             model.NewPointer(userId),
+		Username:           model.NewPointer(userId),
+		PostFileIds:        fileIds,
+	}
+}
+
+func runTestExport(t *testing.T, exportType string, attachmentContents string) *zip.Reader {
+	rctx := request.TestContext(t)
+
+	tempDir, err := os.MkdirTemp("", "")
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		err = os.RemoveAll(tempDir)
+		assert.NoError(t, err)
+	})
+
+	fileBackend, err := filestore.NewFileBackend(filestore.FileBackendSettings{
+		DriverName: model.ImageDriverLocal,
+		Directory:  tempDir,
+	})
+	require.NoError(t, err)
+
+	attachment := &model.FileInfo{
+		Id:       "file-id",
+		Name:     "report.txt",
+		Path:     "path/to/report.txt",
+		MimeType: "text/plain",
+		Size:     int64(len(attachmentContents)),
+	}
+	if attachmentContents != "" {
+		_, err = fileBackend.WriteFile(strings.NewReader(attachmentContents), attachment.Path)
+		require.NoError(t, err)
+	}
+
+	mockStore := &storetest.Store{}
+	defer mockStore.AssertExpectations(t)
+	mockStore.FileInfoStore.On("GetForPost", "root-id", true, true, false).Return([]*model.FileInfo{attachment}, nil)
+
+	exportFileName := path.Join("export", "jobName", "jobName-batch001.zip")
+	results, err := EmlExport(rctx, shared.ExportParams{
+		ExportType: exportType,
+		ChannelMetadata: map[string]*shared.MetadataChannel{
+			"channel-id": {
+				TeamId:             model.NewPointer("team-id"),
+				ChannelId:          "channel-id",
+				ChannelName:        "town-square",
+				ChannelDisplayName: "Town Square",
+				ChannelType:        model.ChannelTypeOpen,
+				RoomId:             "public - channel-id",
+				StartTime:          1,
+				EndTime:            300,
+			},
+		},
+		ChannelMemberHistories: map[string][]*model.ChannelMemberHistoryResult{
+			"channel-id": {
+				{JoinTime: 0, UserId: "alice", UserEmail: "alice@example.com", Username: "alice"},
+				{JoinTime: 0, UserId: "bob", UserEmail: "bob@example.com", Username: "bob"},
+				{JoinTime: 150, UserId: "carol", UserEmail: "carol@example.com", Username: "carol"},
+			},
+		},
+		Posts: []*model.MessageExport{
+			newTestPost("root-id", "", "alice", "From the top", 100, []string{"file-id"}),
+			newTestPost("reply-id", "root-id", "bob", "a reply", 200, []string{}),
+		},
+		BatchPath:             exportFileName,
+		BatchStartTime:        1,
+		BatchEndTime:          300,
+		Config:                &model.Config{ServiceSettings: model.ServiceSettings{SiteURL: model.NewPointer("https://chat.example.com")}},
+		Db:                    shared.NewMessageExportStore(mockStore),
+		FileAttachmentBackend: fileBackend,
+		ExportBackend:         fileBackend,
+	})
+	require.NoError(t, err)
+	if attachmentContents == "" {
+		assert.Equal(t, 1, results.NumWarnings)
+	} else {
+		assert.Equal(t, 0, results.NumWarnings)
+	}
+	assert.Equal(t, 2, results.CreatedPosts)
+
+	zipBytes, err := fileBackend.ReadFile(exportFileName)
+	require.NoError(t, err)
+	zipReader, err := zip.NewReader(bytes.NewReader(zipBytes), int64(len(zipBytes)))
+	require.NoError(t, err)
+	return zipReader
+}
+
+func readZipFile(t *testing.T, zipReader *zip.Reader, name string) []byte {
+	t.Helper()
+	f, err := zipReader.Open(name)
+	require.NoError(t, err)
+	defer f.Close()
+	data, err := io.ReadAll(f)
+	require.NoError(t, err)
+	return data
+}
+
+func assertRootMessage(t *testing.T, data []byte, attachmentContents string) {
+	t.Helper()
+	msg, err := mail.ReadMessage(bytes.NewReader(data))
+	require.NoError(t, err)
+
+	assert.Equal(t, "<root-id@chat.example.com>", msg.Header.Get("Message-ID"))
+	assert.Empty(t, msg.Header.Get("In-Reply-To"))
+	assert.Equal(t, "Town Square", msg.Header.Get("Subject"))
+	assert.Equal(t, `"alice" <alice@example.com>`, msg.Header.Get("From"))
+	assert.Equal(t, `"bob" <bob@example.com>`, msg.Header.Get("To"))
+	assert.Equal(t, "root-id", msg.Header.Get(PostIdHeader))
+	assert.Equal(t, "channel-id", msg.Header.Get(ChannelIdHeader))
+	assert.Equal(t, "public", msg.Header.Get(ChannelTypeHeader))
+	assert.Equal(t, "team-name", msg.Header.Get(TeamNameHeader))
+
+	mediaType, params, err := mime.ParseMediaType(msg.Header.Get("Content-Type"))
+	require.NoError(t, err)
+	if attachmentContents == "" {
+		// The attachment is missing, so it's a warning and not a MIME part.
+		assert.Equal(t, "text/plain", mediaType)
+		return
+	}
+	require.Equal(t, "multipart/mixed", mediaType)
+
+	parts := multipart.NewReader(msg.Body, params["boundary"])
+	body, err := parts.NextPart()
+	require.NoError(t, err)
+	bodyData, err := io.ReadAll(body)
+	require.NoError(t, err)
+	assert.Equal(t, "From the top", string(bodyData))
+
+	attachment, err := parts.NextPart()
+	require.NoError(t, err)
+	assert.Equal(t, "report.txt", attachment.FileName())
+	assert.Equal(t, "base64", attachment.Header.Get("Content-Transfer-Encoding"))
+	attachmentData, err := io.ReadAll(base64.NewDecoder(base64.StdEncoding, attachment))
+	require.NoError(t, err)
+	assert.Equal(t, attachmentContents, string(attachmentData))
+
+	_, err = parts.NextPart()
+	assert.Equal(t, io.EOF, err)
+}
+
+func assertReplyMessage(t *testing.T, data []byte) {
+	t.Helper()
+	msg, err := mail.ReadMessage(bytes.NewReader(data))
+	require.NoError(t, err)
+
+	assert.Equal(t, "<reply-id@chat.example.com>", msg.Header.Get("Message-ID"))
+	assert.Equal(t, "<root-id@chat.example.com>", msg.Header.Get("In-Reply-To"))
+	assert.Equal(t, "<root-id@chat.example.com>", msg.Header.Get("References"))
+	assert.Equal(t, "Re: Town Square", msg.Header.Get("Subject"))
+	assert.Equal(t, `"bob" <bob@example.com>`, msg.Header.Get("From"))
+	// carol joined between the two posts.
+	assert.Equal(t, `"alice" <alice@example.com>, "carol" <carol@example.com>`, msg.Header.Get("To"))
+
+	body, err := io.ReadAll(msg.Body)
+	require.NoError(t, err)
+	assert.Equal(t, "a reply", string(body))
+}
+
+func TestEmlExport(t *testing.T) {
+	t.Run("eml", func(t *testing.T) {
+		zipReader := runTestExport(t, model.ComplianceExportTypeEml, "quarterly numbers")
+
+		var names []string
+		for _, f := range zipReader.File {
+			names = append(names, f.Name)
+		}
+		assert.Equal(t, []string{
+			"town-square - (channel-id)/root-id.eml",
+			"town-square - (channel-id)/reply-id.eml",
+			"metadata.json",
+		}, names)
+
+		assertRootMessage(t, readZipFile(t, zipReader, "town-square - (channel-id)/root-id.eml"), "quarterly numbers")
+		assertReplyMessage(t, readZipFile(t, zipReader, "town-square - (channel-id)/reply-id.eml"))
+	})
+
+	t.Run("mbox", func(t *testing.T) {
+		zipReader := runTestExport(t, model.ComplianceExportTypeMbox, "quarterly numbers")
+		require.Len(t, zipReader.File, 2)
+		assert.Equal(t, "metadata.json", zipReader.File[1].Name)
+
+		mbox := readZipFile(t, zipReader, "town-square - (channel-id).mbox")
+		require.True(t, bytes.HasSuffix(mbox, []byte("\r\n\r\n")))
+		mbox = bytes.TrimSuffix(mbox, []byte("\r\n\r\n"))
+		messages := bytes.Split(mbox, []byte("\r\n\r\nFrom "))
+		require.Len(t, messages, 2)
+		assert.True(t, bytes.HasPrefix(messages[0], []byte("From alice@example.com Thu Jan  1 00:00:00 1970\r\n")))
+		assert.True(t, bytes.HasPrefix(messages[1], []byte("bob@example.com Thu Jan  1 00:00:00 1970\r\n")))
+
+		// The body line starting with "From " is quoted.
+		assert.Contains(t, string(messages[0]), "\r\n>From the top")
+
+		_, root, _ := bytes.Cut(messages[0], []byte("\r\n"))
+		root = bytes.ReplaceAll(root, []byte("\r\n>From "), []byte("\r\nFrom "))
+		assertRootMessage(t, root, "quarterly numbers")
+
+		_, reply, _ := bytes.Cut(messages[1], []byte("\r\n"))
+		assertReplyMessage(t, reply)
+	})
+
+	t.Run("missing attachment", func(t *testing.T) {
+		zipReader := runTestExport(t, model.ComplianceExportTypeEml, "")
+		assertRootMessage(t, readZipFile(t, zipReader, "town-square - (channel-id)/root-id.eml"), "")
+
+		warnings := readZipFile(t, zipReader, EMLWarningFilename)
+		assert.Equal(t, "Warning:"+shared.MissingFileMessageDuringBackendRead+" - Post: root-id - path/to/report.txt\n", string(warnings))
+	})
+}
+
+func TestMessageKey(t *testing.T) {
+	post := shared.PostExport{
+		MessageExport: model.MessageExport{PostId: model.NewPointer("post-id")},
+		UpdateAt:      42,
+	}
+	assert.Equal(t, "post-id", messageKey(post))
+
+	post.UpdatedType = shared.EditedOriginalMsg
+	assert.Equal(t, "post-id", messageKey(post))
+
+	post.UpdatedType = shared.Deleted
+	assert.Equal(t, "post-id.42", messageKey(post))
+}
+
+func TestMboxrdWriter(t *testing.T) {
+	for name, tc := range map[string]struct {
+		input    []string
+		expected string
+	}{
+		"no quoting":             {[]string{"Subject: x\r\n\r\nhello\r\n"}, "Subject: x\r\n\r\nhello\r\n\r\n"},
+		"from line":              {[]string{"a\r\nFrom here\r\n"}, "a\r\n>From here\r\n\r\n"},
+		"quoted from line":       {[]string{"a\r\n>>From here\r\n"}, "a\r\n>>>From here\r\n\r\n"},
+		"split across writes":    {[]string{"a\r\nFr", "om here", "\r\n"}, "a\r\n>From here\r\n\r\n"},
+		"not a from line":        {[]string{"From: alice\r\nFromage\r\n>Fro\r\n"}, "From: alice\r\nFromage\r\n>Fro\r\n\r\n"},
+		"no final line break":    {[]string{"a\r\nFro"}, "a\r\nFro\r\n\r\n"},
+		"first line is from":     {[]string{"From x"}, ">From x\r\n\r\n"},
+		"from in the middle":     {[]string{"say From here\r\n"}, "say From here\r\n\r\n"},
+		"empty lines are copied": {[]string{"\r\n\r\nFrom x\r\n"}, "\r\n\r\n>From x\r\n\r\n"},
+	} {
+		t.Run(name, func(t *testing.T) {
+			var buf bytes.Buffer
+			w := &mboxrdWriter{w: &buf, lineStart: true}
+			for _, input := range tc.input {
+				n, err := w.Write([]byte(input))
+				require.NoError(t, err)
+				assert.Equal(t, len(input), n)
+			}
+			require.NoError(t, w.Close())
+			assert.Equal(t, tc.expected, buf.String())
+		})
+	}
+}
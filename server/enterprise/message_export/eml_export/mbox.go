@@ -0,0 +1,82 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.enterprise for license information.
+
+package eml_export
+
+import (
+	"bytes"
+	"io"
+)
+
+var mboxFromLine = []byte("From ")
+
+// mboxrdWriter writes a message to an mbox file, quoting the lines of the message matching ">*From " with an
+// additional '>' as the mboxrd format requires. Close ends the message with the empty line separating it from
+// the next one.
+type mboxrdWriter struct {
+	w io.Writer
+	// pending is the start of the current line, held until it's known whether the line needs quoting.
+	pending   []byte
+	lineStart bool
+}
+
+func (m *mboxrdWriter) Write(p []byte) (int, error) {
+	written := len(p)
+	for len(p) > 0 {
+		if !m.lineStart {
+			end := bytes.IndexByte(p, '\n')
+			if end < 0 {
+				_, err := m.w.Write(p)
+				return written, err
+			}
+			if _, err := m.w.Write(p[:end+1]); err != nil {
+				return written, err
+			}
+			p = p[end+1:]
+			m.lineStart = true
+			continue
+		}
+
+		m.pending = append(m.pending, p[0])
+		p = p[1:]
+
+		quoted := bytes.TrimLeft(m.pending, ">")
+		switch {
+		case bytes.Equal(quoted, mboxFromLine):
+			if _, err := m.w.Write([]byte{'>'}); err != nil {
+				return written, err
+			}
+		case len(quoted) < len(mboxFromLine) && bytes.HasPrefix(mboxFromLine, quoted):
+			// Can't tell yet.
+			continue
+		}
+
+		if err := m.flushPending(); err != nil {
+			return written, err
+		}
+	}
+	return written, nil
+}
+
+func (m *mboxrdWriter) flushPending() error {
+	_, err := m.w.Write(m.pending)
+	m.lineStart = len(m.pending) > 0 && m.pending[len(m.pending)-1] == '\n'
+	m.pending = m.pending[:0]
+	return err
+}
+
+func (m *mboxrdWriter) Close() error {
+	endsLine := m.lineStart
+	if len(m.pending) > 0 {
+		if err := m.flushPending(); err != nil {
+			return err
+		}
+		endsLine = m.lineStart
+	}
+	separator := "\r\n"
+	if !endsLine {
+		separator = "\r\n\r\n"
+	}
+	_, err := io.WriteString(m.w, separator)
+	return err
+}
@@ -0,0 +1,303 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.enterprise for license information.
+
+package jsonl_export
+
+import (
+	"archive/zip"
+	"cmp"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"slices"
+	"strings"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/public/shared/mlog"
+	"github.com/mattermost/mattermost/server/public/shared/request"
+	"github.com/mattermost/mattermost/server/v8/enterprise/internal/file"
+	"github.com/mattermost/mattermost/server/v8/enterprise/message_export/shared"
+	"github.com/mattermost/mattermost/server/v8/platform/shared/filestore"
+)
+
+const (
+	MessageLineType           = "message"
+	AttachmentLineType        = "attachment"
+	DeletedAttachmentLineType = "deleted_attachment"
+	EnterLineType             = "enter"
+	LeaveLineType             = "leave"
+	PreviouslyJoinedLineType  = "previously_joined"
+	JSONLPostsFilename        = "posts.jsonl"
+	JSONLWarningFilename      = "warning.txt"
+)
+
+// Line is a line of the posts.jsonl file of the export: a post, an uploaded or deleted file, or a user joining or
+// leaving a channel.
+type Line struct {
+	Type               string                 `json:"type"`
+	CreateAt           int64                  `json:"create_at"`
+	UpdateAt           int64                  `json:"update_at,omitempty"`
+	UpdateType         shared.PostUpdatedType `json:"update_type,omitempty"`
+	TeamId             string                 `json:"team_id,omitempty"`
+	TeamName           string                 `json:"team_name,omitempty"`
+	TeamDisplayName    string                 `json:"team_display_name,omitempty"`
+	ChannelId          string                 `json:"channel_id"`
+	ChannelName        string                 `json:"channel_name"`
+	ChannelDisplayName string                 `json:"channel_display_name"`
+	ChannelType        string                 `json:"channel_type"`
+	UserId             string                 `json:"user_id"`
+	UserEmail          string                 `json:"user_email"`
+	Username           string                 `json:"username"`
+	UserType           shared.UserType        `json:"user_type"`
+	PostId             string                 `json:"post_id,omitempty"`
+	RootId             string                 `json:"root_id,omitempty"`
+	EditedByPostId     string                 `json:"edited_by_post_id,omitempty"`
+	PreviewsPostId     string                 `json:"previews_post_id,omitempty"`
+	PostType           string                 `json:"post_type,omitempty"`
+	Message            string                 `json:"message,omitempty"`
+	File               *File                  `json:"file,omitempty"`
+	Recipients         []Recipient            `json:"recipients,omitempty"`
+}
+
+// File is a file uploaded or deleted. Path is the path of the uploaded file in the export.
+type File struct {
+	Id       string `json:"id"`
+	Name     string `json:"name"`
+	MimeType string `json:"mime_type,omitempty"`
+	Size     int64  `json:"size"`
+	Path     string `json:"path,omitempty"`
+}
+
+// Recipient is a user that was in the channel when a post was sent.
+type Recipient struct {
+	UserId    string `json:"user_id"`
+	UserEmail string `json:"user_email"`
+	Username  string `json:"username"`
+}
+
+// JsonlExport exports the batch as JSON lines, sorted by creation time, along with the files uploaded.
+func JsonlExport(rctx request.CTX, p shared.ExportParams) (shared.RunExportResults, error) {
+	exportData, err := shared.GetGenericExportData(p)
+	results := exportData.Results
+	if err != nil {
+		return results, err
+	}
+
+	lines := make([]Line, 0, results.CreatedPosts+results.EditedOrigMsgPosts+results.DeletedPosts+results.EditedNewMsgPosts+
+		results.UpdatedPosts+results.UploadedFiles+results.DeletedFiles+results.Joins+results.Leaves)
+	for _, channel := range exportData.Exports {
+		lines = append(lines, joinLeaveLines(channel)...)
+
+		recipients := shared.GetPostRecipients(channel)
+		for i, post := range channel.Posts {
+			line := postToLine(channel, post, MessageLineType)
+			line.PostType = model.SafeDereference(post.PostType)
+			line.Message = post.Message
+			for _, recipient := range recipients[i] {
+				line.Recipients = append(line.Recipients, Recipient{
+					UserId:    recipient.UserId,
+					UserEmail: recipient.Email,
+					Username:  recipient.Username,
+				})
+			}
+			lines = append(lines, line)
+		}
+
+		for _, upload := range channel.UploadStarts {
+			lines = append(lines, attachmentToLine(channel, shared.UploadStartToExportEntry(upload)))
+		}
+		for _, deleted := range channel.DeletedFiles {
+			lines = append(lines, attachmentToLine(channel, deleted))
+		}
+	}
+
+	// Lines were added by channel and by type, sort them by (CreateAt, PostId) like the csv export does, then by
+	// channel and user for the joins and leaves to be in a stable order.
+	slices.SortStableFunc(lines, func(a, b Line) int {
+		return cmp.Or(
+			cmp.Compare(a.CreateAt, b.CreateAt),
+			strings.Compare(a.PostId, b.PostId),
+			strings.Compare(a.ChannelId, b.ChannelId),
+			strings.Compare(a.UserId, b.UserId),
+		)
+	})
+
+	// Using a 2M buffer because the file backend may be s3, see csv_export.go.
+	buf := make([]byte, 1024*1024*2)
+	temp, err := os.CreateTemp("", "compliance-export-batch-*.zip")
+	if err != nil {
+		return results, fmt.Errorf("unable to create temporary JSONL export file: %w", err)
+	}
+	defer file.DeleteTemp(rctx.Logger(), temp)
+
+	zipFile := zip.NewWriter(temp)
+	postsFile, err := zipFile.Create(JSONLPostsFilename)
+	if err != nil {
+		return results, fmt.Errorf("unable to create the zip export file: %w", err)
+	}
+	encoder := json.NewEncoder(postsFile)
+	for _, line := range lines {
+		if err = encoder.Encode(line); err != nil {
+			return results, fmt.Errorf("unable to export a line: %w", err)
+		}
+	}
+
+	var missingFiles []string
+	for _, channel := range exportData.Exports {
+		for _, upload := range channel.UploadStarts {
+			postId := model.SafeDereference(upload.PostId)
+			var r io.ReadCloser
+			r, err = p.FileAttachmentBackend.Reader(upload.FileInfo.Path)
+			if err != nil {
+				missingFiles = append(missingFiles, "Warning:"+shared.MissingFileMessageDuringBackendRead+" - Post: "+postId+" - "+upload.FileInfo.Path)
+				rctx.Logger().Warn(shared.MissingFileMessageDuringBackendRead,
+					mlog.String("post_id", postId),
+					mlog.String("filename", upload.FileInfo.Path),
+					mlog.Err(err),
+				)
+				continue
+			}
+
+			if err = func() error {
+				defer r.Close()
+				var attachmentDst io.Writer
+				attachmentDst, err = zipFile.Create(attachmentPath(postId, upload.FileInfo))
+				if err != nil {
+					return err
+				}
+
+				_, err = io.CopyBuffer(attachmentDst, r, buf)
+				return err
+			}(); err != nil {
+				// Add a warning instead of failing the export, see csv_export.go.
+				missingFiles = append(missingFiles, "Warning:"+shared.MissingFileMessageDuringCopy+" - Post: "+postId+" - "+upload.FileInfo.Path)
+				rctx.Logger().Warn(shared.MissingFileMessageDuringCopy,
+					mlog.String("post_id", postId),
+					mlog.String("filename", upload.FileInfo.Path),
+					mlog.Err(err),
+				)
+			}
+		}
+	}
+
+	results.NumWarnings = len(missingFiles)
+	if results.NumWarnings > 0 {
+		warningFile, _ := zipFile.Create(JSONLWarningFilename)
+		for _, value := range missingFiles {
+			_, err = warningFile.Write([]byte(value + "\n"))
+			if err != nil {
+				return results, fmt.Errorf("unable to create the warning file: %w", err)
+			}
+		}
+	}
+
+	metadataFile, err := zipFile.Create("metadata.json")
+	if err != nil {
+		return results, fmt.Errorf("unable to create the zip file: %w", err)
+	}
+	data, err := json.MarshalIndent(exportData.Metadata, "", "  ")
+	if err != nil {
+		return results, fmt.Errorf("unable to convert metadata to json: %w", err)
+	}
+	_, err = metadataFile.Write(data)
+	if err != nil {
+		return results, fmt.Errorf("unable to add metadata file to the zip file: %w", err)
+	}
+	err = zipFile.Close()
+	if err != nil {
+		return results, fmt.Errorf("unable to close the zip file: %w", err)
+	}
+
+	_, err = temp.Seek(0, 0)
+	if err != nil {
+		return results, fmt.Errorf("unable to seek to start of export file: %w", err)
+	}
+
+	// Try to write the file without a timeout due to the potential size of the file.
+	_, err = filestore.TryWriteFileContext(rctx.Context(), p.ExportBackend, temp, p.BatchPath)
+	if err != nil {
+		return results, fmt.Errorf("unable to write the jsonl file: %w", err)
+	}
+	return results, nil
+}
+
+func joinLeaveLines(channel shared.ChannelExport) []Line {
+	lines := make([]Line, 0, len(channel.JoinEvents)+len(channel.LeaveEvents))
+	for _, join := range channel.JoinEvents {
+		lineType := EnterLineType
+		if join.JoinTime <= channel.StartTime {
+			lineType = PreviouslyJoinedLineType
+		}
+		lines = append(lines, channelLine(channel, lineType, join.JoinTime, join.UserId, join.UserEmail, join.Username, join.UserType))
+	}
+	for _, leave := range channel.LeaveEvents {
+		if leave.ClosedOut {
+			// The user didn't leave, the export period ended; see export_data.go.
+			continue
+		}
+		lines = append(lines, channelLine(channel, LeaveLineType, leave.LeaveTime, leave.UserId, leave.UserEmail, leave.Username, leave.UserType))
+	}
+	return lines
+}
+
+func channelLine(channel shared.ChannelExport, lineType string, at int64, userId, email, username string, userType shared.UserType) Line {
+	return Line{
+		Type:               lineType,
+		CreateAt:           at,
+		TeamId:             channel.TeamId,
+		TeamName:           channel.TeamName,
+		TeamDisplayName:    channel.TeamDisplayName,
+		ChannelId:          channel.ChannelId,
+		ChannelName:        channel.ChannelName,
+		ChannelDisplayName: channel.DisplayName,
+		ChannelType:        shared.ChannelTypeDisplayName(channel.ChannelType),
+		UserId:             userId,
+		UserEmail:          email,
+		Username:           username,
+		UserType:           userType,
+	}
+}
+
+func postToLine(channel shared.ChannelExport, post shared.PostExport, lineType string) Line {
+	userType := shared.User
+	if post.IsBot {
+		userType = shared.Bot
+	}
+
+	line := channelLine(channel, lineType, model.SafeDereference(post.PostCreateAt),
+		model.SafeDereference(post.UserId), model.SafeDereference(post.UserEmail), model.SafeDereference(post.Username), userType)
+	line.UpdateAt = model.SafeDereference(post.PostUpdateAt)
+	line.UpdateType = post.UpdatedType
+	line.PostId = model.SafeDereference(post.PostId)
+	line.RootId = model.SafeDereference(post.PostRootId)
+	line.EditedByPostId = post.EditedNewMsgId
+	line.PreviewsPostId = post.PreviewID()
+	return line
+}
+
+func attachmentToLine(channel shared.ChannelExport, post shared.PostExport) Line {
+	lineType := AttachmentLineType
+	if post.UpdatedType == shared.FileDeleted {
+		lineType = DeletedAttachmentLineType
+	}
+
+	line := postToLine(channel, post, lineType)
+	line.File = &File{
+		Id:       post.FileInfo.Id,
+		Name:     post.FileInfo.Name,
+		MimeType: post.FileInfo.MimeType,
+		Size:     post.FileInfo.Size,
+	}
+	if lineType == DeletedAttachmentLineType {
+		line.UpdateAt = post.FileInfo.DeleteAt
+	} else {
+		line.File.Path = attachmentPath(line.PostId, post.FileInfo)
+	}
+	return line
+}
+
+func attachmentPath(postId string, fileInfo *model.FileInfo) string {
+	return path.Join("files", postId, fmt.Sprintf("%s-%s", fileInfo.Id, path.Base(fileInfo.Path)))
+}
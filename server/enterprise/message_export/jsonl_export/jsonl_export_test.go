@@ -0,0 +1,191 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.enterprise for license information.
+
+package jsonl_export
+
+import (
+	"archive/zip"
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"io"
+	"os"
+	"path"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/public/shared/request"
+	"github.com/mattermost/mattermost/server/v8/channels/store/storetest"
+	"github.com/mattermost/mattermost/server/v8/enterprise/message_export/shared"
+	"github.com/mattermost/mattermost/server/v8/platform/shared/filestore"
+)
+
+func TestJsonlExport(t *testing.T) {
+	rctx := request.TestContext(t)
+
+	tempDir, err := os.MkdirTemp("", "")
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		err = os.RemoveAll(tempDir)
+		assert.NoError(t, err)
+	})
+
+	fileBackend, err := filestore.NewFileBackend(filestore.FileBackendSettings{
+		DriverName: model.ImageDriverLocal,
+		Directory:  tempDir,
+	})
+	require.NoError(t, err)
+
+	attachment := &model.FileInfo{Id: "file-id", Name: "report.txt", Path: "path/to/report.txt", MimeType: "text/plain", Size: 17}
+	deletedAttachment := &model.FileInfo{Id: "file-id-2", Name: "old.txt", Path: "path/to/old.txt", Size: 3, DeleteAt: 250}
+	_, err = fileBackend.WriteFile(strings.NewReader("quarterly numbers"), attachment.Path)
+	require.NoError(t, err)
+	// Deleted files are kept in the file store.
+	_, err = fileBackend.WriteFile(strings.NewReader("old"), deletedAttachment.Path)
+	require.NoError(t, err)
+
+	mockStore := &storetest.Store{}
+	defer mockStore.AssertExpectations(t)
+	mockStore.FileInfoStore.On("GetForPost", "root-id", true, true, false).Return([]*model.FileInfo{attachment, deletedAttachment}, nil)
+
+	chanTypeOpen := model.ChannelTypeOpen
+	post := func(id, rootId, userId, message string, createAt, updateAt int64, fileIds []string) *model.MessageExport {
+		return &model.MessageExport{
+			PostId:             model.NewPointer(id),
+			PostOriginalId:     model.NewPointer(""),
+			PostRootId:         model.NewPointer(rootId),
+			TeamId:             model.NewPointer("team-id"),
+			TeamName:           model.NewPointer("team-name"),
+			TeamDisplayName:    model.NewPointer("team-display-name"),
+			ChannelId:          model.NewPointer("channel-id"),
+			ChannelName:        model.NewPointer("town-square"),
+			ChannelDisplayName: model.NewPointer("Town Square"),
+			ChannelType:        &chanTypeOpen,
+			PostCreateAt:       model.NewPointer(createAt),
+			PostUpdateAt:       model.NewPointer(updateAt),
+			PostMessage:        model.NewPointer(message),
+			PostType:           model.NewPointer(""),
+			UserEmail:          model.NewPointer(userId + "@example.com"),
+			UserId:             model.NewPointer(userId),
+			Username:           model.NewPointer(userId),
+			PostFileIds:        fileIds,
+		}
+	}
+
+	exportFileName := path.Join("export", "jobName", "jobName-batch001.zip")
+	results, err := JsonlExport(rctx, shared.ExportParams{
+		ExportType: model.ComplianceExportTypeJsonl,
+		ChannelMetadata: map[string]*shared.MetadataChannel{
+			"channel-id": {
+				TeamId:             model.NewPointer("team-id"),
+				ChannelId:          "channel-id",
+				ChannelName:        "town-square",
+				ChannelDisplayName: "Town Square",
+				ChannelType:        model.ChannelTypeOpen,
+				RoomId:             "public - channel-id",
+				StartTime:          1,
+				EndTime:            300,
+			},
+		},
+		ChannelMemberHistories: map[string][]*model.ChannelMemberHistoryResult{
+			"channel-id": {
+				{JoinTime: 0, UserId: "alice", UserEmail: "alice@example.com", Username: "alice"},
+				{JoinTime: 0, UserId: "bob", UserEmail: "bob@example.com", Username: "bob"},
+				{JoinTime: 150, UserId: "carol", UserEmail: "carol@example.com", Username: "carol", LeaveTime: model.NewPointer(int64(180))},
+			},
+		},
+		Posts: []*model.MessageExport{
+			post("root-id", "", "alice", "hello", 100, 100, []string{"file-id", "file-id-2"}),
+			post("reply-id", "root-id", "bob", "a reply", 200, 200, []string{}),
+		},
+		BatchPath:             exportFileName,
+		BatchStartTime:        1,
+		BatchEndTime:          300,
+		Db:                    shared.NewMessageExportStore(mockStore),
+		FileAttachmentBackend: fileBackend,
+		ExportBackend:         fileBackend,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 0, results.NumWarnings)
+	assert.Equal(t, 2, results.CreatedPosts)
+
+	zipBytes, err := fileBackend.ReadFile(exportFileName)
+	require.NoError(t, err)
+	zipReader, err := zip.NewReader(bytes.NewReader(zipBytes), int64(len(zipBytes)))
+	require.NoError(t, err)
+
+	var names []string
+	for _, f := range zipReader.File {
+		names = append(names, f.Name)
+	}
+	assert.Equal(t, []string{JSONLPostsFilename, "files/root-id/file-id-report.txt", "files/root-id/file-id-2-old.txt", "metadata.json"}, names)
+
+	attachmentFile, err := zipReader.Open("files/root-id/file-id-report.txt")
+	require.NoError(t, err)
+	attachmentData, err := io.ReadAll(attachmentFile)
+	require.NoError(t, err)
+	require.NoError(t, attachmentFile.Close())
+	assert.Equal(t, "quarterly numbers", string(attachmentData))
+
+	postsFile, err := zipReader.Open(JSONLPostsFilename)
+	require.NoError(t, err)
+	defer postsFile.Close()
+
+	var lines []Line
+	scanner := bufio.NewScanner(postsFile)
+	for scanner.Scan() {
+		var line Line
+		require.NoError(t, json.Unmarshal(scanner.Bytes(), &line))
+		lines = append(lines, line)
+	}
+	require.NoError(t, scanner.Err())
+
+	type summary struct {
+		Type     string
+		CreateAt int64
+		UserId   string
+		PostId   string
+	}
+	var summaries []summary
+	for _, line := range lines {
+		summaries = append(summaries, summary{line.Type, line.CreateAt, line.UserId, line.PostId})
+		assert.Equal(t, "channel-id", line.ChannelId)
+		assert.Equal(t, "public", line.ChannelType)
+		assert.Equal(t, "team-id", line.TeamId)
+	}
+	assert.Equal(t, []summary{
+		{PreviouslyJoinedLineType, 0, "alice", ""},
+		{PreviouslyJoinedLineType, 0, "bob", ""},
+		{MessageLineType, 100, "alice", "root-id"},
+		{AttachmentLineType, 100, "alice", "root-id"},
+		// the post wasn't deleted, so its deleted file is exported as uploaded too.
+		{AttachmentLineType, 100, "alice", "root-id"},
+		{DeletedAttachmentLineType, 100, "alice", "root-id"},
+		{EnterLineType, 150, "carol", ""},
+		{LeaveLineType, 180, "carol", ""},
+		{MessageLineType, 200, "bob", "reply-id"},
+	}, summaries)
+
+	root := lines[2]
+	assert.Equal(t, "hello", root.Message)
+	assert.Equal(t, []Recipient{{UserId: "bob", UserEmail: "bob@example.com", Username: "bob"}}, root.Recipients)
+
+	upload := lines[3]
+	require.NotNil(t, upload.File)
+	assert.Equal(t, File{Id: "file-id", Name: "report.txt", MimeType: "text/plain", Size: 17, Path: "files/root-id/file-id-report.txt"}, *upload.File)
+
+	deleted := lines[5]
+	require.NotNil(t, deleted.File)
+	assert.Equal(t, shared.FileDeleted, deleted.UpdateType)
+	assert.Equal(t, int64(250), deleted.UpdateAt)
+	assert.Empty(t, deleted.File.Path)
+
+	reply := lines[8]
+	assert.Equal(t, "root-id", reply.RootId)
+	// carol left before the reply.
+	assert.Equal(t, []Recipient{{UserId: "alice", UserEmail: "alice@example.com", Username: "alice"}}, reply.Recipients)
+}
@@ -0,0 +1,140 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.enterprise for license information.
+
+package shared
+
+import (
+	"sort"
+
+	"github.com/mattermost/mattermost/server/public/model"
+)
+
+type MembershipMapUser struct {
+	UserId   string
+	Email    string
+	Username string
+}
+
+// Provides a clean interface for tracking the users that are present in any number of channels by channel id and user email
+type MembershipMap map[string]map[string]MembershipMapUser
+
+func (m *MembershipMap) init(channelId string) {
+	if *m == nil {
+		*m = make(map[string]map[string]MembershipMapUser)
+	}
+	if (*m)[channelId] == nil {
+		(*m)[channelId] = make(map[string]MembershipMapUser)
+	}
+}
+
+func (m *MembershipMap) AddUserToChannel(channelId string, user MembershipMapUser) {
+	m.init(channelId)
+	if !m.IsUserInChannel(channelId, user.Email) {
+		(*m)[channelId][user.Email] = user
+	}
+}
+
+func (m *MembershipMap) RemoveUserFromChannel(channelId string, userEmail string) {
+	m.init(channelId)
+	delete((*m)[channelId], userEmail)
+}
+
+func (m *MembershipMap) IsUserInChannel(channelId string, userEmail string) bool {
+	m.init(channelId)
+	_, exists := (*m)[channelId][userEmail]
+	return exists
+}
+
+func (m *MembershipMap) GetUserEmailsInChannel(channelId string) []string {
+	m.init(channelId)
+	users := make([]string, 0, len((*m)[channelId]))
+	for k := range (*m)[channelId] {
+		users = append(users, k)
+	}
+	return users
+}
+
+func (m *MembershipMap) GetUsersInChannel(channelId string) []MembershipMapUser {
+	m.init(channelId)
+	users := make([]MembershipMapUser, 0, len((*m)[channelId]))
+	for _, v := range (*m)[channelId] {
+		users = append(users, v)
+	}
+	return users
+}
+
+// GetPostRecipients replays the join and leave events of the channel export to return, for each of its posts, the
+// users that were in the channel when the post was sent, its author excluded. The result is indexed like
+// channel.Posts and each list of recipients is sorted by username.
+func GetPostRecipients(channel ChannelExport) [][]MembershipMapUser {
+	type membershipEvent struct {
+		time  int64
+		join  bool
+		order int
+		user  MembershipMapUser
+	}
+
+	events := make([]membershipEvent, 0, len(channel.JoinEvents)+len(channel.LeaveEvents))
+	for _, join := range channel.JoinEvents {
+		events = append(events, membershipEvent{
+			time: join.JoinTime,
+			join: true,
+			user: MembershipMapUser{UserId: join.UserId, Email: join.UserEmail, Username: join.Username},
+		})
+	}
+	for _, leave := range channel.LeaveEvents {
+		if leave.ClosedOut {
+			// the user didn't leave, the export period ended.
+			continue
+		}
+		events = append(events, membershipEvent{
+			time: leave.LeaveTime,
+			user: MembershipMapUser{UserId: leave.UserId, Email: leave.UserEmail, Username: leave.Username},
+		})
+	}
+	// Joins happen before leaves at the same time, so that a user leaving right away isn't a recipient.
+	sort.SliceStable(events, func(i, j int) bool {
+		if events[i].time == events[j].time {
+			return events[i].join && !events[j].join
+		}
+		return events[i].time < events[j].time
+	})
+
+	postOrder := make([]int, len(channel.Posts))
+	for i := range postOrder {
+		postOrder[i] = i
+	}
+	sort.SliceStable(postOrder, func(i, j int) bool {
+		return model.SafeDereference(channel.Posts[postOrder[i]].PostCreateAt) < model.SafeDereference(channel.Posts[postOrder[j]].PostCreateAt)
+	})
+
+	var members MembershipMap
+	members.init(channel.ChannelId)
+
+	recipients := make([][]MembershipMapUser, len(channel.Posts))
+	next := 0
+	for _, i := range postOrder {
+		post := channel.Posts[i]
+		createAt := model.SafeDereference(post.PostCreateAt)
+		for ; next < len(events) && events[next].time <= createAt; next++ {
+			if events[next].join {
+				members.AddUserToChannel(channel.ChannelId, events[next].user)
+			} else {
+				members.RemoveUserFromChannel(channel.ChannelId, events[next].user.Email)
+			}
+		}
+
+		postRecipients := make([]MembershipMapUser, 0, len(members[channel.ChannelId]))
+		for _, user := range members.GetUsersInChannel(channel.ChannelId) {
+			if user.UserId != model.SafeDereference(post.UserId) {
+				postRecipients = append(postRecipients, user)
+			}
+		}
+		sort.Slice(postRecipients, func(i, j int) bool {
+			return postRecipients[i].Username < postRecipients[j].Username
+		})
+		recipients[i] = postRecipients
+	}
+
+	return recipients
+}
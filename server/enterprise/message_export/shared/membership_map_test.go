@@ -0,0 +1,122 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.enterprise for license information.
+
+package shared
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost/server/public/model"
+)
+
+func TestMembershipMap(t *testing.T) {
+	membershipMap := make(MembershipMap)
+
+	channelId := model.NewId()
+
+	user1 := &MembershipMapUser{
+		Email:    model.NewId() + "@mattermost.com",
+		Username: model.NewId(),
+		UserId:   model.NewId(),
+	}
+	user2 := &MembershipMapUser{
+		Email:    model.NewId() + "@mattermost.com",
+		Username: model.NewId(),
+		UserId:   model.NewId(),
+	}
+
+	assert.False(t, membershipMap.IsUserInChannel(channelId, user1.Email))
+	membershipMap.AddUserToChannel(channelId, *user1)
+	assert.True(t, membershipMap.IsUserInChannel(channelId, user1.Email))
+
+	assert.False(t, membershipMap.IsUserInChannel(channelId, user2.Email))
+	membershipMap.AddUserToChannel(channelId, *user2)
+	assert.True(t, membershipMap.IsUserInChannel(channelId, user2.Email))
+
+	// ensure that the correct user emails are returned
+	emails := membershipMap.GetUserEmailsInChannel(channelId)
+	assert.Len(t, emails, 2)
+	assert.Contains(t, emails, user1.Email)
+	assert.Contains(t, emails, user2.Email)
+
+	// ensure that the correct user objects are returned
+	users := membershipMap.GetUsersInChannel(channelId)
+	assert.Len(t, users, 2)
+	if users[0].UserId == user1.UserId {
+		assert.Equal(t, user1.Username, users[0].Username)
+		assert.Equal(t, user1.Email, users[0].Email)
+		assert.Equal(t, user2.UserId, users[1].UserId)
+		assert.Equal(t, user2.Username, users[1].Username)
+		assert.Equal(t, user2.Email, users[1].Email)
+	} else if users[0].UserId == user2.UserId {
+		assert.Equal(t, user2.Username, users[0].Username)
+		assert.Equal(t, user2.Email, users[0].Email)
+		assert.Equal(t, user1.UserId, users[1].UserId)
+		assert.Equal(t, user1.Username, users[1].Username)
+		assert.Equal(t, user1.Email, users[1].Email)
+	} else {
+		assert.Fail(t, "First returned user is not recognized")
+	}
+
+	// remove user1 from the channel
+	membershipMap.RemoveUserFromChannel(channelId, user1.Email)
+	assert.False(t, membershipMap.IsUserInChannel(channelId, user1.Email))
+	assert.True(t, membershipMap.IsUserInChannel(channelId, user2.Email))
+
+	// ensure that user2's email is returned
+	emails = membershipMap.GetUserEmailsInChannel(channelId)
+	assert.Len(t, emails, 1)
+	assert.Contains(t, emails, user2.Email)
+
+	// ensure that only user2 is returned
+	users = membershipMap.GetUsersInChannel(channelId)
+	assert.Len(t, users, 1)
+	assert.Equal(t, user2.UserId, users[0].UserId)
+	assert.Equal(t, user2.Username, users[0].Username)
+	assert.Equal(t, user2.Email, users[0].Email)
+}
+
+func TestGetPostRecipients(t *testing.T) {
+	post := func(id, userId string, createAt int64) PostExport {
+		return PostExport{MessageExport: model.MessageExport{
+			PostId:       model.NewPointer(id),
+			UserId:       model.NewPointer(userId),
+			PostCreateAt: model.NewPointer(createAt),
+		}}
+	}
+
+	channel := ChannelExport{
+		ChannelId: model.NewId(),
+		StartTime: 0,
+		EndTime:   1000,
+		// posts aren't sorted by create time, they're exported by update time.
+		Posts: []PostExport{
+			post("post-3", "alice", 500),
+			post("post-1", "alice", 10),
+			post("post-2", "bob", 100),
+		},
+		JoinEvents: []JoinExport{
+			{UserId: "alice", Username: "alice", UserEmail: "alice@example.com", JoinTime: 0},
+			{UserId: "bob", Username: "bob", UserEmail: "bob@example.com", JoinTime: 0},
+			{UserId: "carol", Username: "carol", UserEmail: "carol@example.com", JoinTime: 100},
+		},
+		LeaveEvents: []LeaveExport{
+			{UserId: "bob", Username: "bob", UserEmail: "bob@example.com", LeaveTime: 400},
+			{UserId: "carol", Username: "carol", UserEmail: "carol@example.com", LeaveTime: 1000, ClosedOut: true},
+		},
+	}
+
+	recipients := GetPostRecipients(channel)
+	require.Len(t, recipients, 3)
+
+	bob := MembershipMapUser{UserId: "bob", Email: "bob@example.com", Username: "bob"}
+	carol := MembershipMapUser{UserId: "carol", Email: "carol@example.com", Username: "carol"}
+	alice := MembershipMapUser{UserId: "alice", Email: "alice@example.com", Username: "alice"}
+
+	assert.Equal(t, []MembershipMapUser{carol}, recipients[0])
+	assert.Equal(t, []MembershipMapUser{bob}, recipients[1])
+	assert.Equal(t, []MembershipMapUser{alice, carol}, recipients[2])
+}
@@ -64,6 +64,8 @@ type MetricsInterfaceImpl struct {
 	DbReplicaLagGaugeAbs     *prometheus.GaugeVec
 	DbReplicaLagGaugeTime    *prometheus.GaugeVec
 
+	DbReadYourWritesMasterFallbackCounter prometheus.Counter
+
 	PostCreateCounter     prometheus.Counter
 	WebhookPostCounter    prometheus.Counter
 	PostSentEmailCounter  prometheus.Counter
@@ -171,6 +173,11 @@ type MetricsInterfaceImpl struct {
 	PluginMultiHookTimeHistogram       *prometheus.HistogramVec
 	PluginMultiHookServerTimeHistogram prometheus.Histogram
 	PluginAPITimeHistogram             *prometheus.HistogramVec
+	PluginMemoryGauge                  *prometheus.GaugeVec
+	PluginCPUTimeGauge                 *prometheus.GaugeVec
+	PluginGoroutinesGauge              *prometheus.GaugeVec
+	PluginAPICallRateGauge             *prometheus.GaugeVec
+	PluginHookLatencyGauge             *prometheus.GaugeVec
 
 	LoggerQueueGauge      *DynamicGauge
 	LoggerLoggedCounters  *DynamicCounter
@@ -417,6 +424,15 @@ func New(ps *platform.PlatformService, driver, dataSource string) *MetricsInterf
 	)
 	m.Registry.MustRegister(m.DbReplicaLagGaugeTime)
 
+	m.DbReadYourWritesMasterFallbackCounter = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace:   MetricsNamespace,
+		Subsystem:   MetricsSubsystemDB,
+		Name:        "read_your_writes_master_fallbacks_total",
+		Help:        "The total number of reads sent to master because the replicas could lack a recent write of the user.",
+		ConstLabels: additionalLabels,
+	})
+	m.Registry.MustRegister(m.DbReadYourWritesMasterFallbackCounter)
+
 	// HTTP Subsystem
 
 	m.HTTPWebsocketsGauge = prometheus.NewGaugeVec(prometheus.GaugeOpts{
@@ -526,9 +542,11 @@ func New(ps *platform.PlatformService, driver, dataSource string) *MetricsInterf
 		model.ClusterEventClearSessionCacheForAllUsers,
 		model.ClusterEventInstallPlugin,
 		model.ClusterEventRemovePlugin,
+		model.ClusterEventDeactivatePlugin,
 		model.ClusterEventPluginEvent,
 		model.ClusterEventInvalidateCacheForTermsOfService,
 		model.ClusterEventBusyStateChanged,
+		model.ClusterEventSSERequest,
 	} {
 		m.ClusterEventMap[event] = m.ClusterEventTypeCounters.With(prometheus.Labels{"name": string(event)})
 	}
@@ -889,6 +907,66 @@ func New(ps *platform.PlatformService, driver, dataSource string) *MetricsInterf
 	)
 	m.Registry.MustRegister(m.PluginAPITimeHistogram)
 
+	m.PluginMemoryGauge = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace:   MetricsNamespace,
+			Subsystem:   MetricsSubsystemPlugin,
+			Name:        "memory_rss_bytes",
+			Help:        "Resident set size of the plugin process in bytes.",
+			ConstLabels: additionalLabels,
+		},
+		[]string{"plugin_id"},
+	)
+	m.Registry.MustRegister(m.PluginMemoryGauge)
+
+	m.PluginCPUTimeGauge = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace:   MetricsNamespace,
+			Subsystem:   MetricsSubsystemPlugin,
+			Name:        "cpu_time_seconds",
+			Help:        "Total CPU time used by the plugin process in seconds.",
+			ConstLabels: additionalLabels,
+		},
+		[]string{"plugin_id"},
+	)
+	m.Registry.MustRegister(m.PluginCPUTimeGauge)
+
+	m.PluginGoroutinesGauge = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace:   MetricsNamespace,
+			Subsystem:   MetricsSubsystemPlugin,
+			Name:        "goroutines",
+			Help:        "Number of goroutines in the plugin process.",
+			ConstLabels: additionalLabels,
+		},
+		[]string{"plugin_id"},
+	)
+	m.Registry.MustRegister(m.PluginGoroutinesGauge)
+
+	m.PluginAPICallRateGauge = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace:   MetricsNamespace,
+			Subsystem:   MetricsSubsystemPlugin,
+			Name:        "api_calls_per_second",
+			Help:        "Rate of plugin API calls per second since the previous health check.",
+			ConstLabels: additionalLabels,
+		},
+		[]string{"plugin_id"},
+	)
+	m.Registry.MustRegister(m.PluginAPICallRateGauge)
+
+	m.PluginHookLatencyGauge = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace:   MetricsNamespace,
+			Subsystem:   MetricsSubsystemPlugin,
+			Name:        "hook_latency_seconds",
+			Help:        "Average duration of the plugin hooks invoked since the previous health check in seconds.",
+			ConstLabels: additionalLabels,
+		},
+		[]string{"plugin_id"},
+	)
+	m.Registry.MustRegister(m.PluginHookLatencyGauge)
+
 	// Logging subsystem
 
 	m.LoggerQueueGauge = NewDynamicGauge(
@@ -1900,6 +1978,22 @@ func (mi *MetricsInterfaceImpl) ObservePluginAPIDuration(pluginID, apiName strin
 	mi.PluginAPITimeHistogram.With(prometheus.Labels{"plugin_id": pluginID, "api_name": apiName, "success": strconv.FormatBool(success)}).Observe(elapsed)
 }
 
+func (mi *MetricsInterfaceImpl) ObservePluginResourceUsage(pluginID string, usage *model.PluginResourceUsage) {
+	mi.PluginMemoryGauge.WithLabelValues(pluginID).Set(float64(usage.MemoryRSSBytes))
+	mi.PluginCPUTimeGauge.WithLabelValues(pluginID).Set(float64(usage.CPUTimeMs) / 1000)
+	mi.PluginGoroutinesGauge.WithLabelValues(pluginID).Set(float64(usage.Goroutines))
+	mi.PluginAPICallRateGauge.WithLabelValues(pluginID).Set(usage.APICallsPerSecond)
+	mi.PluginHookLatencyGauge.WithLabelValues(pluginID).Set(usage.HookLatencyMs / 1000)
+}
+
+func (mi *MetricsInterfaceImpl) ClearPluginResourceUsage(pluginID string) {
+	mi.PluginMemoryGauge.DeleteLabelValues(pluginID)
+	mi.PluginCPUTimeGauge.DeleteLabelValues(pluginID)
+	mi.PluginGoroutinesGauge.DeleteLabelValues(pluginID)
+	mi.PluginAPICallRateGauge.DeleteLabelValues(pluginID)
+	mi.PluginHookLatencyGauge.DeleteLabelValues(pluginID)
+}
+
 func (mi *MetricsInterfaceImpl) GetLoggerMetricsCollector() mlog.MetricsCollector {
 	return &LoggerMetricsCollector{
 		queueGauge:      mi.LoggerQueueGauge,
@@ -1998,6 +2092,11 @@ func (mi *MetricsInterfaceImpl) SetReplicaLagTime(node string, value float64) {
 	mi.DbReplicaLagGaugeTime.With(prometheus.Labels{"node": node}).Set(value)
 }
 
+// IncrementReadYourWritesMasterFallback counts a read sent to master instead of a replica after a recent write.
+func (mi *MetricsInterfaceImpl) IncrementReadYourWritesMasterFallback() {
+	mi.DbReadYourWritesMasterFallbackCounter.Inc()
+}
+
 func normalizeNotificationPlatform(platform string) string {
 	switch platform {
 	case "apple_rn-v2", "apple_rnbeta-v2", "ios":
@@ -0,0 +1,281 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package marketplace
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"path"
+	"sort"
+
+	"github.com/blang/semver/v4"
+	"github.com/pkg/errors"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/v8/platform/shared/filestore"
+)
+
+// CatalogIndexFile is the name of the file listing the plugins published to a catalog.
+const CatalogIndexFile = "index.json"
+
+// ErrCatalogPluginNotFound is returned when no plugin in the catalog matches the request.
+var ErrCatalogPluginNotFound = errors.New("plugin not found")
+
+// CatalogPlugin is an entry of the catalog index.
+type CatalogPlugin struct {
+	Manifest *model.Manifest `json:"manifest"`
+	IconData string          `json:"icon_data,omitempty"`
+	// BundlePath and SignaturePath are relative to the catalog directory.
+	BundlePath    string `json:"bundle_path"`
+	SignaturePath string `json:"signature_path"`
+	PublishedAt   int64  `json:"published_at"`
+}
+
+// CatalogIndex is the content of the catalog index file.
+type CatalogIndex struct {
+	Plugins []*CatalogPlugin `json:"plugins"`
+}
+
+// Catalog is a marketplace served from plugin bundles published to a directory of a file backend,
+// for servers that can't reach the marketplace server.
+//
+// The catalog directory holds the index file, and the bundle and signature of every published
+// plugin version. Catalog doesn't synchronize concurrent calls to Publish, which the caller must
+// serialize across the servers sharing the catalog.
+type Catalog struct {
+	backend filestore.FileBackend
+	dir     string
+}
+
+// NewCatalog creates a catalog stored in the given directory of the file backend.
+func NewCatalog(backend filestore.FileBackend, dir string) *Catalog {
+	return &Catalog{
+		backend: backend,
+		dir:     dir,
+	}
+}
+
+// GetPlugins returns the catalog plugins compatible with the server version of the filter, or
+// only the versions of the filter plugin if set. Unless ReturnAllVersions is set, only the latest
+// version of each plugin is returned.
+func (c *Catalog) GetPlugins(filter *model.MarketplacePluginFilter) ([]*model.BaseMarketplacePlugin, error) {
+	index, err := c.readIndex()
+	if err != nil {
+		return nil, err
+	}
+
+	var serverVersion *semver.Version
+	if filter.ServerVersion != "" {
+		v, err := semver.Parse(filter.ServerVersion)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to parse server version")
+		}
+		serverVersion = &v
+	}
+
+	type candidate struct {
+		plugin  *CatalogPlugin
+		version semver.Version
+	}
+	var candidates []candidate
+	for _, p := range index.Plugins {
+		if p.Manifest == nil || (filter.PluginId != "" && p.Manifest.Id != filter.PluginId) {
+			continue
+		}
+
+		version, err := semver.Parse(p.Manifest.Version)
+		if err != nil {
+			continue
+		}
+
+		if serverVersion != nil && p.Manifest.MinServerVersion != "" {
+			minServerVersion, err := semver.Parse(p.Manifest.MinServerVersion)
+			if err != nil || serverVersion.LT(minServerVersion) {
+				continue
+			}
+		}
+
+		candidates = append(candidates, candidate{plugin: p, version: version})
+	}
+
+	// Sort by plugin, then newest version first.
+	sort.SliceStable(candidates, func(i, j int) bool {
+		if candidates[i].plugin.Manifest.Id != candidates[j].plugin.Manifest.Id {
+			return candidates[i].plugin.Manifest.Id < candidates[j].plugin.Manifest.Id
+		}
+		return candidates[i].version.GT(candidates[j].version)
+	})
+
+	plugins := []*model.BaseMarketplacePlugin{}
+	for i, candidate := range candidates {
+		if !filter.ReturnAllVersions && i > 0 && candidates[i-1].plugin.Manifest.Id == candidate.plugin.Manifest.Id {
+			continue
+		}
+
+		plugins = append(plugins, &model.BaseMarketplacePlugin{
+			HomepageURL:     candidate.plugin.Manifest.HomepageURL,
+			IconData:        candidate.plugin.IconData,
+			ReleaseNotesURL: candidate.plugin.Manifest.ReleaseNotesURL,
+			Manifest:        candidate.plugin.Manifest,
+		})
+	}
+
+	return plugins, nil
+}
+
+// GetPlugin returns the given version of the filter plugin.
+func (c *Catalog) GetPlugin(filter *model.MarketplacePluginFilter, pluginVersion string) (*model.BaseMarketplacePlugin, error) {
+	filter.ReturnAllVersions = true
+
+	if filter.PluginId == "" {
+		return nil, errors.New("missing pluginID")
+	}
+
+	if pluginVersion == "" {
+		return nil, errors.New("missing pluginVersion")
+	}
+
+	plugins, err := c.GetPlugins(filter)
+	if err != nil {
+		return nil, err
+	}
+	for _, plugin := range plugins {
+		if plugin.Manifest.Version == pluginVersion {
+			return plugin, nil
+		}
+	}
+	return nil, ErrCatalogPluginNotFound
+}
+
+// GetLatestPlugin returns the latest compatible version of the filter plugin.
+func (c *Catalog) GetLatestPlugin(filter *model.MarketplacePluginFilter) (*model.BaseMarketplacePlugin, error) {
+	filter.ReturnAllVersions = false
+
+	if filter.PluginId == "" {
+		return nil, errors.New("no pluginID provided")
+	}
+
+	plugins, err := c.GetPlugins(filter)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(plugins) == 0 {
+		return nil, ErrCatalogPluginNotFound
+	}
+
+	return plugins[0], nil
+}
+
+// OpenPlugin opens the bundle and signature of the given plugin version. The caller is
+// responsible for closing both.
+func (c *Catalog) OpenPlugin(pluginID, pluginVersion string) (bundle, signature filestore.ReadCloseSeeker, err error) {
+	index, err := c.readIndex()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	for _, p := range index.Plugins {
+		if p.Manifest == nil || p.Manifest.Id != pluginID || p.Manifest.Version != pluginVersion {
+			continue
+		}
+
+		bundle, err = c.backend.Reader(path.Join(c.dir, p.BundlePath))
+		if err != nil {
+			return nil, nil, errors.Wrapf(err, "failed to open bundle %s", p.BundlePath)
+		}
+
+		signature, err = c.backend.Reader(path.Join(c.dir, p.SignaturePath))
+		if err != nil {
+			bundle.Close()
+			return nil, nil, errors.Wrapf(err, "failed to open signature %s", p.SignaturePath)
+		}
+
+		return bundle, signature, nil
+	}
+
+	return nil, nil, ErrCatalogPluginNotFound
+}
+
+// Publish adds the given plugin bundle and signature to the catalog, replacing the same version
+// if already published. The caller is responsible for verifying the signature.
+func (c *Catalog) Publish(manifest *model.Manifest, iconData string, bundle, signature io.Reader, publishedAt int64) error {
+	if manifest == nil || manifest.Id == "" || manifest.Version == "" {
+		return errors.New("missing plugin id or version")
+	}
+
+	index, err := c.readIndex()
+	if err != nil {
+		return err
+	}
+
+	entry := &CatalogPlugin{
+		Manifest:      manifest,
+		IconData:      iconData,
+		BundlePath:    path.Join(manifest.Id, fmt.Sprintf("%s-%s.tar.gz", manifest.Id, manifest.Version)),
+		SignaturePath: path.Join(manifest.Id, fmt.Sprintf("%s-%s.tar.gz.sig", manifest.Id, manifest.Version)),
+		PublishedAt:   publishedAt,
+	}
+
+	if _, err := c.backend.WriteFile(bundle, path.Join(c.dir, entry.BundlePath)); err != nil {
+		return errors.Wrapf(err, "failed to write bundle %s", entry.BundlePath)
+	}
+	if _, err := c.backend.WriteFile(signature, path.Join(c.dir, entry.SignaturePath)); err != nil {
+		return errors.Wrapf(err, "failed to write signature %s", entry.SignaturePath)
+	}
+
+	replaced := false
+	for i, p := range index.Plugins {
+		if p.Manifest != nil && p.Manifest.Id == manifest.Id && p.Manifest.Version == manifest.Version {
+			index.Plugins[i] = entry
+			replaced = true
+			break
+		}
+	}
+	if !replaced {
+		index.Plugins = append(index.Plugins, entry)
+	}
+
+	return c.writeIndex(index)
+}
+
+// readIndex reads the catalog index, which is empty until a plugin is published.
+func (c *Catalog) readIndex() (*CatalogIndex, error) {
+	indexPath := path.Join(c.dir, CatalogIndexFile)
+
+	exists, err := c.backend.FileExists(indexPath)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to check for catalog index")
+	}
+	if !exists {
+		return &CatalogIndex{Plugins: []*CatalogPlugin{}}, nil
+	}
+
+	data, err := c.backend.ReadFile(indexPath)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read catalog index")
+	}
+
+	var index CatalogIndex
+	if err := json.Unmarshal(data, &index); err != nil {
+		return nil, errors.Wrap(err, "failed to parse catalog index")
+	}
+
+	return &index, nil
+}
+
+func (c *Catalog) writeIndex(index *CatalogIndex) error {
+	data, err := json.MarshalIndent(index, "", "  ")
+	if err != nil {
+		return errors.Wrap(err, "failed to encode catalog index")
+	}
+
+	if _, err := c.backend.WriteFile(bytes.NewReader(data), path.Join(c.dir, CatalogIndexFile)); err != nil {
+		return errors.Wrap(err, "failed to write catalog index")
+	}
+
+	return nil
+}
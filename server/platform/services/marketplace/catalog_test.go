@@ -0,0 +1,132 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package marketplace
+
+import (
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/v8/platform/shared/filestore"
+)
+
+func newTestCatalog(t *testing.T) *Catalog {
+	t.Helper()
+
+	backend, err := filestore.NewFileBackend(filestore.FileBackendSettings{
+		DriverName: model.ImageDriverLocal,
+		Directory:  t.TempDir(),
+	})
+	require.NoError(t, err)
+
+	return NewCatalog(backend, "catalog")
+}
+
+func publishTestPlugin(t *testing.T, catalog *Catalog, id, version, minServerVersion string) {
+	t.Helper()
+
+	manifest := &model.Manifest{Id: id, Name: id, Version: version, MinServerVersion: minServerVersion}
+	err := catalog.Publish(manifest, "", strings.NewReader("bundle "+id+" "+version), strings.NewReader("signature "+id+" "+version), model.GetMillis())
+	require.NoError(t, err)
+}
+
+func TestCatalog(t *testing.T) {
+	t.Run("empty catalog", func(t *testing.T) {
+		catalog := newTestCatalog(t)
+
+		plugins, err := catalog.GetPlugins(&model.MarketplacePluginFilter{})
+		require.NoError(t, err)
+		assert.Empty(t, plugins)
+
+		_, err = catalog.GetLatestPlugin(&model.MarketplacePluginFilter{PluginId: "testplugin"})
+		assert.ErrorIs(t, err, ErrCatalogPluginNotFound)
+	})
+
+	t.Run("latest compatible versions", func(t *testing.T) {
+		catalog := newTestCatalog(t)
+		publishTestPlugin(t, catalog, "testplugin", "1.0.0", "")
+		publishTestPlugin(t, catalog, "testplugin", "1.2.0", "5.0.0")
+		publishTestPlugin(t, catalog, "testplugin", "2.0.0", "99.0.0")
+		publishTestPlugin(t, catalog, "otherplugin", "0.1.0", "")
+
+		plugins, err := catalog.GetPlugins(&model.MarketplacePluginFilter{ServerVersion: "10.0.0"})
+		require.NoError(t, err)
+		require.Len(t, plugins, 2)
+		assert.Equal(t, "otherplugin", plugins[0].Manifest.Id)
+		assert.Equal(t, "testplugin", plugins[1].Manifest.Id)
+		assert.Equal(t, "1.2.0", plugins[1].Manifest.Version)
+
+		plugins, err = catalog.GetPlugins(&model.MarketplacePluginFilter{ServerVersion: "10.0.0", PluginId: "testplugin", ReturnAllVersions: true})
+		require.NoError(t, err)
+		require.Len(t, plugins, 2)
+		assert.Equal(t, "1.2.0", plugins[0].Manifest.Version)
+		assert.Equal(t, "1.0.0", plugins[1].Manifest.Version)
+
+		plugin, err := catalog.GetLatestPlugin(&model.MarketplacePluginFilter{PluginId: "testplugin"})
+		require.NoError(t, err)
+		assert.Equal(t, "2.0.0", plugin.Manifest.Version)
+
+		plugin, err = catalog.GetPlugin(&model.MarketplacePluginFilter{PluginId: "testplugin"}, "1.0.0")
+		require.NoError(t, err)
+		assert.Equal(t, "1.0.0", plugin.Manifest.Version)
+
+		_, err = catalog.GetPlugin(&model.MarketplacePluginFilter{PluginId: "testplugin"}, "3.0.0")
+		assert.ErrorIs(t, err, ErrCatalogPluginNotFound)
+	})
+
+	t.Run("open published plugin", func(t *testing.T) {
+		catalog := newTestCatalog(t)
+		publishTestPlugin(t, catalog, "testplugin", "1.0.0", "")
+
+		bundle, signature, err := catalog.OpenPlugin("testplugin", "1.0.0")
+		require.NoError(t, err)
+		defer bundle.Close()
+		defer signature.Close()
+
+		data, err := io.ReadAll(bundle)
+		require.NoError(t, err)
+		assert.Equal(t, "bundle testplugin 1.0.0", string(data))
+
+		data, err = io.ReadAll(signature)
+		require.NoError(t, err)
+		assert.Equal(t, "signature testplugin 1.0.0", string(data))
+
+		_, _, err = catalog.OpenPlugin("testplugin", "2.0.0")
+		assert.ErrorIs(t, err, ErrCatalogPluginNotFound)
+	})
+
+	t.Run("republish replaces version", func(t *testing.T) {
+		catalog := newTestCatalog(t)
+		publishTestPlugin(t, catalog, "testplugin", "1.0.0", "")
+
+		manifest := &model.Manifest{Id: "testplugin", Name: "Renamed", Version: "1.0.0"}
+		err := catalog.Publish(manifest, "", strings.NewReader("new bundle"), strings.NewReader("new signature"), model.GetMillis())
+		require.NoError(t, err)
+
+		plugins, err := catalog.GetPlugins(&model.MarketplacePluginFilter{ReturnAllVersions: true})
+		require.NoError(t, err)
+		require.Len(t, plugins, 1)
+		assert.Equal(t, "Renamed", plugins[0].Manifest.Name)
+
+		bundle, signature, err := catalog.OpenPlugin("testplugin", "1.0.0")
+		require.NoError(t, err)
+		defer bundle.Close()
+		defer signature.Close()
+
+		data, err := io.ReadAll(bundle)
+		require.NoError(t, err)
+		assert.Equal(t, "new bundle", string(data))
+	})
+
+	t.Run("missing plugin id", func(t *testing.T) {
+		catalog := newTestCatalog(t)
+
+		err := catalog.Publish(&model.Manifest{Version: "1.0.0"}, "", strings.NewReader(""), strings.NewReader(""), 0)
+		assert.Error(t, err)
+	})
+}
@@ -62,6 +62,7 @@ const (
 	TrackConfigPassword            = "config_password"
 	TrackConfigCluster             = "config_cluster"
 	TrackConfigMetrics             = "config_metrics"
+	TrackConfigTracing             = "config_tracing"
 	TrackConfigSupport             = "config_support"
 	TrackConfigNativeApp           = "config_nativeapp"
 	TrackConfigExperimental        = "config_experimental"
@@ -616,6 +617,8 @@ func (ts *TelemetryService) trackConfig() {
 		"disable_database_search":              *cfg.SqlSettings.DisableDatabaseSearch,
 		"migrations_statement_timeout_seconds": *cfg.SqlSettings.MigrationsStatementTimeoutSeconds,
 		"replica_monitor_interval_seconds":     *cfg.SqlSettings.ReplicaMonitorIntervalSeconds,
+		"read_your_writes_window_milliseconds": *cfg.SqlSettings.ReadYourWritesWindowMilliseconds,
+		"partition_archive_after_days":         *cfg.SqlSettings.PartitionArchiveAfterDays,
 	}
 
 	configs[TrackConfigLog] = map[string]any{
@@ -631,13 +634,17 @@ func (ts *TelemetryService) trackConfig() {
 	}
 
 	configs[TrackConfigAudit] = map[string]any{
-		"file_enabled":          *cfg.ExperimentalAuditSettings.FileEnabled,
-		"file_max_size_mb":      *cfg.ExperimentalAuditSettings.FileMaxSizeMB,
-		"file_max_age_days":     *cfg.ExperimentalAuditSettings.FileMaxAgeDays,
-		"file_max_backups":      *cfg.ExperimentalAuditSettings.FileMaxBackups,
-		"file_compress":         *cfg.ExperimentalAuditSettings.FileCompress,
-		"file_max_queue_size":   *cfg.ExperimentalAuditSettings.FileMaxQueueSize,
-		"advanced_logging_json": len(cfg.ExperimentalAuditSettings.AdvancedLoggingJSON) != 0,
+		"file_enabled":                   *cfg.ExperimentalAuditSettings.FileEnabled,
+		"file_max_size_mb":               *cfg.ExperimentalAuditSettings.FileMaxSizeMB,
+		"file_max_age_days":              *cfg.ExperimentalAuditSettings.FileMaxAgeDays,
+		"file_max_backups":               *cfg.ExperimentalAuditSettings.FileMaxBackups,
+		"file_compress":                  *cfg.ExperimentalAuditSettings.FileCompress,
+		"file_max_queue_size":            *cfg.ExperimentalAuditSettings.FileMaxQueueSize,
+		"advanced_logging_json":          len(cfg.ExperimentalAuditSettings.AdvancedLoggingJSON) != 0,
+		"hash_chain_enabled":             *cfg.ExperimentalAuditSettings.HashChainEnabled,
+		"hash_chain_checkpoint_interval": *cfg.ExperimentalAuditSettings.HashChainCheckpointInterval,
+		"database_enabled":               *cfg.ExperimentalAuditSettings.DatabaseEnabled,
+		"database_retention_days":        *cfg.ExperimentalAuditSettings.DatabaseRetentionDays,
 	}
 
 	configs[TrackConfigNotificationLog] = map[string]any{
@@ -838,6 +845,12 @@ func (ts *TelemetryService) trackConfig() {
 		"enable_client_metrics": *cfg.MetricsSettings.EnableClientMetrics,
 	}
 
+	configs[TrackConfigTracing] = map[string]any{
+		"enable":      *cfg.TracingSettings.Enable,
+		"insecure":    *cfg.TracingSettings.Insecure,
+		"sample_rate": *cfg.TracingSettings.SampleRate,
+	}
+
 	configs[TrackConfigNativeApp] = map[string]any{
 		"isdefault_app_custom_url_schemes":    isDefaultArray(cfg.NativeAppSettings.AppCustomURLSchemes, model.GetDefaultAppCustomURLSchemes()),
 		"isdefault_app_download_link":         isDefault(*cfg.NativeAppSettings.AppDownloadLink, model.NativeappSettingsDefaultAppDownloadLink),
@@ -953,7 +966,9 @@ func (ts *TelemetryService) trackConfig() {
 	}
 
 	configs[TrackConfigExport] = map[string]any{
-		"retention_days": *cfg.ExportSettings.RetentionDays,
+		"retention_days":                  *cfg.ExportSettings.RetentionDays,
+		"enable_user_data_export":         *cfg.ExportSettings.EnableUserDataExport,
+		"user_data_export_interval_hours": *cfg.ExportSettings.UserDataExportIntervalHours,
 	}
 
 	configs[TrackConfigWrangler] = map[string]any{
@@ -1495,7 +1510,9 @@ func (ts *TelemetryService) trackPluginConfig(configs map[string]any, cfg *model
 		"enable_remote_marketplace":     *cfg.PluginSettings.EnableRemoteMarketplace,
 		"automatic_prepackaged_plugins": *cfg.PluginSettings.AutomaticPrepackagedPlugins,
 		"is_default_marketplace_url":    isDefault(*cfg.PluginSettings.MarketplaceURL, model.PluginSettingsDefaultMarketplaceURL),
+		"enable_marketplace_catalog":    *cfg.PluginSettings.MarketplaceCatalogPath != "",
 		"signature_public_key_files":    len(cfg.PluginSettings.SignaturePublicKeyFiles),
+		"revoked_signature_key_ids":     len(cfg.PluginSettings.RevokedSignatureKeyIds),
 		"chimera_oauth_proxy_url":       *cfg.PluginSettings.ChimeraOAuthProxyURL,
 	}
 
@@ -0,0 +1,166 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+// Package tracing creates the OpenTelemetry spans of the server and exports
+// them over OTLP. Spans are started through the global tracer provider, so
+// they cost next to nothing until a Provider is created from TracingSettings.
+package tracing
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
+	"go.opentelemetry.io/otel/trace/noop"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/public/shared/request"
+)
+
+const (
+	instrumentationName = "github.com/mattermost/mattermost/server/v8"
+	serviceName         = "mattermost-server"
+)
+
+// propagator is the format of the trace context exchanged with clients,
+// collectors and plugins.
+var propagator = propagation.TraceContext{}
+
+// Provider exports the spans to an OpenTelemetry collector.
+type Provider struct {
+	tp *sdktrace.TracerProvider
+}
+
+// NewProvider creates a provider exporting spans as configured by settings
+// and registers it globally. instanceID identifies this node of the cluster.
+func NewProvider(settings model.TracingSettings, instanceID string) (*Provider, error) {
+	opts := []otlptracehttp.Option{
+		otlptracehttp.WithEndpoint(*settings.OTLPEndpoint),
+	}
+	if *settings.Insecure {
+		opts = append(opts, otlptracehttp.WithInsecure())
+	}
+
+	// The exporter connects lazily, so an unreachable collector doesn't
+	// prevent the server from starting.
+	exporter, err := otlptracehttp.New(context.Background(), opts...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create OTLP exporter: %w", err)
+	}
+
+	ratio := sdktrace.TraceIDRatioBased(*settings.SampleRate)
+	tp := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(resource.NewSchemaless(
+			semconv.ServiceName(serviceName),
+			semconv.ServiceVersion(model.CurrentVersion),
+			semconv.ServiceInstanceID(instanceID),
+		)),
+		// Requests joining a trace started by a client are sampled like any
+		// other, so that clients can't force every request to be traced.
+		sdktrace.WithSampler(sdktrace.ParentBased(ratio, sdktrace.WithRemoteParentSampled(ratio))),
+	)
+
+	otel.SetTracerProvider(tp)
+	otel.SetTextMapPropagator(propagator)
+
+	return &Provider{tp: tp}, nil
+}
+
+// Shutdown unregisters the provider and exports the pending spans.
+func (p *Provider) Shutdown(ctx context.Context) error {
+	otel.SetTracerProvider(noop.NewTracerProvider())
+	return p.tp.Shutdown(ctx)
+}
+
+func tracer() trace.Tracer {
+	return otel.Tracer(instrumentationName)
+}
+
+// StartSpan starts a span, as a child of the span of ctx if there is one.
+func StartSpan(ctx context.Context, name string, attrs ...attribute.KeyValue) (context.Context, trace.Span) {
+	return tracer().Start(ctx, name, trace.WithAttributes(attrs...))
+}
+
+// StartChildSpan starts a span only if ctx is part of a recorded trace.
+// It's meant for code run both as part of requests and in the background,
+// such as the store, which would otherwise start a trace on every call.
+func StartChildSpan(ctx context.Context, name string, attrs ...attribute.KeyValue) (context.Context, trace.Span) {
+	if ctx == nil {
+		return ctx, trace.SpanFromContext(context.Background())
+	}
+	if parent := trace.SpanFromContext(ctx); !parent.IsRecording() {
+		return ctx, parent
+	}
+	return StartSpan(ctx, name, attrs...)
+}
+
+// StartRequestSpan is like StartChildSpan for a request.CTX, whose context
+// is replaced by the one holding the new span.
+func StartRequestSpan(rctx request.CTX, name string, attrs ...attribute.KeyValue) (request.CTX, trace.Span) {
+	if rctx == nil {
+		return rctx, trace.SpanFromContext(context.Background())
+	}
+	ctx, span := StartChildSpan(rctx.Context(), name, attrs...)
+	if !span.IsRecording() {
+		return rctx, span
+	}
+	return rctx.WithContext(ctx), span
+}
+
+// StartHTTPServerSpan starts the span of an incoming HTTP request, in the
+// trace given by its traceparent header if any.
+func StartHTTPServerSpan(ctx context.Context, r *http.Request, name string, attrs ...attribute.KeyValue) (context.Context, trace.Span) {
+	ctx = propagator.Extract(ctx, propagation.HeaderCarrier(r.Header))
+
+	attrs = append(attrs,
+		semconv.HTTPRequestMethodKey.String(r.Method),
+		semconv.URLPathKey.String(r.URL.Path),
+		semconv.UserAgentOriginalKey.String(r.UserAgent()),
+	)
+	return tracer().Start(ctx, name, trace.WithSpanKind(trace.SpanKindServer), trace.WithAttributes(attrs...))
+}
+
+// EndHTTPServerSpan ends the span of an HTTP request with its response status.
+func EndHTTPServerSpan(span trace.Span, statusCode int) {
+	if !span.IsRecording() {
+		span.End()
+		return
+	}
+
+	span.SetAttributes(semconv.HTTPResponseStatusCodeKey.Int(statusCode))
+	if statusCode >= http.StatusInternalServerError {
+		span.SetStatus(codes.Error, http.StatusText(statusCode))
+	}
+	span.End()
+}
+
+// EndSpan ends a span, marking it as failed if err isn't nil.
+func EndSpan(span trace.Span, err error) {
+	if err != nil && span.IsRecording() {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
+
+// TraceParent returns the W3C traceparent of the span of ctx, or an empty
+// string if it isn't part of a recorded trace.
+func TraceParent(ctx context.Context) string {
+	if ctx == nil || !trace.SpanFromContext(ctx).IsRecording() {
+		return ""
+	}
+
+	carrier := propagation.MapCarrier{}
+	propagator.Inject(ctx, carrier)
+	return carrier.Get("traceparent")
+}
@@ -0,0 +1,131 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package tracing
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+	"go.opentelemetry.io/otel/trace/noop"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/public/shared/request"
+)
+
+func setupRecorder(t *testing.T) *tracetest.SpanRecorder {
+	t.Helper()
+
+	recorder := tracetest.NewSpanRecorder()
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+	otel.SetTracerProvider(tp)
+	t.Cleanup(func() {
+		otel.SetTracerProvider(noop.NewTracerProvider())
+		require.NoError(t, tp.Shutdown(context.Background()))
+	})
+
+	return recorder
+}
+
+func TestNewProvider(t *testing.T) {
+	settings := model.TracingSettings{}
+	settings.SetDefaults()
+
+	provider, err := NewProvider(settings, model.NewId())
+	require.NoError(t, err)
+
+	// The span isn't ended before the shutdown, so nothing gets exported to
+	// the unreachable collector.
+	_, span := StartSpan(context.Background(), "test")
+	assert.True(t, span.IsRecording())
+
+	require.NoError(t, provider.Shutdown(context.Background()))
+	span.End()
+
+	_, span = StartSpan(context.Background(), "test")
+	assert.False(t, span.IsRecording())
+}
+
+func TestStartChildSpan(t *testing.T) {
+	recorder := setupRecorder(t)
+
+	t.Run("no parent", func(t *testing.T) {
+		ctx, span := StartChildSpan(context.Background(), "child")
+		assert.False(t, span.IsRecording())
+		assert.Equal(t, context.Background(), ctx)
+		span.End()
+		assert.Empty(t, recorder.Ended())
+	})
+
+	t.Run("with parent", func(t *testing.T) {
+		ctx, parent := StartSpan(context.Background(), "parent")
+		_, span := StartChildSpan(ctx, "child")
+		require.True(t, span.IsRecording())
+		EndSpan(span, errors.New("failure"))
+		parent.End()
+
+		ended := recorder.Ended()
+		require.Len(t, ended, 2)
+		assert.Equal(t, "child", ended[0].Name())
+		assert.Equal(t, ended[1].SpanContext().SpanID(), ended[0].Parent().SpanID())
+		assert.Equal(t, codes.Error, ended[0].Status().Code)
+		assert.Equal(t, "failure", ended[0].Status().Description)
+	})
+}
+
+func TestStartRequestSpan(t *testing.T) {
+	setupRecorder(t)
+
+	rctx := request.EmptyContext(nil)
+	newRctx, span := StartRequestSpan(rctx, "app:Test")
+	assert.False(t, span.IsRecording())
+	assert.Equal(t, rctx, newRctx)
+
+	ctx, parent := StartSpan(context.Background(), "parent")
+	defer parent.End()
+	newRctx, span = StartRequestSpan(rctx.WithContext(ctx), "app:Test")
+	defer span.End()
+	require.True(t, span.IsRecording())
+	assert.Equal(t, span.SpanContext(), trace.SpanFromContext(newRctx.Context()).SpanContext())
+}
+
+func TestHTTPServerSpan(t *testing.T) {
+	recorder := setupRecorder(t)
+
+	ctx, client := StartSpan(context.Background(), "client")
+	traceParent := TraceParent(ctx)
+	require.NotEmpty(t, traceParent)
+	client.End()
+
+	r := httptest.NewRequest(http.MethodGet, "/api/v4/users/me", nil)
+	r.Header.Set("traceparent", traceParent)
+
+	_, span := StartHTTPServerSpan(context.Background(), r, "web:getUser")
+	EndHTTPServerSpan(span, http.StatusInternalServerError)
+
+	ended := recorder.Ended()
+	require.Len(t, ended, 2)
+	assert.Equal(t, client.SpanContext().TraceID(), ended[1].SpanContext().TraceID())
+	assert.Equal(t, client.SpanContext().SpanID(), ended[1].Parent().SpanID())
+	assert.Equal(t, trace.SpanKindServer, ended[1].SpanKind())
+	assert.Equal(t, codes.Error, ended[1].Status().Code)
+}
+
+func TestTraceParent(t *testing.T) {
+	assert.Empty(t, TraceParent(context.Background()))
+
+	setupRecorder(t)
+	ctx, span := StartSpan(context.Background(), "test")
+	defer span.End()
+	assert.Regexp(t, `^00-[0-9a-f]{32}-[0-9a-f]{16}-01$`, TraceParent(ctx))
+}
@@ -16,9 +16,11 @@ const (
 // Audit & Certificates
 const (
 	AuditEventAddAuditLogCertificate    = "addAuditLogCertificate"    // add certificate for secure audit log transmission
+	AuditEventExportAuditRecords        = "exportAuditRecords"        // export stored audit records
 	AuditEventGetAudits                 = "getAudits"                 // get audit log entries
 	AuditEventGetUserAudits             = "getUserAudits"             // get audit log entries for specific user
 	AuditEventRemoveAuditLogCertificate = "removeAuditLogCertificate" // remove certificate used for audit log transmission
+	AuditEventSearchAuditRecords        = "searchAuditRecords"        // search stored audit records
 )
 
 // Bots
@@ -235,6 +237,7 @@ const (
 	AuditEventGetFirstAdminVisitMarketplaceStatus = "getFirstAdminVisitMarketplaceStatus" // get first admin visit status
 	AuditEventInstallMarketplacePlugin            = "installMarketplacePlugin"            // install plugin from official marketplace
 	AuditEventInstallPluginFromURL                = "installPluginFromURL"                // install plugin from external URL
+	AuditEventPublishMarketplaceCatalogPlugin     = "publishMarketplaceCatalogPlugin"     // publish plugin to local marketplace catalog
 	AuditEventRemovePlugin                        = "removePlugin"                        // delete plugin
 	AuditEventSetFirstAdminVisitMarketplaceStatus = "setFirstAdminVisitMarketplaceStatus" // set first admin visit status
 	AuditEventUploadPlugin                        = "uploadPlugin"                        // upload plugin file to server for installation
@@ -380,10 +383,13 @@ const (
 	AuditEventAttachDeviceId               = "attachDeviceId"               // attach device ID to user session for mobile app
 	AuditEventCreateUser                   = "createUser"                   // create user account
 	AuditEventCreateUserAccessToken        = "createUserAccessToken"        // create personal access token for user API access
+	AuditEventCreateUserDataExport         = "createUserDataExport"         // request an export of the data of a user
 	AuditEventDeleteUser                   = "deleteUser"                   // delete user account
 	AuditEventDemoteUserToGuest            = "demoteUserToGuest"            // demote regular user to guest account with limited permissions
 	AuditEventDisableUserAccessToken       = "disableUserAccessToken"       // disable user personal access token
+	AuditEventDownloadUserDataExport       = "downloadUserDataExport"       // download an export of the data of a user
 	AuditEventEnableUserAccessToken        = "enableUserAccessToken"        // enable user personal access token
+	AuditEventEraseUser                    = "eraseUser"                    // request the erasure of a user account
 	AuditEventExtendSessionExpiry          = "extendSessionExpiry"          // extend user session expiration time
 	AuditEventLocalDeleteUser              = "localDeleteUser"              // delete user locally
 	AuditEventLocalPermanentDeleteAllUsers = "localPermanentDeleteAllUsers" // permanently delete all users locally
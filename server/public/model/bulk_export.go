@@ -13,4 +13,8 @@ type BulkExportOpts struct {
 	IncludeArchivedChannels bool
 	IncludeRolesAndSchemes  bool
 	CreateArchive           bool
+	// Since, when non-zero, limits the export to the entities created,
+	// updated or deleted at or after this time in milliseconds. Deleted
+	// entities are exported as tombstone lines.
+	Since int64
 }
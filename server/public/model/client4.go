@@ -1917,6 +1917,88 @@ func (c *Client4) GetUserAudits(ctx context.Context, userId string, page int, pe
 	return audits, BuildResponse(r), nil
 }
 
+// CreateUserDataExport requests an export of the data of a user. Users
+// exporting their own data are rate limited.
+func (c *Client4) CreateUserDataExport(ctx context.Context, userId string) (*Job, *Response, error) {
+	r, err := c.DoAPIPost(ctx, c.userRoute(userId)+"/data_exports", "")
+	if err != nil {
+		return nil, BuildResponse(r), err
+	}
+	defer closeBody(r)
+
+	var job Job
+	if err := json.NewDecoder(r.Body).Decode(&job); err != nil {
+		return nil, BuildResponse(r), NewAppError("CreateUserDataExport", "api.unmarshal_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+	return &job, BuildResponse(r), nil
+}
+
+// GetUserDataExports returns the exports of the data of a user, newest first.
+func (c *Client4) GetUserDataExports(ctx context.Context, userId string) ([]*Job, *Response, error) {
+	r, err := c.DoAPIGet(ctx, c.userRoute(userId)+"/data_exports", "")
+	if err != nil {
+		return nil, BuildResponse(r), err
+	}
+	defer closeBody(r)
+
+	var jobs []*Job
+	if err := json.NewDecoder(r.Body).Decode(&jobs); err != nil {
+		return nil, BuildResponse(r), NewAppError("GetUserDataExports", "api.unmarshal_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+	return jobs, BuildResponse(r), nil
+}
+
+// DownloadUserDataExport downloads the zip archive of a successful export of
+// the data of a user.
+func (c *Client4) DownloadUserDataExport(ctx context.Context, userId, jobId string) ([]byte, *Response, error) {
+	r, err := c.DoAPIGet(ctx, c.userRoute(userId)+"/data_exports/"+jobId+"/download", "")
+	if err != nil {
+		return nil, BuildResponse(r), err
+	}
+	defer closeBody(r)
+
+	data, err := io.ReadAll(r.Body)
+	if err != nil {
+		return nil, BuildResponse(r), NewAppError("DownloadUserDataExport", "model.client.read_job_result_file.app_error", nil, "", r.StatusCode).Wrap(err)
+	}
+	return data, BuildResponse(r), nil
+}
+
+// EraseUser requests the erasure of a user, replacing it with a pseudonymous
+// tombstone account. When dryRun is set, the job only reports what it would
+// erase. Must be authenticated as a system admin.
+func (c *Client4) EraseUser(ctx context.Context, userId string, dryRun bool) (*Job, *Response, error) {
+	values := url.Values{}
+	values.Set("dry_run", strconv.FormatBool(dryRun))
+	r, err := c.DoAPIPost(ctx, c.userRoute(userId)+"/erase?"+values.Encode(), "")
+	if err != nil {
+		return nil, BuildResponse(r), err
+	}
+	defer closeBody(r)
+
+	var job Job
+	if err := json.NewDecoder(r.Body).Decode(&job); err != nil {
+		return nil, BuildResponse(r), NewAppError("EraseUser", "api.unmarshal_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+	return &job, BuildResponse(r), nil
+}
+
+// GetUserWebSocketConnections returns the diagnostic details of the websocket
+// connections of a user across the cluster. Must be authenticated as a system admin.
+func (c *Client4) GetUserWebSocketConnections(ctx context.Context, userId string) ([]*WebConnInfo, *Response, error) {
+	r, err := c.DoAPIGet(ctx, c.userRoute(userId)+"/websocket_connections", "")
+	if err != nil {
+		return nil, BuildResponse(r), err
+	}
+	defer closeBody(r)
+
+	var infos []*WebConnInfo
+	if err := json.NewDecoder(r.Body).Decode(&infos); err != nil {
+		return nil, BuildResponse(r), NewAppError("GetUserWebSocketConnections", "api.marshal_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+	return infos, BuildResponse(r), nil
+}
+
 // VerifyUserEmail will verify a user's email using the supplied token.
 func (c *Client4) VerifyUserEmail(ctx context.Context, token string) (*Response, error) {
 	requestBody := map[string]string{"token": token}
@@ -6081,6 +6163,46 @@ func (c *Client4) GetAudits(ctx context.Context, page int, perPage int, etag str
 	return audits, BuildResponse(r), nil
 }
 
+// SearchAuditRecords returns a page of the audit records saved in the database
+// matching the given options.
+func (c *Client4) SearchAuditRecords(ctx context.Context, opts AuditRecordSearchOptions) ([]*StoredAuditRecord, *Response, error) {
+	buf, err := json.Marshal(opts)
+	if err != nil {
+		return nil, nil, NewAppError("SearchAuditRecords", "api.marshal_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+	r, err := c.DoAPIPostBytes(ctx, "/audits/records/search", buf)
+	if err != nil {
+		return nil, BuildResponse(r), err
+	}
+	defer closeBody(r)
+
+	var records []*StoredAuditRecord
+	if err := json.NewDecoder(r.Body).Decode(&records); err != nil {
+		return nil, BuildResponse(r), NewAppError("SearchAuditRecords", "api.unmarshal_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+	return records, BuildResponse(r), nil
+}
+
+// ExportAuditRecords writes every audit record matching the given options to wr,
+// in the given format. The paging options are ignored.
+func (c *Client4) ExportAuditRecords(ctx context.Context, opts AuditRecordSearchOptions, format string, wr io.Writer) (int64, *Response, error) {
+	buf, err := json.Marshal(opts)
+	if err != nil {
+		return 0, nil, NewAppError("ExportAuditRecords", "api.marshal_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+	r, err := c.DoAPIPostBytes(ctx, "/audits/records/export?format="+url.QueryEscape(format), buf)
+	if err != nil {
+		return 0, BuildResponse(r), err
+	}
+	defer closeBody(r)
+
+	n, err := io.Copy(wr, r.Body)
+	if err != nil {
+		return n, BuildResponse(r), NewAppError("ExportAuditRecords", "model.client.copy.app_error", nil, "", r.StatusCode).Wrap(err)
+	}
+	return n, BuildResponse(r), nil
+}
+
 // Brand Section
 
 // GetBrandImage retrieves the previously uploaded brand image.
@@ -7703,6 +7825,47 @@ func (c *Client4) InstallMarketplacePlugin(ctx context.Context, request *Install
 	return &m, BuildResponse(r), nil
 }
 
+// PublishMarketplaceCatalogPlugin adds a signed plugin bundle to the marketplace catalog
+// configured on the server.
+func (c *Client4) PublishMarketplaceCatalogPlugin(ctx context.Context, file, signature io.Reader) (*Manifest, *Response, error) {
+	body := new(bytes.Buffer)
+	writer := multipart.NewWriter(body)
+
+	part, err := writer.CreateFormFile("plugin", "plugin.tar.gz")
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if _, err = io.Copy(part, file); err != nil {
+		return nil, nil, err
+	}
+
+	part, err = writer.CreateFormFile("signature", "plugin.tar.gz.sig")
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if _, err = io.Copy(part, signature); err != nil {
+		return nil, nil, err
+	}
+
+	if err = writer.Close(); err != nil {
+		return nil, nil, err
+	}
+
+	r, err := c.DoAPIRequestReader(ctx, http.MethodPost, c.APIURL+c.pluginsRoute()+"/marketplace/catalog", body, map[string]string{"Content-Type": writer.FormDataContentType()})
+	if err != nil {
+		return nil, BuildResponse(r), err
+	}
+	defer closeBody(r)
+
+	var m Manifest
+	if err := json.NewDecoder(r.Body).Decode(&m); err != nil {
+		return nil, nil, NewAppError("PublishMarketplaceCatalogPlugin", "api.unmarshal_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+	return &m, BuildResponse(r), nil
+}
+
 // ReattachPlugin asks the server to reattach to a plugin launched by another process.
 //
 // Only available in local mode, and currently only used for testing.
@@ -7763,6 +7926,21 @@ func (c *Client4) GetPluginStatuses(ctx context.Context) (PluginStatuses, *Respo
 	return list, BuildResponse(r), nil
 }
 
+// GetPluginSignatureReports will return the signing status of the plugins installed on the server
+// handling the request.
+func (c *Client4) GetPluginSignatureReports(ctx context.Context) ([]*PluginSignatureReport, *Response, error) {
+	r, err := c.DoAPIGet(ctx, c.pluginsRoute()+"/signatures", "")
+	if err != nil {
+		return nil, BuildResponse(r), err
+	}
+	defer closeBody(r)
+	var list []*PluginSignatureReport
+	if err := json.NewDecoder(r.Body).Decode(&list); err != nil {
+		return nil, nil, NewAppError("GetPluginSignatureReports", "api.unmarshal_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+	return list, BuildResponse(r), nil
+}
+
 // RemovePlugin will disable and delete a plugin.
 func (c *Client4) RemovePlugin(ctx context.Context, id string) (*Response, error) {
 	r, err := c.DoAPIDelete(ctx, c.pluginRoute(id))
@@ -39,9 +39,11 @@ const (
 	ClusterEventClearSessionCacheForAllUsers                ClusterEvent = "inv_all_user_sessions"
 	ClusterEventInstallPlugin                               ClusterEvent = "install_plugin"
 	ClusterEventRemovePlugin                                ClusterEvent = "remove_plugin"
+	ClusterEventDeactivatePlugin                            ClusterEvent = "deactivate_plugin"
 	ClusterEventPluginEvent                                 ClusterEvent = "plugin_event"
 	ClusterEventInvalidateCacheForTermsOfService            ClusterEvent = "inv_terms_of_service"
 	ClusterEventBusyStateChanged                            ClusterEvent = "busy_state_change"
+	ClusterEventSSERequest                                  ClusterEvent = "sse_request"
 	// Note: if you are adding a new event, please also add it in the slice of
 	// m.ClusterEventMap in metrics/metrics.go file.
 
@@ -60,6 +62,8 @@ const (
 	ClusterGossipEventResponseWebConnCount          = "gossip_response_webconn_count"
 	ClusterGossipEventRequestWSQueues               = "gossip_request_ws_queues"
 	ClusterGossipEventResponseWSQueues              = "gossip_response_ws_queues"
+	ClusterGossipEventRequestWebConnInfos           = "gossip_request_webconn_infos"
+	ClusterGossipEventResponseWebConnInfos          = "gossip_response_webconn_infos"
 
 	// SendTypes for ClusterMessage.
 	ClusterSendBestEffort = "best_effort"
@@ -635,6 +635,46 @@ func TestMessageExportSettingsIsValidActiance(t *testing.T) {
 	require.Nil(t, mes.isValid())
 }
 
+func TestMessageExportSettingsIsValidEmlMboxJsonl(t *testing.T) {
+	for _, exportFormat := range []string{ComplianceExportTypeEml, ComplianceExportTypeMbox, ComplianceExportTypeJsonl} {
+		mes := &MessageExportSettings{
+			EnableExport:        NewPointer(true),
+			ExportFormat:        NewPointer(exportFormat),
+			ExportFromTimestamp: NewPointer(int64(0)),
+			DailyRunTime:        NewPointer("15:04"),
+			BatchSize:           NewPointer(100),
+		}
+
+		// should pass because everything is valid
+		require.Nil(t, mes.isValid(), exportFormat)
+	}
+
+	mes := &MessageExportSettings{
+		EnableExport:        NewPointer(true),
+		ExportFormat:        NewPointer("pst"),
+		ExportFromTimestamp: NewPointer(int64(0)),
+		DailyRunTime:        NewPointer("15:04"),
+		BatchSize:           NewPointer(100),
+	}
+
+	// should fail because the export format is unknown
+	require.NotNil(t, mes.isValid())
+}
+
+func TestExportSettingsIsValidUserDataExportInterval(t *testing.T) {
+	es := &ExportSettings{}
+	es.SetDefaults()
+	require.Nil(t, es.isValid())
+	require.True(t, *es.EnableUserDataExport)
+	require.Equal(t, ExportSettingsDefaultUserDataExportIntervalHours, *es.UserDataExportIntervalHours)
+
+	es.UserDataExportIntervalHours = NewPointer(0)
+	require.Nil(t, es.isValid())
+
+	es.UserDataExportIntervalHours = NewPointer(-1)
+	require.NotNil(t, es.isValid())
+}
+
 func TestMessageExportSettingsIsValidGlobalRelaySettingsMissing(t *testing.T) {
 	mes := &MessageExportSettings{
 		EnableExport:        NewPointer(true),
@@ -1750,6 +1790,61 @@ func TestSanitizeDataSource(t *testing.T) {
 			assert.Equal(t, tc.Sanitized, out)
 		}
 	})
+
+	t.Run(DatabaseDriverSqlite, func(t *testing.T) {
+		out, err := SanitizeDataSource(DatabaseDriverSqlite, "file:/var/lib/mattermost/mattermost.db?_pragma=busy_timeout(5000)")
+		require.NoError(t, err)
+		assert.Equal(t, "file:/var/lib/mattermost/mattermost.db?_pragma=busy_timeout(5000)", out)
+	})
+}
+
+func TestSqlSettingsIsValidSqlite(t *testing.T) {
+	s := SqlSettings{DriverName: NewPointer(DatabaseDriverSqlite), DataSource: NewPointer("mattermost.db")}
+	s.SetDefaults(false)
+	require.Nil(t, s.isValid())
+
+	s.DataSourceReplicas = []string{"replica.db"}
+	appErr := s.isValid()
+	require.NotNil(t, appErr)
+	assert.Equal(t, "model.config.is_valid.sql_sqlite_replicas.app_error", appErr.Id)
+}
+
+func TestSqlSettingsIsValidReadYourWritesWindow(t *testing.T) {
+	s := SqlSettings{}
+	s.SetDefaults(false)
+	assert.Equal(t, 5000, *s.ReadYourWritesWindowMilliseconds)
+
+	s.ReadYourWritesWindowMilliseconds = NewPointer(0)
+	require.Nil(t, s.isValid())
+
+	s.ReadYourWritesWindowMilliseconds = NewPointer(-1)
+	appErr := s.isValid()
+	require.NotNil(t, appErr)
+	assert.Equal(t, "model.config.is_valid.sql_read_your_writes_window.app_error", appErr.Id)
+}
+
+func TestSqlSettingsIsValidPartitionArchive(t *testing.T) {
+	s := SqlSettings{}
+	s.SetDefaults(false)
+	assert.Equal(t, 0, *s.PartitionArchiveAfterDays)
+	assert.Equal(t, "archive", *s.PartitionArchiveSchema)
+	require.Nil(t, s.isValid())
+
+	s.PartitionArchiveAfterDays = NewPointer(-1)
+	appErr := s.isValid()
+	require.NotNil(t, appErr)
+	assert.Equal(t, "model.config.is_valid.sql_partition_archive_after_days.app_error", appErr.Id)
+
+	s.PartitionArchiveAfterDays = NewPointer(365)
+	for _, schema := range []string{"", "Archive", "1archive", "archive; DROP TABLE Posts", "archive.posts"} {
+		s.PartitionArchiveSchema = NewPointer(schema)
+		appErr = s.isValid()
+		require.NotNil(t, appErr, schema)
+		assert.Equal(t, "model.config.is_valid.sql_partition_archive_schema.app_error", appErr.Id)
+	}
+
+	s.PartitionArchiveSchema = NewPointer("posts_archive")
+	require.Nil(t, s.isValid())
 }
 
 func TestConfigFilteredByTag(t *testing.T) {
@@ -2252,6 +2347,44 @@ func TestExperimentalAuditSettingsIsValid(t *testing.T) {
 			},
 			ExpectError: true,
 		},
+		"hash chain enabled with valid settings": {
+			ExperimentalAuditSettings: ExperimentalAuditSettings{
+				HashChainEnabled:        NewPointer(true),
+				HashChainFileName:       NewPointer("audit_chain.log"),
+				HashChainSigningKeyFile: NewPointer("audit_chain.key"),
+			},
+			ExpectError: false,
+		},
+		"hash chain enabled with empty filename": {
+			ExperimentalAuditSettings: ExperimentalAuditSettings{
+				HashChainEnabled:        NewPointer(true),
+				HashChainSigningKeyFile: NewPointer("audit_chain.key"),
+			},
+			ExpectError: true,
+		},
+		"hash chain enabled without signing key": {
+			ExperimentalAuditSettings: ExperimentalAuditSettings{
+				HashChainEnabled:  NewPointer(true),
+				HashChainFileName: NewPointer("audit_chain.log"),
+			},
+			ExpectError: true,
+		},
+		"negative database retention days": {
+			ExperimentalAuditSettings: ExperimentalAuditSettings{
+				DatabaseEnabled:       NewPointer(true),
+				DatabaseRetentionDays: NewPointer(-1),
+			},
+			ExpectError: true,
+		},
+		"zero hash chain checkpoint interval": {
+			ExperimentalAuditSettings: ExperimentalAuditSettings{
+				HashChainEnabled:            NewPointer(true),
+				HashChainFileName:           NewPointer("audit_chain.log"),
+				HashChainSigningKeyFile:     NewPointer("audit_chain.key"),
+				HashChainCheckpointInterval: NewPointer(0),
+			},
+			ExpectError: true,
+		},
 		"AdvancedLoggingJSON has JSON error ": {
 			ExperimentalAuditSettings: ExperimentalAuditSettings{
 				AdvancedLoggingJSON: json.RawMessage(`
@@ -2275,6 +2408,51 @@ func TestExperimentalAuditSettingsIsValid(t *testing.T) {
 	}
 }
 
+func TestTracingSettingsIsValid(t *testing.T) {
+	t.Parallel()
+
+	for name, test := range map[string]struct {
+		TracingSettings TracingSettings
+		ExpectError     bool
+	}{
+		"defaults": {
+			TracingSettings: TracingSettings{},
+			ExpectError:     false,
+		},
+		"enabled with default endpoint": {
+			TracingSettings: TracingSettings{Enable: NewPointer(true)},
+			ExpectError:     false,
+		},
+		"enabled with endpoint missing the port": {
+			TracingSettings: TracingSettings{Enable: NewPointer(true), OTLPEndpoint: NewPointer("collector")},
+			ExpectError:     true,
+		},
+		"disabled with invalid endpoint": {
+			TracingSettings: TracingSettings{OTLPEndpoint: NewPointer("collector")},
+			ExpectError:     false,
+		},
+		"negative sample rate": {
+			TracingSettings: TracingSettings{SampleRate: NewPointer(-0.1)},
+			ExpectError:     true,
+		},
+		"sample rate above one": {
+			TracingSettings: TracingSettings{SampleRate: NewPointer(1.5)},
+			ExpectError:     true,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			test.TracingSettings.SetDefaults()
+
+			appErr := test.TracingSettings.isValid()
+			if test.ExpectError {
+				require.NotNil(t, appErr)
+			} else {
+				require.Nil(t, appErr)
+			}
+		})
+	}
+}
+
 func TestFilterConfig(t *testing.T) {
 	t.Run("should clear default values", func(t *testing.T) {
 		cfg := &Config{}
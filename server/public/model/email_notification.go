@@ -0,0 +1,22 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+const (
+	EmailNotificationTypeImmediate = "immediate"
+	EmailNotificationTypeBatched   = "batched"
+)
+
+// EmailNotification is an email notifying a user about new posts, as seen by the
+// EmailNotificationWillBeSent plugin hook. TemplateData holds the text values the
+// body was rendered with, such as the title and the button URL.
+type EmailNotification struct {
+	Type         string            `json:"type"`
+	RecipientId  string            `json:"recipient_id"`
+	To           string            `json:"to"`
+	Subject      string            `json:"subject"`
+	Body         string            `json:"body"`
+	TemplateData map[string]string `json:"template_data"`
+	PostIds      []string          `json:"post_ids"`
+}
@@ -45,6 +45,9 @@ const (
 	JobTypeDeleteDmsPreferencesMigration = "delete_dms_preferences_migration"
 	JobTypeMobileSessionMetadata         = "mobile_session_metadata"
 	JobTypeAccessControlSync             = "access_control_sync"
+	JobTypeUserDataExport                = "user_data_export"
+	JobTypeUserErasure                   = "user_erasure"
+	JobTypePartitionMaintenance          = "partition_maintenance"
 
 	JobStatusPending         = "pending"
 	JobStatusInProgress      = "in_progress"
@@ -78,6 +81,7 @@ var AllJobTypes = [...]string{
 	JobTypeCleanupDesktopTokens,
 	JobTypeRefreshMaterializedViews,
 	JobTypeMobileSessionMetadata,
+	JobTypePartitionMaintenance,
 }
 
 type Job struct {
@@ -230,7 +234,7 @@ func IsValidJobType(jobType string) bool {
 		}
 	}
 
-	return false
+	return IsPluginJobType(jobType)
 }
 
 func (j *Job) LogClone() any {
@@ -194,7 +194,7 @@ func TestJobIsValidStatusChange(t *testing.T) {
 
 func TestIsValidJobType(t *testing.T) {
 	t.Run("valid", func(t *testing.T) {
-		validTypes := []string{JobTypeExportProcess, JobTypeImportProcess}
+		validTypes := []string{JobTypeExportProcess, JobTypeImportProcess, PluginJobType("com.example.plugin", "sync")}
 		for _, jobType := range validTypes {
 			t.Run(jobType, func(t *testing.T) {
 				require.True(t, IsValidJobType(jobType))
@@ -203,7 +203,7 @@ func TestIsValidJobType(t *testing.T) {
 	})
 
 	t.Run("invalid", func(t *testing.T) {
-		invalidTypes := []string{"invalid!", ""}
+		invalidTypes := []string{"invalid!", "", "plugin:", "plugin:com.example.plugin", "plugin:com.example.plugin:Sync"}
 		for _, jobType := range invalidTypes {
 			t.Run(jobType, func(t *testing.T) {
 				require.False(t, IsValidJobType(jobType))
@@ -9,6 +9,7 @@ import (
 	"io"
 	"os"
 	"path/filepath"
+	"slices"
 	"strings"
 
 	"github.com/blang/semver/v4"
@@ -209,6 +210,26 @@ type Manifest struct {
 	// Minimum server version: 5.6
 	MinServerVersion string `json:"min_server_version,omitempty" yaml:"min_server_version,omitempty"`
 
+	// Dependencies lists the other plugins your plugin requires, such as plugins it calls through
+	// PluginHTTP. Dependencies are activated before your plugin, which is only activated once all
+	// of them are running.
+	//
+	// Minimum server version: 10.12
+	Dependencies []*ManifestDependency `json:"dependencies,omitempty" yaml:"dependencies,omitempty"`
+
+	// RequiredCapabilities lists the server features your plugin requires, such as "wasm_runtime".
+	// The plugin can't be installed on a server lacking any of them.
+	//
+	// Minimum server version: 10.12
+	RequiredCapabilities []string `json:"required_capabilities,omitempty" yaml:"required_capabilities,omitempty"`
+
+	// RequiredPermissions lists the ids of the permissions your plugin relies on, for plugins
+	// checking permissions introduced by recent server versions. The plugin can't be installed on
+	// a server not defining any of them.
+	//
+	// Minimum server version: 10.12
+	RequiredPermissions []string `json:"required_permissions,omitempty" yaml:"required_permissions,omitempty"`
+
 	// Server defines the server-side portion of your plugin.
 	Server *ManifestServer `json:"server,omitempty" yaml:"server,omitempty"`
 
@@ -236,6 +257,178 @@ type ManifestServer struct {
 	// If your plugin is compiled for multiple platforms, consider bundling them together
 	// and using the Executables field instead.
 	Executable string `json:"executable" yaml:"executable"`
+
+	// Wasm configures the sandbox used when Executable is a WebAssembly module (a ".wasm" file)
+	// instead of a native binary. WebAssembly plugins run in-process with no filesystem or network
+	// access, and only have access to the API methods allowed by the declared capabilities.
+	//
+	// Minimum server version: 10.12
+	Wasm *ManifestServerWasm `json:"wasm,omitempty" yaml:"wasm,omitempty"`
+
+	// Migrations is the path to the directory holding your database migrations, relative to the
+	// root of your bundle. It holds a subdirectory per database driver ("postgres", "mysql" and
+	// "sqlite") with files named like "000001_create_items.up.sql" and
+	// "000001_create_items.down.sql".
+	//
+	// The migrations are applied when the plugin is activated, and may only create and modify
+	// the tables and indexes whose names start with PluginTablePrefix(manifest.Id). When an older
+	// version of the plugin is installed, the migrations it doesn't ship are rolled back.
+	//
+	// Minimum server version: 10.12
+	Migrations string `json:"migrations,omitempty" yaml:"migrations,omitempty"`
+}
+
+const (
+	// PluginWasmCapabilityKV allows reading and writing the plugin's own key-value store.
+	PluginWasmCapabilityKV = "kv"
+	// PluginWasmCapabilityPostsRead allows reading posts.
+	PluginWasmCapabilityPostsRead = "posts:read"
+	// PluginWasmCapabilityPostsWrite allows creating and updating posts.
+	PluginWasmCapabilityPostsWrite = "posts:write"
+	// PluginWasmCapabilityUsersRead allows reading users.
+	PluginWasmCapabilityUsersRead = "users:read"
+	// PluginWasmCapabilityChannelsRead allows reading channels.
+	PluginWasmCapabilityChannelsRead = "channels:read"
+	// PluginWasmCapabilityConfigRead allows reading the plugin's configuration.
+	PluginWasmCapabilityConfigRead = "config:read"
+
+	// PluginWasmMaxMemoryLimitMB is the largest memory limit a WebAssembly plugin may request.
+	PluginWasmMaxMemoryLimitMB = 1024
+	// PluginWasmMaxHookTimeoutMs is the largest per-call CPU time limit a WebAssembly plugin may request.
+	PluginWasmMaxHookTimeoutMs = 60000
+)
+
+var pluginWasmCapabilities = map[string]bool{
+	PluginWasmCapabilityKV:           true,
+	PluginWasmCapabilityPostsRead:    true,
+	PluginWasmCapabilityPostsWrite:   true,
+	PluginWasmCapabilityUsersRead:    true,
+	PluginWasmCapabilityChannelsRead: true,
+	PluginWasmCapabilityConfigRead:   true,
+}
+
+type ManifestServerWasm struct {
+	// MemoryLimitMB is the maximum amount of linear memory the module may allocate.
+	// Defaults to 64 MB when unset.
+	MemoryLimitMB int `json:"memory_limit_mb,omitempty" yaml:"memory_limit_mb,omitempty"`
+
+	// HookTimeoutMs is the maximum time a single hook invocation may run before the module is
+	// terminated. Defaults to 5000 ms when unset.
+	HookTimeoutMs int `json:"hook_timeout_ms,omitempty" yaml:"hook_timeout_ms,omitempty"`
+
+	// Capabilities lists the groups of API methods the module is allowed to call, such as "kv"
+	// or "posts:read". Logging is always available.
+	Capabilities []string `json:"capabilities,omitempty" yaml:"capabilities,omitempty"`
+}
+
+// HasCapability returns true if the module declared the given capability.
+func (w *ManifestServerWasm) HasCapability(capability string) bool {
+	if w == nil {
+		return false
+	}
+	return slices.Contains(w.Capabilities, capability)
+}
+
+func (w *ManifestServerWasm) isValid() error {
+	if w.MemoryLimitMB < 0 || w.MemoryLimitMB > PluginWasmMaxMemoryLimitMB {
+		return fmt.Errorf("memory_limit_mb must be between 0 and %d", PluginWasmMaxMemoryLimitMB)
+	}
+
+	if w.HookTimeoutMs < 0 || w.HookTimeoutMs > PluginWasmMaxHookTimeoutMs {
+		return fmt.Errorf("hook_timeout_ms must be between 0 and %d", PluginWasmMaxHookTimeoutMs)
+	}
+
+	for _, capability := range w.Capabilities {
+		if !pluginWasmCapabilities[capability] {
+			return fmt.Errorf("unknown capability: %s", capability)
+		}
+	}
+
+	return nil
+}
+
+// ManifestDependency is a plugin required by another plugin.
+type ManifestDependency struct {
+	// Id is the id of the required plugin.
+	Id string `json:"id" yaml:"id"`
+
+	// Version is the semver range the version of the required plugin must be in, such as
+	// ">=1.2.0 <2.0.0". Any version is accepted when unset.
+	Version string `json:"version,omitempty" yaml:"version,omitempty"`
+}
+
+// SatisfiedBy returns true if the given plugin version is in the range of the dependency.
+func (d *ManifestDependency) SatisfiedBy(version string) bool {
+	if d.Version == "" {
+		return true
+	}
+
+	versionRange, err := semver.ParseRange(d.Version)
+	if err != nil {
+		return false
+	}
+
+	v, err := semver.Parse(version)
+	if err != nil {
+		return false
+	}
+
+	return versionRange(v)
+}
+
+func (d *ManifestDependency) String() string {
+	if d.Version == "" {
+		return d.Id
+	}
+	return d.Id + " " + d.Version
+}
+
+func (d *ManifestDependency) isValid() error {
+	if !IsValidPluginId(d.Id) {
+		return fmt.Errorf("invalid plugin ID: %s", d.Id)
+	}
+
+	if d.Version != "" {
+		if _, err := semver.ParseRange(d.Version); err != nil {
+			return errors.Wrapf(err, "failed to parse version range of %s", d.Id)
+		}
+	}
+
+	return nil
+}
+
+const (
+	// PluginServerCapabilityWasmRuntime is the support for WebAssembly server plugins.
+	PluginServerCapabilityWasmRuntime = "wasm_runtime"
+	// PluginServerCapabilityResourceLimits is the support for per-plugin resource limits.
+	PluginServerCapabilityResourceLimits = "resource_limits"
+	// PluginServerCapabilityLifecycleVetoHooks is the support for the hooks allowing plugins to
+	// reject the creation, update or deletion of channels, teams and users.
+	PluginServerCapabilityLifecycleVetoHooks = "lifecycle_veto_hooks"
+	// PluginServerCapabilitySearchHooks is the support for the SearchWillBeExecuted and
+	// SearchResultsProvided hooks.
+	PluginServerCapabilitySearchHooks = "search_hooks"
+	// PluginServerCapabilityEmailNotificationHook is the support for the
+	// EmailNotificationWillBeSent hook.
+	PluginServerCapabilityEmailNotificationHook = "email_notification_hook"
+	// PluginServerCapabilityDependencies is the support for plugin dependencies.
+	PluginServerCapabilityDependencies = "plugin_dependencies"
+	// PluginServerCapabilityJobs is the support for job types registered by plugins.
+	PluginServerCapabilityJobs = "plugin_jobs"
+	// PluginServerCapabilityMigrations is the support for database migrations shipped by plugins.
+	PluginServerCapabilityMigrations = "plugin_migrations"
+)
+
+// PluginServerCapabilities are the server features plugins may list in their required capabilities.
+var PluginServerCapabilities = []string{
+	PluginServerCapabilityWasmRuntime,
+	PluginServerCapabilityResourceLimits,
+	PluginServerCapabilityLifecycleVetoHooks,
+	PluginServerCapabilitySearchHooks,
+	PluginServerCapabilityEmailNotificationHook,
+	PluginServerCapabilityDependencies,
+	PluginServerCapabilityJobs,
+	PluginServerCapabilityMigrations,
 }
 
 type ManifestWebapp struct {
@@ -294,6 +487,16 @@ func (m *Manifest) HasServer() bool {
 	return m.Server != nil
 }
 
+// HasServerMigrations returns true if the plugin ships database migrations.
+func (m *Manifest) HasServerMigrations() bool {
+	return m.HasServer() && m.Server.Migrations != ""
+}
+
+// HasWasmServer returns true if the server-side portion of the plugin is a WebAssembly module.
+func (m *Manifest) HasWasmServer() bool {
+	return m.Server != nil && strings.HasSuffix(strings.ToLower(m.Server.Executable), ".wasm")
+}
+
 func (m *Manifest) HasWebapp() bool {
 	return m.Webapp != nil
 }
@@ -310,6 +513,43 @@ func (m *Manifest) MeetMinServerVersion(serverVersion string) (bool, error) {
 	return true, nil
 }
 
+// UnmetServerRequirements returns a description of each capability and permission required by the
+// plugin that the server doesn't provide.
+func (m *Manifest) UnmetServerRequirements() []string {
+	var unmet []string
+
+	for _, capability := range m.RequiredCapabilities {
+		if !slices.Contains(PluginServerCapabilities, capability) {
+			unmet = append(unmet, fmt.Sprintf("requires the unsupported server capability %s", capability))
+		}
+	}
+
+	for _, permissionID := range m.RequiredPermissions {
+		if !slices.ContainsFunc(AllPermissions, func(p *Permission) bool { return p.Id == permissionID }) {
+			unmet = append(unmet, fmt.Sprintf("requires the unknown permission %s", permissionID))
+		}
+	}
+
+	return unmet
+}
+
+// UnmetDependencies returns a description of each dependency of the plugin not satisfied by the
+// given installed plugins.
+func (m *Manifest) UnmetDependencies(installed []*Manifest) []string {
+	var unmet []string
+
+	for _, dependency := range m.Dependencies {
+		i := slices.IndexFunc(installed, func(manifest *Manifest) bool { return manifest != nil && manifest.Id == dependency.Id })
+		if i == -1 {
+			unmet = append(unmet, fmt.Sprintf("requires plugin %s, which is not installed", dependency))
+		} else if !dependency.SatisfiedBy(installed[i].Version) {
+			unmet = append(unmet, fmt.Sprintf("requires plugin %s, but version %s is installed", dependency, installed[i].Version))
+		}
+	}
+
+	return unmet
+}
+
 func (m *Manifest) IsValid() error {
 	if !IsValidPluginId(m.Id) {
 		return errors.New("invalid plugin ID")
@@ -345,6 +585,33 @@ func (m *Manifest) IsValid() error {
 		}
 	}
 
+	for i, dependency := range m.Dependencies {
+		if dependency == nil {
+			return errors.New("invalid empty dependency")
+		}
+		if err := dependency.isValid(); err != nil {
+			return errors.Wrap(err, "invalid dependency")
+		}
+		if dependency.Id == m.Id {
+			return errors.New("a plugin can't depend on itself")
+		}
+		if slices.ContainsFunc(m.Dependencies[:i], func(d *ManifestDependency) bool { return d != nil && d.Id == dependency.Id }) {
+			return fmt.Errorf("duplicate dependency: %s", dependency.Id)
+		}
+	}
+
+	if m.HasServerMigrations() {
+		if p := filepath.ToSlash(filepath.Clean(m.Server.Migrations)); filepath.IsAbs(m.Server.Migrations) || p == ".." || strings.HasPrefix(p, "../") {
+			return errors.New("the migrations directory must be inside the bundle")
+		}
+	}
+
+	if m.Server != nil && m.Server.Wasm != nil {
+		if err := m.Server.Wasm.isValid(); err != nil {
+			return errors.Wrap(err, "invalid wasm settings")
+		}
+	}
+
 	if m.SettingsSchema != nil {
 		err := m.SettingsSchema.isValid()
 		if err != nil {
@@ -31,6 +31,18 @@ func TestIsValid(t *testing.T) {
 		{"SettingSchema error", &Manifest{Id: "com.company.test", Name: "some name", HomepageURL: "http://someurl.com", SupportURL: "http://someotherurl.com", Version: "5.10.0", MinServerVersion: "5.10.8", SettingsSchema: &PluginSettingsSchema{
 			Settings: []*PluginSetting{{Type: "Invalid"}},
 		}}, true},
+		{"Invalid wasm memory limit", &Manifest{Id: "com.company.test", Name: "some name", Server: &ManifestServer{Executable: "plugin.wasm", Wasm: &ManifestServerWasm{MemoryLimitMB: PluginWasmMaxMemoryLimitMB + 1}}}, true},
+		{"Invalid wasm hook timeout", &Manifest{Id: "com.company.test", Name: "some name", Server: &ManifestServer{Executable: "plugin.wasm", Wasm: &ManifestServerWasm{HookTimeoutMs: -1}}}, true},
+		{"Invalid wasm capability", &Manifest{Id: "com.company.test", Name: "some name", Server: &ManifestServer{Executable: "plugin.wasm", Wasm: &ManifestServerWasm{Capabilities: []string{"filesystem"}}}}, true},
+		{"Valid wasm settings", &Manifest{Id: "com.company.test", Name: "some name", Server: &ManifestServer{Executable: "plugin.wasm", Wasm: &ManifestServerWasm{MemoryLimitMB: 32, HookTimeoutMs: 1000, Capabilities: []string{PluginWasmCapabilityKV}}}}, false},
+		{"Invalid dependency id", &Manifest{Id: "com.company.test", Name: "some name", Dependencies: []*ManifestDependency{{Id: "some id"}}}, true},
+		{"Invalid dependency version range", &Manifest{Id: "com.company.test", Name: "some name", Dependencies: []*ManifestDependency{{Id: "com.company.other", Version: "latest"}}}, true},
+		{"Dependency on itself", &Manifest{Id: "com.company.test", Name: "some name", Dependencies: []*ManifestDependency{{Id: "com.company.test"}}}, true},
+		{"Duplicate dependency", &Manifest{Id: "com.company.test", Name: "some name", Dependencies: []*ManifestDependency{{Id: "com.company.other"}, {Id: "com.company.other", Version: ">=1.0.0"}}}, true},
+		{"Valid dependencies", &Manifest{Id: "com.company.test", Name: "some name", Dependencies: []*ManifestDependency{{Id: "com.company.other", Version: ">=1.2.0 <2.0.0"}, {Id: "com.company.another"}}}, false},
+		{"Absolute migrations directory", &Manifest{Id: "com.company.test", Name: "some name", Server: &ManifestServer{Executable: "plugin", Migrations: "/migrations"}}, true},
+		{"Migrations directory outside the bundle", &Manifest{Id: "com.company.test", Name: "some name", Server: &ManifestServer{Executable: "plugin", Migrations: "server/../../migrations"}}, true},
+		{"Valid migrations directory", &Manifest{Id: "com.company.test", Name: "some name", Server: &ManifestServer{Executable: "plugin", Migrations: "server/migrations"}}, false},
 		{"Minimal valid manifest", &Manifest{Id: "com.company.test", Name: "some name"}, false},
 		{"Happy case", &Manifest{
 			Id:               "com.company.test",
@@ -823,6 +835,13 @@ func TestManifestHasServer(t *testing.T) {
 	}
 }
 
+func TestManifestHasWasmServer(t *testing.T) {
+	assert.False(t, (&Manifest{}).HasWasmServer())
+	assert.False(t, (&Manifest{Server: &ManifestServer{Executable: "server/dist/plugin-linux-amd64"}}).HasWasmServer())
+	assert.True(t, (&Manifest{Server: &ManifestServer{Executable: "server/dist/plugin.wasm"}}).HasWasmServer())
+	assert.True(t, (&Manifest{Server: &ManifestServer{Executable: "server/dist/plugin.WASM"}}).HasWasmServer())
+}
+
 func TestManifestHasWebapp(t *testing.T) {
 	testCases := []struct {
 		Description string
@@ -908,3 +927,65 @@ func TestManifestMeetMinServerVersion(t *testing.T) {
 		})
 	}
 }
+
+func TestManifestDependencySatisfiedBy(t *testing.T) {
+	for name, test := range map[string]struct {
+		VersionRange string
+		Version      string
+		Expected     bool
+	}{
+		"any version":              {VersionRange: "", Version: "0.1.0", Expected: true},
+		"any version, unversioned": {VersionRange: "", Version: "", Expected: true},
+		"in range":                 {VersionRange: ">=1.2.0 <2.0.0", Version: "1.5.3", Expected: true},
+		"lower bound":              {VersionRange: ">=1.2.0 <2.0.0", Version: "1.2.0", Expected: true},
+		"below range":              {VersionRange: ">=1.2.0 <2.0.0", Version: "1.1.9", Expected: false},
+		"above range":              {VersionRange: ">=1.2.0 <2.0.0", Version: "2.0.0", Expected: false},
+		"alternative ranges":       {VersionRange: "<1.0.0 || >=3.0.0", Version: "3.1.0", Expected: true},
+		"unparsable version":       {VersionRange: ">=1.0.0", Version: "latest", Expected: false},
+	} {
+		t.Run(name, func(t *testing.T) {
+			dependency := &ManifestDependency{Id: "com.company.other", Version: test.VersionRange}
+			assert.Equal(t, test.Expected, dependency.SatisfiedBy(test.Version))
+		})
+	}
+}
+
+func TestManifestUnmetServerRequirements(t *testing.T) {
+	manifest := &Manifest{
+		Id:                   "com.company.test",
+		RequiredCapabilities: []string{PluginServerCapabilityWasmRuntime, "teleportation"},
+		RequiredPermissions:  []string{PermissionCreatePost.Id, "teleport_users"},
+	}
+	assert.Equal(t, []string{
+		"requires the unsupported server capability teleportation",
+		"requires the unknown permission teleport_users",
+	}, manifest.UnmetServerRequirements())
+
+	manifest.RequiredCapabilities = manifest.RequiredCapabilities[:1]
+	manifest.RequiredPermissions = manifest.RequiredPermissions[:1]
+	assert.Empty(t, manifest.UnmetServerRequirements())
+}
+
+func TestManifestUnmetDependencies(t *testing.T) {
+	manifest := &Manifest{
+		Id: "com.company.test",
+		Dependencies: []*ManifestDependency{
+			{Id: "com.company.any"},
+			{Id: "com.company.ranged", Version: ">=1.2.0"},
+			{Id: "com.company.missing"},
+		},
+	}
+
+	installed := []*Manifest{
+		{Id: "com.company.any", Version: "0.0.1"},
+		{Id: "com.company.ranged", Version: "1.0.0"},
+	}
+	assert.Equal(t, []string{
+		"requires plugin com.company.ranged >=1.2.0, but version 1.0.0 is installed",
+		"requires plugin com.company.missing, which is not installed",
+	}, manifest.UnmetDependencies(installed))
+
+	installed[1].Version = "1.2.0"
+	installed = append(installed, &Manifest{Id: "com.company.missing"})
+	assert.Empty(t, manifest.UnmetDependencies(installed))
+}
@@ -0,0 +1,63 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+import (
+	"regexp"
+	"strings"
+	"time"
+)
+
+const (
+	// JobTypePluginPrefix prefixes the types of the jobs registered by plugins, which are of the
+	// form "plugin:<plugin id>:<name>".
+	JobTypePluginPrefix = "plugin:"
+
+	// JobTypeMaxLength is the maximum length of a job type.
+	JobTypeMaxLength = 255
+
+	// PluginJobTypeNameMaxLength is the maximum length of the name of a job type registered by a
+	// plugin.
+	PluginJobTypeNameMaxLength = 32
+)
+
+var validPluginJobTypeName = regexp.MustCompile(`^[a-z0-9_]+$`)
+
+// PluginJobTypeOptions configures a job type registered by a plugin.
+type PluginJobTypeOptions struct {
+	// Interval is the period at which the cluster leader schedules a job of the type. Jobs are
+	// only created on demand when zero.
+	Interval time.Duration `json:"interval"`
+}
+
+// PluginJobType returns the job type of the jobs registered by the given plugin under the given name.
+func PluginJobType(pluginID, name string) string {
+	return JobTypePluginPrefix + pluginID + ":" + name
+}
+
+// ParsePluginJobType returns the id of the plugin and the name of a job type registered by a plugin.
+func ParsePluginJobType(jobType string) (pluginID, name string, ok bool) {
+	rest, ok := strings.CutPrefix(jobType, JobTypePluginPrefix)
+	if !ok {
+		return "", "", false
+	}
+
+	pluginID, name, ok = strings.Cut(rest, ":")
+	if !ok || !IsValidPluginId(pluginID) || !IsValidPluginJobTypeName(name) {
+		return "", "", false
+	}
+
+	return pluginID, name, true
+}
+
+// IsPluginJobType returns true if the job type is a valid job type registered by a plugin.
+func IsPluginJobType(jobType string) bool {
+	_, _, ok := ParsePluginJobType(jobType)
+	return ok
+}
+
+// IsValidPluginJobTypeName returns true if the name is valid for a job type registered by a plugin.
+func IsValidPluginJobTypeName(name string) bool {
+	return len(name) <= PluginJobTypeNameMaxLength && validPluginJobTypeName.MatchString(name)
+}
@@ -0,0 +1,37 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParsePluginJobType(t *testing.T) {
+	t.Run("valid", func(t *testing.T) {
+		pluginID, name, ok := ParsePluginJobType(PluginJobType("com.example.plugin", "nightly_sync"))
+		assert.True(t, ok)
+		assert.Equal(t, "com.example.plugin", pluginID)
+		assert.Equal(t, "nightly_sync", name)
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		for _, jobType := range []string{
+			"",
+			JobTypeExportProcess,
+			"plugin:",
+			"plugin:com.example.plugin",
+			"plugin::sync",
+			"plugin:com.example.plugin:",
+			"plugin:com.example.plugin:Sync",
+			"plugin:com.example.plugin:sync:extra",
+			PluginJobType("com.example.plugin", strings.Repeat("a", PluginJobTypeNameMaxLength+1)),
+		} {
+			_, _, ok := ParsePluginJobType(jobType)
+			assert.False(t, ok, jobType)
+		}
+	})
+}
@@ -0,0 +1,101 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+import (
+	"fmt"
+)
+
+const (
+	PluginResourceLimitActionRestart = "restart"
+	PluginResourceLimitActionDisable = "disable"
+)
+
+// PluginResourceUsage describes the resources used by a running plugin, as last sampled by the
+// plugin health check.
+type PluginResourceUsage struct {
+	// MemoryRSSBytes is the resident set size of the plugin process, or the size of the linear
+	// memory of a WebAssembly plugin.
+	MemoryRSSBytes int64 `json:"memory_rss_bytes"`
+	// CPUTimeMs is the total CPU time used by the plugin process since it was started.
+	CPUTimeMs int64 `json:"cpu_time_ms"`
+	// BusyTimeMs is the total time spent running the module of a WebAssembly plugin since it was
+	// started, API calls excluded. It's reported instead of CPUTimeMs, which can't be measured
+	// for a module run by the server process.
+	BusyTimeMs int64 `json:"busy_time_ms,omitempty"`
+	// CPUPercent is the share of a single CPU used by the plugin since the previous sample, or
+	// the share of time spent running the module of a WebAssembly plugin.
+	CPUPercent float64 `json:"cpu_percent"`
+	// Goroutines is the number of goroutines reported by the plugin, or 0 if unknown.
+	Goroutines int `json:"goroutines"`
+	// APICallsPerSecond is the rate of plugin API calls since the previous sample.
+	APICallsPerSecond float64 `json:"api_calls_per_second"`
+	// HookLatencyMs is the average duration of the hooks invoked since the previous sample.
+	HookLatencyMs float64 `json:"hook_latency_ms"`
+	// UpdateAt is when the usage was sampled.
+	UpdateAt int64 `json:"update_at"`
+}
+
+// PluginResourceLimits are the limits an administrator sets on the resources used by a plugin.
+// A zero value means the resource is not limited.
+type PluginResourceLimits struct {
+	MaxMemoryMB          int
+	MaxCPUPercent        int
+	MaxGoroutines        int
+	MaxAPICallsPerSecond int
+	MaxHookLatencyMs     int
+	// Action is taken when a limit is exceeded, either "restart" (the default) or "disable".
+	Action string
+}
+
+// GetAction returns the action to take when a limit is exceeded.
+func (l *PluginResourceLimits) GetAction() string {
+	if l.Action == "" {
+		return PluginResourceLimitActionRestart
+	}
+	return l.Action
+}
+
+// ExceededBy returns a description of the first limit exceeded by the given usage, or an empty
+// string if the usage is within the limits.
+func (l *PluginResourceLimits) ExceededBy(usage *PluginResourceUsage) string {
+	if l == nil || usage == nil {
+		return ""
+	}
+
+	if l.MaxMemoryMB > 0 && usage.MemoryRSSBytes > int64(l.MaxMemoryMB)*1024*1024 {
+		return fmt.Sprintf("memory usage of %d MB exceeds the limit of %d MB", usage.MemoryRSSBytes/1024/1024, l.MaxMemoryMB)
+	}
+
+	if l.MaxCPUPercent > 0 && usage.CPUPercent > float64(l.MaxCPUPercent) {
+		return fmt.Sprintf("CPU usage of %.0f%% exceeds the limit of %d%%", usage.CPUPercent, l.MaxCPUPercent)
+	}
+
+	if l.MaxGoroutines > 0 && usage.Goroutines > l.MaxGoroutines {
+		return fmt.Sprintf("%d goroutines exceed the limit of %d", usage.Goroutines, l.MaxGoroutines)
+	}
+
+	if l.MaxAPICallsPerSecond > 0 && usage.APICallsPerSecond > float64(l.MaxAPICallsPerSecond) {
+		return fmt.Sprintf("%.1f API calls per second exceed the limit of %d", usage.APICallsPerSecond, l.MaxAPICallsPerSecond)
+	}
+
+	if l.MaxHookLatencyMs > 0 && usage.HookLatencyMs > float64(l.MaxHookLatencyMs) {
+		return fmt.Sprintf("average hook latency of %.0f ms exceeds the limit of %d ms", usage.HookLatencyMs, l.MaxHookLatencyMs)
+	}
+
+	return ""
+}
+
+// isSet returns whether any limit is set.
+func (l *PluginResourceLimits) isSet() bool {
+	return l.MaxMemoryMB > 0 || l.MaxCPUPercent > 0 || l.MaxGoroutines > 0 || l.MaxAPICallsPerSecond > 0 || l.MaxHookLatencyMs > 0
+}
+
+func (l *PluginResourceLimits) isValid() bool {
+	if l.MaxMemoryMB < 0 || l.MaxCPUPercent < 0 || l.MaxGoroutines < 0 || l.MaxAPICallsPerSecond < 0 || l.MaxHookLatencyMs < 0 {
+		return false
+	}
+
+	return l.Action == "" || l.Action == PluginResourceLimitActionRestart || l.Action == PluginResourceLimitActionDisable
+}
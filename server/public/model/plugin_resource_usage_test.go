@@ -0,0 +1,118 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPluginResourceLimitsExceededBy(t *testing.T) {
+	limits := &PluginResourceLimits{
+		MaxMemoryMB:          100,
+		MaxCPUPercent:        50,
+		MaxGoroutines:        1000,
+		MaxAPICallsPerSecond: 20,
+		MaxHookLatencyMs:     500,
+	}
+
+	for name, tc := range map[string]struct {
+		limits   *PluginResourceLimits
+		usage    *PluginResourceUsage
+		expected string
+	}{
+		"nil limits": {
+			usage: &PluginResourceUsage{MemoryRSSBytes: 1 << 40},
+		},
+		"nil usage": {
+			limits: limits,
+		},
+		"zero limits are ignored": {
+			limits: &PluginResourceLimits{},
+			usage:  &PluginResourceUsage{MemoryRSSBytes: 1 << 40, CPUPercent: 400, Goroutines: 1e6},
+		},
+		"within limits": {
+			limits: limits,
+			usage:  &PluginResourceUsage{MemoryRSSBytes: 100 * 1024 * 1024, CPUPercent: 50, Goroutines: 1000, APICallsPerSecond: 20, HookLatencyMs: 500},
+		},
+		"memory": {
+			limits:   limits,
+			usage:    &PluginResourceUsage{MemoryRSSBytes: 150 * 1024 * 1024},
+			expected: "memory usage of 150 MB exceeds the limit of 100 MB",
+		},
+		"cpu": {
+			limits:   limits,
+			usage:    &PluginResourceUsage{CPUPercent: 75.2},
+			expected: "CPU usage of 75% exceeds the limit of 50%",
+		},
+		"goroutines": {
+			limits:   limits,
+			usage:    &PluginResourceUsage{Goroutines: 1001},
+			expected: "1001 goroutines exceed the limit of 1000",
+		},
+		"api calls": {
+			limits:   limits,
+			usage:    &PluginResourceUsage{APICallsPerSecond: 20.5},
+			expected: "20.5 API calls per second exceed the limit of 20",
+		},
+		"hook latency": {
+			limits:   limits,
+			usage:    &PluginResourceUsage{HookLatencyMs: 501},
+			expected: "average hook latency of 501 ms exceeds the limit of 500 ms",
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, tc.limits.ExceededBy(tc.usage))
+		})
+	}
+}
+
+func TestPluginResourceLimitsGetAction(t *testing.T) {
+	assert.Equal(t, PluginResourceLimitActionRestart, (&PluginResourceLimits{}).GetAction())
+	assert.Equal(t, PluginResourceLimitActionDisable, (&PluginResourceLimits{Action: PluginResourceLimitActionDisable}).GetAction())
+}
+
+func TestPluginSettingsResourceLimitsIsValid(t *testing.T) {
+	for name, tc := range map[string]struct {
+		limits *PluginResourceLimits
+		valid  bool
+	}{
+		"nil":            {limits: nil, valid: true},
+		"empty":          {limits: &PluginResourceLimits{}, valid: true},
+		"restart":        {limits: &PluginResourceLimits{MaxMemoryMB: 10, Action: PluginResourceLimitActionRestart}, valid: true},
+		"disable":        {limits: &PluginResourceLimits{MaxCPUPercent: 10, Action: PluginResourceLimitActionDisable}, valid: true},
+		"negative limit": {limits: &PluginResourceLimits{MaxGoroutines: -1}, valid: false},
+		"unknown action": {limits: &PluginResourceLimits{Action: "ignore"}, valid: false},
+	} {
+		t.Run(name, func(t *testing.T) {
+			cfg := &Config{}
+			cfg.SetDefaults()
+			cfg.PluginSettings.ResourceLimits["com.example.plugin"] = tc.limits
+
+			appErr := cfg.PluginSettings.isValid()
+			if tc.valid {
+				require.Nil(t, appErr)
+			} else {
+				require.NotNil(t, appErr)
+				assert.Equal(t, "model.config.is_valid.plugin_resource_limits.app_error", appErr.Id)
+			}
+		})
+	}
+
+	t.Run("health check disabled", func(t *testing.T) {
+		cfg := &Config{}
+		cfg.SetDefaults()
+		cfg.PluginSettings.EnableHealthCheck = NewPointer(false)
+
+		cfg.PluginSettings.ResourceLimits["com.example.plugin"] = &PluginResourceLimits{Action: PluginResourceLimitActionDisable}
+		require.Nil(t, cfg.PluginSettings.isValid())
+
+		cfg.PluginSettings.ResourceLimits["com.example.plugin"] = &PluginResourceLimits{MaxMemoryMB: 10}
+		appErr := cfg.PluginSettings.isValid()
+		require.NotNil(t, appErr)
+		assert.Equal(t, "model.config.is_valid.plugin_resource_limits_health_check.app_error", appErr.Id)
+	})
+}
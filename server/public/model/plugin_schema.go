@@ -0,0 +1,37 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"strings"
+)
+
+// pluginTableNamespaceMaxLength is the maximum length of the part of the table prefix derived from
+// the plugin id, leaving room for the table names within the identifier limits of the databases.
+const pluginTableNamespaceMaxLength = 24
+
+// PluginTablePrefix returns the prefix of the names of the database tables and indexes owned by
+// the given plugin, such as "plugin_com_example_todo_". Plugin ids too long to fit are shortened
+// and suffixed with a hash of the id.
+func PluginTablePrefix(pluginID string) string {
+	namespace := strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z', r >= '0' && r <= '9':
+			return r
+		case r >= 'A' && r <= 'Z':
+			return r - 'A' + 'a'
+		default:
+			return '_'
+		}
+	}, pluginID)
+
+	if len(namespace) > pluginTableNamespaceMaxLength {
+		sum := sha256.Sum256([]byte(pluginID))
+		namespace = namespace[:pluginTableNamespaceMaxLength-9] + "_" + hex.EncodeToString(sum[:4])
+	}
+
+	return "plugin_" + namespace + "_"
+}
@@ -0,0 +1,22 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPluginTablePrefix(t *testing.T) {
+	assert.Equal(t, "plugin_com_example_todo_", PluginTablePrefix("com.example.todo"))
+	assert.Equal(t, "plugin_com_example_my_plugin_", PluginTablePrefix("com.Example.my-plugin"))
+
+	long := "com.example." + strings.Repeat("a", 40)
+	prefix := PluginTablePrefix(long)
+	assert.Len(t, prefix, len("plugin_")+pluginTableNamespaceMaxLength+1)
+	assert.True(t, strings.HasPrefix(prefix, "plugin_com_example_aaa"))
+	assert.NotEqual(t, prefix, PluginTablePrefix(long+"b"))
+}
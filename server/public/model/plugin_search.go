@@ -0,0 +1,20 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+const (
+	PluginSearchTypePosts = "posts"
+	PluginSearchTypeFiles = "files"
+)
+
+// PluginSearch describes a search run on behalf of a user, as seen by the
+// SearchWillBeExecuted and SearchResultsProvided plugin hooks.
+type PluginSearch struct {
+	Type    string          `json:"type"`
+	UserId  string          `json:"user_id"`
+	TeamId  string          `json:"team_id"`
+	Params  []*SearchParams `json:"params"`
+	Page    int             `json:"page"`
+	PerPage int             `json:"per_page"`
+}
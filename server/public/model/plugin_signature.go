@@ -0,0 +1,82 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+import (
+	"regexp"
+	"strings"
+)
+
+// PluginSignatureKeyNameMattermost is the name reported for the public key built into the server.
+const PluginSignatureKeyNameMattermost = "mattermost"
+
+const (
+	// PluginSignatureStatusVerified is the status of a plugin signed with a trusted key.
+	PluginSignatureStatusVerified = "verified"
+	// PluginSignatureStatusUnsigned is the status of a plugin installed without a signature.
+	PluginSignatureStatusUnsigned = "unsigned"
+	// PluginSignatureStatusInvalid is the status of a plugin whose signature can't be verified
+	// with any of the trusted keys.
+	PluginSignatureStatusInvalid = "invalid"
+	// PluginSignatureStatusExpired is the status of a plugin signed after its key expired.
+	PluginSignatureStatusExpired = "expired"
+	// PluginSignatureStatusRevoked is the status of a plugin signed with a revoked key.
+	PluginSignatureStatusRevoked = "revoked"
+)
+
+var pluginSignatureKeyIdRegexp = regexp.MustCompile(`^([0-9A-F]{16}|[0-9A-F]{40})$`)
+
+// PluginSignature describes the key a plugin bundle is signed with.
+type PluginSignature struct {
+	// KeyId is the long ID of the key that made the signature, which may be a subkey.
+	KeyId string `json:"key_id"`
+	// KeyFingerprint is the fingerprint of the primary key the signing key belongs to.
+	KeyFingerprint string `json:"key_fingerprint,omitempty"`
+	// KeyName is the name of the public key file configured by the administrator, or
+	// PluginSignatureKeyNameMattermost for the key built into the server.
+	KeyName string `json:"key_name,omitempty"`
+	// SignedAt is when the signature was made, according to the signature itself.
+	SignedAt int64 `json:"signed_at"`
+}
+
+// IsRevoked returns true if the signing key or its primary key is in the given list of key IDs
+// and fingerprints.
+func (s *PluginSignature) IsRevoked(revokedKeyIds []string) bool {
+	for _, id := range revokedKeyIds {
+		id = NormalizePluginSignatureKeyId(id)
+		if id == "" {
+			continue
+		}
+		if id == s.KeyId || id == s.KeyFingerprint || (len(s.KeyFingerprint) == 40 && id == s.KeyFingerprint[24:]) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// PluginSignatureReport is the signing status of an installed plugin.
+type PluginSignatureReport struct {
+	PluginId string `json:"plugin_id"`
+	Version  string `json:"version"`
+	// Status is one of the PluginSignatureStatus values.
+	Status string `json:"status"`
+	// Signature is set when the signature could be decoded, even if it's not trusted.
+	Signature *PluginSignature `json:"signature,omitempty"`
+	// Error explains why the signature isn't trusted.
+	Error string `json:"error,omitempty"`
+}
+
+// NormalizePluginSignatureKeyId returns the key ID or fingerprint in upper case, without spaces
+// and without the "0x" prefix.
+func NormalizePluginSignatureKeyId(id string) string {
+	id = strings.ToUpper(strings.ReplaceAll(strings.TrimSpace(id), " ", ""))
+	return strings.TrimPrefix(id, "0X")
+}
+
+// IsValidPluginSignatureKeyId returns true if the given string is a 16 digit long key ID or a 40
+// digit fingerprint, in hexadecimal.
+func IsValidPluginSignatureKeyId(id string) bool {
+	return pluginSignatureKeyIdRegexp.MatchString(NormalizePluginSignatureKeyId(id))
+}
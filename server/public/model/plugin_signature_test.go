@@ -0,0 +1,41 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIsValidPluginSignatureKeyId(t *testing.T) {
+	for id, valid := range map[string]bool{
+		"D1B54B47A5CEFEC4":                                   true,
+		"d1b54b47a5cefec4":                                   true,
+		"0xD1B54B47A5CEFEC4":                                 true,
+		"C55881B80F69E863B85AD5D1D1B54B47A5CEFEC4":           true,
+		"C558 81B8 0F69 E863 B85A  D5D1 D1B5 4B47 A5CE FEC4": true,
+		"":                  false,
+		"A5CEFEC4":          false,
+		"D1B54B47A5CEFEC4Z": false,
+		"Z1B54B47A5CEFEC4":  false,
+	} {
+		assert.Equal(t, valid, IsValidPluginSignatureKeyId(id), id)
+	}
+}
+
+func TestPluginSignatureIsRevoked(t *testing.T) {
+	signature := &PluginSignature{
+		KeyId:          "0123456789ABCDEF",
+		KeyFingerprint: "C55881B80F69E863B85AD5D1D1B54B47A5CEFEC4",
+	}
+
+	assert.False(t, signature.IsRevoked(nil))
+	assert.False(t, signature.IsRevoked([]string{"FEDCBA9876543210"}))
+	assert.True(t, signature.IsRevoked([]string{"FEDCBA9876543210", "0123456789abcdef"}))
+	assert.True(t, signature.IsRevoked([]string{"C55881B80F69E863B85AD5D1D1B54B47A5CEFEC4"}))
+	assert.True(t, signature.IsRevoked([]string{"0xD1B54B47A5CEFEC4"}))
+
+	assert.False(t, (&PluginSignature{KeyId: "0123456789ABCDEF"}).IsRevoked([]string{""}))
+}
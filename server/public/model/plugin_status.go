@@ -22,6 +22,21 @@ type PluginStatus struct {
 	Name        string `json:"name"`
 	Description string `json:"description"`
 	Version     string `json:"version"`
+
+	// UnmetRequirements describes the dependencies, capabilities and permissions required by the
+	// plugin that aren't currently satisfied.
+	UnmetRequirements []string `json:"unmet_requirements,omitempty"`
+
+	// SchemaVersion is the version of the last database migration applied for the plugin, if it
+	// ships migrations.
+	SchemaVersion uint32 `json:"schema_version,omitempty"`
+
+	// SigningKeyId is the ID of the key the plugin was verified to be signed with when installed, if
+	// it was signed with a trusted key.
+	SigningKeyId string `json:"signing_key_id,omitempty"`
+
+	// ResourceUsage is the resource usage of the plugin when it is running.
+	ResourceUsage *PluginResourceUsage `json:"resource_usage,omitempty"`
 }
 
 type PluginStatuses []*PluginStatus
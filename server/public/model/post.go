@@ -83,7 +83,8 @@ const (
 	PostPropsChannelMentions          = "channel_mentions"
 	PostPropsUnsafeLinks              = "unsafe_links"
 
-	PostPriorityUrgent = "urgent"
+	PostPriorityUrgent    = "urgent"
+	PostPriorityImportant = "important"
 )
 
 type Post struct {
@@ -0,0 +1,204 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"unicode/utf8"
+)
+
+const (
+	AuditRecordSearchDefaultPerPage = 100
+	AuditRecordSearchMaxPerPage     = 1000
+
+	AuditRecordExportFormatCSV  = "csv"
+	AuditRecordExportFormatJSON = "json"
+
+	storedAuditRecordLevelMaxRunes      = 64
+	storedAuditRecordEventNameMaxRunes  = 128
+	storedAuditRecordStatusMaxRunes     = 32
+	storedAuditRecordUserIdMaxRunes     = 128
+	storedAuditRecordSessionIdMaxRunes  = 26
+	storedAuditRecordIpAddressMaxRunes  = 64
+	storedAuditRecordObjectTypeMaxRunes = 64
+	storedAuditRecordObjectIdMaxRunes   = 64
+)
+
+// StoredAuditRecord is an AuditRecord persisted to the database so that
+// it can be searched. The fields used for filtering are extracted from
+// the record, which is stored as is.
+type StoredAuditRecord struct {
+	Id         string       `json:"id"`
+	CreateAt   int64        `json:"create_at"`
+	Level      string       `json:"level"`
+	EventName  string       `json:"event_name"`
+	Status     string       `json:"status"`
+	UserId     string       `json:"user_id"`
+	SessionId  string       `json:"session_id"`
+	IpAddress  string       `json:"ip_address"`
+	ObjectType string       `json:"object_type"`
+	ObjectId   string       `json:"object_id"`
+	Record     *AuditRecord `json:"record"`
+}
+
+// NewStoredAuditRecord wraps an audit record logged with the given level.
+func NewStoredAuditRecord(level string, rec AuditRecord) *StoredAuditRecord {
+	return &StoredAuditRecord{
+		Level:      level,
+		EventName:  rec.EventName,
+		Status:     rec.Status,
+		UserId:     rec.Actor.UserId,
+		SessionId:  rec.Actor.SessionId,
+		IpAddress:  rec.Actor.IpAddress,
+		ObjectType: rec.EventData.ObjectType,
+		ObjectId:   auditRecordObjectId(rec.EventData),
+		Record:     &rec,
+	}
+}
+
+// auditRecordObjectId returns the id of the object modified by the event,
+// as found in its resulting or prior state.
+func auditRecordObjectId(data AuditEventData) string {
+	for _, state := range []map[string]any{data.ResultState, data.PriorState} {
+		if id, ok := state["id"].(string); ok && id != "" {
+			return id
+		}
+	}
+	return ""
+}
+
+func (r *StoredAuditRecord) PreSave() {
+	if r.Id == "" {
+		r.Id = NewId()
+	}
+
+	if r.CreateAt == 0 {
+		r.CreateAt = GetMillis()
+	}
+
+	// The indexed fields are truncated to fit their columns rather than
+	// failing the insert, since the complete values are kept in Record.
+	r.Level = truncateRunes(r.Level, storedAuditRecordLevelMaxRunes)
+	r.EventName = truncateRunes(r.EventName, storedAuditRecordEventNameMaxRunes)
+	r.Status = truncateRunes(r.Status, storedAuditRecordStatusMaxRunes)
+	r.UserId = truncateRunes(r.UserId, storedAuditRecordUserIdMaxRunes)
+	r.SessionId = truncateRunes(r.SessionId, storedAuditRecordSessionIdMaxRunes)
+	r.IpAddress = truncateRunes(r.IpAddress, storedAuditRecordIpAddressMaxRunes)
+	r.ObjectType = truncateRunes(r.ObjectType, storedAuditRecordObjectTypeMaxRunes)
+	r.ObjectId = truncateRunes(r.ObjectId, storedAuditRecordObjectIdMaxRunes)
+}
+
+func truncateRunes(s string, maxRunes int) string {
+	if utf8.RuneCountInString(s) <= maxRunes {
+		return s
+	}
+	return string([]rune(s)[:maxRunes])
+}
+
+func (r *StoredAuditRecord) IsValid() *AppError {
+	if !IsValidId(r.Id) {
+		return NewAppError("StoredAuditRecord.IsValid", "model.stored_audit_record.is_valid.id.app_error", nil, "", http.StatusBadRequest)
+	}
+
+	if r.CreateAt == 0 {
+		return NewAppError("StoredAuditRecord.IsValid", "model.stored_audit_record.is_valid.create_at.app_error", nil, "id="+r.Id, http.StatusBadRequest)
+	}
+
+	if r.Record == nil {
+		return NewAppError("StoredAuditRecord.IsValid", "model.stored_audit_record.is_valid.record.app_error", nil, "id="+r.Id, http.StatusBadRequest)
+	}
+
+	return nil
+}
+
+// AuditRecordCSVHeader returns the header of the CSV export of audit records.
+func AuditRecordCSVHeader() []string {
+	return []string{"id", "create_at", "level", "event_name", "status", "user_id", "session_id", "ip_address", "object_type", "object_id", "record"}
+}
+
+// ToCSVRow returns the record as a row matching AuditRecordCSVHeader.
+func (r *StoredAuditRecord) ToCSVRow() ([]string, error) {
+	record, err := json.Marshal(r.Record)
+	if err != nil {
+		return nil, err
+	}
+
+	return []string{
+		r.Id,
+		strconv.FormatInt(r.CreateAt, 10),
+		r.Level,
+		r.EventName,
+		r.Status,
+		r.UserId,
+		r.SessionId,
+		r.IpAddress,
+		r.ObjectType,
+		r.ObjectId,
+		string(record),
+	}, nil
+}
+
+// AuditRecordSearchOptions are the filters of a search of the stored
+// audit records. Empty fields match every record.
+type AuditRecordSearchOptions struct {
+	UserId    string `json:"user_id,omitempty"`
+	EventName string `json:"event_name,omitempty"`
+	Status    string `json:"status,omitempty"`
+	ObjectId  string `json:"object_id,omitempty"`
+	IpAddress string `json:"ip_address,omitempty"`
+	// Since and Until restrict the records to the time range [Since, Until),
+	// in milliseconds.
+	Since   int64 `json:"since,omitempty"`
+	Until   int64 `json:"until,omitempty"`
+	Page    int   `json:"page"`
+	PerPage int   `json:"per_page"`
+}
+
+func (o AuditRecordSearchOptions) Auditable() map[string]any {
+	return map[string]any{
+		"user_id":    o.UserId,
+		"event_name": o.EventName,
+		"status":     o.Status,
+		"object_id":  o.ObjectId,
+		"ip_address": o.IpAddress,
+		"since":      o.Since,
+		"until":      o.Until,
+		"page":       o.Page,
+		"per_page":   o.PerPage,
+	}
+}
+
+func (o *AuditRecordSearchOptions) SetDefaults() {
+	if o.PerPage == 0 {
+		o.PerPage = AuditRecordSearchDefaultPerPage
+	}
+}
+
+func (o *AuditRecordSearchOptions) IsValid() *AppError {
+	// The actor of records logged by the CLI is the OS user rather than a
+	// Mattermost user, so the user id isn't necessarily a valid id.
+	if utf8.RuneCountInString(o.UserId) > storedAuditRecordUserIdMaxRunes {
+		return NewAppError("AuditRecordSearchOptions.IsValid", "model.audit_record_search.is_valid.user_id.app_error", nil, "", http.StatusBadRequest)
+	}
+
+	if utf8.RuneCountInString(o.ObjectId) > storedAuditRecordObjectIdMaxRunes {
+		return NewAppError("AuditRecordSearchOptions.IsValid", "model.audit_record_search.is_valid.object_id.app_error", nil, "", http.StatusBadRequest)
+	}
+
+	if o.Status != "" && o.Status != AuditStatusSuccess && o.Status != AuditStatusAttempt && o.Status != AuditStatusFail {
+		return NewAppError("AuditRecordSearchOptions.IsValid", "model.audit_record_search.is_valid.status.app_error", nil, "", http.StatusBadRequest)
+	}
+
+	if o.Since < 0 || o.Until < 0 || (o.Until != 0 && o.Until <= o.Since) {
+		return NewAppError("AuditRecordSearchOptions.IsValid", "model.audit_record_search.is_valid.time_range.app_error", nil, "", http.StatusBadRequest)
+	}
+
+	if o.Page < 0 || o.PerPage <= 0 || o.PerPage > AuditRecordSearchMaxPerPage {
+		return NewAppError("AuditRecordSearchOptions.IsValid", "model.audit_record_search.is_valid.paging.app_error", map[string]any{"Max": AuditRecordSearchMaxPerPage}, "", http.StatusBadRequest)
+	}
+
+	return nil
+}
@@ -0,0 +1,103 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewStoredAuditRecord(t *testing.T) {
+	userID := NewId()
+	postID := NewId()
+
+	rec := AuditRecord{
+		EventName: AuditEventUpdatePost,
+		Status:    AuditStatusSuccess,
+		Actor:     AuditEventActor{UserId: userID, SessionId: NewId(), IpAddress: "10.0.0.1"},
+		EventData: AuditEventData{
+			ObjectType: "post",
+			PriorState: map[string]any{"id": postID},
+		},
+	}
+
+	stored := NewStoredAuditRecord("audit-api", rec)
+	assert.Equal(t, "audit-api", stored.Level)
+	assert.Equal(t, AuditEventUpdatePost, stored.EventName)
+	assert.Equal(t, AuditStatusSuccess, stored.Status)
+	assert.Equal(t, userID, stored.UserId)
+	assert.Equal(t, rec.Actor.SessionId, stored.SessionId)
+	assert.Equal(t, "10.0.0.1", stored.IpAddress)
+	assert.Equal(t, "post", stored.ObjectType)
+	assert.Equal(t, postID, stored.ObjectId)
+
+	assert.NotNil(t, stored.IsValid())
+
+	stored.PreSave()
+	assert.True(t, IsValidId(stored.Id))
+	assert.NotZero(t, stored.CreateAt)
+	assert.Nil(t, stored.IsValid())
+}
+
+func TestStoredAuditRecordPreSave(t *testing.T) {
+	stored := NewStoredAuditRecord("audit-cli", AuditRecord{
+		EventName: strings.Repeat("é", 200),
+		Actor:     AuditEventActor{UserId: strings.Repeat("u", 200)},
+	})
+	stored.PreSave()
+
+	assert.Len(t, []rune(stored.EventName), storedAuditRecordEventNameMaxRunes)
+	assert.Len(t, stored.UserId, storedAuditRecordUserIdMaxRunes)
+	// The record itself is kept intact.
+	assert.Len(t, stored.Record.Actor.UserId, 200)
+}
+
+func TestStoredAuditRecordToCSVRow(t *testing.T) {
+	stored := NewStoredAuditRecord("audit-api", AuditRecord{EventName: AuditEventLogin, Status: AuditStatusFail})
+	stored.PreSave()
+
+	row, err := stored.ToCSVRow()
+	require.NoError(t, err)
+	require.Len(t, row, len(AuditRecordCSVHeader()))
+	assert.Equal(t, stored.Id, row[0])
+	assert.Equal(t, AuditEventLogin, row[3])
+	assert.Contains(t, row[10], `"event_name":"login"`)
+}
+
+func TestAuditRecordSearchOptionsIsValid(t *testing.T) {
+	testCases := []struct {
+		name    string
+		opts    AuditRecordSearchOptions
+		errorID string
+	}{
+		{"defaults", AuditRecordSearchOptions{}, ""},
+		{"all filters", AuditRecordSearchOptions{UserId: NewId(), EventName: AuditEventLogin, Status: AuditStatusAttempt, ObjectId: NewId(), IpAddress: "10.0.0.1", Since: 1, Until: 2}, ""},
+		{"non id user", AuditRecordSearchOptions{UserId: "root"}, ""},
+		{"user id too long", AuditRecordSearchOptions{UserId: strings.Repeat("u", 129)}, "model.audit_record_search.is_valid.user_id.app_error"},
+		{"object id too long", AuditRecordSearchOptions{ObjectId: strings.Repeat("o", 65)}, "model.audit_record_search.is_valid.object_id.app_error"},
+		{"unknown status", AuditRecordSearchOptions{Status: "done"}, "model.audit_record_search.is_valid.status.app_error"},
+		{"negative since", AuditRecordSearchOptions{Since: -1}, "model.audit_record_search.is_valid.time_range.app_error"},
+		{"empty time range", AuditRecordSearchOptions{Since: 2, Until: 2}, "model.audit_record_search.is_valid.time_range.app_error"},
+		{"negative page", AuditRecordSearchOptions{Page: -1}, "model.audit_record_search.is_valid.paging.app_error"},
+		{"too many per page", AuditRecordSearchOptions{PerPage: AuditRecordSearchMaxPerPage + 1}, "model.audit_record_search.is_valid.paging.app_error"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.opts.SetDefaults()
+			appErr := tc.opts.IsValid()
+			if tc.errorID == "" {
+				assert.Nil(t, appErr)
+				return
+			}
+			require.NotNil(t, appErr)
+			assert.Equal(t, tc.errorID, appErr.Id)
+			assert.Equal(t, http.StatusBadRequest, appErr.StatusCode)
+		})
+	}
+}
@@ -0,0 +1,14 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+// TablePartition is a monthly partition of a table partitioned by CreateAt.
+// The partition holds the rows created in [From, To).
+type TablePartition struct {
+	Table    string `json:"table"`
+	Name     string `json:"name"`
+	From     int64  `json:"from"`
+	To       int64  `json:"to"`
+	Archived bool   `json:"archived"`
+}
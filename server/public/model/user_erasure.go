@@ -0,0 +1,63 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+import (
+	"encoding/json"
+)
+
+const (
+	// UserErasureUsernamePrefix prefixes the username of the tombstone
+	// accounts replacing erased users.
+	UserErasureUsernamePrefix = "erased-"
+
+	UserErasureJobDataUserId   = "user_id"
+	UserErasureJobDataDryRun   = "dry_run"
+	UserErasureJobDataUsername = "username"
+	UserErasureJobDataReport   = "report"
+)
+
+// UserErasureReport lists what an erasure job removed or pseudonymized. For
+// dry runs, it lists what the job would have removed or pseudonymized.
+type UserErasureReport struct {
+	UserId                  string   `json:"user_id"`
+	DryRun                  bool     `json:"dry_run"`
+	Username                string   `json:"username"`
+	Sessions                int      `json:"sessions"`
+	AccessTokens            int      `json:"access_tokens"`
+	Preferences             int      `json:"preferences"`
+	Drafts                  int      `json:"drafts"`
+	ScheduledPosts          int      `json:"scheduled_posts"`
+	CustomProfileAttributes int      `json:"custom_profile_attributes"`
+	Files                   int      `json:"files"`
+	ProfileImage            bool     `json:"profile_image"`
+	DataExports             int      `json:"data_exports"`
+	Audits                  int      `json:"audits"`
+	AuditRecords            int64    `json:"audit_records"`
+	Mentions                int      `json:"mentions"`
+	SearchIndexes           []string `json:"search_indexes"`
+	Warnings                []string `json:"warnings"`
+}
+
+// UserErasureReportFromJob returns the report stored in the data of an
+// erasure job, or nil if the job didn't complete yet.
+func UserErasureReportFromJob(job *Job) (*UserErasureReport, error) {
+	data, ok := job.Data[UserErasureJobDataReport]
+	if !ok || data == "" {
+		return nil, nil
+	}
+
+	var report UserErasureReport
+	if err := json.Unmarshal([]byte(data), &report); err != nil {
+		return nil, err
+	}
+	return &report, nil
+}
+
+// ErasedUsername returns the username of the tombstone account replacing
+// the erased user. It doesn't derive from the id of the user for the
+// username not to be linked back to the user.
+func ErasedUsername() string {
+	return UserErasureUsernamePrefix + NewId()[:15]
+}
@@ -0,0 +1,41 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestErasedUsername(t *testing.T) {
+	username := ErasedUsername()
+	assert.True(t, IsValidUsername(username))
+	assert.NotEqual(t, username, ErasedUsername())
+}
+
+func TestUserErasureReportFromJob(t *testing.T) {
+	t.Run("no report", func(t *testing.T) {
+		report, err := UserErasureReportFromJob(&Job{Data: StringMap{UserErasureJobDataUserId: NewId()}})
+		require.NoError(t, err)
+		assert.Nil(t, report)
+	})
+
+	t.Run("report", func(t *testing.T) {
+		expected := &UserErasureReport{UserId: NewId(), DryRun: true, Mentions: 2, AuditRecords: 3}
+		data, err := json.Marshal(expected)
+		require.NoError(t, err)
+
+		report, err := UserErasureReportFromJob(&Job{Data: StringMap{UserErasureJobDataReport: string(data)}})
+		require.NoError(t, err)
+		assert.Equal(t, expected, report)
+	})
+
+	t.Run("invalid report", func(t *testing.T) {
+		_, err := UserErasureReportFromJob(&Job{Data: StringMap{UserErasureJobDataReport: "{"}})
+		require.Error(t, err)
+	})
+}
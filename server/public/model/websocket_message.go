@@ -114,6 +114,37 @@ type WSQueues struct {
 	ReuseCount int               `json:"reuse_count"`
 }
 
+const (
+	WebConnTransportWebSocket = "websocket"
+	WebConnTransportSSE       = "sse"
+)
+
+// WebConnInfo is a point-in-time view of a single websocket connection
+// as held by the hub it is registered on. It's used to diagnose
+// delivery issues and doesn't contain any message content.
+type WebConnInfo struct {
+	Hostname           string `json:"hostname"`
+	HubIndex           int    `json:"hub_index"`
+	ConnectionId       string `json:"connection_id"`
+	UserId             string `json:"user_id"`
+	SessionId          string `json:"session_id"`
+	Transport          string `json:"transport"`
+	OriginClient       string `json:"origin_client"`
+	RemoteAddress      string `json:"remote_address"`
+	XForwardedFor      string `json:"x_forwarded_for"`
+	Active             bool   `json:"active"`
+	ReuseCount         int    `json:"reuse_count"`
+	Sequence           int64  `json:"sequence"`
+	SendQueueLength    int    `json:"send_queue_length"`
+	SendQueueCapacity  int    `json:"send_queue_capacity"`
+	DeadQueuePointer   int    `json:"dead_queue_pointer"`
+	DeadQueueCapacity  int    `json:"dead_queue_capacity"`
+	LastUserActivityAt int64  `json:"last_user_activity_at"`
+	ActiveChannelId    string `json:"active_channel_id"`
+	ActiveTeamId       string `json:"active_team_id"`
+	PostedAck          bool   `json:"posted_ack"`
+}
+
 type WebSocketMessage interface {
 	ToJSON() ([]byte, error)
 	IsValid() bool
@@ -1550,6 +1550,37 @@ type API interface {
 	// @tag Audit
 	// Minimum server version: 10.10
 	LogAuditRecWithLevel(rec *model.AuditRecord, level mlog.Level)
+
+	// RegisterJobType registers a job type run by the plugin's RunJob hook. The name must consist of
+	// lowercase letters, numbers and underscores, and is scoped to the plugin: the jobs are created
+	// with the type returned by model.PluginJobType. When options.Interval is set, the cluster leader
+	// creates a job of the type at that interval.
+	//
+	// Job types are unregistered when the plugin is deactivated, so plugins should register them
+	// in OnActivate. Registering a job type again updates its options.
+	//
+	// @tag Job
+	// Minimum server version: 10.12
+	RegisterJobType(name string, options *model.PluginJobTypeOptions) error
+
+	// CreateJob creates a pending job of a type registered by the plugin.
+	//
+	// @tag Job
+	// Minimum server version: 10.12
+	CreateJob(name string, data map[string]string) (*model.Job, error)
+
+	// GetJob gets a job of a type registered by the plugin.
+	//
+	// @tag Job
+	// Minimum server version: 10.12
+	GetJob(jobID string) (*model.Job, error)
+
+	// SetJobProgress sets the progress, from 0 to 100, of a running job of a type registered by
+	// the plugin.
+	//
+	// @tag Job
+	// Minimum server version: 10.12
+	SetJobProgress(jobID string, progress int64) error
 }
 
 var handshake = plugin.HandshakeConfig{
@@ -1657,3 +1657,31 @@ func (api *apiTimerLayer) LogAuditRecWithLevel(rec *model.AuditRecord, level mlo
 	api.apiImpl.LogAuditRecWithLevel(rec, level)
 	api.recordTime(startTime, "LogAuditRecWithLevel", true)
 }
+
+func (api *apiTimerLayer) RegisterJobType(name string, options *model.PluginJobTypeOptions) error {
+	startTime := timePkg.Now()
+	_returnsA := api.apiImpl.RegisterJobType(name, options)
+	api.recordTime(startTime, "RegisterJobType", _returnsA == nil)
+	return _returnsA
+}
+
+func (api *apiTimerLayer) CreateJob(name string, data map[string]string) (*model.Job, error) {
+	startTime := timePkg.Now()
+	_returnsA, _returnsB := api.apiImpl.CreateJob(name, data)
+	api.recordTime(startTime, "CreateJob", _returnsB == nil)
+	return _returnsA, _returnsB
+}
+
+func (api *apiTimerLayer) GetJob(jobID string) (*model.Job, error) {
+	startTime := timePkg.Now()
+	_returnsA, _returnsB := api.apiImpl.GetJob(jobID)
+	api.recordTime(startTime, "GetJob", _returnsB == nil)
+	return _returnsA, _returnsB
+}
+
+func (api *apiTimerLayer) SetJobProgress(jobID string, progress int64) error {
+	startTime := timePkg.Now()
+	_returnsA := api.apiImpl.SetJobProgress(jobID, progress)
+	api.recordTime(startTime, "SetJobProgress", _returnsA == nil)
+	return _returnsA
+}
@@ -19,6 +19,7 @@ import (
 	"net/url"
 	"os"
 	"reflect"
+	"runtime"
 	"sync"
 
 	"github.com/go-sql-driver/mysql"
@@ -234,6 +235,27 @@ func (s *hooksRPCServer) Implemented(args struct{}, reply *[]string) error {
 	return encodableError(nil)
 }
 
+// PluginRuntimeStats describes the Go runtime of a plugin process.
+type PluginRuntimeStats struct {
+	Goroutines int
+}
+
+// RuntimeStats asks the plugin process for statistics about its runtime. Plugins built against
+// an older version of this package don't implement it and return an error.
+func (g *hooksRPCClient) RuntimeStats() (*PluginRuntimeStats, error) {
+	stats := &PluginRuntimeStats{}
+	if err := g.client.Call("Plugin.RuntimeStats", struct{}{}, stats); err != nil {
+		return nil, err
+	}
+	return stats, nil
+}
+
+// RuntimeStats replies with statistics about the runtime of the plugin process.
+func (s *hooksRPCServer) RuntimeStats(args struct{}, reply *PluginRuntimeStats) error {
+	reply.Goroutines = runtime.NumGoroutine()
+	return nil
+}
+
 type Z_OnActivateArgs struct {
 	APIMuxId    uint32
 	DriverMuxId uint32
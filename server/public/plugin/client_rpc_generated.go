@@ -153,6 +153,43 @@ func (s *hooksRPCServer) UserHasBeenCreated(args *Z_UserHasBeenCreatedArgs, retu
 	return nil
 }
 
+func init() {
+	hookNameToId["UserWillBeUpdated"] = UserWillBeUpdatedID
+}
+
+type Z_UserWillBeUpdatedArgs struct {
+	A *Context
+	B *model.User
+	C *model.User
+}
+
+type Z_UserWillBeUpdatedReturns struct {
+	A *model.User
+	B string
+}
+
+func (g *hooksRPCClient) UserWillBeUpdated(c *Context, newUser, oldUser *model.User) (*model.User, string) {
+	_args := &Z_UserWillBeUpdatedArgs{c, newUser, oldUser}
+	_returns := &Z_UserWillBeUpdatedReturns{}
+	if g.implemented[UserWillBeUpdatedID] {
+		if err := g.client.Call("Plugin.UserWillBeUpdated", _args, _returns); err != nil {
+			g.log.Error("RPC call UserWillBeUpdated to plugin failed.", mlog.Err(err))
+		}
+	}
+	return _returns.A, _returns.B
+}
+
+func (s *hooksRPCServer) UserWillBeUpdated(args *Z_UserWillBeUpdatedArgs, returns *Z_UserWillBeUpdatedReturns) error {
+	if hook, ok := s.impl.(interface {
+		UserWillBeUpdated(c *Context, newUser, oldUser *model.User) (*model.User, string)
+	}); ok {
+		returns.A, returns.B = hook.UserWillBeUpdated(args.A, args.B, args.C)
+	} else {
+		return encodableError(fmt.Errorf("Hook UserWillBeUpdated called but not implemented."))
+	}
+	return nil
+}
+
 func init() {
 	hookNameToId["UserWillLogIn"] = UserWillLogInID
 }
@@ -325,6 +362,149 @@ func (s *hooksRPCServer) MessageHasBeenDeleted(args *Z_MessageHasBeenDeletedArgs
 	return nil
 }
 
+func init() {
+	hookNameToId["ChannelWillBeCreated"] = ChannelWillBeCreatedID
+}
+
+type Z_ChannelWillBeCreatedArgs struct {
+	A *Context
+	B *model.Channel
+}
+
+type Z_ChannelWillBeCreatedReturns struct {
+	A *model.Channel
+	B string
+}
+
+func (g *hooksRPCClient) ChannelWillBeCreated(c *Context, channel *model.Channel) (*model.Channel, string) {
+	_args := &Z_ChannelWillBeCreatedArgs{c, channel}
+	_returns := &Z_ChannelWillBeCreatedReturns{}
+	if g.implemented[ChannelWillBeCreatedID] {
+		if err := g.client.Call("Plugin.ChannelWillBeCreated", _args, _returns); err != nil {
+			g.log.Error("RPC call ChannelWillBeCreated to plugin failed.", mlog.Err(err))
+		}
+	}
+	return _returns.A, _returns.B
+}
+
+func (s *hooksRPCServer) ChannelWillBeCreated(args *Z_ChannelWillBeCreatedArgs, returns *Z_ChannelWillBeCreatedReturns) error {
+	if hook, ok := s.impl.(interface {
+		ChannelWillBeCreated(c *Context, channel *model.Channel) (*model.Channel, string)
+	}); ok {
+		returns.A, returns.B = hook.ChannelWillBeCreated(args.A, args.B)
+	} else {
+		return encodableError(fmt.Errorf("Hook ChannelWillBeCreated called but not implemented."))
+	}
+	return nil
+}
+
+func init() {
+	hookNameToId["ChannelWillBeUpdated"] = ChannelWillBeUpdatedID
+}
+
+type Z_ChannelWillBeUpdatedArgs struct {
+	A *Context
+	B *model.Channel
+	C *model.Channel
+}
+
+type Z_ChannelWillBeUpdatedReturns struct {
+	A *model.Channel
+	B string
+}
+
+func (g *hooksRPCClient) ChannelWillBeUpdated(c *Context, newChannel, oldChannel *model.Channel) (*model.Channel, string) {
+	_args := &Z_ChannelWillBeUpdatedArgs{c, newChannel, oldChannel}
+	_returns := &Z_ChannelWillBeUpdatedReturns{}
+	if g.implemented[ChannelWillBeUpdatedID] {
+		if err := g.client.Call("Plugin.ChannelWillBeUpdated", _args, _returns); err != nil {
+			g.log.Error("RPC call ChannelWillBeUpdated to plugin failed.", mlog.Err(err))
+		}
+	}
+	return _returns.A, _returns.B
+}
+
+func (s *hooksRPCServer) ChannelWillBeUpdated(args *Z_ChannelWillBeUpdatedArgs, returns *Z_ChannelWillBeUpdatedReturns) error {
+	if hook, ok := s.impl.(interface {
+		ChannelWillBeUpdated(c *Context, newChannel, oldChannel *model.Channel) (*model.Channel, string)
+	}); ok {
+		returns.A, returns.B = hook.ChannelWillBeUpdated(args.A, args.B, args.C)
+	} else {
+		return encodableError(fmt.Errorf("Hook ChannelWillBeUpdated called but not implemented."))
+	}
+	return nil
+}
+
+func init() {
+	hookNameToId["ChannelWillBeArchived"] = ChannelWillBeArchivedID
+}
+
+type Z_ChannelWillBeArchivedArgs struct {
+	A *Context
+	B *model.Channel
+}
+
+type Z_ChannelWillBeArchivedReturns struct {
+	A string
+}
+
+func (g *hooksRPCClient) ChannelWillBeArchived(c *Context, channel *model.Channel) string {
+	_args := &Z_ChannelWillBeArchivedArgs{c, channel}
+	_returns := &Z_ChannelWillBeArchivedReturns{}
+	if g.implemented[ChannelWillBeArchivedID] {
+		if err := g.client.Call("Plugin.ChannelWillBeArchived", _args, _returns); err != nil {
+			g.log.Error("RPC call ChannelWillBeArchived to plugin failed.", mlog.Err(err))
+		}
+	}
+	return _returns.A
+}
+
+func (s *hooksRPCServer) ChannelWillBeArchived(args *Z_ChannelWillBeArchivedArgs, returns *Z_ChannelWillBeArchivedReturns) error {
+	if hook, ok := s.impl.(interface {
+		ChannelWillBeArchived(c *Context, channel *model.Channel) string
+	}); ok {
+		returns.A = hook.ChannelWillBeArchived(args.A, args.B)
+	} else {
+		return encodableError(fmt.Errorf("Hook ChannelWillBeArchived called but not implemented."))
+	}
+	return nil
+}
+
+func init() {
+	hookNameToId["ChannelWillBeUnarchived"] = ChannelWillBeUnarchivedID
+}
+
+type Z_ChannelWillBeUnarchivedArgs struct {
+	A *Context
+	B *model.Channel
+}
+
+type Z_ChannelWillBeUnarchivedReturns struct {
+	A string
+}
+
+func (g *hooksRPCClient) ChannelWillBeUnarchived(c *Context, channel *model.Channel) string {
+	_args := &Z_ChannelWillBeUnarchivedArgs{c, channel}
+	_returns := &Z_ChannelWillBeUnarchivedReturns{}
+	if g.implemented[ChannelWillBeUnarchivedID] {
+		if err := g.client.Call("Plugin.ChannelWillBeUnarchived", _args, _returns); err != nil {
+			g.log.Error("RPC call ChannelWillBeUnarchived to plugin failed.", mlog.Err(err))
+		}
+	}
+	return _returns.A
+}
+
+func (s *hooksRPCServer) ChannelWillBeUnarchived(args *Z_ChannelWillBeUnarchivedArgs, returns *Z_ChannelWillBeUnarchivedReturns) error {
+	if hook, ok := s.impl.(interface {
+		ChannelWillBeUnarchived(c *Context, channel *model.Channel) string
+	}); ok {
+		returns.A = hook.ChannelWillBeUnarchived(args.A, args.B)
+	} else {
+		return encodableError(fmt.Errorf("Hook ChannelWillBeUnarchived called but not implemented."))
+	}
+	return nil
+}
+
 func init() {
 	hookNameToId["ChannelHasBeenCreated"] = ChannelHasBeenCreatedID
 }
@@ -429,6 +609,43 @@ func (s *hooksRPCServer) UserHasLeftChannel(args *Z_UserHasLeftChannelArgs, retu
 	return nil
 }
 
+func init() {
+	hookNameToId["TeamWillBeJoined"] = TeamWillBeJoinedID
+}
+
+type Z_TeamWillBeJoinedArgs struct {
+	A *Context
+	B *model.Team
+	C *model.User
+	D *model.User
+}
+
+type Z_TeamWillBeJoinedReturns struct {
+	A string
+}
+
+func (g *hooksRPCClient) TeamWillBeJoined(c *Context, team *model.Team, user *model.User, actor *model.User) string {
+	_args := &Z_TeamWillBeJoinedArgs{c, team, user, actor}
+	_returns := &Z_TeamWillBeJoinedReturns{}
+	if g.implemented[TeamWillBeJoinedID] {
+		if err := g.client.Call("Plugin.TeamWillBeJoined", _args, _returns); err != nil {
+			g.log.Error("RPC call TeamWillBeJoined to plugin failed.", mlog.Err(err))
+		}
+	}
+	return _returns.A
+}
+
+func (s *hooksRPCServer) TeamWillBeJoined(args *Z_TeamWillBeJoinedArgs, returns *Z_TeamWillBeJoinedReturns) error {
+	if hook, ok := s.impl.(interface {
+		TeamWillBeJoined(c *Context, team *model.Team, user *model.User, actor *model.User) string
+	}); ok {
+		returns.A = hook.TeamWillBeJoined(args.A, args.B, args.C, args.D)
+	} else {
+		return encodableError(fmt.Errorf("Hook TeamWillBeJoined called but not implemented."))
+	}
+	return nil
+}
+
 func init() {
 	hookNameToId["UserHasJoinedTeam"] = UserHasJoinedTeamID
 }
@@ -914,6 +1131,41 @@ func (s *hooksRPCServer) NotificationWillBePushed(args *Z_NotificationWillBePush
 	return nil
 }
 
+func init() {
+	hookNameToId["EmailNotificationWillBeSent"] = EmailNotificationWillBeSentID
+}
+
+type Z_EmailNotificationWillBeSentArgs struct {
+	A *model.EmailNotification
+}
+
+type Z_EmailNotificationWillBeSentReturns struct {
+	A *model.EmailNotification
+	B string
+}
+
+func (g *hooksRPCClient) EmailNotificationWillBeSent(emailNotification *model.EmailNotification) (*model.EmailNotification, string) {
+	_args := &Z_EmailNotificationWillBeSentArgs{emailNotification}
+	_returns := &Z_EmailNotificationWillBeSentReturns{}
+	if g.implemented[EmailNotificationWillBeSentID] {
+		if err := g.client.Call("Plugin.EmailNotificationWillBeSent", _args, _returns); err != nil {
+			g.log.Error("RPC call EmailNotificationWillBeSent to plugin failed.", mlog.Err(err))
+		}
+	}
+	return _returns.A, _returns.B
+}
+
+func (s *hooksRPCServer) EmailNotificationWillBeSent(args *Z_EmailNotificationWillBeSentArgs, returns *Z_EmailNotificationWillBeSentReturns) error {
+	if hook, ok := s.impl.(interface {
+		EmailNotificationWillBeSent(emailNotification *model.EmailNotification) (*model.EmailNotification, string)
+	}); ok {
+		returns.A, returns.B = hook.EmailNotificationWillBeSent(args.A)
+	} else {
+		return encodableError(fmt.Errorf("Hook EmailNotificationWillBeSent called but not implemented."))
+	}
+	return nil
+}
+
 func init() {
 	hookNameToId["UserHasBeenDeactivated"] = UserHasBeenDeactivatedID
 }
@@ -1162,6 +1414,79 @@ func (s *hooksRPCServer) GenerateSupportData(args *Z_GenerateSupportDataArgs, re
 	return nil
 }
 
+func init() {
+	hookNameToId["SearchWillBeExecuted"] = SearchWillBeExecutedID
+}
+
+type Z_SearchWillBeExecutedArgs struct {
+	A *Context
+	B *model.PluginSearch
+}
+
+type Z_SearchWillBeExecutedReturns struct {
+	A *model.PluginSearch
+	B string
+}
+
+func (g *hooksRPCClient) SearchWillBeExecuted(c *Context, search *model.PluginSearch) (*model.PluginSearch, string) {
+	_args := &Z_SearchWillBeExecutedArgs{c, search}
+	_returns := &Z_SearchWillBeExecutedReturns{}
+	if g.implemented[SearchWillBeExecutedID] {
+		if err := g.client.Call("Plugin.SearchWillBeExecuted", _args, _returns); err != nil {
+			g.log.Error("RPC call SearchWillBeExecuted to plugin failed.", mlog.Err(err))
+		}
+	}
+	return _returns.A, _returns.B
+}
+
+func (s *hooksRPCServer) SearchWillBeExecuted(args *Z_SearchWillBeExecutedArgs, returns *Z_SearchWillBeExecutedReturns) error {
+	if hook, ok := s.impl.(interface {
+		SearchWillBeExecuted(c *Context, search *model.PluginSearch) (*model.PluginSearch, string)
+	}); ok {
+		returns.A, returns.B = hook.SearchWillBeExecuted(args.A, args.B)
+	} else {
+		return encodableError(fmt.Errorf("Hook SearchWillBeExecuted called but not implemented."))
+	}
+	return nil
+}
+
+func init() {
+	hookNameToId["SearchResultsProvided"] = SearchResultsProvidedID
+}
+
+type Z_SearchResultsProvidedArgs struct {
+	A *Context
+	B *model.PluginSearch
+}
+
+type Z_SearchResultsProvidedReturns struct {
+	A *model.PostSearchResults
+	B error
+}
+
+func (g *hooksRPCClient) SearchResultsProvided(c *Context, search *model.PluginSearch) (*model.PostSearchResults, error) {
+	_args := &Z_SearchResultsProvidedArgs{c, search}
+	_returns := &Z_SearchResultsProvidedReturns{}
+	if g.implemented[SearchResultsProvidedID] {
+		if err := g.client.Call("Plugin.SearchResultsProvided", _args, _returns); err != nil {
+			g.log.Error("RPC call SearchResultsProvided to plugin failed.", mlog.Err(err))
+		}
+	}
+	return _returns.A, _returns.B
+}
+
+func (s *hooksRPCServer) SearchResultsProvided(args *Z_SearchResultsProvidedArgs, returns *Z_SearchResultsProvidedReturns) error {
+	if hook, ok := s.impl.(interface {
+		SearchResultsProvided(c *Context, search *model.PluginSearch) (*model.PostSearchResults, error)
+	}); ok {
+		returns.A, returns.B = hook.SearchResultsProvided(args.A, args.B)
+		returns.B = encodableError(returns.B)
+	} else {
+		return encodableError(fmt.Errorf("Hook SearchResultsProvided called but not implemented."))
+	}
+	return nil
+}
+
 func init() {
 	hookNameToId["OnSAMLLogin"] = OnSAMLLoginID
 }
@@ -1199,6 +1524,41 @@ func (s *hooksRPCServer) OnSAMLLogin(args *Z_OnSAMLLoginArgs, returns *Z_OnSAMLL
 	return nil
 }
 
+func init() {
+	hookNameToId["RunJob"] = RunJobID
+}
+
+type Z_RunJobArgs struct {
+	A *model.Job
+}
+
+type Z_RunJobReturns struct {
+	A error
+}
+
+func (g *hooksRPCClient) RunJob(job *model.Job) error {
+	_args := &Z_RunJobArgs{job}
+	_returns := &Z_RunJobReturns{}
+	if g.implemented[RunJobID] {
+		if err := g.client.Call("Plugin.RunJob", _args, _returns); err != nil {
+			g.log.Error("RPC call RunJob to plugin failed.", mlog.Err(err))
+		}
+	}
+	return _returns.A
+}
+
+func (s *hooksRPCServer) RunJob(args *Z_RunJobArgs, returns *Z_RunJobReturns) error {
+	if hook, ok := s.impl.(interface {
+		RunJob(job *model.Job) error
+	}); ok {
+		returns.A = hook.RunJob(args.A)
+		returns.A = encodableError(returns.A)
+	} else {
+		return encodableError(fmt.Errorf("Hook RunJob called but not implemented."))
+	}
+	return nil
+}
+
 type Z_RegisterCommandArgs struct {
 	A *model.Command
 }
@@ -7813,3 +8173,124 @@ func (s *apiRPCServer) DeletePropertyValuesForField(args *Z_DeletePropertyValues
 	}
 	return nil
 }
+
+type Z_RegisterJobTypeArgs struct {
+	A string
+	B *model.PluginJobTypeOptions
+}
+
+type Z_RegisterJobTypeReturns struct {
+	A error
+}
+
+func (g *apiRPCClient) RegisterJobType(name string, options *model.PluginJobTypeOptions) error {
+	_args := &Z_RegisterJobTypeArgs{name, options}
+	_returns := &Z_RegisterJobTypeReturns{}
+	if err := g.client.Call("Plugin.RegisterJobType", _args, _returns); err != nil {
+		log.Printf("RPC call to RegisterJobType API failed: %s", err.Error())
+	}
+	return _returns.A
+}
+
+func (s *apiRPCServer) RegisterJobType(args *Z_RegisterJobTypeArgs, returns *Z_RegisterJobTypeReturns) error {
+	if hook, ok := s.impl.(interface {
+		RegisterJobType(name string, options *model.PluginJobTypeOptions) error
+	}); ok {
+		returns.A = hook.RegisterJobType(args.A, args.B)
+		returns.A = encodableError(returns.A)
+	} else {
+		return encodableError(fmt.Errorf("API RegisterJobType called but not implemented."))
+	}
+	return nil
+}
+
+type Z_CreateJobArgs struct {
+	A string
+	B map[string]string
+}
+
+type Z_CreateJobReturns struct {
+	A *model.Job
+	B error
+}
+
+func (g *apiRPCClient) CreateJob(name string, data map[string]string) (*model.Job, error) {
+	_args := &Z_CreateJobArgs{name, data}
+	_returns := &Z_CreateJobReturns{}
+	if err := g.client.Call("Plugin.CreateJob", _args, _returns); err != nil {
+		log.Printf("RPC call to CreateJob API failed: %s", err.Error())
+	}
+	return _returns.A, _returns.B
+}
+
+func (s *apiRPCServer) CreateJob(args *Z_CreateJobArgs, returns *Z_CreateJobReturns) error {
+	if hook, ok := s.impl.(interface {
+		CreateJob(name string, data map[string]string) (*model.Job, error)
+	}); ok {
+		returns.A, returns.B = hook.CreateJob(args.A, args.B)
+		returns.B = encodableError(returns.B)
+	} else {
+		return encodableError(fmt.Errorf("API CreateJob called but not implemented."))
+	}
+	return nil
+}
+
+type Z_GetJobArgs struct {
+	A string
+}
+
+type Z_GetJobReturns struct {
+	A *model.Job
+	B error
+}
+
+func (g *apiRPCClient) GetJob(jobID string) (*model.Job, error) {
+	_args := &Z_GetJobArgs{jobID}
+	_returns := &Z_GetJobReturns{}
+	if err := g.client.Call("Plugin.GetJob", _args, _returns); err != nil {
+		log.Printf("RPC call to GetJob API failed: %s", err.Error())
+	}
+	return _returns.A, _returns.B
+}
+
+func (s *apiRPCServer) GetJob(args *Z_GetJobArgs, returns *Z_GetJobReturns) error {
+	if hook, ok := s.impl.(interface {
+		GetJob(jobID string) (*model.Job, error)
+	}); ok {
+		returns.A, returns.B = hook.GetJob(args.A)
+		returns.B = encodableError(returns.B)
+	} else {
+		return encodableError(fmt.Errorf("API GetJob called but not implemented."))
+	}
+	return nil
+}
+
+type Z_SetJobProgressArgs struct {
+	A string
+	B int64
+}
+
+type Z_SetJobProgressReturns struct {
+	A error
+}
+
+func (g *apiRPCClient) SetJobProgress(jobID string, progress int64) error {
+	_args := &Z_SetJobProgressArgs{jobID, progress}
+	_returns := &Z_SetJobProgressReturns{}
+	if err := g.client.Call("Plugin.SetJobProgress", _args, _returns); err != nil {
+		log.Printf("RPC call to SetJobProgress API failed: %s", err.Error())
+	}
+	return _returns.A
+}
+
+func (s *apiRPCServer) SetJobProgress(args *Z_SetJobProgressArgs, returns *Z_SetJobProgressReturns) error {
+	if hook, ok := s.impl.(interface {
+		SetJobProgress(jobID string, progress int64) error
+	}); ok {
+		returns.A = hook.SetJobProgress(args.A, args.B)
+		returns.A = encodableError(returns.A)
+	} else {
+		return encodableError(fmt.Errorf("API SetJobProgress called but not implemented."))
+	}
+	return nil
+}
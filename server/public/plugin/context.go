@@ -12,4 +12,7 @@ type Context struct {
 	IPAddress      string
 	AcceptLanguage string
 	UserAgent      string
+	// TraceParent is the W3C traceparent of the span of the hook, set when
+	// the request invoking it is traced.
+	TraceParent string
 }
@@ -0,0 +1,77 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package plugin
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/mattermost/mattermost/server/public/model"
+)
+
+// GroupByDependencies splits the given plugins into groups to activate in order, such that the
+// dependencies of a plugin among the given plugins are in an earlier group. Plugins in the same
+// group can be activated concurrently. Plugins with circular dependencies are in the last group,
+// and fail to activate.
+func GroupByDependencies(plugins []*model.BundleInfo) [][]*model.BundleInfo {
+	var groups [][]*model.BundleInfo
+
+	remaining := slices.Clone(plugins)
+	for len(remaining) > 0 {
+		pending := func(id string) bool {
+			return slices.ContainsFunc(remaining, func(p *model.BundleInfo) bool { return p.Manifest != nil && p.Manifest.Id == id })
+		}
+
+		var group, rest []*model.BundleInfo
+		for _, p := range remaining {
+			ready := true
+			if p.Manifest != nil {
+				for _, dependency := range p.Manifest.Dependencies {
+					if pending(dependency.Id) {
+						ready = false
+						break
+					}
+				}
+			}
+
+			if ready {
+				group = append(group, p)
+			} else {
+				rest = append(rest, p)
+			}
+		}
+
+		if len(group) == 0 {
+			return append(groups, rest)
+		}
+
+		groups = append(groups, group)
+		remaining = rest
+	}
+
+	return groups
+}
+
+// unmetRequirements returns a description of each requirement of the given plugin not currently
+// satisfied, including dependencies installed but not running.
+func (env *Environment) unmetRequirements(manifest *model.Manifest, plugins []*model.BundleInfo) []string {
+	installed := make([]*model.Manifest, 0, len(plugins))
+	for _, p := range plugins {
+		if p.Manifest != nil {
+			installed = append(installed, p.Manifest)
+		}
+	}
+
+	unmet := manifest.UnmetServerRequirements()
+	unmet = append(unmet, manifest.UnmetDependencies(installed)...)
+
+	for _, dependency := range manifest.Dependencies {
+		i := slices.IndexFunc(installed, func(m *model.Manifest) bool { return m.Id == dependency.Id })
+		if i != -1 && dependency.SatisfiedBy(installed[i].Version) && !env.IsActive(dependency.Id) {
+			unmet = append(unmet, fmt.Sprintf("requires plugin %s, which is not running", dependency))
+		}
+	}
+
+	return unmet
+}
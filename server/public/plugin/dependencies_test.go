@@ -0,0 +1,131 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package plugin
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/public/shared/mlog"
+)
+
+func TestGroupByDependencies(t *testing.T) {
+	bundle := func(id string, dependencies ...string) *model.BundleInfo {
+		manifest := &model.Manifest{Id: id}
+		for _, dependency := range dependencies {
+			manifest.Dependencies = append(manifest.Dependencies, &model.ManifestDependency{Id: dependency})
+		}
+		return &model.BundleInfo{Manifest: manifest}
+	}
+
+	ids := func(groups [][]*model.BundleInfo) [][]string {
+		var result [][]string
+		for _, group := range groups {
+			var groupIDs []string
+			for _, p := range group {
+				groupIDs = append(groupIDs, p.Manifest.Id)
+			}
+			result = append(result, groupIDs)
+		}
+		return result
+	}
+
+	t.Run("no plugins", func(t *testing.T) {
+		assert.Empty(t, GroupByDependencies(nil))
+	})
+
+	t.Run("no dependencies", func(t *testing.T) {
+		groups := GroupByDependencies([]*model.BundleInfo{bundle("a"), bundle("b")})
+		assert.Equal(t, [][]string{{"a", "b"}}, ids(groups))
+	})
+
+	t.Run("dependencies first", func(t *testing.T) {
+		groups := GroupByDependencies([]*model.BundleInfo{
+			bundle("app", "lib", "ui"),
+			bundle("ui", "lib"),
+			bundle("lib"),
+			bundle("standalone"),
+		})
+		assert.Equal(t, [][]string{{"lib", "standalone"}, {"ui"}, {"app"}}, ids(groups))
+	})
+
+	t.Run("dependencies outside of the given plugins are ignored", func(t *testing.T) {
+		groups := GroupByDependencies([]*model.BundleInfo{bundle("a", "disabled"), bundle("b", "a")})
+		assert.Equal(t, [][]string{{"a"}, {"b"}}, ids(groups))
+	})
+
+	t.Run("circular dependencies are last", func(t *testing.T) {
+		groups := GroupByDependencies([]*model.BundleInfo{bundle("a", "b"), bundle("b", "a"), bundle("c")})
+		assert.Equal(t, [][]string{{"c"}, {"a", "b"}}, ids(groups))
+	})
+}
+
+func TestActivateWithDependencies(t *testing.T) {
+	pluginDir := t.TempDir()
+
+	writeWebappPlugin := func(t *testing.T, manifest *model.Manifest) {
+		t.Helper()
+
+		dir := filepath.Join(pluginDir, manifest.Id)
+		require.NoError(t, os.MkdirAll(filepath.Join(dir, "webapp"), 0700))
+		require.NoError(t, os.WriteFile(filepath.Join(dir, "webapp", "main.js"), []byte("// "+manifest.Id), 0600))
+
+		manifest.Webapp = &model.ManifestWebapp{BundlePath: "webapp/main.js"}
+		data, err := json.Marshal(manifest)
+		require.NoError(t, err)
+		require.NoError(t, os.WriteFile(filepath.Join(dir, "plugin.json"), data, 0600))
+	}
+
+	writeWebappPlugin(t, &model.Manifest{Id: "lib", Version: "1.0.0"})
+	writeWebappPlugin(t, &model.Manifest{Id: "app", Version: "1.0.0", Dependencies: []*model.ManifestDependency{{Id: "lib", Version: ">=1.0.0"}}})
+	writeWebappPlugin(t, &model.Manifest{Id: "newer", Version: "1.0.0", Dependencies: []*model.ManifestDependency{{Id: "lib", Version: ">=2.0.0"}}})
+	writeWebappPlugin(t, &model.Manifest{Id: "future", Version: "1.0.0", RequiredCapabilities: []string{"teleportation"}})
+
+	env, err := NewEnvironment(nil, nil, pluginDir, t.TempDir(), mlog.CreateConsoleTestLogger(t), nil)
+	require.NoError(t, err)
+
+	unmetRequirements := func(t *testing.T, id string) []string {
+		t.Helper()
+
+		statuses, err := env.Statuses()
+		require.NoError(t, err)
+		for _, status := range statuses {
+			if status.PluginId == id {
+				return status.UnmetRequirements
+			}
+		}
+		require.Failf(t, "plugin status not found", id)
+		return nil
+	}
+
+	_, activated, err := env.Activate("app")
+	require.Error(t, err)
+	assert.False(t, activated)
+	assert.Contains(t, err.Error(), "requires plugin lib >=1.0.0, which is not running")
+	assert.Equal(t, model.PluginStateFailedToStart, env.GetPluginState("app"))
+	assert.Equal(t, []string{"requires plugin lib >=1.0.0, which is not running"}, unmetRequirements(t, "app"))
+
+	_, activated, err = env.Activate("lib")
+	require.NoError(t, err)
+	assert.True(t, activated)
+
+	_, activated, err = env.Activate("app")
+	require.NoError(t, err)
+	assert.True(t, activated)
+	assert.Empty(t, unmetRequirements(t, "app"))
+
+	_, _, err = env.Activate("newer")
+	require.Error(t, err)
+	assert.Equal(t, []string{"requires plugin lib >=2.0.0, but version 1.0.0 is installed"}, unmetRequirements(t, "newer"))
+
+	_, _, err = env.Activate("future")
+	require.Error(t, err)
+	assert.Equal(t, []string{"requires the unsupported server capability teleportation"}, unmetRequirements(t, "future"))
+}
@@ -8,7 +8,9 @@ import (
 	"hash/fnv"
 	"os"
 	"path/filepath"
+	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	plugin "github.com/hashicorp/go-plugin"
@@ -42,6 +44,8 @@ type PrepackagedPlugin struct {
 	IconData      string
 	Manifest      *model.Manifest
 	SignaturePath string
+	// Signature is the key the plugin is signed with, once verified.
+	Signature *model.PluginSignature
 }
 
 // Environment represents the execution environment of active plugins.
@@ -60,6 +64,10 @@ type Environment struct {
 	prepackagedPlugins               []*PrepackagedPlugin
 	transitionallyPrepackagedPlugins []*PrepackagedPlugin
 	prepackagedPluginsLock           sync.RWMutex
+	resourceLimits                   atomic.Pointer[map[string]*model.PluginResourceLimits]
+	resourceLimitExceededHandler     atomic.Pointer[ResourceLimitExceededHandler]
+	resourceLimitRestarts            sync.Map
+	migrationHandler                 atomic.Pointer[MigrationHandler]
 }
 
 func NewEnvironment(
@@ -206,6 +214,14 @@ func (env *Environment) setPluginSupervisor(id string, supervisor *supervisor) {
 	}
 }
 
+// getPluginSupervisor returns the supervisor of a registered plugin, if any.
+func (env *Environment) getPluginSupervisor(id string) *supervisor {
+	if rp, ok := env.registeredPlugins.Load(id); ok {
+		return rp.(registeredPlugin).supervisor
+	}
+	return nil
+}
+
 // PublicFilesPath returns a path and true if the plugin with the given id is active.
 // It returns an empty string and false if the path is not set or invalid
 func (env *Environment) PublicFilesPath(id string) (string, error) {
@@ -240,6 +256,13 @@ func (env *Environment) Statuses() (model.PluginStatuses, error) {
 			Description: plugin.Manifest.Description,
 			Version:     plugin.Manifest.Version,
 		}
+		if pluginState == model.PluginStateRunning {
+			if sup := env.getPluginSupervisor(plugin.Manifest.Id); sup != nil {
+				status.ResourceUsage = sup.ResourceUsage()
+			}
+		} else {
+			status.UnmetRequirements = env.unmetRequirements(plugin.Manifest, plugins)
+		}
 
 		pluginStatuses = append(pluginStatuses, status)
 	}
@@ -351,6 +374,14 @@ func (env *Environment) Activate(id string) (manifest *model.Manifest, activated
 		return nil, false, err
 	}
 
+	if unmet := env.unmetRequirements(pluginInfo.Manifest, plugins); len(unmet) > 0 {
+		return nil, false, fmt.Errorf("plugin has unmet requirements: %s: %v", strings.Join(unmet, "; "), id)
+	}
+
+	if err = env.applyMigrations(pluginInfo); err != nil {
+		return nil, false, errors.Wrapf(err, "unable to apply database migrations: %v", id)
+	}
+
 	componentActivated := false
 
 	if pluginInfo.Manifest.HasWebapp() {
@@ -468,6 +499,10 @@ func (env *Environment) Deactivate(id string) bool {
 		rp.supervisor.Shutdown()
 	}
 
+	if env.metrics != nil {
+		env.metrics.ClearPluginResourceUsage(id)
+	}
+
 	return true
 }
 
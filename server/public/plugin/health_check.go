@@ -48,11 +48,12 @@ func (job *PluginHealthCheckJob) run() {
 }
 
 // CheckPlugin determines the plugin's health status, then handles the error or success case.
-// If the plugin passes the health check, do nothing.
+// If the plugin passes the health check, its resource usage is checked against its limits.
 // If the plugin fails the health check, the function either restarts or deactivates the plugin, based on the quantity and frequency of its failures.
 func (job *PluginHealthCheckJob) CheckPlugin(id string) {
 	err := job.env.PerformHealthCheck(id)
 	if err == nil {
+		job.env.CheckResourceUsage(id)
 		return
 	}
 
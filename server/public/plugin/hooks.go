@@ -63,6 +63,16 @@ const (
 	OnSharedChannelsProfileImageSyncMsgID     = 44
 	GenerateSupportDataID                     = 45
 	OnSAMLLoginID                             = 46
+	ChannelWillBeCreatedID                    = 47
+	ChannelWillBeUpdatedID                    = 48
+	ChannelWillBeArchivedID                   = 49
+	ChannelWillBeUnarchivedID                 = 50
+	TeamWillBeJoinedID                        = 51
+	UserWillBeUpdatedID                       = 52
+	SearchWillBeExecutedID                    = 53
+	SearchResultsProvidedID                   = 54
+	EmailNotificationWillBeSentID             = 55
+	RunJobID                                  = 56
 	TotalHooksID                              = iota
 )
 
@@ -124,6 +134,17 @@ type Hooks interface {
 	// Minimum server version: 5.10
 	UserHasBeenCreated(c *Context, user *model.User)
 
+	// UserWillBeUpdated is invoked when a user's profile is updated, before it is committed to the
+	// database.
+	//
+	// To reject the update, return an non-empty string describing why the update was rejected.
+	// To modify the user, return the replacement, non-nil *model.User and an empty string.
+	// The id, roles, deletion, remote and authentication fields of the replacement are ignored.
+	// To allow the update without modification, return a nil *model.User and an empty string.
+	//
+	// Minimum server version: 10.12
+	UserWillBeUpdated(c *Context, newUser, oldUser *model.User) (*model.User, string)
+
 	// UserWillLogIn before the login of the user is returned. Returning a non empty string will reject the login event.
 	// If you don't need to reject the login event, see UserHasLoggedIn
 	//
@@ -196,6 +217,43 @@ type Hooks interface {
 	// Minimum server version: 9.1
 	MessageHasBeenDeleted(c *Context, post *model.Post)
 
+	// ChannelWillBeCreated is invoked when a channel is created, before it is committed to the
+	// database. It is not invoked for direct and group message channels.
+	//
+	// To reject the channel, return an non-empty string describing why the channel was rejected.
+	// To modify the channel, return the replacement, non-nil *model.Channel and an empty string.
+	// The team, type and creator of the replacement are ignored.
+	// To allow the channel without modification, return a nil *model.Channel and an empty string.
+	//
+	// If you don't need to modify or reject channels, use ChannelHasBeenCreated instead.
+	//
+	// Minimum server version: 10.12
+	ChannelWillBeCreated(c *Context, channel *model.Channel) (*model.Channel, string)
+
+	// ChannelWillBeUpdated is invoked when a channel is updated, before it is committed to the
+	// database. This includes changes to the name, header, purpose, privacy and scheme of the channel,
+	// and moves of the channel to another team.
+	//
+	// To reject the update, return an non-empty string describing why the update was rejected.
+	// To modify the channel, return the replacement, non-nil *model.Channel and an empty string.
+	// The id, team, type and creator of the replacement are ignored.
+	// To allow the update without modification, return a nil *model.Channel and an empty string.
+	//
+	// Minimum server version: 10.12
+	ChannelWillBeUpdated(c *Context, newChannel, oldChannel *model.Channel) (*model.Channel, string)
+
+	// ChannelWillBeArchived is invoked before a channel is archived. Returning a non empty string
+	// will reject the archival and keep the channel unchanged.
+	//
+	// Minimum server version: 10.12
+	ChannelWillBeArchived(c *Context, channel *model.Channel) string
+
+	// ChannelWillBeUnarchived is invoked before an archived channel is restored. Returning a non
+	// empty string will reject the restoration and keep the channel archived.
+	//
+	// Minimum server version: 10.12
+	ChannelWillBeUnarchived(c *Context, channel *model.Channel) string
+
 	// ChannelHasBeenCreated is invoked after the channel has been committed to the database.
 	//
 	// Minimum server version: 5.2
@@ -213,6 +271,13 @@ type Hooks interface {
 	// Minimum server version: 5.2
 	UserHasLeftChannel(c *Context, channelMember *model.ChannelMember, actor *model.User)
 
+	// TeamWillBeJoined is invoked before a user joins a team, or rejoins a team they have left.
+	// If actor is not nil, the user is being added to the team by the actor.
+	// Returning a non empty string will reject the membership.
+	//
+	// Minimum server version: 10.12
+	TeamWillBeJoined(c *Context, team *model.Team, user *model.User, actor *model.User) string
+
 	// UserHasJoinedTeam is invoked after the membership has been committed to the database.
 	// If actor is not nil, the user was added to the team by the actor.
 	//
@@ -327,6 +392,20 @@ type Hooks interface {
 	// Minimum server version: 9.0
 	NotificationWillBePushed(pushNotification *model.PushNotification, userID string) (*model.PushNotification, string)
 
+	// EmailNotificationWillBeSent is invoked before an email notification about new posts is sent,
+	// both for immediate notifications and for the notifications sent by email batching. The
+	// notification holds the recipient, the data the body was rendered with and the rendered
+	// subject and HTML body.
+	//
+	// To suppress the email, return an non-empty string describing why the email was suppressed.
+	// To modify or redirect the email, return the replacement, non-nil *model.EmailNotification and an
+	// empty string. Only the To, Subject and Body of the replacement are used, the empty ones
+	// keeping the original value.
+	// To send the email without modification, return a nil *model.EmailNotification and an empty string.
+	//
+	// Minimum server version: 10.12
+	EmailNotificationWillBeSent(emailNotification *model.EmailNotification) (*model.EmailNotification, string)
+
 	// UserHasBeenDeactivated is invoked when a user is deactivated.
 	//
 	// Minimum server version: 9.1
@@ -398,8 +477,43 @@ type Hooks interface {
 	// Minimum server version: 9.8
 	GenerateSupportData(c *Context) ([]*model.FileData, error)
 
+	// SearchWillBeExecuted is invoked before a user's post or file search is run, after the search
+	// terms have been parsed. The Type of the search tells posts and files apart.
+	//
+	// To reject the search, return an non-empty string describing why the search was rejected.
+	// To rewrite or restrict the search, return a non-nil *model.PluginSearch with the replacement
+	// Params and an empty string. Changes to the other fields of the search are ignored.
+	// To run the search without modification, return a nil *model.PluginSearch and an empty string.
+	//
+	// Minimum server version: 10.12
+	SearchWillBeExecuted(c *Context, search *model.PluginSearch) (*model.PluginSearch, string)
+
+	// SearchResultsProvided is invoked when a user searches posts, allowing the plugin to contribute
+	// results from its own sources, such as wiki pages or tickets. The returned posts are not saved:
+	// they are merged into the search results by CreateAt and marked with the from_plugin prop.
+	// Posts without an Id are given one.
+	//
+	// It is only invoked for the first page of the search results, the Page of the search being 0.
+	// The plugin is responsible for only returning results the user may see, and for returning at
+	// most PerPage results. A returned error is logged and the plugin's results are skipped.
+	//
+	// Minimum server version: 10.12
+	SearchResultsProvided(c *Context, search *model.PluginSearch) (*model.PostSearchResults, error)
+
 	// OnSAMLLogin is invoked after a successful SAML login.
 	//
 	// Minimum server version: 10.7
 	OnSAMLLogin(c *Context, user *model.User, assertion *saml2.AssertionInfo) error
+
+	// RunJob is invoked when a job of a type registered by the plugin through API.RegisterJobType
+	// is picked up by the job server. The job is marked as successful when the hook returns nil, and
+	// as failed otherwise.
+	//
+	// Long running jobs may report their progress with API.SetJobProgress, and should periodically
+	// check through API.GetJob whether their cancellation was requested. The server stops waiting for
+	// the hook once the cancellation is requested or the job server stops, leaving the job canceled or
+	// pending respectively.
+	//
+	// Minimum server version: 10.12
+	RunJob(job *model.Job) error
 }
@@ -57,61 +57,88 @@ func (hooks *hooksTimerLayer) OnConfigurationChange() error {
 }
 
 func (hooks *hooksTimerLayer) ServeHTTP(c *Context, w http.ResponseWriter, r *http.Request) {
+	c, span := hooks.startSpan(c, "ServeHTTP")
 	startTime := timePkg.Now()
 	hooks.hooksImpl.ServeHTTP(c, w, r)
 	hooks.recordTime(startTime, "ServeHTTP", true)
+	hooks.endSpan(span, true)
 }
 
 func (hooks *hooksTimerLayer) ExecuteCommand(c *Context, args *model.CommandArgs) (*model.CommandResponse, *model.AppError) {
+	c, span := hooks.startSpan(c, "ExecuteCommand")
 	startTime := timePkg.Now()
 	_returnsA, _returnsB := hooks.hooksImpl.ExecuteCommand(c, args)
 	hooks.recordTime(startTime, "ExecuteCommand", _returnsB == nil)
+	hooks.endSpan(span, _returnsB == nil)
 	return _returnsA, _returnsB
 }
 
 func (hooks *hooksTimerLayer) UserHasBeenCreated(c *Context, user *model.User) {
+	c, span := hooks.startSpan(c, "UserHasBeenCreated")
 	startTime := timePkg.Now()
 	hooks.hooksImpl.UserHasBeenCreated(c, user)
 	hooks.recordTime(startTime, "UserHasBeenCreated", true)
+	hooks.endSpan(span, true)
+}
+
+func (hooks *hooksTimerLayer) UserWillBeUpdated(c *Context, newUser, oldUser *model.User) (*model.User, string) {
+	c, span := hooks.startSpan(c, "UserWillBeUpdated")
+	startTime := timePkg.Now()
+	_returnsA, _returnsB := hooks.hooksImpl.UserWillBeUpdated(c, newUser, oldUser)
+	hooks.recordTime(startTime, "UserWillBeUpdated", true)
+	hooks.endSpan(span, true)
+	return _returnsA, _returnsB
 }
 
 func (hooks *hooksTimerLayer) UserWillLogIn(c *Context, user *model.User) string {
+	c, span := hooks.startSpan(c, "UserWillLogIn")
 	startTime := timePkg.Now()
 	_returnsA := hooks.hooksImpl.UserWillLogIn(c, user)
 	hooks.recordTime(startTime, "UserWillLogIn", true)
+	hooks.endSpan(span, true)
 	return _returnsA
 }
 
 func (hooks *hooksTimerLayer) UserHasLoggedIn(c *Context, user *model.User) {
+	c, span := hooks.startSpan(c, "UserHasLoggedIn")
 	startTime := timePkg.Now()
 	hooks.hooksImpl.UserHasLoggedIn(c, user)
 	hooks.recordTime(startTime, "UserHasLoggedIn", true)
+	hooks.endSpan(span, true)
 }
 
 func (hooks *hooksTimerLayer) MessageWillBePosted(c *Context, post *model.Post) (*model.Post, string) {
+	c, span := hooks.startSpan(c, "MessageWillBePosted")
 	startTime := timePkg.Now()
 	_returnsA, _returnsB := hooks.hooksImpl.MessageWillBePosted(c, post)
 	hooks.recordTime(startTime, "MessageWillBePosted", true)
+	hooks.endSpan(span, true)
 	return _returnsA, _returnsB
 }
 
 func (hooks *hooksTimerLayer) MessageWillBeUpdated(c *Context, newPost, oldPost *model.Post) (*model.Post, string) {
+	c, span := hooks.startSpan(c, "MessageWillBeUpdated")
 	startTime := timePkg.Now()
 	_returnsA, _returnsB := hooks.hooksImpl.MessageWillBeUpdated(c, newPost, oldPost)
 	hooks.recordTime(startTime, "MessageWillBeUpdated", true)
+	hooks.endSpan(span, true)
 	return _returnsA, _returnsB
 }
 
 func (hooks *hooksTimerLayer) MessageHasBeenPosted(c *Context, post *model.Post) {
+	c, span := hooks.startSpan(c, "MessageHasBeenPosted")
 	startTime := timePkg.Now()
 	hooks.hooksImpl.MessageHasBeenPosted(c, post)
 	hooks.recordTime(startTime, "MessageHasBeenPosted", true)
+	hooks.endSpan(span, true)
 }
 
 func (hooks *hooksTimerLayer) MessageHasBeenUpdated(c *Context, newPost, oldPost *model.Post) {
+	c, span := hooks.startSpan(c, "MessageHasBeenUpdated")
 	startTime := timePkg.Now()
 	hooks.hooksImpl.MessageHasBeenUpdated(c, newPost, oldPost)
 	hooks.recordTime(startTime, "MessageHasBeenUpdated", true)
+	hooks.endSpan(span, true)
 }
 
 func (hooks *hooksTimerLayer) MessagesWillBeConsumed(posts []*model.Post) []*model.Post {
@@ -122,64 +149,129 @@ func (hooks *hooksTimerLayer) MessagesWillBeConsumed(posts []*model.Post) []*mod
 }
 
 func (hooks *hooksTimerLayer) MessageHasBeenDeleted(c *Context, post *model.Post) {
+	c, span := hooks.startSpan(c, "MessageHasBeenDeleted")
 	startTime := timePkg.Now()
 	hooks.hooksImpl.MessageHasBeenDeleted(c, post)
 	hooks.recordTime(startTime, "MessageHasBeenDeleted", true)
+	hooks.endSpan(span, true)
+}
+
+func (hooks *hooksTimerLayer) ChannelWillBeCreated(c *Context, channel *model.Channel) (*model.Channel, string) {
+	c, span := hooks.startSpan(c, "ChannelWillBeCreated")
+	startTime := timePkg.Now()
+	_returnsA, _returnsB := hooks.hooksImpl.ChannelWillBeCreated(c, channel)
+	hooks.recordTime(startTime, "ChannelWillBeCreated", true)
+	hooks.endSpan(span, true)
+	return _returnsA, _returnsB
+}
+
+func (hooks *hooksTimerLayer) ChannelWillBeUpdated(c *Context, newChannel, oldChannel *model.Channel) (*model.Channel, string) {
+	c, span := hooks.startSpan(c, "ChannelWillBeUpdated")
+	startTime := timePkg.Now()
+	_returnsA, _returnsB := hooks.hooksImpl.ChannelWillBeUpdated(c, newChannel, oldChannel)
+	hooks.recordTime(startTime, "ChannelWillBeUpdated", true)
+	hooks.endSpan(span, true)
+	return _returnsA, _returnsB
+}
+
+func (hooks *hooksTimerLayer) ChannelWillBeArchived(c *Context, channel *model.Channel) string {
+	c, span := hooks.startSpan(c, "ChannelWillBeArchived")
+	startTime := timePkg.Now()
+	_returnsA := hooks.hooksImpl.ChannelWillBeArchived(c, channel)
+	hooks.recordTime(startTime, "ChannelWillBeArchived", true)
+	hooks.endSpan(span, true)
+	return _returnsA
+}
+
+func (hooks *hooksTimerLayer) ChannelWillBeUnarchived(c *Context, channel *model.Channel) string {
+	c, span := hooks.startSpan(c, "ChannelWillBeUnarchived")
+	startTime := timePkg.Now()
+	_returnsA := hooks.hooksImpl.ChannelWillBeUnarchived(c, channel)
+	hooks.recordTime(startTime, "ChannelWillBeUnarchived", true)
+	hooks.endSpan(span, true)
+	return _returnsA
 }
 
 func (hooks *hooksTimerLayer) ChannelHasBeenCreated(c *Context, channel *model.Channel) {
+	c, span := hooks.startSpan(c, "ChannelHasBeenCreated")
 	startTime := timePkg.Now()
 	hooks.hooksImpl.ChannelHasBeenCreated(c, channel)
 	hooks.recordTime(startTime, "ChannelHasBeenCreated", true)
+	hooks.endSpan(span, true)
 }
 
 func (hooks *hooksTimerLayer) UserHasJoinedChannel(c *Context, channelMember *model.ChannelMember, actor *model.User) {
+	c, span := hooks.startSpan(c, "UserHasJoinedChannel")
 	startTime := timePkg.Now()
 	hooks.hooksImpl.UserHasJoinedChannel(c, channelMember, actor)
 	hooks.recordTime(startTime, "UserHasJoinedChannel", true)
+	hooks.endSpan(span, true)
 }
 
 func (hooks *hooksTimerLayer) UserHasLeftChannel(c *Context, channelMember *model.ChannelMember, actor *model.User) {
+	c, span := hooks.startSpan(c, "UserHasLeftChannel")
 	startTime := timePkg.Now()
 	hooks.hooksImpl.UserHasLeftChannel(c, channelMember, actor)
 	hooks.recordTime(startTime, "UserHasLeftChannel", true)
+	hooks.endSpan(span, true)
+}
+
+func (hooks *hooksTimerLayer) TeamWillBeJoined(c *Context, team *model.Team, user *model.User, actor *model.User) string {
+	c, span := hooks.startSpan(c, "TeamWillBeJoined")
+	startTime := timePkg.Now()
+	_returnsA := hooks.hooksImpl.TeamWillBeJoined(c, team, user, actor)
+	hooks.recordTime(startTime, "TeamWillBeJoined", true)
+	hooks.endSpan(span, true)
+	return _returnsA
 }
 
 func (hooks *hooksTimerLayer) UserHasJoinedTeam(c *Context, teamMember *model.TeamMember, actor *model.User) {
+	c, span := hooks.startSpan(c, "UserHasJoinedTeam")
 	startTime := timePkg.Now()
 	hooks.hooksImpl.UserHasJoinedTeam(c, teamMember, actor)
 	hooks.recordTime(startTime, "UserHasJoinedTeam", true)
+	hooks.endSpan(span, true)
 }
 
 func (hooks *hooksTimerLayer) UserHasLeftTeam(c *Context, teamMember *model.TeamMember, actor *model.User) {
+	c, span := hooks.startSpan(c, "UserHasLeftTeam")
 	startTime := timePkg.Now()
 	hooks.hooksImpl.UserHasLeftTeam(c, teamMember, actor)
 	hooks.recordTime(startTime, "UserHasLeftTeam", true)
+	hooks.endSpan(span, true)
 }
 
 func (hooks *hooksTimerLayer) FileWillBeUploaded(c *Context, info *model.FileInfo, file io.Reader, output io.Writer) (*model.FileInfo, string) {
+	c, span := hooks.startSpan(c, "FileWillBeUploaded")
 	startTime := timePkg.Now()
 	_returnsA, _returnsB := hooks.hooksImpl.FileWillBeUploaded(c, info, file, output)
 	hooks.recordTime(startTime, "FileWillBeUploaded", true)
+	hooks.endSpan(span, true)
 	return _returnsA, _returnsB
 }
 
 func (hooks *hooksTimerLayer) ReactionHasBeenAdded(c *Context, reaction *model.Reaction) {
+	c, span := hooks.startSpan(c, "ReactionHasBeenAdded")
 	startTime := timePkg.Now()
 	hooks.hooksImpl.ReactionHasBeenAdded(c, reaction)
 	hooks.recordTime(startTime, "ReactionHasBeenAdded", true)
+	hooks.endSpan(span, true)
 }
 
 func (hooks *hooksTimerLayer) ReactionHasBeenRemoved(c *Context, reaction *model.Reaction) {
+	c, span := hooks.startSpan(c, "ReactionHasBeenRemoved")
 	startTime := timePkg.Now()
 	hooks.hooksImpl.ReactionHasBeenRemoved(c, reaction)
 	hooks.recordTime(startTime, "ReactionHasBeenRemoved", true)
+	hooks.endSpan(span, true)
 }
 
 func (hooks *hooksTimerLayer) OnPluginClusterEvent(c *Context, ev model.PluginClusterEvent) {
+	c, span := hooks.startSpan(c, "OnPluginClusterEvent")
 	startTime := timePkg.Now()
 	hooks.hooksImpl.OnPluginClusterEvent(c, ev)
 	hooks.recordTime(startTime, "OnPluginClusterEvent", true)
+	hooks.endSpan(span, true)
 }
 
 func (hooks *hooksTimerLayer) OnWebSocketConnect(webConnID, userID string) {
@@ -208,9 +300,11 @@ func (hooks *hooksTimerLayer) RunDataRetention(nowTime, batchSize int64) (int64,
 }
 
 func (hooks *hooksTimerLayer) OnInstall(c *Context, event model.OnInstallEvent) error {
+	c, span := hooks.startSpan(c, "OnInstall")
 	startTime := timePkg.Now()
 	_returnsA := hooks.hooksImpl.OnInstall(c, event)
 	hooks.recordTime(startTime, "OnInstall", _returnsA == nil)
+	hooks.endSpan(span, _returnsA == nil)
 	return _returnsA
 }
 
@@ -240,16 +334,27 @@ func (hooks *hooksTimerLayer) NotificationWillBePushed(pushNotification *model.P
 	return _returnsA, _returnsB
 }
 
+func (hooks *hooksTimerLayer) EmailNotificationWillBeSent(emailNotification *model.EmailNotification) (*model.EmailNotification, string) {
+	startTime := timePkg.Now()
+	_returnsA, _returnsB := hooks.hooksImpl.EmailNotificationWillBeSent(emailNotification)
+	hooks.recordTime(startTime, "EmailNotificationWillBeSent", true)
+	return _returnsA, _returnsB
+}
+
 func (hooks *hooksTimerLayer) UserHasBeenDeactivated(c *Context, user *model.User) {
+	c, span := hooks.startSpan(c, "UserHasBeenDeactivated")
 	startTime := timePkg.Now()
 	hooks.hooksImpl.UserHasBeenDeactivated(c, user)
 	hooks.recordTime(startTime, "UserHasBeenDeactivated", true)
+	hooks.endSpan(span, true)
 }
 
 func (hooks *hooksTimerLayer) ServeMetrics(c *Context, w http.ResponseWriter, r *http.Request) {
+	c, span := hooks.startSpan(c, "ServeMetrics")
 	startTime := timePkg.Now()
 	hooks.hooksImpl.ServeMetrics(c, w, r)
 	hooks.recordTime(startTime, "ServeMetrics", true)
+	hooks.endSpan(span, true)
 }
 
 func (hooks *hooksTimerLayer) OnSharedChannelsSyncMsg(msg *model.SyncMsg, rc *model.RemoteCluster) (model.SyncResponse, error) {
@@ -267,9 +372,11 @@ func (hooks *hooksTimerLayer) OnSharedChannelsPing(rc *model.RemoteCluster) bool
 }
 
 func (hooks *hooksTimerLayer) PreferencesHaveChanged(c *Context, preferences []model.Preference) {
+	c, span := hooks.startSpan(c, "PreferencesHaveChanged")
 	startTime := timePkg.Now()
 	hooks.hooksImpl.PreferencesHaveChanged(c, preferences)
 	hooks.recordTime(startTime, "PreferencesHaveChanged", true)
+	hooks.endSpan(span, true)
 }
 
 func (hooks *hooksTimerLayer) OnSharedChannelsAttachmentSyncMsg(fi *model.FileInfo, post *model.Post, rc *model.RemoteCluster) error {
@@ -287,15 +394,44 @@ func (hooks *hooksTimerLayer) OnSharedChannelsProfileImageSyncMsg(user *model.Us
 }
 
 func (hooks *hooksTimerLayer) GenerateSupportData(c *Context) ([]*model.FileData, error) {
+	c, span := hooks.startSpan(c, "GenerateSupportData")
 	startTime := timePkg.Now()
 	_returnsA, _returnsB := hooks.hooksImpl.GenerateSupportData(c)
 	hooks.recordTime(startTime, "GenerateSupportData", _returnsB == nil)
+	hooks.endSpan(span, _returnsB == nil)
+	return _returnsA, _returnsB
+}
+
+func (hooks *hooksTimerLayer) SearchWillBeExecuted(c *Context, search *model.PluginSearch) (*model.PluginSearch, string) {
+	c, span := hooks.startSpan(c, "SearchWillBeExecuted")
+	startTime := timePkg.Now()
+	_returnsA, _returnsB := hooks.hooksImpl.SearchWillBeExecuted(c, search)
+	hooks.recordTime(startTime, "SearchWillBeExecuted", true)
+	hooks.endSpan(span, true)
+	return _returnsA, _returnsB
+}
+
+func (hooks *hooksTimerLayer) SearchResultsProvided(c *Context, search *model.PluginSearch) (*model.PostSearchResults, error) {
+	c, span := hooks.startSpan(c, "SearchResultsProvided")
+	startTime := timePkg.Now()
+	_returnsA, _returnsB := hooks.hooksImpl.SearchResultsProvided(c, search)
+	hooks.recordTime(startTime, "SearchResultsProvided", _returnsB == nil)
+	hooks.endSpan(span, _returnsB == nil)
 	return _returnsA, _returnsB
 }
 
 func (hooks *hooksTimerLayer) OnSAMLLogin(c *Context, user *model.User, assertion *saml2.AssertionInfo) error {
+	c, span := hooks.startSpan(c, "OnSAMLLogin")
 	startTime := timePkg.Now()
 	_returnsA := hooks.hooksImpl.OnSAMLLogin(c, user, assertion)
 	hooks.recordTime(startTime, "OnSAMLLogin", _returnsA == nil)
+	hooks.endSpan(span, _returnsA == nil)
+	return _returnsA
+}
+
+func (hooks *hooksTimerLayer) RunJob(job *model.Job) error {
+	startTime := timePkg.Now()
+	_returnsA := hooks.hooksImpl.RunJob(job)
+	hooks.recordTime(startTime, "RunJob", _returnsA == nil)
 	return _returnsA
 }
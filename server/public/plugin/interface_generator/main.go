@@ -183,6 +183,25 @@ func FieldListToRecordSuccess(structPrefix string, fieldList *ast.FieldList) str
 	return fmt.Sprintf("%s == nil", result)
 }
 
+// FieldListHasContext returns true if the first parameter is the c *Context
+// passed to the hooks invoked on behalf of a request.
+func FieldListHasContext(fieldList *ast.FieldList) bool {
+	if fieldList == nil || len(fieldList.List) == 0 {
+		return false
+	}
+
+	field := fieldList.List[0]
+	if len(field.Names) == 0 || field.Names[0].Name != "c" {
+		return false
+	}
+	star, ok := field.Type.(*ast.StarExpr)
+	if !ok {
+		return false
+	}
+	ident, ok := star.X.(*ast.Ident)
+	return ok && ident.Name == "Context"
+}
+
 func FieldListToStructList(fieldList *ast.FieldList, fileset *token.FileSet) string {
 	result := []string{}
 	if fieldList == nil || len(fieldList.List) == 0 {
@@ -463,9 +482,15 @@ func (hooks *hooksTimerLayer) recordTime(startTime timePkg.Time, name string, su
 {{range .HooksMethods}}
 
 func (hooks *hooksTimerLayer) {{.Name}}{{funcStyle .Params}} {{funcStyle .Return}} {
+	{{- if hasContext .Params }}
+	c, span := hooks.startSpan(c, "{{.Name}}")
+	{{- end }}
 	startTime := timePkg.Now()
 	{{ if .Return }} {{destruct "_returns" .Return}} := {{ end }} hooks.hooksImpl.{{.Name}}({{valuesOnly .Params}})
 	hooks.recordTime(startTime, "{{.Name}}", {{ shouldRecordSuccess "_returns" .Return }})
+	{{- if hasContext .Params }}
+	hooks.endSpan(span, {{ shouldRecordSuccess "_returns" .Return }})
+	{{- end }}
 	{{ if .Return }} return {{destruct "_returns" .Return}} {{end -}}
 }
 
@@ -549,6 +574,9 @@ func generatePluginTimerLayer(info *PluginInterfaceInfo) {
 		"shouldRecordSuccess": func(structPrefix string, fields *ast.FieldList) string {
 			return FieldListToRecordSuccess(structPrefix, fields)
 		},
+		"hasContext": func(fields *ast.FieldList) bool {
+			return FieldListHasContext(fields)
+		},
 	}
 
 	// Prepare template params
@@ -3,9 +3,15 @@
 
 package plugin
 
+import (
+	"github.com/mattermost/mattermost/server/public/model"
+)
+
 type metricsInterface interface {
 	ObservePluginHookDuration(pluginID, hookName string, success bool, elapsed float64)
 	ObservePluginMultiHookIterationDuration(pluginID string, elapsed float64)
 	ObservePluginMultiHookDuration(elapsed float64)
 	ObservePluginAPIDuration(pluginID, apiName string, success bool, elapsed float64)
+	ObservePluginResourceUsage(pluginID string, usage *model.PluginResourceUsage)
+	ClearPluginResourceUsage(pluginID string)
 }
@@ -0,0 +1,31 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package plugin
+
+import (
+	"github.com/mattermost/mattermost/server/public/model"
+)
+
+// MigrationHandler applies the database migrations shipped in the bundle of a plugin about to be
+// activated. The plugin fails to start if it returns an error.
+type MigrationHandler func(bundle *model.BundleInfo) error
+
+// SetMigrationHandler sets the function applying the database migrations of the plugins that ship
+// some before they are activated.
+func (env *Environment) SetMigrationHandler(handler MigrationHandler) {
+	env.migrationHandler.Store(&handler)
+}
+
+func (env *Environment) applyMigrations(bundle *model.BundleInfo) error {
+	if !bundle.Manifest.HasServerMigrations() {
+		return nil
+	}
+
+	handler := env.migrationHandler.Load()
+	if handler == nil {
+		return nil
+	}
+
+	return (*handler)(bundle)
+}
@@ -326,6 +326,36 @@ func (_m *API) CreateGroup(group *model.Group) (*model.Group, *model.AppError) {
 	return r0, r1
 }
 
+// CreateJob provides a mock function with given fields: name, data
+func (_m *API) CreateJob(name string, data map[string]string) (*model.Job, error) {
+	ret := _m.Called(name, data)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateJob")
+	}
+
+	var r0 *model.Job
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string, map[string]string) (*model.Job, error)); ok {
+		return rf(name, data)
+	}
+	if rf, ok := ret.Get(0).(func(string, map[string]string) *model.Job); ok {
+		r0 = rf(name, data)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.Job)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string, map[string]string) error); ok {
+		r1 = rf(name, data)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // CreateOAuthApp provides a mock function with given fields: app
 func (_m *API) CreateOAuthApp(app *model.OAuthApp) (*model.OAuthApp, *model.AppError) {
 	ret := _m.Called(app)
@@ -2316,6 +2346,36 @@ func (_m *API) GetGroupsForUser(userID string) ([]*model.Group, *model.AppError)
 	return r0, r1
 }
 
+// GetJob provides a mock function with given fields: jobID
+func (_m *API) GetJob(jobID string) (*model.Job, error) {
+	ret := _m.Called(jobID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetJob")
+	}
+
+	var r0 *model.Job
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string) (*model.Job, error)); ok {
+		return rf(jobID)
+	}
+	if rf, ok := ret.Get(0).(func(string) *model.Job); ok {
+		r0 = rf(jobID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.Job)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(jobID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetLDAPUserAttributes provides a mock function with given fields: userID, attributes
 func (_m *API) GetLDAPUserAttributes(userID string, attributes []string) (map[string]string, *model.AppError) {
 	ret := _m.Called(userID, attributes)
@@ -4579,6 +4639,24 @@ func (_m *API) RegisterCommand(command *model.Command) error {
 	return r0
 }
 
+// RegisterJobType provides a mock function with given fields: name, options
+func (_m *API) RegisterJobType(name string, options *model.PluginJobTypeOptions) error {
+	ret := _m.Called(name, options)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RegisterJobType")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, *model.PluginJobTypeOptions) error); ok {
+		r0 = rf(name, options)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // RegisterPluginForSharedChannels provides a mock function with given fields: opts
 func (_m *API) RegisterPluginForSharedChannels(opts model.RegisterPluginOpts) (string, error) {
 	ret := _m.Called(opts)
@@ -5167,6 +5245,24 @@ func (_m *API) SetFileSearchableContent(fileID string, content string) *model.Ap
 	return r0
 }
 
+// SetJobProgress provides a mock function with given fields: jobID, progress
+func (_m *API) SetJobProgress(jobID string, progress int64) error {
+	ret := _m.Called(jobID, progress)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetJobProgress")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, int64) error); ok {
+		r0 = rf(jobID, progress)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // SetProfileImage provides a mock function with given fields: userID, data
 func (_m *API) SetProfileImage(userID string, data []byte) *model.AppError {
 	ret := _m.Called(userID, data)
@@ -27,6 +27,102 @@ func (_m *Hooks) ChannelHasBeenCreated(c *plugin.Context, channel *model.Channel
 	_m.Called(c, channel)
 }
 
+// ChannelWillBeArchived provides a mock function with given fields: c, channel
+func (_m *Hooks) ChannelWillBeArchived(c *plugin.Context, channel *model.Channel) string {
+	ret := _m.Called(c, channel)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ChannelWillBeArchived")
+	}
+
+	var r0 string
+	if rf, ok := ret.Get(0).(func(*plugin.Context, *model.Channel) string); ok {
+		r0 = rf(c, channel)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	return r0
+}
+
+// ChannelWillBeCreated provides a mock function with given fields: c, channel
+func (_m *Hooks) ChannelWillBeCreated(c *plugin.Context, channel *model.Channel) (*model.Channel, string) {
+	ret := _m.Called(c, channel)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ChannelWillBeCreated")
+	}
+
+	var r0 *model.Channel
+	var r1 string
+	if rf, ok := ret.Get(0).(func(*plugin.Context, *model.Channel) (*model.Channel, string)); ok {
+		return rf(c, channel)
+	}
+	if rf, ok := ret.Get(0).(func(*plugin.Context, *model.Channel) *model.Channel); ok {
+		r0 = rf(c, channel)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.Channel)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(*plugin.Context, *model.Channel) string); ok {
+		r1 = rf(c, channel)
+	} else {
+		r1 = ret.Get(1).(string)
+	}
+
+	return r0, r1
+}
+
+// ChannelWillBeUnarchived provides a mock function with given fields: c, channel
+func (_m *Hooks) ChannelWillBeUnarchived(c *plugin.Context, channel *model.Channel) string {
+	ret := _m.Called(c, channel)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ChannelWillBeUnarchived")
+	}
+
+	var r0 string
+	if rf, ok := ret.Get(0).(func(*plugin.Context, *model.Channel) string); ok {
+		r0 = rf(c, channel)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	return r0
+}
+
+// ChannelWillBeUpdated provides a mock function with given fields: c, newChannel, oldChannel
+func (_m *Hooks) ChannelWillBeUpdated(c *plugin.Context, newChannel *model.Channel, oldChannel *model.Channel) (*model.Channel, string) {
+	ret := _m.Called(c, newChannel, oldChannel)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ChannelWillBeUpdated")
+	}
+
+	var r0 *model.Channel
+	var r1 string
+	if rf, ok := ret.Get(0).(func(*plugin.Context, *model.Channel, *model.Channel) (*model.Channel, string)); ok {
+		return rf(c, newChannel, oldChannel)
+	}
+	if rf, ok := ret.Get(0).(func(*plugin.Context, *model.Channel, *model.Channel) *model.Channel); ok {
+		r0 = rf(c, newChannel, oldChannel)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.Channel)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(*plugin.Context, *model.Channel, *model.Channel) string); ok {
+		r1 = rf(c, newChannel, oldChannel)
+	} else {
+		r1 = ret.Get(1).(string)
+	}
+
+	return r0, r1
+}
+
 // ConfigurationWillBeSaved provides a mock function with given fields: newCfg
 func (_m *Hooks) ConfigurationWillBeSaved(newCfg *model.Config) (*model.Config, error) {
 	ret := _m.Called(newCfg)
@@ -57,6 +153,36 @@ func (_m *Hooks) ConfigurationWillBeSaved(newCfg *model.Config) (*model.Config,
 	return r0, r1
 }
 
+// EmailNotificationWillBeSent provides a mock function with given fields: emailNotification
+func (_m *Hooks) EmailNotificationWillBeSent(emailNotification *model.EmailNotification) (*model.EmailNotification, string) {
+	ret := _m.Called(emailNotification)
+
+	if len(ret) == 0 {
+		panic("no return value specified for EmailNotificationWillBeSent")
+	}
+
+	var r0 *model.EmailNotification
+	var r1 string
+	if rf, ok := ret.Get(0).(func(*model.EmailNotification) (*model.EmailNotification, string)); ok {
+		return rf(emailNotification)
+	}
+	if rf, ok := ret.Get(0).(func(*model.EmailNotification) *model.EmailNotification); ok {
+		r0 = rf(emailNotification)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.EmailNotification)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(*model.EmailNotification) string); ok {
+		r1 = rf(emailNotification)
+	} else {
+		r1 = ret.Get(1).(string)
+	}
+
+	return r0, r1
+}
+
 // ExecuteCommand provides a mock function with given fields: c, args
 func (_m *Hooks) ExecuteCommand(c *plugin.Context, args *model.CommandArgs) (*model.CommandResponse, *model.AppError) {
 	ret := _m.Called(c, args)
@@ -544,6 +670,84 @@ func (_m *Hooks) RunDataRetention(nowTime int64, batchSize int64) (int64, error)
 	return r0, r1
 }
 
+// RunJob provides a mock function with given fields: job
+func (_m *Hooks) RunJob(job *model.Job) error {
+	ret := _m.Called(job)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RunJob")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*model.Job) error); ok {
+		r0 = rf(job)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// SearchResultsProvided provides a mock function with given fields: c, search
+func (_m *Hooks) SearchResultsProvided(c *plugin.Context, search *model.PluginSearch) (*model.PostSearchResults, error) {
+	ret := _m.Called(c, search)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SearchResultsProvided")
+	}
+
+	var r0 *model.PostSearchResults
+	var r1 error
+	if rf, ok := ret.Get(0).(func(*plugin.Context, *model.PluginSearch) (*model.PostSearchResults, error)); ok {
+		return rf(c, search)
+	}
+	if rf, ok := ret.Get(0).(func(*plugin.Context, *model.PluginSearch) *model.PostSearchResults); ok {
+		r0 = rf(c, search)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.PostSearchResults)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(*plugin.Context, *model.PluginSearch) error); ok {
+		r1 = rf(c, search)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SearchWillBeExecuted provides a mock function with given fields: c, search
+func (_m *Hooks) SearchWillBeExecuted(c *plugin.Context, search *model.PluginSearch) (*model.PluginSearch, string) {
+	ret := _m.Called(c, search)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SearchWillBeExecuted")
+	}
+
+	var r0 *model.PluginSearch
+	var r1 string
+	if rf, ok := ret.Get(0).(func(*plugin.Context, *model.PluginSearch) (*model.PluginSearch, string)); ok {
+		return rf(c, search)
+	}
+	if rf, ok := ret.Get(0).(func(*plugin.Context, *model.PluginSearch) *model.PluginSearch); ok {
+		r0 = rf(c, search)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.PluginSearch)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(*plugin.Context, *model.PluginSearch) string); ok {
+		r1 = rf(c, search)
+	} else {
+		r1 = ret.Get(1).(string)
+	}
+
+	return r0, r1
+}
+
 // ServeHTTP provides a mock function with given fields: c, w, r
 func (_m *Hooks) ServeHTTP(c *plugin.Context, w http.ResponseWriter, r *http.Request) {
 	_m.Called(c, w, r)
@@ -554,6 +758,24 @@ func (_m *Hooks) ServeMetrics(c *plugin.Context, w http.ResponseWriter, r *http.
 	_m.Called(c, w, r)
 }
 
+// TeamWillBeJoined provides a mock function with given fields: c, team, user, actor
+func (_m *Hooks) TeamWillBeJoined(c *plugin.Context, team *model.Team, user *model.User, actor *model.User) string {
+	ret := _m.Called(c, team, user, actor)
+
+	if len(ret) == 0 {
+		panic("no return value specified for TeamWillBeJoined")
+	}
+
+	var r0 string
+	if rf, ok := ret.Get(0).(func(*plugin.Context, *model.Team, *model.User, *model.User) string); ok {
+		r0 = rf(c, team, user, actor)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	return r0
+}
+
 // UserHasBeenCreated provides a mock function with given fields: c, user
 func (_m *Hooks) UserHasBeenCreated(c *plugin.Context, user *model.User) {
 	_m.Called(c, user)
@@ -589,6 +811,36 @@ func (_m *Hooks) UserHasLoggedIn(c *plugin.Context, user *model.User) {
 	_m.Called(c, user)
 }
 
+// UserWillBeUpdated provides a mock function with given fields: c, newUser, oldUser
+func (_m *Hooks) UserWillBeUpdated(c *plugin.Context, newUser *model.User, oldUser *model.User) (*model.User, string) {
+	ret := _m.Called(c, newUser, oldUser)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UserWillBeUpdated")
+	}
+
+	var r0 *model.User
+	var r1 string
+	if rf, ok := ret.Get(0).(func(*plugin.Context, *model.User, *model.User) (*model.User, string)); ok {
+		return rf(c, newUser, oldUser)
+	}
+	if rf, ok := ret.Get(0).(func(*plugin.Context, *model.User, *model.User) *model.User); ok {
+		r0 = rf(c, newUser, oldUser)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.User)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(*plugin.Context, *model.User, *model.User) string); ok {
+		r1 = rf(c, newUser, oldUser)
+	} else {
+		r1 = ret.Get(1).(string)
+	}
+
+	return r0, r1
+}
+
 // UserWillLogIn provides a mock function with given fields: c, user
 func (_m *Hooks) UserWillLogIn(c *plugin.Context, user *model.User) string {
 	ret := _m.Called(c, user)
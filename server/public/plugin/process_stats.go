@@ -0,0 +1,17 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+//go:build !linux
+// +build !linux
+
+package plugin
+
+import (
+	"errors"
+	"time"
+)
+
+// getProcessStats returns the resident set size and the total CPU time of a process.
+func getProcessStats(pid int) (int64, time.Duration, error) {
+	return 0, 0, errors.New("process statistics are only available on Linux")
+}
@@ -0,0 +1,59 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package plugin
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// userHZ is the number of clock ticks per second used by /proc/<pid>/stat, which is 100 on all
+// supported architectures.
+const userHZ = 100
+
+// getProcessStats returns the resident set size and the total CPU time of a process.
+func getProcessStats(pid int) (int64, time.Duration, error) {
+	statm, err := os.ReadFile(fmt.Sprintf("/proc/%d/statm", pid))
+	if err != nil {
+		return 0, 0, errors.Wrap(err, "failed to read process memory statistics")
+	}
+	fields := strings.Fields(string(statm))
+	if len(fields) < 2 {
+		return 0, 0, errors.New("unexpected format of process memory statistics")
+	}
+	residentPages, err := strconv.ParseInt(fields[1], 10, 64)
+	if err != nil {
+		return 0, 0, errors.Wrap(err, "failed to parse resident set size")
+	}
+
+	stat, err := os.ReadFile(fmt.Sprintf("/proc/%d/stat", pid))
+	if err != nil {
+		return 0, 0, errors.Wrap(err, "failed to read process statistics")
+	}
+	// The command name can contain spaces and is wrapped in parentheses, so skip past it.
+	index := strings.LastIndexByte(string(stat), ')')
+	if index == -1 {
+		return 0, 0, errors.New("unexpected format of process statistics")
+	}
+	// Fields following the command name start with the state, utime and stime are the 12th and 13th.
+	fields = strings.Fields(string(stat[index+1:]))
+	if len(fields) < 13 {
+		return 0, 0, errors.New("unexpected format of process statistics")
+	}
+	utime, err := strconv.ParseInt(fields[11], 10, 64)
+	if err != nil {
+		return 0, 0, errors.Wrap(err, "failed to parse user CPU time")
+	}
+	stime, err := strconv.ParseInt(fields[12], 10, 64)
+	if err != nil {
+		return 0, 0, errors.Wrap(err, "failed to parse system CPU time")
+	}
+
+	return residentPages * int64(os.Getpagesize()), time.Duration(utime+stime) * time.Second / userHZ, nil
+}
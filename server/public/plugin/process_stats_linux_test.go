@@ -0,0 +1,22 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package plugin
+
+import (
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetProcessStats(t *testing.T) {
+	rss, cpuTime, err := getProcessStats(os.Getpid())
+	require.NoError(t, err)
+	assert.Positive(t, rss)
+	assert.GreaterOrEqual(t, cpuTime.Nanoseconds(), int64(0))
+
+	_, _, err = getProcessStats(-1)
+	assert.Error(t, err)
+}
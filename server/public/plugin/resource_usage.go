@@ -0,0 +1,215 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package plugin
+
+import (
+	"sync/atomic"
+	"time"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/public/shared/mlog"
+)
+
+// ResourceLimitExceededHandler is called after a plugin that exceeded its resource limits was
+// restarted or deactivated, with a description of the exceeded limit and the action taken.
+type ResourceLimitExceededHandler func(pluginID string, usage *model.PluginResourceUsage, reason, action string)
+
+// resourceCounters counts the API calls and hook invocations of a plugin, forwarding them to the
+// server metrics if any.
+type resourceCounters struct {
+	metrics      metricsInterface
+	apiCalls     atomic.Int64
+	hookCalls    atomic.Int64
+	hookDuration atomic.Int64
+}
+
+func (c *resourceCounters) ObservePluginHookDuration(pluginID, hookName string, success bool, elapsed float64) {
+	c.hookCalls.Add(1)
+	c.hookDuration.Add(int64(elapsed * float64(time.Second)))
+	if c.metrics != nil {
+		c.metrics.ObservePluginHookDuration(pluginID, hookName, success, elapsed)
+	}
+}
+
+func (c *resourceCounters) ObservePluginMultiHookIterationDuration(pluginID string, elapsed float64) {
+	if c.metrics != nil {
+		c.metrics.ObservePluginMultiHookIterationDuration(pluginID, elapsed)
+	}
+}
+
+func (c *resourceCounters) ObservePluginMultiHookDuration(elapsed float64) {
+	if c.metrics != nil {
+		c.metrics.ObservePluginMultiHookDuration(elapsed)
+	}
+}
+
+func (c *resourceCounters) ObservePluginAPIDuration(pluginID, apiName string, success bool, elapsed float64) {
+	c.apiCalls.Add(1)
+	if c.metrics != nil {
+		c.metrics.ObservePluginAPIDuration(pluginID, apiName, success, elapsed)
+	}
+}
+
+func (c *resourceCounters) ObservePluginResourceUsage(pluginID string, usage *model.PluginResourceUsage) {
+	if c.metrics != nil {
+		c.metrics.ObservePluginResourceUsage(pluginID, usage)
+	}
+}
+
+func (c *resourceCounters) ClearPluginResourceUsage(pluginID string) {
+	if c.metrics != nil {
+		c.metrics.ClearPluginResourceUsage(pluginID)
+	}
+}
+
+// resourceSample is the state at the time of the previous sample, used to compute rates.
+type resourceSample struct {
+	time         time.Time
+	cpuTime      time.Duration
+	apiCalls     int64
+	hookCalls    int64
+	hookDuration int64
+}
+
+// sampleResourceUsage measures the resources used by the plugin since the previous sample.
+func (sup *supervisor) sampleResourceUsage() *model.PluginResourceUsage {
+	sup.lock.RLock()
+	wasmHooks, client, hooksClient := sup.wasmHooks, sup.client, sup.hooksClient
+	sup.lock.RUnlock()
+
+	now := time.Now()
+	usage := &model.PluginResourceUsage{
+		UpdateAt: now.UnixMilli(),
+	}
+
+	// The CPU time of a WebAssembly plugin can't be told apart from the server's, so the time
+	// spent running its module is used instead.
+	var cpuTime time.Duration
+	if wasmHooks != nil {
+		var busyTime time.Duration
+		usage.MemoryRSSBytes, busyTime = wasmHooks.resourceUsage()
+		usage.BusyTimeMs = busyTime.Milliseconds()
+		cpuTime = busyTime
+	} else if client != nil {
+		if reattachConfig := client.ReattachConfig(); reattachConfig != nil && reattachConfig.Pid != 0 {
+			var err error
+			usage.MemoryRSSBytes, cpuTime, err = getProcessStats(reattachConfig.Pid)
+			if err != nil {
+				mlog.Debug("Failed to get plugin process statistics", mlog.String("plugin_id", sup.pluginID), mlog.Err(err))
+			}
+		}
+		if hooksClient != nil {
+			if stats, err := hooksClient.RuntimeStats(); err == nil {
+				usage.Goroutines = stats.Goroutines
+			}
+		}
+		usage.CPUTimeMs = cpuTime.Milliseconds()
+	}
+
+	sup.usageLock.Lock()
+	defer sup.usageLock.Unlock()
+
+	sample := resourceSample{
+		time:         now,
+		cpuTime:      cpuTime,
+		apiCalls:     sup.counters.apiCalls.Load(),
+		hookCalls:    sup.counters.hookCalls.Load(),
+		hookDuration: sup.counters.hookDuration.Load(),
+	}
+	if previous := sup.lastSample; !previous.time.IsZero() {
+		elapsed := now.Sub(previous.time)
+		if elapsed > 0 {
+			usage.CPUPercent = float64(cpuTime-previous.cpuTime) / float64(elapsed) * 100
+			usage.APICallsPerSecond = float64(sample.apiCalls-previous.apiCalls) / elapsed.Seconds()
+		}
+		if hookCalls := sample.hookCalls - previous.hookCalls; hookCalls > 0 {
+			usage.HookLatencyMs = float64(sample.hookDuration-previous.hookDuration) / float64(hookCalls) / float64(time.Millisecond)
+		}
+	}
+	sup.lastSample = sample
+	sup.resourceUsage = usage
+
+	return usage
+}
+
+// ResourceUsage returns the resource usage of the plugin as of the last sample, if any.
+func (sup *supervisor) ResourceUsage() *model.PluginResourceUsage {
+	sup.usageLock.Lock()
+	defer sup.usageLock.Unlock()
+	return sup.resourceUsage
+}
+
+// SetResourceLimits sets the resource limits of the plugins, keyed by plugin id.
+func (env *Environment) SetResourceLimits(limits map[string]*model.PluginResourceLimits) {
+	env.resourceLimits.Store(&limits)
+}
+
+// SetResourceLimitExceededHandler sets the function called after a plugin exceeding its resource
+// limits was restarted or deactivated.
+func (env *Environment) SetResourceLimitExceededHandler(handler ResourceLimitExceededHandler) {
+	env.resourceLimitExceededHandler.Store(&handler)
+}
+
+func (env *Environment) getResourceLimits(id string) *model.PluginResourceLimits {
+	limits := env.resourceLimits.Load()
+	if limits == nil {
+		return nil
+	}
+	return (*limits)[id]
+}
+
+// CheckResourceUsage samples the resource usage of an active plugin, and restarts or deactivates
+// it if it exceeds its resource limits. A plugin restarted HealthCheckNumRestartsLimit times within
+// HealthCheckDeactivationWindow is deactivated instead, as a plugin failing its health check is.
+func (env *Environment) CheckResourceUsage(id string) {
+	p, ok := env.registeredPlugins.Load(id)
+	if !ok {
+		return
+	}
+	rp := p.(registeredPlugin)
+	if rp.supervisor == nil || !env.IsActive(id) {
+		return
+	}
+
+	usage := rp.supervisor.sampleResourceUsage()
+	if env.metrics != nil {
+		env.metrics.ObservePluginResourceUsage(id, usage)
+	}
+
+	limits := env.getResourceLimits(id)
+	reason := limits.ExceededBy(usage)
+	if reason == "" {
+		return
+	}
+
+	action := limits.GetAction()
+	if action == model.PluginResourceLimitActionRestart {
+		var timestamps []time.Time
+		if stored, ok := env.resourceLimitRestarts.Load(id); ok {
+			timestamps = stored.([]time.Time)
+		}
+		timestamps = append(timestamps, time.Now())
+
+		if shouldDeactivatePlugin(timestamps) {
+			action = model.PluginResourceLimitActionDisable
+			env.resourceLimitRestarts.Delete(id)
+		} else {
+			env.resourceLimitRestarts.Store(id, removeStaleTimestamps(timestamps))
+		}
+	}
+
+	env.logger.Warn("Plugin exceeded its resource limits", mlog.String("plugin_id", id), mlog.String("reason", reason), mlog.String("action", action))
+
+	if action == model.PluginResourceLimitActionDisable {
+		env.Deactivate(id)
+		env.setPluginState(id, model.PluginStateFailedToStayRunning)
+		env.SetPluginError(id, reason)
+	} else if err := env.RestartPlugin(id); err != nil {
+		env.logger.Error("Failed to restart plugin", mlog.String("plugin_id", id), mlog.Err(err))
+	}
+
+	if handler := env.resourceLimitExceededHandler.Load(); handler != nil && *handler != nil {
+		(*handler)(id, usage, reason, action)
+	}
+}
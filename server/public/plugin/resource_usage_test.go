@@ -0,0 +1,151 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package plugin
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/public/plugin/utils"
+	"github.com/mattermost/mattermost/server/public/shared/mlog"
+)
+
+func TestResourceCounters(t *testing.T) {
+	counters := &resourceCounters{}
+	counters.ObservePluginAPIDuration("foo", "GetUser", true, 0.1)
+	counters.ObservePluginAPIDuration("foo", "GetUser", false, 0.1)
+	counters.ObservePluginHookDuration("foo", "OnActivate", true, 0.25)
+	counters.ObservePluginMultiHookDuration(1)
+
+	assert.Equal(t, int64(2), counters.apiCalls.Load())
+	assert.Equal(t, int64(1), counters.hookCalls.Load())
+	assert.Equal(t, int64(250*time.Millisecond), counters.hookDuration.Load())
+}
+
+func TestSampleResourceUsage(t *testing.T) {
+	sup := &supervisor{pluginID: "foo", counters: &resourceCounters{}}
+	assert.Nil(t, sup.ResourceUsage())
+
+	usage := sup.sampleResourceUsage()
+	assert.Zero(t, usage.APICallsPerSecond)
+	assert.Zero(t, usage.HookLatencyMs)
+	assert.Equal(t, usage, sup.ResourceUsage())
+
+	for range 10 {
+		sup.counters.ObservePluginAPIDuration("foo", "GetUser", true, 0)
+	}
+	sup.counters.ObservePluginHookDuration("foo", "OnActivate", true, 0.1)
+	sup.counters.ObservePluginHookDuration("foo", "OnActivate", true, 0.3)
+
+	// Pretend the previous sample was taken a second ago.
+	sup.lastSample.time = sup.lastSample.time.Add(-time.Second)
+
+	usage = sup.sampleResourceUsage()
+	assert.InDelta(t, 10, usage.APICallsPerSecond, 0.5)
+	assert.InDelta(t, 200, usage.HookLatencyMs, 0.001)
+
+	// Rates only cover the calls since the previous sample.
+	usage = sup.sampleResourceUsage()
+	assert.Zero(t, usage.HookLatencyMs)
+}
+
+func TestCheckResourceUsage(t *testing.T) {
+	pluginDir := t.TempDir()
+	dir := filepath.Join(pluginDir, "foo")
+	require.NoError(t, os.Mkdir(dir, 0700))
+
+	utils.CompileGo(t, `
+		package main
+
+		import (
+			"github.com/mattermost/mattermost/server/public/plugin"
+		)
+
+		type MyPlugin struct {
+			plugin.MattermostPlugin
+		}
+
+		func main() {
+			plugin.ClientMain(&MyPlugin{})
+		}
+	`, filepath.Join(dir, "backend.exe"))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "plugin.json"), []byte(`{"id": "foo", "server": {"executable": "backend.exe"}}`), 0600))
+
+	newAPIImpl := func(*model.Manifest) API { return nil }
+	env, err := NewEnvironment(newAPIImpl, nil, pluginDir, t.TempDir(), mlog.CreateConsoleTestLogger(t), nil)
+	require.NoError(t, err)
+	defer env.Shutdown()
+
+	var handled []string
+	env.SetResourceLimitExceededHandler(func(pluginID string, usage *model.PluginResourceUsage, reason, action string) {
+		require.NotNil(t, usage)
+		handled = append(handled, pluginID+":"+action+":"+reason)
+	})
+
+	_, activated, err := env.Activate("foo")
+	require.NoError(t, err)
+	require.True(t, activated)
+
+	t.Run("within limits", func(t *testing.T) {
+		env.SetResourceLimits(map[string]*model.PluginResourceLimits{"foo": {MaxGoroutines: 100000}})
+		env.CheckResourceUsage("foo")
+
+		assert.Empty(t, handled)
+		assert.True(t, env.IsActive("foo"))
+
+		statuses, err := env.Statuses()
+		require.NoError(t, err)
+		require.Len(t, statuses, 1)
+		require.NotNil(t, statuses[0].ResourceUsage)
+		assert.Positive(t, statuses[0].ResourceUsage.Goroutines)
+	})
+
+	t.Run("restart", func(t *testing.T) {
+		handled = nil
+		env.SetResourceLimits(map[string]*model.PluginResourceLimits{"foo": {MaxGoroutines: 1}})
+		env.CheckResourceUsage("foo")
+
+		require.Len(t, handled, 1)
+		assert.Contains(t, handled[0], "foo:restart:")
+		assert.True(t, env.IsActive("foo"))
+	})
+
+	t.Run("repeated restarts disable", func(t *testing.T) {
+		handled = nil
+		env.SetResourceLimits(map[string]*model.PluginResourceLimits{"foo": {MaxGoroutines: 1}})
+		// The plugin was restarted once already.
+		for range HealthCheckNumRestartsLimit - 1 {
+			env.CheckResourceUsage("foo")
+		}
+
+		require.Len(t, handled, HealthCheckNumRestartsLimit-1)
+		for _, h := range handled[:len(handled)-1] {
+			assert.Contains(t, h, "foo:restart:")
+		}
+		assert.Contains(t, handled[len(handled)-1], "foo:disable:")
+		assert.False(t, env.IsActive("foo"))
+		assert.Equal(t, model.PluginStateFailedToStayRunning, env.GetPluginState("foo"))
+
+		_, activated, err := env.Activate("foo")
+		require.NoError(t, err)
+		require.True(t, activated)
+	})
+
+	t.Run("disable", func(t *testing.T) {
+		handled = nil
+		env.SetResourceLimits(map[string]*model.PluginResourceLimits{"foo": {MaxGoroutines: 1, Action: model.PluginResourceLimitActionDisable}})
+		env.CheckResourceUsage("foo")
+
+		require.Len(t, handled, 1)
+		assert.Contains(t, handled[0], "foo:disable:")
+		assert.False(t, env.IsActive("foo"))
+		assert.Equal(t, model.PluginStateFailedToStayRunning, env.GetPluginState("foo"))
+	})
+}
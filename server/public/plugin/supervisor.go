@@ -31,6 +31,15 @@ type supervisor struct {
 	implemented  [TotalHooksID]bool
 	hooksClient  *hooksRPCClient
 	isReattached bool
+
+	// wasmExecutable is the path to the WebAssembly module run in-process instead of a plugin process.
+	wasmExecutable string
+	wasmHooks      *wasmHooks
+
+	counters      *resourceCounters
+	usageLock     sync.Mutex
+	lastSample    resourceSample
+	resourceUsage *model.PluginResourceUsage
 }
 
 type driverForPlugin struct {
@@ -43,8 +52,11 @@ func (d *driverForPlugin) Conn(isMaster bool) (string, error) {
 }
 
 func WithExecutableFromManifest(pluginInfo *model.BundleInfo) func(*supervisor, *plugin.ClientConfig) error {
-	return func(_ *supervisor, clientConfig *plugin.ClientConfig) error {
+	return func(sup *supervisor, clientConfig *plugin.ClientConfig) error {
 		executable := pluginInfo.Manifest.GetExecutableForRuntime(runtime.GOOS, runtime.GOARCH)
+		if pluginInfo.Manifest.HasWasmServer() {
+			executable = pluginInfo.Manifest.Server.Executable
+		}
 		if executable == "" {
 			return fmt.Errorf("backend executable not found for environment: %s/%s", runtime.GOOS, runtime.GOARCH)
 		}
@@ -56,6 +68,11 @@ func WithExecutableFromManifest(pluginInfo *model.BundleInfo) func(*supervisor,
 
 		executable = filepath.Join(pluginInfo.Path, executable)
 
+		if pluginInfo.Manifest.HasWasmServer() {
+			sup.wasmExecutable = executable
+			return nil
+		}
+
 		cmd := exec.Command(executable)
 
 		// This doesn't add more security than before
@@ -88,6 +105,7 @@ func WithReattachConfig(pluginReattachConfig *model.PluginReattachConfig) func(*
 func newSupervisor(pluginInfo *model.BundleInfo, apiImpl API, driver AppDriver, parentLogger *mlog.Logger, metrics metricsInterface, opts ...func(*supervisor, *plugin.ClientConfig) error) (retSupervisor *supervisor, retErr error) {
 	sup := supervisor{
 		pluginID: pluginInfo.Manifest.Id,
+		counters: &resourceCounters{metrics: metrics},
 	}
 	if driver != nil {
 		sup.appDriver = &driverForPlugin{AppDriver: driver, pluginID: pluginInfo.Manifest.Id}
@@ -110,7 +128,7 @@ func newSupervisor(pluginInfo *model.BundleInfo, apiImpl API, driver AppDriver,
 		"hooks": &hooksPlugin{
 			log:        wrappedLogger,
 			driverImpl: sup.appDriver,
-			apiImpl:    &apiTimerLayer{pluginInfo.Manifest.Id, apiImpl, metrics},
+			apiImpl:    &apiTimerLayer{pluginInfo.Manifest.Id, apiImpl, sup.counters},
 		},
 	}
 
@@ -122,13 +140,26 @@ func newSupervisor(pluginInfo *model.BundleInfo, apiImpl API, driver AppDriver,
 		Logger:          hclogAdaptedLogger,
 		StartTimeout:    time.Second * 3,
 	}
+	var err error
 	for _, opt := range opts {
-		err := opt(&sup, clientConfig)
+		err = opt(&sup, clientConfig)
 		if err != nil {
 			return nil, errors.Wrap(err, "failed to apply option")
 		}
 	}
 
+	if sup.wasmExecutable != "" {
+		sup.wasmHooks, err = newWasmHooks(pluginInfo.Manifest.Id, sup.wasmExecutable, pluginInfo.Manifest.Server.Wasm, &apiTimerLayer{pluginInfo.Manifest.Id, apiImpl, sup.counters}, wrappedLogger)
+		if err != nil {
+			return nil, err
+		}
+		sup.hooks = &hooksTimerLayer{pluginInfo.Manifest.Id, sup.wasmHooks, sup.counters}
+		if err = sup.setImplemented(); err != nil {
+			return nil, err
+		}
+		return &sup, nil
+	}
+
 	sup.client = plugin.NewClient(clientConfig)
 
 	rpcClient, err := sup.client.Client()
@@ -146,11 +177,19 @@ func newSupervisor(pluginInfo *model.BundleInfo, apiImpl API, driver AppDriver,
 		sup.hooksClient = c
 	}
 
-	sup.hooks = &hooksTimerLayer{pluginInfo.Manifest.Id, raw.(Hooks), metrics}
+	sup.hooks = &hooksTimerLayer{pluginInfo.Manifest.Id, raw.(Hooks), sup.counters}
 
+	if err = sup.setImplemented(); err != nil {
+		return nil, err
+	}
+
+	return &sup, nil
+}
+
+func (sup *supervisor) setImplemented() error {
 	impl, err := sup.hooks.Implemented()
 	if err != nil {
-		return nil, err
+		return err
 	}
 	for _, hookName := range impl {
 		if hookId, ok := hookNameToId[hookName]; ok {
@@ -158,7 +197,7 @@ func newSupervisor(pluginInfo *model.BundleInfo, apiImpl API, driver AppDriver,
 		}
 	}
 
-	return &sup, nil
+	return nil
 }
 
 func (sup *supervisor) Shutdown() {
@@ -182,6 +221,10 @@ func (sup *supervisor) Shutdown() {
 		sup.client.Kill()
 	}
 
+	if sup.wasmHooks != nil {
+		sup.wasmHooks.Close()
+	}
+
 	// Wait for API RPC server and DB RPC server to exit.
 	// And then shutdown conns.
 	if sup.hooksClient != nil {
@@ -220,6 +263,10 @@ func (sup *supervisor) PerformHealthCheck() error {
 func (sup *supervisor) Ping() error {
 	sup.lock.RLock()
 	defer sup.lock.RUnlock()
+	if sup.wasmHooks != nil {
+		return sup.wasmHooks.Ping()
+	}
+
 	client, err := sup.client.Client()
 	if err != nil {
 		return err
@@ -0,0 +1,53 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package plugin
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const tracerName = "github.com/mattermost/mattermost/server/public/plugin"
+
+// startSpan starts the span of a hook invoked as part of a traced request,
+// as given by c.TraceParent. The returned context carries the new span to the
+// plugin, so that it can continue the trace.
+func (hooks *hooksTimerLayer) startSpan(c *Context, hookName string) (*Context, trace.Span) {
+	if c == nil || c.TraceParent == "" {
+		return c, trace.SpanFromContext(context.Background())
+	}
+
+	propagator := propagation.TraceContext{}
+	ctx := propagator.Extract(context.Background(), propagation.MapCarrier{"traceparent": c.TraceParent})
+	if !trace.SpanContextFromContext(ctx).IsSampled() {
+		return c, trace.SpanFromContext(context.Background())
+	}
+
+	ctx, span := otel.Tracer(tracerName).Start(ctx, "plugin:"+hookName,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(
+			attribute.String("plugin.id", hooks.pluginID),
+			attribute.String("plugin.hook", hookName),
+		),
+	)
+
+	carrier := propagation.MapCarrier{}
+	propagator.Inject(ctx, carrier)
+	newContext := *c
+	newContext.TraceParent = carrier.Get("traceparent")
+
+	return &newContext, span
+}
+
+func (hooks *hooksTimerLayer) endSpan(span trace.Span, success bool) {
+	if !success {
+		span.SetStatus(codes.Error, "hook failed")
+	}
+	span.End()
+}
@@ -0,0 +1,51 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package plugin
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHooksTimerLayerStartSpan(t *testing.T) {
+	hooks := &hooksTimerLayer{pluginID: "com.mattermost.test"}
+
+	t.Run("nil context", func(t *testing.T) {
+		c, span := hooks.startSpan(nil, "ExecuteCommand")
+		assert.Nil(t, c)
+		assert.False(t, span.IsRecording())
+		hooks.endSpan(span, true)
+	})
+
+	t.Run("request not traced", func(t *testing.T) {
+		c := &Context{RequestId: "request"}
+		newContext, span := hooks.startSpan(c, "ExecuteCommand")
+		assert.Same(t, c, newContext)
+		assert.False(t, span.IsRecording())
+	})
+
+	t.Run("request not sampled", func(t *testing.T) {
+		c := &Context{TraceParent: "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-00"}
+		newContext, span := hooks.startSpan(c, "ExecuteCommand")
+		assert.Same(t, c, newContext)
+		assert.False(t, span.IsRecording())
+	})
+
+	t.Run("request sampled", func(t *testing.T) {
+		c := &Context{
+			RequestId:   "request",
+			TraceParent: "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01",
+		}
+		newContext, span := hooks.startSpan(c, "ExecuteCommand")
+		defer hooks.endSpan(span, false)
+
+		require.NotSame(t, c, newContext)
+		assert.Equal(t, "request", newContext.RequestId)
+		assert.Equal(t, "4bf92f3577b34da6a3ce929d0e0e4736", span.SpanContext().TraceID().String())
+		assert.Regexp(t, `^00-4bf92f3577b34da6a3ce929d0e0e4736-[0-9a-f]{16}-01$`, newContext.TraceParent)
+		assert.Equal(t, "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01", c.TraceParent)
+	})
+}
@@ -15,7 +15,12 @@ import (
 )
 
 func CompileGo(t *testing.T, sourceCode, outputPath string) {
-	compileGo(t, "go", sourceCode, outputPath)
+	compileGo(t, "go", sourceCode, outputPath, nil, nil)
+}
+
+// CompileGoWasm compiles the given source code into a WASI reactor module, as used by WebAssembly plugins.
+func CompileGoWasm(t *testing.T, sourceCode, outputPath string) {
+	compileGo(t, "go", sourceCode, outputPath, []string{"-buildmode=c-shared"}, []string{"GOOS=wasip1", "GOARCH=wasm"})
 }
 
 func CompileGoVersion(t *testing.T, goVersion, sourceCode, outputPath string) {
@@ -23,10 +28,10 @@ func CompileGoVersion(t *testing.T, goVersion, sourceCode, outputPath string) {
 	if goVersion != "" {
 		goBin = os.Getenv("GOBIN")
 	}
-	compileGo(t, filepath.Join(goBin, "go"+goVersion), sourceCode, outputPath)
+	compileGo(t, filepath.Join(goBin, "go"+goVersion), sourceCode, outputPath, nil, nil)
 }
 
-func compileGo(t *testing.T, goBin, sourceCode, outputPath string) {
+func compileGo(t *testing.T, goBin, sourceCode, outputPath string, buildArgs, env []string) {
 	dir, err := os.MkdirTemp(".", "")
 	require.NoError(t, err)
 	defer os.RemoveAll(dir)
@@ -43,9 +48,15 @@ func compileGo(t *testing.T, goBin, sourceCode, outputPath string) {
 	require.True(t, ok)
 	serverPath := filepath.Dir(filepath.Dir(sourceFile))
 
+	args := append([]string{"build", "-o", outputPath}, buildArgs...)
+	args = append(args, main)
+
 	out := &bytes.Buffer{}
-	cmd := exec.Command(goBin, "build", "-o", outputPath, main)
+	cmd := exec.Command(goBin, args...)
 	cmd.Dir = serverPath
+	if len(env) > 0 {
+		cmd.Env = append(os.Environ(), env...)
+	}
 	cmd.Stdout = out
 	cmd.Stderr = out
 	err = cmd.Run()
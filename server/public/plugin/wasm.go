@@ -0,0 +1,787 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package plugin
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/tetratelabs/wazero"
+	wasmapi "github.com/tetratelabs/wazero/api"
+	"github.com/tetratelabs/wazero/imports/wasi_snapshot_preview1"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/public/shared/mlog"
+)
+
+// WebAssembly plugins are loaded in-process with wazero instead of being started as a separate
+// process. The module has no filesystem or network access, and talks to the server through a
+// small JSON based ABI:
+//
+//   - The module must export its "memory" and an "mm_alloc(size i32) i32" function used by the
+//     server to allocate buffers in the module's memory. It may also export "mm_free(ptr i32, size i32)"
+//     to be told when those buffers are no longer used by the server.
+//   - A hook is implemented by exporting a function named after it, e.g. "MessageWillBePosted",
+//     with the signature "(ptr i32, size i32) i64". The input is a JSON array of the hook
+//     arguments, and the function returns the location of a JSON array of the hook return values
+//     packed as ptr<<32|size, or 0 if it has nothing to return. Only the hooks listed in
+//     wasmSupportedHooks can be implemented.
+//   - The server provides a "mattermost" host module with "log(level i32, ptr i32, size i32)" and
+//     "api_call(namePtr i32, nameSize i32, argsPtr i32, argsSize i32) i64". The latter calls an
+//     API method allowed by the capabilities declared in the manifest, taking a JSON array of
+//     arguments and returning {"results": [...]} or {"error": "..."} packed like hook results.
+//
+// A module built as a WASI reactor has its "_initialize" function called once when it is loaded.
+// Calls into the module are serialized, and each one is bounded by the hook timeout of the
+// manifest, the time spent waiting for API calls excluded. A module that runs out of time or
+// memory is terminated, which the health check reports as a crash.
+
+const (
+	wasmHostModuleName = "mattermost"
+
+	wasmAllocFunctionName = "mm_alloc"
+	wasmFreeFunctionName  = "mm_free"
+
+	wasmPageSize = 64 * 1024
+
+	defaultWasmMemoryLimitMB = 64
+	defaultWasmHookTimeout   = 5 * time.Second
+
+	// wasmStartTimeout bounds loading the module and running its initialization.
+	wasmStartTimeout = 10 * time.Second
+)
+
+const (
+	wasmLogLevelDebug = iota
+	wasmLogLevelInfo
+	wasmLogLevelWarn
+	wasmLogLevelError
+)
+
+// wasmSupportedHooks maps the names of the hooks that can be implemented by a WebAssembly plugin to their ids.
+var wasmSupportedHooks = map[string]int{
+	"OnActivate":            OnActivateID,
+	"OnDeactivate":          OnDeactivateID,
+	"OnConfigurationChange": OnConfigurationChangeID,
+	"ExecuteCommand":        ExecuteCommandID,
+	"UserHasBeenCreated":    UserHasBeenCreatedID,
+	"MessageWillBePosted":   MessageWillBePostedID,
+	"MessageWillBeUpdated":  MessageWillBeUpdatedID,
+	"MessageHasBeenPosted":  MessageHasBeenPostedID,
+	"MessageHasBeenUpdated": MessageHasBeenUpdatedID,
+	"MessageHasBeenDeleted": MessageHasBeenDeletedID,
+	"ChannelHasBeenCreated": ChannelHasBeenCreatedID,
+	"UserHasJoinedChannel":  UserHasJoinedChannelID,
+	"UserHasJoinedTeam":     UserHasJoinedTeamID,
+}
+
+// wasmAPIMethod is an API method that can be called by a WebAssembly plugin holding the given capability.
+type wasmAPIMethod struct {
+	capability string
+	call       func(api API, args []json.RawMessage) ([]any, error)
+}
+
+var wasmAPIMethods = map[string]wasmAPIMethod{
+	"KVGet": {model.PluginWasmCapabilityKV, func(api API, args []json.RawMessage) ([]any, error) {
+		var key string
+		if err := decodeWasmArgs(args, &key); err != nil {
+			return nil, err
+		}
+		value, appErr := api.KVGet(key)
+		return []any{value, appErr}, nil
+	}},
+	"KVSet": {model.PluginWasmCapabilityKV, func(api API, args []json.RawMessage) ([]any, error) {
+		var key string
+		var value []byte
+		if err := decodeWasmArgs(args, &key, &value); err != nil {
+			return nil, err
+		}
+		return []any{api.KVSet(key, value)}, nil
+	}},
+	"KVDelete": {model.PluginWasmCapabilityKV, func(api API, args []json.RawMessage) ([]any, error) {
+		var key string
+		if err := decodeWasmArgs(args, &key); err != nil {
+			return nil, err
+		}
+		return []any{api.KVDelete(key)}, nil
+	}},
+	"GetPost": {model.PluginWasmCapabilityPostsRead, func(api API, args []json.RawMessage) ([]any, error) {
+		var postID string
+		if err := decodeWasmArgs(args, &postID); err != nil {
+			return nil, err
+		}
+		post, appErr := api.GetPost(postID)
+		return []any{post, appErr}, nil
+	}},
+	"CreatePost": {model.PluginWasmCapabilityPostsWrite, func(api API, args []json.RawMessage) ([]any, error) {
+		var post *model.Post
+		if err := decodeWasmArgs(args, &post); err != nil {
+			return nil, err
+		}
+		post, appErr := api.CreatePost(post)
+		return []any{post, appErr}, nil
+	}},
+	"UpdatePost": {model.PluginWasmCapabilityPostsWrite, func(api API, args []json.RawMessage) ([]any, error) {
+		var post *model.Post
+		if err := decodeWasmArgs(args, &post); err != nil {
+			return nil, err
+		}
+		post, appErr := api.UpdatePost(post)
+		return []any{post, appErr}, nil
+	}},
+	"GetUser": {model.PluginWasmCapabilityUsersRead, func(api API, args []json.RawMessage) ([]any, error) {
+		var userID string
+		if err := decodeWasmArgs(args, &userID); err != nil {
+			return nil, err
+		}
+		user, appErr := api.GetUser(userID)
+		return []any{user, appErr}, nil
+	}},
+	"GetChannel": {model.PluginWasmCapabilityChannelsRead, func(api API, args []json.RawMessage) ([]any, error) {
+		var channelID string
+		if err := decodeWasmArgs(args, &channelID); err != nil {
+			return nil, err
+		}
+		channel, appErr := api.GetChannel(channelID)
+		return []any{channel, appErr}, nil
+	}},
+	"GetPluginConfig": {model.PluginWasmCapabilityConfigRead, func(api API, args []json.RawMessage) ([]any, error) {
+		return []any{api.GetPluginConfig()}, nil
+	}},
+}
+
+func decodeWasmArgs(args []json.RawMessage, dest ...any) error {
+	if len(args) != len(dest) {
+		return fmt.Errorf("expected %d arguments, got %d", len(dest), len(args))
+	}
+	for i, arg := range args {
+		if err := json.Unmarshal(arg, dest[i]); err != nil {
+			return errors.Wrapf(err, "failed to decode argument %d", i)
+		}
+	}
+	return nil
+}
+
+// wasmHooks runs the hooks of a plugin compiled to WebAssembly.
+type wasmHooks struct {
+	// Hooks not implemented by the module fall back to an RPC client that has no implemented hooks,
+	// returning the same defaults as a plugin process that doesn't implement them.
+	*hooksRPCClient
+
+	pluginID    string
+	settings    *model.ManifestServerWasm
+	apiImpl     API
+	logger      *mlog.Logger
+	hookTimeout time.Duration
+
+	runtime     wazero.Runtime
+	module      wasmapi.Module
+	implemented [TotalHooksID]bool
+
+	// lock guards the fields below. The module runs one call at a time, but a call suspended in
+	// an API call lets others run, since the API call may itself run hooks of the plugin. Calls
+	// nest: the suspended call resumes once the calls made since have returned.
+	lock         sync.Mutex
+	cond         *sync.Cond
+	running      bool
+	depth        int
+	runningSince time.Time
+
+	// busyTime is the total time spent running the module, API calls excluded.
+	busyTime time.Duration
+	// memorySize is the size of the module's memory when it last stopped running.
+	memorySize int64
+}
+
+func newWasmHooks(pluginID, executable string, settings *model.ManifestServerWasm, apiImpl API, logger *mlog.Logger) (*wasmHooks, error) {
+	code, err := os.ReadFile(executable)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read wasm module")
+	}
+
+	if settings == nil {
+		settings = &model.ManifestServerWasm{}
+	}
+	memoryLimitMB := settings.MemoryLimitMB
+	if memoryLimitMB == 0 {
+		memoryLimitMB = defaultWasmMemoryLimitMB
+	}
+	hookTimeout := defaultWasmHookTimeout
+	if settings.HookTimeoutMs > 0 {
+		hookTimeout = time.Duration(settings.HookTimeoutMs) * time.Millisecond
+	}
+
+	h := &wasmHooks{
+		hooksRPCClient: &hooksRPCClient{log: logger},
+		pluginID:       pluginID,
+		settings:       settings,
+		apiImpl:        apiImpl,
+		logger:         logger,
+		hookTimeout:    hookTimeout,
+	}
+	h.cond = sync.NewCond(&h.lock)
+
+	ctx, cancel := context.WithTimeout(context.Background(), wasmStartTimeout)
+	defer cancel()
+
+	h.runtime = wazero.NewRuntimeWithConfig(ctx, wazero.NewRuntimeConfig().
+		WithMemoryLimitPages(uint32(memoryLimitMB*1024*1024/wasmPageSize)).
+		WithCloseOnContextDone(true))
+
+	success := false
+	defer func() {
+		if !success {
+			h.runtime.Close(context.Background())
+		}
+	}()
+
+	if _, err = wasi_snapshot_preview1.Instantiate(ctx, h.runtime); err != nil {
+		return nil, errors.Wrap(err, "failed to instantiate WASI")
+	}
+
+	_, err = h.runtime.NewHostModuleBuilder(wasmHostModuleName).
+		NewFunctionBuilder().WithFunc(h.hostLog).Export("log").
+		NewFunctionBuilder().WithFunc(h.hostAPICall).Export("api_call").
+		Instantiate(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to instantiate host module")
+	}
+
+	compiled, err := h.runtime.CompileModule(ctx, code)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to compile wasm module")
+	}
+
+	if len(compiled.ExportedMemories()) == 0 {
+		return nil, errors.New("wasm module does not export its memory")
+	}
+	exports := compiled.ExportedFunctions()
+	if _, ok := exports[wasmAllocFunctionName]; !ok {
+		return nil, fmt.Errorf("wasm module does not export %s", wasmAllocFunctionName)
+	}
+	for hookName, hookID := range wasmSupportedHooks {
+		definition, ok := exports[hookName]
+		if !ok {
+			continue
+		}
+		if !isWasmHookSignature(definition) {
+			return nil, fmt.Errorf("wasm module exports %s with an invalid signature", hookName)
+		}
+		h.implemented[hookID] = true
+	}
+
+	moduleConfig := wazero.NewModuleConfig().
+		WithName(pluginID).
+		WithStartFunctions("_initialize").
+		WithStdout(logger.With(mlog.String("source", "plugin_stdout")).StdLogWriter()).
+		WithStderr(logger.With(mlog.String("source", "plugin_stderr")).StdLogWriter()).
+		WithSysWalltime().
+		WithSysNanotime()
+	h.module, err = h.runtime.InstantiateModule(ctx, compiled, moduleConfig)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to instantiate wasm module")
+	}
+
+	success = true
+	return h, nil
+}
+
+func isWasmHookSignature(definition wasmapi.FunctionDefinition) bool {
+	params := definition.ParamTypes()
+	results := definition.ResultTypes()
+	return len(params) == 2 && params[0] == wasmapi.ValueTypeI32 && params[1] == wasmapi.ValueTypeI32 &&
+		len(results) == 1 && results[0] == wasmapi.ValueTypeI64
+}
+
+// Close terminates the module and releases its resources.
+func (h *wasmHooks) Close() {
+	if err := h.runtime.Close(context.Background()); err != nil {
+		h.logger.Warn("Failed to close wasm runtime", mlog.Err(err))
+	}
+}
+
+// Ping returns an error if the module was terminated.
+func (h *wasmHooks) Ping() error {
+	if h.module.IsClosed() {
+		return errors.New("wasm module was terminated")
+	}
+	return nil
+}
+
+// resourceUsage returns the size of the module's memory and the time spent running it.
+func (h *wasmHooks) resourceUsage() (int64, time.Duration) {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+
+	return h.memorySize, h.busyTime
+}
+
+// start waits for the module to stop running and marks it running.
+func (h *wasmHooks) start() {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+
+	for h.running {
+		h.cond.Wait()
+	}
+	h.depth++
+	h.setRunning(true)
+}
+
+// finish marks the module done with the innermost call.
+func (h *wasmHooks) finish() {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+
+	h.depth--
+	h.setRunning(false)
+}
+
+// suspend marks the module as waiting for an API call, returning the depth of the call to resume.
+func (h *wasmHooks) suspend() int {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+
+	h.setRunning(false)
+	return h.depth
+}
+
+// resume waits for the calls made since the suspended call to return, and marks the module running.
+func (h *wasmHooks) resume(depth int) {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+
+	for h.running || h.depth != depth {
+		h.cond.Wait()
+	}
+	h.setRunning(true)
+}
+
+func (h *wasmHooks) setRunning(running bool) {
+	h.running = running
+	if running {
+		h.runningSince = time.Now()
+		return
+	}
+
+	h.busyTime += time.Since(h.runningSince)
+	if memory := h.module.Memory(); memory != nil && !h.module.IsClosed() {
+		h.memorySize = int64(memory.Size())
+	}
+	h.cond.Broadcast()
+}
+
+// call invokes the exported function implementing the given hook, decoding its return values into results.
+func (h *wasmHooks) call(hookName string, results []any, args ...any) error {
+	h.start()
+	defer h.finish()
+
+	if h.module.IsClosed() {
+		return fmt.Errorf("failed to call %s: wasm module was terminated", hookName)
+	}
+
+	input, err := json.Marshal(args)
+	if err != nil {
+		return errors.Wrapf(err, "failed to encode arguments of %s", hookName)
+	}
+
+	ctx, cancel := withWasmCallDeadline(h.hookTimeout)
+	defer cancel()
+
+	inputPtr, err := writeWasmBuffer(ctx, h.module, input)
+	if err != nil {
+		return errors.Wrapf(err, "failed to call %s", hookName)
+	}
+	defer freeWasmBuffer(ctx, h.module, inputPtr, uint32(len(input)))
+
+	packed, err := h.module.ExportedFunction(hookName).Call(ctx, uint64(inputPtr), uint64(len(input)))
+	if err != nil {
+		return errors.Wrapf(err, "failed to call %s", hookName)
+	}
+
+	output, err := readWasmBuffer(ctx, h.module, packed[0])
+	if err != nil {
+		return errors.Wrapf(err, "failed to read the results of %s", hookName)
+	}
+	if len(output) == 0 || len(results) == 0 {
+		return nil
+	}
+
+	var values []json.RawMessage
+	if err := json.Unmarshal(output, &values); err != nil {
+		return errors.Wrapf(err, "failed to decode the results of %s", hookName)
+	}
+	for i := 0; i < len(values) && i < len(results); i++ {
+		if err := json.Unmarshal(values[i], results[i]); err != nil {
+			return errors.Wrapf(err, "failed to decode the results of %s", hookName)
+		}
+	}
+
+	return nil
+}
+
+func writeWasmBuffer(ctx context.Context, module wasmapi.Module, data []byte) (uint32, error) {
+	if len(data) == 0 {
+		return 0, nil
+	}
+
+	ret, err := module.ExportedFunction(wasmAllocFunctionName).Call(ctx, uint64(len(data)))
+	if err != nil {
+		return 0, errors.Wrap(err, "failed to allocate wasm memory")
+	}
+
+	memory := module.Memory()
+	if memory == nil {
+		return 0, errors.New("wasm module has no memory")
+	}
+	ptr := uint32(ret[0])
+	if !memory.Write(ptr, data) {
+		return 0, fmt.Errorf("wasm memory allocation out of range: %d+%d", ptr, len(data))
+	}
+
+	return ptr, nil
+}
+
+// readWasmBuffer copies the buffer referenced by a packed ptr<<32|size value out of the module's memory.
+func readWasmBuffer(ctx context.Context, module wasmapi.Module, packed uint64) ([]byte, error) {
+	ptr, size := uint32(packed>>32), uint32(packed)
+	if size == 0 {
+		return nil, nil
+	}
+
+	memory := module.Memory()
+	if memory == nil {
+		return nil, errors.New("wasm module has no memory")
+	}
+	data, ok := memory.Read(ptr, size)
+	if !ok {
+		return nil, fmt.Errorf("wasm memory read out of range: %d+%d", ptr, size)
+	}
+	data = append([]byte(nil), data...)
+	freeWasmBuffer(ctx, module, ptr, size)
+
+	return data, nil
+}
+
+func freeWasmBuffer(ctx context.Context, module wasmapi.Module, ptr, size uint32) {
+	free := module.ExportedFunction(wasmFreeFunctionName)
+	if free == nil || size == 0 {
+		return
+	}
+	// Failures surface on the next call into the module, there is nothing else to do here.
+	_, _ = free.Call(ctx, uint64(ptr), uint64(size))
+}
+
+func (h *wasmHooks) hostLog(_ context.Context, module wasmapi.Module, level, ptr, size uint32) {
+	memory := module.Memory()
+	if memory == nil {
+		h.logger.Warn("Failed to read log message of wasm module without memory")
+		return
+	}
+	message, ok := memory.Read(ptr, size)
+	if !ok {
+		return
+	}
+
+	switch level {
+	case wasmLogLevelDebug:
+		h.logger.Debug(string(message))
+	case wasmLogLevelInfo:
+		h.logger.Info(string(message))
+	case wasmLogLevelWarn:
+		h.logger.Warn(string(message))
+	default:
+		h.logger.Error(string(message))
+	}
+}
+
+type wasmAPICallResponse struct {
+	Results []any  `json:"results,omitempty"`
+	Error   string `json:"error,omitempty"`
+}
+
+func (h *wasmHooks) hostAPICall(ctx context.Context, module wasmapi.Module, namePtr, nameSize, argsPtr, argsSize uint32) uint64 {
+	response := h.apiCall(ctx, module, namePtr, nameSize, argsPtr, argsSize)
+
+	output, err := json.Marshal(response)
+	if err != nil {
+		output, _ = json.Marshal(wasmAPICallResponse{Error: err.Error()})
+	}
+
+	ptr, err := writeWasmBuffer(ctx, module, output)
+	if err != nil {
+		h.logger.Warn("Failed to write API call response to wasm module", mlog.Err(err))
+		return 0
+	}
+
+	return uint64(ptr)<<32 | uint64(len(output))
+}
+
+func (h *wasmHooks) apiCall(ctx context.Context, module wasmapi.Module, namePtr, nameSize, argsPtr, argsSize uint32) wasmAPICallResponse {
+	memory := module.Memory()
+	if memory == nil {
+		return wasmAPICallResponse{Error: "wasm module has no memory"}
+	}
+
+	name, ok := memory.Read(namePtr, nameSize)
+	if !ok {
+		return wasmAPICallResponse{Error: "method name out of range"}
+	}
+
+	method, ok := wasmAPIMethods[string(name)]
+	if !ok {
+		return wasmAPICallResponse{Error: fmt.Sprintf("unknown API method %s", name)}
+	}
+	if !h.settings.HasCapability(method.capability) {
+		return wasmAPICallResponse{Error: fmt.Sprintf("%s requires the %s capability", name, method.capability)}
+	}
+
+	var args []json.RawMessage
+	if argsSize > 0 {
+		input, ok := memory.Read(argsPtr, argsSize)
+		if !ok {
+			return wasmAPICallResponse{Error: "arguments out of range"}
+		}
+		if err := json.Unmarshal(input, &args); err != nil {
+			return wasmAPICallResponse{Error: fmt.Sprintf("failed to decode arguments: %v", err)}
+		}
+	}
+
+	// The call doesn't count towards the hook timeout, nor does waiting for the module afterwards.
+	deadline := wasmCallDeadlineFromContext(ctx)
+	deadline.pause()
+	depth := h.suspend()
+	results, err := method.call(h.apiImpl, args)
+	h.resume(depth)
+	deadline.start()
+	if err != nil {
+		return wasmAPICallResponse{Error: fmt.Sprintf("%s: %v", name, err)}
+	}
+
+	return wasmAPICallResponse{Results: results}
+}
+
+type wasmCallDeadlineKey struct{}
+
+// wasmCallDeadline cancels the context of a call into the module once it has run for the hook
+// timeout. Unlike a context deadline, it can be paused while the module waits for an API call.
+type wasmCallDeadline struct {
+	lock      sync.Mutex
+	cancel    context.CancelFunc
+	timer     *time.Timer
+	remaining time.Duration
+	startedAt time.Time
+}
+
+// withWasmCallDeadline returns a context canceled once the call has run for timeout.
+func withWasmCallDeadline(timeout time.Duration) (context.Context, context.CancelFunc) {
+	ctx, cancel := context.WithCancel(context.Background())
+	deadline := &wasmCallDeadline{cancel: cancel, remaining: timeout}
+	deadline.start()
+
+	return context.WithValue(ctx, wasmCallDeadlineKey{}, deadline), func() {
+		deadline.pause()
+		cancel()
+	}
+}
+
+// wasmCallDeadlineFromContext returns the deadline of the call, or nil outside of a hook call.
+func wasmCallDeadlineFromContext(ctx context.Context) *wasmCallDeadline {
+	deadline, _ := ctx.Value(wasmCallDeadlineKey{}).(*wasmCallDeadline)
+	return deadline
+}
+
+func (d *wasmCallDeadline) start() {
+	if d == nil {
+		return
+	}
+	d.lock.Lock()
+	defer d.lock.Unlock()
+
+	d.startedAt = time.Now()
+	d.timer = time.AfterFunc(d.remaining, d.cancel)
+}
+
+func (d *wasmCallDeadline) pause() {
+	if d == nil {
+		return
+	}
+	d.lock.Lock()
+	defer d.lock.Unlock()
+
+	if d.timer == nil {
+		return
+	}
+	// A timer that already fired has canceled the call, which keeps no time left.
+	if d.timer.Stop() {
+		d.remaining -= time.Since(d.startedAt)
+	} else {
+		d.remaining = 0
+	}
+	d.timer = nil
+}
+
+func (h *wasmHooks) logHookError(hookName string, err error) {
+	h.logger.Error("Wasm call to plugin hook failed.", mlog.String("hook", hookName), mlog.Err(err))
+}
+
+func (h *wasmHooks) Implemented() ([]string, error) {
+	var impl []string
+	for hookName, hookID := range wasmSupportedHooks {
+		if h.implemented[hookID] {
+			impl = append(impl, hookName)
+		}
+	}
+	sort.Strings(impl)
+	return impl, nil
+}
+
+func (h *wasmHooks) OnActivate() error {
+	if !h.implemented[OnActivateID] {
+		return nil
+	}
+	var appErr *model.AppError
+	if err := h.call("OnActivate", []any{&appErr}); err != nil {
+		return err
+	}
+	if appErr != nil {
+		return appErr
+	}
+	return nil
+}
+
+func (h *wasmHooks) OnDeactivate() error {
+	if !h.implemented[OnDeactivateID] {
+		return nil
+	}
+	var appErr *model.AppError
+	if err := h.call("OnDeactivate", []any{&appErr}); err != nil {
+		return err
+	}
+	if appErr != nil {
+		return appErr
+	}
+	return nil
+}
+
+func (h *wasmHooks) OnConfigurationChange() error {
+	if !h.implemented[OnConfigurationChangeID] {
+		return nil
+	}
+	var appErr *model.AppError
+	if err := h.call("OnConfigurationChange", []any{&appErr}); err != nil {
+		return err
+	}
+	if appErr != nil {
+		return appErr
+	}
+	return nil
+}
+
+func (h *wasmHooks) ExecuteCommand(c *Context, args *model.CommandArgs) (*model.CommandResponse, *model.AppError) {
+	if !h.implemented[ExecuteCommandID] {
+		return h.hooksRPCClient.ExecuteCommand(c, args)
+	}
+	var response *model.CommandResponse
+	var appErr *model.AppError
+	if err := h.call("ExecuteCommand", []any{&response, &appErr}, c, args); err != nil {
+		h.logHookError("ExecuteCommand", err)
+	}
+	return response, appErr
+}
+
+func (h *wasmHooks) UserHasBeenCreated(c *Context, user *model.User) {
+	if !h.implemented[UserHasBeenCreatedID] {
+		return
+	}
+	if err := h.call("UserHasBeenCreated", nil, c, user); err != nil {
+		h.logHookError("UserHasBeenCreated", err)
+	}
+}
+
+func (h *wasmHooks) MessageWillBePosted(c *Context, post *model.Post) (*model.Post, string) {
+	if !h.implemented[MessageWillBePostedID] {
+		return post, ""
+	}
+	var replacementPost *model.Post
+	var rejectionReason string
+	if err := h.call("MessageWillBePosted", []any{&replacementPost, &rejectionReason}, c, post); err != nil {
+		h.logHookError("MessageWillBePosted", err)
+		return post, ""
+	}
+	return replacementPost, rejectionReason
+}
+
+func (h *wasmHooks) MessageWillBeUpdated(c *Context, newPost, oldPost *model.Post) (*model.Post, string) {
+	if !h.implemented[MessageWillBeUpdatedID] {
+		return newPost, ""
+	}
+	var replacementPost *model.Post
+	var rejectionReason string
+	if err := h.call("MessageWillBeUpdated", []any{&replacementPost, &rejectionReason}, c, newPost, oldPost); err != nil {
+		h.logHookError("MessageWillBeUpdated", err)
+		return newPost, ""
+	}
+	return replacementPost, rejectionReason
+}
+
+func (h *wasmHooks) MessageHasBeenPosted(c *Context, post *model.Post) {
+	if !h.implemented[MessageHasBeenPostedID] {
+		return
+	}
+	if err := h.call("MessageHasBeenPosted", nil, c, post); err != nil {
+		h.logHookError("MessageHasBeenPosted", err)
+	}
+}
+
+func (h *wasmHooks) MessageHasBeenUpdated(c *Context, newPost, oldPost *model.Post) {
+	if !h.implemented[MessageHasBeenUpdatedID] {
+		return
+	}
+	if err := h.call("MessageHasBeenUpdated", nil, c, newPost, oldPost); err != nil {
+		h.logHookError("MessageHasBeenUpdated", err)
+	}
+}
+
+func (h *wasmHooks) MessageHasBeenDeleted(c *Context, post *model.Post) {
+	if !h.implemented[MessageHasBeenDeletedID] {
+		return
+	}
+	if err := h.call("MessageHasBeenDeleted", nil, c, post); err != nil {
+		h.logHookError("MessageHasBeenDeleted", err)
+	}
+}
+
+func (h *wasmHooks) ChannelHasBeenCreated(c *Context, channel *model.Channel) {
+	if !h.implemented[ChannelHasBeenCreatedID] {
+		return
+	}
+	if err := h.call("ChannelHasBeenCreated", nil, c, channel); err != nil {
+		h.logHookError("ChannelHasBeenCreated", err)
+	}
+}
+
+func (h *wasmHooks) UserHasJoinedChannel(c *Context, channelMember *model.ChannelMember, actor *model.User) {
+	if !h.implemented[UserHasJoinedChannelID] {
+		return
+	}
+	if err := h.call("UserHasJoinedChannel", nil, c, channelMember, actor); err != nil {
+		h.logHookError("UserHasJoinedChannel", err)
+	}
+}
+
+func (h *wasmHooks) UserHasJoinedTeam(c *Context, teamMember *model.TeamMember, actor *model.User) {
+	if !h.implemented[UserHasJoinedTeamID] {
+		return
+	}
+	if err := h.call("UserHasJoinedTeam", nil, c, teamMember, actor); err != nil {
+		h.logHookError("UserHasJoinedTeam", err)
+	}
+}
@@ -0,0 +1,400 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package plugin
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/public/plugin/utils"
+	"github.com/mattermost/mattermost/server/public/shared/mlog"
+)
+
+// wasmTestPluginABI implements the guest side of the WebAssembly plugin ABI for the test plugins.
+const wasmTestPluginABI = `
+var buffers = map[uint32][]byte{}
+
+//go:wasmexport mm_alloc
+func mmAlloc(size uint32) uint32 {
+	buf := make([]byte, size)
+	ptr := uint32(uintptr(unsafe.Pointer(unsafe.SliceData(buf))))
+	buffers[ptr] = buf
+	return ptr
+}
+
+//go:wasmexport mm_free
+func mmFree(ptr, _ uint32) {
+	delete(buffers, ptr)
+}
+
+//go:wasmimport mattermost log
+func hostLog(level, ptr, size uint32)
+
+//go:wasmimport mattermost api_call
+func hostAPICall(namePtr, nameSize, argsPtr, argsSize uint32) uint64
+
+func bytesAt(ptr, size uint32) []byte {
+	return unsafe.Slice((*byte)(unsafe.Pointer(uintptr(ptr))), size)
+}
+
+func bytesOf(data []byte) (uint32, uint32) {
+	if len(data) == 0 {
+		return 0, 0
+	}
+	return uint32(uintptr(unsafe.Pointer(unsafe.SliceData(data)))), uint32(len(data))
+}
+
+func args(ptr, size uint32) []json.RawMessage {
+	var values []json.RawMessage
+	json.Unmarshal(bytesAt(ptr, size), &values)
+	return values
+}
+
+func results(values ...any) uint64 {
+	data, _ := json.Marshal(values)
+	ptr := mmAlloc(uint32(len(data)))
+	copy(bytesAt(ptr, uint32(len(data))), data)
+	return uint64(ptr)<<32 | uint64(len(data))
+}
+
+func logInfo(message string) {
+	data := []byte(message)
+	ptr, size := bytesOf(data)
+	hostLog(1, ptr, size)
+}
+
+func apiCall(name string, values ...any) (json.RawMessage, string) {
+	nameData := []byte(name)
+	argsData, _ := json.Marshal(values)
+	namePtr, nameSize := bytesOf(nameData)
+	argsPtr, argsSize := bytesOf(argsData)
+	packed := hostAPICall(namePtr, nameSize, argsPtr, argsSize)
+
+	var response struct {
+		Results json.RawMessage
+		Error   string
+	}
+	json.Unmarshal(bytesAt(uint32(packed>>32), uint32(packed)), &response)
+	mmFree(uint32(packed>>32), uint32(packed))
+	return response.Results, response.Error
+}
+
+func main() {}
+`
+
+func compileWasmTestPlugin(t *testing.T, pluginID, source, wasmSettings string) *model.BundleInfo {
+	t.Helper()
+
+	dir := t.TempDir()
+	utils.CompileGoWasm(t, source+wasmTestPluginABI, filepath.Join(dir, "plugin.wasm"))
+
+	manifest := `{"id": "` + pluginID + `", "server": {"executable": "plugin.wasm", "wasm": ` + wasmSettings + `}}`
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "plugin.json"), []byte(manifest), 0600))
+
+	bundle := model.BundleInfoForPath(dir)
+	require.NoError(t, bundle.ManifestError)
+	return bundle
+}
+
+type wasmTestAPI struct {
+	API
+	kv    map[string][]byte
+	delay time.Duration
+}
+
+func (api *wasmTestAPI) KVSet(key string, value []byte) *model.AppError {
+	time.Sleep(api.delay)
+	api.kv[key] = value
+	return nil
+}
+
+func (api *wasmTestAPI) KVGet(key string) ([]byte, *model.AppError) {
+	return api.kv[key], nil
+}
+
+// wasmTestPostAPI runs the MessageWillBePosted hook of the plugin on the posts it creates, as the
+// server does.
+type wasmTestPostAPI struct {
+	API
+	sup   *supervisor
+	posts []*model.Post
+}
+
+func (api *wasmTestPostAPI) CreatePost(post *model.Post) (*model.Post, *model.AppError) {
+	// Resource usage is reported while the module waits for API calls.
+	api.sup.wasmHooks.resourceUsage()
+
+	post, rejectionReason := api.sup.Hooks().MessageWillBePosted(&Context{}, post)
+	if post == nil {
+		return nil, model.NewAppError("CreatePost", "rejected", nil, rejectionReason, http.StatusBadRequest)
+	}
+	api.posts = append(api.posts, post)
+	return post, nil
+}
+
+func TestWasmSupervisor(t *testing.T) {
+	if testing.Short() {
+		t.Skip("compiling wasm plugins is slow")
+	}
+
+	t.Run("hooks", func(t *testing.T) {
+		bundle := compileWasmTestPlugin(t, "wasmhooks", `
+package main
+
+import (
+	"encoding/json"
+	"strings"
+	"unsafe"
+)
+
+//go:wasmexport MessageWillBePosted
+func messageWillBePosted(ptr, size uint32) uint64 {
+	var post map[string]any
+	json.Unmarshal(args(ptr, size)[1], &post)
+
+	message := post["message"].(string)
+	if strings.Contains(message, "reject") {
+		return results(nil, "rejected by wasm")
+	}
+
+	post["message"] = strings.ToUpper(message)
+	logInfo("changed a post")
+	return results(post, "")
+}
+`, `{}`)
+
+		sup, err := newSupervisor(bundle, nil, nil, mlog.CreateConsoleTestLogger(t), nil, WithExecutableFromManifest(bundle))
+		require.NoError(t, err)
+		defer sup.Shutdown()
+		require.NotNil(t, sup.wasmHooks)
+
+		assert.True(t, sup.Implements(MessageWillBePostedID))
+		assert.False(t, sup.Implements(MessageHasBeenPostedID))
+		assert.NoError(t, sup.Hooks().OnActivate())
+		assert.NoError(t, sup.PerformHealthCheck())
+
+		post, rejectionReason := sup.Hooks().MessageWillBePosted(&Context{}, &model.Post{Id: "postid", Message: "hello"})
+		assert.Empty(t, rejectionReason)
+		require.NotNil(t, post)
+		assert.Equal(t, "postid", post.Id)
+		assert.Equal(t, "HELLO", post.Message)
+
+		post, rejectionReason = sup.Hooks().MessageWillBePosted(&Context{}, &model.Post{Message: "please reject"})
+		assert.Nil(t, post)
+		assert.Equal(t, "rejected by wasm", rejectionReason)
+
+		// Hooks not exported by the module behave as if the plugin didn't implement them.
+		sup.Hooks().MessageHasBeenPosted(&Context{}, &model.Post{})
+		w := httptest.NewRecorder()
+		sup.Hooks().ServeHTTP(&Context{}, w, httptest.NewRequest(http.MethodGet, "/", nil))
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+
+	t.Run("api calls are limited to the declared capabilities", func(t *testing.T) {
+		bundle := compileWasmTestPlugin(t, "wasmapi", `
+package main
+
+import (
+	"encoding/json"
+	"unsafe"
+)
+
+//go:wasmexport OnActivate
+func onActivate(ptr, size uint32) uint64 {
+	apiCall("KVSet", "key", []byte("value"))
+
+	_, err := apiCall("GetUser", "userid")
+	apiCall("KVSet", "denied", []byte(err))
+
+	return 0
+}
+`, `{"capabilities": ["kv"]}`)
+
+		api := &wasmTestAPI{kv: map[string][]byte{}}
+		sup, err := newSupervisor(bundle, api, nil, mlog.CreateConsoleTestLogger(t), nil, WithExecutableFromManifest(bundle))
+		require.NoError(t, err)
+		defer sup.Shutdown()
+
+		require.NoError(t, sup.Hooks().OnActivate())
+		assert.Equal(t, []byte("value"), api.kv["key"])
+		assert.Equal(t, "GetUser requires the users:read capability", string(api.kv["denied"]))
+	})
+
+	t.Run("api calls can run hooks of the plugin", func(t *testing.T) {
+		bundle := compileWasmTestPlugin(t, "wasmreentrant", `
+package main
+
+import (
+	"encoding/json"
+	"strings"
+	"unsafe"
+)
+
+//go:wasmexport MessageWillBePosted
+func messageWillBePosted(ptr, size uint32) uint64 {
+	var post map[string]any
+	json.Unmarshal(args(ptr, size)[1], &post)
+
+	message := post["message"].(string)
+	if message == "ping" {
+		if _, err := apiCall("CreatePost", map[string]any{"message": "pong"}); err != "" {
+			return results(nil, err)
+		}
+	}
+
+	post["message"] = strings.ToUpper(message)
+	return results(post, "")
+}
+`, `{"capabilities": ["posts:write"]}`)
+
+		api := &wasmTestPostAPI{}
+		sup, err := newSupervisor(bundle, api, nil, mlog.CreateConsoleTestLogger(t), nil, WithExecutableFromManifest(bundle))
+		require.NoError(t, err)
+		defer sup.Shutdown()
+		api.sup = sup
+
+		post, rejectionReason := sup.Hooks().MessageWillBePosted(&Context{}, &model.Post{Message: "ping"})
+		assert.Empty(t, rejectionReason)
+		require.NotNil(t, post)
+		assert.Equal(t, "PING", post.Message)
+
+		require.Len(t, api.posts, 1)
+		assert.Equal(t, "PONG", api.posts[0].Message)
+	})
+
+	t.Run("hook timeout terminates the module", func(t *testing.T) {
+		bundle := compileWasmTestPlugin(t, "wasmtimeout", `
+package main
+
+import (
+	"encoding/json"
+	"unsafe"
+)
+
+var counter int
+
+//go:wasmexport OnConfigurationChange
+func onConfigurationChange(ptr, size uint32) uint64 {
+	for {
+		counter++
+	}
+}
+`, `{"hook_timeout_ms": 200}`)
+
+		sup, err := newSupervisor(bundle, nil, nil, mlog.CreateConsoleTestLogger(t), nil, WithExecutableFromManifest(bundle))
+		require.NoError(t, err)
+		defer sup.Shutdown()
+
+		assert.Error(t, sup.Hooks().OnConfigurationChange())
+		assert.Error(t, sup.PerformHealthCheck())
+	})
+
+	t.Run("api calls don't count towards the hook timeout", func(t *testing.T) {
+		bundle := compileWasmTestPlugin(t, "wasmslowapi", `
+package main
+
+import (
+	"encoding/json"
+	"unsafe"
+)
+
+//go:wasmexport OnActivate
+func onActivate(ptr, size uint32) uint64 {
+	apiCall("KVSet", "key", []byte("value"))
+	return 0
+}
+`, `{"capabilities": ["kv"], "hook_timeout_ms": 200}`)
+
+		api := &wasmTestAPI{kv: map[string][]byte{}, delay: 500 * time.Millisecond}
+		sup, err := newSupervisor(bundle, api, nil, mlog.CreateConsoleTestLogger(t), nil, WithExecutableFromManifest(bundle))
+		require.NoError(t, err)
+		defer sup.Shutdown()
+
+		require.NoError(t, sup.Hooks().OnActivate())
+		assert.Equal(t, []byte("value"), api.kv["key"])
+		assert.NoError(t, sup.PerformHealthCheck())
+	})
+
+	t.Run("memory limit", func(t *testing.T) {
+		bundle := compileWasmTestPlugin(t, "wasmmemory", `
+package main
+
+import (
+	"encoding/json"
+	"unsafe"
+)
+
+var retained [][]byte
+
+//go:wasmexport OnConfigurationChange
+func onConfigurationChange(ptr, size uint32) uint64 {
+	for i := 0; i < 64; i++ {
+		retained = append(retained, make([]byte, 1024*1024))
+	}
+	return 0
+}
+`, `{"memory_limit_mb": 32}`)
+
+		sup, err := newSupervisor(bundle, nil, nil, mlog.CreateConsoleTestLogger(t), nil, WithExecutableFromManifest(bundle))
+		require.NoError(t, err)
+		defer sup.Shutdown()
+
+		assert.Error(t, sup.Hooks().OnConfigurationChange())
+	})
+}
+
+func TestWasmModuleWithoutMemory(t *testing.T) {
+	// A module exporting "mm_alloc(size i32) i32", returning 0, and no memory.
+	code := []byte{
+		0x00, 0x61, 0x73, 0x6d, 0x01, 0x00, 0x00, 0x00,
+		0x01, 0x06, 0x01, 0x60, 0x01, 0x7f, 0x01, 0x7f,
+		0x03, 0x02, 0x01, 0x00,
+		0x07, 0x0c, 0x01, 0x08, 'm', 'm', '_', 'a', 'l', 'l', 'o', 'c', 0x00, 0x00,
+		0x0a, 0x06, 0x01, 0x04, 0x00, 0x41, 0x00, 0x0b,
+	}
+	executable := filepath.Join(t.TempDir(), "plugin.wasm")
+	require.NoError(t, os.WriteFile(executable, code, 0600))
+
+	_, err := newWasmHooks("wasmnomemory", executable, nil, nil, mlog.CreateConsoleTestLogger(t))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "memory")
+}
+
+func TestWasmCallDeadline(t *testing.T) {
+	ctx, cancel := withWasmCallDeadline(100 * time.Millisecond)
+	defer cancel()
+
+	deadline := wasmCallDeadlineFromContext(ctx)
+	require.NotNil(t, deadline)
+
+	deadline.pause()
+	time.Sleep(200 * time.Millisecond)
+	assert.NoError(t, ctx.Err())
+
+	deadline.start()
+	select {
+	case <-ctx.Done():
+	case <-time.After(5 * time.Second):
+		require.Fail(t, "the call wasn't canceled")
+	}
+
+	// Outside of hook calls, pausing does nothing.
+	wasmCallDeadlineFromContext(context.Background()).pause()
+}
+
+func TestWasmAPICallResponse(t *testing.T) {
+	data, err := json.Marshal(wasmAPICallResponse{Results: []any{nil, "value"}})
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"results": [null, "value"]}`, string(data))
+}
@@ -494,6 +494,12 @@ export type ExperimentalAuditSettings = {
     FileMaxQueueSize: number;
     AdvancedLoggingJSON: Record<string, any>;
     Certificate: string;
+    HashChainEnabled: boolean;
+    HashChainFileName: string;
+    HashChainSigningKeyFile: string;
+    HashChainCheckpointInterval: number;
+    DatabaseEnabled: boolean;
+    DatabaseRetentionDays: number;
 };
 
 export type NotificationLogSettings = {
@@ -820,6 +826,13 @@ export type MetricsSettings = {
     ClientSideUserIds: string[];
 };
 
+export type TracingSettings = {
+    Enable: boolean;
+    OTLPEndpoint: string;
+    Insecure: boolean;
+    SampleRate: number;
+};
+
 export type ExperimentalSettings = {
     ClientSideCertEnable: boolean;
     ClientSideCertCheck: string;
@@ -944,8 +957,20 @@ export type PluginSettings = {
     AutomaticPrepackagedPlugins: boolean;
     RequirePluginSignature: boolean;
     MarketplaceURL: string;
+    MarketplaceCatalogPath: string;
     SignaturePublicKeyFiles: string[];
+    RevokedSignatureKeyIds: string[];
     ChimeraOAuthProxyURL: string;
+    ResourceLimits: Record<string, PluginResourceLimits>;
+};
+
+export type PluginResourceLimits = {
+    MaxMemoryMB: number;
+    MaxCPUPercent: number;
+    MaxGoroutines: number;
+    MaxAPICallsPerSecond: number;
+    MaxHookLatencyMs: number;
+    Action: 'restart' | 'disable' | '';
 };
 
 export type DisplaySettings = {
@@ -985,6 +1010,8 @@ export type ImportSettings = {
 export type ExportSettings = {
     Directory: string;
     RetentionDays: number;
+    EnableUserDataExport: boolean;
+    UserDataExportIntervalHours: number;
 };
 
 export type AccessControlSettings = {
@@ -1049,6 +1076,7 @@ export type AdminConfig = {
     NativeAppSettings: NativeAppSettings;
     ClusterSettings: ClusterSettings;
     MetricsSettings: MetricsSettings;
+    TracingSettings: TracingSettings;
     ExperimentalSettings: ExperimentalSettings;
     AnalyticsSettings: AnalyticsSettings;
     CacheSettings: CacheSettings;
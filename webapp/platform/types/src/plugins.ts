@@ -17,6 +17,9 @@ export type PluginManifest = {
     icon_path?: string;
     version: string;
     min_server_version?: string;
+    dependencies?: PluginManifestDependency[];
+    required_capabilities?: string[];
+    required_permissions?: string[];
     translate?: boolean;
     server?: PluginManifestServer;
     backend?: PluginManifestServer;
@@ -25,6 +28,11 @@ export type PluginManifest = {
     props?: Record<string, any>;
 };
 
+export type PluginManifestDependency = {
+    id: string;
+    version?: string;
+};
+
 export type PluginRedux = PluginManifest & {active: boolean};
 
 export type PluginManifestServer = {
@@ -34,6 +42,7 @@ export type PluginManifestServer = {
         'windows-amd64'?: string;
     };
     executable: string;
+    migrations?: string;
 };
 
 export type PluginManifestWebapp = {
@@ -88,6 +97,36 @@ export type PluginStatus = {
     name: string;
     description: string;
     version: string;
+    unmet_requirements?: string[];
+    resource_usage?: PluginResourceUsage;
+    schema_version?: number;
+    signing_key_id?: string;
+};
+
+export type PluginSignature = {
+    key_id: string;
+    key_fingerprint?: string;
+    key_name?: string;
+    signed_at: number;
+};
+
+export type PluginSignatureReport = {
+    plugin_id: string;
+    version: string;
+    status: 'verified' | 'unsigned' | 'invalid' | 'expired' | 'revoked';
+    signature?: PluginSignature;
+    error?: string;
+};
+
+export type PluginResourceUsage = {
+    memory_rss_bytes: number;
+    cpu_time_ms: number;
+    busy_time_ms?: number;
+    cpu_percent: number;
+    goroutines: number;
+    api_calls_per_second: number;
+    hook_latency_ms: number;
+    update_at: number;
 };
 
 type PluginInstance = {